@@ -148,6 +148,7 @@ func InitializeState(
 		RegionInheritedConfig:     args.RegionInheritedConfig,
 		MongoSession:              session,
 		AdminPassword:             info.Password,
+		SecretsKey:                servingInfo.SecretsKey,
 		NewPolicy:                 newPolicy,
 	})
 	if err != nil {
@@ -97,6 +97,7 @@ func (*formatSuite) TestReadWriteStateConfig(c *gc.C) {
 		CAPrivateKey: "ca special key",
 		StatePort:    12345,
 		APIPort:      23456,
+		SecretsKey:   "secrets key",
 	}
 	params := agentParams
 	params.Paths.DataDir = c.MkDir()
@@ -5,6 +5,9 @@ package action
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
@@ -105,6 +108,8 @@ func (c *Client) EnqueueOperation(arg params.Actions) (params.EnqueuedActions, e
 	results := params.EnqueuedActions{}
 	if v := c.BestAPIVersion(); v < 6 {
 		return results, errors.Errorf("EnqueueOperation not supported by this version (%d) of Juju", v)
+	} else if arg.Rolling != nil && v < 8 {
+		return results, errors.Errorf("rolling operations not supported by this version (%d) of Juju", v)
 	}
 	err := c.facade.FacadeCall("EnqueueOperation", arg, &results)
 	return results, err
@@ -180,3 +185,24 @@ func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, e
 	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// DownloadAttachment returns a reader for the contents of the file with
+// the given name attached to the task. The caller is responsible for
+// closing the reader.
+func (c *Client) DownloadAttachment(taskID, name string) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("/actions/%s/attachments/%s", taskID, url.PathEscape(name))
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create download request")
+	}
+	// The returned httpClient sets the base url to /model/<uuid> if it can.
+	httpClient, err := c.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp *http.Response
+	if err := httpClient.Do(c.facade.RawAPICaller().Context(), req, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot download attachment %q", name)
+	}
+	return resp.Body, nil
+}
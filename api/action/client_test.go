@@ -369,3 +369,23 @@ func (s *actionSuite) TestEnqueueOperationNotSupported(c *gc.C) {
 	_, err := client.EnqueueOperation(params.Actions{})
 	c.Assert(err, gc.ErrorMatches, "EnqueueOperation not supported by this version \\(5\\) of Juju")
 }
+
+func (s *actionSuite) TestEnqueueRollingOperationNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.EnqueueOperation(params.Actions{
+		Rolling: &params.RollingStrategy{BatchSize: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, "rolling operations not supported by this version \\(7\\) of Juju")
+}
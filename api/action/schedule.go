@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddActionSchedule adds a schedule on which the controller enqueues an
+// action, returning the added schedule.
+func (c *Client) AddActionSchedule(arg params.AddActionScheduleArg) (params.ActionSchedule, error) {
+	if v := c.BestAPIVersion(); v < 7 {
+		return params.ActionSchedule{}, errors.Errorf("AddActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	args := params.AddActionSchedulesArgs{
+		Schedules: []params.AddActionScheduleArg{arg},
+	}
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("AddActionSchedules", args, &results); err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ActionSchedule{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ActionSchedule{}, result.Error
+	}
+	if result.Schedule == nil {
+		return params.ActionSchedule{}, errors.New("missing action schedule in result")
+	}
+	return *result.Schedule, nil
+}
+
+// ListActionSchedules returns all the action schedules in the model,
+// with the history of their recent runs.
+func (c *Client) ListActionSchedules() ([]params.ActionSchedule, error) {
+	if v := c.BestAPIVersion(); v < 7 {
+		return nil, errors.Errorf("ListActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	var result params.ActionSchedulesResult
+	if err := c.facade.FacadeCall("ListActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Schedules, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given ID.
+func (c *Client) RemoveActionSchedule(id string) error {
+	if v := c.BestAPIVersion(); v < 7 {
+		return errors.Errorf("RemoveActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	args := params.ActionScheduleIds{Ids: []string{id}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveActionSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return maybeNotFound(err)
+	}
+	return nil
+}
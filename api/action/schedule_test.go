@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestAddActionSchedule(c *gc.C) {
+	arg := params.AddActionScheduleArg{
+		Schedule:   "@daily",
+		Receivers:  []string{"mysql/leader"},
+		Name:       "backup",
+		Parameters: map[string]interface{}{"target": "s3"},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "AddActionSchedules")
+				c.Assert(a, jc.DeepEquals, params.AddActionSchedulesArgs{
+					Schedules: []params.AddActionScheduleArg{arg},
+				})
+				*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+					Results: []params.ActionScheduleResult{{
+						Schedule: &params.ActionSchedule{Id: "1", Name: "backup"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	schedule, err := client.AddActionSchedule(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule, jc.DeepEquals, params.ActionSchedule{Id: "1", Name: "backup"})
+}
+
+func (s *scheduleSuite) TestAddActionScheduleError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+					Results: []params.ActionScheduleResult{{
+						Error: &params.Error{Message: `unit "mysql/9" not found`},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.AddActionSchedule(params.AddActionScheduleArg{})
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/9" not found`)
+}
+
+func (s *scheduleSuite) TestAddActionScheduleNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.AddActionSchedule(params.AddActionScheduleArg{})
+	c.Assert(err, gc.ErrorMatches, `AddActionSchedules not supported by this version \(6\) of Juju`)
+}
+
+func (s *scheduleSuite) TestListActionSchedules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "ListActionSchedules")
+				c.Assert(a, gc.IsNil)
+				*(result.(*params.ActionSchedulesResult)) = params.ActionSchedulesResult{
+					Schedules: []params.ActionSchedule{{Id: "1"}, {Id: "2"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	schedules, err := client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []params.ActionSchedule{{Id: "1"}, {Id: "2"}})
+}
+
+func (s *scheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "RemoveActionSchedules")
+				c.Assert(a, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"3"}})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{
+						Error: &params.Error{Code: params.CodeNotFound, Message: `action schedule "3" not found`},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	err := client.RemoveActionSchedule("3")
+	c.Assert(err, gc.ErrorMatches, `action schedule "3" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// Client provides access to the ActionScheduler API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side ActionScheduler facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, actionSchedulerFacade)}
+}
+
+// WatchActionSchedules returns a NotifyWatcher that triggers whenever
+// an action schedule in the model is added, removed or run.
+func (c *Client) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (c *Client) ActionSchedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedulesResult
+	if err := c.facade.FacadeCall("ActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Schedules, nil
+}
+
+// RecordRuns enqueues the actions for the given scheduled runs, or
+// records them as missed.
+func (c *Client) RecordRuns(runs []params.ActionScheduleRunArg) error {
+	args := params.ActionScheduleRunArgs{Runs: runs}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RecordRuns", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestActionSchedules(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:    "ActionScheduler",
+		IdIsEmpty: true,
+		Method:    "ActionSchedules",
+		Results: params.ActionSchedulesResult{
+			Schedules: []params.ActionSchedule{{Id: "1", Schedule: "@daily"}},
+		},
+	})
+	client := actionscheduler.NewClient(caller)
+	schedules, err := client.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []params.ActionSchedule{{Id: "1", Schedule: "@daily"}})
+}
+
+func (s *clientSuite) TestActionSchedulesError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:  "ActionScheduler",
+		Method:  "ActionSchedules",
+		Results: params.ActionSchedulesResult{Error: &params.Error{Message: "boom"}},
+	})
+	client := actionscheduler.NewClient(caller)
+	_, err := client.ActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestRecordRuns(c *gc.C) {
+	runs := []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 1, 2, 0, 0, 0, time.UTC),
+	}, {
+		Id:        "2",
+		Scheduled: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		Missed:    4,
+	}}
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade: "ActionScheduler",
+		Method: "RecordRuns",
+		Args:   params.ActionScheduleRunArgs{Runs: runs},
+		Results: params.ErrorResults{Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "kaboom"}},
+		}},
+	})
+	client := actionscheduler.NewClient(caller)
+	err := client.RecordRuns(runs)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/mgo.v2"
@@ -17,6 +18,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	apiserveragent "github.com/juju/juju/apiserver/facades/agent/agent"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/juju/testing"
@@ -53,6 +55,9 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		SharedSecret: ssi.SharedSecret,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
+		// The secrets key comes from the controller's agent config,
+		// not the database.
+		SecretsKey: coretesting.SecretsKey,
 	}
 	err := s.State.SetStateServingInfo(ssi)
 	c.Assert(err, jc.ErrorIsNil)
@@ -103,6 +108,32 @@ func (s *servingInfoSuite) TestIsMasterPermission(c *gc.C) {
 	})
 }
 
+func (s *servingInfoSuite) TestMetricsConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AgentMetricsPort:     17072,
+		controller.AgentMetricsUsername: "prometheus",
+		controller.AgentMetricsPassword: "s3cret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	st, _ := s.OpenAPIAsNewMachine(c)
+	apiSt, err := apiagent.NewState(st)
+	c.Assert(err, jc.ErrorIsNil)
+	config, err := apiSt.MetricsConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(config.Port, gc.Equals, 17072)
+	c.Check(config.Username, gc.Equals, "prometheus")
+	c.Check(config.PasswordSalt, gc.Not(gc.Equals), "")
+	c.Check(config.PasswordHash, gc.Equals, utils.UserPasswordHash("s3cret", config.PasswordSalt))
+}
+
+func (s *servingInfoSuite) TestMetricsConfigWithoutPort(c *gc.C) {
+	st, _ := s.OpenAPIAsNewMachine(c)
+	apiSt, err := apiagent.NewState(st)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = apiSt.MetricsConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type machineSuite struct {
 	testing.JujuConnSuite
 	machine *state.Machine
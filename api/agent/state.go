@@ -78,6 +78,38 @@ func (st *State) IsMaster() (bool, error) {
 	return results.Master, err
 }
 
+// MetricsConfig holds the port and credentials the connected machine
+// agent serves agent metrics with. The password is only known by its
+// salted hash, as computed by utils.UserPasswordHash.
+type MetricsConfig struct {
+	Port         int
+	Username     string
+	PasswordHash string
+	PasswordSalt string
+}
+
+// MetricsConfig returns the port and credentials the connected machine
+// agent serves agent metrics with. It returns a NotFound error if the
+// controller has no agent-metrics-port configured.
+func (st *State) MetricsConfig() (MetricsConfig, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return MetricsConfig{}, errors.NotSupportedf("MetricsConfig")
+	}
+	var result params.MetricsConfigResult
+	if err := st.facade.FacadeCall("MetricsConfig", nil, &result); err != nil {
+		if params.IsCodeNotFound(err) {
+			return MetricsConfig{}, errors.NewNotFound(err, "")
+		}
+		return MetricsConfig{}, errors.Trace(err)
+	}
+	return MetricsConfig{
+		Port:         result.Port,
+		Username:     result.Username,
+		PasswordHash: result.PasswordHash,
+		PasswordSalt: result.PasswordSalt,
+	}, nil
+}
+
 type Entity struct {
 	st  *State
 	tag names.Tag
@@ -1559,3 +1559,68 @@ func (s *applicationSuite) TestApplicationsInfoResultMismatch(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestUnitsInfoPriorV12(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{
+		BestVersion:   11,
+		APICallerFunc: apiCaller,
+	})
+	_, err := client.UnitsInfo(nil)
+	c.Assert(err, gc.ErrorMatches, "UnitsInfo for Application facade v11 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "UnitsInfo")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{
+					{Tag: "unit-foo-0"},
+					{Tag: "unit-bar-1"},
+				}})
+
+			result, ok := response.(*params.UnitInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.UnitInfoResult{
+				{Error: &params.Error{Message: "boom"}},
+				{Result: &params.UnitResult{
+					Tag:         "unit-bar-1",
+					Application: "bar",
+					Life:        "alive",
+					HookHistory: []params.HookExecution{{Kind: "install", Name: "install"}},
+				}},
+			}
+			return nil
+		},
+	)
+
+	client := application.NewClient(basetesting.BestVersionCaller{
+		BestVersion:   12,
+		APICallerFunc: apiCaller,
+	})
+	results, err := client.UnitsInfo([]names.UnitTag{
+		names.NewUnitTag("foo/0"),
+		names.NewUnitTag("bar/1"),
+	})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.UnitInfoResult{
+		{Error: &params.Error{Message: "boom"}},
+		{Result: &params.UnitResult{
+			Tag:         "unit-bar-1",
+			Application: "bar",
+			Life:        "alive",
+			HookHistory: []params.HookExecution{{Kind: "install", Name: "install"}},
+		}},
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides a client for querying the audit log
+// recorded by the controllers.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+const auditLogFacade = "AuditLog"
+
+// Client provides access to the AuditLog facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, auditLogFacade)
+	return &Client{
+		ClientFacade: frontend,
+		facade:       backend,
+	}
+}
+
+// Query returns the audit log entries matching the query, in the
+// order the requests were made. If the query has no limit, the
+// controller applies a default one.
+func (c *Client) Query(query auditlog.Query) ([]params.AuditLogEntry, error) {
+	args := params.AuditLogQueryArgs{
+		Who:        query.Who,
+		ModelUUID:  query.ModelUUID,
+		Facade:     query.Facade,
+		Method:     query.Method,
+		ErrorsOnly: query.ErrorsOnly,
+		Limit:      query.Limit,
+	}
+	if !query.After.IsZero() {
+		args.After = &query.After
+	}
+	if !query.Before.IsZero() {
+		args.Before = &query.Before
+	}
+	var results params.AuditLogQueryResults
+	if err := c.facade.FacadeCall("Query", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Entries, nil
+}
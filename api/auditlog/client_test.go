@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestQuery(c *gc.C) {
+	after := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AuditLog")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Query")
+		c.Check(arg, jc.DeepEquals, params.AuditLogQueryArgs{
+			Who:        "bob",
+			Facade:     "Application",
+			After:      &after,
+			ErrorsOnly: true,
+			Limit:      10,
+		})
+		*result.(*params.AuditLogQueryResults) = params.AuditLogQueryResults{
+			Entries: []params.AuditLogEntry{{
+				ControllerID: "0",
+				Who:          "bob",
+				Facade:       "Application",
+				Method:       "Deploy",
+			}},
+		}
+		return nil
+	})
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Query(coreauditlog.Query{
+		Who:        "bob",
+		Facade:     "Application",
+		After:      after,
+		ErrorsOnly: true,
+		Limit:      10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.AuditLogEntry{{
+		ControllerID: "0",
+		Who:          "bob",
+		Facade:       "Application",
+		Method:       "Deploy",
+	}})
+}
+
+func (s *ClientSuite) TestQueryError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(coreauditlog.Query{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
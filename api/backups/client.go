@@ -18,6 +18,10 @@ type Client struct {
 	base.ClientFacade
 	facade base.FacadeCaller
 	client *httprequest.Client
+
+	// destination is the URL of the backup destination used
+	// by the client; empty means the controller's own storage.
+	destination string
 }
 
 // MakeClient is a direct constructor function for a backups client.
@@ -38,3 +42,19 @@ func NewClient(st base.APICallCloser) (*Client, error) {
 	}
 	return MakeClient(frontend, backend, client), nil
 }
+
+// NewClientForDestination returns a new backups API client which
+// creates and reads backups in the destination with the given URL,
+// rather than the controller's own storage.
+func NewClientForDestination(st base.APICallCloser, destination string) (*Client, error) {
+	client, err := NewClient(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if destination != "" && client.BestAPIVersion() < 3 {
+		client.Close()
+		return nil, errors.NotSupportedf("backup destinations on this controller")
+	}
+	client.destination = destination
+	return client, nil
+}
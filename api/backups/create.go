@@ -13,13 +13,32 @@ import (
 // returns the metadata associated with the resulting backup and a
 // filename for download.
 func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
-	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{
+	return c.create(params.BackupsCreateArgs{
 		Notes:      notes,
 		KeepCopy:   keepCopy,
 		NoDownload: noDownload,
+	})
+}
+
+// CreateIncremental sends a request to create an incremental backup,
+// holding only the changes to juju's state since the most recent full
+// backup. It returns the metadata associated with the resulting backup
+// and a filename for download.
+func (c *Client) CreateIncremental(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("incremental backups on this controller")
 	}
+	return c.create(params.BackupsCreateArgs{
+		Notes:       notes,
+		KeepCopy:    keepCopy,
+		NoDownload:  noDownload,
+		Incremental: true,
+	})
+}
 
+func (c *Client) create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
+	var result params.BackupsMetadataResult
+	args.Destination = c.destination
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
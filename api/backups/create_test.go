@@ -47,3 +47,30 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateIncremental(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Incremental, jc.IsTrue)
+			c.Check(p.KeepCopy, jc.IsTrue)
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+				result.Base = "full-backup"
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateIncremental("", true, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Base, gc.Equals, "full-backup")
+	s.checkMetadataResult(c, result, s.Meta)
+}
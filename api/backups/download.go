@@ -26,7 +26,8 @@ func (c *Client) Download(id string) (io.ReadCloser, error) {
 		c.facade.RawAPICaller().Context(),
 		&downloadParams{
 			Body: params.BackupsDownloadArgs{
-				ID: id,
+				ID:          id,
+				Destination: c.destination,
 			},
 		},
 		&resp,
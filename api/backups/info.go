@@ -12,7 +12,7 @@ import (
 // Info implements the API method.
 func (c *Client) Info(id string) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
-	args := params.BackupsInfoArgs{ID: id}
+	args := params.BackupsInfoArgs{ID: id, Destination: c.destination}
 	if err := c.facade.FacadeCall("Info", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -12,7 +12,7 @@ import (
 // List implements the API method.
 func (c *Client) List() (*params.BackupsListResult, error) {
 	var result params.BackupsListResult
-	args := params.BackupsListArgs{}
+	args := params.BackupsListArgs{Destination: c.destination}
 	if err := c.facade.FacadeCall("List", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -43,3 +43,21 @@ func (s *listSuite) TestList(c *gc.C) {
 	resultItem := result.List[0]
 	s.checkMetadataResult(c, &resultItem, s.Meta)
 }
+
+func (s *listSuite) TestListDestination(c *gc.C) {
+	client, err := backups.NewClientForDestination(s.APIState, "s3://juju-backups")
+	c.Assert(err, jc.ErrorIsNil)
+	cleanup := backups.PatchClientFacadeCall(client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "List")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsListArgs{
+				Destination: "s3://juju-backups",
+			})
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err = client.List()
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -13,7 +13,7 @@ func (c *Client) Remove(ids ...string) ([]params.ErrorResult, error) {
 	if len(ids) == 0 {
 		return []params.ErrorResult{}, nil
 	}
-	args := params.BackupsRemoveArgs{IDs: ids}
+	args := params.BackupsRemoveArgs{IDs: ids, Destination: c.destination}
 	results := params.ErrorResults{}
 	err := c.facade.FacadeCall("Remove", args, &results)
 	if len(results.Results) != len(ids) {
@@ -83,7 +83,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 	list := results.List
 	for _, b := range list {
 		if b.Checksum == meta.Checksum {
-			return c.restore(b.ID, newClient)
+			return c.restore(b.ID, nil, newClient)
 		}
 	}
 
@@ -95,7 +95,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
 
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, nil, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
@@ -104,7 +104,21 @@ func (c *Client) Restore(backupId string, newClient ClientConnection) error {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, nil, newClient)
+}
+
+// RestoreUntil performs restore using the id of an incremental backup
+// stored in the server, replaying only the changes made before the
+// given time.
+func (c *Client) RestoreUntil(backupId string, until time.Time, newClient ClientConnection) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("restoring to a point in time on this controller")
+	}
+	if err := prepareRestore(newClient); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("Server in 'about to restore' mode")
+	return c.restore(backupId, &until, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -117,15 +131,18 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
-// client connection factory newClient (newClient should no longer be
+// It takes backupId as the identifier for the remote backup file, an
+// optional time to restore an incremental backup up to, and a client
+// connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId string, until *time.Time, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
+		BackupId:    backupId,
+		Destination: c.destination,
+		Until:       until,
 	}
 
 	cleanExit := false
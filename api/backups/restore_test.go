@@ -4,7 +4,11 @@
 package backups_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/backups"
@@ -51,3 +55,43 @@ func (s *restoreSuite) TestRestoreFromFileBackupExistsOnController(c *gc.C) {
 	mockBackupsClient, _ := connFunc()
 	mockBackupsClient.RestoreReader(nil, &testBackupResults, connFunc)
 }
+
+func (s *restoreSuite) TestRestoreUntil(c *gc.C) {
+	mockController := gomock.NewController(c)
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().Close().AnyTimes()
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(4)
+
+	until := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	restoreArgs := params.RestoreArgs{
+		BackupId: "incremental-backup",
+		Until:    &until,
+	}
+	gomock.InOrder(
+		mockBackupFacadeCaller.EXPECT().FacadeCall("PrepareRestore", nil, gomock.Any()),
+		mockBackupFacadeCaller.EXPECT().FacadeCall("Restore", restoreArgs, gomock.Any()),
+		mockBackupFacadeCaller.EXPECT().FacadeCall("FinishRestore", gomock.Any(), gomock.Any()),
+	)
+
+	connFunc := func() (*backups.Client, error) {
+		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
+	}
+	client, _ := connFunc()
+	err := client.RestoreUntil("incremental-backup", until, connFunc)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestRestoreUntilNotSupported(c *gc.C) {
+	mockController := gomock.NewController(c)
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(3)
+
+	connFunc := func() (*backups.Client, error) {
+		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
+	}
+	client, _ := connFunc()
+	err := client.RestoreUntil("incremental-backup", time.Now(), connFunc)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
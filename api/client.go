@@ -540,6 +540,14 @@ func openURI(apiCaller base.APICaller, uri string, query url.Values) (io.ReadClo
 	return blob, nil
 }
 
+// ExportModel returns a reader for a signed archive of the model, holding
+// its description along with the charms, agent binaries and resources it
+// uses. The archive may be imported into another controller without the
+// two controllers needing to be able to reach one another.
+func (c *Client) ExportModel() (io.ReadCloser, error) {
+	return openURI(c.st, "/export", nil)
+}
+
 // NewCharmDownloader returns a new charm downloader that wraps the
 // provided API caller.
 func NewCharmDownloader(apiCaller base.APICaller) *downloader.Downloader {
@@ -416,6 +416,12 @@ func (s *clientSuite) TestOpenURIError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*error parsing version.+")
 }
 
+func (s *clientSuite) TestExportModelControllerModel(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.ExportModel()
+	c.Assert(err, gc.ErrorMatches, ".*the controller model cannot be exported")
+}
+
 func (s *clientSuite) TestOpenCharmFound(c *gc.C) {
 	client := s.APIState.Client()
 	curl, ch, repoPath := addLocalCharm(c, client, "dummy", false)
@@ -625,6 +631,11 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Replay:        true,
 		NoTail:        true,
 		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+
+		IncludeMessage:  []string{"fail.*"},
+		ExcludeMessage:  []string{"ping"},
+		IncludeLocation: []string{`uniter\.go`},
+		ExcludeLocation: []string{"worker"},
 	}
 
 	client := s.APIState.Client()
@@ -646,6 +657,11 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"replay":        {"true"},
 		"noTail":        {"true"},
 		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+
+		"includeMessage":  params.IncludeMessage,
+		"excludeMessage":  params.ExcludeMessage,
+		"includeLocation": params.IncludeLocation,
+		"excludeLocation": params.ExcludeLocation,
 	})
 }
 
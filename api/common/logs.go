@@ -34,6 +34,20 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeMessage lists regular expressions matched against the log
+	// message text. If none are set, all messages are considered included.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against the log
+	// message text. Messages matching any of them are excluded.
+	ExcludeMessage []string
+	// IncludeLocation lists regular expressions matched against the source
+	// location ("filename:lineno") of the log message. If none are set, all
+	// locations are considered included.
+	IncludeLocation []string
+	// ExcludeLocation lists regular expressions matched against the source
+	// location of the log message. Messages matching any of them are
+	// excluded.
+	ExcludeLocation []string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -61,6 +75,11 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"includeModule": args.IncludeModule,
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
+
+		"includeMessage":  args.IncludeMessage,
+		"excludeMessage":  args.ExcludeMessage,
+		"includeLocation": args.IncludeLocation,
+		"excludeLocation": args.ExcludeLocation,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
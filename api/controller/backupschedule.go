@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// BackupScheduleStatus holds the outcome of the controller's most recent
+// scheduled backups.
+type BackupScheduleStatus struct {
+	// LastAttempt is when a scheduled backup was last attempted.
+	LastAttempt time.Time
+
+	// LastSuccess is when a scheduled backup last succeeded, or zero
+	// if none has.
+	LastSuccess time.Time
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string
+
+	// LastError is the reason the last attempt failed, or empty if
+	// it succeeded.
+	LastError string
+}
+
+// BackupScheduleStatus returns the status of the controller's scheduled
+// backups. If no scheduled backup has been attempted, an error
+// satisfying errors.IsNotFound is returned.
+func (c *Client) BackupScheduleStatus() (BackupScheduleStatus, error) {
+	if c.BestAPIVersion() < 10 {
+		return BackupScheduleStatus{}, errors.NotSupportedf("BackupScheduleStatus not supported by this version of Juju")
+	}
+	var result params.BackupScheduleStatusResult
+	err := c.facade.FacadeCall("BackupScheduleStatus", nil, &result)
+	if err != nil {
+		return BackupScheduleStatus{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return BackupScheduleStatus{}, result.Error
+	}
+	if result.Result == nil {
+		return BackupScheduleStatus{}, errors.NotFoundf("scheduled backup")
+	}
+	status := BackupScheduleStatus{
+		LastAttempt:  result.Result.LastAttempt,
+		LastBackupID: result.Result.LastBackupID,
+		LastError:    result.Result.LastError,
+	}
+	if result.Result.LastSuccess != nil {
+		status.LastSuccess = *result.Result.LastSuccess
+	}
+	return status, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestBackupScheduleStatusPriorV10(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupScheduleStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestBackupScheduleStatus(c *gc.C) {
+	lastAttempt := time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC)
+	lastSuccess := lastAttempt.AddDate(0, 0, -1)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "BackupScheduleStatus")
+			c.Check(result, gc.FitsTypeOf, &params.BackupScheduleStatusResult{})
+
+			out := result.(*params.BackupScheduleStatusResult)
+			out.Result = &params.BackupScheduleStatus{
+				LastAttempt:  lastAttempt,
+				LastSuccess:  &lastSuccess,
+				LastBackupID: "20200501-030000.deadbeef",
+				LastError:    "HA not ready",
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, controller.BackupScheduleStatus{
+		LastAttempt:  lastAttempt,
+		LastSuccess:  lastSuccess,
+		LastBackupID: "20200501-030000.deadbeef",
+		LastError:    "HA not ready",
+	})
+}
+
+func (s *Suite) TestBackupScheduleStatusNeverRun(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupScheduleStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
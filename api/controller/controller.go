@@ -289,6 +289,11 @@ type MigrationSpec struct {
 	TargetUser            string
 	TargetPassword        string
 	TargetMacaroons       []macaroon.Slice
+
+	// Offline is set when the model has been exported and imported
+	// into the target controller, so that the migration only switches
+	// the model's agents over to it.
+	Offline bool
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -319,16 +324,76 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	if spec.Offline && c.BestAPIVersion() < 11 {
+		return "", errors.NotSupportedf("offline migration by this version of Juju")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationProblems holds the problems which would prevent a model
+// from being migrated.
+type MigrationProblems struct {
+	// Source holds the problems found on the source controller.
+	Source []string
+
+	// Target holds the problems found on the target controller.
+	Target []string
+}
+
+// DryRunMigration checks whether the specified model could be
+// migrated, without starting a migration, and returns every problem
+// found which would prevent it.
+func (c *Client) DryRunMigration(spec MigrationSpec) (MigrationProblems, error) {
+	if c.BestAPIVersion() < 11 {
+		return MigrationProblems{}, errors.NotSupportedf("DryRunMigration not supported by this version of Juju")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return MigrationProblems{}, errors.Trace(err)
+	}
+	response := params.DryRunMigrationResults{}
+	if err := c.facade.FacadeCall("DryRunMigration", args, &response); err != nil {
+		return MigrationProblems{}, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return MigrationProblems{}, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return MigrationProblems{}, errors.Trace(result.Error)
+	}
+	return MigrationProblems{
+		Source: result.SourceProblems,
+		Target: result.TargetProblems,
+	}, nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -340,20 +405,9 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Password:        spec.TargetPassword,
 				Macaroons:       macsJSON,
 			},
+			Offline: spec.Offline,
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -125,6 +125,7 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 				Password:        spec.TargetPassword,
 				Macaroons:       string(macsJSON),
 			},
+			Offline: spec.Offline,
 		}},
 	}
 }
@@ -172,6 +173,90 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestInitiateOfflineMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.InitiateMigrationResults)) = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.Offline = true
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestInitiateOfflineMigrationAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 10}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.Offline = true
+	_, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestDryRunMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.DryRunMigrationResults)) = params.DryRunMigrationResults{
+				Results: []params.DryRunMigrationResult{{
+					SourceProblems: []string{"cleanup needed"},
+					TargetProblems: []string{"upgrade in progress"},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	problems, err := client.DryRunMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, jc.DeepEquals, controller.MigrationProblems{
+		Source: []string{"cleanup needed"},
+		Target: []string{"upgrade in progress"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DryRunMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestDryRunMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.DryRunMigrationResults)) = params.DryRunMigrationResults{
+				Results: []params.DryRunMigrationResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestDryRunMigrationAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 10}
+	client := controller.NewClient(apiCaller)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
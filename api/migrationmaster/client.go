@@ -110,6 +110,7 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		Offline: status.Spec.Offline,
 	}, nil
 }
 
@@ -130,6 +131,18 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// SetTransferVolume records the data sent to the target controller
+// while the migration has been in its current phase.
+func (c *Client) SetTransferVolume(volume migration.TransferVolume) error {
+	args := params.MigrationTransferVolume{
+		Model:     volume.Model,
+		Charms:    volume.Charms,
+		Tools:     volume.Tools,
+		Resources: volume.Resources,
+	}
+	return c.caller.FacadeCall("SetTransferVolume", args, nil)
+}
+
 // ModelInfo return basic information about the model to migrated.
 func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	var info params.MigrationModelInfo
@@ -183,10 +196,12 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 	}
 
 	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
+		Bytes:           serialized.Bytes,
+		Charms:          serialized.Charms,
+		Tools:           tools,
+		Resources:       resources,
+		Secrets:         convertSecrets(serialized.Secrets),
+		ActionSchedules: convertActionSchedules(serialized.ActionSchedules),
 	}, nil
 }
 
@@ -315,6 +330,59 @@ func groupTagIds(tagStrs []string) ([]string, []string, []string, error) {
 	return machines, units, applications, nil
 }
 
+func convertSecrets(in []params.SerializedModelSecret) []migration.SerializedModelSecret {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]migration.SerializedModelSecret, 0, len(in))
+	for _, secret := range in {
+		outSecret := migration.SerializedModelSecret{
+			ID:          secret.ID,
+			Owner:       secret.Owner,
+			Description: secret.Description,
+			Revision:    secret.Revision,
+			CreateTime:  secret.CreateTime,
+			UpdateTime:  secret.UpdateTime,
+			Consumers:   secret.Consumers,
+			Value:       secret.Value,
+		}
+		if secret.ExpireTime != nil {
+			outSecret.ExpireTime = *secret.ExpireTime
+		}
+		out = append(out, outSecret)
+	}
+	return out
+}
+
+func convertActionSchedules(in []params.SerializedActionSchedule) []migration.SerializedActionSchedule {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]migration.SerializedActionSchedule, 0, len(in))
+	for _, schedule := range in {
+		outSchedule := migration.SerializedActionSchedule{
+			ID:         schedule.ID,
+			Schedule:   schedule.Schedule,
+			Receivers:  schedule.Receivers,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Created:    schedule.Created,
+			CreatedBy:  schedule.CreatedBy,
+		}
+		for _, run := range schedule.Runs {
+			outSchedule.Runs = append(outSchedule.Runs, migration.SerializedActionScheduleRun{
+				Scheduled:   run.Scheduled,
+				Recorded:    run.Recorded,
+				Status:      run.Status,
+				OperationID: run.OperationID,
+				Message:     run.Message,
+			})
+		}
+		out = append(out, outSchedule)
+	}
+	return out
+}
+
 func convertResources(in []params.SerializedModelResource) ([]migration.SerializedModelResource, error) {
 	if len(in) == 0 {
 		return nil, nil
@@ -95,6 +95,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 					Password:      "secret",
 					Macaroons:     string(macsJSON),
 				},
+				Offline: true,
 			},
 			MigrationId:      "id",
 			Phase:            "IMPORT",
@@ -122,6 +123,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		Offline: true,
 	})
 }
 
@@ -173,6 +175,31 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetTransferVolume(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetTransferVolume(migration.TransferVolume{
+		Model:     1,
+		Charms:    2,
+		Tools:     3,
+		Resources: 4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.MigrationTransferVolume{
+		Model:     1,
+		Charms:    2,
+		Tools:     3,
+		Resources: 4,
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetTransferVolume", []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	var stub jujutesting.Stub
 	owner := names.NewUserTag("owner")
@@ -296,6 +323,30 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 					},
 				},
 			}},
+			Secrets: []params.SerializedModelSecret{{
+				ID:         "secret-id",
+				Owner:      "application-fooapp",
+				Revision:   2,
+				CreateTime: appTs,
+				UpdateTime: unitTs,
+				ExpireTime: &unitTs,
+				Value:      map[string]string{"password": "s3cret"},
+			}},
+			ActionSchedules: []params.SerializedActionSchedule{{
+				ID:         "1",
+				Schedule:   "@daily",
+				Receivers:  []string{"fooapp/0"},
+				ActionName: "backup",
+				Parameters: map[string]interface{}{"dest": "/tmp"},
+				Created:    appTs,
+				CreatedBy:  "admin",
+				Runs: []params.SerializedActionScheduleRun{{
+					Scheduled: unitTs,
+					Recorded:  unitTs,
+					Status:    "missed",
+					Message:   "missed 1 scheduled run",
+				}},
+			}},
 		}
 		return nil
 	})
@@ -364,6 +415,30 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				},
 			},
 		}},
+		Secrets: []migration.SerializedModelSecret{{
+			ID:         "secret-id",
+			Owner:      "application-fooapp",
+			Revision:   2,
+			CreateTime: appTs,
+			UpdateTime: unitTs,
+			ExpireTime: unitTs,
+			Value:      map[string]string{"password": "s3cret"},
+		}},
+		ActionSchedules: []migration.SerializedActionSchedule{{
+			ID:         "1",
+			Schedule:   "@daily",
+			Receivers:  []string{"fooapp/0"},
+			ActionName: "backup",
+			Parameters: map[string]interface{}{"dest": "/tmp"},
+			Created:    appTs,
+			CreatedBy:  "admin",
+			Runs: []migration.SerializedActionScheduleRun{{
+				Scheduled: unitTs,
+				Recorded:  unitTs,
+				Status:    "missed",
+				Message:   "missed 1 scheduled run",
+			}},
+		}},
 	})
 }
 
@@ -568,6 +643,11 @@ func (s *ClientSuite) TestStreamModelLogs(c *gc.C) {
 		"includeModule": nil,
 		"excludeEntity": nil,
 		"excludeModule": nil,
+
+		"includeMessage":  nil,
+		"excludeMessage":  nil,
+		"includeLocation": nil,
+		"excludeLocation": nil,
 	})
 }
 
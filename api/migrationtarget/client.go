@@ -43,21 +43,89 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := migrationModelInfo(model)
+	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
+}
+
+// ValidateMigration asks the target controller to report every
+// problem which would prevent the model, and its serialized
+// description, from being migrated to it. The model is not imported.
+func (c *Client) ValidateMigration(model coremigration.ModelInfo, bytes []byte) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("ValidateMigration")
+	}
+	args := params.ValidateMigrationArgs{
+		ModelInfo: migrationModelInfo(model),
+		Bytes:     bytes,
+	}
+	var result params.ValidateMigrationResult
+	if err := c.caller.FacadeCall("ValidateMigration", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Problems, nil
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) params.MigrationModelInfo {
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
-	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
 }
 
 // Import takes a serialized model and imports it into the target
-// controller.
-func (c *Client) Import(bytes []byte) error {
-	serialized := params.SerializedModel{Bytes: bytes}
-	return errors.Trace(c.caller.FacadeCall("Import", serialized, nil))
+// controller, along with the model's secrets and action schedules. The
+// binaries used by the model are uploaded separately.
+func (c *Client) Import(serialized coremigration.SerializedModel) error {
+	// Older controllers would silently drop the secrets and schedules.
+	if len(serialized.Secrets) > 0 && c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("importing secrets")
+	}
+	if len(serialized.ActionSchedules) > 0 && c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("importing action schedules")
+	}
+	args := params.SerializedModel{Bytes: serialized.Bytes}
+	for _, secret := range serialized.Secrets {
+		argSecret := params.SerializedModelSecret{
+			ID:          secret.ID,
+			Owner:       secret.Owner,
+			Description: secret.Description,
+			Revision:    secret.Revision,
+			CreateTime:  secret.CreateTime,
+			UpdateTime:  secret.UpdateTime,
+			Consumers:   secret.Consumers,
+			Value:       secret.Value,
+		}
+		if !secret.ExpireTime.IsZero() {
+			expire := secret.ExpireTime
+			argSecret.ExpireTime = &expire
+		}
+		args.Secrets = append(args.Secrets, argSecret)
+	}
+	for _, schedule := range serialized.ActionSchedules {
+		argSchedule := params.SerializedActionSchedule{
+			ID:         schedule.ID,
+			Schedule:   schedule.Schedule,
+			Receivers:  schedule.Receivers,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Created:    schedule.Created,
+			CreatedBy:  schedule.CreatedBy,
+		}
+		for _, run := range schedule.Runs {
+			argSchedule.Runs = append(argSchedule.Runs, params.SerializedActionScheduleRun{
+				Scheduled:   run.Scheduled,
+				Recorded:    run.Recorded,
+				Status:      run.Status,
+				OperationID: run.OperationID,
+				Message:     run.Message,
+			})
+		}
+		args.ActionSchedules = append(args.ActionSchedules, argSchedule)
+	}
+	return errors.Trace(c.caller.FacadeCall("Import", args, nil))
 }
 
 // Abort removes all data relating to a previously imported model.
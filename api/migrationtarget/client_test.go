@@ -78,18 +78,160 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
-func (s *ClientSuite) TestImport(c *gc.C) {
+func (s *ClientSuite) TestValidateMigration(c *gc.C) {
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.ValidateMigrationResult)) = params.ValidateMigrationResult{
+				Problems: []string{"upgrade in progress"},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	problems, err := client.ValidateMigration(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+	}, []byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{"upgrade in progress"})
+
+	expectedArg := params.ValidateMigrationArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:         "uuid",
+			Name:         "name",
+			OwnerTag:     ownerTag.String(),
+			AgentVersion: vers,
+		},
+		Bytes: []byte("foo"),
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ValidateMigration", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestValidateMigrationNotSupported(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
+	_, err := client.ValidateMigration(coremigration.ModelInfo{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
 
-	err := client.Import([]byte("foo"))
+func (s *ClientSuite) TestImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		},
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
 
-	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	created := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	err := client.Import(coremigration.SerializedModel{
+		Bytes:  []byte("foo"),
+		Charms: []string{"cs:foo-1"},
+		Secrets: []coremigration.SerializedModelSecret{{
+			ID:         "secret-id",
+			Owner:      "application-foo",
+			Revision:   1,
+			CreateTime: created,
+			UpdateTime: created,
+			Value:      map[string]string{"password": "s3cret"},
+		}},
+		ActionSchedules: []coremigration.SerializedActionSchedule{{
+			ID:         "1",
+			Schedule:   "@daily",
+			Receivers:  []string{"foo/0"},
+			ActionName: "backup",
+			Created:    created,
+			CreatedBy:  "admin",
+			Runs: []coremigration.SerializedActionScheduleRun{{
+				Scheduled:   created,
+				Recorded:    created,
+				Status:      "enqueued",
+				OperationID: "1",
+			}},
+		}},
+	})
+
+	// Only the model, its secrets and its action schedules are
+	// sent; binaries are uploaded separately.
+	expectedArg := params.SerializedModel{
+		Bytes: []byte("foo"),
+		Secrets: []params.SerializedModelSecret{{
+			ID:         "secret-id",
+			Owner:      "application-foo",
+			Revision:   1,
+			CreateTime: created,
+			UpdateTime: created,
+			Value:      map[string]string{"password": "s3cret"},
+		}},
+		ActionSchedules: []params.SerializedActionSchedule{{
+			ID:         "1",
+			Schedule:   "@daily",
+			Receivers:  []string{"foo/0"},
+			ActionName: "backup",
+			Created:    created,
+			CreatedBy:  "admin",
+			Runs: []params.SerializedActionScheduleRun{{
+				Scheduled:   created,
+				Recorded:    created,
+				Status:      "enqueued",
+				OperationID: "1",
+			}},
+		}},
+	}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Import", []interface{}{"", expectedArg}},
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportSecretsNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.Import(coremigration.SerializedModel{
+		Bytes: []byte("foo"),
+		Secrets: []coremigration.SerializedModelSecret{{
+			ID:    "secret-id",
+			Value: map[string]string{"password": "s3cret"},
+		}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportActionSchedulesNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.Import(coremigration.SerializedModel{
+		Bytes: []byte("foo"),
+		ActionSchedules: []coremigration.SerializedActionSchedule{{
+			ID:        "1",
+			Schedule:  "@daily",
+			Receivers: []string{"foo/0"},
+		}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportWithoutSecrets(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.Import(coremigration.SerializedModel{Bytes: []byte("foo")})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Import", []interface{}{"", params.SerializedModel{Bytes: []byte("foo")}}},
+	})
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -603,3 +603,27 @@ func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.Cl
 	}
 	return out.OneError()
 }
+
+// MigrationProgress returns the progress of the latest migration of the
+// given model through each of its phases.
+func (c *Client) MigrationProgress(model names.ModelTag) (params.MigrationProgress, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 9 {
+		return params.MigrationProgress{}, errors.NotImplementedf("MigrationProgress in version %v", bestVer)
+	}
+
+	var out params.MigrationProgressResults
+	in := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+	err := c.facade.FacadeCall("MigrationProgress", in, &out)
+	if err != nil {
+		return params.MigrationProgress{}, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return params.MigrationProgress{}, errors.Errorf("expected 1 result, got %d", len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return params.MigrationProgress{}, errors.Trace(err)
+	}
+	return *out.Results[0].Result, nil
+}
@@ -611,6 +611,70 @@ func (s *modelmanagerSuite) TestListModelSummariesServerError(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *modelmanagerSuite) TestMigrationProgress(c *gc.C) {
+	start := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	expected := params.MigrationProgress{
+		MigrationId: coretesting.ModelTag.Id() + ":0",
+		Phase:       "IMPORT",
+		Start:       start,
+		Phases: []params.MigrationPhaseProgress{{
+			Phase:   "IMPORT",
+			Started: start,
+			Sent:    params.MigrationTransferVolume{Model: 100},
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "MigrationProgress")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+			})
+			out := result.(*params.MigrationProgressResults)
+			out.Results = []params.MigrationProgressResult{{Result: &expected}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	progress, err := client.MigrationProgress(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, jc.DeepEquals, expected)
+}
+
+func (s *modelmanagerSuite) TestMigrationProgressError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationProgressResults)
+			out.Results = []params.MigrationProgressResult{{
+				Error: &params.Error{Message: "migration not found", Code: params.CodeNotFound},
+			}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.MigrationProgress(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "migration not found")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *modelmanagerSuite) TestMigrationProgressNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.MigrationProgress(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "MigrationProgress in version 8 not implemented")
+}
+
 func (s *modelmanagerSuite) TestChangeModelCredential(c *gc.C) {
 	credentialTag := names.NewCloudCredentialTag("foo/bob/bar")
 	called := false
@@ -0,0 +1,41 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides a client for reading the secrets created
+// by the charms in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const secretsFacade = "Secrets"
+
+// Client provides access to the Secrets facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, secretsFacade)
+	return &Client{
+		ClientFacade: frontend,
+		facade:       backend,
+	}
+}
+
+// ListSecrets returns the secrets in the model. If showValues is true
+// the decrypted value of each secret is included.
+func (c *Client) ListSecrets(showValues bool) ([]params.ListSecretResult, error) {
+	args := params.ListSecretsArgs{ShowValues: showValues}
+	var results params.ListSecretResults
+	if err := c.facade.FacadeCall("ListSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
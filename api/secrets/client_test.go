@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestListSecrets(c *gc.C) {
+	created := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, jc.DeepEquals, params.ListSecretsArgs{ShowValues: true})
+		*result.(*params.ListSecretResults) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				ID:         "9m4e2mr0ui3e8a215n4g",
+				Owner:      "application-mariadb",
+				Revision:   1,
+				CreateTime: created,
+				UpdateTime: created,
+				Value:      map[string]string{"password": "s3cret"},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.ListSecretResult{{
+		ID:         "9m4e2mr0ui3e8a215n4g",
+		Owner:      "application-mariadb",
+		Revision:   1,
+		CreateTime: created,
+		UpdateTime: created,
+		Value:      map[string]string{"password": "s3cret"},
+	}})
+}
+
+func (s *ClientSuite) TestListSecretsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ListSecrets(false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const secretsManagerFacade = "SecretsManager"
+
+// SecretsAccessor provides access to the secrets shared between charms,
+// through the SecretsManager facade.
+type SecretsAccessor struct {
+	facade base.FacadeCaller
+}
+
+// NewSecretsAccessor creates a SecretsAccessor on the specified facade,
+// and uses this name when calling through the caller.
+func NewSecretsAccessor(facade base.FacadeCaller) *SecretsAccessor {
+	return &SecretsAccessor{facade}
+}
+
+// CreateSecret creates a secret owned by the caller's application, and
+// returns its ID. The secret doesn't expire if expireTime is zero.
+func (sa *SecretsAccessor) CreateSecret(description string, expireTime time.Time, value map[string]string) (string, error) {
+	arg := params.CreateSecretArg{
+		Description: description,
+		Value:       value,
+	}
+	if !expireTime.IsZero() {
+		arg.ExpireTime = &expireTime
+	}
+	var results params.StringResults
+	err := sa.facade.FacadeCall("CreateSecrets", params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
+
+// UpdateSecret updates the value and expiry time of a secret owned by
+// the caller's application. A nil value or expireTime is left
+// unchanged, and a zero expireTime stops the secret expiring.
+func (sa *SecretsAccessor) UpdateSecret(id string, expireTime *time.Time, value map[string]string) error {
+	var results params.ErrorResults
+	err := sa.facade.FacadeCall("UpdateSecrets", params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			ID:         id,
+			ExpireTime: expireTime,
+			Value:      value,
+		}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SecretValue returns the value of a secret the caller owns or has
+// been granted access to.
+func (sa *SecretsAccessor) SecretValue(id string) (map[string]string, error) {
+	var results params.SecretValueResults
+	err := sa.facade.FacadeCall("GetSecretValues", params.GetSecretArgs{
+		IDs: []string{id},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Value, nil
+}
+
+// GrantSecret allows an application or unit to read a secret owned by
+// the caller's application.
+func (sa *SecretsAccessor) GrantSecret(id string, subject names.Tag) error {
+	return sa.grantRevoke("GrantSecrets", id, subject)
+}
+
+// RevokeSecret stops an application or unit from reading a secret
+// owned by the caller's application.
+func (sa *SecretsAccessor) RevokeSecret(id string, subject names.Tag) error {
+	return sa.grantRevoke("RevokeSecrets", id, subject)
+}
+
+func (sa *SecretsAccessor) grantRevoke(method, id string, subject names.Tag) error {
+	var results params.ErrorResults
+	err := sa.facade.FacadeCall(method, params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			ID:      id,
+			Subject: subject.String(),
+		}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
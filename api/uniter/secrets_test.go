@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&secretsSuite{})
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *secretsSuite) TestCreateSecret(c *gc.C) {
+	expire := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CreateSecrets")
+		c.Check(arg, jc.DeepEquals, params.CreateSecretArgs{
+			Args: []params.CreateSecretArg{{
+				Description: "password",
+				ExpireTime:  &expire,
+				Value:       map[string]string{"password": "s3cret"},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "secret-id"}},
+		}
+		called = true
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mariadb/0"))
+	secretID, err := st.CreateSecret("password", expire, map[string]string{"password": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+	c.Check(secretID, gc.Equals, "secret-id")
+}
+
+func (s *secretsSuite) TestCreateSecretError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mariadb/0"))
+	_, err := st.CreateSecret("", time.Time{}, map[string]string{"password": "s3cret"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *secretsSuite) TestUpdateSecret(c *gc.C) {
+	var never time.Time
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "UpdateSecrets")
+		c.Check(arg, jc.DeepEquals, params.UpdateSecretArgs{
+			Args: []params.UpdateSecretArg{{
+				ID:         "secret-id",
+				ExpireTime: &never,
+				Value:      map[string]string{"password": "n3w"},
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		called = true
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mariadb/0"))
+	err := st.UpdateSecret("secret-id", &never, map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *secretsSuite) TestSecretValue(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "GetSecretValues")
+		c.Check(arg, jc.DeepEquals, params.GetSecretArgs{IDs: []string{"secret-id"}})
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			Results: []params.SecretValueResult{{
+				Value: map[string]string{"password": "s3cret"},
+			}},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mariadb/0"))
+	value, err := st.SecretValue("secret-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *secretsSuite) TestGrantRevokeSecret(c *gc.C) {
+	var requests []string
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(arg, jc.DeepEquals, params.GrantRevokeSecretArgs{
+			Args: []params.GrantRevokeSecretArg{{
+				ID:      "secret-id",
+				Subject: "application-wordpress",
+			}},
+		})
+		requests = append(requests, request)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mariadb/0"))
+	err := st.GrantSecret("secret-id", names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.RevokeSecret("secret-id", names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(requests, jc.DeepEquals, []string{"GrantSecrets", "RevokeSecrets"})
+}
@@ -5,6 +5,9 @@ package uniter
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
@@ -31,6 +34,7 @@ type State struct {
 	*common.UpgradeSeriesAPI
 	*common.UnitStateAPI
 	*StorageAccessor
+	*SecretsAccessor
 
 	LeadershipSettings *LeadershipSettingsAccessor
 	facade             base.FacadeCaller
@@ -53,6 +57,7 @@ func NewState(
 		UpgradeSeriesAPI: common.NewUpgradeSeriesAPI(facadeCaller, authTag),
 		UnitStateAPI:     common.NewUniterStateAPI(facadeCaller, authTag),
 		StorageAccessor:  NewStorageAccessor(facadeCaller),
+		SecretsAccessor:  NewSecretsAccessor(base.NewFacadeCaller(caller, secretsManagerFacade)),
 		facade:           facadeCaller,
 		unitTag:          authTag,
 	}
@@ -311,6 +316,30 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// UploadActionAttachment uploads size bytes read from r to the controller
+// as an attachment of the action with the given name. The contents must
+// match the given SHA384 hash.
+func (st *State) UploadActionAttachment(tag names.ActionTag, name string, r io.ReadSeeker, size int64, sha384 string) error {
+	endpoint := fmt.Sprintf("/actions/%s/attachments/%s", tag.Id(), url.PathEscape(name))
+	req, err := http.NewRequest("PUT", endpoint, r)
+	if err != nil {
+		return errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeRaw)
+	req.Header.Set("Content-Sha384", sha384)
+	req.ContentLength = size
+
+	// The returned httpClient sets the base url to /model/<uuid> if it can.
+	httpClient, err := st.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := httpClient.Do(st.facade.RawAPICaller().Context(), req, nil); err != nil {
+		return errors.Annotatef(err, "cannot upload attachment %q", name)
+	}
+	return nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
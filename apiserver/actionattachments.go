@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// actionAttachmentsHandler handles the upload of files attached to
+// actions by the units running them, and their download by users.
+type actionAttachmentsHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements http.Handler.
+func (h *actionAttachmentsHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	var err error
+	switch req.Method {
+	case "GET":
+		err = h.serveGet(resp, req)
+	case "PUT":
+		err = h.servePut(resp, req)
+	default:
+		err = errors.MethodNotAllowedf("unsupported method: %q", req.Method)
+	}
+	if err != nil {
+		if err := sendError(resp, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+// servePut stores the request body as an attachment of the action. Only
+// the unit running the action may attach files to it.
+func (h *actionAttachmentsHandler) servePut(resp http.ResponseWriter, req *http.Request) error {
+	defer req.Body.Close()
+
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.UnitTagKind)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+
+	action, err := h.action(st.State, req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if action.Receiver() != entity.Tag().Id() {
+		return common.ErrPerm
+	}
+	hash := req.Header.Get("Content-Sha384")
+	if hash == "" {
+		return errors.BadRequestf("missing Content-Sha384 header")
+	}
+	if req.ContentLength < 0 {
+		return errors.BadRequestf("missing Content-Length header")
+	}
+	name := req.URL.Query().Get(":name")
+	if err := action.AddAttachment(name, req.Body, req.ContentLength, hash); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("unit %s attached %q to task %s", entity.Tag().Id(), name, action.Id())
+	return errors.Trace(sendStatusAndJSON(resp, http.StatusOK, &params.ErrorResult{}))
+}
+
+// serveGet sends the contents of an attachment of the action to a user
+// with read access to the model.
+func (h *actionAttachmentsHandler) serveGet(resp http.ResponseWriter, req *http.Request) error {
+	st, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+
+	ok, err := common.HasPermission(
+		st.UserPermission,
+		entity.Tag(),
+		permission.ReadAccess,
+		names.NewModelTag(st.ModelUUID()),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+
+	action, err := h.action(st.State, req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attachment, r, err := action.OpenAttachment(req.URL.Query().Get(":name"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+
+	hdr := resp.Header()
+	hdr.Set("Content-Type", params.ContentTypeRaw)
+	hdr.Set("Content-Length", fmt.Sprint(attachment.Size))
+	hdr.Set("Content-Sha384", attachment.SHA384)
+	resp.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(resp, r); err != nil {
+		// The headers have been sent, so the error can only be logged.
+		logger.Errorf("unable to complete stream for attachment %q: %v", attachment.Name, err)
+	}
+	return nil
+}
+
+func (h *actionAttachmentsHandler) action(st *state.State, req *http.Request) (state.Action, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.Action(req.URL.Query().Get(":action"))
+}
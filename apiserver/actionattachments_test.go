@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type actionAttachmentsSuite struct {
+	apiserverBaseSuite
+	app      *state.Application
+	unit     *state.Unit
+	password string
+	action   state.Action
+}
+
+var _ = gc.Suite(&actionAttachmentsSuite{})
+
+func (s *actionAttachmentsSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	s.app = s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	s.unit, s.password = s.Factory.MakeUnitReturningPassword(c, &factory.UnitParams{
+		Application: s.app,
+		SetCharmURL: true,
+	})
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = s.unit.AddAction(operationID, "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = s.action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *actionAttachmentsSuite) attachmentURL(name string) string {
+	return s.URL(fmt.Sprintf("/model/%s/actions/%s/attachments/%s", s.State.ModelUUID(), s.action.Id(), name), nil).String()
+}
+
+func (s *actionAttachmentsSuite) upload(c *gc.C, tag, password, name, content string) *http.Response {
+	return apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Tag:      tag,
+		Password: password,
+		Method:   "PUT",
+		URL:      s.attachmentURL(name),
+		Body:     strings.NewReader(content),
+		ExtraHeaders: map[string]string{
+			"Content-Sha384": fmt.Sprintf("%x", sha512.Sum384([]byte(content))),
+		},
+	})
+}
+
+func (s *actionAttachmentsSuite) assertErrorResponse(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, statusCode, gc.Commentf("body: %s", body))
+
+	var result params.ErrorResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, msg)
+}
+
+func (s *actionAttachmentsSuite) TestUploadAndDownload(c *gc.C) {
+	resp := s.upload(c, s.unit.Tag().String(), s.password, "dump.sql", "select 1;")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	resp.Body.Close()
+
+	resp = s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.attachmentURL("dump.sql"),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Sha384"), gc.Equals, fmt.Sprintf("%x", sha512.Sum384([]byte("select 1;"))))
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(body), gc.Equals, "select 1;")
+}
+
+func (s *actionAttachmentsSuite) TestUploadByOtherUnit(c *gc.C) {
+	other, password := s.Factory.MakeUnitReturningPassword(c, &factory.UnitParams{
+		Application: s.app,
+	})
+	resp := s.upload(c, other.Tag().String(), password, "dump.sql", "select 1;")
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *actionAttachmentsSuite) TestUploadByUser(c *gc.C) {
+	resp := s.upload(c, s.Owner.String(), ownerPassword, "dump.sql", "select 1;")
+	s.assertErrorResponse(c, resp, http.StatusInternalServerError, "tag kind user not valid")
+}
+
+func (s *actionAttachmentsSuite) TestUploadMissingHash(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Tag:      s.unit.Tag().String(),
+		Password: s.password,
+		Method:   "PUT",
+		URL:      s.attachmentURL("dump.sql"),
+		Body:     strings.NewReader("select 1;"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "missing Content-Sha384 header")
+}
+
+func (s *actionAttachmentsSuite) TestDownloadNotFound(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.attachmentURL("dump.sql"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusNotFound, `attachment "dump.sql" of task ".*" not found`)
+}
+
+func (s *actionAttachmentsSuite) TestDownloadByUnit(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Tag:      s.unit.Tag().String(),
+		Password: s.password,
+		Method:   "GET",
+		URL:      s.attachmentURL("dump.sql"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusInternalServerError, "tag kind unit not valid")
+}
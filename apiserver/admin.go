@@ -178,8 +178,12 @@ func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult
 	// Wrap the audit logger in a filter that prevents us from logging
 	// lots of readonly conversations (like "juju status" requests).
 	filter := observer.MakeInterestingRequestFilter(cfg.ExcludeMethods)
+	target := cfg.Target
+	if cfg.Forward && cfg.ForwardTarget != nil {
+		target = auditlog.NewMultiLog(target, cfg.ForwardTarget)
+	}
 	result, err := auditlog.NewRecorder(
-		observer.NewAuditLogFilter(cfg.Target, filter),
+		observer.NewAuditLogFilter(target, filter),
 		a.srv.clock,
 		auditlog.ConversationArgs{
 			Who:          a.root.entity.Tag().Id(),
@@ -1069,6 +1069,48 @@ func (s *loginSuite) TestLoginAddsAuditConversationEventually(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestAuditLoggingForwards(c *gc.C) {
+	log := &servertesting.FakeAuditLog{}
+	forwarded := &servertesting.FakeAuditLog{}
+	s.cfg.GetAuditConfig = func() auditlog.Config {
+		return auditlog.Config{
+			Enabled:       true,
+			Target:        log,
+			Forward:       true,
+			ForwardTarget: forwarded,
+		}
+	}
+	info := s.newServer(c)
+
+	password := "shhh..."
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: password,
+	})
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:     user.Tag().String(),
+		Credentials: password,
+		CLIArgs:     "hey you guys",
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var addResults params.AddMachinesResults
+	addReq := &params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Jobs: []model.MachineJob{"JobHostUnits"},
+		}},
+	}
+	err = conn.APICall("Client", 1, "", "AddMachines", addReq, &addResults)
+	c.Assert(err, jc.ErrorIsNil)
+
+	log.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse")
+	forwarded.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse")
+	c.Assert(forwarded.Calls(), jc.DeepEquals, log.Calls())
+}
+
 func (s *loginSuite) TestAuditLoggingFailureOnInterestingRequest(c *gc.C) {
 	log := &servertesting.FakeAuditLog{}
 	log.SetErrors(errors.Errorf("bad news bears"))
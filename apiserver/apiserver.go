@@ -690,7 +690,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		ctxt:          httpCtxt,
 		stateAuthFunc: httpCtxt.stateForMigrationImporting,
 	}
+	modelExportHandler := &modelExportHandler{
+		ctxt:  httpCtxt,
+		tools: modelToolsDownloadHandler,
+	}
 	backupHandler := &backupHandler{ctxt: httpCtxt}
+	actionAttachmentsHandler := &actionAttachmentsHandler{ctxt: httpCtxt}
 	registerHandler := &registerUserHandler{ctxt: httpCtxt}
 	guiArchiveHandler := &guiArchiveHandler{ctxt: httpCtxt}
 	guiVersionHandler := &guiVersionHandler{ctxt: httpCtxt}
@@ -756,6 +761,16 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	}, {
 		pattern: modelRoutePrefix + "/units/:unit/resources/:resource",
 		handler: unitResourcesHandler,
+	}, {
+		pattern:    modelRoutePrefix + "/actions/:action/attachments/:name",
+		handler:    actionAttachmentsHandler,
+		methods:    []string{"GET", "PUT"},
+		authorizer: tagKindAuthorizer{names.UserTagKind, names.UnitTagKind},
+	}, {
+		pattern:    modelRoutePrefix + "/export",
+		methods:    []string{"GET"},
+		handler:    modelExportHandler,
+		authorizer: controllerAdminAuthorizer,
 	}, {
 		pattern: modelRoutePrefix + "/backups",
 		handler: backupHandler,
@@ -28,6 +28,19 @@ var newBackups = func(st *state.State, m *state.Model) (backups.Backups, io.Clos
 	return backups.NewBackups(stor), stor
 }
 
+var newDestinationBackups = func(st *state.State, destination string) (backups.Backups, io.Closer, error) {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dest, err := backups.OpenDestination(destination, controllerConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	stor := backups.NewDestinationStorage(dest)
+	return backups.NewBackups(stor), stor, nil
+}
+
 // backupHandler handles backup requests.
 type backupHandler struct {
 	ctxt httpContext
@@ -54,13 +67,10 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	backups, closer := newBackups(st.State, m)
-	defer closer.Close()
-
 	switch req.Method {
 	case "GET":
 		logger.Infof("handling backups download request")
-		id, err := h.download(backups, resp, req)
+		id, err := h.download(st.State, m, resp, req)
 		if err != nil {
 			h.sendError(resp, err)
 			return
@@ -68,6 +78,8 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		logger.Infof("backups download request successful for %q", id)
 	case "PUT":
 		logger.Infof("handling backups upload request")
+		backups, closer := newBackups(st.State, m)
+		defer closer.Close()
 		id, err := h.upload(backups, resp, req)
 		if err != nil {
 			h.sendError(resp, err)
@@ -79,13 +91,27 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (h *backupHandler) download(backups backups.Backups, resp http.ResponseWriter, req *http.Request) (string, error) {
+func (h *backupHandler) download(st *state.State, m *state.Model, resp http.ResponseWriter, req *http.Request) (string, error) {
 	args, err := h.parseGETArgs(req)
 	if err != nil {
 		return "", err
 	}
 	logger.Infof("backups download request for %q", args.ID)
 
+	var (
+		backups backups.Backups
+		closer  io.Closer
+	)
+	if args.Destination == "" {
+		backups, closer = newBackups(st, m)
+	} else {
+		backups, closer, err = newDestinationBackups(st, args.Destination)
+		if err != nil {
+			return "", err
+		}
+	}
+	defer closer.Close()
+
 	meta, archive, err := backups.Get(args.ID)
 	if err != nil {
 		return "", err
@@ -288,7 +288,43 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action, compat b
 			Message:   m.Message(),
 		})
 	}
+	attachments, err := action.Attachments()
+	if err != nil {
+		result.Error = ServerError(err)
+	}
+	for _, a := range attachments {
+		result.Attachments = append(result.Attachments, params.ActionAttachment{
+			Name:    a.Name,
+			Size:    a.Size,
+			SHA384:  a.SHA384,
+			Created: a.Created,
+		})
+	}
+
+	return result
+}
 
+// MakeActionSchedule converts a state.ActionSchedule to a
+// params.ActionSchedule.
+func MakeActionSchedule(schedule state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Id:         schedule.Id,
+		Schedule:   schedule.Schedule,
+		Receivers:  schedule.Receivers,
+		Name:       schedule.ActionName,
+		Parameters: schedule.Parameters,
+		Created:    schedule.Created,
+		CreatedBy:  schedule.CreatedBy,
+	}
+	for _, run := range schedule.Runs {
+		result.Runs = append(result.Runs, params.ActionScheduleRun{
+			Scheduled:   run.Scheduled,
+			Recorded:    run.Recorded,
+			Status:      string(run.Status),
+			OperationId: run.OperationId,
+			Message:     run.Message,
+		})
+	}
 	return result
 }
 
@@ -35,18 +35,23 @@ var _ = gc.Suite(&controllerConfigSuite{})
 
 type fakeControllerAccessor struct {
 	controllerConfigError error
+	extraConfig           map[string]interface{}
 }
 
 func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 	if f.controllerConfigError != nil {
 		return nil, f.controllerConfigError
 	}
-	return map[string]interface{}{
+	config := map[string]interface{}{
 		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
 		controller.CACertKey:         testing.CACert,
 		controller.APIPort:           4321,
 		controller.StatePort:         1234,
-	}, nil
+	}
+	for key, value := range f.extraConfig {
+		config[key] = value
+	}
+	return config, nil
 }
 
 func (f *fakeControllerAccessor) ControllerInfo(modelUUID string) ([]string, string, error) {
@@ -75,6 +80,28 @@ func (*controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	})
 }
 
+func (*controllerConfigSuite) TestControllerConfigHidesSecrets(c *gc.C) {
+	cc := common.NewControllerConfig(
+		&fakeControllerAccessor{extraConfig: map[string]interface{}{
+			controller.AgentMetricsPort:     17072,
+			controller.AgentMetricsUsername: "prometheus",
+			controller.AgentMetricsPassword: "s3cret",
+			controller.BackupS3Endpoint:     "https://s3.example.com",
+			controller.BackupS3AccessKey:    "access",
+			controller.BackupS3SecretKey:    "secret",
+		}},
+	)
+	result, err := cc.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Config[controller.AgentMetricsPort], gc.Equals, 17072)
+	c.Check(result.Config[controller.AgentMetricsUsername], gc.Equals, "prometheus")
+	c.Check(result.Config[controller.BackupS3Endpoint], gc.Equals, "https://s3.example.com")
+	for _, key := range controller.SecretAttributes.Values() {
+		_, ok := result.Config[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+}
+
 func (*controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	cc := common.NewControllerConfig(
 		&fakeControllerAccessor{
@@ -122,6 +122,8 @@ func (u *UnitStateAPI) State(args params.Entities) (params.UnitStateResults, err
 		res[i].RelationState = rState
 		sState, _ := unitState.StorageState()
 		res[i].StorageState = sState
+		history, _ := unitState.HookHistory()
+		res[i].HookHistory = history
 	}
 
 	return params.UnitStateResults{Results: res}, nil
@@ -167,6 +169,9 @@ func (u *UnitStateAPI) SetState(args params.SetUnitStateArgs) (params.ErrorResul
 		if arg.StorageState != nil {
 			unitState.SetStorageState(*arg.StorageState)
 		}
+		if arg.HookHistory != nil {
+			unitState.SetHookHistory(*arg.HookHistory)
+		}
 
 		ops := unit.SetStateOperation(unitState)
 		if err = u.backend.ApplyOperation(ops); err != nil {
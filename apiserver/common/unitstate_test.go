@@ -60,6 +60,8 @@ func (s *unitStateSuite) assertBackendApi(c *gc.C) *gomock.Controller {
 	return ctrl
 }
 
+const expHookHistory = "- kind: install\n"
+
 func (s *unitStateSuite) expectState() (map[string]string, string, map[int]string, string) {
 	expState := map[string]string{
 		"foo.bar":  "baz",
@@ -77,6 +79,7 @@ func (s *unitStateSuite) expectState() (map[string]string, string, map[int]strin
 	unitState.SetUniterState(expUniterState)
 	unitState.SetRelationState(expRelationState)
 	unitState.SetStorageState(expStorageState)
+	unitState.SetHookHistory(expHookHistory)
 
 	exp := s.mockUnit.EXPECT()
 	exp.State().Return(unitState, nil)
@@ -128,6 +131,7 @@ func (s *unitStateSuite) TestState(c *gc.C) {
 				UniterState:   expUniterState,
 				RelationState: expRelationState,
 				StorageState:  expStorageState,
+				HookHistory:   expHookHistory,
 			},
 			{Error: apiservertesting.ErrUnauthorized},
 			{Error: apiservertesting.ErrUnauthorized},
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -77,6 +78,13 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeMessage -> []string - regular expressions matched against the message text
+//      - if none are set, then all lines are considered included
+//   excludeMessage -> []string - regular expressions matched against the message text
+//      - lines matching any of them are excluded from the response
+//   includeLocation -> []string - regular expressions matched against the source location
+//      - if none are set, then all lines are considered included
+//   excludeLocation -> []string - regular expressions matched against the source location
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
@@ -190,6 +198,11 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+
+	includeMessage  []string
+	excludeMessage  []string
+	includeLocation []string
+	excludeLocation []string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -250,5 +263,22 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	for _, arg := range []struct {
+		name   string
+		target *[]string
+	}{
+		{"includeMessage", &params.includeMessage},
+		{"excludeMessage", &params.excludeMessage},
+		{"includeLocation", &params.includeLocation},
+		{"excludeLocation", &params.excludeLocation},
+	} {
+		for _, value := range queryMap[arg.name] {
+			if _, err := regexp.Compile(value); err != nil {
+				return params, errors.Errorf("%s value %q is not a valid regular expression", arg.name, value)
+			}
+		}
+		*arg.target = queryMap[arg.name]
+	}
+
 	return params, nil
 }
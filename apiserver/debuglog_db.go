@@ -77,6 +77,11 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+
+		IncludeMessage:  reqParams.includeMessage,
+		ExcludeMessage:  reqParams.excludeMessage,
+		IncludeLocation: reqParams.includeLocation,
+		ExcludeLocation: reqParams.excludeLocation,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
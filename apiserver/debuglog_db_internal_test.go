@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -46,6 +47,11 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+
+		includeMessage:  []string{"fail.*"},
+		excludeMessage:  []string{"ping"},
+		includeLocation: []string{"uniter"},
+		excludeLocation: []string{"worker"},
 	}
 
 	called := false
@@ -62,6 +68,10 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeMessage, jc.DeepEquals, []string{"fail.*"})
+		c.Assert(params.ExcludeMessage, jc.DeepEquals, []string{"ping"})
+		c.Assert(params.IncludeLocation, jc.DeepEquals, []string{"uniter"})
+		c.Assert(params.ExcludeLocation, jc.DeepEquals, []string{"worker"})
 
 		return newFakeLogTailer(), nil
 	})
@@ -96,6 +106,25 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestReadParamsRegex(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"includeMessage":  {"fail.*", "error"},
+		"excludeLocation": {`uniter\.go`},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.includeMessage, jc.DeepEquals, []string{"fail.*", "error"})
+	c.Assert(params.excludeMessage, gc.HasLen, 0)
+	c.Assert(params.includeLocation, gc.HasLen, 0)
+	c.Assert(params.excludeLocation, jc.DeepEquals, []string{`uniter\.go`})
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsInvalidRegex(c *gc.C) {
+	_, err := readDebugLogParams(url.Values{
+		"includeMessage": {"fail("},
+	})
+	c.Assert(err, gc.ErrorMatches, `includeMessage value "fail\(" is not a valid regular expression`)
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
@@ -8,6 +8,7 @@ package agent
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
@@ -34,6 +35,23 @@ type AgentAPIV2 struct {
 	resources facade.Resources
 }
 
+// AgentAPIV3 implements version 3 of the Agent API, which adds
+// MetricsConfig.
+type AgentAPIV3 struct {
+	*AgentAPIV2
+}
+
+// NewAgentAPIV3 returns an object implementing version 3 of the Agent
+// API with the given authorizer representing the currently logged in
+// client.
+func NewAgentAPIV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV3, error) {
+	v2, err := NewAgentAPIV2(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &AgentAPIV3{v2}, nil
+}
+
 // NewAgentAPIV2 returns an object implementing version 2 of the Agent API
 // with the given authorizer representing the currently logged in client.
 func NewAgentAPIV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV2, error) {
@@ -134,11 +152,44 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		CAPrivateKey:      info.CAPrivateKey,
 		SharedSecret:      info.SharedSecret,
 		SystemIdentity:    info.SystemIdentity,
+		// The secrets key isn't kept in the database, so it comes
+		// from this controller's agent config.
+		SecretsKey: api.st.SecretsKey(),
 	}
 
 	return result, nil
 }
 
+// MetricsConfig returns the port and credentials the authenticated
+// machine agent serves agent metrics with. The agent serves them with
+// a certificate of its own, so the controller's CA is never used to
+// sign certificates for agents. The call fails if the controller has
+// no agent-metrics-port configured. The password is only returned as a
+// salted hash, so agents never learn it.
+func (api *AgentAPIV3) MetricsConfig() (params.MetricsConfigResult, error) {
+	if _, ok := api.auth.GetAuthTag().(names.MachineTag); !ok {
+		return params.MetricsConfigResult{}, common.ErrPerm
+	}
+	config, err := api.st.ControllerConfig()
+	if err != nil {
+		return params.MetricsConfigResult{}, errors.Trace(err)
+	}
+	port := config.AgentMetricsPort()
+	if port == 0 {
+		return params.MetricsConfigResult{}, errors.NotFoundf("agent-metrics-port")
+	}
+	salt, err := utils.RandomSalt()
+	if err != nil {
+		return params.MetricsConfigResult{}, errors.Trace(err)
+	}
+	return params.MetricsConfigResult{
+		Port:         port,
+		Username:     config.AgentMetricsUsername(),
+		PasswordHash: utils.UserPasswordHash(config.AgentMetricsPassword(), salt),
+		PasswordSalt: salt,
+	}, nil
+}
+
 // MongoIsMaster is called by the IsMaster API call
 // instead of mongo.IsMaster. It exists so it can
 // be overridden by tests.
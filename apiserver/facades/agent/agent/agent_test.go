@@ -6,7 +6,9 @@ package agent_test
 import (
 	stdtesting "testing"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -276,3 +279,56 @@ func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
+
+func (s *agentSuite) TestControllerConfigHidesBackupCredentials(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := agent.NewAgentAPIV2(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Config[controller.BackupS3Endpoint], gc.Equals, "https://s3.example.com")
+	for _, key := range []string{controller.BackupS3AccessKey, controller.BackupS3SecretKey} {
+		_, ok := result.Config[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+}
+
+func (s *agentSuite) TestMetricsConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AgentMetricsPort:     17072,
+		controller.AgentMetricsUsername: "prometheus",
+		controller.AgentMetricsPassword: "s3cret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.MetricsConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Port, gc.Equals, 17072)
+	c.Check(result.Username, gc.Equals, "prometheus")
+	c.Check(result.PasswordSalt, gc.Not(gc.Equals), "")
+	c.Check(result.PasswordHash, gc.Equals, utils.UserPasswordHash("s3cret", result.PasswordSalt))
+}
+
+func (s *agentSuite) TestMetricsConfigWithoutPort(c *gc.C) {
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.MetricsConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *agentSuite) TestMetricsConfigUnitAgent(c *gc.C) {
+	auth := s.authorizer
+	auth.Tag = names.NewUnitTag("foosball/1")
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.MetricsConfig()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
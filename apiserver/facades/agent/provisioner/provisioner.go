@@ -464,6 +464,7 @@ func (api *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.SnapStoreProxyID = cfg.SnapStoreProxy()
 	result.SnapStoreProxyURL = cfg.SnapStoreProxyURL()
 	result.CloudInitUserData = cfg.CloudInitUserData()
+	result.CloudInitUserHooks = cfg.CloudInitUserHooks()
 	result.ContainerInheritProperties = cfg.ContainerInheritProperties()
 	return result, nil
 }
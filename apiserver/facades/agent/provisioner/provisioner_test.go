@@ -1515,6 +1515,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"snap-store-assertions":        "BLOB",
 		"snap-store-proxy":             "b4dc0ffee",
 		"cloudinit-userdata":           validCloudInitUserData,
+		"cloudinit-user-hooks":         "pre-agent-install: echo hello\n",
 		"container-inherit-properties": "ca-certs,apt-primary",
 	}
 	err := s.Model.UpdateModelConfig(attrs, nil)
@@ -1552,6 +1553,9 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"preruncmd":       []interface{}{"mkdir /tmp/preruncmd", "mkdir /tmp/preruncmd2"},
 		"postruncmd":      []interface{}{"mkdir /tmp/postruncmd", "mkdir /tmp/postruncmd2"},
 		"package_upgrade": false})
+	c.Check(results.CloudInitUserHooks, gc.DeepEquals, map[string]string{
+		"pre-agent-install": "echo hello",
+	})
 	c.Check(results.ContainerInheritProperties, gc.DeepEquals, "ca-certs,apt-primary")
 }
 
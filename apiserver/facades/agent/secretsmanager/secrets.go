@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsmanager implements the API used by unit agents to
+// create and read the secrets shared between charms.
+package secretsmanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SecretsManagerAPI implements the SecretsManager facade.
+type SecretsManagerAPI struct {
+	backend Backend
+
+	// authTag is the tag of the calling unit or, for operators,
+	// application agent.
+	authTag names.Tag
+
+	// application is the application owning the secrets created by
+	// the caller.
+	application names.ApplicationTag
+}
+
+// NewSecretsManagerAPI is used for API registration.
+func NewSecretsManagerAPI(ctx facade.Context) (*SecretsManagerAPI, error) {
+	return newSecretsManagerAPI(&backend{ctx.State()}, ctx.Auth())
+}
+
+func newSecretsManagerAPI(backend Backend, authorizer facade.Authorizer) (*SecretsManagerAPI, error) {
+	if !authorizer.AuthUnitAgent() && !authorizer.AuthApplicationAgent() {
+		return nil, common.ErrPerm
+	}
+	api := &SecretsManagerAPI{
+		backend: backend,
+		authTag: authorizer.GetAuthTag(),
+	}
+	switch tag := api.authTag.(type) {
+	case names.UnitTag:
+		appName, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		api.application = names.NewApplicationTag(appName)
+	case names.ApplicationTag:
+		api.application = tag
+	default:
+		return nil, errors.Errorf("expected names.UnitTag or names.ApplicationTag, got %T", tag)
+	}
+	return api, nil
+}
+
+// CreateSecrets creates new secrets owned by the caller's application,
+// and returns their IDs.
+func (s *SecretsManagerAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		createParams := state.CreateSecretParams{
+			Owner:       s.application,
+			Description: arg.Description,
+			Value:       arg.Value,
+		}
+		if arg.ExpireTime != nil {
+			createParams.ExpireTime = *arg.ExpireTime
+		}
+		secret, err := s.backend.CreateSecret(createParams)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = secret.ID()
+	}
+	return result, nil
+}
+
+// UpdateSecrets updates the values and expiry times of secrets owned
+// by the caller's application.
+func (s *SecretsManagerAPI) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := s.updateSecret(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) updateSecret(arg params.UpdateSecretArg) error {
+	if err := s.checkOwner(arg.ID); err != nil {
+		return errors.Trace(err)
+	}
+	updateParams := state.UpdateSecretParams{
+		Value: arg.Value,
+	}
+	if arg.ExpireTime != nil {
+		expire := *arg.ExpireTime
+		updateParams.ExpireTime = &expire
+	}
+	return s.backend.UpdateSecret(arg.ID, updateParams)
+}
+
+// GetSecretValues returns the values of secrets the caller owns or has
+// been granted access to.
+func (s *SecretsManagerAPI) GetSecretValues(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.IDs)),
+	}
+	for i, id := range args.IDs {
+		value, err := s.getSecretValue(id)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Value = value
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) getSecretValue(id string) (map[string]string, error) {
+	secret, err := s.backend.Secret(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !secret.CanRead(s.authTag) {
+		return nil, common.ErrPerm
+	}
+	return s.backend.SecretValue(id)
+}
+
+// GrantSecrets allows applications or units to read secrets owned by
+// the caller's application.
+func (s *SecretsManagerAPI) GrantSecrets(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.grantRevoke(args, s.backend.GrantSecretAccess)
+}
+
+// RevokeSecrets stops applications or units from reading secrets owned
+// by the caller's application.
+func (s *SecretsManagerAPI) RevokeSecrets(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.grantRevoke(args, s.backend.RevokeSecretAccess)
+}
+
+func (s *SecretsManagerAPI) grantRevoke(
+	args params.GrantRevokeSecretArgs, op func(string, names.Tag) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := s.checkOwner(arg.ID)
+		if err == nil {
+			var subject names.Tag
+			if subject, err = names.ParseTag(arg.Subject); err == nil {
+				err = op(arg.ID, subject)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// checkOwner returns an error if the secret with the given ID isn't
+// owned by the caller's application.
+func (s *SecretsManagerAPI) checkOwner(id string) error {
+	secret, err := s.backend.Secret(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner, err := secret.Owner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if owner != names.Tag(s.application) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Secret describes the secret methods used by the SecretsManager facade.
+type Secret interface {
+	ID() string
+	Owner() (names.Tag, error)
+	CanRead(names.Tag) bool
+}
+
+// Backend defines the state methods used by the SecretsManager facade.
+type Backend interface {
+	CreateSecret(state.CreateSecretParams) (Secret, error)
+	Secret(id string) (Secret, error)
+	SecretValue(id string) (map[string]string, error)
+	UpdateSecret(id string, args state.UpdateSecretParams) error
+	GrantSecretAccess(id string, consumer names.Tag) error
+	RevokeSecretAccess(id string, consumer names.Tag) error
+}
@@ -0,0 +1,259 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsManagerSuite struct {
+	coretesting.BaseSuite
+
+	authorizer *apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	facade     *secretsmanager.SecretsManagerAPI
+}
+
+var _ = gc.Suite(&SecretsManagerSuite{})
+
+func (s *SecretsManagerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mariadb/0"),
+	}
+	s.backend = &mockBackend{
+		secrets: map[string]*mockSecret{
+			"owned": {
+				id:    "owned",
+				owner: names.NewApplicationTag("mariadb"),
+			},
+			"granted": {
+				id:        "granted",
+				owner:     names.NewApplicationTag("vault"),
+				consumers: []names.Tag{names.NewUnitTag("mariadb/0")},
+			},
+			"other": {
+				id:    "other",
+				owner: names.NewApplicationTag("vault"),
+			},
+		},
+	}
+	var err error
+	s.facade, err = secretsmanager.NewTestAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsManagerSuite) TestNewAPIRequiresAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := secretsmanager.NewTestAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SecretsManagerSuite) TestCreateSecrets(c *gc.C) {
+	expire := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	results, err := s.facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Description: "password",
+			ExpireTime:  &expire,
+			Value:       map[string]string{"password": "s3cret"},
+		}, {
+			Value: map[string]string{},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{
+			Result: "new-secret",
+		}, {
+			Error: &params.Error{Message: "empty secret value not valid"},
+		}},
+	})
+	s.backend.CheckCall(c, 0, "CreateSecret", state.CreateSecretParams{
+		Owner:       names.NewApplicationTag("mariadb"),
+		Description: "password",
+		ExpireTime:  expire,
+		Value:       map[string]string{"password": "s3cret"},
+	})
+}
+
+func (s *SecretsManagerSuite) TestCreateSecretsApplicationAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewApplicationTag("mariadb")
+	facade, err := secretsmanager.NewTestAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Value: map[string]string{"password": "s3cret"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "CreateSecret", state.CreateSecretParams{
+		Owner: names.NewApplicationTag("mariadb"),
+		Value: map[string]string{"password": "s3cret"},
+	})
+}
+
+func (s *SecretsManagerSuite) TestUpdateSecrets(c *gc.C) {
+	var never time.Time
+	results, err := s.facade.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			ID:         "owned",
+			ExpireTime: &never,
+			Value:      map[string]string{"password": "n3w"},
+		}, {
+			ID:    "granted",
+			Value: map[string]string{"password": "n3w"},
+		}, {
+			ID:    "missing",
+			Value: map[string]string{"password": "n3w"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+			{Error: &params.Error{Message: `secret "missing" not found`, Code: params.CodeNotFound}},
+		},
+	})
+	s.backend.CheckCall(c, 1, "UpdateSecret", "owned", state.UpdateSecretParams{
+		Value:      map[string]string{"password": "n3w"},
+		ExpireTime: &never,
+	})
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValues(c *gc.C) {
+	results, err := s.facade.GetSecretValues(params.GetSecretArgs{
+		IDs: []string{"owned", "granted", "other", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{
+			{Value: map[string]string{"id": "owned"}},
+			{Value: map[string]string{"id": "granted"}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+			{Error: &params.Error{Message: `secret "missing" not found`, Code: params.CodeNotFound}},
+		},
+	})
+}
+
+func (s *SecretsManagerSuite) TestGrantSecrets(c *gc.C) {
+	results, err := s.facade.GrantSecrets(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			ID:      "owned",
+			Subject: "application-wordpress",
+		}, {
+			ID:      "owned",
+			Subject: "bad-tag",
+		}, {
+			ID:      "granted",
+			Subject: "application-wordpress",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"bad-tag" is not a valid tag`}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	s.backend.CheckCall(c, 1, "GrantSecretAccess", "owned", names.NewApplicationTag("wordpress"))
+}
+
+func (s *SecretsManagerSuite) TestRevokeSecrets(c *gc.C) {
+	results, err := s.facade.RevokeSecrets(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			ID:      "owned",
+			Subject: "unit-wordpress-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.backend.CheckCall(c, 1, "RevokeSecretAccess", "owned", names.NewUnitTag("wordpress/1"))
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	secrets map[string]*mockSecret
+}
+
+func (b *mockBackend) CreateSecret(args state.CreateSecretParams) (secretsmanager.Secret, error) {
+	b.MethodCall(b, "CreateSecret", args)
+	if len(args.Value) == 0 {
+		return nil, errors.NotValidf("empty secret value")
+	}
+	return &mockSecret{id: "new-secret", owner: args.Owner}, nil
+}
+
+func (b *mockBackend) Secret(id string) (secretsmanager.Secret, error) {
+	b.MethodCall(b, "Secret", id)
+	secret, ok := b.secrets[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return secret, nil
+}
+
+func (b *mockBackend) SecretValue(id string) (map[string]string, error) {
+	b.MethodCall(b, "SecretValue", id)
+	return map[string]string{"id": id}, nil
+}
+
+func (b *mockBackend) UpdateSecret(id string, args state.UpdateSecretParams) error {
+	b.MethodCall(b, "UpdateSecret", id, args)
+	return nil
+}
+
+func (b *mockBackend) GrantSecretAccess(id string, consumer names.Tag) error {
+	b.MethodCall(b, "GrantSecretAccess", id, consumer)
+	return nil
+}
+
+func (b *mockBackend) RevokeSecretAccess(id string, consumer names.Tag) error {
+	b.MethodCall(b, "RevokeSecretAccess", id, consumer)
+	return nil
+}
+
+type mockSecret struct {
+	id        string
+	owner     names.Tag
+	consumers []names.Tag
+}
+
+func (s *mockSecret) ID() string {
+	return s.id
+}
+
+func (s *mockSecret) Owner() (names.Tag, error) {
+	return s.owner, nil
+}
+
+func (s *mockSecret) CanRead(entity names.Tag) bool {
+	if entity.String() == "unit-mariadb-0" && s.owner.String() == "application-mariadb" {
+		return true
+	}
+	for _, consumer := range s.consumers {
+		if consumer == entity {
+			return true
+		}
+	}
+	return false
+}
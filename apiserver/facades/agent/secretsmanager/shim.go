@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+type backend struct {
+	*state.State
+}
+
+// CreateSecret is part of the Backend interface.
+func (b *backend) CreateSecret(args state.CreateSecretParams) (Secret, error) {
+	secret, err := b.State.CreateSecret(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secret, nil
+}
+
+// Secret is part of the Backend interface.
+func (b *backend) Secret(id string) (Secret, error) {
+	secret, err := b.State.Secret(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secret, nil
+}
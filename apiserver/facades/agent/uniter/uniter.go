@@ -76,10 +76,17 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV15 implements version (v15) of the Uniter API, which
+// adds State, SetState and CommitHookChanges. It neither returns nor
+// accepts a unit's hook history.
+type UniterAPIV15 struct {
+	UniterAPI
+}
+
 // UniterAPIV14 implements version (v14) of the Uniter API,
 // which adds GetPodSpec, SetState and State.
 type UniterAPIV14 struct {
-	UniterAPI
+	UniterAPIV15
 }
 
 // UniterAPIV13 implements version (v13) of the Uniter API,
@@ -237,14 +244,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV15 creates an instance of the V15 uniter API.
+func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV15{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV14 creates an instance of the V14 uniter API.
 func NewUniterAPIV14(context facade.Context) (*UniterAPIV14, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV15(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV14{
-		UniterAPI: *uniterAPI,
+		UniterAPIV15: *uniterAPI,
 	}, nil
 }
 
@@ -3296,6 +3314,34 @@ func (u *UniterAPI) updateUnitNetworkInfoOperation(unitTag names.UnitTag, unit *
 	return state.ComposeModelOperations(modelOps...), nil
 }
 
+// State returns the state persisted by the charm running in each of
+// the given units and the state internal to the uniter for them,
+// without their hook history, which was added in v16.
+func (u *UniterAPIV15) State(args params.Entities) (params.UnitStateResults, error) {
+	results, err := u.UniterAPI.State(args)
+	if err != nil {
+		return results, err
+	}
+	for i := range results.Results {
+		results.Results[i].HookHistory = ""
+	}
+	return results, nil
+}
+
+// SetState sets the state persisted by the charm running in each of
+// the given units and the state internal to the uniter for them. Any
+// hook history is ignored, as it was added in v16.
+func (u *UniterAPIV15) SetState(args params.SetUnitStateArgs) (params.ErrorResults, error) {
+	v15Args := params.SetUnitStateArgs{
+		Args: make([]params.SetUnitStateArg, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		arg.HookHistory = nil
+		v15Args.Args[i] = arg
+	}
+	return u.UniterAPI.SetState(v15Args)
+}
+
 // State isn't on the v14 API.
 func (u *UniterAPIV14) State(_ struct{}) {}
 
@@ -5232,6 +5232,59 @@ func (t *fakeToken) Check(int, interface{}) error {
 	return t.err
 }
 
+type uniterV15Suite struct {
+	uniterSuiteBase
+	uniterV15 *uniter.UniterAPIV15
+}
+
+var _ = gc.Suite(&uniterV15Suite{})
+
+func (s *uniterV15Suite) SetUpTest(c *gc.C) {
+	s.uniterSuiteBase.SetUpTest(c)
+
+	uniterV15, err := uniter.NewUniterAPIV15(s.facadeContext())
+	c.Assert(err, jc.ErrorIsNil)
+	s.uniterV15 = uniterV15
+}
+
+func (s *uniterV15Suite) TestStateOmitsHookHistory(c *gc.C) {
+	us := state.NewUnitState()
+	us.SetUniterState("uniter state")
+	us.SetHookHistory("- kind: install\n")
+	err := s.wordpressUnit.SetState(us)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniterV15.State(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].UniterState, gc.Equals, "uniter state")
+	c.Assert(result.Results[0].HookHistory, gc.Equals, "")
+}
+
+func (s *uniterV15Suite) TestSetStateIgnoresHookHistory(c *gc.C) {
+	uniterState := "uniter state"
+	hookHistory := "- kind: install\n"
+	result, err := s.uniterV15.SetState(params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{{
+			Tag:         "unit-wordpress-0",
+			UniterState: &uniterState,
+			HookHistory: &hookHistory,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	us, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	got, _ := us.UniterState()
+	c.Assert(got, gc.Equals, uniterState)
+	history, _ := us.HookHistory()
+	c.Assert(history, gc.Equals, "")
+}
+
 type uniterV14Suite struct {
 	uniterSuiteBase
 	uniterV14 *uniter.UniterAPIV14
@@ -46,6 +46,16 @@ type APIv5 struct {
 
 // APIv6 provides the Action API facade for version 6.
 type APIv6 struct {
+	*APIv7
+}
+
+// APIv7 provides the Action API facade for version 7.
+type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Action API facade for version 8.
+type APIv8 struct {
 	*ActionAPI
 }
 
@@ -87,13 +97,31 @@ func NewActionAPIV5(ctx facade.Context) (*APIv5, error) {
 
 // NewActionAPIV6 returns an initialized ActionAPI for version 6.
 func NewActionAPIV6(ctx facade.Context) (*APIv6, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV7(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv6{api}, nil
 }
 
+// NewActionAPIV7 returns an initialized ActionAPI for version 7.
+func NewActionAPIV7(ctx facade.Context) (*APIv7, error) {
+	api, err := NewActionAPIV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{api}, nil
+}
+
+// NewActionAPIV8 returns an initialized ActionAPI for version 8.
+func NewActionAPIV8(ctx facade.Context) (*APIv8, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
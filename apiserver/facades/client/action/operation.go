@@ -19,6 +19,14 @@ import (
 // EnqueueOperation isn't on the V5 API.
 func (*APIv5) EnqueueOperation(_, _ struct{}) {}
 
+// EnqueueOperation on the V7 API does not support rolling operations.
+func (a *APIv7) EnqueueOperation(arg params.Actions) (params.EnqueuedActions, error) {
+	if arg.Rolling != nil {
+		return params.EnqueuedActions{}, errors.NotSupportedf("rolling operations in this version of the Action facade")
+	}
+	return a.APIv8.EnqueueOperation(arg)
+}
+
 // EnqueueOperation takes a list of Actions and queues them up to be executed as
 // an operation, each action running as a task on the the designated ActionReceiver.
 // We return the ID of the overall operation and each individual task.
@@ -75,7 +83,16 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 		}
 	}
 	summary := fmt.Sprintf("%v run on %v", operationName, strings.Join(receivers, ","))
-	operationID, err := a.model.EnqueueOperation(summary)
+	var operationID string
+	var err error
+	if arg.Rolling != nil {
+		operationID, err = a.model.EnqueueRollingOperation(summary, state.RollingStrategy{
+			BatchSize:   arg.Rolling.BatchSize,
+			MaxFailures: arg.Rolling.MaxFailures,
+		})
+	} else {
+		operationID, err = a.model.EnqueueOperation(summary)
+	}
 	if err != nil {
 		return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
 	}
@@ -168,19 +185,7 @@ func (a *ActionAPI) ListOperations(arg params.OperationQueryArgs) (params.Operat
 		Results:   make([]params.OperationResult, len(summaryResults)),
 	}
 	for i, r := range summaryResults {
-		result.Results[i] = params.OperationResult{
-			OperationTag: r.Operation.Tag().String(),
-			Summary:      r.Operation.Summary(),
-			Enqueued:     r.Operation.Enqueued(),
-			Started:      r.Operation.Started(),
-			Completed:    r.Operation.Completed(),
-			Status:       string(r.Operation.Status()),
-			Actions:      make([]params.ActionResult, len(r.Actions)),
-		}
-		for j, a := range r.Actions {
-			receiver := names.NewUnitTag(a.Receiver())
-			result.Results[i].Actions[j] = common.MakeActionResult(receiver, a, false)
-		}
+		result.Results[i] = makeOperationResult(r.Operation, r.Actions)
 	}
 	return result, nil
 }
@@ -204,19 +209,69 @@ func (a *ActionAPI) Operations(arg params.Entities) (params.OperationResults, er
 			continue
 		}
 
-		results.Results[i] = params.OperationResult{
-			OperationTag: op.Operation.Tag().String(),
-			Summary:      op.Operation.Summary(),
-			Enqueued:     op.Operation.Enqueued(),
-			Started:      op.Operation.Started(),
-			Completed:    op.Operation.Completed(),
-			Status:       string(op.Operation.Status()),
-			Actions:      make([]params.ActionResult, len(op.Actions)),
+		results.Results[i] = makeOperationResult(op.Operation, op.Actions)
+	}
+	return results, nil
+}
+
+func makeOperationResult(op state.Operation, actions []state.Action) params.OperationResult {
+	result := params.OperationResult{
+		OperationTag: op.Tag().String(),
+		Summary:      op.Summary(),
+		Enqueued:     op.Enqueued(),
+		Started:      op.Started(),
+		Completed:    op.Completed(),
+		Status:       string(op.Status()),
+		Actions:      make([]params.ActionResult, len(actions)),
+	}
+	for i, a := range actions {
+		receiver := names.NewUnitTag(a.Receiver())
+		result.Actions[i] = common.MakeActionResult(receiver, a, false)
+	}
+	if rolling := op.Rolling(); rolling != nil {
+		result.Rolling = &params.RollingStrategy{
+			BatchSize:   rolling.BatchSize,
+			MaxFailures: rolling.MaxFailures,
+		}
+		result.Batches = operationBatches(actions)
+		result.Message = op.Message()
+	}
+	return result
+}
+
+// batchStatusOrder determines the status of a batch of a rolling
+// operation from the status of its tasks.
+var batchStatusOrder = []state.ActionStatus{
+	state.ActionRunning,
+	state.ActionAborting,
+	state.ActionPending,
+	state.ActionFailed,
+	state.ActionAborted,
+	state.ActionCancelled,
+	state.ActionCompleted,
+}
+
+// operationBatches groups the tasks of a rolling operation into their
+// batches, and reports the status of each batch.
+func operationBatches(actions []state.Action) []params.OperationBatch {
+	var batches []params.OperationBatch
+	var taskStatus []set.Strings
+	for _, a := range actions {
+		n := a.Batch()
+		for len(batches) <= n {
+			batches = append(batches, params.OperationBatch{})
+			taskStatus = append(taskStatus, set.NewStrings())
 		}
-		for j, a := range op.Actions {
-			receiver := names.NewUnitTag(a.Receiver())
-			results.Results[i].Actions[j] = common.MakeActionResult(receiver, a, false)
+		batches[n].Tasks = append(batches[n].Tasks, a.ActionTag().String())
+		taskStatus[n].Add(string(a.Status()))
+	}
+	for i := range batches {
+		for _, s := range batchStatusOrder {
+			if taskStatus[i].Contains(string(s)) {
+				batches[i].Status = string(s)
+				break
+			}
 		}
 	}
-	return results, nil
+	return batches
 }
@@ -293,3 +293,49 @@ func (s *operationSuite) TestOperations(c *gc.C) {
 	c.Assert(action.Tag, gc.Equals, "action-5")
 	c.Assert(result.Actions[3].Status, gc.Equals, "pending")
 }
+
+func (s *operationSuite) TestEnqueueRollingOperation(c *gc.C) {
+	s.toSupportNewActionID(c)
+
+	result, err := s.action.EnqueueOperation(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		},
+		Rolling: &params.RollingStrategy{BatchSize: 2, MaxFailures: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OperationTag, gc.Equals, "operation-1")
+
+	a, err := s.Model.Action("2")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operations, err := s.action.Operations(params.Entities{
+		Entities: []params.Entity{{Tag: result.OperationTag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 1)
+	op := operations.Results[0]
+	c.Assert(op.Rolling, jc.DeepEquals, &params.RollingStrategy{BatchSize: 2, MaxFailures: 1})
+	c.Assert(op.Batches, jc.DeepEquals, []params.OperationBatch{{
+		Status: "pending",
+		Tasks:  []string{"action-2", "action-3"},
+	}, {
+		Status: "pending",
+		Tasks:  []string{"action-4"},
+	}})
+	c.Assert(op.Message, gc.Equals, "")
+}
+
+func (s *operationSuite) TestEnqueueRollingOperationInvalid(c *gc.C) {
+	_, err := s.action.EnqueueOperation(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		},
+		Rolling: &params.RollingStrategy{BatchSize: 0},
+	})
+	c.Assert(err, gc.ErrorMatches, "creating operation for actions: batch size 0 not valid")
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules isn't on the V6 API.
+func (*APIv6) AddActionSchedules(_, _ struct{}) {}
+
+// ListActionSchedules isn't on the V6 API.
+func (*APIv6) ListActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the V6 API.
+func (*APIv6) RemoveActionSchedules(_, _ struct{}) {}
+
+// AddActionSchedules adds schedules on which actions are enqueued by the
+// controller, returning each added schedule or an error if it could not
+// be added.
+func (a *ActionAPI) AddActionSchedules(args params.AddActionSchedulesArgs) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.model.AddActionSchedule(state.AddActionScheduleArgs{
+			Schedule:   arg.Schedule,
+			Receivers:  arg.Receivers,
+			ActionName: arg.Name,
+			Parameters: arg.Parameters,
+			CreatedBy:  a.authorizer.GetAuthTag().Id(),
+		})
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := common.MakeActionSchedule(schedule)
+		results.Results[i].Schedule = &result
+	}
+	return results, nil
+}
+
+// ListActionSchedules returns all the action schedules in the model,
+// with the history of their recent runs.
+func (a *ActionAPI) ListActionSchedules() (params.ActionSchedulesResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedulesResult{}, errors.Trace(err)
+	}
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedulesResult{}, errors.Trace(err)
+	}
+	result := params.ActionSchedulesResult{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = common.MakeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given IDs.
+// Actions already enqueued by the schedules are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		results.Results[i].Error = common.ServerError(a.model.RemoveActionSchedule(id))
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestAddActionSchedules(c *gc.C) {
+	results, err := s.action.AddActionSchedules(params.AddActionSchedulesArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Schedule:   "@daily",
+			Receivers:  []string{"wordpress/leader"},
+			Name:       "fakeaction",
+			Parameters: map[string]interface{}{"foo": "bar"},
+		}, {
+			Schedule:  "@daily",
+			Receivers: []string{"wordpress/9"},
+			Name:      "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	schedule := results.Results[0].Schedule
+	c.Assert(schedule, gc.NotNil)
+	c.Assert(schedule.Id, gc.Equals, "1")
+	c.Assert(schedule.Receivers, jc.DeepEquals, []string{"wordpress/leader"})
+	c.Assert(schedule.Name, gc.Equals, "fakeaction")
+	c.Assert(schedule.CreatedBy, gc.Equals, "admin")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "wordpress/9" not found`)
+
+	listed, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Schedules, gc.HasLen, 1)
+	c.Assert(listed.Schedules[0].Id, gc.Equals, "1")
+	c.Assert(listed.Schedules[0].Parameters, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
+func (s *scheduleSuite) TestAddActionSchedulesBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestAddActionSchedulesBlocked")
+	_, err := s.action.AddActionSchedules(params.AddActionSchedulesArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Schedule:  "@daily",
+			Receivers: []string{"wordpress/0"},
+			Name:      "fakeaction",
+		}},
+	})
+	s.AssertBlocked(c, err, "TestAddActionSchedulesBlocked")
+}
+
+func (s *scheduleSuite) TestRemoveActionSchedules(c *gc.C) {
+	_, err := s.action.AddActionSchedules(params.AddActionSchedulesArgs{
+		Schedules: []params.AddActionScheduleArg{{
+			Schedule:  "0 0 2 * * *",
+			Receivers: []string{"wordpress/0"},
+			Name:      "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{
+		Ids: []string{"1", "2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action schedule "2" not found`)
+
+	listed, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Schedules, gc.HasLen, 0)
+}
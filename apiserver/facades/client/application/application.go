@@ -92,6 +92,12 @@ type APIv10 struct {
 // The Get call also returns the current endpoint bindings while the SetCharm
 // call access a map of operator-defined bindings.
 type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+// It adds the UnitsInfo call.
+type APIv12 struct {
 	*APIBase
 }
 
@@ -190,13 +196,21 @@ func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
 }
 
 func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV12(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv11{api}, nil
 }
 
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -2485,6 +2499,66 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 	return params.ApplicationInfoResults{out}, nil
 }
 
+// UnitsInfo isn't on the v11 API.
+func (u *APIv11) UnitsInfo(_, _ struct{}) {}
+
+// UnitsInfo returns unit information, including the history of the
+// hooks and actions most recently run by each unit.
+func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	out := make([]params.UnitInfoResult, len(in.Entities))
+	for i, one := range in.Entities {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		result, err := api.unitResult(unit)
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		out[i].Result = result
+	}
+	return params.UnitInfoResults{out}, nil
+}
+
+func (api *APIBase) unitResult(unit Unit) (*params.UnitResult, error) {
+	result := &params.UnitResult{
+		Tag:         unit.Tag().String(),
+		Application: unit.ApplicationName(),
+		Life:        unit.Life().String(),
+	}
+	if curl, _ := unit.CharmURL(); curl != nil {
+		result.Charm = curl.String()
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil && !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	result.Machine = machineId
+	if result.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitState, err := unit.State()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if history, _ := unitState.HookHistory(); history != "" {
+		if err := goyaml.Unmarshal([]byte(history), &result.HookHistory); err != nil {
+			return nil, errors.Annotatef(err, "reading hook history of unit %q", unit.Name())
+		}
+	}
+	return result, nil
+}
+
 // MergeBindings merges operator-defined bindings with the current bindings for
 // one or more applications.
 func (api *APIBase) MergeBindings(in params.ApplicationMergeBindingsArgs) (params.ErrorResults, error) {
@@ -56,7 +56,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv11
+	api          *application.APIv12
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -86,7 +86,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv11{api}
+	s.api = &application.APIv12{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -1892,6 +1892,58 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
+func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.hookHistory = `
+- kind: db-relation-joined
+  name: db-relation-joined
+  relation: db:1
+  started: 2020-04-01T12:00:00Z
+  duration: 2s
+  exit-code: 1
+  tools-called: [relation-get, relation-set]
+`[1:]
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-wordpress-0"}, {Tag: "application-postgresql"}}
+	result, err := s.api.UnitsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(*result.Results[0].Result, jc.DeepEquals, params.UnitResult{
+		Tag:             "unit-postgresql-0",
+		Application:     "postgresql",
+		Charm:           "cs:quantal/postgresql-1",
+		Life:            "alive",
+		Machine:         "machine-0",
+		WorkloadVersion: "1.0",
+		HookHistory: []params.HookExecution{{
+			Kind:        "db-relation-joined",
+			Name:        "db-relation-joined",
+			Relation:    "db:1",
+			Started:     time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+			Duration:    2 * time.Second,
+			ExitCode:    1,
+			ToolsCalled: []string{"relation-get", "relation-set"},
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "wordpress/0" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	unit.CheckCallNames(c, "ApplicationName", "Life", "CharmURL", "AssignedMachineId", "WorkloadVersion", "State")
+}
+
+func (s *ApplicationSuite) TestUnitsInfoStateErr(c *gc.C) {
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.SetErrors(
+		nil,                   // u.AssignedMachineId() call
+		nil,                   // u.WorkloadVersion() call
+		errors.Errorf("boom"), // u.State() call
+	)
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}}
+	result, err := s.api.UnitsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
 func (s *ApplicationSuite) TestApplicationMergeBindingsErr(c *gc.C) {
 	req := params.ApplicationMergeBindingsArgs{
 		Args: []params.ApplicationMergeBindings{
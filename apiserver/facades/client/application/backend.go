@@ -147,6 +147,10 @@ type Unit interface {
 	Name() string
 	Tag() names.Tag
 	UnitTag() names.UnitTag
+	ApplicationName() string
+	CharmURL() (*charm.URL, bool)
+	WorkloadVersion() (string, error)
+	State() (*state.UnitState, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyUnitOperation
 	IsPrincipal() bool
@@ -768,10 +768,11 @@ func (r *mockRelation) DestroyWithForce(force bool, maxWait time.Duration) ([]er
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag        names.UnitTag
-	machineId  string
-	name       string
-	agentTools *tools.Tools
+	tag         names.UnitTag
+	machineId   string
+	name        string
+	agentTools  *tools.Tools
+	hookHistory string
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -823,6 +824,35 @@ func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	return u.agentTools, u.NextErr()
 }
 
+func (u *mockUnit) ApplicationName() string {
+	u.MethodCall(u, "ApplicationName")
+	appName, _ := names.UnitApplication(u.tag.Id())
+	return appName
+}
+
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	return state.Alive
+}
+
+func (u *mockUnit) CharmURL() (*charm.URL, bool) {
+	u.MethodCall(u, "CharmURL")
+	appName, _ := names.UnitApplication(u.tag.Id())
+	return charm.MustParseURL("cs:quantal/" + appName + "-1"), true
+}
+
+func (u *mockUnit) WorkloadVersion() (string, error) {
+	u.MethodCall(u, "WorkloadVersion")
+	return "1.0", u.NextErr()
+}
+
+func (u *mockUnit) State() (*state.UnitState, error) {
+	u.MethodCall(u, "State")
+	unitState := state.NewUnitState()
+	unitState.SetHookHistory(u.hookHistory)
+	return unitState, u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog implements the API endpoint used by clients to
+// query the audit log recorded by the controllers.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/permission"
+)
+
+// DefaultLimit is the number of entries returned by Query when no
+// limit is given.
+const DefaultLimit = 100
+
+// API implements the AuditLog facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return newAPI(&backend{ctx.StatePool().SystemState()}, ctx.Auth())
+}
+
+func newAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Query returns the audit log entries matching the given arguments,
+// in the order the requests were made. Only controller superusers may
+// query the audit log.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResults, error) {
+	var results params.AuditLogQueryResults
+	if err := api.checkIsSuperuser(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	query := auditlog.Query{
+		Who:        args.Who,
+		ModelUUID:  args.ModelUUID,
+		Facade:     args.Facade,
+		Method:     args.Method,
+		ErrorsOnly: args.ErrorsOnly,
+		Limit:      args.Limit,
+	}
+	if args.After != nil {
+		query.After = *args.After
+	}
+	if args.Before != nil {
+		query.Before = *args.Before
+	}
+	if query.Limit < 0 {
+		return results, errors.NotValidf("negative limit %d", query.Limit)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultLimit
+	}
+
+	entries, err := api.backend.QueryAuditLog(query)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		results.Entries[i] = toParamsEntry(entry)
+	}
+	return results, nil
+}
+
+func (api *API) checkIsSuperuser() error {
+	isSuperuser, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isSuperuser {
+		return common.ServerError(common.ErrPerm)
+	}
+	return nil
+}
+
+func toParamsEntry(entry auditlog.Entry) params.AuditLogEntry {
+	result := params.AuditLogEntry{
+		ControllerID:   entry.ControllerID,
+		ConversationID: entry.Request.ConversationID,
+		ConnectionID:   entry.Request.ConnectionID,
+		Who:            entry.Conversation.Who,
+		What:           entry.Conversation.What,
+		ModelName:      entry.Conversation.ModelName,
+		ModelUUID:      entry.Conversation.ModelUUID,
+		RequestID:      entry.Request.RequestID,
+		When:           entry.Request.When,
+		Facade:         entry.Request.Facade,
+		Method:         entry.Request.Method,
+		Version:        entry.Request.Version,
+		Args:           entry.Request.Args,
+	}
+	for _, e := range entry.Errors {
+		result.Errors = append(result.Errors, &params.Error{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
+
+// Backend defines the state methods used by the AuditLog facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	QueryAuditLog(auditlog.Query) ([]auditlog.Entry, error)
+}
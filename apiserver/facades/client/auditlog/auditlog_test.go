@@ -0,0 +1,167 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	api        *auditlog.API
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *auditLogSuite) TestAgentNotAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQueryNotSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	s.backend.entries = []coreauditlog.Entry{{
+		ControllerID: "1",
+		Conversation: coreauditlog.Conversation{
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			When:           "2020-05-01T03:00:00Z",
+			ModelName:      "default",
+			ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+		},
+		Request: coreauditlog.Request{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			RequestID:      2,
+			When:           "2020-05-01T03:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        7,
+			Args:           `{"applications":[]}`,
+		},
+		Errors: []*coreauditlog.Error{{Message: "oops", Code: "not found"}},
+	}}
+
+	after := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC)
+	results, err := s.api.Query(params.AuditLogQueryArgs{
+		Who:        "bob",
+		ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:     "Application",
+		Method:     "Deploy",
+		After:      &after,
+		Before:     &before,
+		ErrorsOnly: true,
+		Limit:      5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AuditLogQueryResults{
+		Entries: []params.AuditLogEntry{{
+			ControllerID:   "1",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			ModelName:      "default",
+			ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			RequestID:      2,
+			When:           "2020-05-01T03:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        7,
+			Args:           `{"applications":[]}`,
+			Errors:         []*params.Error{{Message: "oops", Code: "not found"}},
+		}},
+	})
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "ControllerTag"},
+		{FuncName: "QueryAuditLog", Args: []interface{}{coreauditlog.Query{
+			Who:        "bob",
+			ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Facade:     "Application",
+			Method:     "Deploy",
+			After:      after,
+			Before:     before,
+			ErrorsOnly: true,
+			Limit:      5,
+		}}},
+	})
+}
+
+func (s *auditLogSuite) TestQueryDefaultLimit(c *gc.C) {
+	results, err := s.api.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, gc.HasLen, 0)
+	s.backend.CheckCall(c, 1, "QueryAuditLog", coreauditlog.Query{Limit: auditlog.DefaultLimit})
+}
+
+func (s *auditLogSuite) TestQueryNegativeLimit(c *gc.C) {
+	_, err := s.api.Query(params.AuditLogQueryArgs{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit -1 not valid")
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	_, err := s.api.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	entries []coreauditlog.Entry
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	b.MethodCall(b, "ControllerTag")
+	b.PopNoErr()
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) QueryAuditLog(query coreauditlog.Query) ([]coreauditlog.Entry, error) {
+	b.MethodCall(b, "QueryAuditLog", query)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.entries, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+)
+
+type backend struct {
+	*state.State
+}
+
+// QueryAuditLog is part of the Backend interface.
+func (b *backend) QueryAuditLog(query auditlog.Query) ([]auditlog.Entry, error) {
+	return state.QueryAuditLog(b.State, query)
+}
@@ -54,6 +54,18 @@ type APIv2 struct {
 	*API
 }
 
+// APIv3 serves backup-specific API methods for version 3, which adds
+// support for backup destinations outside the controller.
+type APIv3 struct {
+	*APIv2
+}
+
+// APIv4 serves backup-specific API methods for version 4, which adds
+// support for incremental backups and restoring to a point in time.
+type APIv4 struct {
+	*APIv3
+}
+
 func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	api, err := NewAPI(backend, resources, authorizer)
 	if err != nil {
@@ -62,6 +74,22 @@ func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Aut
 	return &APIv2{api}, nil
 }
 
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+func NewAPIv4(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	api, err := NewAPIv3(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -135,6 +163,29 @@ var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
 	return backups.NewBackups(stor), stor
 }
 
+var newDestinationBackups = func(backend Backend, destination string) (backups.Backups, io.Closer, error) {
+	controllerConfig, err := backend.ControllerConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dest, err := backups.OpenDestination(destination, controllerConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	stor := backups.NewDestinationStorage(dest)
+	return backups.NewBackups(stor), stor, nil
+}
+
+// openBackups returns the backups kept in the destination with the
+// given URL, or in the controller's own storage if it is empty.
+func (a *API) openBackups(destination string) (backups.Backups, io.Closer, error) {
+	if destination == "" {
+		b, closer := newBackups(a.backend)
+		return b, closer, nil
+	}
+	return newDestinationBackups(a.backend, destination)
+}
+
 // CreateResult updates the result with the information in the
 // metadata value.
 func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadataResult {
@@ -176,6 +227,10 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.CAPrivateKey = meta.CAPrivateKey
 	result.Filename = filename
 
+	result.Base = meta.Base
+	result.OplogStart = meta.Oplog.Start
+	result.OplogEnd = meta.Oplog.End
+
 	return result
 }
 
@@ -201,6 +256,11 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 		MachineInstanceID: result.ControllerMachineInstanceID,
 		HANodes:           result.HANodes,
 	}
+	meta.Base = result.Base
+	meta.Oplog = backups.OplogRange{
+		Start: result.OplogStart,
+		End:   result.OplogEnd,
+	}
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
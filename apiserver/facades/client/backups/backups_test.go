@@ -26,7 +26,8 @@ type backupsSuite struct {
 	testing.JujuConnSuite
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	api        *backupsAPI.APIv2
+	api        *backupsAPI.APIv4
+	apiv2      *backupsAPI.APIv2
 	meta       *backups.Metadata
 	machineTag names.MachineTag
 }
@@ -63,7 +64,9 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 		controllerNodesF: func() ([]state.ControllerNode, error) { return nil, nil },
 		machineF:         func(id string) (backupsAPI.Machine, error) { return &testMachine{}, nil },
 	}
-	s.api, err = backupsAPI.NewAPIv2(shim, s.resources, s.authorizer)
+	s.api, err = backupsAPI.NewAPIv4(shim, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.apiv2, err = backupsAPI.NewAPIv2(shim, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -18,28 +18,66 @@ var waitUntilReady = replicaset.WaitUntilReady
 // of its state.  It returns the metadata for that backup.
 //
 // NOTE(hml) this provides backwards compatibility for facade version 1.
-func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	args.KeepCopy = true
-	args.NoDownload = true
-
-	apiv2 := APIv2{a}
-	result, err := apiv2.Create(args)
+func (a *API) Create(args params.BackupsCreateArgsV2) (params.BackupsMetadataResult, error) {
+	result, err := a.create(params.BackupsCreateArgs{
+		Notes:      args.Notes,
+		KeepCopy:   true,
+		NoDownload: true,
+	})
 	if err != nil {
 		return result, errors.Trace(err)
 	}
 	return result, nil
 }
 
-func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	backupsMethods, closer := newBackups(a.backend)
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup.
+func (a *APIv2) Create(args params.BackupsCreateArgsV2) (params.BackupsMetadataResult, error) {
+	return a.create(params.BackupsCreateArgs{
+		Notes:      args.Notes,
+		KeepCopy:   args.KeepCopy,
+		NoDownload: args.NoDownload,
+	})
+}
+
+// Create is the API method that requests juju to create a new backup
+// of its state, optionally in a backup destination.  It returns the
+// metadata for that backup.
+func (a *APIv3) Create(args params.BackupsCreateArgsV3) (params.BackupsMetadataResult, error) {
+	return a.create(params.BackupsCreateArgs{
+		Notes:       args.Notes,
+		KeepCopy:    args.KeepCopy,
+		NoDownload:  args.NoDownload,
+		Destination: args.Destination,
+	})
+}
+
+// Create is the API method that requests juju to create a new full or
+// incremental backup of its state, optionally in a backup destination.
+// It returns the metadata for that backup.
+func (a *APIv4) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	return a.create(args)
+}
+
+func (a *API) create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	result := params.BackupsMetadataResult{}
+	backupsMethods, closer, err := a.openBackups(args.Destination)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	defer closer.Close()
 
+	// There's no point creating a backup in a destination
+	// without keeping it there.
+	if args.Destination != "" {
+		args.KeepCopy = true
+	}
+
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
 
-	result := params.BackupsMetadataResult{}
 	// Don't go if HA isn't ready.
-	err := waitUntilReady(session, 60)
+	err = waitUntilReady(session, 60)
 	if err != nil {
 		return result, errors.Annotatef(err, "HA not ready; try again later")
 	}
@@ -87,6 +125,18 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 	}
 	meta.Controller.HANodes = int64(len(nodes))
 
+	if args.Incremental {
+		all, err := backupsMethods.List()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		base, err := backups.LatestFullBackup(all)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		meta.Base = base.ID()
+	}
+
 	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
 	if err != nil {
 		return result, errors.Trace(err)
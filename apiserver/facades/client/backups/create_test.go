@@ -4,12 +4,15 @@
 package backups_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -70,3 +73,42 @@ func (s *backupsSuite) TestCreateController(c *gc.C) {
 	expected := backups.CreateResult(s.meta, "test-filename")
 	c.Check(result, gc.DeepEquals, expected)
 }
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.meta.SetID("full-backup")
+	started := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	s.meta.Oplog = statebackups.OplogRange{Start: started, End: started.Add(time.Minute)}
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{Incremental: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List", "Create"})
+	c.Check(fake.BaseArg, gc.Equals, "full-backup")
+}
+
+func (s *backupsSuite) TestCreateIncrementalNoFullBackup(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{Incremental: true})
+	c.Check(err, gc.ErrorMatches, "full backup to base an incremental backup on not found")
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List"})
+}
+
+func (s *backupsSuite) TestCreateV2(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.apiv2.Create(params.BackupsCreateArgsV2{KeepCopy: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Create"})
+	c.Check(fake.KeepCopy, jc.IsTrue)
+	c.Check(fake.NoDownload, jc.IsFalse)
+}
@@ -10,8 +10,21 @@ import (
 )
 
 // Info provides the implementation of the API method.
-func (a *API) Info(args params.BackupsInfoArgs) (params.BackupsMetadataResult, error) {
-	backups, closer := newBackups(a.backend)
+func (a *API) Info(args params.BackupsInfoArgsV2) (params.BackupsMetadataResult, error) {
+	return a.info(params.BackupsInfoArgs{ID: args.ID})
+}
+
+// Info provides the implementation of the API method, for a backup
+// which may be held in a backup destination.
+func (a *APIv3) Info(args params.BackupsInfoArgs) (params.BackupsMetadataResult, error) {
+	return a.info(args)
+}
+
+func (a *API) info(args params.BackupsInfoArgs) (params.BackupsMetadataResult, error) {
+	backups, closer, err := a.openBackups(args.Destination)
+	if err != nil {
+		return params.BackupsMetadataResult{}, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	meta, file, err := backups.Get(args.ID)
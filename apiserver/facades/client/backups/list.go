@@ -10,10 +10,23 @@ import (
 )
 
 // List provides the implementation of the API method.
-func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
+func (a *API) List(args params.BackupsListArgsV2) (params.BackupsListResult, error) {
+	return a.list(params.BackupsListArgs{})
+}
+
+// List provides the implementation of the API method, listing the
+// backups held in a backup destination if one is given.
+func (a *APIv3) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
+	return a.list(args)
+}
+
+func (a *API) list(args params.BackupsListArgs) (params.BackupsListResult, error) {
 	var result params.BackupsListResult
 
-	backups, closer := newBackups(a.backend)
+	backups, closer, err := a.openBackups(args.Destination)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	metaList, err := backups.List()
@@ -5,13 +5,17 @@ package backups_test
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestListOkay(c *gc.C) {
@@ -36,3 +40,52 @@ func (s *backupsSuite) TestListError(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestListDestination(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	destFake := backupstesting.FakeBackups{
+		MetaList: []*statebackups.Metadata{s.meta},
+	}
+	var destination string
+	s.PatchValue(backups.NewDestinationBackups,
+		func(_ backups.Backend, dest string) (statebackups.Backups, io.Closer, error) {
+			destination = dest
+			return &destFake, ioutil.NopCloser(nil), nil
+		},
+	)
+	args := params.BackupsListArgs{Destination: "file:///srv/backups"}
+	result, err := s.api.List(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(destination, gc.Equals, "file:///srv/backups")
+	c.Check(fake.Calls, gc.HasLen, 0)
+	c.Check(destFake.Calls, jc.DeepEquals, []string{"List"})
+	c.Check(result.List, gc.HasLen, 1)
+}
+
+func (s *backupsSuite) TestListDestinationError(c *gc.C) {
+	s.PatchValue(backups.NewDestinationBackups,
+		func(backups.Backend, string) (statebackups.Backups, io.Closer, error) {
+			return nil, nil, errors.NotSupportedf(`backup destination scheme "ftp"`)
+		},
+	)
+	args := params.BackupsListArgs{Destination: "ftp://example.com"}
+	_, err := s.api.List(args)
+
+	c.Check(err, gc.ErrorMatches, `backup destination scheme "ftp" not supported`)
+}
+
+func (s *backupsSuite) TestListV2(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	s.PatchValue(backups.NewDestinationBackups,
+		func(backups.Backend, string) (statebackups.Backups, io.Closer, error) {
+			c.Fatalf("unexpected backup destination")
+			return nil, nil, nil
+		},
+	)
+	result, err := s.apiv2.List(params.BackupsListArgsV2{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List"})
+	c.Check(result.List, gc.HasLen, 1)
+}
@@ -4,13 +4,28 @@
 package backups
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 )
 
 // Remove deletes the backups defined by ID from the database.
-func (a *APIv2) Remove(args params.BackupsRemoveArgs) (params.ErrorResults, error) {
-	backups, closer := newBackups(a.backend)
+func (a *APIv2) Remove(args params.BackupsRemoveArgsV2) (params.ErrorResults, error) {
+	return a.remove(params.BackupsRemoveArgs{IDs: args.IDs})
+}
+
+// Remove deletes the backups defined by ID from the database, or from
+// a backup destination if one is given.
+func (a *APIv3) Remove(args params.BackupsRemoveArgs) (params.ErrorResults, error) {
+	return a.remove(args)
+}
+
+func (a *API) remove(args params.BackupsRemoveArgs) (params.ErrorResults, error) {
+	backups, closer, err := a.openBackups(args.Destination)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
 	defer closer.Close()
 	results := make([]params.ErrorResult, len(args.IDs))
 	for i, id := range args.IDs {
@@ -20,11 +20,34 @@ import (
 var bootstrapNode = names.NewMachineTag("0")
 
 // Restore implements the server side of Backups.Restore.
-func (a *API) Restore(p params.RestoreArgs) error {
+func (a *API) Restore(p params.RestoreArgsV2) error {
+	return a.restore(params.RestoreArgs{BackupId: p.BackupId})
+}
+
+// Restore implements the server side of Backups.Restore, restoring a
+// backup which may be held in a backup destination.
+func (a *APIv3) Restore(p params.RestoreArgsV3) error {
+	return a.restore(params.RestoreArgs{
+		BackupId:    p.BackupId,
+		Destination: p.Destination,
+	})
+}
+
+// Restore implements the server side of Backups.Restore, restoring a
+// backup which may be held in a backup destination, up to an optional
+// point in time.
+func (a *APIv4) Restore(p params.RestoreArgs) error {
+	return a.restore(p)
+}
+
+func (a *API) restore(p params.RestoreArgs) error {
 	logger.Infof("Starting server side restore")
 
 	// Get hold of a backup file Reader
-	backup, closer := newBackups(a.backend)
+	backup, closer, err := a.openBackups(p.Destination)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer closer.Close()
 
 	// Obtain the address of current machine, where we will be performing restore.
@@ -67,6 +90,9 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
 	}
+	if p.Until != nil {
+		restoreArgs.Until = *p.Until
+	}
 
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
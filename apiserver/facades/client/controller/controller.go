@@ -7,6 +7,7 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -56,10 +57,24 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv10 provides the v10 Controller API. The only difference
+// between this and v11 is that v10 doesn't have the DryRunMigration
+// method.
+type ControllerAPIv10 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the BackupScheduleStatus
+// method.
+type ControllerAPIv9 struct {
+	*ControllerAPIv10
+}
+
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -94,10 +109,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv11
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv11 creates a new ControllerAPIv11.
+func NewControllerAPIv11(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -119,6 +134,24 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPIv10, error) {
+	v11, err := NewControllerAPIv11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv10{v11}, nil
+}
+
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
 // NewControllerAPIv8 creates a new ControllerAPIv8.
 func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
 	v9, err := NewControllerAPIv9(ctx)
@@ -308,6 +341,35 @@ func (c *ControllerAPI) MongoVersion() (params.StringResult, error) {
 	return result, nil
 }
 
+// BackupScheduleStatus isn't on the v9 API.
+func (c *ControllerAPIv9) BackupScheduleStatus(_, _ struct{}) {}
+
+// BackupScheduleStatus returns the outcome of the controller's most
+// recent scheduled backups. The result is empty if no scheduled backup
+// has been attempted.
+func (c *ControllerAPI) BackupScheduleStatus() (params.BackupScheduleStatusResult, error) {
+	result := params.BackupScheduleStatusResult{}
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	status, err := c.state.BackupScheduleStatus()
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Result = &params.BackupScheduleStatus{
+		LastAttempt:  status.LastAttempt,
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	if !status.LastSuccess.IsZero() {
+		lastSuccess := status.LastSuccess
+		result.Result.LastSuccess = &lastSuccess
+	}
+	return result, nil
+}
+
 // AllModels allows controller administrators to get the list of all the
 // models in the controller.
 func (c *ControllerAPI) AllModels() (params.UserModelList, error) {
@@ -600,41 +662,103 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationSpec(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence, spec.Offline); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+		Offline:     spec.Offline,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// DryRunMigration isn't on the v10 API.
+func (c *ControllerAPIv10) DryRunMigration(_, _ struct{}) {}
+
+// DryRunMigration checks whether one or more models could be migrated
+// to other controllers, without starting any migration. For each model
+// every problem found on the source and target controllers which would
+// prevent the migration is reported.
+func (c *ControllerAPI) DryRunMigration(reqArgs params.InitiateMigrationArgs) (
+	params.DryRunMigrationResults, error,
+) {
+	out := params.DryRunMigrationResults{
+		Results: make([]params.DryRunMigrationResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		sourceProblems, targetProblems, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.SourceProblems = sourceProblems
+			result.TargetProblems = targetProblems
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) ([]string, []string, error) {
+	hostedState, targetInfo, err := c.migrationSpec(spec)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	defer hostedState.Release()
+	return runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+}
+
+// migrationSpec returns the state of the model to be migrated and the
+// details of the target controller described by the spec. The caller
+// is responsible for releasing the returned state.
+func (c *ControllerAPI) migrationSpec(spec params.MigrationSpec) (*state.PooledState, coremigration.TargetInfo, error) {
+	var targetInfo coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, targetInfo, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, targetInfo, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, targetInfo, errors.NotFoundf("model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, targetInfo, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, targetInfo, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, targetInfo, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	targetInfo = coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -644,20 +768,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:       macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, targetInfo, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -736,8 +851,10 @@ func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
-// retrieved from the target controller.
-var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) error {
+// retrieved from the target controller. The target controller isn't
+// contacted for an offline migration, as it already holds the imported
+// model and may not be reachable from this controller.
+var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence, offline bool) error {
 	// Check model and source controller.
 	backend, err := migration.PrecheckShim(st, ctlrSt)
 	if err != nil {
@@ -748,6 +865,14 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	if err := migration.SourcePrecheck(backend, modelPresence, controllerPresence); err != nil {
 		return errors.Annotate(err, "source prechecks failed")
 	}
+	if offline {
+		// The agents need the target's CA certificate to connect to
+		// it, and it can't be fetched from here.
+		if targetInfo.CACert == "" {
+			return errors.New("offline migration requires the target controller's CA certificate")
+		}
+		return nil
+	}
 
 	// Check target controller.
 	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
@@ -782,6 +907,73 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationDryRun runs every precheck on the migration, and has the
+// target controller check that it could import the model, returning
+// all of the problems found on the source and target controllers which
+// would prevent the migration.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) ([]string, []string, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	sourceProblems, err := migration.SourcePrecheckReport(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "source prechecks failed")
+	}
+	serialized, err := migration.ExportModel(st)
+	if err != nil {
+		sourceProblems = append(sourceProblems, fmt.Sprintf("model cannot be exported: %v", err))
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dstUserList, err := getTargetControllerUsers(conn)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var targetProblems []string
+	if err := srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		targetProblems = append(targetProblems, err.Error())
+	}
+	client := migrationtarget.NewClient(conn)
+	if targetInfo.CACert == "" {
+		if _, err := client.CACert(); params.IsCodeNotImplemented(err) {
+			// An earlier version of the controller, which we can't
+			// migrate to.
+			return sourceProblems, append(targetProblems, "controller API version is too old"), nil
+		} else if err != nil {
+			return nil, nil, errors.Annotatef(err, "cannot retrieve CA certificate")
+		}
+	}
+	var problems []string
+	if serialized != nil {
+		problems, err = client.ValidateMigration(modelInfo, serialized)
+	}
+	if serialized == nil || errors.IsNotSupported(err) {
+		// Without a model description, or a target controller which
+		// can check one, only the target prechecks can be run, and
+		// they stop at the first problem found.
+		problems = nil
+		if err := client.Prechecks(modelInfo); err != nil {
+			problems = []string{err.Error()}
+		}
+	} else if err != nil {
+		return nil, nil, errors.Annotate(err, "target prechecks failed")
+	}
+	return sourceProblems, append(targetProblems, problems...), nil
+}
+
 // userList encapsulates information about the users who have been granted
 // access to a model or the users known to a particular controller.
 type userList struct {
@@ -496,6 +496,41 @@ func (s *controllerSuite) TestInitiateMigration(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) TestInitiateMigrationOffline(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	// The model has been exported.
+	err = m.SetMigrationMode(state.MigrationModeExporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetPrecheckResult(s, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			Offline: true,
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Id(), gc.Equals, out.Results[0].MigrationId)
+	c.Check(mig.Offline(), jc.IsTrue)
+}
+
 func (s *controllerSuite) TestInitiateMigrationSpecError(c *gc.C) {
 	// Create a hosted model to migrate.
 	st := s.Factory.MakeModel(c, nil)
@@ -614,6 +649,94 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestDryRunMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetDryRunResult(s,
+		[]string{"unit foo/0 not idle or executing (failed)"},
+		[]string{"upgrade in progress"},
+		nil,
+	)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0], jc.DeepEquals, params.DryRunMigrationResult{
+		ModelTag:       m.ModelTag().String(),
+		SourceProblems: []string{"unit foo/0 not idle or executing (failed)"},
+		TargetProblems: []string{"upgrade in progress"},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// No migration is started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestDryRunMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetDryRunResult(s, nil, nil, errors.New("boom"))
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Check(out.Results[0].SourceProblems, gc.HasLen, 0)
+	c.Check(out.Results[0].TargetProblems, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestDryRunMigrationRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endPoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endPoint.DryRunMigration(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -1032,6 +1155,30 @@ func (s *controllerSuite) TestMongoVersion(c *gc.C) {
 	c.Assert(result.Result, gc.Matches, "^([0-9]{1,}).([0-9]{1,}).([0-9]{1,})$")
 }
 
+func (s *controllerSuite) TestBackupScheduleStatus(c *gc.C) {
+	result, err := s.controller.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.IsNil)
+
+	lastAttempt := time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC)
+	err = s.State.SetBackupScheduleStatus(state.BackupScheduleStatus{
+		LastAttempt:  lastAttempt,
+		LastBackupID: "20200501-030000.deadbeef",
+		LastSuccess:  lastAttempt.AddDate(0, 0, -1),
+		LastError:    "HA not ready",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.controller.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Check(result.Result.LastAttempt.Equal(lastAttempt), jc.IsTrue)
+	c.Assert(result.Result.LastSuccess, gc.NotNil)
+	c.Check(result.Result.LastSuccess.Equal(lastAttempt.AddDate(0, 0, -1)), jc.IsTrue)
+	c.Check(result.Result.LastBackupID, gc.Equals, "20200501-030000.deadbeef")
+	c.Check(result.Result.LastError, gc.Equals, "HA not ready")
+}
+
 func (s *controllerSuite) TestIdentityProviderURL(c *gc.C) {
 	// Preserve default controller config as we will be mutating it just
 	// for this test
@@ -14,7 +14,13 @@ type patcher interface {
 }
 
 func SetPrecheckResult(p patcher, err error) {
-	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) error {
+	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence, bool) error {
 		return err
 	})
 }
+
+func SetDryRunResult(p patcher, sourceProblems, targetProblems []string, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) ([]string, []string, error) {
+		return sourceProblems, targetProblems, err
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -181,7 +182,7 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 }
 
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV7{s.modelmanager}
+	api := &modelmanager.ModelManagerAPIV7{&modelmanager.ModelManagerAPIV8{s.modelmanager}}
 
 	results, err := api.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -420,6 +421,74 @@ func (s *modelInfoSuite) TestFailedMigration(c *gc.C) {
 	c.Assert(*migrationResult.End, gc.Equals, end)
 }
 
+func (s *modelInfoSuite) TestMigrationProgress(c *gc.C) {
+	start := time.Now().Add(-20 * time.Minute)
+	imported := start.Add(time.Minute)
+	s.st.migration = &mockMigration{
+		status: "uploading model binaries into target controller",
+		start:  start,
+		phase:  migration.IMPORT,
+		progress: []migration.PhaseProgress{{
+			Phase:   migration.QUIESCE,
+			Started: start,
+			Ended:   imported,
+		}, {
+			Phase:   migration.IMPORT,
+			Started: imported,
+			Sent: migration.TransferVolume{
+				Model:  100,
+				Charms: 2000,
+			},
+		}},
+	}
+
+	results, err := s.modelmanager.MigrationProgress(params.Entities{
+		Entities: []params.Entity{{coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, &params.MigrationProgress{
+		MigrationId:           coretesting.ModelTag.Id() + ":1",
+		Attempt:               1,
+		Phase:                 "IMPORT",
+		Status:                "uploading model binaries into target controller",
+		TargetControllerTag:   "controller-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		TargetControllerAlias: "target",
+		Start:                 start,
+		Phases: []params.MigrationPhaseProgress{{
+			Phase:   "QUIESCE",
+			Started: start,
+			Ended:   &imported,
+		}, {
+			Phase:   "IMPORT",
+			Started: imported,
+			Sent: params.MigrationTransferVolume{
+				Model:  100,
+				Charms: 2000,
+			},
+		}},
+	})
+}
+
+func (s *modelInfoSuite) TestMigrationProgressNoMigration(c *gc.C) {
+	results, err := s.modelmanager.MigrationProgress(params.Entities{
+		Entities: []params.Entity{{coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `migration of model ".*" not found`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *modelInfoSuite) TestMigrationProgressNoAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("nemo@local"))
+	results, err := s.modelmanager.MigrationProgress(params.Entities{
+		Entities: []params.Entity{{coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelInfoSuite) TestNoMigration(c *gc.C) {
 	results, err := s.modelmanager.ModelInfo(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
@@ -1215,9 +1284,34 @@ type mockModelUser struct {
 type mockMigration struct {
 	state.ModelMigration
 
-	status string
-	start  time.Time
-	end    time.Time
+	status   string
+	start    time.Time
+	end      time.Time
+	phase    migration.Phase
+	progress []migration.PhaseProgress
+}
+
+func (m *mockMigration) Id() string {
+	return coretesting.ModelTag.Id() + ":1"
+}
+
+func (m *mockMigration) Attempt() int {
+	return 1
+}
+
+func (m *mockMigration) Phase() (migration.Phase, error) {
+	return m.phase, nil
+}
+
+func (m *mockMigration) TargetInfo() (*migration.TargetInfo, error) {
+	return &migration.TargetInfo{
+		ControllerTag:   names.NewControllerTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+		ControllerAlias: "target",
+	}, nil
+}
+
+func (m *mockMigration) PhaseProgress() []migration.PhaseProgress {
+	return m.progress
 }
 
 func (m *mockMigration) StatusMessage() string {
@@ -40,6 +40,13 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV9 defines the methods on the version 9 facade for the
+// modelmanager API endpoint.
+type ModelManagerV9 interface {
+	ModelManagerV8
+	MigrationProgress(args params.Entities) (params.MigrationProgressResults, error)
+}
+
 // ModelManagerV8 defines the methods on the version 8 facade for the
 // modelmanager API endpoint.
 type ModelManagerV8 interface {
@@ -129,10 +136,16 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV8 provides a way to wrap the different calls between
+// version 9 and version 8 of the model manager API
+type ModelManagerAPIV8 struct {
+	*ModelManagerAPI
+}
+
 // ModelManagerAPIV7 provides a way to wrap the different calls between
 // version 8 and version 7 of the model manager API
 type ModelManagerAPIV7 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV8
 }
 
 // ModelManagerAPIV6 provides a way to wrap the different calls between
@@ -166,7 +179,8 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV9 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV8 = (*ModelManagerAPIV8)(nil)
 	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
 	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
 	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
@@ -175,8 +189,8 @@ var (
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV8 is used for API registration.
-func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -213,6 +227,15 @@ func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPIV8, error) {
+	v9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV8{v9}, nil
+}
+
 // NewFacadeV7 is used for API registration.
 func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
 	v8, err := NewFacadeV8(ctx)
@@ -1240,6 +1263,99 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 	return info, nil
 }
 
+// MigrationProgress returns the progress of the latest migration of
+// each of the given models through its phases, including when each
+// phase was entered and left and the data sent to the target
+// controller. Only model admins and controller superusers can see it.
+func (m *ModelManagerAPI) MigrationProgress(args params.Entities) (params.MigrationProgressResults, error) {
+	results := params.MigrationProgressResults{
+		Results: make([]params.MigrationProgressResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		progress, err := m.migrationProgress(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = progress
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) migrationProgress(arg params.Entity) (*params.MigrationProgress, error) {
+	tag, err := names.ParseModelTag(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !m.isAdmin {
+		modelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !modelAdmin {
+			return nil, common.ErrPerm
+		}
+	}
+
+	st, release, err := m.state.GetBackend(tag.Id())
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	mig, err := st.LatestMigration()
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("migration of model %q", tag.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	progress := &params.MigrationProgress{
+		MigrationId:           mig.Id(),
+		Attempt:               mig.Attempt(),
+		Phase:                 phase.String(),
+		Status:                mig.StatusMessage(),
+		TargetControllerTag:   target.ControllerTag.String(),
+		TargetControllerAlias: target.ControllerAlias,
+		Start:                 mig.StartTime(),
+	}
+	if end := mig.EndTime(); !end.IsZero() {
+		progress.End = &end
+	}
+	for _, p := range mig.PhaseProgress() {
+		phaseProgress := params.MigrationPhaseProgress{
+			Phase:   p.Phase.String(),
+			Started: p.Started,
+			Sent: params.MigrationTransferVolume{
+				Model:     p.Sent.Model,
+				Charms:    p.Sent.Charms,
+				Tools:     p.Sent.Tools,
+				Resources: p.Sent.Resources,
+			},
+		}
+		if !p.Ended.IsZero() {
+			ended := p.Ended
+			phaseProgress.Ended = &ended
+		}
+		progress.Phases = append(progress.Phases, phaseProgress)
+	}
+	return progress, nil
+}
+
+// MigrationProgress is not available on older versions of the model
+// manager API.
+func (m *ModelManagerAPIV8) MigrationProgress(_, _ struct{}) {}
+
 // ModifyModelAccess changes the model access granted to users.
 func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest) (result params.ErrorResults, _ error) {
 	result = params.ErrorResults{
@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the API used by clients to inspect the
+// secrets created by charms.
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+)
+
+// SecretsAPI implements the Secrets facade.
+type SecretsAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewSecretsAPI is used for API registration.
+func NewSecretsAPI(ctx facade.Context) (*SecretsAPI, error) {
+	return newSecretsAPI(&backend{ctx.State()}, ctx.Auth())
+}
+
+func newSecretsAPI(backend Backend, authorizer facade.Authorizer) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &SecretsAPI{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (s *SecretsAPI) checkIsModelAdmin() error {
+	isAdmin, err := s.authorizer.HasPermission(permission.AdminAccess, s.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the secrets in the model, along with their
+// values if requested. Only model admins may list secrets.
+func (s *SecretsAPI) ListSecrets(args params.ListSecretsArgs) (params.ListSecretResults, error) {
+	result := params.ListSecretResults{}
+	if err := s.checkIsModelAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	secrets, err := s.backend.AllSecrets()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ListSecretResult, len(secrets))
+	for i, secret := range secrets {
+		details, err := secretDetails(secret)
+		if err != nil {
+			return params.ListSecretResults{}, errors.Trace(err)
+		}
+		if args.ShowValues {
+			value, err := s.backend.SecretValue(secret.ID())
+			if err != nil {
+				details.ValueError = common.ServerError(err)
+			} else {
+				details.Value = value
+			}
+		}
+		result.Results[i] = details
+	}
+	return result, nil
+}
+
+func secretDetails(secret Secret) (params.ListSecretResult, error) {
+	owner, err := secret.Owner()
+	if err != nil {
+		return params.ListSecretResult{}, errors.Trace(err)
+	}
+	consumers, err := secret.Consumers()
+	if err != nil {
+		return params.ListSecretResult{}, errors.Trace(err)
+	}
+	result := params.ListSecretResult{
+		ID:          secret.ID(),
+		Owner:       owner.String(),
+		Description: secret.Description(),
+		Revision:    secret.Revision(),
+		CreateTime:  secret.CreateTime(),
+		UpdateTime:  secret.UpdateTime(),
+	}
+	if expire := secret.ExpireTime(); !expire.IsZero() {
+		result.ExpireTime = &expire
+	}
+	for _, consumer := range consumers {
+		result.Consumers = append(result.Consumers, consumer.String())
+	}
+	return result, nil
+}
+
+// Secret describes the secret methods used by the Secrets facade.
+type Secret interface {
+	ID() string
+	Owner() (names.Tag, error)
+	Description() string
+	Revision() int
+	CreateTime() time.Time
+	UpdateTime() time.Time
+	ExpireTime() time.Time
+	Consumers() ([]names.Tag, error)
+}
+
+// Backend defines the state methods used by the Secrets facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllSecrets() ([]Secret, error)
+	SecretValue(id string) (map[string]string, error)
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+
+	authorizer *apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	facade     *secrets.SecretsAPI
+	created    time.Time
+	expire     time.Time
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.created = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	s.expire = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		secrets: []secrets.Secret{&mockSecret{
+			id:          "one",
+			owner:       names.NewApplicationTag("mariadb"),
+			description: "password",
+			revision:    2,
+			created:     s.created,
+			updated:     s.created.Add(time.Hour),
+			expire:      s.expire,
+			consumers:   []names.Tag{names.NewApplicationTag("wordpress")},
+		}, &mockSecret{
+			id:      "two",
+			owner:   names.NewApplicationTag("vault"),
+			created: s.created,
+			updated: s.created,
+		}},
+	}
+	var err error
+	s.facade, err = secrets.NewTestAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mariadb/0")
+	_, err := secrets.NewTestAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SecretsSuite) TestListSecretsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	facade, err := secrets.NewTestAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	results, err := s.facade.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.ListSecretResult{{
+			ID:          "one",
+			Owner:       "application-mariadb",
+			Description: "password",
+			Revision:    2,
+			CreateTime:  s.created,
+			UpdateTime:  s.created.Add(time.Hour),
+			ExpireTime:  &s.expire,
+			Consumers:   []string{"application-wordpress"},
+		}, {
+			ID:         "two",
+			Owner:      "application-vault",
+			CreateTime: s.created,
+			UpdateTime: s.created,
+		}},
+	})
+	s.backend.CheckCallNames(c, "AllSecrets")
+}
+
+func (s *SecretsSuite) TestListSecretsShowValues(c *gc.C) {
+	results, err := s.facade.ListSecrets(params.ListSecretsArgs{ShowValues: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+	c.Check(results.Results[0].ValueError, gc.IsNil)
+	c.Check(results.Results[1].Value, gc.IsNil)
+	c.Check(results.Results[1].ValueError, jc.DeepEquals, &params.Error{Message: `secret "two" expired`})
+	s.backend.CheckCallNames(c, "AllSecrets", "SecretValue", "SecretValue")
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	secrets []secrets.Secret
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AllSecrets() ([]secrets.Secret, error) {
+	b.MethodCall(b, "AllSecrets")
+	return b.secrets, b.NextErr()
+}
+
+func (b *mockBackend) SecretValue(id string) (map[string]string, error) {
+	b.MethodCall(b, "SecretValue", id)
+	if id == "two" {
+		return nil, errors.Errorf("secret %q expired", id)
+	}
+	return map[string]string{"password": "s3cret"}, nil
+}
+
+type mockSecret struct {
+	id          string
+	owner       names.Tag
+	description string
+	revision    int
+	created     time.Time
+	updated     time.Time
+	expire      time.Time
+	consumers   []names.Tag
+}
+
+func (s *mockSecret) ID() string                      { return s.id }
+func (s *mockSecret) Owner() (names.Tag, error)       { return s.owner, nil }
+func (s *mockSecret) Description() string             { return s.description }
+func (s *mockSecret) Revision() int                   { return s.revision }
+func (s *mockSecret) CreateTime() time.Time           { return s.created }
+func (s *mockSecret) UpdateTime() time.Time           { return s.updated }
+func (s *mockSecret) ExpireTime() time.Time           { return s.expire }
+func (s *mockSecret) Consumers() ([]names.Tag, error) { return s.consumers, nil }
@@ -0,0 +1,33 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+type backend struct {
+	*state.State
+}
+
+// ModelTag is part of the Backend interface.
+func (b *backend) ModelTag() names.ModelTag {
+	return names.NewModelTag(b.ModelUUID())
+}
+
+// AllSecrets is part of the Backend interface.
+func (b *backend) AllSecrets() ([]Secret, error) {
+	secrets, err := b.State.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Secret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = secret
+	}
+	return result, nil
+}
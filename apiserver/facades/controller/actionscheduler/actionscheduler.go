@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler implements the API used by the action
+// scheduler worker.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods used by the ActionScheduler facade.
+type Backend interface {
+	AllActionSchedules() ([]state.ActionSchedule, error)
+	RunActionSchedule(id string, scheduled time.Time) (state.ActionScheduleRun, error)
+	RecordActionScheduleMissed(id string, scheduled time.Time, count int) error
+	WatchActionSchedules() state.NotifyWatcher
+}
+
+// API implements the API used by the action scheduler worker.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewAPI creates a new instance of the ActionScheduler API.
+func NewAPI(ctx facade.Context) (*API, error) {
+	m, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIForBackend(m, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPIForBackend creates a new instance of the ActionScheduler API
+// using the given backend.
+func NewAPIForBackend(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that triggers whenever
+// an action schedule in the model is added, removed or run.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	watch := api.backend.WatchActionSchedules()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (api *API) ActionSchedules() (params.ActionSchedulesResult, error) {
+	schedules, err := api.backend.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedulesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.ActionSchedulesResult{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = common.MakeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RecordRuns enqueues the actions for each scheduled run, or records
+// the run as missed.
+func (api *API) RecordRuns(args params.ActionScheduleRunArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Runs)),
+	}
+	for i, run := range args.Runs {
+		var err error
+		if run.Missed > 0 {
+			err = api.backend.RecordActionScheduleMissed(run.Id, run.Scheduled, run.Missed)
+		} else {
+			_, err = api.backend.RunActionSchedule(run.Id, run.Scheduled)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
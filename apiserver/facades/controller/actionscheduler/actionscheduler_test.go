@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type actionSchedulerSuite struct {
+	coretesting.BaseSuite
+
+	backend   *mockBackend
+	resources *common.Resources
+	api       *actionscheduler.API
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	var err error
+	s.api, err = actionscheduler.NewAPIForBackend(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Controller: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *actionSchedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := actionscheduler.NewAPIForBackend(s.backend, s.resources, apiservertesting.FakeAuthorizer{})
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionSchedulerSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
+	s.backend.CheckCallNames(c, "WatchActionSchedules")
+}
+
+func (s *actionSchedulerSuite) TestActionSchedules(c *gc.C) {
+	created := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	s.backend.schedules = []state.ActionSchedule{{
+		Id:         "1",
+		Schedule:   "@daily",
+		Receivers:  []string{"mysql/leader"},
+		ActionName: "backup",
+		Created:    created,
+		CreatedBy:  "admin",
+		Runs: []state.ActionScheduleRun{{
+			Scheduled:   created.Add(24 * time.Hour),
+			Recorded:    created.Add(24 * time.Hour),
+			Status:      state.ActionScheduleRunEnqueued,
+			OperationId: "7",
+		}},
+	}}
+	result, err := s.api.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedulesResult{
+		Schedules: []params.ActionSchedule{{
+			Id:        "1",
+			Schedule:  "@daily",
+			Receivers: []string{"mysql/leader"},
+			Name:      "backup",
+			Created:   created,
+			CreatedBy: "admin",
+			Runs: []params.ActionScheduleRun{{
+				Scheduled:   created.Add(24 * time.Hour),
+				Recorded:    created.Add(24 * time.Hour),
+				Status:      "enqueued",
+				OperationId: "7",
+			}},
+		}},
+	})
+}
+
+func (s *actionSchedulerSuite) TestActionSchedulesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	result, err := s.api.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSchedulerSuite) TestRecordRuns(c *gc.C) {
+	scheduled := time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC)
+	s.backend.SetErrors(nil, errors.NotFoundf("action schedule %q", "3"))
+	result, err := s.api.RecordRuns(params.ActionScheduleRunArgs{
+		Runs: []params.ActionScheduleRunArg{
+			{Id: "1", Scheduled: scheduled},
+			{Id: "3", Scheduled: scheduled, Missed: 2},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `action schedule "3" not found`)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"RunActionSchedule", []interface{}{"1", scheduled}},
+		{"RecordActionScheduleMissed", []interface{}{"3", scheduled, 2}},
+	})
+}
+
+type mockBackend struct {
+	testing.Stub
+	schedules []state.ActionSchedule
+}
+
+func (b *mockBackend) AllActionSchedules() ([]state.ActionSchedule, error) {
+	b.MethodCall(b, "AllActionSchedules")
+	return b.schedules, b.NextErr()
+}
+
+func (b *mockBackend) RunActionSchedule(id string, scheduled time.Time) (state.ActionScheduleRun, error) {
+	b.MethodCall(b, "RunActionSchedule", id, scheduled)
+	return state.ActionScheduleRun{}, b.NextErr()
+}
+
+func (b *mockBackend) RecordActionScheduleMissed(id string, scheduled time.Time, count int) error {
+	b.MethodCall(b, "RecordActionScheduleMissed", id, scheduled, count)
+	return b.NextErr()
+}
+
+func (b *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	b.MethodCall(b, "WatchActionSchedules")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
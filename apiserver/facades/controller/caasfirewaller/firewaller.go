@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
+// Facade provides access to the CAASFirewaller v1 API facade.
 type Facade struct {
 	*common.LifeGetter
 	*common.AgentEntityWatcher
@@ -20,6 +22,17 @@ type Facade struct {
 	state     CAASFirewallerState
 }
 
+// FacadeV2 provides access to the CAASFirewaller v2 API facade.
+type FacadeV2 struct {
+	*Facade
+	*common.ModelWatcher
+}
+
+// FacadeV3 provides access to the CAASFirewaller v3 API facade.
+type FacadeV3 struct {
+	*FacadeV2
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -31,6 +44,24 @@ func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	)
 }
 
+// NewStateFacadeV2 provides the signature required for facade registration.
+func NewStateFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	return NewFacadeV2(
+		ctx.Resources(),
+		ctx.Auth(),
+		stateShim{ctx.State()},
+	)
+}
+
+// NewStateFacadeV3 provides the signature required for facade registration.
+func NewStateFacadeV3(ctx facade.Context) (*FacadeV3, error) {
+	return NewFacadeV3(
+		ctx.Resources(),
+		ctx.Auth(),
+		stateShim{ctx.State()},
+	)
+}
+
 // NewFacade returns a new CAAS firewaller Facade facade.
 func NewFacade(
 	resources facade.Resources,
@@ -58,6 +89,35 @@ func NewFacade(
 	}, nil
 }
 
+// NewFacadeV2 returns a new CAAS firewaller v2 facade.
+func NewFacadeV2(
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	st CAASFirewallerState,
+) (*FacadeV2, error) {
+	facadeV1, err := NewFacade(resources, authorizer, st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV2{
+		Facade:       facadeV1,
+		ModelWatcher: common.NewModelWatcher(st, resources, authorizer),
+	}, nil
+}
+
+// NewFacadeV3 returns a new CAAS firewaller v3 facade.
+func NewFacadeV3(
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	st CAASFirewallerState,
+) (*FacadeV3, error) {
+	facadeV2, err := NewFacadeV2(resources, authorizer, st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV3{FacadeV2: facadeV2}, nil
+}
+
 // WatchApplications starts a StringsWatcher to watch CAAS applications
 // deployed to this model.
 func (f *Facade) WatchApplications() (params.StringsWatchResult, error) {
@@ -123,3 +183,92 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	}
 	return app.ApplicationConfig()
 }
+
+// RelatedApplications returns the names of the applications in this model
+// which are related to each of the specified applications. Applications
+// related across models are not included.
+func (f *FacadeV2) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		related, err := f.relatedApplications(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+func (f *FacadeV2) relatedApplications(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		endpoints, err := rel.RelatedEndpoints(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range endpoints {
+			if ep.ApplicationName == tag.Id() || related.Contains(ep.ApplicationName) {
+				continue
+			}
+			// Remote applications have no workloads in this model.
+			_, err := f.state.Application(ep.ApplicationName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			related.Add(ep.ApplicationName)
+		}
+	}
+	return related.SortedValues(), nil
+}
+
+// WatchApplicationsConfig starts a NotifyWatcher for each of the
+// specified applications, which notifies of changes to the
+// application's config.
+func (f *FacadeV3) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (f *FacadeV3) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
@@ -4,6 +4,7 @@
 package caasfirewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -27,10 +28,12 @@ type CAASFirewallerSuite struct {
 	st                  *mockState
 	applicationsChanges chan []string
 	appExposedChanges   chan struct{}
+	appConfigChanges    chan struct{}
+	configChanges       chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	facade     *caasfirewaller.Facade
+	facade     *caasfirewaller.FacadeV3
 }
 
 func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
@@ -38,17 +41,25 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
+	s.appConfigChanges = make(chan struct{}, 1)
+	s.configChanges = make(chan struct{}, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
+	appConfigWatcher := statetesting.NewMockNotifyWatcher(s.appConfigChanges)
 	s.st = &mockState{
 		application: mockApplication{
-			life:    state.Alive,
-			watcher: appExposedWatcher,
+			life:          state.Alive,
+			watcher:       appExposedWatcher,
+			configWatcher: appConfigWatcher,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		appExposedWatcher:   appExposedWatcher,
+		modelConfig:         coretesting.ModelConfig(c),
+		configWatcher:       statetesting.NewMockNotifyWatcher(s.configChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, appConfigWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.configWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -56,7 +67,7 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 		Controller: true,
 	}
 
-	facade, err := caasfirewaller.NewFacade(s.resources, s.authorizer, s.st)
+	facade, err := caasfirewaller.NewFacadeV3(s.resources, s.authorizer, s.st)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -67,6 +78,10 @@ func (s *CAASFirewallerSuite) TestPermission(c *gc.C) {
 	}
 	_, err := caasfirewaller.NewFacade(s.resources, s.authorizer, s.st)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = caasfirewaller.NewFacadeV2(s.resources, s.authorizer, s.st)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = caasfirewaller.NewFacadeV3(s.resources, s.authorizer, s.st)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
 func (s *CAASFirewallerSuite) TestWatchApplications(c *gc.C) {
@@ -104,6 +119,29 @@ func (s *CAASFirewallerSuite) TestWatchApplication(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.appExposedWatcher)
 }
 
+func (s *CAASFirewallerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.appConfigChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+	s.st.CheckCallNames(c, "Application")
+	s.st.CheckCall(c, 0, "Application", "gitlab")
+}
+
 func (s *CAASFirewallerSuite) TestIsExposed(c *gc.C) {
 	s.st.application.exposed = true
 	results, err := s.facade.IsExposed(params.Entities{
@@ -159,3 +197,55 @@ func (s *CAASFirewallerSuite) TestApplicationConfig(c *gc.C) {
 	})
 	c.Assert(results.Results[0].Config, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 }
+
+func (s *CAASFirewallerSuite) TestModelConfig(c *gc.C) {
+	result, err := s.facade.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config, jc.DeepEquals, params.ModelConfig(s.st.modelConfig.AllAttrs()))
+}
+
+func (s *CAASFirewallerSuite) TestWatchForModelConfigChanges(c *gc.C) {
+	s.configChanges <- struct{}{}
+
+	result, err := s.facade.WatchForModelConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.configWatcher)
+}
+
+func (s *CAASFirewallerSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.relations = []caasfirewaller.Relation{
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "gitlab"}, {ApplicationName: "mysql"},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "gitlab"},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "remote-redis"}, {ApplicationName: "gitlab"},
+		}},
+	}
+	// gitlab and mysql are found, remote-redis is a remote application.
+	s.st.SetErrors(nil, nil, errors.NotFoundf("application remote-redis"))
+
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mysql"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.CheckCall(c, 0, "Application", "gitlab")
+	s.st.CheckCall(c, 1, "Application", "mysql")
+	s.st.CheckCall(c, 2, "Application", "remote-redis")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -18,6 +19,21 @@ type mockState struct {
 	application         mockApplication
 	applicationsWatcher *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	modelConfig         *config.Config
+	configWatcher       *statetesting.MockNotifyWatcher
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	st.MethodCall(st, "ModelConfig")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return st.modelConfig, nil
+}
+
+func (st *mockState) WatchForModelConfigChanges() state.NotifyWatcher {
+	st.MethodCall(st, "WatchForModelConfigChanges")
+	return st.configWatcher
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -43,9 +59,11 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life          state.Life
+	exposed       bool
+	watcher       state.NotifyWatcher
+	configWatcher state.NotifyWatcher
+	relations     []caasfirewaller.Relation
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -70,3 +88,31 @@ func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, err
 func (a *mockApplication) Watch() state.NotifyWatcher {
 	return a.watcher
 }
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
+func (a *mockApplication) Relations() ([]caasfirewaller.Relation, error) {
+	a.MethodCall(a, "Relations")
+	return a.relations, a.NextErr()
+}
+
+type mockRelation struct {
+	endpoints []state.Endpoint
+}
+
+func (r *mockRelation) RelatedEndpoints(applicationName string) ([]state.Endpoint, error) {
+	var related []state.Endpoint
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName != applicationName {
+			related = append(related, ep)
+		}
+	}
+	if len(related) == 0 {
+		// A peer relation.
+		return r.endpoints, nil
+	}
+	return related, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v3"
 
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -19,6 +20,8 @@ import (
 // migrationmaster facade.
 type Backend interface {
 	migration.StateExporter
+	ExportSecrets() ([]coremigration.SerializedModelSecret, error)
+	ExportActionSchedules() ([]coremigration.SerializedActionSchedule, error)
 
 	WatchForMigration() state.NotifyWatcher
 	LatestMigration() (state.ModelMigration, error)
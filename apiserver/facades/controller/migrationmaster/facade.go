@@ -33,13 +33,21 @@ type API struct {
 	presence        facade.Presence
 }
 
-type APIV1 struct {
+// APIV2 implements the v2 MigrationMaster API, which lacks
+// SetTransferVolume.
+type APIV2 struct {
 	*API
 }
 
-// NewMigrationMasterFacadeV2 exists to provide the required signature for API
+// APIV1 implements the v1 MigrationMaster API, which also lacks
+// ProcessRelations.
+type APIV1 struct {
+	*APIV2
+}
+
+// NewMigrationMasterFacadeV3 exists to provide the required signature for API
 // registration, converting st to backend.
-func NewMigrationMasterFacadeV2(ctx facade.Context) (*API, error) {
+func NewMigrationMasterFacadeV3(ctx facade.Context) (*API, error) {
 	controllerState := ctx.StatePool().SystemState()
 	precheckBackend, err := migration.PrecheckShim(ctx.State(), controllerState)
 	if err != nil {
@@ -55,6 +63,16 @@ func NewMigrationMasterFacadeV2(ctx facade.Context) (*API, error) {
 	)
 }
 
+// NewMigrationMasterFacadeV2 exists to provide the required signature for API
+// registration, converting st to backend.
+func NewMigrationMasterFacadeV2(ctx facade.Context) (*APIV2, error) {
+	v3, err := NewMigrationMasterFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{v3}, nil
+}
+
 // NewMigrationMasterFacade exists to provide the required signature for API
 // registration, converting st to backend.
 func NewMigrationMasterFacade(ctx facade.Context) (*APIV1, error) {
@@ -135,6 +153,7 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 				Password:      target.Password,
 				Macaroons:     string(macsJSON),
 			},
+			Offline: mig.Offline(),
 		},
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
@@ -222,6 +241,26 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// SetTransferVolume records the data sent to the target controller
+// while the active model migration has been in its current phase.
+func (api *API) SetTransferVolume(args params.MigrationTransferVolume) error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetTransferVolume(coremigration.TransferVolume{
+		Model:     args.Model,
+		Charms:    args.Charms,
+		Tools:     args.Tools,
+		Resources: args.Resources,
+	})
+	return errors.Annotate(err, "failed to set transfer volume")
+}
+
+// SetTransferVolume is masked on older versions of the migration
+// master API.
+func (api *APIV2) SetTransferVolume(_, _ struct{}) {}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -241,9 +280,67 @@ func (api *API) Export() (params.SerializedModel, error) {
 	if model.Type() == string(coremodel.IAAS) {
 		serialized.Tools = getUsedTools(model)
 	}
+	secrets, err := api.backend.ExportSecrets()
+	if err != nil {
+		return serialized, errors.Annotate(err, "exporting secrets")
+	}
+	serialized.Secrets = secretsToSerialized(secrets)
+	schedules, err := api.backend.ExportActionSchedules()
+	if err != nil {
+		return serialized, errors.Annotate(err, "exporting action schedules")
+	}
+	serialized.ActionSchedules = actionSchedulesToSerialized(schedules)
 	return serialized, nil
 }
 
+func secretsToSerialized(secrets []coremigration.SerializedModelSecret) []params.SerializedModelSecret {
+	var out []params.SerializedModelSecret
+	for _, secret := range secrets {
+		outSecret := params.SerializedModelSecret{
+			ID:          secret.ID,
+			Owner:       secret.Owner,
+			Description: secret.Description,
+			Revision:    secret.Revision,
+			CreateTime:  secret.CreateTime,
+			UpdateTime:  secret.UpdateTime,
+			Consumers:   secret.Consumers,
+			Value:       secret.Value,
+		}
+		if !secret.ExpireTime.IsZero() {
+			expire := secret.ExpireTime
+			outSecret.ExpireTime = &expire
+		}
+		out = append(out, outSecret)
+	}
+	return out
+}
+
+func actionSchedulesToSerialized(schedules []coremigration.SerializedActionSchedule) []params.SerializedActionSchedule {
+	var out []params.SerializedActionSchedule
+	for _, schedule := range schedules {
+		outSchedule := params.SerializedActionSchedule{
+			ID:         schedule.ID,
+			Schedule:   schedule.Schedule,
+			Receivers:  schedule.Receivers,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Created:    schedule.Created,
+			CreatedBy:  schedule.CreatedBy,
+		}
+		for _, run := range schedule.Runs {
+			outSchedule.Runs = append(outSchedule.Runs, params.SerializedActionScheduleRun{
+				Scheduled:   run.Scheduled,
+				Recorded:    run.Recorded,
+				Status:      run.Status,
+				OperationID: run.OperationID,
+				Message:     run.Message,
+			})
+		}
+		out = append(out, outSchedule)
+	}
+	return out
+}
+
 // ProcessRelations is masked on older versions of the migration master API
 func (api *APIV1) ProcessRelations(_, _ struct{}) {}
 
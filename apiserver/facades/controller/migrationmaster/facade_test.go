@@ -127,6 +127,7 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	exp.Id().Return("ID")
 	now := time.Now()
 	exp.PhaseChangedTime().Return(now)
+	exp.Offline().Return(true)
 
 	s.backend.EXPECT().LatestMigration().Return(mig, nil)
 
@@ -145,6 +146,7 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 				Password:      password,
 				Macaroons:     `[[{"l":"location","i":"id","s64":"qYAr8nQmJzPWKDppxigFtWaNv0dbzX7cJaligz98LLo"}]]`,
 			},
+			Offline: true,
 		},
 		MigrationId:      "ID",
 		Phase:            "IMPORT",
@@ -246,6 +248,42 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSetTransferVolume(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetTransferVolume(coremigration.TransferVolume{
+		Model:     1,
+		Charms:    2,
+		Tools:     3,
+		Resources: 4,
+	}).Return(nil)
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetTransferVolume(params.MigrationTransferVolume{
+		Model:     1,
+		Charms:    2,
+		Tools:     3,
+		Resources: 4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestSetTransferVolumeError(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetTransferVolume(coremigration.TransferVolume{Model: 1}).Return(errors.New("blam"))
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetTransferVolume(params.MigrationTransferVolume{Model: 1})
+	c.Assert(err, gc.ErrorMatches, "failed to set transfer volume: blam")
+}
+
 func (s *Suite) TestPrechecksModelError(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -336,7 +374,31 @@ func (s *Suite) assertExport(c *gc.C, modelType string) {
 	})
 	unitRev := unitRes.Revision()
 
+	created := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
 	s.backend.EXPECT().Export().Return(s.model, nil)
+	s.backend.EXPECT().ExportSecrets().Return([]coremigration.SerializedModelSecret{{
+		ID:         "secret-id",
+		Owner:      "application-foo",
+		Revision:   2,
+		CreateTime: created,
+		UpdateTime: created,
+		Consumers:  []string{"unit-bar-0"},
+		Value:      map[string]string{"password": "s3cret"},
+	}}, nil)
+	s.backend.EXPECT().ExportActionSchedules().Return([]coremigration.SerializedActionSchedule{{
+		ID:         "1",
+		Schedule:   "@daily",
+		Receivers:  []string{"foo/leader"},
+		ActionName: "backup",
+		Created:    created,
+		CreatedBy:  "admin",
+		Runs: []coremigration.SerializedActionScheduleRun{{
+			Scheduled:   created,
+			Recorded:    created,
+			Status:      "enqueued",
+			OperationID: "2",
+		}},
+	}}, nil)
 
 	serialized, err := s.mustMakeAPI(c).Export()
 	c.Assert(err, jc.ErrorIsNil)
@@ -394,7 +456,29 @@ func (s *Suite) assertExport(c *gc.C, modelType string) {
 			},
 		},
 	}})
-
+	c.Check(serialized.Secrets, jc.DeepEquals, []params.SerializedModelSecret{{
+		ID:         "secret-id",
+		Owner:      "application-foo",
+		Revision:   2,
+		CreateTime: created,
+		UpdateTime: created,
+		Consumers:  []string{"unit-bar-0"},
+		Value:      map[string]string{"password": "s3cret"},
+	}})
+	c.Check(serialized.ActionSchedules, jc.DeepEquals, []params.SerializedActionSchedule{{
+		ID:         "1",
+		Schedule:   "@daily",
+		Receivers:  []string{"foo/leader"},
+		ActionName: "backup",
+		Created:    created,
+		CreatedBy:  "admin",
+		Runs: []params.SerializedActionScheduleRun{{
+			Scheduled:   created,
+			Recorded:    created,
+			Status:      "enqueued",
+			OperationID: "2",
+		}},
+	}})
 }
 
 func (s *Suite) TestReap(c *gc.C) {
@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	description "github.com/juju/description"
+	migration "github.com/juju/juju/core/migration"
 	state "github.com/juju/juju/state"
 	version "github.com/juju/version"
 	names_v3 "gopkg.in/juju/names.v3"
@@ -67,6 +68,36 @@ func (mr *MockBackendMockRecorder) Export() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBackend)(nil).Export))
 }
 
+// ExportActionSchedules mocks base method
+func (m *MockBackend) ExportActionSchedules() ([]migration.SerializedActionSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportActionSchedules")
+	ret0, _ := ret[0].([]migration.SerializedActionSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportActionSchedules indicates an expected call of ExportActionSchedules
+func (mr *MockBackendMockRecorder) ExportActionSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportActionSchedules", reflect.TypeOf((*MockBackend)(nil).ExportActionSchedules))
+}
+
+// ExportSecrets mocks base method
+func (m *MockBackend) ExportSecrets() ([]migration.SerializedModelSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSecrets")
+	ret0, _ := ret[0].([]migration.SerializedModelSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportSecrets indicates an expected call of ExportSecrets
+func (mr *MockBackendMockRecorder) ExportSecrets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSecrets", reflect.TypeOf((*MockBackend)(nil).ExportSecrets))
+}
+
 // LatestMigration mocks base method
 func (m *MockBackend) LatestMigration() (state.ModelMigration, error) {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ import (
 	resource "github.com/juju/juju/resource"
 	state "github.com/juju/juju/state"
 	version "github.com/juju/version"
+	charm_v6 "gopkg.in/juju/charm.v6"
 	names_v3 "gopkg.in/juju/names.v3"
 )
 
@@ -113,6 +114,21 @@ func (mr *MockPrecheckBackendMockRecorder) AllRelations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRelations", reflect.TypeOf((*MockPrecheckBackend)(nil).AllRelations))
 }
 
+// Charm mocks base method
+func (m *MockPrecheckBackend) Charm(arg0 *charm_v6.URL) (migration.PrecheckCharm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Charm", arg0)
+	ret0, _ := ret[0].(migration.PrecheckCharm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Charm indicates an expected call of Charm
+func (mr *MockPrecheckBackendMockRecorder) Charm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Charm", reflect.TypeOf((*MockPrecheckBackend)(nil).Charm), arg0)
+}
+
 // CloudCredential mocks base method
 func (m *MockPrecheckBackend) CloudCredential(arg0 names_v3.CloudCredentialTag) (state.Credential, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +138,20 @@ func (mr *MockModelMigrationMockRecorder) ModelUserAccess(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelUserAccess", reflect.TypeOf((*MockModelMigration)(nil).ModelUserAccess), arg0)
 }
 
+// Offline mocks base method
+func (m *MockModelMigration) Offline() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offline")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Offline indicates an expected call of Offline
+func (mr *MockModelMigrationMockRecorder) Offline() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offline", reflect.TypeOf((*MockModelMigration)(nil).Offline))
+}
+
 // Phase mocks base method
 func (m *MockModelMigration) Phase() (migration.Phase, error) {
 	m.ctrl.T.Helper()
@@ -167,6 +181,20 @@ func (mr *MockModelMigrationMockRecorder) PhaseChangedTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PhaseChangedTime", reflect.TypeOf((*MockModelMigration)(nil).PhaseChangedTime))
 }
 
+// PhaseProgress mocks base method
+func (m *MockModelMigration) PhaseProgress() []migration.PhaseProgress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PhaseProgress")
+	ret0, _ := ret[0].([]migration.PhaseProgress)
+	return ret0
+}
+
+// PhaseProgress indicates an expected call of PhaseProgress
+func (mr *MockModelMigrationMockRecorder) PhaseProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PhaseProgress", reflect.TypeOf((*MockModelMigration)(nil).PhaseProgress))
+}
+
 // Refresh mocks base method
 func (m *MockModelMigration) Refresh() error {
 	m.ctrl.T.Helper()
@@ -209,6 +237,20 @@ func (mr *MockModelMigrationMockRecorder) SetStatusMessage(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatusMessage", reflect.TypeOf((*MockModelMigration)(nil).SetStatusMessage), arg0)
 }
 
+// SetTransferVolume mocks base method
+func (m *MockModelMigration) SetTransferVolume(arg0 migration.TransferVolume) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransferVolume", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTransferVolume indicates an expected call of SetTransferVolume
+func (mr *MockModelMigrationMockRecorder) SetTransferVolume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransferVolume", reflect.TypeOf((*MockModelMigration)(nil).SetTransferVolume), arg0)
+}
+
 // StartTime mocks base method
 func (m *MockModelMigration) StartTime() time.Time {
 	m.ctrl.T.Helper()
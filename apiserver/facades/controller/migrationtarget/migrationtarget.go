@@ -37,14 +37,46 @@ type API struct {
 	getCAASBroker stateenvirons.NewCAASBrokerFunc
 }
 
-// NewFacade is used for API registration.
-func NewFacade(ctx facade.Context) (*API, error) {
+// APIV2 implements the v2 MigrationTarget API. It's the same as v3,
+// but v2 clients don't send the model's secrets or action schedules to
+// Import.
+type APIV2 struct {
+	*API
+}
+
+// APIV1 implements the v1 MigrationTarget API. The only difference
+// between this and v2 is that v1 doesn't have the ValidateMigration
+// method.
+type APIV1 struct {
+	*APIV2
+}
+
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*API, error) {
 	return NewAPI(
 		ctx,
 		stateenvirons.GetNewEnvironFunc(environs.New),
 		stateenvirons.GetNewCAASBrokerFunc(caas.New))
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	v3, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{v3}, nil
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*APIV1, error) {
+	v2, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{v2}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, getCAASBroker stateenvirons.NewCAASBrokerFunc) (*API, error) {
@@ -82,7 +114,7 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := migrationModelInfo(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -98,15 +130,58 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	return migration.TargetPrecheck(
 		backend,
 		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
+		modelInfo,
+		api.presence.ModelPresence(controllerState.ModelUUID()),
+	)
+}
+
+// ValidateMigration isn't on the v1 API.
+func (api *APIV1) ValidateMigration(_, _ struct{}) {}
+
+// ValidateMigration reports every problem which would prevent the
+// serialized model from being migrated to this controller. All of the
+// prechecks are run and the model is checked to see whether it could
+// be imported, but nothing is written to the controller.
+func (api *API) ValidateMigration(args params.ValidateMigrationArgs) (params.ValidateMigrationResult, error) {
+	var result params.ValidateMigrationResult
+	modelInfo, err := migrationModelInfo(args.ModelInfo)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	controllerState := api.pool.SystemState()
+	backend, err := migration.PrecheckShim(api.state, controllerState)
+	if err != nil {
+		return result, errors.Annotate(err, "creating backend")
+	}
+	precheckProblems, err := migration.TargetPrecheckReport(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	importProblems, err := migration.ValidateImport(controllerState, args.Bytes)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Problems = append(precheckProblems, importProblems...)
+	return result, nil
+}
+
+func migrationModelInfo(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -118,12 +193,65 @@ func (api *API) Import(serialized params.SerializedModel) error {
 		return err
 	}
 	defer st.Close()
+	if err := st.ImportSecrets(secretsFromSerialized(serialized.Secrets)); err != nil {
+		return errors.Annotate(err, "importing secrets")
+	}
+	if err := st.ImportActionSchedules(actionSchedulesFromSerialized(serialized.ActionSchedules)); err != nil {
+		return errors.Annotate(err, "importing action schedules")
+	}
 	// TODO(mjs) - post import checks
 	// NOTE(fwereade) - checks here would be sensible, but we will
 	// also need to check after the binaries are imported too.
 	return err
 }
 
+func secretsFromSerialized(in []params.SerializedModelSecret) []coremigration.SerializedModelSecret {
+	var out []coremigration.SerializedModelSecret
+	for _, secret := range in {
+		outSecret := coremigration.SerializedModelSecret{
+			ID:          secret.ID,
+			Owner:       secret.Owner,
+			Description: secret.Description,
+			Revision:    secret.Revision,
+			CreateTime:  secret.CreateTime,
+			UpdateTime:  secret.UpdateTime,
+			Consumers:   secret.Consumers,
+			Value:       secret.Value,
+		}
+		if secret.ExpireTime != nil {
+			outSecret.ExpireTime = *secret.ExpireTime
+		}
+		out = append(out, outSecret)
+	}
+	return out
+}
+
+func actionSchedulesFromSerialized(in []params.SerializedActionSchedule) []coremigration.SerializedActionSchedule {
+	var out []coremigration.SerializedActionSchedule
+	for _, schedule := range in {
+		outSchedule := coremigration.SerializedActionSchedule{
+			ID:         schedule.ID,
+			Schedule:   schedule.Schedule,
+			Receivers:  schedule.Receivers,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Created:    schedule.Created,
+			CreatedBy:  schedule.CreatedBy,
+		}
+		for _, run := range schedule.Runs {
+			outSchedule.Runs = append(outSchedule.Runs, coremigration.SerializedActionScheduleRun{
+				Scheduled:   run.Scheduled,
+				Recorded:    run.Recorded,
+				Status:      run.Status,
+				OperationID: run.OperationID,
+				Message:     run.Message,
+			})
+		}
+		out = append(out, outSchedule)
+	}
+	return out
+}
+
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
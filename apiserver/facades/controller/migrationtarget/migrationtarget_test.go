@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"time"
 
@@ -78,6 +79,32 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
 	c.Assert(err, jc.ErrorIsNil)
 
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+}
+
+func (s *Suite) TestFacadeRegisteredV2(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+}
+
+func (s *Suite) TestFacadeRegisteredV3(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
 	api, err := aFactory(&facadetest.Context{
 		State_:     s.State,
 		Resources_: s.resources,
@@ -141,6 +168,53 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestValidateMigration(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.ValidateMigration(params.ValidateMigrationArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   uuid,
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+
+	// The model must not have been imported.
+	_, _, err = s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestValidateMigrationReportsProblems(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set the model version ahead of the controller.
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	result, err := api.ValidateMigration(params.ValidateMigrationArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   utils.MustNewUUID().String(),
+			Name:                   s.Model.Name(),
+			OwnerTag:               s.Model.Owner().String(),
+			AgentVersion:           modelVersion,
+			ControllerAgentVersion: controllerVersion,
+		},
+		Bytes: []byte("not a model"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 3)
+	c.Check(result.Problems[0], gc.Equals, fmt.Sprintf(
+		"model has higher version than target controller (%s > %s)", modelVersion, controllerVersion))
+	c.Check(result.Problems[1], gc.Equals, fmt.Sprintf("model named %q already exists", s.Model.Name()))
+	c.Check(result.Problems[2], gc.Matches, "model description cannot be read: .*")
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -179,6 +253,79 @@ func (s *Suite) TestImportLeadership(c *gc.C) {
 	claimer.stub.CheckCall(c, 0, "ClaimLeadership", "wordpress", "wordpress/2", time.Minute)
 }
 
+func (s *Suite) TestImportSecrets(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: application.ApplicationTag(),
+		Value: map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err := s.State.ExportSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err = api.Import(params.SerializedModel{
+		Bytes: bytes,
+		Secrets: []params.SerializedModelSecret{{
+			ID:         secret.ID(),
+			Owner:      application.Tag().String(),
+			Revision:   secrets[0].Revision,
+			CreateTime: secrets[0].CreateTime,
+			UpdateTime: secrets[0].UpdateTime,
+			Value:      secrets[0].Value,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.StatePool.Get(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	value, err := st.SecretValue(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *Suite) TestImportActionSchedules(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	created := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.Import(params.SerializedModel{
+		Bytes: bytes,
+		ActionSchedules: []params.SerializedActionSchedule{{
+			ID:         "1",
+			Schedule:   "@daily",
+			Receivers:  []string{unit.Name()},
+			ActionName: "backup",
+			Created:    created,
+			CreatedBy:  "admin",
+			Runs: []params.SerializedActionScheduleRun{{
+				Scheduled: created,
+				Recorded:  created,
+				Status:    "missed",
+				Message:   "missed 1 scheduled run",
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	schedule, err := model.ActionSchedule("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Receivers, jc.DeepEquals, []string{unit.Name()})
+	c.Check(schedule.Runs, jc.DeepEquals, []state.ActionScheduleRun{{
+		Scheduled: created,
+		Recorded:  created,
+		Status:    state.ActionScheduleRunMissed,
+		Message:   "missed 1 scheduled run",
+	}})
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
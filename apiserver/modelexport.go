@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/migration/archive"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// modelExportHandler sends a signed offline migration archive of a
+// model, holding the model description along with the charms, agent
+// binaries and resources it uses. The archive can be imported into a
+// controller which cannot be reached from this one.
+//
+// Exporting a model locks it for migration, so that it is read-only
+// until "juju migrate --offline" switches its agents over to the
+// controller the archive was imported into, or that migration is
+// aborted.
+type modelExportHandler struct {
+	ctxt  httpContext
+	tools *toolsDownloadHandler
+}
+
+// ServeHTTP implements http.Handler.
+func (h *modelExportHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	var err error
+	switch req.Method {
+	case "GET":
+		err = h.serveGet(resp, req)
+	default:
+		err = errors.MethodNotAllowedf("unsupported method: %q", req.Method)
+	}
+	if err != nil {
+		if err := sendError(resp, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+func (h *modelExportHandler) serveGet(resp http.ResponseWriter, req *http.Request) error {
+	st, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+
+	if st.IsController() {
+		return errors.New("the controller model cannot be exported")
+	}
+	info, caKey, err := h.archiveInfo(st.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	locked, err := lockForExport(st.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	bytes, err := migration.ExportModel(st.State)
+	if err != nil {
+		if locked {
+			unlockForExport(st.State)
+		}
+		return errors.Annotate(err, "exporting model")
+	}
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	source := &modelExportSource{
+		st:        st.State,
+		tools:     h.tools,
+		charms:    storage.NewStorage(st.ModelUUID(), st.MongoSession()),
+		resources: resources,
+	}
+
+	logger.Infof("exporting model %s to an offline migration archive", st.ModelUUID())
+	resp.Header().Set("Content-Type", params.ContentTypeRaw)
+	resp.WriteHeader(http.StatusOK)
+	if err := archive.Write(resp, info, bytes, source, caKey); err != nil {
+		// The headers have been sent, so the error can only be
+		// logged. The client will see an incomplete archive.
+		logger.Errorf("unable to complete export of model %s: %v", st.ModelUUID(), err)
+	}
+	return nil
+}
+
+// lockForExport puts the model into the exporting migration mode,
+// which stops users from changing it after the archive is taken. It
+// returns whether the mode was changed, so a failed export can undo
+// it; a model exported earlier is already locked.
+func lockForExport(st *state.State) (bool, error) {
+	m, err := st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	switch m.MigrationMode() {
+	case state.MigrationModeImporting:
+		return false, errors.New("model is being imported")
+	case state.MigrationModeExporting:
+		active, err := st.IsMigrationActive()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if active {
+			return false, errors.New("model is being migrated")
+		}
+		return false, nil
+	}
+	if err := m.SetMigrationMode(state.MigrationModeExporting); err != nil {
+		return false, errors.Annotate(err, "locking model for export")
+	}
+	return true, nil
+}
+
+// unlockForExport returns the model to normal operation after an
+// export failed.
+func unlockForExport(st *state.State) {
+	m, err := st.Model()
+	if err == nil {
+		err = m.SetMigrationMode(state.MigrationModeNone)
+	}
+	if err != nil {
+		logger.Errorf("unable to unlock model %s after failed export: %v", st.ModelUUID(), err)
+	}
+}
+
+// archiveInfo returns the details of the model and controller which
+// are recorded in the archive, along with the CA private key which is
+// used to sign it.
+func (h *modelExportHandler) archiveInfo(st *state.State) (archive.Info, string, error) {
+	var empty archive.Info
+	m, err := st.Model()
+	if err != nil {
+		return empty, "", errors.Trace(err)
+	}
+	cfg, err := m.Config()
+	if err != nil {
+		return empty, "", errors.Trace(err)
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		return empty, "", errors.New("no agent version in model config")
+	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return empty, "", errors.Trace(err)
+	}
+	caCert, ok := controllerConfig.CACert()
+	if !ok {
+		return empty, "", errors.New("missing CA certificate in controller config")
+	}
+	servingInfo, err := st.StateServingInfo()
+	if err != nil {
+		return empty, "", errors.Trace(err)
+	}
+	return archive.Info{
+		SourceControllerUUID: st.ControllerUUID(),
+		SourceCACert:         caCert,
+		Model: coremigration.ModelInfo{
+			UUID:                   m.UUID(),
+			Owner:                  m.Owner(),
+			Name:                   m.Name(),
+			AgentVersion:           agentVersion,
+			ControllerAgentVersion: jujuversion.Current,
+		},
+	}, servingInfo.CAPrivateKey, nil
+}
+
+// modelExportSource reads the binaries written to a model export
+// archive from the controller's storage.
+type modelExportSource struct {
+	st        *state.State
+	tools     *toolsDownloadHandler
+	charms    storage.Storage
+	resources state.Resources
+}
+
+// OpenCharm is part of the archive.Source interface.
+func (s *modelExportSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := s.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reader, _, err := s.charms.Get(ch.StoragePath())
+	return reader, errors.Trace(err)
+}
+
+// OpenTools is part of the archive.Source interface.
+func (s *modelExportSource) OpenTools(v version.Binary) (io.ReadCloser, error) {
+	reader, _, err := s.tools.openTools(s.st, v)
+	return reader, errors.Trace(err)
+}
+
+// OpenResource is part of the archive.Source interface.
+func (s *modelExportSource) OpenResource(application, name string) (io.ReadCloser, error) {
+	_, reader, err := s.resources.OpenResource(application, name)
+	return reader, errors.Trace(err)
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/migration/archive"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type modelExportSuite struct {
+	apiserverBaseSuite
+	modelState *state.State
+}
+
+var _ = gc.Suite(&modelExportSuite{})
+
+func (s *modelExportSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+	s.modelState = s.Factory.MakeModel(c, &factory.ModelParams{Name: "exported"})
+	s.AddCleanup(func(*gc.C) { s.modelState.Close() })
+}
+
+func (s *modelExportSuite) exportURL(modelUUID string) string {
+	return s.URL(fmt.Sprintf("/model/%s/export", modelUUID), nil).String()
+}
+
+func (s *modelExportSuite) TestExport(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.exportURL(s.modelState.ModelUUID()),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeRaw)
+
+	a, err := archive.Read(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	defer a.Close()
+
+	c.Assert(a.Verify(coretesting.CACert), jc.ErrorIsNil)
+	c.Check(a.Info.SourceControllerUUID, gc.Equals, s.State.ControllerUUID())
+	c.Check(a.Info.Model.UUID, gc.Equals, s.modelState.ModelUUID())
+	c.Check(a.Info.Model.Name, gc.Equals, "exported")
+	c.Check(a.Charms(), gc.HasLen, 0)
+
+	// The exported model is locked until it is switched over.
+	m, err := s.modelState.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.MigrationMode(), gc.Equals, state.MigrationModeExporting)
+}
+
+func (s *modelExportSuite) TestExportImportingModel(c *gc.C) {
+	m, err := s.modelState.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.exportURL(s.modelState.ModelUUID()),
+	})
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusInternalServerError, gc.Commentf("body: %s", body))
+
+	var result params.ErrorResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "model is being imported")
+}
+
+func (s *modelExportSuite) TestExportControllerModel(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.exportURL(s.State.ModelUUID()),
+	})
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusInternalServerError, gc.Commentf("body: %s", body))
+
+	var result params.ErrorResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "the controller model cannot be exported")
+}
+
+func (s *modelExportSuite) TestExportRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2"})
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:   "GET",
+		URL:      s.exportURL(s.modelState.ModelUUID()),
+		Tag:      user.Tag().String(),
+		Password: "hunter2",
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusForbidden, "text/plain; charset=utf-8")
+	c.Assert(string(body), gc.Matches, "authorization failed: user .* is not a controller admin\n")
+}
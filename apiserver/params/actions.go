@@ -38,6 +38,18 @@ const (
 // Actions is a slice of Action for bulk requests.
 type Actions struct {
 	Actions []Action `json:"actions,omitempty"`
+
+	// Rolling, if set when enqueuing an operation, runs the
+	// operation's tasks in batches rather than all at once.
+	Rolling *RollingStrategy `json:"rolling,omitempty"`
+}
+
+// RollingStrategy describes how the tasks of a rolling operation are
+// run: BatchSize tasks at a time, stopping once more than MaxFailures
+// tasks have failed.
+type RollingStrategy struct {
+	BatchSize   int `json:"batch-size"`
+	MaxFailures int `json:"max-failures"`
 }
 
 // Action describes an Action that will be or has been queued up.
@@ -65,17 +77,27 @@ type ActionMessage struct {
 	Message   string    `json:"message"`
 }
 
+// ActionAttachment describes a file attached to an action by the unit
+// running it.
+type ActionAttachment struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	SHA384  string    `json:"sha384"`
+	Created time.Time `json:"created"`
+}
+
 // ActionResult describes an Action that will be or has been completed.
 type ActionResult struct {
-	Action    *Action                `json:"action,omitempty"`
-	Enqueued  time.Time              `json:"enqueued,omitempty"`
-	Started   time.Time              `json:"started,omitempty"`
-	Completed time.Time              `json:"completed,omitempty"`
-	Status    string                 `json:"status,omitempty"`
-	Message   string                 `json:"message,omitempty"`
-	Log       []ActionMessage        `json:"log,omitempty"`
-	Output    map[string]interface{} `json:"output,omitempty"`
-	Error     *Error                 `json:"error,omitempty"`
+	Action      *Action                `json:"action,omitempty"`
+	Enqueued    time.Time              `json:"enqueued,omitempty"`
+	Started     time.Time              `json:"started,omitempty"`
+	Completed   time.Time              `json:"completed,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Message     string                 `json:"message,omitempty"`
+	Log         []ActionMessage        `json:"log,omitempty"`
+	Output      map[string]interface{} `json:"output,omitempty"`
+	Attachments []ActionAttachment     `json:"attachments,omitempty"`
+	Error       *Error                 `json:"error,omitempty"`
 }
 
 // ActionsByReceivers wrap a slice of Actions for API calls.
@@ -151,6 +173,18 @@ type OperationResult struct {
 	Status       string         `json:"status,omitempty"`
 	Actions      []ActionResult `json:"actions,omitempty"`
 	Error        *Error         `json:"error,omitempty"`
+
+	// Rolling, Batches and Message are only set for rolling operations.
+	Rolling *RollingStrategy `json:"rolling,omitempty"`
+	Batches []OperationBatch `json:"batches,omitempty"`
+	Message string           `json:"message,omitempty"`
+}
+
+// OperationBatch reports the progress of a batch of tasks of a
+// rolling operation.
+type OperationBatch struct {
+	Status string   `json:"status"`
+	Tasks  []string `json:"tasks"`
 }
 
 // ActionExecutionResults holds a slice of ActionExecutionResult for a
@@ -201,3 +235,79 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// AddActionSchedulesArgs holds the details of action schedules to add.
+type AddActionSchedulesArgs struct {
+	Schedules []AddActionScheduleArg `json:"schedules"`
+}
+
+// AddActionScheduleArg holds the details of an action schedule to add.
+// Each receiver is a unit name, or "<application>/leader" to run the
+// action on the application's leader at the time of each run.
+type AddActionScheduleArg struct {
+	Schedule   string                 `json:"schedule"`
+	Receivers  []string               `json:"receivers"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ActionSchedule is a schedule on which an action is run, with the
+// history of its recent runs.
+type ActionSchedule struct {
+	Id         string                 `json:"id"`
+	Schedule   string                 `json:"schedule"`
+	Receivers  []string               `json:"receivers"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Created    time.Time              `json:"created"`
+	CreatedBy  string                 `json:"created-by"`
+	Runs       []ActionScheduleRun    `json:"runs,omitempty"`
+}
+
+// ActionScheduleRun records a single run of an action schedule.
+type ActionScheduleRun struct {
+	Scheduled   time.Time `json:"scheduled"`
+	Recorded    time.Time `json:"recorded"`
+	Status      string    `json:"status"`
+	OperationId string    `json:"operation-id,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds a slice of ActionScheduleResult for bulk
+// requests.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results,omitempty"`
+}
+
+// ActionSchedulesResult holds all the action schedules in a model.
+type ActionSchedulesResult struct {
+	Schedules []ActionSchedule `json:"schedules,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// ActionScheduleIds holds the IDs of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionScheduleRunArgs holds the scheduled runs of action schedules
+// to be recorded by the action scheduler.
+type ActionScheduleRunArgs struct {
+	Runs []ActionScheduleRunArg `json:"runs"`
+}
+
+// ActionScheduleRunArg identifies a scheduled run of an action
+// schedule. If Missed is non-zero, that many runs up to and including
+// the scheduled time were missed and are recorded as such; otherwise
+// the schedule's actions are enqueued.
+type ActionScheduleRunArg struct {
+	Id        string    `json:"id"`
+	Scheduled time.Time `json:"scheduled"`
+	Missed    int       `json:"missed,omitempty"`
+}
@@ -446,3 +446,36 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// HookExecution describes a single hook or action run by a unit.
+type HookExecution struct {
+	Kind        string        `json:"kind" yaml:"kind"`
+	Name        string        `json:"name" yaml:"name"`
+	Relation    string        `json:"relation,omitempty" yaml:"relation,omitempty"`
+	Started     time.Time     `json:"started" yaml:"started"`
+	Duration    time.Duration `json:"duration" yaml:"duration"`
+	ExitCode    int           `json:"exit-code" yaml:"exit-code"`
+	ToolsCalled []string      `json:"tools-called,omitempty" yaml:"tools-called,omitempty"`
+}
+
+// UnitResult holds unit info.
+type UnitResult struct {
+	Tag             string          `json:"tag"`
+	Application     string          `json:"application"`
+	Charm           string          `json:"charm,omitempty"`
+	Life            string          `json:"life"`
+	Machine         string          `json:"machine,omitempty"`
+	WorkloadVersion string          `json:"workload-version,omitempty"`
+	HookHistory     []HookExecution `json:"hook-history,omitempty"`
+}
+
+// UnitInfoResult holds a unit info result or a retrieval error.
+type UnitInfoResult struct {
+	Result *UnitResult `json:"result,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// UnitInfoResults holds units associated with entities.
+type UnitInfoResults struct {
+	Results []UnitInfoResult `json:"results"`
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the arguments for a call to the Query method
+// of the AuditLog facade. Empty fields don't restrict the entries
+// returned.
+type AuditLogQueryArgs struct {
+	Who        string     `json:"who,omitempty"`
+	ModelUUID  string     `json:"model-uuid,omitempty"`
+	Facade     string     `json:"facade,omitempty"`
+	Method     string     `json:"method,omitempty"`
+	After      *time.Time `json:"after,omitempty"`
+	Before     *time.Time `json:"before,omitempty"`
+	ErrorsOnly bool       `json:"errors-only,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds an API request recorded in the audit log, along
+// with the conversation it was made in and the errors it returned.
+type AuditLogEntry struct {
+	ControllerID   string   `json:"controller-id"`
+	ConversationID string   `json:"conversation-id"`
+	ConnectionID   string   `json:"connection-id"`
+	Who            string   `json:"who"`
+	What           string   `json:"what"`
+	ModelName      string   `json:"model-name"`
+	ModelUUID      string   `json:"model-uuid"`
+	RequestID      uint64   `json:"request-id"`
+	When           string   `json:"when"`
+	Facade         string   `json:"facade"`
+	Method         string   `json:"method"`
+	Version        int      `json:"version"`
+	Args           string   `json:"args,omitempty"`
+	Errors         []*Error `json:"errors,omitempty"`
+}
+
+// AuditLogQueryResults holds the results of a call to the Query
+// method of the AuditLog facade, in the order the requests were made.
+type AuditLogQueryResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -14,20 +14,61 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// Destination is the URL of the backup destination in which
+	// to store the backup. If empty, the controller's own storage
+	// is used.
+	Destination string `json:"destination,omitempty"`
+
+	// Incremental requests a backup of only the changes made since
+	// the most recent full backup, rather than a full backup.
+	Incremental bool `json:"incremental,omitempty"`
+}
+
+// BackupsCreateArgsV3 holds the args for the API Create method
+// for facade version 3. V3 is missing the incremental arg.
+type BackupsCreateArgsV3 struct {
+	Notes       string `json:"notes"`
+	KeepCopy    bool   `json:"keep-copy"`
+	NoDownload  bool   `json:"no-download"`
+	Destination string `json:"destination,omitempty"`
+}
+
+// BackupsCreateArgsV2 holds the args for the API Create method
+// for facade versions 1 and 2. V2 is missing the destination and
+// incremental args.
+type BackupsCreateArgsV2 struct {
+	Notes      string `json:"notes"`
+	KeepCopy   bool   `json:"keep-copy"`
+	NoDownload bool   `json:"no-download"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
 type BackupsInfoArgs struct {
+	ID          string `json:"id"`
+	Destination string `json:"destination,omitempty"`
+}
+
+// BackupsInfoArgsV2 holds the args for the API Info method for
+// facade versions 1 and 2. V2 is missing the destination arg.
+type BackupsInfoArgsV2 struct {
 	ID string `json:"id"`
 }
 
 // BackupsListArgs holds the args for the API List method.
 type BackupsListArgs struct {
+	Destination string `json:"destination,omitempty"`
+}
+
+// BackupsListArgsV2 holds the args for the API List method for
+// facade versions 1 and 2. V2 is missing the destination arg.
+type BackupsListArgsV2 struct {
 }
 
 // BackupsDownloadArgs holds the args for the API Download method.
 type BackupsDownloadArgs struct {
-	ID string `json:"id"`
+	ID          string `json:"id"`
+	Destination string `json:"destination,omitempty"`
 }
 
 // BackupsUploadArgs holds the args for the API Upload method.
@@ -38,6 +79,13 @@ type BackupsUploadArgs struct {
 
 // BackupsRemoveArgs holds the args for the API Remove method.
 type BackupsRemoveArgs struct {
+	IDs         []string `json:"ids"`
+	Destination string   `json:"destination,omitempty"`
+}
+
+// BackupsRemoveArgsV2 holds the args for the API Remove method for
+// facade version 2. V2 is missing the destination arg.
+type BackupsRemoveArgsV2 struct {
 	IDs []string `json:"ids"`
 }
 
@@ -90,10 +138,46 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// Base is the ID of the full backup an incremental backup is based
+	// on. It is empty for full backups.
+	Base string `json:"base,omitempty"`
+
+	// OplogStart and OplogEnd are the times of the first and last
+	// database changes held in the backup's oplog.
+	OplogStart time.Time `json:"oplog-start"` // May be zero...
+	OplogEnd   time.Time `json:"oplog-end"`   // May be zero...
 }
 
 // RestoreArgs Holds the backup file or id
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Destination holds the URL of the backup destination holding
+	// the backup, if it is not in the controller's own storage.
+	Destination string `json:"destination,omitempty"`
+
+	// Until, if set, is the time up to which the changes held in an
+	// incremental backup are restored.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// RestoreArgsV3 holds the args for the API Restore method for
+// facade version 3. V3 is missing the until arg.
+type RestoreArgsV3 struct {
+	// BackupId holds the id of the backup in server if any
+	BackupId string `json:"backup-id"`
+
+	// Destination holds the URL of the backup destination holding
+	// the backup, if it is not in the controller's own storage.
+	Destination string `json:"destination,omitempty"`
+}
+
+// RestoreArgsV2 holds the args for the API Restore method for
+// facade versions 1 and 2. V2 is missing the destination and
+// until args.
+type RestoreArgsV2 struct {
+	// BackupId holds the id of the backup in server if any
+	BackupId string `json:"backup-id"`
 }
@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/core/life"
+import (
+	"time"
+
+	"github.com/juju/juju/core/life"
+)
 
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
@@ -115,3 +119,20 @@ type ControllerVersionResults struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
 }
+
+// BackupScheduleStatus holds the outcome of the controller's most recent
+// scheduled backups.
+type BackupScheduleStatus struct {
+	LastAttempt  time.Time  `json:"last-attempt"`
+	LastSuccess  *time.Time `json:"last-success,omitempty"`
+	LastBackupID string     `json:"last-backup-id,omitempty"`
+	LastError    string     `json:"last-error,omitempty"`
+}
+
+// BackupScheduleStatusResult holds the results from an api call to get
+// the status of the controller's scheduled backups. Result is nil if no
+// scheduled backup has been attempted.
+type BackupScheduleStatusResult struct {
+	Result *BackupScheduleStatus `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
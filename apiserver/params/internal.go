@@ -262,6 +262,9 @@ type UnitStateResult struct {
 	RelationState map[int]string `json:"relation-state,omitempty"`
 	// StorageState is a internal storage state for this unit.
 	StorageState string `json:"storage-state,omitempty"`
+	// HookHistory is the uniter's record of recent hook and action
+	// executions for this unit.
+	HookHistory string `json:"hook-history,omitempty"`
 }
 
 // UnitStateResults holds multiple unit state maps or errors.
@@ -288,6 +291,7 @@ type SetUnitStateArg struct {
 	UniterState   *string            `json:"uniter-state,omitempty"`
 	RelationState *map[int]string    `json:"relation-state,omitempty"`
 	StorageState  *string            `json:"storage-state,omitempty"`
+	HookHistory   *string            `json:"hook-history,omitempty"`
 }
 
 // CommitHookChangesArgs serves as a container for CommitHookChangesArg objects
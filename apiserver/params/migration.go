@@ -25,6 +25,7 @@ type InitiateMigrationArgs struct {
 type MigrationSpec struct {
 	ModelTag   string              `json:"model-tag"`
 	TargetInfo MigrationTargetInfo `json:"target-info"`
+	Offline    bool                `json:"offline,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -53,6 +54,23 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// DryRunMigrationResults is used to return the result of checking
+// whether one or more models could be migrated.
+type DryRunMigrationResults struct {
+	Results []DryRunMigrationResult `json:"results"`
+}
+
+// DryRunMigrationResult is used to return the problems which would
+// prevent a single model from being migrated. The source problems are
+// found on the source controller and the target problems on the target
+// controller.
+type DryRunMigrationResult struct {
+	ModelTag       string   `json:"model-tag"`
+	Error          *Error   `json:"error,omitempty"`
+	SourceProblems []string `json:"source-problems,omitempty"`
+	TargetProblems []string `json:"target-problems,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -65,13 +83,61 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// MigrationTransferVolume holds the number of bytes of each kind of
+// data sent to the target controller during a migration phase. It is
+// passed to the migrationmaster.SetTransferVolume API method.
+type MigrationTransferVolume struct {
+	Model     int64 `json:"model"`
+	Charms    int64 `json:"charms"`
+	Tools     int64 `json:"tools"`
+	Resources int64 `json:"resources"`
+}
+
+// MigrationPhaseProgress records when a migration entered and left a
+// phase, along with the data sent to the target controller in it.
+type MigrationPhaseProgress struct {
+	Phase   string                  `json:"phase"`
+	Started time.Time               `json:"started"`
+	Ended   *time.Time              `json:"ended,omitempty"`
+	Sent    MigrationTransferVolume `json:"sent"`
+}
+
+// MigrationProgress describes the progress of the latest migration of
+// a model through each of its phases.
+type MigrationProgress struct {
+	MigrationId           string                   `json:"migration-id"`
+	Attempt               int                      `json:"attempt"`
+	Phase                 string                   `json:"phase"`
+	Status                string                   `json:"status"`
+	TargetControllerTag   string                   `json:"target-controller-tag"`
+	TargetControllerAlias string                   `json:"target-controller-alias,omitempty"`
+	Start                 time.Time                `json:"start"`
+	End                   *time.Time               `json:"end,omitempty"`
+	Phases                []MigrationPhaseProgress `json:"phases"`
+}
+
+// MigrationProgressResult holds the progress of a model's latest
+// migration, or an error if it could not be determined.
+type MigrationProgressResult struct {
+	Result *MigrationProgress `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// MigrationProgressResults holds the results of a call to
+// ModelManager.MigrationProgress.
+type MigrationProgressResults struct {
+	Results []MigrationProgressResult `json:"results"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
-	Bytes     []byte                    `json:"bytes"`
-	Charms    []string                  `json:"charms"`
-	Tools     []SerializedModelTools    `json:"tools"`
-	Resources []SerializedModelResource `json:"resources"`
+	Bytes           []byte                     `json:"bytes"`
+	Charms          []string                   `json:"charms"`
+	Tools           []SerializedModelTools     `json:"tools"`
+	Resources       []SerializedModelResource  `json:"resources"`
+	Secrets         []SerializedModelSecret    `json:"secrets,omitempty"`
+	ActionSchedules []SerializedActionSchedule `json:"action-schedules,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -110,6 +176,42 @@ type SerializedModelResourceRevision struct {
 	Username       string    `json:"username,omitempty"`
 }
 
+// SerializedModelSecret holds a secret and its decrypted value, for
+// encrypting again with the target controller's key.
+type SerializedModelSecret struct {
+	ID          string            `json:"id"`
+	Owner       string            `json:"owner"`
+	Description string            `json:"description,omitempty"`
+	Revision    int               `json:"revision"`
+	CreateTime  time.Time         `json:"create-time"`
+	UpdateTime  time.Time         `json:"update-time"`
+	ExpireTime  *time.Time        `json:"expire-time,omitempty"`
+	Consumers   []string          `json:"consumers,omitempty"`
+	Value       map[string]string `json:"value"`
+}
+
+// SerializedActionSchedule holds an action schedule and the history of
+// its runs.
+type SerializedActionSchedule struct {
+	ID         string                        `json:"id"`
+	Schedule   string                        `json:"schedule"`
+	Receivers  []string                      `json:"receivers"`
+	ActionName string                        `json:"action-name"`
+	Parameters map[string]interface{}        `json:"parameters,omitempty"`
+	Created    time.Time                     `json:"created"`
+	CreatedBy  string                        `json:"created-by"`
+	Runs       []SerializedActionScheduleRun `json:"runs,omitempty"`
+}
+
+// SerializedActionScheduleRun holds a single run of an action schedule.
+type SerializedActionScheduleRun struct {
+	Scheduled   time.Time `json:"scheduled"`
+	Recorded    time.Time `json:"recorded"`
+	Status      string    `json:"status"`
+	OperationID string    `json:"operation-id,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
@@ -135,6 +237,19 @@ type MigrationModelInfo struct {
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
 }
 
+// ValidateMigrationArgs holds the details of a model to be checked by
+// the migration target, without importing it.
+type ValidateMigrationArgs struct {
+	ModelInfo MigrationModelInfo `json:"model-info"`
+	Bytes     []byte             `json:"bytes"`
+}
+
+// ValidateMigrationResult holds the problems found by the migration
+// target which would prevent a model from being migrated to it.
+type ValidateMigrationResult struct {
+	Problems []string `json:"problems,omitempty"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
 	MigrationId string `json:"migration-id"`
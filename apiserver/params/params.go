@@ -610,6 +610,20 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretsKey is the base64 encoded key used to encrypt charm
+	// secrets. It is shared by the controllers, but isn't stored in
+	// the database.
+	SecretsKey string `json:"secrets-key,omitempty"`
+}
+
+// MetricsConfigResult holds the port and credentials a machine agent
+// serves agent metrics with. The password is only sent as a salted
+// hash.
+type MetricsConfigResult struct {
+	Port         int    `json:"port"`
+	Username     string `json:"username"`
+	PasswordHash string `json:"password-hash"`
+	PasswordSalt string `json:"password-salt"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -654,6 +668,7 @@ type ContainerConfig struct {
 	SnapStoreProxyURL          string                 `json:"snap-store-proxy-url"`
 	AptMirror                  string                 `json:"apt-mirror"`
 	CloudInitUserData          map[string]interface{} `json:"cloudinit-userdata,omitempty"`
+	CloudInitUserHooks         map[string]string      `json:"cloudinit-user-hooks,omitempty"`
 	ContainerInheritProperties string                 `json:"container-inherit-properties,omitempty"`
 	*UpdateBehavior
 }
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CreateSecretArgs holds the arguments for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the details of a secret to create, owned by
+// the application of the calling unit.
+type CreateSecretArg struct {
+	Description string            `json:"description,omitempty"`
+	ExpireTime  *time.Time        `json:"expire-time,omitempty"`
+	Value       map[string]string `json:"value"`
+}
+
+// UpdateSecretArgs holds the arguments for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the changes to make to a secret. If Value is
+// nil the secret's value is left unchanged. If ExpireTime is nil the
+// expiry time is left unchanged, and if it is the zero time the secret
+// no longer expires.
+type UpdateSecretArg struct {
+	ID         string            `json:"id"`
+	ExpireTime *time.Time        `json:"expire-time,omitempty"`
+	Value      map[string]string `json:"value,omitempty"`
+}
+
+// GetSecretArgs holds the IDs of the secrets whose values are read.
+type GetSecretArgs struct {
+	IDs []string `json:"ids"`
+}
+
+// SecretValueResults holds the values of secrets.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the value of a secret, or an error.
+type SecretValueResult struct {
+	Value map[string]string `json:"value,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantRevokeSecretArgs holds the arguments for granting and revoking
+// access to secrets.
+type GrantRevokeSecretArgs struct {
+	Args []GrantRevokeSecretArg `json:"args"`
+}
+
+// GrantRevokeSecretArg identifies a secret, and the tag of the
+// application or unit whose access to it is granted or revoked.
+type GrantRevokeSecretArg struct {
+	ID      string `json:"id"`
+	Subject string `json:"subject"`
+}
+
+// ListSecretsArgs holds the arguments for listing the secrets in a
+// model.
+type ListSecretsArgs struct {
+	// ShowValues is true if the decrypted secret values are
+	// returned.
+	ShowValues bool `json:"show-values"`
+}
+
+// ListSecretResults holds the secrets in a model.
+type ListSecretResults struct {
+	Results []ListSecretResult `json:"results"`
+}
+
+// ListSecretResult holds the details of a secret. Value is only set if
+// requested, and ValueError holds the reason the value couldn't be
+// read.
+type ListSecretResult struct {
+	ID          string            `json:"id"`
+	Owner       string            `json:"owner"`
+	Description string            `json:"description,omitempty"`
+	Revision    int               `json:"revision"`
+	CreateTime  time.Time         `json:"create-time"`
+	UpdateTime  time.Time         `json:"update-time"`
+	ExpireTime  *time.Time        `json:"expire-time,omitempty"`
+	Consumers   []string          `json:"consumers,omitempty"`
+	Value       map[string]string `json:"value,omitempty"`
+	ValueError  *Error            `json:"value-error,omitempty"`
+}
@@ -15,6 +15,7 @@ import (
 var commonModelFacadeNames = set.NewStrings(
 	"Action",
 	"ActionPruner",
+	"ActionScheduler",
 	"AllWatcher",
 	"Agent",
 	"Annotations",
@@ -54,6 +55,8 @@ var commonModelFacadeNames = set.NewStrings(
 	"RemoteRelations",
 	"Resumer",
 	"RetryStrategy",
+	"Secrets",
+	"SecretsManager",
 	"Singular",
 	"StatusHistory",
 	"Storage",
@@ -138,7 +138,13 @@ func (h *toolsDownloadHandler) getToolsForRequest(r *http.Request, st *state.Sta
 		return nil, 0, errors.Annotate(err, "error parsing version")
 	}
 	logger.Debugf("request for agent binaries: %s", version)
+	return h.openTools(st, version)
+}
 
+// openTools returns a reader for the agent binaries with the given
+// version, fetching them into tools storage if they are not already
+// there.
+func (h *toolsDownloadHandler) openTools(st *state.State, version version.Binary) (io.ReadCloser, int64, error) {
 	storage, err := st.ToolsStorage()
 	if err != nil {
 		return nil, 0, errors.Annotate(err, "error getting storage for agent binaries")
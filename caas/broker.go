@@ -234,6 +234,9 @@ type Broker interface {
 
 	// ClusterVersionGetter provides methods to get cluster version information.
 	ClusterVersionGetter
+
+	// NetworkPolicyManager provides the API to restrict ingress to applications.
+	NetworkPolicyManager
 }
 
 // Upgrader provides the API to perform upgrades.
@@ -242,6 +245,18 @@ type Upgrader interface {
 	Upgrade(appName string, vers version.Number) error
 }
 
+// NetworkPolicyManager provides the API to restrict ingress to applications.
+type NetworkPolicyManager interface {
+	// EnsureNetworkPolicy only allows ingress to the specified application
+	// from the application itself and the specified related applications,
+	// on the ports the application's service exposes.
+	EnsureNetworkPolicy(appName string, relatedApps []string) error
+
+	// DeleteNetworkPolicy removes any ingress restriction from the
+	// specified application.
+	DeleteNetworkPolicy(appName string) error
+}
+
 // StorageValidator provides methods to validate storage.
 type StorageValidator interface {
 	// ValidateStorageClass returns an error if the storage config is not valid.
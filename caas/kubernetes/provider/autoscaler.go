@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	autoscaling "k8s.io/api/autoscaling/v2beta2"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// horizontalPodAutoscalerSpec returns the autoscaler managing the replicas
+// of the stateful set or deployment with the specified name.
+func horizontalPodAutoscalerSpec(
+	appName, deploymentName string,
+	deploymentType caas.DeploymentType,
+	annotations k8sannotations.Annotation,
+	spec specs.AutoscalingSpec,
+) *autoscaling.HorizontalPodAutoscaler {
+	kind := "Deployment"
+	if deploymentType == caas.DeploymentStateful {
+		kind = "StatefulSet"
+	}
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName,
+			},
+			MaxReplicas: spec.MaxReplicas,
+		},
+	}
+	if spec.MinReplicas > 0 {
+		hpa.Spec.MinReplicas = int32Ptr(spec.MinReplicas)
+	}
+	resourceMetric := func(name core.ResourceName, utilization int32) autoscaling.MetricSpec {
+		return autoscaling.MetricSpec{
+			Type: autoscaling.ResourceMetricSourceType,
+			Resource: &autoscaling.ResourceMetricSource{
+				Name: name,
+				Target: autoscaling.MetricTarget{
+					Type:               autoscaling.UtilizationMetricType,
+					AverageUtilization: int32Ptr(utilization),
+				},
+			},
+		}
+	}
+	if spec.TargetCPUUtilizationPercentage > 0 {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics,
+			resourceMetric(core.ResourceCPU, spec.TargetCPUUtilizationPercentage))
+	}
+	if spec.TargetMemoryUtilizationPercentage > 0 {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics,
+			resourceMetric(core.ResourceMemory, spec.TargetMemoryUtilizationPercentage))
+	}
+	return hpa
+}
+
+// configureAutoscaler creates or updates the autoscaler for the application
+// if its pod spec asks for one, otherwise any existing autoscaler is deleted.
+// The autoscaler then owns the number of replicas; the scale it chooses is
+// reported back to Juju when the workload changes.
+func (k *kubernetesClient) configureAutoscaler(
+	appName, deploymentName string,
+	deploymentType caas.DeploymentType,
+	annotations k8sannotations.Annotation,
+	workloadSpec *workloadSpec,
+) error {
+	if workloadSpec.Service == nil || workloadSpec.Service.Autoscaling == nil {
+		return errors.Trace(k.deleteHorizontalPodAutoscaler(deploymentName))
+	}
+	logger.Debugf("creating/updating autoscaler for %s", appName)
+	return errors.Trace(k.ensureHorizontalPodAutoscaler(horizontalPodAutoscalerSpec(
+		appName, deploymentName, deploymentType, annotations, *workloadSpec.Service.Autoscaling,
+	)))
+}
+
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(spec *autoscaling.HorizontalPodAutoscaler) error {
+	autoscalers := k.client().AutoscalingV2beta2().HorizontalPodAutoscalers(k.namespace)
+	_, err := autoscalers.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = autoscalers.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(name string) error {
+	err := k.client().AutoscalingV2beta2().HorizontalPodAutoscalers(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
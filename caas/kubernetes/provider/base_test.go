@@ -62,6 +62,10 @@ type BaseSuite struct {
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockAutoscaling            *mocks.MockAutoscalingV2beta2Interface
+	mockAutoscalers            *mocks.MockHorizontalPodAutoscalerInterface
+	mockNetworking             *mocks.MockNetworkingV1Interface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
 	mockNodes                  *mocks.MockNodeInterface
 	mockEvents                 *mocks.MockEventInterface
 
@@ -277,6 +281,24 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.mockApps.EXPECT().DaemonSets(namespace).AnyTimes().Return(s.mockDaemonSets)
 	s.mockExtensions.EXPECT().Ingresses(namespace).AnyTimes().Return(s.mockIngressInterface)
 
+	s.mockAutoscaling = mocks.NewMockAutoscalingV2beta2Interface(ctrl)
+	s.mockAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV2beta2().AnyTimes().Return(s.mockAutoscaling)
+	s.mockAutoscaling.EXPECT().HorizontalPodAutoscalers(namespace).AnyTimes().Return(s.mockAutoscalers)
+	// Applications without autoscaling in their pod spec always have
+	// any stale autoscaler removed, so allow that by default.
+	s.mockAutoscalers.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes().Return(s.k8sNotFoundError())
+
+	s.mockNetworking = mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(s.mockNetworking)
+	s.mockNetworking.EXPECT().NetworkPolicies(namespace).AnyTimes().Return(s.mockNetworkPolicies)
+	// Deleting an application removes any network policy it has.
+	s.mockNetworkPolicies.EXPECT().Delete(gomock.Any(), gomock.Any()).AnyTimes().Return(s.k8sNotFoundError())
+	// Ensuring the service of an application updates the ports of its
+	// network policy, which it has none of by default.
+	s.mockNetworkPolicies.EXPECT().Get("app-name", v1.GetOptions{}).AnyTimes().Return(nil, s.k8sNotFoundError())
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
@@ -24,10 +24,15 @@ const (
 	serviceExternalNameKey             = "kubernetes-service-externalname"
 	serviceAnnotationsKey              = "kubernetes-service-annotations"
 
-	ingressClassKey          = "kubernetes-ingress-class"
+	IngressClassConfigKey    = "kubernetes-ingress-class"
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	IngressTLSSecretConfigKey                = "kubernetes-ingress-tls-secret"
+	IngressCertManagerIssuerConfigKey        = "kubernetes-ingress-cert-manager-issuer"
+	IngressCertManagerClusterIssuerConfigKey = "kubernetes-ingress-cert-manager-cluster-issuer"
+	IngressRulesConfigKey                    = "kubernetes-ingress-rules"
 )
 
 var configFields = environschema.Fields{
@@ -66,7 +71,7 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.ProviderGroup,
 	},
-	ingressClassKey: {
+	IngressClassConfigKey: {
 		Description: "the class of the ingress controller to be used by the ingress resource",
 		Type:        environschema.Tstring,
 		Group:       environschema.ProviderGroup,
@@ -86,15 +91,40 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	IngressTLSSecretConfigKey: {
+		Description: "the name of the secret holding the TLS certificate for the ingress hosts",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	IngressCertManagerIssuerConfigKey: {
+		Description: "the cert-manager issuer, in the model namespace, used to obtain the TLS certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	IngressCertManagerClusterIssuerConfigKey: {
+		Description: "the cert-manager cluster issuer used to obtain the TLS certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	IngressRulesConfigKey: {
+		Description: "a space separated list of additional host[/path] rules for the ingress resource",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
 	ServiceTypeConfigKey:     schema.Omit,
 	serviceAnnotationsKey:    schema.Omit,
-	ingressClassKey:          defaultIngressClass,
+	IngressClassConfigKey:    defaultIngressClass,
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+
+	IngressTLSSecretConfigKey:                schema.Omit,
+	IngressCertManagerIssuerConfigKey:        schema.Omit,
+	IngressCertManagerClusterIssuerConfigKey: schema.Omit,
+	IngressRulesConfigKey:                    schema.Omit,
 }
 
 // ConfigSchema returns the configuration schema for
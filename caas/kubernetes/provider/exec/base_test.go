@@ -29,6 +29,7 @@ type BaseSuite struct {
 	restClient            *mocks.MockRestClientInterface
 	execClient            exec.Executor
 	mockPodGetter         *mocks.MockPodInterface
+	mockJobs              *mocks.MockJobInterface
 	mockRemoteCmdExecutor *execmocks.MockExecutor
 	suiteMocks            *suiteMocks
 
@@ -64,6 +65,12 @@ func (s *BaseSuite) setupExecClient(c *gc.C) *gomock.Controller {
 	s.mockPodGetter = mocks.NewMockPodInterface(ctrl)
 	mockCoreV1.EXPECT().Pods(s.namespace).AnyTimes().Return(s.mockPodGetter)
 
+	mockBatchV1 := mocks.NewMockBatchV1Interface(ctrl)
+	s.k8sClient.EXPECT().BatchV1().AnyTimes().Return(mockBatchV1)
+
+	s.mockJobs = mocks.NewMockJobInterface(ctrl)
+	mockBatchV1.EXPECT().Jobs(s.namespace).AnyTimes().Return(s.mockJobs)
+
 	s.mockRemoteCmdExecutor = execmocks.NewMockExecutor(ctrl)
 
 	s.suiteMocks = newSuiteMocks(ctrl)
@@ -45,10 +45,12 @@ type client struct {
 	podGetter typedcorev1.PodInterface
 }
 
-// Executor provides the API to exec or cp on a pod inside the cluster.
+// Executor provides the API to exec or cp on a pod inside the cluster,
+// or to run a job based on a pod.
 type Executor interface {
 	Exec(params ExecParams, cancel <-chan struct{}) error
 	Copy(params CopyParams, cancel <-chan struct{}) error
+	RunJob(params JobParams, cancel <-chan struct{}) error
 }
 
 // NewInCluster returns a in-cluster exec client.
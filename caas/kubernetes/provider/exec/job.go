@@ -0,0 +1,434 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package exec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/exec"
+
+	"github.com/juju/juju/caas"
+)
+
+const (
+	jobPollDelay = time.Second
+
+	// maxJobNameLength is the maximum length of a label value, as the
+	// job name is used for the job-name label of the Job's pod.
+	maxJobNameLength = 63
+
+	// jobReadyFile is created in the data dir of a Job's pod once
+	// all the files have been copied into it.
+	jobReadyFile = ".juju-job-ready"
+
+	// labelJobID identifies a Job so it can be reattached to after the
+	// operator restarts.
+	labelJobID = "juju-job-id"
+
+	// labelJobPod is the name of the workload pod a Job is based on.
+	labelJobPod = "juju-job-pod"
+)
+
+// JobFile is a file or directory on the host which is copied to Dest
+// in the pod of a Job before the commands are run.
+type JobFile struct {
+	Src  string
+	Dest string
+}
+
+// JobParams holds all the necessary parameters for RunJob.
+type JobParams struct {
+	// ID identifies the Job. If a Job with the same ID is still running,
+	// e.g. because the operator restarted, RunJob reattaches to it rather
+	// than running the commands again.
+	ID string
+
+	Commands      []string
+	Env           []string
+	PodName       string
+	ContainerName string
+	WorkingDir    string
+	Files         []JobFile
+
+	// Stdout receives the logs of the Job's pod.
+	Stdout io.Writer
+}
+
+func (jp *JobParams) validate(podGetter typedcorev1.PodInterface) (err error) {
+	if len(jp.Commands) == 0 {
+		return errors.NotValidf("empty commands")
+	}
+	if jp.ID == "" {
+		return errors.NotValidf("empty job ID")
+	}
+	if jp.PodName, jp.ContainerName, err = getValidatedPodContainer(
+		podGetter, jp.PodName, jp.ContainerName,
+	); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RunJob runs commands to completion in a Kubernetes Job using the image of
+// the specified workload pod. The logs of the Job are written to Stdout and a
+// non zero exit status of the commands is returned as an ExitError.
+// The Job is owned by the workload pod, so it is removed with the pod.
+func (c client) RunJob(params JobParams, cancel <-chan struct{}) error {
+	if err := params.validate(c.podGetter); err != nil {
+		return errors.Trace(err)
+	}
+	pod, err := c.podGetter.Get(params.PodName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	job, jobPodName, err := c.existingJob(pod.GetName(), params.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if job != nil {
+		logger.Debugf("reattaching to job %q of pod %q", job.GetName(), pod.GetName())
+	} else {
+		if job, err = jobSpec(jobName(pod.GetName()), pod, params); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("creating job %q from pod %q for cmd %v", job.GetName(), pod.GetName(), params.Commands)
+		if _, err = c.clientset.BatchV1().Jobs(c.namespace).Create(job); err != nil {
+			return errors.Annotatef(err, "creating job %q", job.GetName())
+		}
+	}
+	defer c.deleteJob(job.GetName())
+
+	if jobPodName == "" {
+		if jobPodName, err = c.waitForJobPod(job.GetName(), cancel); err != nil {
+			return errors.Trace(err)
+		}
+		if err := c.prepareJobPod(jobPodName, job, params.Files, cancel); err != nil {
+			return errors.Annotatef(err, "preparing pod %q of job %q", jobPodName, job.GetName())
+		}
+	}
+
+	containerName := params.ContainerName
+	if _, err := c.waitForJobContainer(jobPodName, containerName, false, cancel); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.streamJobLogs(jobPodName, containerName, params.Stdout, cancel); err != nil {
+		return errors.Trace(err)
+	}
+	state, err := c.waitForJobContainer(jobPodName, containerName, true, cancel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if code := int(state.Terminated.ExitCode); code != 0 {
+		return exec.CodeExitError{
+			Err:  errors.Errorf("job %q terminated with exit code %d", job.GetName(), code),
+			Code: code,
+		}
+	}
+	return nil
+}
+
+// existingJob returns the Job of the specified pod with the specified ID,
+// and the name of the Job's pod, if the commands of the Job have started.
+// A unit runs one action at a time, so any other Job of the pod was left
+// behind by an operator which restarted and is deleted, as is a Job which
+// was interrupted while its files were being copied.
+func (c client) existingJob(podName, id string) (*batchv1.Job, string, error) {
+	jobs, err := c.clientset.BatchV1().Jobs(c.namespace).List(metav1.ListOptions{
+		LabelSelector: labelJobPod + "=" + podName,
+	})
+	if err != nil {
+		return nil, "", errors.Annotatef(err, "listing jobs of pod %q", podName)
+	}
+	var (
+		job        *batchv1.Job
+		jobPodName string
+	)
+	for i, existing := range jobs.Items {
+		if existing.Labels[labelJobID] == id && job == nil {
+			if jobPodName, err = c.startedJobPod(existing.GetName()); err != nil {
+				return nil, "", errors.Trace(err)
+			}
+			if jobPodName != "" {
+				job = &jobs.Items[i]
+				continue
+			}
+		}
+		logger.Infof("deleting orphaned job %q of pod %q", existing.GetName(), podName)
+		c.deleteJob(existing.GetName())
+	}
+	return job, jobPodName, nil
+}
+
+// startedJobPod returns the name of the pod of the specified Job if its
+// workload container has started, or "" otherwise.
+func (c client) startedJobPod(jobName string) (string, error) {
+	pods, err := c.podGetter.List(metav1.ListOptions{
+		LabelSelector: "job-name=" + jobName,
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "listing pods of job %q", jobName)
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running != nil || status.State.Terminated != nil {
+				return pod.GetName(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// deleteJob deletes the specified Job and its pod.
+func (c client) deleteJob(jobName string) {
+	propagationPolicy := metav1.DeletePropagationForeground
+	err := c.clientset.BatchV1().Jobs(c.namespace).Delete(jobName, &metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		logger.Warningf("cannot delete job %q: %v", jobName, err)
+	}
+}
+
+func jobName(podName string) string {
+	suffix := "-job-" + randomString(6, utils.LowerAlpha)
+	if len(podName)+len(suffix) > maxJobNameLength {
+		podName = strings.TrimRight(podName[:maxJobNameLength-len(suffix)], "-")
+	}
+	return podName + suffix
+}
+
+// jobSpec returns a Job which runs the commands in the workload container
+// of the specified pod. The juju init container of the pod is replaced by
+// one which waits for the files of the Job to be copied.
+func jobSpec(jobName string, pod *core.Pod, params JobParams) (*batchv1.Job, error) {
+	var initContainer *core.Container
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == caas.InitContainerName {
+			initContainer = pod.Spec.InitContainers[i].DeepCopy()
+			break
+		}
+	}
+	if initContainer == nil {
+		return nil, errors.NotFoundf("container %q in pod %q", caas.InitContainerName, pod.GetName())
+	}
+	// The juju init container runs in the juju data dir.
+	dataDir := initContainer.WorkingDir
+	initContainer.Command = []string{"/bin/sh"}
+	initContainer.Args = []string{
+		"-c",
+		fmt.Sprintf(
+			caas.JujudStartUpSh,
+			dataDir,
+			"tools",
+			fmt.Sprintf("while [ ! -f %[1]s ]; do sleep 1; done; rm %[1]s", path.Join(dataDir, jobReadyFile)),
+		),
+	}
+
+	var container *core.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == params.ContainerName {
+			container = pod.Spec.Containers[i].DeepCopy()
+			break
+		}
+	}
+	if container == nil {
+		return nil, errors.NotFoundf("container %q in pod %q", params.ContainerName, pod.GetName())
+	}
+	cmd := ""
+	if params.WorkingDir != "" {
+		cmd += fmt.Sprintf("cd %s; ", params.WorkingDir)
+	}
+	if len(params.Env) > 0 {
+		cmd += processEnv(params.Env)
+	}
+	cmd += fmt.Sprintf("exec %s; ", strings.Join(params.Commands, " "))
+	container.Command = []string{"sh", "-c", cmd}
+	container.Args = nil
+	container.Ports = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	container.Lifecycle = nil
+
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: jobName,
+			Labels: map[string]string{
+				labelJobID:  params.ID,
+				labelJobPod: pod.GetName(),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       pod.GetName(),
+				UID:        pod.GetUID(),
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					RestartPolicy:                core.RestartPolicyNever,
+					InitContainers:               []core.Container{*initContainer},
+					Containers:                   []core.Container{*container},
+					Volumes:                      pod.Spec.Volumes,
+					ImagePullSecrets:             pod.Spec.ImagePullSecrets,
+					ServiceAccountName:           pod.Spec.ServiceAccountName,
+					AutomountServiceAccountToken: pod.Spec.AutomountServiceAccountToken,
+					SecurityContext:              pod.Spec.SecurityContext,
+				},
+			},
+		},
+	}, nil
+}
+
+// waitFor calls check until it returns true or an error.
+func waitFor(check func() (bool, error), cancel <-chan struct{}) error {
+	for {
+		done, err := check()
+		if err != nil || done {
+			return errors.Trace(err)
+		}
+		select {
+		case <-cancel:
+			return errors.New("cancelled")
+		case <-time.After(jobPollDelay):
+		}
+	}
+}
+
+// waitForJobPod returns the name of the pod of the specified Job once its
+// init container is running.
+func (c client) waitForJobPod(jobName string, cancel <-chan struct{}) (podName string, err error) {
+	err = waitFor(func() (bool, error) {
+		pods, err := c.podGetter.List(metav1.ListOptions{
+			LabelSelector: "job-name=" + jobName,
+		})
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if len(pods.Items) == 0 {
+			return false, nil
+		}
+		podName = pods.Items[0].GetName()
+		_, _, err = getValidatedPodContainer(c.podGetter, podName, caas.InitContainerName)
+		if _, ok := errors.Cause(err).(*ContainerNotRunningError); ok {
+			return false, nil
+		}
+		return err == nil, errors.Trace(err)
+	}, cancel)
+	return podName, errors.Annotatef(err, "waiting for pod of job %q", jobName)
+}
+
+// prepareJobPod copies the files into the init container of the Job's pod
+// and then lets it complete so the commands of the Job are run.
+func (c client) prepareJobPod(podName string, job *batchv1.Job, files []JobFile, cancel <-chan struct{}) error {
+	initContainer := job.Spec.Template.Spec.InitContainers[0]
+	execInit := func(commands ...string) error {
+		var stdout, stderr bytes.Buffer
+		err := c.Exec(ExecParams{
+			PodName:       podName,
+			ContainerName: initContainer.Name,
+			Commands:      commands,
+			Stdout:        &stdout,
+			Stderr:        &stderr,
+		}, cancel)
+		return errors.Trace(err)
+	}
+	if len(files) > 0 {
+		mkdir := []string{"mkdir", "-p"}
+		for _, f := range files {
+			mkdir = append(mkdir, path.Dir(f.Dest))
+		}
+		if err := execInit(mkdir...); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, f := range files {
+		err := c.Copy(CopyParams{
+			Src: FileResource{
+				Path: f.Src,
+			},
+			Dest: FileResource{
+				Path:          f.Dest,
+				PodName:       podName,
+				ContainerName: initContainer.Name,
+			},
+		}, cancel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(execInit("touch", path.Join(initContainer.WorkingDir, jobReadyFile)))
+}
+
+// waitForJobContainer returns the state of the specified container of a
+// Job's pod once it has started, or once it has terminated if terminated
+// is true.
+func (c client) waitForJobContainer(
+	podName, containerName string, terminated bool, cancel <-chan struct{},
+) (state core.ContainerState, err error) {
+	err = waitFor(func() (bool, error) {
+		pod, err := c.podGetter.Get(podName, metav1.GetOptions{})
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			state = status.State
+			if state.Terminated != nil {
+				return true, nil
+			}
+			return state.Running != nil && !terminated, nil
+		}
+		if pod.Status.Phase == core.PodFailed {
+			return false, errors.Errorf("pod %q failed: %s", podName, pod.Status.Message)
+		}
+		return false, nil
+	}, cancel)
+	return state, errors.Annotatef(err, "waiting for container %q of pod %q", containerName, podName)
+}
+
+// streamJobLogs writes the logs of the specified container to out until
+// the container terminates.
+func (c client) streamJobLogs(podName, containerName string, out io.Writer, cancel <-chan struct{}) error {
+	logs, err := c.podGetter.GetLogs(podName, &core.PodLogOptions{
+		Container: containerName,
+		Follow:    true,
+	}).Stream()
+	if err != nil {
+		return errors.Annotatef(err, "streaming logs of pod %q", podName)
+	}
+	defer logs.Close()
+
+	if out == nil {
+		out = &bytes.Buffer{}
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(out, logs)
+		errChan <- err
+	}()
+	select {
+	case err := <-errChan:
+		return errors.Trace(err)
+	case <-cancel:
+		return errors.New("cancelled")
+	}
+}
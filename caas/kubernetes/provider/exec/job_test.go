@@ -0,0 +1,372 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package exec_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas/kubernetes/provider/exec"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type jobSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&jobSuite{})
+
+type logsTransport struct {
+	logs string
+}
+
+func (t logsTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(t.logs)),
+	}, nil
+}
+
+func (s *jobSuite) workloadPod() *core.Pod {
+	pod := &core.Pod{
+		Spec: core.PodSpec{
+			InitContainers: []core.Container{{
+				Name:       "juju-pod-init",
+				Image:      "operator/image-path",
+				WorkingDir: "/var/lib/juju",
+				Command:    []string{"/bin/sh"},
+				Args:       []string{"-c", "caas-unit-init --wait"},
+				VolumeMounts: []core.VolumeMount{{
+					Name:      "juju-data-dir",
+					MountPath: "/var/lib/juju",
+				}},
+			}},
+			Containers: []core.Container{{
+				Name:  "gitlab",
+				Image: "gitlab/latest",
+				Ports: []core.ContainerPort{{ContainerPort: 80}},
+				ReadinessProbe: &core.Probe{
+					Handler: core.Handler{
+						Exec: &core.ExecAction{Command: []string{"true"}},
+					},
+				},
+				VolumeMounts: []core.VolumeMount{{
+					Name:      "juju-data-dir",
+					MountPath: "/var/lib/juju",
+				}},
+			}},
+			Volumes: []core.Volume{{
+				Name: "juju-data-dir",
+				VolumeSource: core.VolumeSource{
+					EmptyDir: &core.EmptyDirVolumeSource{},
+				},
+			}},
+			ImagePullSecrets: []core.LocalObjectReference{{Name: "gitlab-secret"}},
+		},
+		Status: core.PodStatus{
+			Phase: core.PodRunning,
+			ContainerStatuses: []core.ContainerStatus{
+				{Name: "gitlab", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+			},
+		},
+	}
+	pod.SetName("gitlab-k8s-0")
+	pod.SetUID("gitlab-k8s-0-uid")
+	return pod
+}
+
+func (s *jobSuite) expectedJob() *batchv1.Job {
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gitlab-k8s-0-job-random",
+			Labels: map[string]string{
+				"juju-job-id":  "42",
+				"juju-job-pod": "gitlab-k8s-0",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       "gitlab-k8s-0",
+				UID:        "gitlab-k8s-0-uid",
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					RestartPolicy: core.RestartPolicyNever,
+					InitContainers: []core.Container{{
+						Name:       "juju-pod-init",
+						Image:      "operator/image-path",
+						WorkingDir: "/var/lib/juju",
+						Command:    []string{"/bin/sh"},
+						Args: []string{"-c", `
+export JUJU_DATA_DIR=/var/lib/juju
+export JUJU_TOOLS_DIR=$JUJU_DATA_DIR/tools
+
+mkdir -p $JUJU_TOOLS_DIR
+cp /opt/jujud $JUJU_TOOLS_DIR/jujud
+while [ ! -f /var/lib/juju/.juju-job-ready ]; do sleep 1; done; rm /var/lib/juju/.juju-job-ready
+`[1:]},
+						VolumeMounts: []core.VolumeMount{{
+							Name:      "juju-data-dir",
+							MountPath: "/var/lib/juju",
+						}},
+					}},
+					Containers: []core.Container{{
+						Name:    "gitlab",
+						Image:   "gitlab/latest",
+						Command: []string{"sh", "-c", "cd /var/lib/juju/charm; export AAA=1; exec ./actions/migrate; "},
+						VolumeMounts: []core.VolumeMount{{
+							Name:      "juju-data-dir",
+							MountPath: "/var/lib/juju",
+						}},
+					}},
+					Volumes: []core.Volume{{
+						Name: "juju-data-dir",
+						VolumeSource: core.VolumeSource{
+							EmptyDir: &core.EmptyDirVolumeSource{},
+						},
+					}},
+					ImagePullSecrets: []core.LocalObjectReference{{Name: "gitlab-secret"}},
+				},
+			},
+		},
+	}
+}
+
+func (s *jobSuite) runJob(c *gc.C, exitCode int32) (string, error) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	s.PatchValue(exec.RandomString, func(n int, validRunes []rune) string {
+		return "random"
+	})
+	s.suiteMocks.EXPECT().RemoteCmdExecutorGetter(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(s.mockRemoteCmdExecutor, nil)
+
+	pod := s.workloadPod()
+	job := s.expectedJob()
+	jobPod := core.Pod{
+		Spec: job.Spec.Template.Spec,
+		Status: core.PodStatus{
+			Phase: core.PodPending,
+			InitContainerStatuses: []core.ContainerStatus{
+				{Name: "juju-pod-init", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+			},
+		},
+	}
+	jobPod.SetName("gitlab-k8s-0-job-random-x7k2p")
+	runningJobPod := jobPod
+	runningJobPod.Status = core.PodStatus{
+		Phase: core.PodRunning,
+		ContainerStatuses: []core.ContainerStatus{
+			{Name: "gitlab", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+		},
+	}
+	completedJobPod := jobPod
+	completedJobPod.Status = core.PodStatus{
+		Phase: core.PodSucceeded,
+		ContainerStatuses: []core.ContainerStatus{
+			{Name: "gitlab", State: core.ContainerState{Terminated: &core.ContainerStateTerminated{ExitCode: exitCode}}},
+		},
+	}
+	logsRequest := rest.NewRequestWithClient(
+		&url.URL{Scheme: "http", Host: "localhost", Path: "/"},
+		"",
+		rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+		&http.Client{Transport: logsTransport{logs: "migrating\ndone\n"}},
+	)
+	execRequest := rest.NewRequestWithClient(
+		&url.URL{Path: "/path/"},
+		"",
+		rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+		nil,
+	)
+	propagationPolicy := metav1.DeletePropagationForeground
+
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pod, nil),
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pod, nil),
+		s.mockJobs.EXPECT().List(metav1.ListOptions{LabelSelector: "juju-job-pod=gitlab-k8s-0"}).
+			Return(&batchv1.JobList{}, nil),
+		s.mockJobs.EXPECT().Create(job).
+			Return(job, nil),
+		s.mockPodGetter.EXPECT().List(metav1.ListOptions{LabelSelector: "job-name=gitlab-k8s-0-job-random"}).
+			Return(&core.PodList{Items: []core.Pod{jobPod}}, nil),
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0-job-random-x7k2p", metav1.GetOptions{}).
+			Return(&jobPod, nil),
+
+		// touch the ready file.
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0-job-random-x7k2p", metav1.GetOptions{}).
+			Return(&jobPod, nil),
+		s.restClient.EXPECT().Post().Return(execRequest),
+		s.mockRemoteCmdExecutor.EXPECT().Stream(gomock.Any()).Return(nil),
+
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0-job-random-x7k2p", metav1.GetOptions{}).
+			Return(&runningJobPod, nil),
+		s.mockPodGetter.EXPECT().GetLogs("gitlab-k8s-0-job-random-x7k2p", &core.PodLogOptions{
+			Container: "gitlab",
+			Follow:    true,
+		}).Return(logsRequest),
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0-job-random-x7k2p", metav1.GetOptions{}).
+			Return(&completedJobPod, nil),
+		s.mockJobs.EXPECT().Delete("gitlab-k8s-0-job-random", &metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		}).Return(nil),
+	)
+
+	var stdout bytes.Buffer
+	params := exec.JobParams{
+		ID:         "42",
+		Commands:   []string{"./actions/migrate"},
+		Env:        []string{"AAA=1"},
+		PodName:    "gitlab-k8s-0",
+		WorkingDir: "/var/lib/juju/charm",
+		Stdout:     &stdout,
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.execClient.RunJob(params, nil)
+	}()
+
+	select {
+	case err := <-errChan:
+		return stdout.String(), err
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for RunJob return")
+	}
+	return "", nil
+}
+
+func (s *jobSuite) TestRunJob(c *gc.C) {
+	logs, err := s.runJob(c, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(logs, gc.Equals, "migrating\ndone\n")
+}
+
+func (s *jobSuite) TestRunJobExitCode(c *gc.C) {
+	logs, err := s.runJob(c, 3)
+	c.Assert(err, gc.ErrorMatches, `job "gitlab-k8s-0-job-random" terminated with exit code 3`)
+	exitErr, ok := errors.Cause(err).(exec.ExitError)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(exitErr.ExitStatus(), gc.Equals, 3)
+	c.Assert(logs, gc.Equals, "migrating\ndone\n")
+}
+
+func (s *jobSuite) TestRunJobMissingInitContainer(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	pod := s.workloadPod()
+	pod.Spec.InitContainers = nil
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pod, nil),
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pod, nil),
+		s.mockJobs.EXPECT().List(metav1.ListOptions{LabelSelector: "juju-job-pod=gitlab-k8s-0"}).
+			Return(&batchv1.JobList{}, nil),
+	)
+
+	err := s.execClient.RunJob(exec.JobParams{
+		ID:       "42",
+		Commands: []string{"./actions/migrate"},
+		PodName:  "gitlab-k8s-0",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `container "juju-pod-init" in pod "gitlab-k8s-0" not found`)
+}
+
+func (s *jobSuite) TestRunJobReattach(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	pod := s.workloadPod()
+	job := s.expectedJob()
+	orphan := s.expectedJob()
+	orphan.SetName("gitlab-k8s-0-job-orphan")
+	orphan.Labels["juju-job-id"] = "41"
+	jobPod := core.Pod{
+		Spec: job.Spec.Template.Spec,
+		Status: core.PodStatus{
+			Phase: core.PodSucceeded,
+			ContainerStatuses: []core.ContainerStatus{
+				{Name: "gitlab", State: core.ContainerState{Terminated: &core.ContainerStateTerminated{ExitCode: 0}}},
+			},
+		},
+	}
+	jobPod.SetName("gitlab-k8s-0-job-random-x7k2p")
+	logsRequest := rest.NewRequestWithClient(
+		&url.URL{Scheme: "http", Host: "localhost", Path: "/"},
+		"",
+		rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+		&http.Client{Transport: logsTransport{logs: "migrating\ndone\n"}},
+	)
+	propagationPolicy := metav1.DeletePropagationForeground
+
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pod, nil),
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0", metav1.GetOptions{}).
+			Return(pod, nil),
+		s.mockJobs.EXPECT().List(metav1.ListOptions{LabelSelector: "juju-job-pod=gitlab-k8s-0"}).
+			Return(&batchv1.JobList{Items: []batchv1.Job{*orphan, *job}}, nil),
+		s.mockJobs.EXPECT().Delete("gitlab-k8s-0-job-orphan", &metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		}).Return(nil),
+		s.mockPodGetter.EXPECT().List(metav1.ListOptions{LabelSelector: "job-name=gitlab-k8s-0-job-random"}).
+			Return(&core.PodList{Items: []core.Pod{jobPod}}, nil),
+
+		// The commands aren't run again, only the logs and exit code
+		// are collected.
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0-job-random-x7k2p", metav1.GetOptions{}).
+			Return(&jobPod, nil),
+		s.mockPodGetter.EXPECT().GetLogs("gitlab-k8s-0-job-random-x7k2p", &core.PodLogOptions{
+			Container: "gitlab",
+			Follow:    true,
+		}).Return(logsRequest),
+		s.mockPodGetter.EXPECT().Get("gitlab-k8s-0-job-random-x7k2p", metav1.GetOptions{}).
+			Return(&jobPod, nil),
+		s.mockJobs.EXPECT().Delete("gitlab-k8s-0-job-random", &metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		}).Return(nil),
+	)
+
+	var stdout bytes.Buffer
+	err := s.execClient.RunJob(exec.JobParams{
+		ID:       "42",
+		Commands: []string{"./actions/migrate"},
+		PodName:  "gitlab-k8s-0",
+		Stdout:   &stdout,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout.String(), gc.Equals, "migrating\ndone\n")
+}
+
+func (s *jobSuite) TestRunJobMissingID(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	err := s.execClient.RunJob(exec.JobParams{
+		Commands: []string{"./actions/migrate"},
+		PodName:  "gitlab-k8s-0",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "empty job ID not valid")
+}
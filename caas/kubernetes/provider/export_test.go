@@ -134,6 +134,10 @@ func (k *kubernetesClient) FileSetToVolume(
 	return k.fileSetToVolume(appName, annotations, workloadSpec, fileSet, cfgMapName)
 }
 
+func (k *kubernetesClient) UpdateNetworkPolicyPorts(svc *core.Service) error {
+	return k.updateNetworkPolicyPorts(svc)
+}
+
 func (k *kubernetesClient) ConfigurePodFiles(
 	appName string,
 	annotations map[string]string,
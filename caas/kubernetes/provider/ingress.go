@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"k8s.io/api/extensions/v1beta1"
@@ -15,6 +16,8 @@ import (
 
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/network"
 )
 
 func (k *kubernetesClient) getIngressLabels(appName string) map[string]string {
@@ -127,3 +130,97 @@ func (k *kubernetesClient) deleteIngressResources(appName string) error {
 	}
 	return errors.Trace(err)
 }
+
+const (
+	certManagerIssuerAnnotation        = "cert-manager.io/issuer"
+	certManagerClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+// exposeIngressRules returns the rules of the ingress resource created by
+// juju expose: one for the external hostname and application path, followed
+// by those in the space separated list of additional host[/path] rules.
+// Paths for the same host are grouped into a single rule.
+func exposeIngressRules(host, httpPath, extraRules string, backend v1beta1.IngressBackend) ([]v1beta1.IngressRule, error) {
+	var rules []v1beta1.IngressRule
+	addPath := func(host, path string) {
+		ingPath := v1beta1.HTTPIngressPath{Path: path, Backend: backend}
+		for i, rule := range rules {
+			if rule.Host == host {
+				rules[i].HTTP.Paths = append(rules[i].HTTP.Paths, ingPath)
+				return
+			}
+		}
+		rules = append(rules, v1beta1.IngressRule{
+			Host: host,
+			IngressRuleValue: v1beta1.IngressRuleValue{
+				HTTP: &v1beta1.HTTPIngressRuleValue{
+					Paths: []v1beta1.HTTPIngressPath{ingPath},
+				},
+			},
+		})
+	}
+	addPath(host, httpPath)
+	for _, rule := range strings.Fields(extraRules) {
+		ruleHost, rulePath := rule, "/"
+		if i := strings.Index(rule, "/"); i >= 0 {
+			ruleHost, rulePath = rule[:i], rule[i:]
+		}
+		if ruleHost == "" {
+			return nil, errors.NotValidf("ingress rule %q without a host", rule)
+		}
+		addPath(ruleHost, rulePath)
+	}
+	return rules, nil
+}
+
+// exposeIngressTLS returns the TLS configuration of the ingress resource
+// created by juju expose, adding any cert-manager annotations needed to
+// issue the certificate. If an issuer is set without a secret name, the
+// certificate is stored in a secret named after the application.
+func exposeIngressTLS(
+	deploymentName string, rules []v1beta1.IngressRule, annotations map[string]string, config application.ConfigAttributes,
+) ([]v1beta1.IngressTLS, error) {
+	secretName := config.GetString(IngressTLSSecretConfigKey, "")
+	issuer := config.GetString(IngressCertManagerIssuerConfigKey, "")
+	clusterIssuer := config.GetString(IngressCertManagerClusterIssuerConfigKey, "")
+	if issuer != "" && clusterIssuer != "" {
+		return nil, errors.NotValidf(
+			"setting both %q and %q", IngressCertManagerIssuerConfigKey, IngressCertManagerClusterIssuerConfigKey)
+	}
+	if issuer != "" {
+		annotations[certManagerIssuerAnnotation] = issuer
+	}
+	if clusterIssuer != "" {
+		annotations[certManagerClusterIssuerAnnotation] = clusterIssuer
+	}
+	if secretName == "" && (issuer != "" || clusterIssuer != "") {
+		secretName = deploymentName + "-tls"
+	}
+	if secretName == "" {
+		return nil, nil
+	}
+	hosts := make([]string, len(rules))
+	for i, rule := range rules {
+		hosts[i] = rule.Host
+	}
+	return []v1beta1.IngressTLS{{
+		Hosts:      hosts,
+		SecretName: secretName,
+	}}, nil
+}
+
+// getIngressAddresses returns the addresses assigned to the ingress
+// resource by the ingress controller.
+func getIngressAddresses(ing *v1beta1.Ingress) []network.ProviderAddress {
+	var addrs []network.ProviderAddress
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		addr := lb.IP
+		if addr == "" {
+			addr = lb.Hostname
+		}
+		if addr != "" {
+			addrs = append(addrs, network.NewScopedProviderAddress(addr, network.ScopePublic))
+		}
+	}
+	return addrs
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
 )
@@ -297,3 +298,150 @@ func (s *K8sBrokerSuite) TestEnsureServiceIngressResourcesUpdateConflictWithExis
 		s.mockIngressInterface.EXPECT().Get("test-ingress", v1.GetOptions{}).Return(existingNonJujuManagedIngress, nil),
 	)
 }
+
+func (s *K8sBrokerSuite) TestGetServiceSvcFoundWithIngress(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+		},
+		Spec: core.ServiceSpec{
+			Type:      core.ServiceTypeClusterIP,
+			ClusterIP: "10.1.1.1",
+		},
+	}
+	svc.SetUID("uid-xxxxx")
+	ing := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Status: extensionsv1beta1.IngressStatus{
+			LoadBalancer: core.LoadBalancerStatus{
+				Ingress: []core.LoadBalancerIngress{{IP: "1.2.3.4"}, {Hostname: "lb.example.com"}},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app=app-name"}).
+			Return(&core.ServiceList{Items: []core.Service{svc}}, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(ing, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	caasSvc, err := s.broker.GetService("app-name", caas.ModeWorkload, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caasSvc, gc.DeepEquals, &caas.Service{
+		Id: "uid-xxxxx",
+		Addresses: network.ProviderAddresses{
+			network.NewScopedProviderAddress("1.2.3.4", network.ScopePublic),
+			network.NewScopedProviderAddress("lb.example.com", network.ScopePublic),
+			network.NewScopedProviderAddress("10.1.1.1", network.ScopeCloudLocal),
+		},
+	})
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithTLSAndRules(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+	backend := extensionsv1beta1.IngressBackend{
+		ServiceName: "app-name", ServicePort: intstr.FromInt(8080),
+	}
+	ingress := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":  "",
+				"ingress.kubernetes.io/ssl-redirect":    "false",
+				"kubernetes.io/ingress.class":           "traefik",
+				"kubernetes.io/ingress.allow-http":      "false",
+				"ingress.kubernetes.io/ssl-passthrough": "false",
+				"cert-manager.io/cluster-issuer":        "letsencrypt",
+			},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{{
+				Host: "www.example.com",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: []extensionsv1beta1.HTTPIngressPath{
+							{Path: "/", Backend: backend},
+							{Path: "/static", Backend: backend},
+						},
+					},
+				},
+			}, {
+				Host: "api.example.com",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: []extensionsv1beta1.HTTPIngressPath{
+							{Path: "/v1", Backend: backend},
+						},
+					},
+				},
+			}},
+			TLS: []extensionsv1beta1.IngressTLS{{
+				Hosts:      []string{"www.example.com", "api.example.com"},
+				SecretName: "app-name-tls",
+			}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockIngressInterface.EXPECT().Create(ingress).Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":                         "www.example.com",
+		"kubernetes-ingress-class":                       "traefik",
+		"kubernetes-ingress-rules":                       "www.example.com/static api.example.com/v1",
+		"kubernetes-ingress-cert-manager-cluster-issuer": "letsencrypt",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceBothIssuers(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(svc, nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":                         "www.example.com",
+		"kubernetes-ingress-cert-manager-issuer":         "local",
+		"kubernetes-ingress-cert-manager-cluster-issuer": "letsencrypt",
+	})
+	c.Assert(err, gc.ErrorMatches, `setting both "kubernetes-ingress-cert-manager-issuer" and "kubernetes-ingress-cert-manager-cluster-issuer" not valid`)
+}
@@ -167,6 +167,9 @@ type kubernetesClient struct {
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface,DaemonSetInterface
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
+//go:generate mockgen -package mocks -destination mocks/autoscalingv2beta2_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v2beta2 AutoscalingV2beta2Interface,HorizontalPodAutoscalerInterface
+//go:generate mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1 BatchV1Interface,JobInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
 //go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleInterface,RoleBindingInterface
 //go:generate mockgen -package mocks -destination mocks/apiextensions_mock.go k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1 ApiextensionsV1beta1Interface,CustomResourceDefinitionInterface
@@ -649,6 +652,17 @@ func (k *kubernetesClient) GetService(appName string, mode caas.DeploymentMode,
 		appName = k.operatorName(appName)
 	}
 	deploymentName := k.deploymentName(appName)
+	if mode == caas.ModeWorkload && result.Id != "" {
+		// The addresses of the ingress resource created by juju expose
+		// come first, as that is how users reach an exposed application.
+		ing, err := k.getIngress(deploymentName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			result.Addresses = append(getIngressAddresses(ing), result.Addresses...)
+		}
+	}
 	statefulsets := k.client().AppsV1().StatefulSets(k.namespace)
 	ss, err := statefulsets.Get(deploymentName, v1.GetOptions{})
 	if err != nil && !k8serrors.IsNotFound(err) {
@@ -724,6 +738,12 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	logger.Debugf("deleting application %s", appName)
 
 	deploymentName := k.deploymentName(appName)
+	if err := k.deleteHorizontalPodAutoscaler(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteNetworkPolicy(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	if err := k.deleteService(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
@@ -1085,18 +1105,23 @@ func (k *kubernetesClient) EnsureService(
 	}
 
 	numPods := int32(numUnits)
+	replicas := &numPods
+	if workloadSpec.Service != nil && workloadSpec.Service.Autoscaling != nil {
+		// The autoscaler manages the number of pods.
+		replicas = nil
+	}
 	switch params.Deployment.DeploymentType {
 	case caas.DeploymentStateful:
 		if err := k.configureHeadlessService(appName, deploymentName, annotations.Copy()); err != nil {
 			return errors.Annotate(err, "creating or updating headless service")
 		}
 		cleanups = append(cleanups, func() { _ = k.deleteService(headlessServiceName(deploymentName)) })
-		if err := k.configureStatefulSet(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+		if err := k.configureStatefulSet(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, replicas, params.Filesystems); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { _ = k.deleteDeployment(appName) })
 	case caas.DeploymentStateless:
-		cleanUpDeployment, err := k.configureDeployment(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, &numPods, params.Filesystems)
+		cleanUpDeployment, err := k.configureDeployment(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, replicas, params.Filesystems)
 		cleanups = append(cleanups, cleanUpDeployment...)
 		if err != nil {
 			return errors.Annotate(err, "creating or updating Deployment")
@@ -1111,6 +1136,9 @@ func (k *kubernetesClient) EnsureService(
 		// This should never happend because we have validated both in this method and in `charm.v6`.
 		return errors.NotSupportedf("deployment type %q", params.Deployment.DeploymentType)
 	}
+	if err := k.configureAutoscaler(appName, deploymentName, params.Deployment.DeploymentType, annotations.Copy(), workloadSpec); err != nil {
+		return errors.Annotate(err, "creating or updating HorizontalPodAutoscaler")
+	}
 	return nil
 }
 
@@ -1120,6 +1148,11 @@ func validateDeploymentType(t caas.DeploymentType, params *caas.ServiceParams, w
 			return errors.NewNotValid(nil, fmt.Sprintf("ScalePolicy is only supported for %s applications", caas.DeploymentStateful))
 		}
 	}
+	if t == caas.DeploymentDaemon {
+		if workloadSpec.Service != nil && workloadSpec.Service.Autoscaling != nil {
+			return errors.NewNotValid(nil, fmt.Sprintf("autoscaling is not supported for %s applications", caas.DeploymentDaemon))
+		}
+	}
 	return nil
 }
 
@@ -1493,6 +1526,16 @@ func (k *kubernetesClient) configureDeployment(
 	if err != nil {
 		return cleanUps, errors.Trace(err)
 	}
+	if replicas == nil {
+		// Keep the number of pods chosen by the autoscaler; an update
+		// without replicas would reset it to 1.
+		existing, err := k.getDeployment(deploymentName)
+		if err == nil {
+			replicas = existing.Spec.Replicas
+		} else if !errors.IsNotFound(err) {
+			return cleanUps, errors.Trace(err)
+		}
+	}
 	deployment := &apps.Deployment{
 		ObjectMeta: v1.ObjectMeta{
 			Name:   deploymentName,
@@ -1708,7 +1751,10 @@ func (k *kubernetesClient) configureService(
 			ExternalName:             config.GetString(serviceExternalNameKey, ""),
 		},
 	}
-	return k.ensureK8sService(service)
+	if err := k.ensureK8sService(service); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(k.updateNetworkPolicyPorts(service), "updating network policy ports")
 }
 
 func (k *kubernetesClient) configureHeadlessService(
@@ -1769,7 +1815,7 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if host == "" {
 		return errors.Errorf("external hostname required")
 	}
-	ingressClass := config.GetString(ingressClassKey, defaultIngressClass)
+	ingressClass := config.GetString(IngressClassConfigKey, defaultIngressClass)
 	ingressSSLRedirect := config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)
 	ingressSSLPassthrough := config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)
 	ingressAllowHTTP := config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)
@@ -1789,29 +1835,33 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
+	backend := v1beta1.IngressBackend{
+		ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort,
+	}
+	rules, err := exposeIngressRules(host, httpPath, config.GetString(IngressRulesConfigKey, ""), backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	annotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
+		"kubernetes.io/ingress.class":           ingressClass,
+		"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
+		"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
+	}
+	tls, err := exposeIngressTLS(deploymentName, rules, annotations, config)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	spec := &v1beta1.Ingress{
 		ObjectMeta: v1.ObjectMeta{
-			Name:   deploymentName,
-			Labels: k8slabels.Merge(resourceTags, k.getIngressLabels(appName)),
-			Annotations: map[string]string{
-				"ingress.kubernetes.io/rewrite-target":  "",
-				"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
-				"kubernetes.io/ingress.class":           ingressClass,
-				"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
-				"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
-			},
+			Name:        deploymentName,
+			Labels:      k8slabels.Merge(resourceTags, k.getIngressLabels(appName)),
+			Annotations: annotations,
 		},
 		Spec: v1beta1.IngressSpec{
-			Rules: []v1beta1.IngressRule{{
-				Host: host,
-				IngressRuleValue: v1beta1.IngressRuleValue{
-					HTTP: &v1beta1.HTTPIngressRuleValue{
-						Paths: []v1beta1.HTTPIngressPath{{
-							Path: httpPath,
-							Backend: v1beta1.IngressBackend{
-								ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
-						}}},
-				}}},
+			Rules: rules,
+			TLS:   tls,
 		},
 	}
 	// TODO(caas): refactor juju expose to solve potential conflict with ingress definition in podspec.
@@ -2000,8 +2050,14 @@ func (k *kubernetesClient) WatchService(appName string, mode caas.DeploymentMode
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The ingress resource is watched so that its address is reported
+	// once the ingress controller has assigned it.
+	w3, err := k.newWatcher(factory.Extensions().V1beta1().Ingresses().Informer(), appName, k.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
-	return watcher.NewMultiNotifyWatcher(w1, w2), nil
+	return watcher.NewMultiNotifyWatcher(w1, w2, w3), nil
 }
 
 // legacyJujuPVNameRegexp matches how Juju labels persistent volumes.
@@ -2336,6 +2392,7 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 		if !ok {
 			return nil, errors.Errorf("unexpected kubernetes pod spec type %T", podSpec.ProviderPod)
 		}
+		spec.Pod.TopologySpreadConstraints = pSpec.TopologySpreadConstraints
 
 		k8sResources := pSpec.KubernetesResources
 		if k8sResources != nil {
@@ -2401,8 +2458,19 @@ func populateContainerDetails(deploymentName string, pod *core.PodSpec, podConta
 		if c.ProviderContainer == nil {
 			continue
 		}
-		spec, ok := c.ProviderContainer.(*k8sspecs.K8sContainerSpec)
-		if !ok {
+		var spec *k8sspecs.K8sContainerSpec
+		switch providerSpec := c.ProviderContainer.(type) {
+		case *k8sspecs.K8sContainerSpec:
+			spec = providerSpec
+		case *k8sspecs.K8sContainerSpecV4:
+			spec = &providerSpec.K8sContainerSpec
+			if providerSpec.StartupProbe != nil {
+				pc.StartupProbe = providerSpec.StartupProbe
+			}
+			if providerSpec.Lifecycle != nil {
+				pc.Lifecycle = providerSpec.Lifecycle
+			}
+		default:
 			return errors.Errorf("unexpected kubernetes container spec type %T", c.ProviderContainer)
 		}
 		if spec.LivenessProbe != nil {
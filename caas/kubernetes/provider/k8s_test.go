@@ -210,6 +210,65 @@ func (s *K8sSuite) TestPrepareWorkloadSpecNoConfigConfig(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestPrepareWorkloadSpecLifecycleAndTopologySpread(c *gc.C) {
+	podSpec := specs.PodSpec{}
+	podSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		TopologySpreadConstraints: []core.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: core.ScheduleAnyway,
+		}},
+	}
+	podSpec.Containers = []specs.ContainerSpec{
+		{
+			Name:  "test",
+			Ports: []specs.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+			Image: "juju/image",
+			ProviderContainer: &k8sspecs.K8sContainerSpecV4{
+				StartupProbe: &core.Probe{
+					FailureThreshold: 30,
+					Handler:          core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/started"}},
+				},
+				Lifecycle: &core.Lifecycle{
+					PreStop: &core.Handler{Exec: &core.ExecAction{Command: []string{"stop"}}},
+				},
+			},
+		},
+	}
+
+	spec, err := provider.PrepareWorkloadSpec("app-name", "app-name", &podSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		TopologySpreadConstraints: []core.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: core.ScheduleAnyway,
+		}},
+		InitContainers: initContainers(),
+		Containers: []core.Container{
+			{
+				Name:  "test",
+				Image: "juju/image",
+				Ports: []core.ContainerPort{{ContainerPort: int32(80), Protocol: core.ProtocolTCP}},
+				SecurityContext: &core.SecurityContext{
+					RunAsNonRoot:             boolPtr(false),
+					ReadOnlyRootFilesystem:   boolPtr(false),
+					AllowPrivilegeEscalation: boolPtr(true),
+				},
+				StartupProbe: &core.Probe{
+					FailureThreshold: 30,
+					Handler:          core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/started"}},
+				},
+				Lifecycle: &core.Lifecycle{
+					PreStop: &core.Handler{Exec: &core.ExecAction{Command: []string{"stop"}}},
+				},
+				VolumeMounts: dataVolumeMounts(),
+			},
+		},
+		Volumes: dataVolumes(),
+	})
+}
+
 func (s *K8sSuite) TestPrepareWorkloadSpecWithEnvAndEnvFrom(c *gc.C) {
 
 	podSpec := specs.PodSpec{
@@ -2333,15 +2392,20 @@ func (s *K8sBrokerSuite) assertGetService(c *gc.C, mode caas.DeploymentMode, exp
 	}
 	svc.SetUID("uid-xxxxx")
 
-	gomock.InOrder(
-		append([]*gomock.Call{
-			s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: selector}).
-				Return(&core.ServiceList{Items: []core.Service{svc}}, nil),
+	calls := []*gomock.Call{
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: selector}).
+			Return(&core.ServiceList{Items: []core.Service{svc}}, nil),
 
-			s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	}
+	if mode == caas.ModeWorkload {
+		calls = append(calls,
+			s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).
 				Return(nil, s.k8sNotFoundError()),
-		}, assertCalls...)...,
-	)
+		)
+	}
+	gomock.InOrder(append(calls, assertCalls...)...)
 
 	caasSvc, err := s.broker.GetService("app-name", mode, false)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"reflect"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// networkPolicySpec returns the network policy which only allows ingress to
+// the specified ports of the pods of the specified application from the
+// workload and operator pods of the application itself and of the
+// applications related to it.
+func networkPolicySpec(
+	appName, deploymentName string, relatedApps []string, ports []networkingv1.NetworkPolicyPort,
+) *networkingv1.NetworkPolicy {
+	apps := set.NewStrings(relatedApps...)
+	apps.Add(appName)
+	allowed := apps.SortedValues()
+	peer := func(label string) networkingv1.NetworkPolicyPeer {
+		return networkingv1.NetworkPolicyPeer{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      label,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   allowed,
+				}},
+			},
+		}
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: map[string]string{labelApplication: appName},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{
+					peer(labelApplication),
+					peer(labelOperator),
+				},
+				Ports: ports,
+			}},
+		},
+	}
+}
+
+// networkPolicyPorts returns the pod ports behind the service of the
+// specified application, which are the ports its charm declares. An
+// application without a service has no declared ports, and no ports are
+// returned. The ports are filled in by EnsureService once the service is
+// created.
+func (k *kubernetesClient) networkPolicyPorts(deploymentName string) ([]networkingv1.NetworkPolicyPort, error) {
+	svc, err := k.client().CoreV1().Services(k.namespace).Get(deploymentName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return servicePolicyPorts(svc), nil
+}
+
+// servicePolicyPorts returns the pod ports behind the specified service.
+func servicePolicyPorts(svc *core.Service) []networkingv1.NetworkPolicyPort {
+	var ports []networkingv1.NetworkPolicyPort
+	for _, sp := range svc.Spec.Ports {
+		// The target port is only set when it differs from the
+		// service port.
+		port := sp.TargetPort
+		if port == (intstr.IntOrString{}) {
+			port = intstr.FromInt(int(sp.Port))
+		}
+		protocol := sp.Protocol
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+	return ports
+}
+
+// updateNetworkPolicyPorts restricts the network policy of the
+// application behind the specified service, if it has one, to the
+// service's current ports. The policy may have been written before
+// the service existed, or the charm may have changed its ports.
+func (k *kubernetesClient) updateNetworkPolicyPorts(svc *core.Service) error {
+	policies := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	policy, err := policies.Get(svc.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// Network policies aren't enabled for the model.
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ports := servicePolicyPorts(svc)
+	changed := false
+	for i, rule := range policy.Spec.Ingress {
+		if !reflect.DeepEqual(rule.Ports, ports) {
+			policy.Spec.Ingress[i].Ports = ports
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	logger.Debugf("updating ports of network policy for %s", svc.Name)
+	_, err = policies.Update(policy)
+	return errors.Trace(err)
+}
+
+// EnsureNetworkPolicy restricts ingress to the pods of the specified
+// application so that only the application itself and the specified
+// related applications can connect to them, on the ports the
+// application declares.
+func (k *kubernetesClient) EnsureNetworkPolicy(appName string, relatedApps []string) error {
+	logger.Debugf("creating/updating network policy for %s allowing %v", appName, relatedApps)
+	deploymentName := k.deploymentName(appName)
+	ports, err := k.networkPolicyPorts(deploymentName)
+	if err != nil {
+		return errors.Annotatef(err, "getting ports of %q", appName)
+	}
+	spec := networkPolicySpec(appName, deploymentName, relatedApps, ports)
+	policies := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	_, err = policies.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = policies.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+// DeleteNetworkPolicy removes any ingress restriction from the pods of the
+// specified application.
+func (k *kubernetesClient) DeleteNetworkPolicy(appName string) error {
+	logger.Debugf("deleting network policy for %s", appName)
+	return errors.Trace(k.deleteNetworkPolicy(k.deploymentName(appName)))
+}
+
+func (k *kubernetesClient) deleteNetworkPolicy(name string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func (s *K8sBrokerSuite) networkPolicy(appName string, ports []networkingv1.NetworkPolicyPort, allowed ...string) *networkingv1.NetworkPolicy {
+	peer := func(label string) networkingv1.NetworkPolicyPeer {
+		return networkingv1.NetworkPolicyPeer{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      label,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   allowed,
+				}},
+			},
+		}
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   appName,
+			Labels: map[string]string{"juju-app": appName},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": appName},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{
+					peer("juju-app"),
+					peer("juju-operator"),
+				},
+				Ports: ports,
+			}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyCreate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(8080), Protocol: core.ProtocolTCP},
+				{Port: 53, Protocol: core.ProtocolUDP},
+			},
+		},
+	}
+	tcp, udp := core.ProtocolTCP, core.ProtocolUDP
+	port8080, port53 := intstr.FromInt(8080), intstr.FromInt(53)
+	policy := s.networkPolicy("app-name", []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: &port8080},
+		{Protocol: &udp, Port: &port53},
+	}, "app-name", "mariadb", "redis")
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockNetworkPolicies.EXPECT().Update(policy).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(policy).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", []string{"redis", "mariadb"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyUpdate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	// Without a service, the application has no declared ports.
+	policy := s.networkPolicy("app-name", nil, "app-name")
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Update(policy).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestUpdateNetworkPolicyPorts(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "gitlab"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(8080), Protocol: core.ProtocolTCP},
+			},
+		},
+	}
+	// The policy was written before the service existed.
+	policy := s.networkPolicy("gitlab", nil, "gitlab", "mariadb")
+	tcp, port8080 := core.ProtocolTCP, intstr.FromInt(8080)
+	updated := s.networkPolicy("gitlab", []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: &port8080},
+	}, "gitlab", "mariadb")
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Get("gitlab", v1.GetOptions{}).
+			Return(policy, nil),
+		s.mockNetworkPolicies.EXPECT().Update(updated).
+			Return(updated, nil),
+	)
+
+	err := s.broker.UpdateNetworkPolicyPorts(svc)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestUpdateNetworkPolicyPortsUnchanged(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "gitlab"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Port: 80, Protocol: core.ProtocolTCP},
+			},
+		},
+	}
+	tcp, port80 := core.ProtocolTCP, intstr.FromInt(80)
+	policy := s.networkPolicy("gitlab", []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: &port80},
+	}, "gitlab")
+	s.mockNetworkPolicies.EXPECT().Get("gitlab", v1.GetOptions{}).
+		Return(policy, nil)
+
+	err := s.broker.UpdateNetworkPolicyPorts(svc)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestUpdateNetworkPolicyPortsNoPolicy(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{ObjectMeta: v1.ObjectMeta{Name: "gitlab"}}
+	s.mockNetworkPolicies.EXPECT().Get("gitlab", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+
+	err := s.broker.UpdateNetworkPolicyPorts(svc)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -98,6 +98,23 @@ func (k *kubernetesClient) ensureOperatorRBACResources(operatorName string, labe
 					"create",
 				},
 			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods/log"},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{"batch"},
+				Resources: []string{"jobs"},
+				Verbs: []string{
+					"get",
+					"list",
+					"create",
+					"delete",
+				},
+			},
 		},
 	})
 	cleanUps = append(cleanUps, rCleanups...)
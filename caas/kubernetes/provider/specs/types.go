@@ -19,7 +19,7 @@ var logger = loggo.GetLogger("juju.kubernetes.provider.specs")
 
 type (
 	// K8sPodSpec is the current k8s pod spec.
-	K8sPodSpec = K8sPodSpecV3
+	K8sPodSpec = K8sPodSpecV4
 )
 
 type k8sContainer struct {
@@ -185,6 +185,8 @@ type PodSpecConverter interface {
 
 func getParser(specVersion specs.Version) (parserType, error) {
 	switch specVersion {
+	case specs.Version4:
+		return parsePodSpecV4, nil
 	case specs.Version3:
 		return parsePodSpecV3, nil
 	case specs.Version2:
@@ -47,7 +47,9 @@ func (p podSpecV3) ToLatest() *specs.PodSpec {
 	pSpec.Service = p.caaSSpecV3.Service
 	pSpec.ConfigMaps = p.caaSSpecV3.ConfigMaps
 	pSpec.ServiceAccount = p.caaSSpecV3.ServiceAccount
-	pSpec.ProviderPod = &p.K8sPodSpecV3
+	pSpec.ProviderPod = &K8sPodSpec{
+		KubernetesResources: p.K8sPodSpecV3.KubernetesResources,
+	}
 	return pSpec
 }
 
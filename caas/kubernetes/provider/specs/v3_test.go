@@ -161,6 +161,10 @@ service:
   scalePolicy: serial
   annotations:
     foo: bar
+  autoscaling:
+    minReplicas: 2
+    maxReplicas: 10
+    targetCPUUtilizationPercentage: 80
 serviceAccount:
   automountServiceAccountToken: true
   roles:
@@ -379,6 +383,11 @@ foo: bar
 		pSpecs.Service = &specs.ServiceSpec{
 			ScalePolicy: "serial",
 			Annotations: map[string]string{"foo": "bar"},
+			Autoscaling: &specs.AutoscalingSpec{
+				MinReplicas:                    2,
+				MaxReplicas:                    10,
+				TargetCPUUtilizationPercentage: 80,
+			},
 		}
 		pSpecs.ConfigMaps = map[string]specs.ConfigMap{
 			"mydata": {
@@ -1762,6 +1771,23 @@ bar: a-bad-guy
 	c.Assert(err, gc.ErrorMatches, `json: unknown field "bar"`)
 }
 
+func (s *v3SpecsSuite) TestStartupProbeNotSupported(c *gc.C) {
+	specStr := version3Header + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    kubernetes:
+      startupProbe:
+        failureThreshold: 30
+        httpGet:
+          path: /started
+          port: 8080
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `json: unknown field "startupProbe"`)
+}
+
 func float64Ptr(f float64) *float64 {
 	return &f
 }
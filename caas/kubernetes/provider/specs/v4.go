@@ -0,0 +1,218 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+type caaSSpecV4 = specs.PodSpecV4
+
+type podSpecV4 struct {
+	caaSSpecV4      `json:",inline" yaml:",inline"`
+	K8sPodSpecV4    `json:",inline" yaml:",inline"`
+	k8sContainersV4 `json:",inline" yaml:",inline"`
+}
+
+// Validate is defined on ProviderPod.
+func (p podSpecV4) Validate() error {
+	if err := p.K8sPodSpecV4.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.k8sContainersV4.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (p podSpecV4) ToLatest() *specs.PodSpec {
+	pSpec := &specs.PodSpec{}
+	pSpec.Version = specs.CurrentVersion
+	for _, c := range p.Containers {
+		pSpec.Containers = append(pSpec.Containers, c.ToContainerSpec())
+	}
+	for _, c := range p.InitContainers {
+		spec := c.ToContainerSpec()
+		spec.Init = true
+		pSpec.Containers = append(pSpec.Containers, spec)
+	}
+	pSpec.Service = p.caaSSpecV4.Service
+	pSpec.ConfigMaps = p.caaSSpecV4.ConfigMaps
+	pSpec.ServiceAccount = p.caaSSpecV4.ServiceAccount
+	pSpec.ProviderPod = &p.K8sPodSpecV4
+	return pSpec
+}
+
+// K8sContainerSpecV4 is a subset of v1.Container which defines
+// attributes we expose for charms to set from pod spec v4, which adds
+// startup probes and lifecycle hooks.
+type K8sContainerSpecV4 struct {
+	K8sContainerSpec `json:",inline" yaml:",inline"`
+
+	StartupProbe *core.Probe     `json:"startupProbe,omitempty" yaml:"startupProbe,omitempty"`
+	Lifecycle    *core.Lifecycle `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+}
+
+// Validate validates K8sContainerSpecV4.
+func (c *K8sContainerSpecV4) Validate() error {
+	if err := c.K8sContainerSpec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Lifecycle == nil {
+		return nil
+	}
+	if err := validateLifecycleHandler("postStart", c.Lifecycle.PostStart); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(validateLifecycleHandler("preStop", c.Lifecycle.PreStop))
+}
+
+func validateLifecycleHandler(hook string, h *core.Handler) error {
+	if h == nil {
+		return nil
+	}
+	count := 0
+	if h.Exec != nil {
+		count++
+	}
+	if h.HTTPGet != nil {
+		count++
+	}
+	if h.TCPSocket != nil {
+		count++
+	}
+	if count != 1 {
+		return errors.NewNotValid(nil, fmt.Sprintf("%s hook must specify exactly one of exec, httpGet or tcpSocket", hook))
+	}
+	return nil
+}
+
+// k8sContainerV4 has the kubernetes specific attributes of a container,
+// like probes, lifecycle hooks and the security context, at the top level
+// rather than in a "kubernetes" section.
+type k8sContainerV4 struct {
+	specs.ContainerSpec `json:",inline" yaml:",inline"`
+	K8sContainerSpecV4  `json:",inline" yaml:",inline"`
+}
+
+// Validate validates k8sContainerV4.
+func (c *k8sContainerV4) Validate() error {
+	if err := c.ContainerSpec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Init {
+		return errors.NewNotValid(nil, fmt.Sprintf("container %q: use initContainers instead of init", c.Name))
+	}
+	return errors.Trace(c.K8sContainerSpecV4.Validate())
+}
+
+func (c *k8sContainerV4) ToContainerSpec() specs.ContainerSpec {
+	result := specs.ContainerSpec{
+		ImageDetails:    c.ImageDetails,
+		Name:            c.Name,
+		Image:           c.Image,
+		Ports:           c.Ports,
+		Command:         c.Command,
+		Args:            c.Args,
+		WorkingDir:      c.WorkingDir,
+		EnvConfig:       c.EnvConfig,
+		VolumeConfig:    c.VolumeConfig,
+		ImagePullPolicy: c.ImagePullPolicy,
+	}
+	if c.K8sContainerSpecV4 != (K8sContainerSpecV4{}) {
+		k8sSpec := c.K8sContainerSpecV4
+		result.ProviderContainer = &k8sSpec
+	}
+	return result
+}
+
+type k8sContainersV4 struct {
+	Containers     []k8sContainerV4 `json:"containers" yaml:"containers"`
+	InitContainers []k8sContainerV4 `json:"initContainers,omitempty" yaml:"initContainers,omitempty"`
+}
+
+// Validate is defined on ProviderContainer.
+func (cs *k8sContainersV4) Validate() error {
+	if len(cs.Containers) == 0 {
+		return errors.New("require at least one container spec")
+	}
+	names := set.NewStrings()
+	for _, c := range cs.Containers {
+		if err := c.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if names.Contains(c.Name) {
+			return errors.NotValidf("duplicated container name %q", c.Name)
+		}
+		names.Add(c.Name)
+	}
+	for _, c := range cs.InitContainers {
+		if err := c.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if c.Lifecycle != nil || c.LivenessProbe != nil || c.ReadinessProbe != nil || c.StartupProbe != nil {
+			return errors.NewNotValid(nil, fmt.Sprintf("init container %q: lifecycle hooks and probes are not supported", c.Name))
+		}
+		if names.Contains(c.Name) {
+			return errors.NotValidf("duplicated container name %q", c.Name)
+		}
+		names.Add(c.Name)
+	}
+	return nil
+}
+
+// K8sPodSpecV4 is a subset of v1.PodSpec which defines
+// attributes we expose for charms to set.
+type K8sPodSpecV4 struct {
+	TopologySpreadConstraints []core.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" yaml:"topologySpreadConstraints,omitempty"`
+
+	// k8s resources.
+	KubernetesResources *KubernetesResources `json:"kubernetesResources,omitempty" yaml:"kubernetesResources,omitempty"`
+}
+
+// Validate is defined on ProviderPod.
+func (p *K8sPodSpecV4) Validate() error {
+	for _, tsc := range p.TopologySpreadConstraints {
+		if err := validateTopologySpreadConstraint(tsc); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if p.KubernetesResources != nil {
+		if err := p.KubernetesResources.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func validateTopologySpreadConstraint(tsc core.TopologySpreadConstraint) error {
+	if tsc.MaxSkew < 1 {
+		return errors.NotValidf("topology spread constraint maxSkew %d", tsc.MaxSkew)
+	}
+	if tsc.TopologyKey == "" {
+		return errors.New("topology spread constraint topologyKey is missing")
+	}
+	switch tsc.WhenUnsatisfiable {
+	case core.DoNotSchedule, core.ScheduleAnyway:
+	default:
+		return errors.NotSupportedf("topology spread constraint whenUnsatisfiable %q", tsc.WhenUnsatisfiable)
+	}
+	return nil
+}
+
+func parsePodSpecV4(in string) (_ PodSpecConverter, err error) {
+	var spec podSpecV4
+	decoder := newStrictYAMLOrJSONDecoder(strings.NewReader(in), len(in))
+	if err = decoder.Decode(&spec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
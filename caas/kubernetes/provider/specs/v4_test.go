@@ -0,0 +1,266 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/testing"
+)
+
+type v4SpecsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&v4SpecsSuite{})
+
+var version4Header = `
+version: 4
+`[1:]
+
+func (s *v4SpecsSuite) TestParse(c *gc.C) {
+	specStr := version4Header + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    ports:
+      - containerPort: 80
+        name: fred
+        protocol: TCP
+    securityContext:
+      runAsNonRoot: true
+    startupProbe:
+      failureThreshold: 30
+      httpGet:
+        path: /started
+        port: 8080
+    lifecycle:
+      preStop:
+        exec:
+          command: ["/bin/sh", "-c", "gitlab-ctl stop"]
+  - name: sidecar
+    image: sidecar/latest
+initContainers:
+  - name: gitlab-init
+    image: gitlab-init/latest
+    command: ["/bin/sh", "-c", "echo init"]
+    securityContext:
+      privileged: true
+topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: zone
+    whenUnsatisfiable: DoNotSchedule
+    labelSelector:
+      matchLabels:
+        juju-app: gitlab
+service:
+  scalePolicy: serial
+serviceAccount:
+  automountServiceAccountToken: true
+  roles:
+    - rules:
+        - apiGroups: [""]
+          resources: ["pods"]
+          verbs: ["get"]
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := &specs.PodSpec{
+		ServiceAccount: &specs.PrimeServiceAccountSpecV3{
+			ServiceAccountSpecV3: specs.ServiceAccountSpecV3{
+				AutomountServiceAccountToken: boolPtr(true),
+				Roles: []specs.Role{
+					{
+						Rules: []specs.PolicyRule{
+							{
+								APIGroups: []string{""},
+								Resources: []string{"pods"},
+								Verbs:     []string{"get"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected.Version = specs.CurrentVersion
+	expected.Service = &specs.ServiceSpec{ScalePolicy: "serial"}
+	expected.Containers = []specs.ContainerSpec{
+		{
+			Name:  "gitlab",
+			Image: "gitlab/latest",
+			Ports: []specs.ContainerPort{
+				{ContainerPort: 80, Protocol: "TCP", Name: "fred"},
+			},
+			ProviderContainer: &k8sspecs.K8sContainerSpecV4{
+				K8sContainerSpec: k8sspecs.K8sContainerSpec{
+					SecurityContext: &core.SecurityContext{
+						RunAsNonRoot: boolPtr(true),
+					},
+				},
+				StartupProbe: &core.Probe{
+					FailureThreshold: 30,
+					Handler: core.Handler{
+						HTTPGet: &core.HTTPGetAction{
+							Path: "/started",
+							Port: intstr.FromInt(8080),
+						},
+					},
+				},
+				Lifecycle: &core.Lifecycle{
+					PreStop: &core.Handler{
+						Exec: &core.ExecAction{
+							Command: []string{"/bin/sh", "-c", "gitlab-ctl stop"},
+						},
+					},
+				},
+			},
+		}, {
+			Name:  "sidecar",
+			Image: "sidecar/latest",
+		}, {
+			Name:    "gitlab-init",
+			Init:    true,
+			Image:   "gitlab-init/latest",
+			Command: []string{"/bin/sh", "-c", "echo init"},
+			ProviderContainer: &k8sspecs.K8sContainerSpecV4{
+				K8sContainerSpec: k8sspecs.K8sContainerSpec{
+					SecurityContext: &core.SecurityContext{
+						Privileged: boolPtr(true),
+					},
+				},
+			},
+		},
+	}
+	expected.ProviderPod = &k8sspecs.K8sPodSpec{
+		TopologySpreadConstraints: []core.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "zone",
+				WhenUnsatisfiable: core.DoNotSchedule,
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"juju-app": "gitlab"},
+				},
+			},
+		},
+	}
+	c.Assert(spec, jc.DeepEquals, expected)
+}
+
+func (s *v4SpecsSuite) TestValidate(c *gc.C) {
+	for i, tc := range []struct {
+		spec   string
+		errStr string
+	}{
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    init: true
+`[1:],
+			errStr: `container "gitlab": use initContainers instead of init`,
+		},
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+initContainers:
+  - name: gitlab
+    image: gitlab-init/latest
+`[1:],
+			errStr: `duplicated container name "gitlab" not valid`,
+		},
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+initContainers:
+  - name: gitlab-init
+    image: gitlab-init/latest
+    readinessProbe:
+      exec:
+        command: ["true"]
+`[1:],
+			errStr: `init container "gitlab-init": lifecycle hooks and probes are not supported`,
+		},
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    lifecycle:
+      postStart:
+        exec:
+          command: ["true"]
+        tcpSocket:
+          port: 80
+`[1:],
+			errStr: `postStart hook must specify exactly one of exec, httpGet or tcpSocket`,
+		},
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+topologySpreadConstraints:
+  - maxSkew: 0
+    topologyKey: zone
+    whenUnsatisfiable: DoNotSchedule
+`[1:],
+			errStr: `topology spread constraint maxSkew 0 not valid`,
+		},
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+topologySpreadConstraints:
+  - maxSkew: 1
+    whenUnsatisfiable: DoNotSchedule
+`[1:],
+			errStr: `topology spread constraint topologyKey is missing`,
+		},
+		{
+			spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: zone
+    whenUnsatisfiable: Sometimes
+`[1:],
+			errStr: `topology spread constraint whenUnsatisfiable "Sometimes" not supported`,
+		},
+	} {
+		c.Logf("#%d", i)
+		_, err := k8sspecs.ParsePodSpec(version4Header + tc.spec)
+		c.Check(err, gc.ErrorMatches, tc.errStr)
+	}
+}
+
+func (s *v4SpecsSuite) TestUnknownKubernetesSection(c *gc.C) {
+	specStr := version4Header + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    kubernetes:
+      securityContext:
+        runAsNonRoot: true
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `json: unknown field "kubernetes"`)
+}
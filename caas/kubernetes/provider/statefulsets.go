@@ -107,7 +107,10 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	if err != nil {
 		return errors.Trace(err)
 	}
-	existing.Spec.Replicas = spec.Spec.Replicas
+	if spec.Spec.Replicas != nil {
+		// Replicas are left unset when the autoscaler manages them.
+		existing.Spec.Replicas = spec.Spec.Replicas
+	}
 	// TODO(caas) - allow storage `request` configurable - currently we only allow `limit`.
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
 	existing.Spec.Template.Spec.ServiceAccountName = existingPodSpec.ServiceAccountName
@@ -12,10 +12,10 @@ import (
 )
 
 // CurrentVersion is the latest version of pod spec.
-const CurrentVersion Version = Version3
+const CurrentVersion Version = Version4
 
 // PodSpec is the current version of pod spec.
-type PodSpec = PodSpecV3
+type PodSpec = PodSpecV4
 
 // ContainerPort defines a port on a container.
 type ContainerPort struct {
@@ -165,11 +165,49 @@ var supportedPolicies = []ScalePolicyType{
 type ServiceSpec struct {
 	ScalePolicy ScalePolicyType   `json:"scalePolicy,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty"`
+	Autoscaling *AutoscalingSpec  `json:"autoscaling,omitempty"`
 }
 
 // Validate returns an error if the spec is not valid.
 func (ss ServiceSpec) Validate() error {
-	return errors.Trace(ss.ScalePolicy.Validate())
+	if err := ss.ScalePolicy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if ss.Autoscaling != nil {
+		return errors.Trace(ss.Autoscaling.Validate())
+	}
+	return nil
+}
+
+// AutoscalingSpec defines the bounds and the target resource utilisation
+// used to scale the number of pods of the application automatically.
+// The utilisation is a percentage of the resources requested by the pods.
+type AutoscalingSpec struct {
+	MinReplicas                       int32 `json:"minReplicas,omitempty"`
+	MaxReplicas                       int32 `json:"maxReplicas"`
+	TargetCPUUtilizationPercentage    int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	TargetMemoryUtilizationPercentage int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (as AutoscalingSpec) Validate() error {
+	if as.MaxReplicas < 1 {
+		return errors.NotValidf("autoscaling maxReplicas %d", as.MaxReplicas)
+	}
+	if as.MinReplicas < 0 || as.MinReplicas > as.MaxReplicas {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"autoscaling minReplicas %d must be between 0 and maxReplicas %d", as.MinReplicas, as.MaxReplicas))
+	}
+	if as.TargetCPUUtilizationPercentage < 0 {
+		return errors.NotValidf("autoscaling targetCPUUtilizationPercentage %d", as.TargetCPUUtilizationPercentage)
+	}
+	if as.TargetMemoryUtilizationPercentage < 0 {
+		return errors.NotValidf("autoscaling targetMemoryUtilizationPercentage %d", as.TargetMemoryUtilizationPercentage)
+	}
+	if as.TargetCPUUtilizationPercentage == 0 && as.TargetMemoryUtilizationPercentage == 0 {
+		return errors.NotValidf("autoscaling without a cpu or memory utilization target")
+	}
+	return nil
 }
 
 // Version describes pod spec version type.
@@ -56,6 +56,12 @@ version: 3
 `[1:],
 			version: specs.Version(3),
 		},
+		{
+			strSpec: `
+version: 4
+`[1:],
+			version: specs.Version(4),
+		},
 	} {
 		c.Logf("#%d: testing GetVersion: %d", i, tc.version)
 		v, err := specs.GetVersion(tc.strSpec)
@@ -81,6 +87,44 @@ func (s *typesSuite) TestValidateServiceSpec(c *gc.C) {
 	c.Assert(spec.Validate(), jc.ErrorIsNil)
 }
 
+func (s *typesSuite) TestValidateAutoscalingSpec(c *gc.C) {
+	for i, tc := range []struct {
+		spec   specs.AutoscalingSpec
+		errStr string
+	}{
+		{
+			spec: specs.AutoscalingSpec{MinReplicas: 1, MaxReplicas: 5, TargetCPUUtilizationPercentage: 80},
+		},
+		{
+			spec: specs.AutoscalingSpec{MaxReplicas: 5, TargetMemoryUtilizationPercentage: 70},
+		},
+		{
+			spec:   specs.AutoscalingSpec{TargetCPUUtilizationPercentage: 80},
+			errStr: `autoscaling maxReplicas 0 not valid`,
+		},
+		{
+			spec:   specs.AutoscalingSpec{MinReplicas: 6, MaxReplicas: 5, TargetCPUUtilizationPercentage: 80},
+			errStr: `autoscaling minReplicas 6 must be between 0 and maxReplicas 5`,
+		},
+		{
+			spec:   specs.AutoscalingSpec{MaxReplicas: 5, TargetCPUUtilizationPercentage: -1},
+			errStr: `autoscaling targetCPUUtilizationPercentage -1 not valid`,
+		},
+		{
+			spec:   specs.AutoscalingSpec{MaxReplicas: 5},
+			errStr: `autoscaling without a cpu or memory utilization target not valid`,
+		},
+	} {
+		c.Logf("#%d", i)
+		err := specs.ServiceSpec{Autoscaling: &tc.spec}.Validate()
+		if tc.errStr == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, tc.errStr)
+		}
+	}
+}
+
 func (s *typesSuite) TestValidateContainerSpec(c *gc.C) {
 	for i, tc := range []validateTc{
 		{
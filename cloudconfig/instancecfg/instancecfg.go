@@ -103,6 +103,11 @@ type InstanceConfig struct {
 	// specified by the user.
 	CloudInitUserData map[string]interface{}
 
+	// CloudInitUserHooks holds the user hook templates from the
+	// model-config, keyed by the phase of provisioning at which
+	// they are run.
+	CloudInitUserHooks map[string]string
+
 	// MachineId identifies the new machine.
 	MachineId string
 
@@ -913,6 +918,7 @@ func PopulateInstanceConfig(icfg *InstanceConfig,
 	enableOSRefreshUpdates bool,
 	enableOSUpgrade bool,
 	cloudInitUserData map[string]interface{},
+	cloudInitUserHooks map[string]string,
 	profiles []string,
 ) error {
 	icfg.AuthorizedKeys = authorizedKeys
@@ -935,6 +941,7 @@ func PopulateInstanceConfig(icfg *InstanceConfig,
 	icfg.EnableOSRefreshUpdate = enableOSRefreshUpdates
 	icfg.EnableOSUpgrade = enableOSUpgrade
 	icfg.CloudInitUserData = cloudInitUserData
+	icfg.CloudInitUserHooks = cloudInitUserHooks
 	icfg.Profiles = profiles
 	return nil
 }
@@ -960,6 +967,7 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 		cfg.EnableOSRefreshUpdate(),
 		cfg.EnableOSUpgrade(),
 		cfg.CloudInitUserData(),
+		cfg.CloudInitUserHooks(),
 		nil,
 	); err != nil {
 		return errors.Trace(err)
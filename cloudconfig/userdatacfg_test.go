@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/paths"
+	"github.com/juju/juju/core/userhooks"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -855,6 +856,66 @@ func (s *cloudinitSuite) TestCloudInitConfigCloudInitUserData(c *gc.C) {
 	c.Check(testCmd, gc.DeepEquals, []interface{}{"test line one"})
 }
 
+func (s *cloudinitSuite) TestCloudInitConfigCloudInitUserHooks(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		config.CloudInitUserHooksKey: `
+pre-agent-install: echo {{.MachineId}}
+post-agent-install: echo {{.ModelUUID}}
+post-first-hook: |
+  #!/bin/sh
+  echo {{.Series}}
+`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmds := cloudcfg.RunCmds()
+	indexOf := func(match func(string) bool) int {
+		for i, cmd := range cmds {
+			if match(cmd) {
+				return i
+			}
+		}
+		return -1
+	}
+	indexOfCmd := func(want string) int {
+		return indexOf(func(cmd string) bool { return cmd == want })
+	}
+	hookPath := func(phase userhooks.Phase) string {
+		return userhooks.Path(instanceCfg.DataDir, phase)
+	}
+	toolsDir := indexOf(func(cmd string) bool { return strings.HasPrefix(cmd, "bin=") })
+	removeTools := indexOf(func(cmd string) bool { return strings.HasPrefix(cmd, "rm $bin/tools.tar.gz") })
+	c.Assert(toolsDir, jc.GreaterThan, 0)
+	c.Assert(removeTools, jc.GreaterThan, toolsDir)
+
+	preWrite := indexOfCmd(fmt.Sprintf("printf '%%s\\n' '#!/bin/bash\nset -e\necho 42' > '%s'", hookPath(userhooks.PreAgentInstall)))
+	preRun := indexOfCmd(fmt.Sprintf("'%s'", hookPath(userhooks.PreAgentInstall)))
+	c.Check(preWrite, jc.GreaterThan, 0)
+	c.Check(preRun, jc.GreaterThan, preWrite)
+	c.Check(preRun, jc.LessThan, toolsDir)
+
+	postWrite := indexOfCmd(fmt.Sprintf("printf '%%s\\n' '#!/bin/bash\nset -e\necho %s' > '%s'",
+		testing.ModelTag.Id(), hookPath(userhooks.PostAgentInstall)))
+	postRun := indexOfCmd(fmt.Sprintf("'%s'", hookPath(userhooks.PostAgentInstall)))
+	c.Check(postWrite, jc.GreaterThan, toolsDir)
+	c.Check(postRun, jc.GreaterThan, postWrite)
+	c.Check(postRun, jc.LessThan, removeTools)
+
+	// The post-first-hook hook is written, but left for the unit
+	// agent to run.
+	firstHookWrite := indexOfCmd(fmt.Sprintf("printf '%%s\\n' '#!/bin/sh\necho quantal\n' > '%s'", hookPath(userhooks.PostFirstHook)))
+	c.Check(firstHookWrite, jc.GreaterThan, postRun)
+	c.Check(indexOfCmd(fmt.Sprintf("'%s'", hookPath(userhooks.PostFirstHook))), gc.Equals, -1)
+}
+
 var validCloudInitUserData = `
 packages:
   - 'python-keystoneclient'
@@ -25,6 +25,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/core/userhooks"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/service"
@@ -345,6 +346,12 @@ func (w *unixConfigure) ConfigureJuju() error {
 		w.setDataDirPermissions(),
 	)
 
+	// Run the user's pre-agent-install hook before any Juju agent
+	// binaries are put on the machine.
+	if err := w.addUserHook(userhooks.PreAgentInstall, true); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Make a directory for the tools to live in.
 	w.conf.AddScripts(
 		"bin="+shquote(w.icfg.JujuTools()),
@@ -403,7 +410,49 @@ func (w *unixConfigure) ConfigureJuju() error {
 
 	w.conf.AddRunTextFile("/sbin/remove-juju-services", removeServicesScript, 0755)
 
-	return w.addMachineAgentToBoot()
+	if err := w.addMachineAgentToBoot(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.addUserHook(userhooks.PostAgentInstall, true); err != nil {
+		return errors.Trace(err)
+	}
+	// The post-first-hook hook is only written out here; the unit
+	// agent runs it once the first charm hook on the machine has
+	// completed.
+	return w.addUserHook(userhooks.PostFirstHook, false)
+}
+
+// addUserHook writes the user hook from the model config for the given
+// phase, if there is one, to the machine, and runs it if requested.
+func (w *unixConfigure) addUserHook(phase userhooks.Phase, run bool) error {
+	hook, ok := w.icfg.CloudInitUserHooks[string(phase)]
+	if !ok {
+		return nil
+	}
+	params := userhooks.Params{
+		MachineId:      w.icfg.MachineId,
+		Series:         w.icfg.Series,
+		ControllerUUID: w.icfg.ControllerTag.Id(),
+		AgentVersion:   w.icfg.AgentVersion().Number.String(),
+		DataDir:        w.icfg.DataDir,
+		LogDir:         w.icfg.LogDir,
+	}
+	if w.icfg.APIInfo != nil {
+		params.ModelUUID = w.icfg.APIInfo.ModelTag.Id()
+	}
+	script, err := userhooks.Render(hook, params)
+	if err != nil {
+		return errors.Annotatef(err, "rendering %s user hook", phase)
+	}
+	hookPath := userhooks.Path(w.icfg.DataDir, phase)
+	w.conf.AddRunTextFile(hookPath, script, 0700)
+	if run {
+		w.conf.AddScripts(
+			cloudinit.LogProgressCmd("Running %s user hook", phase),
+			shquote(hookPath),
+		)
+	}
+	return nil
 }
 
 // Not all cloudinit-userdata attr are allowed to override, these attr have been
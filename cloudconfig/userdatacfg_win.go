@@ -152,6 +152,9 @@ func (w *windowsConfigure) ConfigureJuju() error {
 func (w *windowsConfigure) ConfigureCustomOverrides() error {
 	// TODO HML 2017-12-08
 	// Implement for Windows support of model-config cloudinit-userdata.
+	if len(w.icfg.CloudInitUserHooks) > 0 {
+		logger.Warningf("cloudinit-user-hooks are not supported on Windows, not adding them to machine %s", w.icfg.MachineId)
+	}
 	return nil
 }
 
@@ -61,6 +61,20 @@ type APIClient interface {
 
 	// WatchActionProgress reports on logged action progress messages.
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// AddActionSchedule adds a schedule on which the controller enqueues
+	// an action, returning the added schedule.
+	AddActionSchedule(params.AddActionScheduleArg) (params.ActionSchedule, error)
+
+	// ListActionSchedules returns all the action schedules in the model.
+	ListActionSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveActionSchedule removes the action schedule with the given ID.
+	RemoveActionSchedule(id string) error
+
+	// DownloadAttachment returns a reader for the contents of a file
+	// attached to a task.
+	DownloadAttachment(taskID, name string) (io.ReadCloser, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -124,6 +124,14 @@ func NewShowOutputCommandForTest(store jujuclient.ClientStore, logMessage func(*
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ShowOutputCommand{c}
 }
 
+func NewShowTaskCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showOutputCommand{
+		logMessageHandler: func(*cmd.Context, string) {},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
 func NewShowOperationCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ShowOperationCommand) {
 	c := &showOperationCommand{}
 	c.SetClientStore(store)
@@ -177,3 +185,41 @@ func NewListOperationsCommandForTest(store jujuclient.ClientStore) (cmd.Command,
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ListOperationsCommand{c}
 }
+
+type ScheduleActionCommand struct {
+	*scheduleActionCommand
+}
+
+func (c *ScheduleActionCommand) UnitNames() []string {
+	return c.unitReceivers
+}
+
+func (c *ScheduleActionCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *ScheduleActionCommand) Schedule() string {
+	return c.schedule
+}
+
+func (c *ScheduleActionCommand) Args() [][]string {
+	return c.args
+}
+
+func NewScheduleActionCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ScheduleActionCommand) {
+	c := &scheduleActionCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ScheduleActionCommand{c}
+}
+
+func NewScheduledActionsCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &scheduledActionsCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
+func NewUnscheduleActionCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &unscheduleActionCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
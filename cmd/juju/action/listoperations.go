@@ -266,6 +266,19 @@ type operationInfo struct {
 	Action  *actionSummary      `yaml:"action,omitempty" json:"action,omitempty"`
 	Timing  timingInfo          `yaml:"timing,omitempty" json:"timing,omitempty"`
 	Tasks   map[string]taskInfo `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	Rolling *rollingInfo        `yaml:"rolling,omitempty" json:"rolling,omitempty"`
+	Batches []batchInfo         `yaml:"batches,omitempty" json:"batches,omitempty"`
+	Message string              `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+type rollingInfo struct {
+	BatchSize   int `yaml:"batch-size" json:"batch-size"`
+	MaxFailures int `yaml:"max-failures" json:"max-failures"`
+}
+
+type batchInfo struct {
+	Status string   `yaml:"status" json:"status"`
+	Tasks  []string `yaml:"tasks" json:"tasks"`
 }
 
 type timingInfo struct {
@@ -300,11 +313,28 @@ func formatOperationResult(operation params.OperationResult, utc bool) operation
 			Started:   formatTimestamp(operation.Started, false, utc, false),
 			Completed: formatTimestamp(operation.Completed, false, utc, false),
 		},
-		Tasks: make(map[string]taskInfo, len(operation.Actions)),
+		Tasks:   make(map[string]taskInfo, len(operation.Actions)),
+		Message: operation.Message,
 	}
 	if err := operation.Error; err != nil {
 		result.Error = err.Error()
 	}
+	if r := operation.Rolling; r != nil {
+		result.Rolling = &rollingInfo{
+			BatchSize:   r.BatchSize,
+			MaxFailures: r.MaxFailures,
+		}
+	}
+	for _, b := range operation.Batches {
+		batch := batchInfo{Status: b.Status}
+		for _, t := range b.Tasks {
+			if tag, err := names.ParseActionTag(t); err == nil {
+				t = tag.Id()
+			}
+			batch.Tasks = append(batch.Tasks, t)
+		}
+		result.Batches = append(result.Batches, batch)
+	}
 	var singleAction actionSummary
 	haveSingleAction := true
 	for i, task := range operation.Actions {
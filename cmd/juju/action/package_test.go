@@ -4,7 +4,9 @@
 package action_test
 
 import (
+	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
@@ -152,6 +154,10 @@ type fakeAPIClient struct {
 	apiErr             error
 	logMessageCh       chan []string
 	waitForResults     chan bool
+	actionSchedules    []params.ActionSchedule
+	addedSchedule      params.AddActionScheduleArg
+	removedSchedule    string
+	attachments        map[string]string
 }
 
 var _ action.APIClient = (*fakeAPIClient)(nil)
@@ -320,3 +326,37 @@ func (c *fakeAPIClient) getOperation(id string) (params.OperationResult, error)
 	}
 	return c.operationResults[0], nil
 }
+
+func (c *fakeAPIClient) AddActionSchedule(arg params.AddActionScheduleArg) (params.ActionSchedule, error) {
+	c.addedSchedule = arg
+	if c.apiErr != nil {
+		return params.ActionSchedule{}, c.apiErr
+	}
+	return params.ActionSchedule{
+		Id:         "1",
+		Schedule:   arg.Schedule,
+		Receivers:  arg.Receivers,
+		Name:       arg.Name,
+		Parameters: arg.Parameters,
+	}, nil
+}
+
+func (c *fakeAPIClient) ListActionSchedules() ([]params.ActionSchedule, error) {
+	return c.actionSchedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedule(id string) error {
+	c.removedSchedule = id
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) DownloadAttachment(taskID, name string) (io.ReadCloser, error) {
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	content, ok := c.attachments[name]
+	if !ok {
+		return nil, errors.NotFoundf("attachment %q", name)
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
@@ -54,6 +54,8 @@ type runCommand struct {
 	parseStrings      bool
 	background        bool
 	maxWait           time.Duration
+	batchSize         int
+	maxFailures       int
 	out               cmd.Output
 	args              [][]string
 	utc               bool
@@ -98,6 +100,14 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+To run the action on a few units at a time, use the --batch-size option. The
+units are split into batches in the order they are given, and each batch is
+run once the tasks of the previous batch have finished. If more than
+--max-failures tasks fail (by default, any at all), the tasks of the remaining
+batches are cancelled. The progress of each batch is shown by
+'juju show-operation <ID>'. Note that --max-wait applies to the whole
+operation, not to each batch.
+
 Examples:
 
     juju run mysql/3 backup --background
@@ -112,6 +122,8 @@ Examples:
     juju run mysql/3 backup --params p.yml file.kind=xz file.quality=high
     juju run sleeper/0 pause time=1000
     juju run sleeper/0 pause --string-args time=1000
+    juju run mysql/0 mysql/1 mysql/2 mysql/3 restart --batch-size 2
+    juju run mysql/0 mysql/1 mysql/2 mysql/3 restart --batch-size 1 --max-failures 1
 
 See also:
     list-operations
@@ -134,6 +146,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.background, "background", false, "Run the action in the background")
 	f.DurationVar(&c.maxWait, "max-wait", 0, "Maximum wait time for a action to complete")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Run the action on this many units at a time")
+	f.IntVar(&c.maxFailures, "max-failures", 0, "Number of failed tasks to tolerate before stopping a batched run")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -170,6 +184,15 @@ func (c *runCommand) Init(args []string) (err error) {
 	if !c.background && c.maxWait == 0 {
 		c.maxWait = 60 * time.Second
 	}
+	if c.batchSize < 0 {
+		return errors.New("--batch-size must be positive")
+	}
+	if c.maxFailures < 0 {
+		return errors.New("--max-failures cannot be negative")
+	}
+	if c.maxFailures > 0 && c.batchSize == 0 {
+		return errors.New("--max-failures requires --batch-size")
+	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
@@ -213,7 +236,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		if numTasks > 1 {
 			plural = "s"
 		}
-		ctx.Infof("Running operation %s with %d task%s", operationId, numTasks, plural)
+		if c.batchSize > 0 && c.batchSize < numTasks {
+			ctx.Infof("Running operation %s with %d task%s, %d at a time", operationId, numTasks, plural, c.batchSize)
+		} else {
+			ctx.Infof("Running operation %s with %d task%s", operationId, numTasks, plural)
+		}
 	}
 
 	var actionTag names.ActionTag
@@ -385,7 +412,14 @@ func (c *runCommand) enqueueActions(ctx *cmd.Context) (string, []enqueuedAction,
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
 	}
-	results, err := c.api.EnqueueOperation(params.Actions{Actions: actions})
+	args := params.Actions{Actions: actions}
+	if c.batchSize > 0 {
+		args.Rolling = &params.RollingStrategy{
+			BatchSize:   c.batchSize,
+			MaxFailures: c.maxFailures,
+		}
+	}
+	results, err := c.api.EnqueueOperation(args)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
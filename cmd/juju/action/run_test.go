@@ -81,6 +81,18 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		should:      "fail with both --background and --max-wait",
 		args:        []string{"--background", "--max-wait=60s", validUnitId, "action"},
 		expectError: "cannot specify both --max-wait and --background",
+	}, {
+		should:      "fail with negative --batch-size",
+		args:        []string{"--batch-size=-1", validUnitId, "action"},
+		expectError: "--batch-size must be positive",
+	}, {
+		should:      "fail with negative --max-failures",
+		args:        []string{"--batch-size=1", "--max-failures=-1", validUnitId, "action"},
+		expectError: "--max-failures cannot be negative",
+	}, {
+		should:      "fail with --max-failures but no --batch-size",
+		args:        []string{"--max-failures=1", validUnitId, "action"},
+		expectError: "--max-failures requires --batch-size",
 	}, {
 		should:      "fail with no action specified",
 		args:        []string{validUnitId},
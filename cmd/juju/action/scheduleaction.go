@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/robfig/cron.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewScheduleActionCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleActionCommand{})
+}
+
+// scheduleActionCommand adds a schedule on which the controller runs an
+// action on the given units.
+type scheduleActionCommand struct {
+	ActionCommandBase
+	unitReceivers []string
+	actionName    string
+	schedule      string
+	paramsYAML    cmd.FileVar
+	parseStrings  bool
+	out           cmd.Output
+	args          [][]string
+}
+
+const scheduleActionDoc = `
+Add a schedule on which the controller runs an action on the given units.
+The action is run as an operation at each time matched by the schedule, and
+the history of each schedule's runs, including any that were missed or
+failed, can be seen with 'juju scheduled-actions <ID>'.
+
+The schedule is a cron expression in UTC, with an optional leading seconds
+field, or one of the descriptors @yearly, @monthly, @weekly, @daily or
+@hourly. "@every <duration>" may also be used to run the action at a fixed
+interval.
+
+Valid unit identifiers are:
+  a standard unit ID, such as mysql/0 or;
+  leader syntax of the form <application>/leader, such as mysql/leader.
+
+If the leader syntax is used, the leader unit for the application is
+resolved at the time of each run.
+
+Params are validated according to the charm for the unit's application, and
+are given in the same way as for 'juju run-action'.
+
+If the controller was unavailable at a scheduled time, the action is still
+run when the controller returns, provided it is no more than 10 minutes
+late; otherwise the run is recorded as missed.
+
+Examples:
+
+    juju schedule-action mysql/leader backup --schedule "0 2 * * *"
+    juju schedule-action mysql/leader backup --schedule @daily out=out.tar.bz2
+    juju schedule-action mysql/0 mysql/1 tidy --schedule "@every 6h"
+    juju schedule-action mysql/leader backup --schedule @weekly --params p.yml
+
+See also:
+    scheduled-actions
+    unschedule-action
+    run-action
+`
+
+// SetFlags implements Command.
+func (c *scheduleActionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar(&c.schedule, "schedule", "", "Cron expression for when to run the action")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info implements Command.
+func (c *scheduleActionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<unit> [<unit> ...] <action> --schedule <cron> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Purpose: "Run an action on a schedule.",
+		Doc:     scheduleActionDoc,
+	})
+}
+
+// Init gets the unit names, action name, schedule and action arguments.
+func (c *scheduleActionCommand) Init(args []string) error {
+	for _, arg := range args {
+		if names.IsValidUnit(arg) || validLeader.MatchString(arg) {
+			c.unitReceivers = append(c.unitReceivers, arg)
+		} else if nameRule.MatchString(arg) {
+			c.actionName = arg
+			break
+		} else {
+			return errors.Errorf("invalid unit or action name %q", arg)
+		}
+	}
+	if len(c.unitReceivers) == 0 {
+		return errors.New("no unit specified")
+	}
+	if c.actionName == "" {
+		return errors.New("no action specified")
+	}
+	if c.schedule == "" {
+		return errors.New("no schedule specified")
+	}
+	if _, err := cron.Parse(c.schedule); err != nil {
+		return errors.Annotatef(err, "invalid schedule %q", c.schedule)
+	}
+
+	// Parse CLI key-value args if they exist.
+	c.args = make([][]string, 0)
+	for _, arg := range args[len(c.unitReceivers)+1:] {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := nameRule.MatchString(key); !valid {
+				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+					"and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		c.args = append(c.args, append(keySlice, thisArg[1]))
+	}
+	return nil
+}
+
+// Run implements Command.
+func (c *scheduleActionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	actionParams := map[string]interface{}{}
+	if c.paramsYAML.Path != "" {
+		b, err := c.paramsYAML.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := yaml.Unmarshal(b, &actionParams); err != nil {
+			return errors.Trace(err)
+		}
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return errors.New("params must contain a YAML map with string keys")
+		}
+		actionParams = betterParams
+	}
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range c.args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !c.parseStrings {
+			if err := yaml.Unmarshal([]byte(value), &cleansedValue); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("params must be a map, got %T", conformantParams)
+	}
+
+	schedule, err := api.AddActionSchedule(params.AddActionScheduleArg{
+		Schedule:   c.schedule,
+		Receivers:  c.unitReceivers,
+		Name:       c.actionName,
+		Parameters: typedConformantParams,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, map[string]string{"Action scheduled with id": schedule.Id})
+}
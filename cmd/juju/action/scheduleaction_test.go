@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleActionSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleActionSuite{})
+
+func (s *ScheduleActionSuite) TestInit(c *gc.C) {
+	tests := []struct {
+		should         string
+		args           []string
+		expectUnits    []string
+		expectAction   string
+		expectSchedule string
+		expectArgs     [][]string
+		expectError    string
+	}{{
+		should:      "fail with no args",
+		expectError: "no unit specified",
+	}, {
+		should:      "fail with no action",
+		args:        []string{validUnitId},
+		expectError: "no action specified",
+	}, {
+		should:      "fail with no schedule",
+		args:        []string{validUnitId, "backup"},
+		expectError: "no schedule specified",
+	}, {
+		should:      "fail with invalid schedule",
+		args:        []string{"--schedule", "every night", validUnitId, "backup"},
+		expectError: `invalid schedule "every night": .*`,
+	}, {
+		should:      "fail with invalid unit",
+		args:        []string{"--schedule", "@daily", invalidUnitId, "backup"},
+		expectError: `invalid unit or action name "` + invalidUnitId + `"`,
+	}, {
+		should:      "fail with invalid arg",
+		args:        []string{"--schedule", "@daily", validUnitId, "backup", "out"},
+		expectError: `argument "out" must be of the form key...=value`,
+	}, {
+		should:         "init properly with leader and args",
+		args:           []string{"--schedule", "0 2 * * *", "mysql/leader", validUnitId2, "backup", "out=a.tar", "file.kind=xz"},
+		expectUnits:    []string{"mysql/leader", validUnitId2},
+		expectAction:   "backup",
+		expectSchedule: "0 2 * * *",
+		expectArgs:     [][]string{{"out", "a.tar"}, {"file", "kind", "xz"}},
+	}}
+
+	for i, t := range tests {
+		c.Logf("test %d: should %s:\n$ juju schedule-action %v", i, t.should, t.args)
+		wrappedCommand, command := action.NewScheduleActionCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		if t.expectError != "" {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.UnitNames(), jc.DeepEquals, t.expectUnits)
+		c.Check(command.ActionName(), gc.Equals, t.expectAction)
+		c.Check(command.Schedule(), gc.Equals, t.expectSchedule)
+		c.Check(command.Args(), jc.DeepEquals, t.expectArgs)
+	}
+}
+
+func (s *ScheduleActionSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiVersion: 7}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewScheduleActionCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "--schedule", "@daily", "mysql/leader", "backup", "out=a.tar", "level=3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.addedSchedule, jc.DeepEquals, params.AddActionScheduleArg{
+		Schedule:   "@daily",
+		Receivers:  []string{"mysql/leader"},
+		Name:       "backup",
+		Parameters: map[string]interface{}{"out": "a.tar", "level": 3},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "Action scheduled with id: \"1\"\n")
+}
+
+func (s *ScheduleActionSuite) TestRunError(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiErr: errors.New(`unit "mysql/9" not found`)}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewScheduleActionCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "--schedule", "@daily", "mysql/9", "backup")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/9" not found`)
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewScheduledActionsCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduledActionsCommand{})
+}
+
+// scheduledActionsCommand lists the action schedules in a model, or
+// shows the run history of a single schedule.
+type scheduledActionsCommand struct {
+	ActionCommandBase
+	out        cmd.Output
+	utc        bool
+	scheduleId string
+}
+
+const scheduledActionsDoc = `
+List the schedules on which the controller runs actions, with the time and
+result of each schedule's most recent run.
+
+If a schedule ID is given, the schedule's full run history is shown. Each
+run is shown as "enqueued", with the ID of the operation that was run;
+"failed", if the action could not be enqueued; or "missed", if the
+controller was unavailable at the scheduled time.
+
+Examples:
+
+    juju scheduled-actions
+    juju scheduled-actions 3
+    juju scheduled-actions --format yaml
+
+See also:
+    schedule-action
+    unschedule-action
+    show-operation
+`
+
+// SetFlags implements Command.
+func (c *scheduledActionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "plain", map[string]cmd.Formatter{
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+		"plain": c.formatTabular,
+	})
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+}
+
+// Info implements Command.
+func (c *scheduledActionsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "scheduled-actions",
+		Args:    "[<schedule-id>]",
+		Purpose: "Lists action schedules and their runs.",
+		Doc:     scheduledActionsDoc,
+		Aliases: []string{"list-scheduled-actions"},
+	})
+}
+
+// Init implements Command.
+func (c *scheduledActionsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.scheduleId = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.
+func (c *scheduledActionsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.scheduleId != "" {
+		var found []params.ActionSchedule
+		for _, s := range schedules {
+			if s.Id == c.scheduleId {
+				found = append(found, s)
+			}
+		}
+		if len(found) == 0 {
+			return errors.NotFoundf("action schedule %q", c.scheduleId)
+		}
+		schedules = found
+	}
+	if len(schedules) == 0 {
+		ctx.Infof("no action schedules")
+		return nil
+	}
+	if c.out.Name() == "plain" {
+		return c.out.Write(ctx, schedules)
+	}
+	out := make(map[string]scheduleInfo, len(schedules))
+	for _, s := range schedules {
+		out[s.Id] = c.formatSchedule(s)
+	}
+	return c.out.Write(ctx, out)
+}
+
+type scheduleInfo struct {
+	Schedule   string                 `yaml:"schedule" json:"schedule"`
+	Action     string                 `yaml:"action" json:"action"`
+	Units      []string               `yaml:"units" json:"units"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Created    string                 `yaml:"created" json:"created"`
+	CreatedBy  string                 `yaml:"created-by" json:"created-by"`
+	Runs       []scheduleRunInfo      `yaml:"runs,omitempty" json:"runs,omitempty"`
+}
+
+type scheduleRunInfo struct {
+	Scheduled string `yaml:"scheduled" json:"scheduled"`
+	Status    string `yaml:"status" json:"status"`
+	Operation string `yaml:"operation,omitempty" json:"operation,omitempty"`
+	Message   string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func (c *scheduledActionsCommand) formatSchedule(s params.ActionSchedule) scheduleInfo {
+	info := scheduleInfo{
+		Schedule:   s.Schedule,
+		Action:     s.Name,
+		Units:      s.Receivers,
+		Parameters: s.Parameters,
+		Created:    formatTimestamp(s.Created, false, c.utc, false),
+		CreatedBy:  s.CreatedBy,
+	}
+	for _, run := range s.Runs {
+		info.Runs = append(info.Runs, scheduleRunInfo{
+			Scheduled: formatTimestamp(run.Scheduled, false, c.utc, false),
+			Status:    run.Status,
+			Operation: run.OperationId,
+			Message:   run.Message,
+		})
+	}
+	return info
+}
+
+func (c *scheduledActionsCommand) formatTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]params.ActionSchedule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	if c.scheduleId != "" {
+		// Show the full run history of the requested schedule.
+		s := schedules[0]
+		w.Println("Id", "Schedule", "Action", "Units")
+		w.Println(s.Id, s.Schedule, s.Name, strings.Join(s.Receivers, ","))
+		w.Println()
+		w.Println("Scheduled", "Status", "Operation", "Message")
+		for i := len(s.Runs) - 1; i >= 0; i-- {
+			run := s.Runs[i]
+			w.Print(formatTimestamp(run.Scheduled, false, c.utc, true))
+			w.Println(run.Status, run.OperationId, run.Message)
+		}
+		return tw.Flush()
+	}
+
+	w.SetColumnAlignRight(0)
+	w.Println("Id", "Schedule", "Action", "Units", "Last run", "Status", "Operation")
+	for _, s := range schedules {
+		w.Print(s.Id, s.Schedule, s.Name, strings.Join(s.Receivers, ","))
+		if len(s.Runs) == 0 {
+			w.Println("never", "", "")
+			continue
+		}
+		last := s.Runs[len(s.Runs)-1]
+		status := last.Status
+		if last.Message != "" && last.Status != "enqueued" {
+			status = fmt.Sprintf("%s (%s)", last.Status, last.Message)
+		}
+		w.Print(formatTimestamp(last.Scheduled, false, c.utc, true))
+		w.Println(status, last.OperationId)
+	}
+	return tw.Flush()
+}
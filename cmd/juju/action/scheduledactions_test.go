@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduledActionsSuite struct {
+	BaseActionSuite
+	fakeClient *fakeAPIClient
+}
+
+var _ = gc.Suite(&ScheduledActionsSuite{})
+
+func (s *ScheduledActionsSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.fakeClient = &fakeAPIClient{
+		apiVersion: 7,
+		actionSchedules: []params.ActionSchedule{{
+			Id:        "1",
+			Schedule:  "0 2 * * *",
+			Receivers: []string{"mysql/leader"},
+			Name:      "backup",
+			Created:   time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+			CreatedBy: "admin",
+			Runs: []params.ActionScheduleRun{{
+				Scheduled:   time.Date(2020, 5, 1, 2, 0, 0, 0, time.UTC),
+				Status:      "enqueued",
+				OperationId: "12",
+			}, {
+				Scheduled: time.Date(2020, 5, 3, 2, 0, 0, 0, time.UTC),
+				Status:    "missed",
+				Message:   "missed 2 scheduled runs",
+			}},
+		}, {
+			Id:        "2",
+			Schedule:  "@hourly",
+			Receivers: []string{"mysql/0", "mysql/1"},
+			Name:      "tidy",
+			Created:   time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC),
+			CreatedBy: "admin",
+		}},
+	}
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return s.fakeClient, nil
+	})
+}
+
+func (s *ScheduledActionsSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(action.NewScheduledActionsCommandForTest(s.store), []string{"1", "2"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["2"\]`)
+}
+
+func (s *ScheduledActionsSuite) TestRunTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduledActionsCommandForTest(s.store), "-m", "admin", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Id  Schedule   Action  Units            Last run             Status                            Operation\n"+
+		" 1  0 2 * * *  backup  mysql/leader     2020-05-03T02:00:00  missed (missed 2 scheduled runs)  \n"+
+		" 2  @hourly    tidy    mysql/0,mysql/1  never                                                  \n")
+}
+
+func (s *ScheduledActionsSuite) TestRunHistory(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduledActionsCommandForTest(s.store), "-m", "admin", "--utc", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Id  Schedule   Action  Units\n"+
+		"1   0 2 * * *  backup  mysql/leader\n"+
+		"\n"+
+		"Scheduled            Status    Operation  Message\n"+
+		"2020-05-03T02:00:00  missed               missed 2 scheduled runs\n"+
+		"2020-05-01T02:00:00  enqueued  12         \n")
+}
+
+func (s *ScheduledActionsSuite) TestRunYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduledActionsCommandForTest(s.store),
+		"-m", "admin", "--utc", "--format", "yaml", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"1":
+  schedule: 0 2 * * *
+  action: backup
+  units:
+  - mysql/leader
+  created: 2020-05-01 00:00:00 +0000 UTC
+  created-by: admin
+  runs:
+  - scheduled: 2020-05-01 02:00:00 +0000 UTC
+    status: enqueued
+    operation: "12"
+  - scheduled: 2020-05-03 02:00:00 +0000 UTC
+    status: missed
+    message: missed 2 scheduled runs
+`[1:])
+}
+
+func (s *ScheduledActionsSuite) TestRunNotFound(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewScheduledActionsCommandForTest(s.store), "-m", "admin", "3")
+	c.Assert(err, gc.ErrorMatches, `action schedule "3" not found`)
+}
+
+func (s *ScheduledActionsSuite) TestRunNone(c *gc.C) {
+	s.fakeClient.actionSchedules = nil
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduledActionsCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no action schedules\n")
+}
@@ -122,6 +122,39 @@ timing:
 summary: an operation
 status: failed
 error: an apiserver error
+`[1:],
+	}, {
+		should:            "show the batches of a stopped rolling operation",
+		withClientQueryID: operationId,
+		withAPITimeout:    1 * time.Second,
+		withAPIResponse: []params.OperationResult{{
+			OperationTag: names.NewOperationTag(operationId).String(),
+			Summary:      "restart run on 2 units",
+			Status:       "failed",
+			Rolling:      &params.RollingStrategy{BatchSize: 1},
+			Batches: []params.OperationBatch{{
+				Status: "failed",
+				Tasks:  []string{names.NewActionTag("1").String()},
+			}, {
+				Status: "cancelled",
+				Tasks:  []string{names.NewActionTag("2").String()},
+			}},
+			Message: "stopped after batch 1: 1 of 2 tasks failed, more than the 0 allowed",
+		}},
+		expectedOutput: `
+summary: restart run on 2 units
+status: failed
+rolling:
+  batch-size: 1
+  max-failures: 0
+batches:
+- status: failed
+  tasks:
+  - "1"
+- status: cancelled
+  tasks:
+  - "2"
+message: 'stopped after batch 1: 1 of 2 tasks failed, more than the 0 allowed'
 `[1:],
 	}, {
 		should:            "only return once status is no longer running or pending",
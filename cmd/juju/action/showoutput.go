@@ -4,7 +4,10 @@
 package action
 
 import (
+	"crypto/sha512"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -51,6 +54,7 @@ type showOutputCommand struct {
 	wait       time.Duration
 	watch      bool
 	utc        bool
+	download   bool
 
 	// compat is true when running as legacy show-action-output
 	compat bool
@@ -83,6 +87,15 @@ See also:
     list-operations
     show-operation
 `
+
+// showTaskAttachmentsDoc is added to the show-task documentation, as
+// show-action-output does not support downloading attachments.
+const showTaskAttachmentsDoc = `
+Files attached to the results by the task with action-attach are listed
+with their size and SHA384 hash. Use --download to save them in the current
+directory; existing files are never overwritten.
+`
+
 const defaultTaskWait = -1 * time.Second
 
 // Set up the output.
@@ -102,6 +115,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 		f.StringVar(&c.legacyWait, "wait", "-1s", "Wait for results")
 	} else {
 		f.DurationVar(&c.wait, "wait", defaultTaskWait, "Wait for results")
+		f.BoolVar(&c.download, "download", false, "Save the files attached to the results")
 	}
 	f.BoolVar(&c.watch, "watch", false, "Wait indefinitely for results")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
@@ -121,6 +135,8 @@ func (c *showOutputCommand) Info() *cmd.Info {
 		info.Doc = strings.Replace(info.Doc, "an action", "a task", -1)
 		info.Doc = strings.Replace(info.Doc, "show-action-output", "show-task", -1)
 		info.Doc = strings.Replace(info.Doc, "run-action", "run", -1)
+		info.Doc = strings.Replace(info.Doc, "\nNote:", showTaskAttachmentsDoc+"\nNote:", 1)
+		info.Doc = strings.Replace(info.Doc, "1 --watch\n", "1 --watch\n    juju show-task 1 --download\n", 1)
 	}
 	return info
 }
@@ -223,11 +239,53 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 
 	formatted := FormatActionResult(c.requestedId, result, c.utc, c.compat)
 	if c.out.Name() != "plain" {
-		return c.out.Write(ctx, formatted)
+		err = c.out.Write(ctx, formatted)
+	} else {
+		info := make(map[string]interface{})
+		info[c.requestedId] = formatted
+		err = c.out.Write(ctx, info)
+	}
+	if err != nil || !c.download {
+		return err
+	}
+	for _, attachment := range result.Attachments {
+		if err := downloadAttachment(ctx, api, c.requestedId, attachment); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// downloadAttachment saves the given attachment of the task in the
+// current directory, checking that its contents match the hash recorded
+// by the controller.
+func downloadAttachment(ctx *cmd.Context, api APIClient, taskID string, attachment params.ActionAttachment) error {
+	path := ctx.AbsPath(attachment.Name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return errors.AlreadyExistsf("file %q", path)
+	}
+	if err != nil {
+		return errors.Trace(err)
 	}
-	info := make(map[string]interface{})
-	info[c.requestedId] = formatted
-	return c.out.Write(ctx, info)
+	defer f.Close()
+
+	r, err := api.DownloadAttachment(taskID, attachment.Name)
+	if err == nil {
+		defer r.Close()
+		hash := sha512.New384()
+		_, err = io.Copy(io.MultiWriter(f, hash), r)
+		if err == nil && fmt.Sprintf("%x", hash.Sum(nil)) != attachment.SHA384 {
+			err = errors.Errorf("attachment %q does not match its SHA384 hash", attachment.Name)
+		}
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return errors.Annotatef(err, "downloading attachment %q", attachment.Name)
+	}
+	ctx.Infof("downloaded attachment %q to %s", attachment.Name, path)
+	return nil
 }
 
 // GetActionResult tries to repeatedly fetch an action until it is
@@ -341,6 +399,17 @@ func FormatActionResult(id string, result params.ActionResult, utc, compat bool)
 			response["results"] = result.Output
 		}
 	}
+	if len(result.Attachments) > 0 {
+		var attachments []map[string]interface{}
+		for _, a := range result.Attachments {
+			attachments = append(attachments, map[string]interface{}{
+				"name":   a.Name,
+				"size":   a.Size,
+				"sha384": a.SHA384,
+			})
+		}
+		response["attachments"] = attachments
+	}
 	if len(result.Log) > 0 {
 		var logs []string
 		for _, msg := range result.Log {
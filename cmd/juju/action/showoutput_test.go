@@ -5,8 +5,13 @@ package action_test
 
 import (
 	"bytes"
+	"crypto/sha512"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -475,3 +480,75 @@ func makeFakeClient(
 	}
 	return client
 }
+
+func (s *ShowOutputSuite) attachmentsClient() *fakeAPIClient {
+	client := makeFakeClient(0, time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+			Status: params.ActionCompleted,
+			Attachments: []params.ActionAttachment{{
+				Name:   "dump.sql",
+				Size:   9,
+				SHA384: fmt.Sprintf("%x", sha512.Sum384([]byte("select 1;"))),
+			}},
+		}},
+		params.ActionsByNames{}, "")
+	client.attachments = map[string]string{"dump.sql": "select 1;"}
+	return client
+}
+
+func (s *ShowOutputSuite) TestShowTaskAttachments(c *gc.C) {
+	defer s.BaseActionSuite.patchAPIClient(s.attachmentsClient())()
+	cmd := action.NewShowTaskCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+attachments:
+- name: dump.sql
+  sha384: `+fmt.Sprintf("%x", sha512.Sum384([]byte("select 1;")))+`
+  size: 9
+id: `+validActionId+`
+status: completed
+unit: mysql/0
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestShowTaskDownload(c *gc.C) {
+	defer s.BaseActionSuite.patchAPIClient(s.attachmentsClient())()
+	dir := c.MkDir()
+	cmd := action.NewShowTaskCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommandInDir(c, cmd, []string{"-m", "admin", validActionId, "--download"}, dir)
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(dir, "dump.sql")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, fmt.Sprintf("downloaded attachment \"dump.sql\" to %s\n", path))
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "select 1;")
+}
+
+func (s *ShowOutputSuite) TestShowTaskDownloadExistingFile(c *gc.C) {
+	defer s.BaseActionSuite.patchAPIClient(s.attachmentsClient())()
+	dir := c.MkDir()
+	path := filepath.Join(dir, "dump.sql")
+	err := ioutil.WriteFile(path, []byte("precious"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	cmd := action.NewShowTaskCommandForTest(s.store)
+	_, err = cmdtesting.RunCommandInDir(c, cmd, []string{"-m", "admin", validActionId, "--download"}, dir)
+	c.Assert(err, gc.ErrorMatches, `file ".*dump.sql" already exists`)
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "precious")
+}
+
+func (s *ShowOutputSuite) TestShowTaskDownloadHashMismatch(c *gc.C) {
+	client := s.attachmentsClient()
+	client.attachments["dump.sql"] = "select 2;"
+	defer s.BaseActionSuite.patchAPIClient(client)()
+	dir := c.MkDir()
+	cmd := action.NewShowTaskCommandForTest(s.store)
+	_, err := cmdtesting.RunCommandInDir(c, cmd, []string{"-m", "admin", validActionId, "--download"}, dir)
+	c.Assert(err, gc.ErrorMatches, `downloading attachment "dump.sql": attachment "dump.sql" does not match its SHA384 hash`)
+	_, err = os.Stat(filepath.Join(dir, "dump.sql"))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewUnscheduleActionCommand() cmd.Command {
+	return modelcmd.Wrap(&unscheduleActionCommand{})
+}
+
+// unscheduleActionCommand removes action schedules.
+type unscheduleActionCommand struct {
+	ActionCommandBase
+	scheduleIds []string
+}
+
+const unscheduleActionDoc = `
+Remove the action schedules with the given IDs. Actions already run by the
+schedules are not affected.
+
+Examples:
+
+    juju unschedule-action 3
+    juju unschedule-action 3 4
+
+See also:
+    schedule-action
+    scheduled-actions
+`
+
+// Info implements Command.
+func (c *unscheduleActionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "unschedule-action",
+		Args:    "<schedule-id> [...]",
+		Purpose: "Remove action schedules.",
+		Doc:     unscheduleActionDoc,
+	})
+}
+
+// Init implements Command.
+func (c *unscheduleActionCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule ID specified")
+	}
+	c.scheduleIds = args
+	return nil
+}
+
+// Run implements Command.
+func (c *unscheduleActionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	for _, id := range c.scheduleIds {
+		if err := api.RemoveActionSchedule(id); err != nil {
+			return errors.Annotatef(err, "removing action schedule %q", id)
+		}
+		ctx.Infof("removed action schedule %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type UnscheduleActionSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&UnscheduleActionSuite{})
+
+func (s *UnscheduleActionSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(action.NewUnscheduleActionCommandForTest(s.store), nil)
+	c.Assert(err, gc.ErrorMatches, "no schedule ID specified")
+}
+
+func (s *UnscheduleActionSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiVersion: 7}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewUnscheduleActionCommandForTest(s.store), "-m", "admin", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.removedSchedule, gc.Equals, "3")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "removed action schedule 3\n")
+}
+
+func (s *UnscheduleActionSuite) TestRunNotFound(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiErr: errors.NotFoundf("action schedule %q", "3")}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := cmdtesting.RunCommand(c, action.NewUnscheduleActionCommandForTest(s.store), "-m", "admin", "3")
+	c.Assert(err, gc.ErrorMatches, `removing action schedule "3": action schedule "3" not found`)
+}
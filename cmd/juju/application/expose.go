@@ -4,13 +4,18 @@
 package application
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 )
 
 var usageExposeSummary = `
@@ -20,8 +25,22 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+On k8s models, the application is exposed through an ingress resource for
+the host set in its juju-external-hostname config. The ingress options
+update the application config before it is exposed:
+
+    --ingress-class selects the ingress controller.
+    --ingress-rule adds a host[/path] rule to the ingress resource, and may
+    be repeated.
+    --tls-secret names the secret holding the TLS certificate for the hosts.
+    --cert-manager-issuer or --cert-manager-cluster-issuer have cert-manager
+    issue the certificate. Unless --tls-secret is given, it is stored in a
+    secret named after the application.
+
 Examples:
     juju expose wordpress
+    juju expose gitlab --ingress-class nginx --cert-manager-cluster-issuer letsencrypt
+    juju expose gitlab --ingress-rule registry.example.com --tls-secret gitlab-tls
 
 See also: 
     unexpose`[1:]
@@ -35,6 +54,12 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	ingressClass             string
+	ingressRules             []string
+	tlsSecret                string
+	certManagerIssuer        string
+	certManagerClusterIssuer string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -46,18 +71,60 @@ func (c *exposeCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.ingressClass, "ingress-class", "", "The class of the ingress controller (k8s models only)")
+	f.Var(cmd.NewAppendStringsValue(&c.ingressRules), "ingress-rule", "Add a host[/path] rule to the ingress resource (k8s models only)")
+	f.StringVar(&c.tlsSecret, "tls-secret", "", "The secret holding the TLS certificate (k8s models only)")
+	f.StringVar(&c.certManagerIssuer, "cert-manager-issuer", "", "The cert-manager issuer of the TLS certificate (k8s models only)")
+	f.StringVar(&c.certManagerClusterIssuer, "cert-manager-cluster-issuer", "", "The cert-manager cluster issuer of the TLS certificate (k8s models only)")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	if c.certManagerIssuer != "" && c.certManagerClusterIssuer != "" {
+		return errors.New("specify either --cert-manager-issuer or --cert-manager-cluster-issuer but not both")
+	}
+	for _, rule := range c.ingressRules {
+		if rule == "" || strings.HasPrefix(rule, "/") || strings.ContainsAny(rule, " \t") {
+			return errors.NotValidf("ingress rule %q", rule)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// ingressConfig returns the application config set by the ingress
+// options, if any were given.
+func (c *exposeCommand) ingressConfig() map[string]string {
+	config := make(map[string]string)
+	if c.ingressClass != "" {
+		config[k8sprovider.IngressClassConfigKey] = c.ingressClass
+	}
+	if len(c.ingressRules) > 0 {
+		config[k8sprovider.IngressRulesConfigKey] = strings.Join(c.ingressRules, " ")
+	}
+	if c.tlsSecret != "" {
+		config[k8sprovider.IngressTLSSecretConfigKey] = c.tlsSecret
+	}
+	if c.certManagerIssuer != "" {
+		config[k8sprovider.IngressCertManagerIssuerConfigKey] = c.certManagerIssuer
+		config[k8sprovider.IngressCertManagerClusterIssuerConfigKey] = ""
+	}
+	if c.certManagerClusterIssuer != "" {
+		config[k8sprovider.IngressCertManagerClusterIssuerConfigKey] = c.certManagerClusterIssuer
+		config[k8sprovider.IngressCertManagerIssuerConfigKey] = ""
+	}
+	return config
+}
+
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
 	Unexpose(applicationName string) error
+	SetApplicationConfig(branchName, applicationName string, config map[string]string) error
 }
 
 func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
@@ -71,10 +138,27 @@ func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
 // Run changes the juju-managed firewall to expose any
 // ports that were also explicitly marked by units as open.
 func (c *exposeCommand) Run(_ *cmd.Context) error {
+	ingressConfig := c.ingressConfig()
+	if len(ingressConfig) > 0 {
+		modelType, err := c.ModelType()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if modelType != model.CAAS {
+			return errors.New("ingress options are only supported on k8s models")
+		}
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
+	if len(ingressConfig) > 0 {
+		err := client.SetApplicationConfig(model.GenerationMaster, c.ApplicationName, ingressConfig)
+		if err != nil {
+			return block.ProcessBlockedError(errors.Annotate(err, "setting ingress config"), block.BlockChange)
+		}
+	}
 	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
 }
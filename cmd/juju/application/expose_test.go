@@ -64,3 +64,22 @@ func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	err := runExpose(c, "some-application-name")
 	s.AssertBlocked(c, err, ".*TestBlockExpose.*")
 }
+
+func (s *ExposeSuite) TestExposeInvalidIngressOptions(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--ingress-rule", "/path")
+	c.Assert(err, gc.ErrorMatches, `ingress rule "/path" not valid`)
+
+	err = runExpose(c, "some-application-name", "--cert-manager-issuer", "a", "--cert-manager-cluster-issuer", "b")
+	c.Assert(err, gc.ErrorMatches, "specify either --cert-manager-issuer or --cert-manager-cluster-issuer but not both")
+}
+
+func (s *ExposeSuite) TestExposeIngressOptionsIAAS(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	err := runExpose(c, "some-application-name", "--tls-secret", "some-secret")
+	c.Assert(err, gc.ErrorMatches, "ingress options are only supported on k8s models")
+
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsFalse)
+}
@@ -45,6 +45,11 @@ Scale a k8s application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+If the charm's pod spec declares autoscaling, the number of units is managed
+by a Kubernetes HorizontalPodAutoscaler within the declared bounds, and the
+scale set here doesn't change the number of pods. The scale the autoscaler
+chooses is reflected in the application's units.
+
 Examples:
 
     juju scale-application mariadb 2
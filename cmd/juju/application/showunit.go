@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showUnitDoc = `
+The command takes deployed unit names as an argument.
+
+Optionally, the history of the hooks and actions most recently run by
+each unit can be included with --hook-history. For each execution the
+start time, kind, relation, duration, exit code and the hook tools it
+called are shown.
+
+Examples:
+    $ juju show-unit mysql/0
+    $ juju show-unit mysql/0 wordpress/1
+    $ juju show-unit mysql/0 --hook-history
+
+`
+
+// NewShowUnitCommand returns a command that displays units info.
+func NewShowUnitCommand() cmd.Command {
+	s := &showUnitCommand{}
+	s.newAPIFunc = func() (UnitsInfoAPI, error) {
+		return s.newUnitAPI()
+	}
+	return modelcmd.Wrap(s)
+}
+
+// showUnitCommand displays unit information.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+
+	out         cmd.Output
+	units       []string
+	hookHistory bool
+	newAPIFunc  func() (UnitsInfoAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showUnitCommand) Info() *cmd.Info {
+	showCmd := &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name>",
+		Purpose: "Displays information about a unit.",
+		Doc:     showUnitDoc,
+	}
+	return jujucmd.Info(showCmd)
+}
+
+// Init implements Command.Init.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.units = args
+	var invalid []string
+	for _, one := range c.units {
+		if !names.IsValidUnit(one) {
+			invalid = append(invalid, one)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	plural := "s"
+	if len(invalid) == 1 {
+		plural = ""
+	}
+	return errors.NotValidf(`unit name%v %v`, plural, strings.Join(invalid, `, `))
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+	f.BoolVar(&c.hookHistory, "hook-history", false, "Show the hooks and actions most recently run by the unit")
+}
+
+// UnitsInfoAPI defines the API methods that show-unit command uses.
+type UnitsInfoAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UnitsInfo([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+func (c *showUnitCommand) newUnitAPI() (UnitsInfoAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if v := client.BestAPIVersion(); v < 12 {
+		// old client does not support showing units.
+		return errors.NotSupportedf("show units on API server version %v", v)
+	}
+
+	tags := make([]names.UnitTag, len(c.units))
+	for i, one := range c.units {
+		tags[i] = names.NewUnitTag(one)
+	}
+
+	results, err := client.UnitsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	var valid []params.UnitResult
+	for _, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{result.Error})
+			continue
+		}
+		valid = append(valid, *result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+
+	output, err := c.formatUnitInfos(valid)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatUnitInfos takes a set of params.UnitResult and creates a
+// mapping from unit name to unit info.
+func (c *showUnitCommand) formatUnitInfos(all []params.UnitResult) (map[string]UnitInfo, error) {
+	if len(all) == 0 {
+		return nil, nil
+	}
+	output := make(map[string]UnitInfo)
+	for _, one := range all {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := UnitInfo{
+			Application:     one.Application,
+			Charm:           one.Charm,
+			Life:            one.Life,
+			Machine:         one.Machine,
+			WorkloadVersion: one.WorkloadVersion,
+		}
+		if c.hookHistory {
+			info.HookHistory = make([]HookExecutionInfo, len(one.HookHistory))
+			for i, e := range one.HookHistory {
+				info.HookHistory[i] = HookExecutionInfo{
+					Started:     e.Started.UTC().Format(time.RFC3339),
+					Kind:        e.Kind,
+					Name:        e.Name,
+					Relation:    e.Relation,
+					Duration:    e.Duration.String(),
+					ExitCode:    e.ExitCode,
+					ToolsCalled: e.ToolsCalled,
+				}
+			}
+		}
+		output[tag.Id()] = info
+	}
+	return output, nil
+}
+
+// UnitInfo defines the serialization behaviour of the unit information.
+type UnitInfo struct {
+	Application     string              `yaml:"application" json:"application"`
+	Charm           string              `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Life            string              `yaml:"life" json:"life"`
+	Machine         string              `yaml:"machine,omitempty" json:"machine,omitempty"`
+	WorkloadVersion string              `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	HookHistory     []HookExecutionInfo `yaml:"hook-history,omitempty" json:"hook-history,omitempty"`
+}
+
+// HookExecutionInfo defines the serialization behaviour of a hook or
+// action run by a unit.
+type HookExecutionInfo struct {
+	Started     string   `yaml:"started" json:"started"`
+	Kind        string   `yaml:"kind" json:"kind"`
+	Name        string   `yaml:"name" json:"name"`
+	Relation    string   `yaml:"relation,omitempty" json:"relation,omitempty"`
+	Duration    string   `yaml:"duration" json:"duration"`
+	ExitCode    int      `yaml:"exit-code" json:"exit-code"`
+	ToolsCalled []string `yaml:"tools-called,omitempty" json:"tools-called,omitempty"`
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowUnitSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockShowUnitAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.mockAPI = &mockShowUnitAPI{
+		version: 12,
+		unitsInfoFunc: func(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+			results := make([]params.UnitInfoResult, len(tags))
+			for i, tag := range tags {
+				results[i].Result = &params.UnitResult{
+					Tag:             tag.String(),
+					Application:     "wordpress",
+					Charm:           "cs:wordpress-5",
+					Life:            "alive",
+					Machine:         "0",
+					WorkloadVersion: "4.9",
+					HookHistory: []params.HookExecution{{
+						Kind:        "db-relation-changed",
+						Name:        "db-relation-changed",
+						Relation:    "db:2",
+						Started:     time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+						Duration:    1500 * time.Millisecond,
+						ExitCode:    1,
+						ToolsCalled: []string{"relation-get", "status-set"},
+					}},
+				}
+			}
+			return results, nil
+		},
+	}
+}
+
+func (s *ShowUnitSuite) runShowUnit(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewShowUnitCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *ShowUnitSuite) TestShowUnitNoArguments(c *gc.C) {
+	_, err := s.runShowUnit(c)
+	c.Assert(err, gc.ErrorMatches, "a unit name must be supplied")
+}
+
+func (s *ShowUnitSuite) TestShowUnitInvalidNames(c *gc.C) {
+	_, err := s.runShowUnit(c, "wordpress", "wordpress/0", "mysql")
+	c.Assert(err, gc.ErrorMatches, "unit names wordpress, mysql not valid")
+}
+
+func (s *ShowUnitSuite) TestShowUnitUnsupported(c *gc.C) {
+	s.mockAPI.version = 11
+	_, err := s.runShowUnit(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "show units on API server version 11 not supported")
+}
+
+func (s *ShowUnitSuite) TestShowUnitApiError(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]params.UnitInfoResult, error) {
+		return []params.UnitInfoResult{
+			{Error: &params.Error{Message: "boom"}},
+		}, nil
+	}
+	_, err := s.runShowUnit(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ShowUnitSuite) TestShowUnit(c *gc.C) {
+	ctx, err := s.runShowUnit(c, "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress/0:
+  application: wordpress
+  charm: cs:wordpress-5
+  life: alive
+  machine: "0"
+  workload-version: "4.9"
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowUnitHookHistory(c *gc.C) {
+	ctx, err := s.runShowUnit(c, "wordpress/0", "--hook-history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress/0:
+  application: wordpress
+  charm: cs:wordpress-5
+  life: alive
+  machine: "0"
+  workload-version: "4.9"
+  hook-history:
+  - started: "2020-04-01T12:00:00Z"
+    kind: db-relation-changed
+    name: db-relation-changed
+    relation: db:2
+    duration: 1.5s
+    exit-code: 1
+    tools-called:
+    - relation-get
+    - status-set
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowUnitHookHistoryJSON(c *gc.C) {
+	ctx, err := s.runShowUnit(c, "wordpress/0", "--hook-history", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"wordpress/0":{"application":"wordpress","charm":"cs:wordpress-5","life":"alive","machine":"0","workload-version":"4.9","hook-history":[{"started":"2020-04-01T12:00:00Z","kind":"db-relation-changed","name":"db-relation-changed","relation":"db:2","duration":"1.5s","exit-code":1,"tools-called":["relation-get","status-set"]}]}}`+"\n")
+}
+
+type mockShowUnitAPI struct {
+	version       int
+	unitsInfoFunc func([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+func (s mockShowUnitAPI) Close() error {
+	return nil
+}
+
+func (s mockShowUnitAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockShowUnitAPI) UnitsInfo(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+	return s.unitsInfoFunc(tags)
+}
@@ -33,6 +33,9 @@ type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
 	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// CreateIncremental sends an RPC request to create a new backup
+	// of the changes since the most recent full backup.
+	CreateIncremental(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -45,6 +48,9 @@ type APIClient interface {
 	Remove(ids ...string) ([]params.ErrorResult, error)
 	// Restore will restore a backup with the given id into the controller.
 	Restore(string, backups.ClientConnection) error
+	// RestoreUntil will restore an incremental backup with the given
+	// id into the controller, up to the given time.
+	RestoreUntil(string, time.Time, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
 	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
 }
@@ -56,6 +62,10 @@ type CommandBase struct {
 	fs      *gnuflag.FlagSet
 	verbose bool
 	quiet   bool
+
+	// Destination is the URL of the backup destination to use
+	// instead of the controller's own storage.
+	Destination string
 }
 
 // NewAPIClient returns a client for the backups api endpoint.
@@ -74,6 +84,12 @@ func (c *CommandBase) SetFlags(f *gnuflag.FlagSet) {
 	c.fs = f
 }
 
+// setDestinationFlag adds the --destination flag, for commands which
+// support backup destinations.
+func (c *CommandBase) setDestinationFlag(f *gnuflag.FlagSet, usage string) {
+	f.StringVar(&c.Destination, "destination", "", usage+" (file:///<dir> or s3://<bucket>[/<prefix>])")
+}
+
 // Init implements Command.SetFlags.
 func (c *CommandBase) Init(args []string) error {
 	c.ModelCommandBase.Init(args)
@@ -104,7 +120,7 @@ var newAPIClient = func(c *CommandBase) (APIClient, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return backups.NewClient(root)
+	return backups.NewClientForDestination(root, c.Destination)
 }
 
 // GetAPI returns a client and the api version of the controller
@@ -114,7 +130,7 @@ var getAPI = func(c *CommandBase) (APIClient, int, error) {
 		return nil, -1, errors.Trace(err)
 	}
 	version := root.BestFacadeVersion("Backups")
-	client, err := backups.NewClient(root)
+	client, err := backups.NewClientForDestination(root, c.Destination)
 	return client, version, errors.Trace(err)
 }
 
@@ -140,7 +156,9 @@ checksum format:       {{.ChecksumFormat}}
 size (B):              {{.Size}} 
 stored:                {{.Stored}} 
 started:               {{.Started}} 
-finished:              {{.Finished}} 
+finished:              {{.Finished}} {{if .Base}}
+base backup ID:        {{.Base}} {{end}}{{if not .OplogStart.IsZero}}
+oplog range:           {{.OplogStart}} to {{.OplogEnd}} {{end}}
 
 notes:                 {{.Notes}} 
 `
@@ -162,6 +180,9 @@ type MetadataParams struct {
 	Hostname       string
 	JujuVersion    version.Number
 	Series         string
+	Base           string
+	OplogStart     time.Time
+	OplogEnd       time.Time
 }
 
 func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
@@ -182,6 +203,9 @@ func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
 		result.Hostname,
 		result.Version,
 		result.Series,
+		result.Base,
+		result.OplogStart,
+		result.OplogEnd,
 	}
 	t := template.Must(template.New("template").Parse(backupMetadataTemplate))
 	content := bytes.Buffer{}
@@ -36,6 +36,21 @@ at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
 
+Use --destination to have the controller store the backup outside of itself,
+either in a directory on the controller machine (such as a mounted network
+share), or in an S3-compatible object store. A backup stored in a destination
+survives the loss of the controller, and always implies --keep-copy. A
+directory must be beneath the backup-local-root controller setting, which
+defaults to /var/lib/juju/backups. The object store is configured with the
+backup-s3-endpoint, backup-s3-region, backup-s3-access-key and
+backup-s3-secret-key controller settings.
+
+Use --incremental to back up only the changes made to Juju's state since the
+most recent full backup in the same storage. Incremental backups are much
+smaller than full backups, and can be restored to any point in time they
+cover with 'juju restore-backup --until'. An incremental backup is useless
+without its full backup, so it always implies --keep-copy.
+
 Use --verbose to see extra information about backup.
 
 To access remote backups stored on the controller, see 'juju download-backup'.
@@ -46,6 +61,9 @@ Examples:
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
     juju create-backup --verbose
+    juju create-backup --no-download --destination s3://juju-backups/prod
+    juju create-backup --destination file:///var/lib/juju/backups/nightly
+    juju create-backup --incremental --no-download
 
 See also:
     backups
@@ -68,6 +86,9 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
+	// Incremental means only the changes since the most recent full
+	// backup should be backed up.
+	Incremental bool
 }
 
 // Info implements Command.Info.
@@ -86,6 +107,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.BoolVar(&c.Incremental, "incremental", false, "Only back up the changes made since the most recent full backup, implies keep-copy")
+	c.setDestinationFlag(f, "Store the archive in this backup destination")
 	c.fs = f
 }
 
@@ -105,7 +128,7 @@ func (c *createCommand) Init(args []string) error {
 				keepCopySet = true
 			}
 		})
-		if keepCopySet && !c.KeepCopy {
+		if keepCopySet && !c.KeepCopy && c.Destination == "" && !c.Incremental {
 			return errors.Errorf("--no-download cannot be set when --keep-copy is not: the backup will not be created")
 		}
 	}
@@ -144,7 +167,9 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		c.KeepCopy = true
 	}
 
-	if c.NoDownload {
+	if c.Destination != "" || c.Incremental {
+		c.KeepCopy = true
+	} else if c.NoDownload {
 		ctx.Warningf(downloadWarning)
 		c.KeepCopy = true
 	}
@@ -158,7 +183,9 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		fmt.Fprintln(ctx.Stdout, c.metadata(metadataResult))
 	}
 
-	if c.KeepCopy {
+	if c.Destination != "" {
+		ctx.Infof("Remote backup stored in %v as %v.", c.Destination, metadataResult.ID)
+	} else if c.KeepCopy {
 		ctx.Infof("Remote backup stored on the controller as %v.", metadataResult.ID)
 	} else {
 		ctx.Infof("Remote backup was not created.")
@@ -205,7 +232,13 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 }
 
 func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	var result *params.BackupsMetadataResult
+	var err error
+	if c.Incremental {
+		result, err = client.CreateIncremental(c.Notes, c.KeepCopy, c.NoDownload)
+	} else {
+		result, err = client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	}
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
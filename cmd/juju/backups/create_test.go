@@ -254,6 +254,25 @@ func (s *createSuite) TestNoDownload(c *gc.C) {
 	c.Check(s.command.Filename, gc.Equals, backups.NotSet)
 }
 
+func (s *createSuite) TestDestination(c *gc.C) {
+	client := &fakeAPIClient{metaresult: s.metaresult}
+	var destination string
+	s.PatchValue(backups.NewGetAPI,
+		func(c *backups.CommandBase) (backups.APIClient, int, error) {
+			destination = c.Destination
+			return client, s.apiVersion, nil
+		},
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--destination", "s3://juju-backups/prod")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(destination, gc.Equals, "s3://juju-backups/prod")
+	client.CheckCalls(c, "Create")
+	client.CheckArgs(c, "", "true", "true")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Remote backup stored in s3://juju-backups/prod as spam.\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, s.expectedOut)
+}
+
 func (s *createSuite) TestKeepCopy(c *gc.C) {
 	client := s.setDownload()
 	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--keep-copy")
@@ -302,3 +321,20 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) TestIncremental(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--incremental", "--no-download")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateIncremental")
+	client.CheckArgs(c, "", "true", "true")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Remote backup stored on the controller as spam.\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, s.expectedOut)
+}
+
+func (s *createSuite) TestIncrementalKeepCopyFalseNoDownload(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--incremental", "--keep-copy=false", "--no-download")
+	c.Check(err, jc.ErrorIsNil)
+}
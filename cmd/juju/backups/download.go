@@ -22,6 +22,9 @@ download-backup retrieves a backup archive file.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
+
+Use --destination to download a backup stored in a backup destination
+rather than on the controller.
 `
 
 // NewDownloadCommand returns a commant used to download backups.
@@ -52,6 +55,7 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	c.setDestinationFlag(f, "Download from this backup destination")
 }
 
 // Init implements Command.Init.
@@ -16,6 +16,9 @@ import (
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+Use --destination to list the backups stored in a backup destination
+rather than on the controller.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -42,6 +45,7 @@ func (c *listCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
+	c.setDestinationFlag(f, "List the backups in this backup destination")
 }
 
 // Init implements Command.Init.
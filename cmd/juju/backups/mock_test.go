@@ -7,6 +7,7 @@ package backups_test
 import (
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	backups "github.com/juju/juju/api/backups"
@@ -122,6 +123,19 @@ func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2)
 }
 
+// CreateIncremental mocks base method
+func (m *MockAPIClient) CreateIncremental(arg0 string, arg1, arg2 bool) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "CreateIncremental", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*params.BackupsMetadataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncremental indicates an expected call of CreateIncremental
+func (mr *MockAPIClientMockRecorder) CreateIncremental(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncremental", reflect.TypeOf((*MockAPIClient)(nil).CreateIncremental), arg0, arg1, arg2)
+}
+
 // Download mocks base method
 func (m *MockAPIClient) Download(arg0 string) (io.ReadCloser, error) {
 	ret := m.ctrl.Call(m, "Download", arg0)
@@ -202,6 +216,18 @@ func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2)
 }
 
+// RestoreUntil mocks base method
+func (m *MockAPIClient) RestoreUntil(arg0 string, arg1 time.Time, arg2 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "RestoreUntil", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUntil indicates an expected call of RestoreUntil
+func (mr *MockAPIClientMockRecorder) RestoreUntil(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUntil", reflect.TypeOf((*MockAPIClient)(nil).RestoreUntil), arg0, arg1, arg2)
+}
+
 // Upload mocks base method
 func (m *MockAPIClient) Upload(arg0 io.ReadSeeker, arg1 params.BackupsMetadataResult) (string, error) {
 	ret := m.ctrl.Call(m, "Upload", arg0, arg1)
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -203,6 +204,16 @@ func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params
 	return createResult, nil
 }
 
+func (c *fakeAPIClient) CreateIncremental(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateIncremental")
+	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
+	c.notes = notes
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, id)
@@ -263,3 +274,7 @@ func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResu
 func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
 	return nil
 }
+
+func (c *fakeAPIClient) RestoreUntil(string, time.Time, apibackups.ClientConnection) error {
+	return nil
+}
@@ -18,6 +18,9 @@ import (
 
 const removeDoc = `
 remove-backup removes a backup from remote storage.
+
+Use --destination to remove backups from a backup destination
+rather than from the controller.
 `
 
 // NewRemoveCommand returns a command used to remove a
@@ -48,6 +51,7 @@ func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.KeepLatest, "keep-latest", false,
 		"Remove all backups on remote storage except for the latest.")
+	c.setDestinationFlag(f, "Remove from this backup destination")
 }
 
 // Init implements Command.Init.
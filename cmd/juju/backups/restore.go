@@ -8,6 +8,7 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -40,6 +41,9 @@ type restoreCommand struct {
 
 	Filename string
 	BackupId string
+	Until    time.Time
+
+	until string
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -50,6 +54,9 @@ type RestoreAPI interface {
 	// Restore is taken from backups.Client.
 	Restore(backupId string, newClient backups.ClientConnection) error
 
+	// RestoreUntil is taken from backups.Client.
+	RestoreUntil(backupId string, until time.Time, newClient backups.ClientConnection) error
+
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
 }
@@ -74,6 +81,15 @@ machines will be added or removed during the restore process.
 Note: Extra care is needed to restore in an HA environment, please see
 https://jaas.ai/docs/controller-backups for more information.
 
+Use --destination with --id to restore a backup stored in a backup
+destination rather than on the controller.
+
+Use --until with the --id of an incremental backup to restore the controller
+to the state it was in at the given time, in RFC3339 format (for example
+2020-05-01T03:00:00Z). The time must fall within the range covered by the
+incremental backup, as shown by 'juju show-backup'. The full backup the
+incremental backup is based on must still be available.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
 `
@@ -93,6 +109,8 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.until, "until", "", "Restore an incremental backup up to this time (RFC3339)")
+	c.setDestinationFlag(f, "Restore the backup from this backup destination")
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -103,6 +121,19 @@ func (c *restoreCommand) Init(args []string) error {
 	if c.Filename != "" && c.BackupId != "" {
 		return errors.Errorf("you must specify either a file or a backup id but not both.")
 	}
+	if c.Filename != "" && c.Destination != "" {
+		return errors.Errorf("--destination can only be used with a backup id.")
+	}
+	if c.until != "" {
+		if c.BackupId == "" {
+			return errors.Errorf("--until can only be used with a backup id.")
+		}
+		var err error
+		c.Until, err = time.Parse(time.RFC3339, c.until)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until time")
+		}
+	}
 
 	if c.Filename != "" {
 		var err error
@@ -196,6 +227,8 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	// to restore the backup.
 	if c.Filename != "" {
 		err = client.RestoreReader(archive, meta, c.newClient)
+	} else if !c.Until.IsZero() {
+		err = client.RestoreUntil(c.BackupId, c.Until, c.newClient)
 	} else {
 		err = client.Restore(c.BackupId, c.newClient)
 	}
@@ -6,6 +6,7 @@ package backups_test
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd"
@@ -135,6 +136,31 @@ var testRestoreBackupArgParsing = []restoreBackupArgParsing{
 		args:     []string{"--file", "afile"},
 		filename: "afile",
 	},
+	{
+		title: "id and destination",
+		args:  []string{"--id", "anid", "--destination", "file:///mnt/backups"},
+		id:    "anid",
+	},
+	{
+		title:    "file and destination",
+		args:     []string{"--file", "afile", "--destination", "file:///mnt/backups"},
+		errMatch: "--destination can only be used with a backup id.",
+	},
+	{
+		title: "id and until",
+		args:  []string{"--id", "anid", "--until", "2020-05-01T03:00:00Z"},
+		id:    "anid",
+	},
+	{
+		title:    "file and until",
+		args:     []string{"--file", "afile", "--until", "2020-05-01T03:00:00Z"},
+		errMatch: "--until can only be used with a backup id.",
+	},
+	{
+		title:    "invalid until",
+		args:     []string{"--id", "anid", "--until", "yesterday"},
+		errMatch: `invalid --until time: parsing time "yesterday".*`,
+	},
 }
 
 func (s *restoreSuite) TestArgParsing(c *gc.C) {
@@ -262,3 +288,20 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://jaas.ai/docs/controller-backups")
 }
+
+func (s *restoreSuite) TestRestoreFromBackupIdUntil(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	until := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		apiClient.EXPECT().RestoreUntil("an_id", until, gomock.Any()).Return(
+			nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--until", "2020-05-01T03:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	out := fmt.Sprintf("restore from %q completed\n", s.command.BackupId)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out)
+}
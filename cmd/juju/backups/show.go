@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -15,6 +16,9 @@ import (
 
 const showDoc = `
 show-backup provides the metadata associated with a backup.
+
+Use --destination to show a backup stored in a backup destination
+rather than on the controller.
 `
 
 // NewShowCommand returns a command used to show metadata for a backup.
@@ -39,6 +43,12 @@ func (c *showCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.setDestinationFlag(f, "Show the backup in this backup destination")
+}
+
 // Init implements Command.Init.
 func (c *showCommand) Init(args []string) error {
 	if len(args) == 0 {
@@ -91,6 +91,7 @@ clouds:                           # mandatory
         endpoint: https://london.mycloud.com:35574/v3.0/
 
 <cloud types> for private clouds: 
+ - libvirt
  - lxd
  - maas
  - manual
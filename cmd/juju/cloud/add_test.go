@@ -546,6 +546,7 @@ func (*addSuite) TestInteractive(c *gc.C) {
 
 	c.Assert(out.String(), gc.Equals, ""+
 		"Cloud Types\n"+
+		"  libvirt\n"+
 		"  lxd\n"+
 		"  maas\n"+
 		"  manual\n"+
@@ -631,6 +632,7 @@ func (*addSuite) TestInteractiveManual(c *gc.C) {
 	c.Check(numCallsToWrite(), gc.Equals, 1)
 	c.Assert(out.String(), gc.Equals, `
 Cloud Types
+  libvirt
   lxd
   maas
   manual
@@ -808,6 +810,7 @@ func (*addSuite) TestInteractiveExistingNameNoOverride(c *gc.C) {
 
 	c.Check(numCallsToWrite(), gc.Equals, 1)
 	c.Check(out.String(), gc.Matches, regexp.QuoteMeta("Cloud Types\n"+
+		"  libvirt\n"+
 		"  lxd\n"+
 		"  maas\n"+
 		"  manual\n"+
@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -52,13 +53,23 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by matching
+a regular expression against the text of the log message. Similarly the
+'--include-location' and '--exclude-location' options match a regular
+expression against the source location ("filename:lineno") of the message.
+This filtering is done by the controller, so only matching messages are sent
+to the client.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-message and --include-location options are each logically
+  ORed together, as are the --exclude-message and --exclude-location options.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  message and location selections are logically ANDed to form the complete
+  filter.
 
 Examples:
 
@@ -101,6 +112,14 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages mentioning "connection refused" logged by the uniter
+source files of any mysql unit, and then stop:
+
+    juju debug-log --replay --no-tail \
+        --include mysql \
+        --include-message "connection refused" \
+        --include-location "uniter/.*\.go"
+
 See also:
     status
     ssh`
@@ -150,6 +169,10 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages whose text matches these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages whose text matches these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLocation), "include-location", "Only show log messages whose source location matches these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLocation), "exclude-location", "Do not show log messages whose source location matches these regular expressions")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -181,6 +204,21 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	for _, arg := range []struct {
+		flag  string
+		exprs []string
+	}{
+		{"--include-message", c.params.IncludeMessage},
+		{"--exclude-message", c.params.ExcludeMessage},
+		{"--include-location", c.params.IncludeLocation},
+		{"--exclude-location", c.params.ExcludeLocation},
+	} {
+		for _, expr := range arg.exprs {
+			if _, err := regexp.Compile(expr); err != nil {
+				return errors.Errorf("%s value %q is not a valid regular expression", arg.flag, expr)
+			}
+		}
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
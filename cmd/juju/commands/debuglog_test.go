@@ -89,6 +89,26 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--include-message", "fail.*", "--exclude-message", "ping"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"fail.*"},
+				ExcludeMessage: []string{"ping"},
+				Backlog:        10,
+			},
+		}, {
+			args: []string{"--include-location", `uniter\.go`, "--exclude-location", "worker/"},
+			expected: common.DebugLogParams{
+				IncludeLocation: []string{`uniter\.go`},
+				ExcludeLocation: []string{"worker/"},
+				Backlog:         10,
+			},
+		}, {
+			args:     []string{"--include-message", "fail("},
+			errMatch: `--include-message value "fail\(" is not a valid regular expression`,
+		}, {
+			args:     []string{"--exclude-location", "[a-"},
+			errMatch: `--exclude-location value "\[a-" is not a valid regular expression`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
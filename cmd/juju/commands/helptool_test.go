@@ -71,6 +71,11 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               add a new secret
+    secret-get               print the value of a secret
+    secret-grant             grant access to a secret
+    secret-revoke            revoke access to a secret
+    secret-set               update an existing secret
     state-delete             delete server-side-state key value pair
     state-get                print server-side-state value
     state-set                set server-side-state values
@@ -92,6 +97,7 @@ Aliases: help-tool, hook-tools
 }
 
 var expectedCommands = []string{
+	"action-attach",
 	"action-fail",
 	"action-get",
 	"action-log",
@@ -122,6 +128,11 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
+	"secret-revoke",
+	"secret-set",
 	"state-delete",
 	"state-get",
 	"state-set",
@@ -482,6 +482,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bind",
@@ -522,6 +523,7 @@ var commandNames = []string{
 	"enable-user",
 	"exec",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -535,6 +537,7 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -554,6 +557,8 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-scheduled-actions",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -607,7 +612,10 @@ var commandNames = []string{
 	"revoke-cloud",
 	"run",
 	"scale-application",
+	"schedule-action",
+	"scheduled-actions",
 	"scp",
+	"secrets",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -626,11 +634,14 @@ var commandNames = []string{
 	"show-credential",
 	"show-credentials",
 	"show-machine",
+	"show-migration",
 	"show-model",
 	"show-offer",
+	"show-secret",
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-space",
 	"show-user",
 	"show-wallet",
@@ -649,6 +660,7 @@ var commandNames = []string{
 	"trust",
 	"unexpose",
 	"unregister",
+	"unschedule-action",
 	"update-cloud",
 	"update-k8s",
 	"update-public-clouds",
@@ -664,6 +676,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
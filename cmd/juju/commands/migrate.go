@@ -4,11 +4,13 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/macaroon-bakery.v2/httpbakery"
 	"gopkg.in/macaroon.v2"
@@ -33,6 +35,8 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	dryRun           bool
+	offline          bool
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
@@ -43,6 +47,7 @@ type migrateCommand struct {
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	DryRunMigration(spec controller.MigrationSpec) (controller.MigrationProblems, error)
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -80,9 +85,33 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+The --dry-run option checks whether the model could be migrated to the
+target controller without starting a migration. Every problem found on
+the source and target controllers is reported, rather than stopping at
+the first one. The command exits with an error if any problems were
+found.
+
+The --offline option completes the migration of a model which was
+exported with "juju export-model" and imported into the target
+controller with "juju import-model". Exporting a model locks it, and
+the imported copy is left inactive. The offline migration only passes
+the target controller's addresses and CA certificate to the model's
+agents, which switch over to it; the source controller never connects
+to the target controller, and the model's logs are not transferred.
+Once the migration has finished, activate the imported copy with
+"juju import-model --activate" against the target controller. If the
+migration is aborted the exported model is unlocked, and the imported
+copy must be removed with "juju import-model --abort".
+
+Examples:
+    juju migrate mymodel othercontroller
+    juju migrate --dry-run mymodel othercontroller
+    juju migrate --offline mymodel othercontroller
+
 See also:
     login
     controllers
+    show-migration
     status
 `
 
@@ -96,6 +125,13 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated, without migrating it")
+	f.BoolVar(&c.offline, "offline", false, "Switch an exported model over to the controller it was imported into")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -107,6 +143,9 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) > 2 {
 		return errors.New("too many arguments specified")
 	}
+	if c.dryRun && c.offline {
+		return errors.New("--dry-run cannot be used with --offline")
+	}
 
 	if err := c.SetModelIdentifier(args[0], false); err != nil {
 		return errors.Trace(err)
@@ -131,6 +170,12 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	spec.Offline = c.offline
+	if c.dryRun {
+		// The user checks are reported by the controller as part
+		// of the dry run, along with everything else.
+		return c.dryRunMigration(ctx, spec, modelName)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -151,6 +196,39 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) dryRunMigration(ctx *cmd.Context, spec *controller.MigrationSpec, modelName string) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	problems, err := api.DryRunMigration(*spec)
+	if err != nil {
+		return err
+	}
+	if len(problems.Source) == 0 && len(problems.Target) == 0 {
+		ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+		return nil
+	}
+	printProblems(ctx, "Source controller", problems.Source)
+	printProblems(ctx, "Target controller", problems.Target)
+	return cmd.ErrSilent
+}
+
+func printProblems(ctx *cmd.Context, heading string, problems []string) {
+	if len(problems) == 0 {
+		return
+	}
+	fmt.Fprintf(ctx.Stdout, "%s:\n", heading)
+	for _, problem := range problems {
+		fmt.Fprintf(ctx.Stdout, "  - %s\n", problem)
+	}
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
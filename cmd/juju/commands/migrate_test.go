@@ -289,6 +289,77 @@ func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestOffline(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--offline", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, "Migration started with ID \"uuid:0\"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+		Offline:               true,
+	})
+}
+
+func (s *MigrateSuite) TestOfflineDryRun(c *gc.C) {
+	_, err := s.makeAndRun(c, "--offline", "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--dry-run cannot be used with --offline")
+}
+
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+	c.Check(s.api.dryRunSpecSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.problems = controller.MigrationProblems{
+		Source: []string{"machine 0 is dying", "unit foo/0 is dying"},
+		Target: []string{"user bob does not exist"},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Source controller:
+  - machine 0 is dying
+  - unit foo/0 is dying
+Target controller:
+  - user bob does not exist
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunTargetProblemsOnly(c *gc.C) {
+	s.api.problems = controller.MigrationProblems{
+		Target: []string{"model with same UUID already exists"},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Target controller:
+  - model with same UUID already exists
+`[1:])
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	cmd := s.makeCommand()
 	_, err := cmdtesting.RunCommand(c, cmd, "wat", "target")
@@ -440,8 +511,10 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen    *controller.MigrationSpec
-	identityURL string
+	specSeen       *controller.MigrationSpec
+	dryRunSpecSeen *controller.MigrationSpec
+	problems       controller.MigrationProblems
+	identityURL    string
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -449,6 +522,11 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) DryRunMigration(spec controller.MigrationSpec) (controller.MigrationProblems, error) {
+	a.dryRunSpecSeen = &spec
+	return a.problems, nil
+}
+
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
 	return a.identityURL, nil
 }
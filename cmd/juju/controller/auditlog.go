@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+)
+
+// defaultAuditLogLimit is the number of entries shown when no
+// --limit is given.
+const defaultAuditLogLimit = 100
+
+// NewAuditLogCommand returns a command to query the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api auditLogAPI
+
+	query  coreauditlog.Query
+	model  string
+	after  string
+	before string
+}
+
+type auditLogAPI interface {
+	Close() error
+	Query(coreauditlog.Query) ([]params.AuditLogEntry, error)
+}
+
+const auditLogDoc = `
+The audit-log command shows the API requests recorded in the audit
+logs of all the machines in the controller, which is only possible if
+auditing is enabled with the "auditing-enabled" controller config
+setting. Only controller superusers can read the audit log.
+
+Requests can be selected by the user making them, the model they were
+made against, the facade and method called, the time they were made
+and whether they failed. Times are given in RFC3339 format, such as
+"2020-05-01T03:00:00Z". By default the 100 most recent matching
+requests are shown; use --limit to change this.
+
+Requests to methods excluded from the audit log by the
+"audit-log-exclude-methods" controller config setting are not shown.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --model prod
+    juju audit-log --facade Application --method Deploy
+    juju audit-log --after 2020-05-01T00:00:00Z --errors-only
+    juju audit-log --limit 1000 --format json
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the API requests recorded in the controller's audit log.",
+		Doc:     auditLogDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.query.Who, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made against this model (name or UUID)")
+	f.StringVar(&c.query.Facade, "facade", "", "Only show requests to this facade")
+	f.StringVar(&c.query.Method, "method", "", "Only show requests to this method")
+	f.StringVar(&c.after, "after", "", "Only show requests made at or after this time")
+	f.StringVar(&c.before, "before", "", "Only show requests made before this time")
+	f.BoolVar(&c.query.ErrorsOnly, "errors-only", false, "Only show requests that returned errors")
+	f.IntVar(&c.query.Limit, "limit", defaultAuditLogLimit, "The maximum number of requests to show")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	var err error
+	if c.after != "" {
+		if c.query.After, err = time.Parse(time.RFC3339, c.after); err != nil {
+			return errors.Errorf("invalid --after time: %v", err)
+		}
+	}
+	if c.before != "" {
+		if c.query.Before, err = time.Parse(time.RFC3339, c.before); err != nil {
+			return errors.Errorf("invalid --before time: %v", err)
+		}
+	}
+	if c.query.Limit <= 0 {
+		return errors.New("--limit must be greater than zero")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	if c.model != "" {
+		if utils.IsValidUUIDString(c.model) {
+			c.query.ModelUUID = c.model
+		} else {
+			uuids, err := c.ModelUUIDs([]string{c.model})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			c.query.ModelUUID = uuids[0]
+		}
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit log entries found.")
+		return nil
+	}
+	formatted := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		formatted[i] = formatAuditLogEntry(entry)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// auditLogEntry is the output representation of an audit log entry.
+type auditLogEntry struct {
+	When           string          `yaml:"when" json:"when"`
+	Controller     string          `yaml:"controller" json:"controller"`
+	User           string          `yaml:"user" json:"user"`
+	Command        string          `yaml:"command,omitempty" json:"command,omitempty"`
+	Model          string          `yaml:"model" json:"model"`
+	ModelUUID      string          `yaml:"model-uuid" json:"model-uuid"`
+	ConversationID string          `yaml:"conversation-id" json:"conversation-id"`
+	RequestID      uint64          `yaml:"request-id" json:"request-id"`
+	Facade         string          `yaml:"facade" json:"facade"`
+	Method         string          `yaml:"method" json:"method"`
+	Version        int             `yaml:"version" json:"version"`
+	Args           string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors         []auditLogError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+type auditLogError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func formatAuditLogEntry(entry params.AuditLogEntry) auditLogEntry {
+	result := auditLogEntry{
+		When:           entry.When,
+		Controller:     entry.ControllerID,
+		User:           entry.Who,
+		Command:        entry.What,
+		Model:          entry.ModelName,
+		ModelUUID:      entry.ModelUUID,
+		ConversationID: entry.ConversationID,
+		RequestID:      entry.RequestID,
+		Facade:         entry.Facade,
+		Method:         entry.Method,
+		Version:        entry.Version,
+		Args:           entry.Args,
+	}
+	for _, e := range entry.Errors {
+		result.Errors = append(result.Errors, auditLogError{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Controller", "User", "Model", "Request", "Errors")
+	for _, entry := range entries {
+		var messages []string
+		for _, e := range entry.Errors {
+			messages = append(messages, e.Message)
+		}
+		w.Println(
+			entry.When,
+			entry.Controller,
+			entry.User,
+			entry.Model,
+			fmt.Sprintf("%s(%d).%s", entry.Facade, entry.Version, entry.Method),
+			strings.Join(messages, "; "),
+		)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/jujuclient"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			ControllerID:   "0",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			ModelName:      "prod",
+			ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			RequestID:      1,
+			When:           "2020-05-01T03:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        7,
+		}, {
+			ControllerID:   "1",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			ModelName:      "prod",
+			ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			RequestID:      2,
+			When:           "2020-05-01T03:00:02Z",
+			Facade:         "Application",
+			Method:         "AddRelation",
+			Version:        7,
+			Errors:         []*params.Error{{Message: "no relations found", Code: "not found"}},
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.store.Models["fake"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/prod": {ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		},
+	}
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *auditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *auditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Controller  User  Model  Request                     Errors\n"+
+		"2020-05-01T03:00:01Z  0           bob   prod   Application(7).Deploy       \n"+
+		"2020-05-01T03:00:02Z  1           bob   prod   Application(7).AddRelation  no relations found\n",
+	)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "Query", Args: []interface{}{coreauditlog.Query{Limit: 100}}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *auditLogSuite) TestYAML(c *gc.C) {
+	s.api.entries = s.api.entries[1:]
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- when: "2020-05-01T03:00:02Z"
+  controller: "1"
+  user: bob
+  command: juju deploy mysql
+  model: prod
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  conversation-id: 0123456789abcdef
+  request-id: 2
+  facade: Application
+  method: AddRelation
+  version: 7
+  errors:
+  - message: no relations found
+    code: not found
+`[1:])
+}
+
+func (s *auditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No audit log entries found.\n")
+}
+
+func (s *auditLogSuite) TestQueryArgs(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "admin/prod",
+		"--facade", "Application",
+		"--method", "Deploy",
+		"--after", "2020-05-01T00:00:00Z",
+		"--before", "2020-05-02T00:00:00Z",
+		"--errors-only",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", coreauditlog.Query{
+		Who:        "bob",
+		ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:     "Application",
+		Method:     "Deploy",
+		After:      time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+		Before:     time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC),
+		ErrorsOnly: true,
+		Limit:      5,
+	})
+}
+
+func (s *auditLogSuite) TestModelUUID(c *gc.C) {
+	_, err := s.run(c, "--model", "deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", coreauditlog.Query{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		Limit:     100,
+	})
+}
+
+func (s *auditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--after", "yesterday"},
+		err:  `invalid --after time: .*`,
+	}, {
+		args: []string{"--before", "2020-05-01"},
+		err:  `invalid --before time: .*`,
+	}, {
+		args: []string{"--limit", "0"},
+		err:  `--limit must be greater than zero`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(common.ErrPerm)
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	jujutesting.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Query(query coreauditlog.Query) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "Query", query)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration/archive"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a command which imports a model from an
+// offline migration archive into a controller.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api ImportModelAPI
+
+	filename     string
+	caCertFile   string
+	sourceCACert string
+	activate     bool
+	abort        bool
+}
+
+// ImportModelAPI defines the methods on the MigrationTarget facade
+// which the import-model command calls.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import(coremigration.SerializedModel) error
+	Abort(string) error
+	Activate(string) error
+	AdoptResources(string) error
+	CheckMachines(string) ([]error, error)
+	UploadCharm(string, *charm.URL, io.ReadSeeker) (*charm.URL, error)
+	UploadTools(string, io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+	UploadResource(string, resource.Resource, io.ReadSeeker) error
+	SetUnitResource(string, string, resource.Resource) error
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive written by "juju export-model" into the
+controller. The model is recreated with the charms, agent binaries and
+resources held in the archive, without the source and target controllers
+needing to be able to reach one another.
+
+The archive is signed by the controller it was exported from. The
+signature is checked against the CA certificate of that controller, which
+is taken from the local client store when the controller is known to this
+client, or else must be supplied with --source-ca-cert.
+
+Exporting the model locked it on the source controller, and the
+imported model is left inactive here. Once the import is complete, run
+"juju migrate --offline" against the source controller to switch the
+model's agents over to this controller. The source controller never
+connects to this controller, so when that migration has finished, run
+"juju import-model --activate" with the same archive to activate the
+model here and take ownership of its cloud resources. If the offline
+migration is aborted instead, run "juju import-model --abort" to remove
+the imported model.
+
+Examples:
+
+    juju import-model mymodel.tar
+    juju import-model -c target mymodel.tar --source-ca-cert source-ca.pem
+    juju import-model --activate mymodel.tar
+    juju import-model --abort mymodel.tar
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Imports a model from an offline migration archive.",
+		Doc:     importModelHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.caCertFile, "source-ca-cert", "", "File holding the CA certificate of the exporting controller")
+	f.BoolVar(&c.activate, "activate", false, "Activate a model imported earlier, once its agents have switched over")
+	f.BoolVar(&c.abort, "abort", false, "Remove a model imported earlier, after its offline migration was aborted")
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	if c.activate && c.abort {
+		return errors.New("--activate cannot be used with --abort")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return importModelClient{
+		Client: migrationtarget.NewClient(root),
+		conn:   root,
+	}, nil
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	file, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+
+	a, err := archive.Read(file)
+	if err != nil {
+		return errors.Annotate(err, "reading archive")
+	}
+	defer a.Close()
+
+	caCert, err := c.sourceCACertFor(ctx, a.Info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.Verify(caCert); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	modelInfo := a.Info.Model
+	switch {
+	case c.activate:
+		return c.activateModel(ctx, client, modelInfo)
+	case c.abort:
+		if err := client.Abort(modelInfo.UUID); err != nil {
+			return errors.Annotate(err, "removing imported model")
+		}
+		ctx.Infof("Imported model %q removed", modelInfo.Name)
+		return nil
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "target prechecks failed")
+	}
+	ctx.Infof("Importing model %q", modelInfo.Name)
+	// Archives don't hold the model's secret values or action
+	// schedules, which are only transferred between controllers by a
+	// live migration.
+	if err := client.Import(coremigration.SerializedModel{Bytes: a.Bytes}); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	if err := c.completeImport(client, a); err != nil {
+		if abortErr := client.Abort(modelInfo.UUID); abortErr != nil {
+			logger.Errorf("unable to abort import of model %q: %v", modelInfo.Name, abortErr)
+		}
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q imported, run \"juju migrate --offline\" on the source controller to switch it over", modelInfo.Name)
+	return nil
+}
+
+// activateModel activates a model imported earlier, once the offline
+// migration on the source controller has switched its agents over, and
+// has the cloud provider hand the model's resources to this controller.
+func (c *importModelCommand) activateModel(ctx *cmd.Context, client ImportModelAPI, modelInfo coremigration.ModelInfo) error {
+	if err := client.Activate(modelInfo.UUID); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	if err := client.AdoptResources(modelInfo.UUID); err != nil {
+		return errors.Annotate(err, "adopting cloud resources")
+	}
+	ctx.Infof("Model %q activated", modelInfo.Name)
+	return nil
+}
+
+// sourceCACertFor returns the CA certificate which the archive's
+// signature is verified against.
+func (c *importModelCommand) sourceCACertFor(ctx *cmd.Context, info archive.Info) (string, error) {
+	if c.caCertFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.caCertFile))
+		if err != nil {
+			return "", errors.Annotate(err, "reading source CA certificate")
+		}
+		return string(data), nil
+	}
+	controllers, err := c.ClientStore().AllControllers()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, details := range controllers {
+		if details.ControllerUUID == info.SourceControllerUUID {
+			return details.CACert, nil
+		}
+	}
+	return "", errors.Errorf(
+		"source controller %s not known to this client, "+
+			"use --source-ca-cert to supply its CA certificate",
+		info.SourceControllerUUID,
+	)
+}
+
+// completeImport uploads the binaries held in the archive for the newly
+// imported model and checks its machines. The model is only activated
+// once the offline migration has switched its agents over, so that it
+// is never live on both controllers.
+func (c *importModelCommand) completeImport(client ImportModelAPI, a *archive.Archive) error {
+	modelUUID := a.Info.Model.UUID
+	if err := a.Upload(importModelUploader{client: client, modelUUID: modelUUID}); err != nil {
+		return errors.Annotate(err, "uploading binaries")
+	}
+	failures, err := client.CheckMachines(modelUUID)
+	if err != nil {
+		return errors.Annotate(err, "checking machines")
+	}
+	if len(failures) > 0 {
+		messages := make([]string, len(failures))
+		for i, failure := range failures {
+			messages[i] = failure.Error()
+		}
+		return errors.Errorf("machine sanity check failed:\n%s", strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+// importModelClient closes the API connection used by the
+// MigrationTarget client.
+type importModelClient struct {
+	*migrationtarget.Client
+	conn api.Connection
+}
+
+// Close is part of the ImportModelAPI interface.
+func (c importModelClient) Close() error {
+	return c.conn.Close()
+}
+
+// importModelUploader adapts an ImportModelAPI to the archive.Uploader
+// interface for a single model.
+type importModelUploader struct {
+	client    ImportModelAPI
+	modelUUID string
+}
+
+// UploadCharm is part of the archive.Uploader interface.
+func (u importModelUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools is part of the archive.Uploader interface.
+func (u importModelUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadResource is part of the archive.Uploader interface.
+func (u importModelUploader) UploadResource(res resource.Resource, r io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, r)
+}
+
+// SetUnitResource is part of the archive.Uploader interface.
+func (u importModelUploader) SetUnitResource(unit string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unit, res)
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration/archive"
+	"github.com/juju/juju/resource"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	importModelUUID        = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	importSourceController = "c0ffee00-0bad-400d-8000-4b1d0d06f00d"
+)
+
+type importModelSuite struct {
+	baseControllerSuite
+	api      *fakeImportModelAPI
+	store    *jujuclient.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&importModelSuite{})
+
+func (s *importModelSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeImportModelAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "target"
+	s.store.Controllers["target"] = jujuclient.ControllerDetails{}
+	s.store.Controllers["source"] = jujuclient.ControllerDetails{
+		ControllerUUID: importSourceController,
+		CACert:         coretesting.CACert,
+	}
+	s.filename = s.writeArchive(c)
+}
+
+func (s *importModelSuite) writeArchive(c *gc.C) string {
+	model := description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name": "mymodel",
+			"uuid": importModelUUID,
+		},
+	})
+	serialized, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := archive.Info{
+		SourceControllerUUID: importSourceController,
+		SourceCACert:         coretesting.CACert,
+		Model: coremigration.ModelInfo{
+			UUID:                   importModelUUID,
+			Owner:                  names.NewUserTag("admin"),
+			Name:                   "mymodel",
+			AgentVersion:           version.MustParse("2.8.0"),
+			ControllerAgentVersion: version.MustParse("2.8.0"),
+		},
+	}
+	var buf bytes.Buffer
+	err = archive.Write(&buf, info, serialized, emptyArchiveSource{}, coretesting.CAKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := filepath.Join(c.MkDir(), "mymodel.tar")
+	err = ioutil.WriteFile(filename, buf.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *importModelSuite) runImport(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewImportModelCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *importModelSuite) TestInitNoFile(c *gc.C) {
+	_, err := s.runImport(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+}
+
+func (s *importModelSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.runImport(c, "a.tar", "b.tar")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.tar"\]`)
+}
+
+func (s *importModelSuite) TestImport(c *gc.C) {
+	ctx, err := s.runImport(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	// The model is left inactive until it is switched over.
+	s.api.CheckCallNames(c, "Prechecks", "Import", "CheckMachines", "Close")
+	s.api.CheckCall(c, 0, "Prechecks", "mymodel")
+	s.api.CheckCall(c, 2, "CheckMachines", importModelUUID)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Importing model \"mymodel\"\n"+
+		"Model \"mymodel\" imported, run \"juju migrate --offline\" on the source controller to switch it over\n")
+}
+
+func (s *importModelSuite) TestInitActivateAndAbort(c *gc.C) {
+	_, err := s.runImport(c, "--activate", "--abort", "a.tar")
+	c.Assert(err, gc.ErrorMatches, "--activate cannot be used with --abort")
+}
+
+func (s *importModelSuite) TestActivate(c *gc.C) {
+	ctx, err := s.runImport(c, "--activate", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Activate", "AdoptResources", "Close")
+	s.api.CheckCall(c, 0, "Activate", importModelUUID)
+	s.api.CheckCall(c, 1, "AdoptResources", importModelUUID)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Model \"mymodel\" activated\n")
+}
+
+func (s *importModelSuite) TestActivateFailed(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runImport(c, "--activate", s.filename)
+	c.Assert(err, gc.ErrorMatches, "activating model: boom")
+	s.api.CheckCallNames(c, "Activate", "Close")
+}
+
+func (s *importModelSuite) TestAbort(c *gc.C) {
+	ctx, err := s.runImport(c, "--abort", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Abort", "Close")
+	s.api.CheckCall(c, 0, "Abort", importModelUUID)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Imported model \"mymodel\" removed\n")
+}
+
+func (s *importModelSuite) TestActivateWrongCACert(c *gc.C) {
+	certFile := filepath.Join(c.MkDir(), "ca.pem")
+	err := ioutil.WriteFile(certFile, []byte(coretesting.OtherCACert), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runImport(c, "--activate", s.filename, "--source-ca-cert", certFile)
+	c.Assert(err, gc.ErrorMatches, "archive signature not valid.*")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *importModelSuite) TestImportSourceCACertFlag(c *gc.C) {
+	delete(s.store.Controllers, "source")
+	certFile := filepath.Join(c.MkDir(), "ca.pem")
+	err := ioutil.WriteFile(certFile, []byte(coretesting.CACert), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runImport(c, s.filename, "--source-ca-cert", certFile)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Prechecks", "Import", "CheckMachines", "Close")
+}
+
+func (s *importModelSuite) TestImportUnknownSourceController(c *gc.C) {
+	delete(s.store.Controllers, "source")
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "source controller "+importSourceController+
+		" not known to this client, use --source-ca-cert to supply its CA certificate")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *importModelSuite) TestImportWrongCACert(c *gc.C) {
+	certFile := filepath.Join(c.MkDir(), "ca.pem")
+	err := ioutil.WriteFile(certFile, []byte(coretesting.OtherCACert), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runImport(c, s.filename, "--source-ca-cert", certFile)
+	c.Assert(err, gc.ErrorMatches, "archive signature not valid.*")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *importModelSuite) TestImportPrechecksFailed(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "target prechecks failed: boom")
+	s.api.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *importModelSuite) TestImportAbortsOnMachineFailures(c *gc.C) {
+	s.api.machineFailures = []error{errors.New("machine 0 not found")}
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "machine sanity check failed:\nmachine 0 not found")
+	s.api.CheckCallNames(c, "Prechecks", "Import", "CheckMachines", "Abort", "Close")
+	s.api.CheckCall(c, 3, "Abort", importModelUUID)
+}
+
+type emptyArchiveSource struct{}
+
+func (emptyArchiveSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return nil, errors.NotFoundf("charm %s", curl)
+}
+
+func (emptyArchiveSource) OpenTools(v version.Binary) (io.ReadCloser, error) {
+	return nil, errors.NotFoundf("tools %s", v)
+}
+
+func (emptyArchiveSource) OpenResource(application, name string) (io.ReadCloser, error) {
+	return nil, errors.NotFoundf("resource %s/%s", application, name)
+}
+
+type fakeImportModelAPI struct {
+	gitjujutesting.Stub
+	machineFailures []error
+}
+
+func (f *fakeImportModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Prechecks(model coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", model.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Import(serialized coremigration.SerializedModel) error {
+	f.MethodCall(f, "Import")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) CheckMachines(modelUUID string) ([]error, error) {
+	f.MethodCall(f, "CheckMachines", modelUUID)
+	return f.machineFailures, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl)
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, _ ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	return tools.List{{Version: vers}}, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res.Name)
+	return f.NextErr()
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -94,6 +95,7 @@ type ControllerAccessAPI interface {
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
 	ControllerVersion() (controller.ControllerVersion, error)
+	BackupScheduleStatus() (controller.BackupScheduleStatus, error)
 	Close() error
 }
 
@@ -188,6 +190,14 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 				details.Errors = append(details.Errors, err.Error())
 				mongoVersion = "(error)"
 			}
+			// Fetch the scheduled backup status if the apiserver
+			// supports it, and scheduled backups have been run.
+			backupStatus, err := client.BackupScheduleStatus()
+			if err == nil {
+				details.ScheduledBackups = convertBackupScheduleStatusForShow(backupStatus)
+			} else if !errors.IsNotSupported(err) && !errors.IsNotFound(err) {
+				details.Errors = append(details.Errors, err.Error())
+			}
 		}
 
 		// Fetch identityURL if the apiserver supports it
@@ -291,6 +301,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// ScheduledBackups holds the status of the controller's scheduled backups.
+	ScheduledBackups *BackupScheduleDetails `yaml:"scheduled-backups,omitempty" json:"scheduled-backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -386,6 +399,34 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupScheduleDetails holds the outcome of a controller's most recent
+// scheduled backups.
+type BackupScheduleDetails struct {
+	// LastAttempt is when a scheduled backup was last attempted.
+	LastAttempt string `yaml:"last-attempt" json:"last-attempt"`
+
+	// LastSuccess is when a scheduled backup last succeeded.
+	LastSuccess string `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string `yaml:"last-backup-id,omitempty" json:"last-backup-id,omitempty"`
+
+	// LastError is the reason the last scheduled backup failed.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
+func convertBackupScheduleStatusForShow(status controller.BackupScheduleStatus) *BackupScheduleDetails {
+	details := &BackupScheduleDetails{
+		LastAttempt:  status.LastAttempt.UTC().Format(time.RFC3339),
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	if !status.LastSuccess.IsZero() {
+		details.LastSuccess = status.LastSuccess.UTC().Format(time.RFC3339)
+	}
+	return details
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
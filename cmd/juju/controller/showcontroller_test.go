@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -527,6 +528,28 @@ func (s *ShowControllerSuite) TestShowControllerWithIdentityProvider(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "identity-url: "+expURL)
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithScheduledBackups(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "scheduled-backups")
+
+	s.fakeController.backupStatus = &apicontroller.BackupScheduleStatus{
+		LastAttempt:  time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC),
+		LastSuccess:  time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		LastBackupID: "20200501-030000.deadbeef",
+		LastError:    "HA not ready",
+	}
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, jc.Contains, "scheduled-backups:")
+	c.Assert(out, gc.Matches, `(?s).*last-attempt: "?2020-05-02T03:00:00Z"?\n.*`)
+	c.Assert(out, gc.Matches, `(?s).*last-success: "?2020-05-01T03:00:00Z"?\n.*`)
+	c.Assert(out, jc.Contains, "last-backup-id: 20200501-030000.deadbeef\n")
+	c.Assert(out, jc.Contains, "last-error: HA not ready\n")
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithCAFingerprint(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -668,6 +691,7 @@ type fakeController struct {
 	bestAPIVersion    int
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
+	backupStatus      *apicontroller.BackupScheduleStatus
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -728,6 +752,13 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) BackupScheduleStatus() (apicontroller.BackupScheduleStatus, error) {
+	if c.backupStatus == nil {
+		return apicontroller.BackupScheduleStatus{}, errors.NotFoundf("scheduled backup")
+	}
+	return *c.backupStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
@@ -368,14 +368,16 @@ func (c *configCommand) handleIsKeyOfModel(attrs config.ConfigValues, ctx *cmd.C
 			return attrs, nil, false
 		}
 	} else {
-		// In tabular format, don't print "cloudinit-userdata" it can be very long,
-		// instead give instructions on how to print specifically.
-		if value, ok := attrs[config.CloudInitUserDataKey]; ok && c.out.Name() == "tabular" {
-			if value.Value.(string) != "" {
-				value.Value = "<value set, see juju model-config cloudinit-userdata>"
-				attrs["cloudinit-userdata"] = value
+		// In tabular format, don't print "cloudinit-userdata" or
+		// "cloudinit-user-hooks" as they can be very long, instead
+		// give instructions on how to print them specifically.
+		if c.out.Name() == "tabular" {
+			for _, key := range []string{config.CloudInitUserDataKey, config.CloudInitUserHooksKey} {
+				if value, ok := attrs[key]; ok && value.Value.(string) != "" {
+					value.Value = fmt.Sprintf("<value set, see juju model-config %s>", key)
+					attrs[key] = value
+				}
 			}
-			return attrs, nil, true
 		}
 	}
 	return attrs, nil, true
@@ -288,6 +288,25 @@ func (s *ConfigCommandSuite) TestPassesCloudInitUserDataLong(c *gc.C) {
 	c.Assert(output2, gc.Equals, expected2)
 }
 
+func (s *ConfigCommandSuite) TestPassesCloudInitUserHooksLong(c *gc.C) {
+	modelCfg, err := s.fake.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	modelCfg["cloudinit-user-hooks"] = "pre-agent-install: echo hello"
+	err = s.fake.ModelSet(modelCfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"Attribute             From   Value\n" +
+		"cloudinit-user-hooks  model  <value set, see juju model-config cloudinit-user-hooks>\n" +
+		"running               model  true\n" +
+		"special               model  special value\n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
 func (s *ConfigCommandSuite) TestPassesCloudInitUserDataShort(c *gc.C) {
 	modelCfg, err := s.fake.ModelGet()
 	c.Assert(err, jc.ErrorIsNil)
@@ -59,6 +59,20 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewExportModelCommandForTest returns an ExportModelCommand with the api provided as specified.
+func NewExportModelCommandForTest(api ExportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowMigrationCommandForTest returns a ShowMigrationCommand with the api provided as specified.
+func NewShowMigrationCommandForTest(api ShowMigrationAPI, clk jujuclock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showMigrationCommand{api: api, clock: clk}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
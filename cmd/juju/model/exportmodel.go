@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportModelCommand returns a fully constructed export-model command.
+func NewExportModelCommand() cmd.Command {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	api ExportModelAPI
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes a signed archive of the model to the given file. The archive holds
+the model's description along with the charms, agent binaries and
+resources it uses, so that the model can be moved to a controller which
+cannot be reached from this one using "juju import-model".
+
+The archive is signed with the CA key of the controller hosting the model.
+Exporting locks the model: its agents keep running, but it can no longer
+be changed. Once the archive has been imported elsewhere, run
+"juju migrate --offline" to switch the model's agents over to the
+importing controller and remove the model from this one, then run
+"juju import-model --activate" against the importing controller. The
+controller model cannot be exported.
+
+Examples:
+
+    juju export-model mymodel.tar
+    juju export-model -m mycontroller:mymodel mymodel.tar
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "Writes a model to an archive for an offline migration.",
+		Doc:     exportModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the API calls used by the export-model command.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel() (io.ReadCloser, error)
+}
+
+func (c *exportModelCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	filename := ctx.AbsPath(c.filename)
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.export(client, file); err != nil {
+		file.Close()
+		os.Remove(filename)
+		return errors.Trace(err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(filename)
+		return errors.Trace(err)
+	}
+
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q exported to %s", modelName, filename)
+	return nil
+}
+
+func (c *exportModelCommand) export(client ExportModelAPI, w io.Writer) error {
+	reader, err := client.ExportModel()
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	defer reader.Close()
+	if _, err := io.Copy(w, reader); err != nil {
+		return errors.Annotate(err, "writing archive")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeExportModelClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+type fakeExportModelClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelClient) ExportModel() (io.ReadCloser, error) {
+	f.MethodCall(f, "ExportModel")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader("archive contents")), nil
+}
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportModelCommandSuite) TestInitNoFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+}
+
+func (s *ExportModelCommandSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), "a.tar", "b.tar")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.tar"\]`)
+}
+
+func (s *ExportModelCommandSuite) TestExport(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar")
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ExportModel", "Close")
+
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive contents")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `Model "admin/mymodel" exported to `+filename+"\n")
+}
+
+func (s *ExportModelCommandSuite) TestExportExistingFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar")
+	err := ioutil.WriteFile(filename, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), filename)
+	c.Assert(err, gc.ErrorMatches, ".*file exists")
+	s.fake.CheckCallNames(c, "Close")
+
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "precious")
+}
+
+func (s *ExportModelCommandSuite) TestExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "mymodel.tar")
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), filename)
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+	s.fake.CheckCallNames(c, "ExportModel", "Close")
+	c.Assert(filename, jc.DoesNotExist)
+}
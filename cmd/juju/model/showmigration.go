@@ -0,0 +1,251 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	jujuclock "github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewShowMigrationCommand returns a fully constructed show-migration
+// command.
+func NewShowMigrationCommand() cmd.Command {
+	return modelcmd.Wrap(&showMigrationCommand{
+		clock: jujuclock.WallClock,
+	})
+}
+
+type showMigrationCommand struct {
+	modelcmd.ModelCommandBase
+	out   cmd.Output
+	api   ShowMigrationAPI
+	clock jujuclock.Clock
+}
+
+const showMigrationHelpDoc = `
+Shows the progress of the latest migration of a model to another
+controller. The time at which the migration entered each of its phases
+is listed, along with the amount of data sent to the target controller
+during the phase. The model, charms, agent binaries and resources are
+all sent during the IMPORT phase.
+
+For a migration which is still running, the duration of the current phase
+is measured up to now.
+
+Examples:
+
+    juju show-migration
+    juju show-migration -m mycontroller:mymodel --format yaml
+
+See also:
+    migrate
+    show-model
+`
+
+// Info implements Command.
+func (c *showMigrationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-migration",
+		Purpose: "Shows the progress of a model's latest migration.",
+		Doc:     showMigrationHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *showMigrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationTabular,
+	})
+}
+
+// ShowMigrationAPI specifies the API calls used by the show-migration
+// command.
+type ShowMigrationAPI interface {
+	Close() error
+	MigrationProgress(names.ModelTag) (params.MigrationProgress, error)
+}
+
+func (c *showMigrationCommand) getAPI() (ShowMigrationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements Command.
+func (c *showMigrationCommand) Run(ctx *cmd.Context) error {
+	_, details, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	progress, err := client.MigrationProgress(names.NewModelTag(details.ModelUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatMigrationProgress(progress, c.clock.Now()))
+}
+
+// migrationProgress is the serialisation format for the output of the
+// show-migration command.
+type migrationProgress struct {
+	MigrationId      string                  `yaml:"migration-id" json:"migration-id"`
+	Attempt          int                     `yaml:"attempt" json:"attempt"`
+	Phase            string                  `yaml:"phase" json:"phase"`
+	Status           string                  `yaml:"status,omitempty" json:"status,omitempty"`
+	TargetController string                  `yaml:"target-controller" json:"target-controller"`
+	Started          time.Time               `yaml:"started" json:"started"`
+	Ended            *time.Time              `yaml:"ended,omitempty" json:"ended,omitempty"`
+	Elapsed          string                  `yaml:"elapsed" json:"elapsed"`
+	Sent             int64                   `yaml:"sent" json:"sent"`
+	Phases           []migrationPhaseDetails `yaml:"phases" json:"phases"`
+}
+
+// migrationPhaseDetails describes a single phase of a migration.
+type migrationPhaseDetails struct {
+	Phase    string                  `yaml:"phase" json:"phase"`
+	Started  time.Time               `yaml:"started" json:"started"`
+	Ended    *time.Time              `yaml:"ended,omitempty" json:"ended,omitempty"`
+	Duration string                  `yaml:"duration" json:"duration"`
+	Sent     *migrationTransferBytes `yaml:"sent,omitempty" json:"sent,omitempty"`
+}
+
+// migrationTransferBytes holds the number of bytes of each kind of data
+// sent to the target controller during a phase.
+type migrationTransferBytes struct {
+	Model     int64 `yaml:"model" json:"model"`
+	Charms    int64 `yaml:"charms" json:"charms"`
+	Tools     int64 `yaml:"tools" json:"tools"`
+	Resources int64 `yaml:"resources" json:"resources"`
+	Total     int64 `yaml:"total" json:"total"`
+}
+
+func formatMigrationProgress(in params.MigrationProgress, now time.Time) migrationProgress {
+	target := in.TargetControllerAlias
+	if target == "" {
+		target = in.TargetControllerTag
+		if tag, err := names.ParseControllerTag(target); err == nil {
+			target = tag.Id()
+		}
+	}
+	end := now
+	if in.End != nil {
+		end = *in.End
+	}
+	out := migrationProgress{
+		MigrationId:      in.MigrationId,
+		Attempt:          in.Attempt,
+		Phase:            in.Phase,
+		Status:           in.Status,
+		TargetController: target,
+		Started:          in.Start,
+		Ended:            in.End,
+		Elapsed:          formatDuration(end.Sub(in.Start)),
+		Phases:           make([]migrationPhaseDetails, len(in.Phases)),
+	}
+	for i, phase := range in.Phases {
+		phaseEnd := end
+		if phase.Ended != nil {
+			phaseEnd = *phase.Ended
+		}
+		details := migrationPhaseDetails{
+			Phase:    phase.Phase,
+			Started:  phase.Started,
+			Ended:    phase.Ended,
+			Duration: formatDuration(phaseEnd.Sub(phase.Started)),
+		}
+		sent := migrationTransferBytes{
+			Model:     phase.Sent.Model,
+			Charms:    phase.Sent.Charms,
+			Tools:     phase.Sent.Tools,
+			Resources: phase.Sent.Resources,
+		}
+		sent.Total = sent.Model + sent.Charms + sent.Tools + sent.Resources
+		if sent.Total > 0 {
+			details.Sent = &sent
+			out.Sent += sent.Total
+		}
+		out.Phases[i] = details
+	}
+	return out
+}
+
+// formatDuration rounds a duration to the second for display.
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return d.Round(time.Second).String()
+}
+
+// formatMigrationTabular writes a tabular summary of a migration's
+// progress.
+func formatMigrationTabular(writer io.Writer, value interface{}) error {
+	progress, ok := value.(migrationProgress)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", progress, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Migration", "Attempt", "Target", "Phase", "Started", "Elapsed", "Sent")
+	w.Println(
+		progress.MigrationId,
+		progress.Attempt,
+		progress.TargetController,
+		progress.Phase,
+		common.FormatTime(&progress.Started, true),
+		progress.Elapsed,
+		humanize.IBytes(uint64(progress.Sent)),
+	)
+	if progress.Status != "" {
+		w.Println()
+		w.Println("Status:", progress.Status)
+	}
+
+	w.Println()
+	w.Println("Phase", "Started", "Duration", "Sent")
+	for _, phase := range progress.Phases {
+		sent := "-"
+		if phase.Sent != nil {
+			sent = formatTransferBytes(phase.Sent)
+		}
+		w.Println(phase.Phase, common.FormatTime(&phase.Started, true), phase.Duration, sent)
+	}
+	tw.Flush()
+	return nil
+}
+
+func formatTransferBytes(sent *migrationTransferBytes) string {
+	return fmt.Sprintf("%s (model %s, charms %s, tools %s, resources %s)",
+		humanize.IBytes(uint64(sent.Total)),
+		humanize.IBytes(uint64(sent.Model)),
+		humanize.IBytes(uint64(sent.Charms)),
+		humanize.IBytes(uint64(sent.Tools)),
+		humanize.IBytes(uint64(sent.Resources)),
+	)
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ShowMigrationCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeShowMigrationClient
+	clock *testclock.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ShowMigrationCommandSuite{})
+
+type fakeShowMigrationClient struct {
+	gitjujutesting.Stub
+	progress params.MigrationProgress
+}
+
+func (f *fakeShowMigrationClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeShowMigrationClient) MigrationProgress(tag names.ModelTag) (params.MigrationProgress, error) {
+	f.MethodCall(f, "MigrationProgress", tag)
+	return f.progress, f.NextErr()
+}
+
+func (s *ShowMigrationCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+
+	start := time.Date(2020, 3, 10, 10, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(start.Add(3*time.Minute + 5*time.Second))
+	s.fake = fakeShowMigrationClient{
+		progress: params.MigrationProgress{
+			MigrationId:           testing.ModelTag.Id() + ":0",
+			Phase:                 "VALIDATION",
+			Status:                "validating",
+			TargetControllerTag:   testing.ControllerTag.String(),
+			TargetControllerAlias: "target",
+			Start:                 start,
+			Phases: []params.MigrationPhaseProgress{{
+				Phase:   "QUIESCE",
+				Started: start,
+				Ended:   timePtr(start.Add(5 * time.Second)),
+			}, {
+				Phase:   "IMPORT",
+				Started: start.Add(5 * time.Second),
+				Ended:   timePtr(start.Add(2*time.Minute + 5*time.Second)),
+				Sent: params.MigrationTransferVolume{
+					Model:  1024,
+					Charms: 2 * 1024 * 1024,
+				},
+			}, {
+				Phase:   "VALIDATION",
+				Started: start.Add(2*time.Minute + 5*time.Second),
+			}},
+		},
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigrationTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowMigrationCommandForTest(&s.fake, s.clock, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "MigrationProgress", "Close")
+	s.fake.CheckCall(c, 0, "MigrationProgress", testing.ModelTag)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Migration                               Attempt  Target  Phase       Started               Elapsed  Sent\n"+
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d:0  0        target  VALIDATION  2020-03-10 10:00:00Z  3m5s     2.0 MiB\n"+
+		"\n"+
+		"Status:  validating\n"+
+		"\n"+
+		"Phase       Started               Duration  Sent\n"+
+		"QUIESCE     2020-03-10 10:00:00Z  5s        -\n"+
+		"IMPORT      2020-03-10 10:00:05Z  2m0s      2.0 MiB (model 1.0 KiB, charms 2.0 MiB, tools 0 B, resources 0 B)\n"+
+		"VALIDATION  2020-03-10 10:02:05Z  1m0s      -\n",
+	)
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigrationJSON(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowMigrationCommandForTest(&s.fake, s.clock, s.store), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["phase"], gc.Equals, "VALIDATION")
+	c.Assert(out["elapsed"], gc.Equals, "3m5s")
+	c.Assert(out["sent"], gc.Equals, float64(2098176))
+	c.Assert(out["phases"], jc.DeepEquals, []interface{}{
+		map[string]interface{}{
+			"phase":    "QUIESCE",
+			"started":  "2020-03-10T10:00:00Z",
+			"ended":    "2020-03-10T10:00:05Z",
+			"duration": "5s",
+		},
+		map[string]interface{}{
+			"phase":    "IMPORT",
+			"started":  "2020-03-10T10:00:05Z",
+			"ended":    "2020-03-10T10:02:05Z",
+			"duration": "2m0s",
+			"sent": map[string]interface{}{
+				"model":     float64(1024),
+				"charms":    float64(2097152),
+				"tools":     float64(0),
+				"resources": float64(0),
+				"total":     float64(2098176),
+			},
+		},
+		map[string]interface{}{
+			"phase":    "VALIDATION",
+			"started":  "2020-03-10T10:02:05Z",
+			"duration": "1m0s",
+		},
+	})
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigrationTargetTag(c *gc.C) {
+	s.fake.progress.TargetControllerAlias = ""
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowMigrationCommandForTest(&s.fake, s.clock, s.store), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["target-controller"], gc.Equals, testing.ControllerTag.Id())
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigrationError(c *gc.C) {
+	s.fake.SetErrors(errors.NotFoundf("migration of model %q", testing.ModelTag.Id()))
+	_, err := cmdtesting.RunCommand(c, model.NewShowMigrationCommandForTest(&s.fake, s.clock, s.store))
+	c.Assert(err, gc.ErrorMatches, `migration of model ".*" not found`)
+	s.fake.CheckCallNames(c, "MigrationProgress", "Close")
+}
@@ -0,0 +1,659 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package query implements the expression language shared by the
+// status --filter option and the wait-for command, which compare the
+// fields of a model's machines, applications and units with values.
+package query
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// The kinds of entity that may be selected in a query.
+const (
+	Machines     = "machines"
+	Applications = "applications"
+	Units        = "units"
+)
+
+// Fields holds the fields of each kind of entity that may be compared.
+var Fields = map[string][]string{
+	Machines: {
+		"name", "status", "message", "life", "instance-status",
+		"dns", "instance-id", "series", "az",
+	},
+	Applications: {
+		"name", "status", "message", "life", "series", "charm", "exposed",
+	},
+	Units: {
+		"name", "status", "message", "life", "application", "agent-status",
+		"machine", "address", "public-address", "leader",
+	},
+}
+
+// TypeField is the field of an entity in a filter naming its kind:
+// "machine", "application" or "unit".
+const TypeField = "type"
+
+// FieldsHelp describes the fields that may be compared, for use in
+// command documentation.
+const FieldsHelp = `
+    name             the machine id, or application or unit name
+    status           the machine's agent status, or the application or
+                     unit's workload status
+    message          the message of that status
+    life             alive, dying or dead
+    application      the unit's application
+    agent-status     the unit's agent status
+    machine          the unit's machine
+    address          the unit's address
+    public-address   the unit's public address
+    leader           "true" if the unit is the leader (status only)
+    instance-status  the machine's instance status
+    dns              the machine's DNS name
+    instance-id      the machine's instance id
+    series           the series of the machine or application
+    az               the machine's availability zone
+    charm            the application's charm
+    exposed          "true" if the application is exposed
+`
+
+// Entity is a machine, application or unit whose fields are compared.
+type Entity interface {
+	// Field returns the value of the named field, or "" if the
+	// entity has no value for it.
+	Field(name string) string
+}
+
+// Model gives access to the entities in a model.
+type Model interface {
+	// Entities returns the entities of the given kind.
+	Entities(kind string) []Entity
+}
+
+// Filter is an expression over the fields of a single entity, such
+// as
+//
+//     status == error && message =~ "hook failed"
+type Filter struct {
+	expr node
+}
+
+// Matches returns whether the filter holds for the entity.
+func (f *Filter) Matches(e Entity) bool {
+	return f.expr.eval(nil, e)
+}
+
+// String returns the canonical form of the filter.
+func (f *Filter) String() string {
+	return f.expr.String()
+}
+
+// Query is an expression over the entities of a model, such as
+//
+//     units(mysql).status == active && count(units(wordpress)) >= 3
+type Query struct {
+	expr node
+}
+
+// Holds returns whether the query holds for the model.
+func (q *Query) Holds(m Model) bool {
+	return q.expr.eval(m, nil)
+}
+
+// String returns the canonical form of the query.
+func (q *Query) String() string {
+	return q.expr.String()
+}
+
+// ParseFilter parses a filter expression. A filter is made up of
+// comparisons of a field with a value, joined with "&&", "||" and "!"
+// and grouped with parentheses. A comparison is one of
+//
+//     <field> == <value>
+//     <field> != <value>
+//     <field> =~ <regexp>
+//     <field> !~ <regexp>
+//
+// Values containing spaces or operators must be quoted.
+func ParseFilter(expr string) (*Filter, error) {
+	n, err := parse(expr, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Filter{expr: n}, nil
+}
+
+// ParseQuery parses a query expression. A query has the same form as
+// a filter, but each field is that of the selected entities
+//
+//     <kind>(<pattern>).<field> <op> <value>
+//
+// which holds when at least one entity is selected and the comparison
+// holds for every selected entity. A query may also compare the
+// number of selected entities with a number
+//
+//     count(<kind>(<pattern>)) <op> <number>
+//
+// with op one of "==", "!=", "<", "<=", ">" or ">=".
+func ParseQuery(expr string) (*Query, error) {
+	n, err := parse(expr, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Query{expr: n}, nil
+}
+
+// node is a parsed expression. The model is nil when evaluating a
+// filter, and the entity is nil when evaluating a query.
+type node interface {
+	eval(m Model, e Entity) bool
+	String() string
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(m Model, e Entity) bool {
+	return n.left.eval(m, e) && n.right.eval(m, e)
+}
+
+func (n andNode) String() string {
+	return operand(n.left, true) + " && " + operand(n.right, true)
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(m Model, e Entity) bool {
+	return n.left.eval(m, e) || n.right.eval(m, e)
+}
+
+func (n orNode) String() string {
+	return n.left.String() + " || " + n.right.String()
+}
+
+type notNode struct{ expr node }
+
+func (n notNode) eval(m Model, e Entity) bool {
+	return !n.expr.eval(m, e)
+}
+
+func (n notNode) String() string {
+	return "!" + operand(n.expr, false)
+}
+
+// operand returns the string form of an operand of "&&", or of "!" if
+// and is false, in parentheses if it binds less tightly.
+func operand(n node, and bool) string {
+	switch n.(type) {
+	case orNode:
+		return "(" + n.String() + ")"
+	case andNode:
+		if !and {
+			return "(" + n.String() + ")"
+		}
+	}
+	return n.String()
+}
+
+// fieldNode compares a field of an entity with a value.
+type fieldNode struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (n fieldNode) eval(_ Model, e Entity) bool {
+	if e == nil {
+		return false
+	}
+	value := e.Field(n.field)
+	switch n.op {
+	case "==":
+		return value == n.value
+	case "!=":
+		return value != n.value
+	case "=~":
+		return n.re.MatchString(value)
+	case "!~":
+		return !n.re.MatchString(value)
+	}
+	return false
+}
+
+func (n fieldNode) String() string {
+	return fmt.Sprintf("%s %s %q", n.field, n.op, n.value)
+}
+
+// selector selects the entities of one kind whose names match a
+// pattern.
+type selector struct {
+	kind    string
+	pattern string
+}
+
+// entities returns the selected entities in the model.
+func (s selector) entities(m Model) []Entity {
+	if m == nil {
+		return nil
+	}
+	var result []Entity
+	for _, e := range m.Entities(s.kind) {
+		name := e.Field("name")
+		if s.kind == Units && !strings.Contains(s.pattern, "/") {
+			// A unit pattern without a "/" selects the units of the
+			// matching applications.
+			name = e.Field("application")
+		}
+		if s.pattern == "*" {
+			result = append(result, e)
+		} else if ok, _ := path.Match(s.pattern, name); ok {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func (s selector) String() string {
+	return fmt.Sprintf("%s(%s)", s.kind, s.pattern)
+}
+
+// selectorNode holds if there is at least one selected entity, and the
+// comparison holds for every selected entity.
+type selectorNode struct {
+	selector selector
+	field    fieldNode
+}
+
+func (n selectorNode) eval(m Model, _ Entity) bool {
+	entities := n.selector.entities(m)
+	if len(entities) == 0 {
+		return false
+	}
+	for _, e := range entities {
+		if !n.field.eval(m, e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n selectorNode) String() string {
+	return n.selector.String() + "." + n.field.String()
+}
+
+// countNode compares the number of selected entities with a value.
+type countNode struct {
+	selector selector
+	op       string
+	value    int
+}
+
+func (n countNode) eval(m Model, _ Entity) bool {
+	count := len(n.selector.entities(m))
+	switch n.op {
+	case "==":
+		return count == n.value
+	case "!=":
+		return count != n.value
+	case "<":
+		return count < n.value
+	case "<=":
+		return count <= n.value
+	case ">":
+		return count > n.value
+	case ">=":
+		return count >= n.value
+	}
+	return false
+}
+
+func (n countNode) String() string {
+	return fmt.Sprintf("count(%s) %s %d", n.selector, n.op, n.value)
+}
+
+func parse(expr string, selectors bool) (node, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := &parser{tokens: tokens, selectors: selectors}
+	n, err := p.or()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !p.done() {
+		return nil, errors.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return n, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// operators holds the operator tokens, longest first.
+var operators = []string{
+	"==", "!=", "=~", "!~", "<=", ">=", "&&", "||",
+	"<", ">", "!", "(", ")",
+}
+
+// tokenize splits an expression into tokens.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	rest := expr
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return tokens, nil
+		}
+		switch c := rest[0]; {
+		case c == '.' && len(tokens) > 0 && tokens[len(tokens)-1] == (token{text: ")"}):
+			tokens = append(tokens, token{text: "."})
+			rest = rest[1:]
+			continue
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(rest[1:], c)
+			if end < 0 {
+				return nil, errors.Errorf("unterminated string %s", rest)
+			}
+			tokens = append(tokens, token{text: rest[1 : end+1], quoted: true})
+			rest = rest[end+2:]
+			continue
+		}
+		var op string
+		for _, candidate := range operators {
+			if strings.HasPrefix(rest, candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op != "" {
+			tokens = append(tokens, token{text: op})
+			rest = rest[len(op):]
+			continue
+		}
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(`()"'=!<>&|`, r)
+		})
+		if end == 0 {
+			return nil, errors.Errorf("unexpected %q", rest[:1])
+		}
+		if end < 0 {
+			end = len(rest)
+		}
+		tokens = append(tokens, token{text: rest[:end]})
+		rest = rest[end:]
+	}
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+
+	// selectors is true when parsing a query, whose fields are those
+	// of selected entities, and false when parsing a filter.
+	selectors bool
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, errors.New("unexpected end of expression")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+// peek returns whether the next token is the given operator.
+func (p *parser) peek(op string) bool {
+	return !p.done() && p.tokens[p.pos] == (token{text: op})
+}
+
+// accept consumes the next token if it is the given operator.
+func (p *parser) accept(op string) bool {
+	if p.peek(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	t, err := p.next()
+	if err != nil {
+		return errors.Errorf("expected %q, found end of expression", op)
+	}
+	if t != (token{text: op}) {
+		return errors.Errorf("expected %q, found %q", op, t.text)
+	}
+	return nil
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for p.accept("||") {
+		right, err := p.and()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for p.accept("&&") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	if p.accept("!") {
+		n, err := p.unary()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return notNode{n}, nil
+	}
+	if p.accept("(") {
+		n, err := p.or()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return n, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if t.quoted || isOperator(t.text) {
+		return nil, errors.Errorf("expected field, found %q", t.text)
+	}
+	if !p.peek("(") {
+		if p.selectors {
+			return nil, errors.NotValidf("field %q without an entity selector", t.text)
+		}
+		if t.text != TypeField && !isField("", t.text) {
+			return nil, errors.NotValidf("field %q", t.text)
+		}
+		return p.field(t.text)
+	}
+	if !p.selectors {
+		return nil, errors.Errorf("entity selector %s(...) not allowed in a filter", t.text)
+	}
+	if t.text == "count" {
+		return p.count()
+	}
+	sel, err := p.selector(t.text)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.expect("."); err != nil {
+		return nil, errors.Trace(err)
+	}
+	field, err := p.next()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if field.quoted || !isField(sel.kind, field.text) {
+		return nil, errors.NotValidf("%s field %q", sel.kind, field.text)
+	}
+	n, err := p.field(field.text)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return selectorNode{selector: sel, field: n}, nil
+}
+
+// field parses the operator and value of a comparison of a field.
+func (p *parser) field(name string) (fieldNode, error) {
+	op, err := p.operator("==", "!=", "=~", "!~")
+	if err != nil {
+		return fieldNode{}, errors.Trace(err)
+	}
+	value, err := p.value()
+	if err != nil {
+		return fieldNode{}, errors.Trace(err)
+	}
+	n := fieldNode{field: name, op: op, value: value}
+	if op == "=~" || op == "!~" {
+		n.re, err = regexp.Compile(value)
+		if err != nil {
+			return fieldNode{}, errors.Annotatef(err, "invalid regexp %q", value)
+		}
+	}
+	return n, nil
+}
+
+func (p *parser) count() (node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, errors.Trace(err)
+	}
+	kind, err := p.next()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sel, err := p.selector(kind.text)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	op, err := p.operator("==", "!=", "<", "<=", ">", ">=")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	t, err := p.value()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := strconv.Atoi(t)
+	if err != nil || value < 0 {
+		return nil, errors.NotValidf("count %q", t)
+	}
+	return countNode{selector: sel, op: op, value: value}, nil
+}
+
+// selector parses the parenthesised pattern following an entity kind.
+func (p *parser) selector(kind string) (selector, error) {
+	if _, ok := Fields[kind]; !ok {
+		return selector{}, errors.NotValidf("entity kind %q", kind)
+	}
+	if err := p.expect("("); err != nil {
+		return selector{}, errors.Trace(err)
+	}
+	pattern, err := p.value()
+	if err != nil {
+		return selector{}, errors.Trace(err)
+	}
+	if err := p.expect(")"); err != nil {
+		return selector{}, errors.Trace(err)
+	}
+	return selector{kind: kind, pattern: pattern}, nil
+}
+
+func (p *parser) operator(valid ...string) (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, op := range valid {
+		if t == (token{text: op}) {
+			return op, nil
+		}
+	}
+	return "", errors.Errorf("expected one of %s, found %q", strings.Join(valid, " "), t.text)
+}
+
+func (p *parser) value() (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !t.quoted && isOperator(t.text) {
+		return "", errors.Errorf("expected value, found %q", t.text)
+	}
+	return t.text, nil
+}
+
+func isOperator(text string) bool {
+	if text == "." {
+		return true
+	}
+	for _, op := range operators {
+		if text == op {
+			return true
+		}
+	}
+	return false
+}
+
+// isField returns whether the name is a field of the given kind of
+// entity, or of any kind if kind is empty.
+func isField(kind, name string) bool {
+	for k, fields := range Fields {
+		if kind != "" && k != kind {
+			continue
+		}
+		for _, field := range fields {
+			if name == field {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package query_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/query"
+)
+
+type querySuite struct{}
+
+var _ = gc.Suite(&querySuite{})
+
+type fakeEntity map[string]string
+
+func (e fakeEntity) Field(name string) string {
+	return e[name]
+}
+
+type fakeModel map[string][]query.Entity
+
+func (m fakeModel) Entities(kind string) []query.Entity {
+	return m[kind]
+}
+
+var testModel = fakeModel{
+	query.Applications: {
+		fakeEntity{"name": "mysql", "status": "active", "life": "alive"},
+		fakeEntity{"name": "wordpress", "status": "waiting", "life": "alive"},
+	},
+	query.Units: {
+		fakeEntity{"name": "mysql/0", "application": "mysql", "status": "active", "message": "Unit is ready", "agent-status": "idle", "machine": "0"},
+		fakeEntity{"name": "wordpress/0", "application": "wordpress", "status": "waiting", "agent-status": "executing", "machine": "1"},
+		fakeEntity{"name": "wordpress/1", "application": "wordpress", "status": "active", "agent-status": "idle", "machine": "0/lxd/0"},
+	},
+	query.Machines: {
+		fakeEntity{"name": "0", "status": "started"},
+		fakeEntity{"name": "0/lxd/0", "status": "pending"},
+	},
+}
+
+func (s *querySuite) TestParseFilter(c *gc.C) {
+	for i, test := range []struct {
+		expr   string
+		parsed string
+	}{{
+		expr:   "status == error",
+		parsed: `status == "error"`,
+	}, {
+		expr:   "type==unit&&message=~'hook failed'",
+		parsed: `type == "unit" && message =~ "hook failed"`,
+	}, {
+		expr:   "status == down || az == us-east-1a && series != bionic",
+		parsed: `status == "down" || az == "us-east-1a" && series != "bionic"`,
+	}, {
+		expr:   "(status == down || az == us-east-1a) && series != bionic",
+		parsed: `(status == "down" || az == "us-east-1a") && series != "bionic"`,
+	}, {
+		expr:   "!(type == unit && leader == true)",
+		parsed: `!(type == "unit" && leader == "true")`,
+	}, {
+		expr:   `message == "Unit is ready && waiting"`,
+		parsed: `message == "Unit is ready && waiting"`,
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		f, err := query.ParseFilter(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(f.String(), gc.Equals, test.parsed)
+	}
+}
+
+func (s *querySuite) TestParseQuery(c *gc.C) {
+	for i, test := range []struct {
+		expr   string
+		parsed string
+	}{{
+		expr:   "units(mysql).status == active",
+		parsed: `units(mysql).status == "active"`,
+	}, {
+		expr:   "units(*).agent-status==idle&&units(*).status!=blocked",
+		parsed: `units(*).agent-status == "idle" && units(*).status != "blocked"`,
+	}, {
+		expr:   `units(mysql/0).message =~ "^Unit is ready"`,
+		parsed: `units(mysql/0).message =~ "^Unit is ready"`,
+	}, {
+		expr:   "count( units(wordpress) ) >= 3",
+		parsed: "count(units(wordpress)) >= 3",
+	}, {
+		expr:   "machines(0/lxd/*).instance-status == 'running'",
+		parsed: `machines(0/lxd/*).instance-status == "running"`,
+	}, {
+		expr:   "applications(mysql).message == 1.2",
+		parsed: `applications(mysql).message == "1.2"`,
+	}, {
+		expr:   "!(units(mysql).status == error || count(units(mysql)) == 0)",
+		parsed: `!(units(mysql).status == "error" || count(units(mysql)) == 0)`,
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		q, err := query.ParseQuery(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(q.String(), gc.Equals, test.parsed)
+	}
+}
+
+func (s *querySuite) TestParseFilterErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{{
+		expr: "",
+		err:  "unexpected end of expression",
+	}, {
+		expr: "colour == red",
+		err:  `field "colour" not valid`,
+	}, {
+		expr: "status = active",
+		err:  `unexpected "="`,
+	}, {
+		expr: "status < active",
+		err:  `expected one of == != =~ !~, found "<"`,
+	}, {
+		expr: "status == && name == mysql",
+		err:  `expected value, found "&&"`,
+	}, {
+		expr: "(status == active",
+		err:  `expected "\)", found end of expression`,
+	}, {
+		expr: "status == active name == mysql",
+		err:  `unexpected "name"`,
+	}, {
+		expr: "message =~ '('",
+		err:  `invalid regexp "\(": .*`,
+	}, {
+		expr: "message == 'ready",
+		err:  `unterminated string 'ready`,
+	}, {
+		expr: "units(mysql).status == active",
+		err:  `entity selector units\(...\) not allowed in a filter`,
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		_, err := query.ParseFilter(test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *querySuite) TestParseQueryErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{{
+		expr: "",
+		err:  "unexpected end of expression",
+	}, {
+		expr: "relations(mysql).status == active",
+		err:  `entity kind "relations" not valid`,
+	}, {
+		expr: "units(mysql).instance-status == running",
+		err:  `units field "instance-status" not valid`,
+	}, {
+		expr: "status == active",
+		err:  `field "status" without an entity selector not valid`,
+	}, {
+		expr: "units(mysql) == active",
+		err:  `expected ".", found "=="`,
+	}, {
+		expr: "units(mysql).status < active",
+		err:  `expected one of == != =~ !~, found "<"`,
+	}, {
+		expr: "units(mysql).status ==",
+		err:  "unexpected end of expression",
+	}, {
+		expr: "count(units(mysql)) > many",
+		err:  `count "many" not valid`,
+	}, {
+		expr: "units(mysql).status == active units(*).agent-status == idle",
+		err:  `unexpected "units"`,
+	}, {
+		expr: `units(mysql).message == "ready`,
+		err:  `unterminated string "ready`,
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		_, err := query.ParseQuery(test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *querySuite) TestFilterMatches(c *gc.C) {
+	unit := fakeEntity{"type": "unit", "name": "mysql/0", "status": "error", "message": `hook failed: "install"`}
+	for i, test := range []struct {
+		expr    string
+		matches bool
+	}{
+		{"status == error", true},
+		{"status != error", false},
+		{"type == unit && message =~ '^hook failed'", true},
+		{"type == unit && message !~ '^hook failed'", false},
+		{"type == machine || name == mysql/0", true},
+		{"!(status == error)", false},
+		{"az == ''", true},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		f, err := query.ParseFilter(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(f.Matches(unit), gc.Equals, test.matches)
+	}
+}
+
+func (s *querySuite) TestQueryHolds(c *gc.C) {
+	for i, test := range []struct {
+		expr  string
+		holds bool
+	}{
+		{"applications(mysql).status == active", true},
+		{"applications(mysql).life == alive", true},
+		{"applications(wordpress).status == active", false},
+		{"units(mysql).status == active", true},
+		{`units(mysql/0).message == "Unit is ready"`, true},
+		{`units(mysql/0).message =~ "ready$"`, true},
+		{"units(*).status == active", false},
+		{"units(*).status != blocked", true},
+		{"units(wordpress/1).machine == 0/lxd/0", true},
+		{"units(mysql).status == active && units(wordpress).agent-status == idle", false},
+		{"units(mysql).status == active && units(wordpress/1).agent-status == idle", true},
+		{"units(wordpress).status == active || units(mysql).status == active", true},
+		{"!(units(wordpress).status == active)", true},
+		{"units(postgresql).status == active", false},
+		{"units(postgresql).status != active", false},
+		{"count(units(postgresql)) == 0", true},
+		{"count(units(wordpress)) == 2", true},
+		{"count(units(*)) >= 3", true},
+		{"count(units(*)) > 3", false},
+		{"count(units(word*)) < 3", true},
+		{"machines(0).status == started", true},
+		{"machines(*).status == started", false},
+		{"count(machines(*)) == 2", true},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		q, err := query.ParseQuery(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(q.Holds(testModel), gc.Equals, test.holds)
+	}
+}
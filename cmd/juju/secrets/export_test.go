@@ -0,0 +1,33 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewListSecretsCommandForTest returns a secrets command using the
+// supplied API.
+func NewListSecretsCommandForTest(api ListSecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listSecretsCommand{}
+	c.newAPIFunc = func() (ListSecretsAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// NewShowSecretCommandForTest returns a show-secret command using the
+// supplied API.
+func NewShowSecretCommandForTest(api ListSecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &showSecretCommand{}
+	c.newAPIFunc = func() (ListSecretsAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
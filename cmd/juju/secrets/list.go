@@ -0,0 +1,182 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ListSecretsAPI defines the API methods used by the secrets commands.
+type ListSecretsAPI interface {
+	Close() error
+	ListSecrets(showValues bool) ([]params.ListSecretResult, error)
+}
+
+type secretsCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (ListSecretsAPI, error)
+}
+
+func (c *secretsCommandBase) secretsAPI() (ListSecretsAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewClient(root), nil
+}
+
+const listSecretsDoc = `
+Lists the secrets created by the charms deployed in the model. Only
+model administrators can list secrets.
+
+The secret values are not shown unless --show-secrets is given, in
+which case they are included in the yaml and json output formats.
+
+Examples:
+
+    juju secrets
+    juju secrets --show-secrets --format yaml
+
+See also:
+    show-secret
+`
+
+// NewListSecretsCommand returns a command to list the secrets in a
+// model.
+func NewListSecretsCommand() cmd.Command {
+	return modelcmd.Wrap(&listSecretsCommand{})
+}
+
+type listSecretsCommand struct {
+	secretsCommandBase
+	out         cmd.Output
+	showSecrets bool
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets in a model.",
+		Doc:     listSecretsDoc,
+		Aliases: []string{"list-secrets"},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.showSecrets, "show-secrets", false, "Show secret values")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.secretsAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ListSecrets(c.showSecrets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	details := make([]secretDetails, len(results))
+	for i, result := range results {
+		details[i] = formatSecretDetails(result)
+	}
+	return c.out.Write(ctx, details)
+}
+
+// secretDetails is the output representation of a secret.
+type secretDetails struct {
+	ID          string            `yaml:"id" json:"id"`
+	Owner       string            `yaml:"owner" json:"owner"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision    int               `yaml:"revision" json:"revision"`
+	Created     time.Time         `yaml:"created" json:"created"`
+	Updated     time.Time         `yaml:"updated" json:"updated"`
+	Expires     *time.Time        `yaml:"expires,omitempty" json:"expires,omitempty"`
+	Consumers   []string          `yaml:"consumers,omitempty" json:"consumers,omitempty"`
+	Value       map[string]string `yaml:"value,omitempty" json:"value,omitempty"`
+	Error       string            `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatSecretDetails(result params.ListSecretResult) secretDetails {
+	details := secretDetails{
+		ID:          result.ID,
+		Owner:       tagId(result.Owner),
+		Description: result.Description,
+		Revision:    result.Revision,
+		Created:     result.CreateTime,
+		Updated:     result.UpdateTime,
+		Expires:     result.ExpireTime,
+		Value:       result.Value,
+	}
+	for _, consumer := range result.Consumers {
+		details.Consumers = append(details.Consumers, tagId(consumer))
+	}
+	if result.ValueError != nil {
+		details.Error = result.ValueError.Error()
+	}
+	return details
+}
+
+// tagId returns the ID of the entity with the supplied tag, or the
+// tag itself if it can't be parsed.
+func tagId(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return t.Id()
+}
+
+func formatSecretsTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]secretDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("ID", "Owner", "Rev", "Expires", "Description")
+	for _, s := range secrets {
+		var expires string
+		if s.Expires != nil {
+			expires = s.Expires.Format(time.RFC3339)
+		}
+		w.Println(s.ID, s.Owner, s.Revision, expires, s.Description)
+	}
+	tw.Flush()
+	return nil
+}
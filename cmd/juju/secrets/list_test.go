@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.BaseSuite
+	api *mockSecretsAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	created := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	expires := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.api = &mockSecretsAPI{
+		secrets: []params.ListSecretResult{{
+			ID:          "9m4e2mr0ui3e8a215n4g",
+			Owner:       "application-mariadb",
+			Description: "admin account",
+			Revision:    2,
+			CreateTime:  created,
+			UpdateTime:  created.Add(time.Hour),
+			ExpireTime:  &expires,
+			Consumers:   []string{"application-wordpress", "unit-mediawiki-0"},
+		}, {
+			ID:         "9m4e2mr0ui3e8a215n50",
+			Owner:      "application-wordpress",
+			Revision:   1,
+			CreateTime: created,
+			UpdateTime: created,
+		}},
+	}
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api, jujuclienttesting.MinimalStore()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                    Owner      Rev  Expires               Description
+9m4e2mr0ui3e8a215n4g  mariadb    2    2020-06-01T00:00:00Z  admin account
+9m4e2mr0ui3e8a215n50  wordpress  1                          
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "ListSecrets", Args: []interface{}{false}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *ListSuite) TestListNone(c *gc.C) {
+	s.api.secrets = nil
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api, jujuclienttesting.MinimalStore()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *ListSuite) TestListYAMLShowSecrets(c *gc.C) {
+	s.api.secrets[0].Value = map[string]string{"password": "s3cret"}
+	s.api.secrets[1].ValueError = &params.Error{Message: "secret expired"}
+	ctx, err := cmdtesting.RunCommand(c,
+		secrets.NewListSecretsCommandForTest(s.api, jujuclienttesting.MinimalStore()),
+		"--show-secrets", "--format", "yaml",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: 9m4e2mr0ui3e8a215n4g
+  owner: mariadb
+  description: admin account
+  revision: 2
+  created: 2020-05-01T03:00:00Z
+  updated: 2020-05-01T04:00:00Z
+  expires: 2020-06-01T00:00:00Z
+  consumers:
+  - wordpress
+  - mediawiki/0
+  value:
+    password: s3cret
+- id: 9m4e2mr0ui3e8a215n50
+  owner: wordpress
+  revision: 1
+  created: 2020-05-01T03:00:00Z
+  updated: 2020-05-01T03:00:00Z
+  error: secret expired
+`[1:])
+	s.api.CheckCall(c, 0, "ListSecrets", true)
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api, jujuclienttesting.MinimalStore()))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockSecretsAPI struct {
+	jujutesting.Stub
+	secrets []params.ListSecretResult
+}
+
+func (m *mockSecretsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSecretsAPI) ListSecrets(showValues bool) ([]params.ListSecretResult, error) {
+	m.MethodCall(m, "ListSecrets", showValues)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.secrets, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showSecretDoc = `
+Shows the details of a secret created by a charm deployed in the
+model. Only model administrators can show secrets.
+
+The secret's value is not shown unless --reveal is given.
+
+Examples:
+
+    juju show-secret 9m4e2mr0ui3e8a215n4g
+    juju show-secret 9m4e2mr0ui3e8a215n4g --reveal --format json
+
+See also:
+    secrets
+`
+
+// NewShowSecretCommand returns a command to show the details of a
+// secret.
+func NewShowSecretCommand() cmd.Command {
+	return modelcmd.Wrap(&showSecretCommand{})
+}
+
+type showSecretCommand struct {
+	secretsCommandBase
+	out    cmd.Output
+	id     string
+	reveal bool
+}
+
+// Info implements cmd.Command.
+func (c *showSecretCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-secret",
+		Args:    "<id>",
+		Purpose: "Shows the details of a secret.",
+		Doc:     showSecretDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *showSecretCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reveal, "reveal", false, "Include the secret value")
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *showSecretCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing secret id")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *showSecretCommand) Run(ctx *cmd.Context) error {
+	api, err := c.secretsAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ListSecrets(c.reveal)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results {
+		if result.ID == c.id {
+			return c.out.Write(ctx, formatSecretDetails(result))
+		}
+	}
+	return errors.NotFoundf("secret %q", c.id)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ShowSuite struct {
+	testing.BaseSuite
+	api *mockSecretsAPI
+}
+
+var _ = gc.Suite(&ShowSuite{})
+
+func (s *ShowSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	created := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	s.api = &mockSecretsAPI{
+		secrets: []params.ListSecretResult{{
+			ID:         "9m4e2mr0ui3e8a215n4g",
+			Owner:      "application-mariadb",
+			Revision:   1,
+			CreateTime: created,
+			UpdateTime: created,
+			Value:      map[string]string{"password": "s3cret"},
+		}},
+	}
+}
+
+func (s *ShowSuite) TestInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, secrets.NewShowSecretCommandForTest(s.api, jujuclienttesting.MinimalStore()))
+	c.Assert(err, gc.ErrorMatches, "missing secret id")
+	_, err = cmdtesting.RunCommand(c, secrets.NewShowSecretCommandForTest(s.api, jujuclienttesting.MinimalStore()), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ShowSuite) TestShowReveal(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c,
+		secrets.NewShowSecretCommandForTest(s.api, jujuclienttesting.MinimalStore()),
+		"9m4e2mr0ui3e8a215n4g", "--reveal",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+id: 9m4e2mr0ui3e8a215n4g
+owner: mariadb
+revision: 1
+created: 2020-05-01T03:00:00Z
+updated: 2020-05-01T03:00:00Z
+value:
+  password: s3cret
+`[1:])
+	s.api.CheckCall(c, 0, "ListSecrets", true)
+}
+
+func (s *ShowSuite) TestShowNotFound(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c,
+		secrets.NewShowSecretCommandForTest(s.api, jujuclienttesting.MinimalStore()),
+		"missing",
+	)
+	c.Assert(err, gc.ErrorMatches, `secret "missing" not found`)
+	s.api.CheckCall(c, 0, "ListSecrets", false)
+}
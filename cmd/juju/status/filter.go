@@ -0,0 +1,184 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/juju/cmd/juju/query"
+	"github.com/juju/juju/core/instance"
+)
+
+const (
+	entityMachine     = "machine"
+	entityApplication = "application"
+	entityUnit        = "unit"
+)
+
+// entityFieldNames holds the names of the fields of the machines,
+// applications and units in a formatted status, in the order they are
+// written by the csv formatter. They are the fields that may be
+// compared in a filter. A field that does not apply to an entity is
+// empty.
+var entityFieldNames = []string{
+	"type",
+	"name",
+	"status",
+	"message",
+	"life",
+	"application",
+	"agent-status",
+	"machine",
+	"address",
+	"public-address",
+	"leader",
+	"instance-status",
+	"dns",
+	"instance-id",
+	"series",
+	"az",
+	"charm",
+	"exposed",
+}
+
+// entityFields maps field names to values for a single machine,
+// application or unit. It implements query.Entity.
+type entityFields map[string]string
+
+// Field is part of the query.Entity interface.
+func (f entityFields) Field(name string) string {
+	return f[name]
+}
+
+func machineFields(id string, m machineStatus) entityFields {
+	var az string
+	if hw, err := instance.ParseHardware(m.Hardware); err == nil && hw.AvailabilityZone != nil {
+		az = *hw.AvailabilityZone
+	}
+	return entityFields{
+		"type":            entityMachine,
+		"name":            id,
+		"status":          string(m.JujuStatus.Current),
+		"message":         m.JujuStatus.Message,
+		"instance-status": string(m.MachineStatus.Current),
+		"dns":             m.DNSName,
+		"instance-id":     string(m.InstanceId),
+		"series":          m.Series,
+		"az":              az,
+	}
+}
+
+func applicationFields(name string, app applicationStatus) entityFields {
+	return entityFields{
+		"type":    entityApplication,
+		"name":    name,
+		"status":  string(app.StatusInfo.Current),
+		"message": app.StatusInfo.Message,
+		"life":    app.Life,
+		"series":  app.Series,
+		"charm":   app.Charm,
+		"exposed": strconv.FormatBool(app.Exposed),
+	}
+}
+
+// unitFields returns the fields of a unit. Subordinate units are not
+// given a machine in the formatted status, so the machine of the
+// principal unit is passed in.
+func unitFields(name, machine string, u unitStatus) entityFields {
+	appName := name
+	if i := strings.Index(name, "/"); i >= 0 {
+		appName = name[:i]
+	}
+	return entityFields{
+		"type":           entityUnit,
+		"name":           name,
+		"status":         string(u.WorkloadStatusInfo.Current),
+		"message":        u.WorkloadStatusInfo.Message,
+		"life":           u.Life,
+		"application":    appName,
+		"agent-status":   string(u.JujuStatusInfo.Current),
+		"machine":        machine,
+		"address":        u.Address,
+		"public-address": u.PublicAddress,
+		"leader":         strconv.FormatBool(u.Leader),
+	}
+}
+
+// filterStatus returns the formatted status with only the machines,
+// applications and units that match the filter. Applications with a
+// matching unit, and machines hosting a matching unit, are also kept,
+// so that the units can be displayed in context.
+func filterStatus(fs formattedStatus, filter *query.Filter) formattedStatus {
+	var (
+		keepApplications = make(map[string]bool)
+		keepMachines     = make(map[string]bool)
+	)
+
+	var filterUnits func(units map[string]unitStatus, machine string) map[string]unitStatus
+	filterUnits = func(units map[string]unitStatus, principalMachine string) map[string]unitStatus {
+		result := make(map[string]unitStatus)
+		for name, u := range units {
+			machine := u.Machine
+			if machine == "" {
+				machine = principalMachine
+			}
+			fields := unitFields(name, machine, u)
+			u.Subordinates = filterUnits(u.Subordinates, machine)
+			if !filter.Matches(fields) && len(u.Subordinates) == 0 {
+				continue
+			}
+			if len(u.Subordinates) == 0 {
+				u.Subordinates = nil
+			}
+			result[name] = u
+			keepApplications[fields["application"]] = true
+			if machine != "" {
+				keepMachines[machine] = true
+			}
+		}
+		return result
+	}
+
+	applications := make(map[string]applicationStatus)
+	for name, app := range fs.Applications {
+		app.Units = filterUnits(app.Units, "")
+		if len(app.Units) == 0 {
+			app.Units = nil
+		}
+		if filter.Matches(applicationFields(name, app)) || app.Units != nil {
+			applications[name] = app
+		}
+	}
+	// Subordinate applications have no units of their own, as their
+	// units are listed under their principals.
+	for name := range keepApplications {
+		if _, ok := applications[name]; !ok {
+			if app, ok := fs.Applications[name]; ok {
+				app.Units = nil
+				applications[name] = app
+			}
+		}
+	}
+
+	var filterMachines func(machines map[string]machineStatus) map[string]machineStatus
+	filterMachines = func(machines map[string]machineStatus) map[string]machineStatus {
+		result := make(map[string]machineStatus)
+		for id, m := range machines {
+			m.Containers = filterMachines(m.Containers)
+			if !filter.Matches(machineFields(id, m)) && !keepMachines[id] && len(m.Containers) == 0 {
+				continue
+			}
+			if len(m.Containers) == 0 {
+				m.Containers = nil
+			}
+			result[id] = m
+		}
+		return result
+	}
+
+	fs.Applications = applications
+	fs.Machines = filterMachines(fs.Machines)
+	return fs
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+
+	"github.com/juju/collections/set"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/query"
+	"github.com/juju/juju/core/status"
+)
+
+type filterSuite struct{}
+
+var _ = gc.Suite(&filterSuite{})
+
+func filterTestStatus() formattedStatus {
+	return formattedStatus{
+		Model: modelStatus{
+			Name: "prod",
+			Type: "iaas",
+		},
+		Machines: map[string]machineStatus{
+			"0": {
+				Id:            "0",
+				JujuStatus:    statusInfoContents{Current: status.Started},
+				MachineStatus: statusInfoContents{Current: status.Running, Message: "Running"},
+				InstanceId:    "i-0",
+				Series:        "bionic",
+				Hardware:      "arch=amd64 availability-zone=us-east-1a",
+				Containers: map[string]machineStatus{
+					"0/lxd/0": {
+						Id:         "0/lxd/0",
+						JujuStatus: statusInfoContents{Current: status.Started},
+						Series:     "bionic",
+					},
+				},
+			},
+			"1": {
+				Id:         "1",
+				JujuStatus: statusInfoContents{Current: status.Down},
+				InstanceId: "i-1",
+				Series:     "bionic",
+				Hardware:   "arch=amd64 availability-zone=us-east-1b",
+			},
+		},
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				Charm:      "cs:mysql-1",
+				Series:     "bionic",
+				StatusInfo: statusInfoContents{Current: status.Active},
+				Units: map[string]unitStatus{
+					"mysql/0": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Active, Message: "ready"},
+						JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+						Machine:            "0",
+						Leader:             true,
+						Subordinates: map[string]unitStatus{
+							"logging/0": {
+								WorkloadStatusInfo: statusInfoContents{Current: status.Error, Message: `hook failed: "install"`},
+								JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+							},
+						},
+					},
+				},
+			},
+			"logging": {
+				Charm:         "cs:logging-2",
+				Series:        "bionic",
+				StatusInfo:    statusInfoContents{Current: status.Error},
+				SubordinateTo: []string{"mysql"},
+			},
+			"wordpress": {
+				Charm:      "cs:wordpress-3",
+				Series:     "bionic",
+				Exposed:    true,
+				StatusInfo: statusInfoContents{Current: status.Error},
+				Units: map[string]unitStatus{
+					"wordpress/0": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Error, Message: `hook failed: "config-changed"`},
+						JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+						Machine:            "0/lxd/0",
+					},
+					"wordpress/1": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Active},
+						JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+						Machine:            "1",
+					},
+				},
+			},
+		},
+	}
+}
+
+// filteredNames returns the names of the machines, applications and
+// units in the formatted status.
+func filteredNames(fs formattedStatus) []string {
+	names := set.NewStrings()
+	for _, record := range statusRecords(fs) {
+		names.Add(record.fields["type"] + ":" + record.fields["name"])
+	}
+	return names.SortedValues()
+}
+
+func (s *filterSuite) TestFilterStatus(c *gc.C) {
+	for i, test := range []struct {
+		filter string
+		names  []string
+	}{{
+		filter: "type == machine && az == us-east-1a",
+		names:  []string{"machine:0"},
+	}, {
+		filter: "type == machine && (status == down || az == us-east-1a)",
+		names:  []string{"machine:0", "machine:1"},
+	}, {
+		filter: "type == unit && status == error && message =~ 'config-changed'",
+		names:  []string{"application:wordpress", "machine:0", "machine:0/lxd/0", "unit:wordpress/0"},
+	}, {
+		filter: `type == unit && status == error && message =~ "^hook failed"`,
+		names: []string{
+			"application:logging", "application:mysql", "application:wordpress",
+			"machine:0", "machine:0/lxd/0",
+			"unit:logging/0", "unit:mysql/0", "unit:wordpress/0",
+		},
+	}, {
+		filter: "type == application && exposed == true",
+		names:  []string{"application:wordpress"},
+	}, {
+		filter: "type == unit && leader == true",
+		names:  []string{"application:mysql", "machine:0", "unit:mysql/0"},
+	}, {
+		filter: "application == wordpress && machine != 1",
+		names:  []string{"application:wordpress", "machine:0", "machine:0/lxd/0", "unit:wordpress/0"},
+	}, {
+		filter: "!(type == unit) && status !~ '^(started|active)$'",
+		names:  []string{"application:logging", "application:wordpress", "machine:1"},
+	}, {
+		filter: "name == postgresql",
+		names:  []string{},
+	}} {
+		c.Logf("test %d: %s", i, test.filter)
+		filter, err := query.ParseFilter(test.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		filtered := filterStatus(filterTestStatus(), filter)
+		c.Check(filteredNames(filtered), jc.DeepEquals, test.names)
+	}
+}
+
+func (s *filterSuite) TestFilterStatusOnlyMatchingSubordinates(c *gc.C) {
+	filter, err := query.ParseFilter("name == mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	filtered := filterStatus(filterTestStatus(), filter)
+	c.Check(filteredNames(filtered), jc.DeepEquals, []string{
+		"application:mysql", "machine:0", "unit:mysql/0",
+	})
+	c.Check(filtered.Applications["mysql"].Units["mysql/0"].Subordinates, gc.HasLen, 0)
+}
+
+func (s *filterSuite) TestFieldNames(c *gc.C) {
+	// The csv formatter writes every field that may be compared in a
+	// filter, and no other.
+	fields := set.NewStrings("type")
+	for _, kindFields := range query.Fields {
+		fields = fields.Union(set.NewStrings(kindFields...))
+	}
+	c.Check(set.NewStrings(entityFieldNames...).SortedValues(), jc.DeepEquals, fields.SortedValues())
+	c.Check(entityFieldNames, gc.HasLen, fields.Size())
+}
+
+func (s *filterSuite) TestFormatJSONLines(c *gc.C) {
+	fs := filterTestStatus()
+	delete(fs.Applications, "mysql")
+	delete(fs.Applications, "logging")
+	delete(fs.Machines, "0")
+	var buf bytes.Buffer
+	err := FormatJSONLines(&buf, fs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		`{"hardware":"arch=amd64 availability-zone=us-east-1b","instance-id":"i-1","juju-status":{"current":"down"},"machine-status":{},"modification-status":{},"name":"1","series":"bionic","type":"machine"}`+"\n"+
+		`{"application-status":{"current":"error"},"charm":"cs:wordpress-3","charm-name":"","charm-origin":"","charm-rev":0,"exposed":true,"name":"wordpress","os":"","series":"bionic","type":"application"}`+"\n"+
+		`{"juju-status":{"current":"idle"},"machine":"0/lxd/0","name":"wordpress/0","type":"unit","workload-status":{"current":"error","message":"hook failed: \"config-changed\""}}`+"\n"+
+		`{"juju-status":{"current":"idle"},"machine":"1","name":"wordpress/1","type":"unit","workload-status":{"current":"active"}}`+"\n",
+	)
+}
+
+func (s *filterSuite) TestFormatCSV(c *gc.C) {
+	var buf bytes.Buffer
+	err := FormatCSV(&buf, filterTestStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		"type,name,status,message,life,application,agent-status,machine,address,public-address,leader,instance-status,dns,instance-id,series,az,charm,exposed\n"+
+		"machine,0,started,,,,,,,,,running,,i-0,bionic,us-east-1a,,\n"+
+		"machine,0/lxd/0,started,,,,,,,,,,,,bionic,,,\n"+
+		"machine,1,down,,,,,,,,,,,i-1,bionic,us-east-1b,,\n"+
+		"application,logging,error,,,,,,,,,,,,bionic,,cs:logging-2,false\n"+
+		"application,mysql,active,,,,,,,,,,,,bionic,,cs:mysql-1,false\n"+
+		"application,wordpress,error,,,,,,,,,,,,bionic,,cs:wordpress-3,true\n"+
+		"unit,mysql/0,active,ready,,mysql,idle,0,,,true,,,,,,,\n"+
+		`unit,logging/0,error,"hook failed: ""install""",,logging,idle,0,,,false,,,,,,,`+"\n"+
+		`unit,wordpress/0,error,"hook failed: ""config-changed""",,wordpress,idle,0/lxd/0,,,false,,,,,,,`+"\n"+
+		"unit,wordpress/1,active,,,wordpress,idle,1,,,false,,,,,,,\n",
+	)
+}
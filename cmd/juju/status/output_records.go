@@ -0,0 +1,140 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+)
+
+// statusRecord is a single machine, application or unit in a formatted
+// status, written as one record by the jsonl and csv formatters.
+type statusRecord struct {
+	fields entityFields
+	value  interface{}
+	// nested holds the names of the JSON fields of value that hold
+	// other records, which are written as records of their own.
+	nested []string
+}
+
+// statusRecords returns the machines, applications and units in the
+// formatted status, in the order they are written. Containers follow
+// their hosts, and subordinate units their principals.
+func statusRecords(fs formattedStatus) []statusRecord {
+	var records []statusRecord
+
+	var addMachines func(machines map[string]machineStatus)
+	addMachines = func(machines map[string]machineStatus) {
+		for _, id := range naturalsort.Sort(stringKeysFromMap(machines)) {
+			m := machines[id]
+			records = append(records, statusRecord{
+				fields: machineFields(id, m),
+				value:  m,
+				nested: []string{"containers"},
+			})
+			addMachines(m.Containers)
+		}
+	}
+	addMachines(fs.Machines)
+
+	for _, name := range naturalsort.Sort(stringKeysFromMap(fs.Applications)) {
+		app := fs.Applications[name]
+		records = append(records, statusRecord{
+			fields: applicationFields(name, app),
+			value:  app,
+			nested: []string{"units"},
+		})
+	}
+
+	var addUnits func(units map[string]unitStatus, principalMachine string)
+	addUnits = func(units map[string]unitStatus, principalMachine string) {
+		for _, name := range naturalsort.Sort(stringKeysFromMap(units)) {
+			u := units[name]
+			machine := u.Machine
+			if machine == "" {
+				machine = principalMachine
+			}
+			records = append(records, statusRecord{
+				fields: unitFields(name, machine, u),
+				value:  u,
+				nested: []string{"subordinates"},
+			})
+			addUnits(u.Subordinates, machine)
+		}
+	}
+	for _, name := range naturalsort.Sort(stringKeysFromMap(fs.Applications)) {
+		addUnits(fs.Applications[name].Units, "")
+	}
+	return records
+}
+
+// FormatJSONLines writes each machine, application and unit as a JSON
+// object on a line of its own. Each object has the same fields as in
+// the json format, plus "type" and "name" fields identifying the
+// entity. Containers and subordinate units are written as objects of
+// their own rather than nested in their parents.
+func FormatJSONLines(writer io.Writer, value interface{}) error {
+	fs, valueConverted := value.(formattedStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	encoder := json.NewEncoder(writer)
+	for _, record := range statusRecords(fs) {
+		line, err := jsonRecord(record.value, record.fields["type"], record.fields["name"], record.nested)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := encoder.Encode(line); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func jsonRecord(value interface{}, kind, name string, nested []string) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, field := range nested {
+		delete(record, field)
+	}
+	record["type"] = kind
+	record["name"] = name
+	return record, nil
+}
+
+// FormatCSV writes each machine, application and unit as a CSV record,
+// after a header record naming the fields. The fields are those that
+// may be used in a status filter; a field that does not apply to an
+// entity is empty.
+func FormatCSV(writer io.Writer, value interface{}) error {
+	fs, valueConverted := value.(formattedStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	w := csv.NewWriter(writer)
+	if err := w.Write(entityFieldNames); err != nil {
+		return errors.Trace(err)
+	}
+	for _, record := range statusRecords(fs) {
+		row := make([]string, len(entityFieldNames))
+		for i, field := range entityFieldNames {
+			row[i] = record.fields[field]
+		}
+		if err := w.Write(row); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
@@ -21,6 +21,7 @@ import (
 	storageapi "github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/query"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
@@ -68,6 +69,11 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// filterExpr is the expression used to filter the machines,
+	// applications and units shown, and filter is the parsed form.
+	filterExpr string
+	filter     *query.Filter
 }
 
 var usageSummary = `
@@ -105,6 +111,24 @@ The available output formats are:
       in structured YAML format.
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
+- jsonl: Displays each machine, application and unit as a JSON object on a
+      line of its own. Each object has a "type" field naming the kind of
+      entity, and a "name" field.
+- csv: Displays each machine, application and unit as a CSV record with the
+      fields described below, after a header record.
+
+The machines, applications and units shown may also be filtered with the
+--filter option, which takes an expression comparing their fields with
+values. Comparisons are made with ==, != (equality), =~ and !~ (regular
+expression match), and may be combined with && (and), || (or), ! (not) and
+parentheses. Values containing spaces or operators must be quoted.
+
+The fields that may be compared are the type of the entity, one of machine,
+application or unit, and the same fields as in wait-for queries:
+` + query.FieldsHelp + `
+A field that does not apply to an entity is empty. As with filter patterns,
+applications with a matching unit, and machines hosting a matching unit, are
+also displayed.
 
 In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other
@@ -116,6 +140,8 @@ Examples:
     juju show-status nova-*
     juju show-status --relations
     juju show-status --storage
+    juju show-status --filter 'type == unit && status == error && message =~ "hook failed"'
+    juju show-status --filter 'type == machine && az == us-east-1a' --format csv
 
 See also:
     machines
@@ -142,6 +168,8 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section")
 
+	f.StringVar(&c.filterExpr, "filter", "", "Only show machines, applications and units matching this expression")
+
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
@@ -170,6 +198,8 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
+		"jsonl":   FormatJSONLines,
+		"csv":     FormatCSV,
 	})
 }
 
@@ -186,6 +216,13 @@ func (c *statusCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.filterExpr != "" {
+		filter, err := query.ParseFilter(c.filterExpr)
+		if err != nil {
+			return errors.Annotate(err, "invalid --filter")
+		}
+		c.filter = filter
+	}
 	if c.clock == nil {
 		c.clock = clock.WallClock
 	}
@@ -331,11 +368,18 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.filter != nil {
+		formatted = filterStatus(formatted, c.filter)
+	}
 
 	if err = c.out.Write(ctx, formatted); err != nil {
 		return err
 	}
 
+	if c.filter != nil && !status.IsEmpty() && len(formatted.Machines) == 0 && len(formatted.Applications) == 0 {
+		ctx.Infof("Nothing matched --filter %q.", c.filterExpr)
+		return nil
+	}
 	if !status.IsEmpty() {
 		return nil
 	}
@@ -130,6 +130,43 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) TestInvalidFilter(c *gc.C) {
+	_, err := s.runStatus(c, "--filter", "colour == red")
+	c.Assert(err, gc.ErrorMatches, `invalid --filter: field "colour" not valid`)
+}
+
+func (s *MinimalStatusSuite) TestFilterMatchesNothing(c *gc.C) {
+	s.statusapi.result.Machines = map[string]params.MachineStatus{
+		"0": {
+			Id:          "0",
+			AgentStatus: params.DetailedStatus{Status: "started"},
+		},
+	}
+	ctx, err := s.runStatus(c, "--filter", "status == down", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		"type,name,status,message,life,application,agent-status,machine,address,public-address,leader,instance-status,dns,instance-id,series,az,charm,exposed\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Nothing matched --filter \"status == down\".\n")
+}
+
+func (s *MinimalStatusSuite) TestFilterCSV(c *gc.C) {
+	s.statusapi.result.Machines = map[string]params.MachineStatus{
+		"0": {
+			Id:          "0",
+			AgentStatus: params.DetailedStatus{Status: "started"},
+		},
+		"1": {
+			Id:          "1",
+			AgentStatus: params.DetailedStatus{Status: "down"},
+		},
+	}
+	ctx, err := s.runStatus(c, "--filter", "status == down", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"type,name,status,message,life,application,agent-status,machine,address,public-address,leader,instance-status,dns,instance-id,series,az,charm,exposed\n"+
+		"machine,1,down,,,,,,,,,,,,,,,\n")
+}
+
 type fakeStatusAPI struct {
 	result *params.FullStatus
 	errors []error
@@ -0,0 +1,37 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewWaitForCommandForTest returns a wait-for command using the
+// supplied API and clock.
+func NewWaitForCommandForTest(api WaitForAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &waitForCommand{clock: clock}
+	c.newAPIFunc = func() (WaitForAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// QueryHolds returns whether the query expression holds for the model
+// described by the deltas.
+func QueryHolds(expr string, deltas []params.Delta) (bool, error) {
+	q, err := query.ParseQuery(expr)
+	if err != nil {
+		return false, err
+	}
+	state := newModelState()
+	state.update(deltas)
+	return q.Holds(state), nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"strconv"
+
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/query"
+	"github.com/juju/juju/core/network"
+)
+
+// modelState holds the applications, units and machines in a model, as
+// reported by an AllWatcher. It implements query.Model.
+type modelState struct {
+	applications map[string]params.EntityInfo
+	units        map[string]params.EntityInfo
+	machines     map[string]params.EntityInfo
+}
+
+func newModelState() *modelState {
+	return &modelState{
+		applications: make(map[string]params.EntityInfo),
+		units:        make(map[string]params.EntityInfo),
+		machines:     make(map[string]params.EntityInfo),
+	}
+}
+
+// update applies the given deltas to the model state.
+func (m *modelState) update(deltas []params.Delta) {
+	for _, delta := range deltas {
+		var entities map[string]params.EntityInfo
+		switch delta.Entity.(type) {
+		case *params.ApplicationInfo:
+			entities = m.applications
+		case *params.UnitInfo:
+			entities = m.units
+		case *params.MachineInfo:
+			entities = m.machines
+		default:
+			continue
+		}
+		id := delta.Entity.EntityId().Id
+		if delta.Removed {
+			delete(entities, id)
+		} else {
+			entities[id] = delta.Entity
+		}
+	}
+}
+
+// Entities is part of the query.Model interface.
+func (m *modelState) Entities(kind string) []query.Entity {
+	var entities map[string]params.EntityInfo
+	switch kind {
+	case query.Applications:
+		entities = m.applications
+	case query.Units:
+		entities = m.units
+	case query.Machines:
+		entities = m.machines
+	}
+	result := make([]query.Entity, 0, len(entities))
+	for _, entity := range entities {
+		switch entity := entity.(type) {
+		case *params.ApplicationInfo:
+			result = append(result, applicationEntity{entity})
+		case *params.UnitInfo:
+			result = append(result, unitEntity{entity})
+		case *params.MachineInfo:
+			result = append(result, machineEntity{entity})
+		}
+	}
+	return result
+}
+
+// applicationEntity exposes the fields of an application to queries.
+type applicationEntity struct {
+	*params.ApplicationInfo
+}
+
+// Field is part of the query.Entity interface.
+func (e applicationEntity) Field(name string) string {
+	switch name {
+	case "name":
+		return e.Name
+	case "status":
+		return string(e.Status.Current)
+	case "message":
+		return e.Status.Message
+	case "life":
+		return string(e.Life)
+	case "series":
+		if curl, err := charm.ParseURL(e.CharmURL); err == nil {
+			return curl.Series
+		}
+	case "charm":
+		return e.CharmURL
+	case "exposed":
+		return strconv.FormatBool(e.Exposed)
+	}
+	return ""
+}
+
+// unitEntity exposes the fields of a unit to queries. The AllWatcher
+// doesn't report leadership, so the leader field is always empty.
+type unitEntity struct {
+	*params.UnitInfo
+}
+
+// Field is part of the query.Entity interface.
+func (e unitEntity) Field(name string) string {
+	switch name {
+	case "name":
+		return e.Name
+	case "status":
+		return string(e.WorkloadStatus.Current)
+	case "message":
+		return e.WorkloadStatus.Message
+	case "life":
+		return string(e.Life)
+	case "application":
+		return e.Application
+	case "agent-status":
+		return string(e.AgentStatus.Current)
+	case "machine":
+		return e.MachineId
+	case "address":
+		return e.PrivateAddress
+	case "public-address":
+		return e.PublicAddress
+	}
+	return ""
+}
+
+// machineEntity exposes the fields of a machine to queries.
+type machineEntity struct {
+	*params.MachineInfo
+}
+
+// Field is part of the query.Entity interface.
+func (e machineEntity) Field(name string) string {
+	switch name {
+	case "name":
+		return e.Id
+	case "status":
+		return string(e.AgentStatus.Current)
+	case "message":
+		return e.AgentStatus.Message
+	case "life":
+		return string(e.Life)
+	case "instance-status":
+		return string(e.InstanceStatus.Current)
+	case "dns":
+		return e.dnsName()
+	case "instance-id":
+		return e.InstanceId
+	case "series":
+		return e.Series
+	case "az":
+		if hc := e.HardwareCharacteristics; hc != nil && hc.AvailabilityZone != nil {
+			return *hc.AvailabilityZone
+		}
+	}
+	return ""
+}
+
+// dnsName returns the machine's first public address, or its first
+// address if it has no public address.
+func (e machineEntity) dnsName() string {
+	for _, addr := range e.Addresses {
+		if addr.Scope == string(network.ScopePublic) {
+			return addr.Value
+		}
+	}
+	if len(e.Addresses) > 0 {
+		return e.Addresses[0].Value
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+)
+
+type modelSuite struct{}
+
+var _ = gc.Suite(&modelSuite{})
+
+func (s *modelSuite) TestQueryHolds(c *gc.C) {
+	deltas := []params.Delta{{
+		Entity: &params.ApplicationInfo{
+			Name:   "mysql",
+			Life:   life.Alive,
+			Status: params.StatusInfo{Current: status.Active},
+		},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active, Message: "Unit is ready"},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "wordpress/0",
+			Application:    "wordpress",
+			MachineId:      "1",
+			WorkloadStatus: params.StatusInfo{Current: status.Waiting},
+			AgentStatus:    params.StatusInfo{Current: status.Executing},
+		},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "wordpress/1",
+			Application:    "wordpress",
+			MachineId:      "0/lxd/0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		},
+	}, {
+		Entity: &params.MachineInfo{
+			Id:          "0",
+			AgentStatus: params.StatusInfo{Current: status.Started},
+		},
+	}, {
+		Entity: &params.MachineInfo{
+			Id:          "0/lxd/0",
+			AgentStatus: params.StatusInfo{Current: status.Pending},
+		},
+	}, {
+		Entity:  &params.UnitInfo{Name: "wordpress/2", Application: "wordpress"},
+		Removed: true,
+	}}
+	for i, test := range []struct {
+		expr  string
+		holds bool
+	}{
+		{"applications(mysql).status == active", true},
+		{"applications(mysql).life == alive", true},
+		{"applications(wordpress).status == active", false},
+		{"units(mysql).status == active", true},
+		{`units(mysql/0).message == "Unit is ready"`, true},
+		{"units(*).status == active", false},
+		{"units(*).status != blocked", true},
+		{"units(wordpress/1).machine == 0/lxd/0", true},
+		{"units(mysql).status == active && units(wordpress).agent-status == idle", false},
+		{"units(mysql).status == active && units(wordpress/1).agent-status == idle", true},
+		{"units(postgresql).status == active", false},
+		{"units(postgresql).status != active", false},
+		{"count(units(postgresql)) == 0", true},
+		{"count(units(wordpress)) == 2", true},
+		{"count(units(*)) >= 3", true},
+		{"count(units(*)) > 3", false},
+		{"count(units(word*)) < 3", true},
+		{"machines(0).status == started", true},
+		{"machines(*).status == started", false},
+		{"count(machines(*)) == 2", true},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		holds, err := waitfor.QueryHolds(test.expr, deltas)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(holds, gc.Equals, test.holds)
+	}
+}
+
+func (s *modelSuite) TestEntityFields(c *gc.C) {
+	zone := "us-east-1a"
+	deltas := []params.Delta{{
+		Entity: &params.ApplicationInfo{
+			Name:     "mysql",
+			CharmURL: "cs:bionic/mysql-1",
+			Exposed:  true,
+		},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			PrivateAddress: "10.0.0.2",
+			PublicAddress:  "54.0.0.2",
+		},
+	}, {
+		Entity: &params.MachineInfo{
+			Id:                      "0",
+			InstanceId:              "i-0",
+			Series:                  "bionic",
+			HardwareCharacteristics: &instance.HardwareCharacteristics{AvailabilityZone: &zone},
+			Addresses: []params.Address{
+				{Value: "10.0.0.2", Scope: "local-cloud"},
+				{Value: "54.0.0.2", Scope: "public"},
+			},
+		},
+	}}
+	for i, expr := range []string{
+		"applications(mysql).series == bionic",
+		"applications(mysql).charm == cs:bionic/mysql-1",
+		"applications(mysql).exposed == true",
+		"units(mysql/0).address == 10.0.0.2",
+		"units(mysql/0).public-address == 54.0.0.2",
+		"units(mysql/0).leader == ''",
+		"machines(0).instance-id == i-0",
+		"machines(0).series == bionic",
+		"machines(0).az == us-east-1a",
+		"machines(0).dns == 54.0.0.2",
+	} {
+		c.Logf("test %d: %s", i, expr)
+		holds, err := waitfor.QueryHolds(expr, deltas)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(holds, jc.IsTrue)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/query"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// TimeoutExitCode is the exit code of the wait-for command when the
+// query does not hold before the timeout expires.
+const TimeoutExitCode = 3
+
+// AllWatcher defines the methods of the model's AllWatcher used by the
+// wait-for command.
+type AllWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+// WaitForAPI defines the API methods used by the wait-for command.
+type WaitForAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+const waitForDoc = `
+The wait-for command blocks until a query over the applications, units
+and machines in the model holds, or until the timeout expires.
+
+A query is made up of conditions joined with && (and), || (or), ! (not)
+and parentheses. A condition either compares a field of the selected
+entities with a value:
+
+    <kind>(<pattern>).<field> == <value>
+    <kind>(<pattern>).<field> != <value>
+    <kind>(<pattern>).<field> =~ <regexp>
+    <kind>(<pattern>).<field> !~ <regexp>
+
+which holds when at least one entity is selected and the comparison
+holds for every selected entity, or compares the number of selected
+entities with a number:
+
+    count(<kind>(<pattern>)) <op> <number>
+
+where op is one of ==, !=, <, <=, > or >=.
+
+The kind is one of "applications", "units" or "machines", and the
+pattern is a glob matched against the entity names; "*" selects every
+entity of the kind. A unit pattern without a "/" is matched against
+the units' application names. Values containing spaces or operators
+must be quoted.
+
+The fields are the same as those of the status --filter option:
+` + query.FieldsHelp + `
+A field that does not apply to an entity is empty.
+
+The command exits with status 0 once the query holds. If the timeout
+expires first it exits with status 3, so scripts can tell a timeout
+from any other error, which exits with status 1.
+
+Examples:
+
+    juju wait-for 'units(*).agent-status == idle && units(*).status == active'
+    juju wait-for 'count(units(wordpress)) >= 3' --timeout 30m
+    juju wait-for 'machines(*).status == started'
+    juju wait-for 'units(mysql/0).message == "Unit is ready"'
+
+See also:
+    status
+`
+
+// NewWaitForCommand returns a command that waits for a query over the
+// model to hold.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{clock: clock.WallClock})
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (WaitForAPI, error)
+	clock      clock.Clock
+
+	query   *query.Query
+	timeout time.Duration
+}
+
+// Info implements Command.Info.
+func (c *waitForCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "wait-for",
+		Args:    "<query>",
+		Purpose: "Waits for a query over the model's applications, units and machines to hold.",
+		Doc:     waitForDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait for the query to hold")
+}
+
+// Init implements Command.Init.
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no query specified")
+	}
+	expr, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	q, err := query.ParseQuery(expr)
+	if err != nil {
+		return errors.Annotate(err, "invalid query")
+	}
+	c.query = q
+	if c.timeout <= 0 {
+		return errors.New("--timeout must be greater than zero")
+	}
+	return nil
+}
+
+func (c *waitForCommand) waitForAPI() (WaitForAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiClient{client}, nil
+}
+
+// Run implements Command.Run.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.waitForAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	// Stopping the watcher causes any pending call to Next to
+	// return, which ends the goroutine below.
+	defer watcher.Stop()
+
+	type nextResult struct {
+		deltas []params.Delta
+		err    error
+	}
+	results := make(chan nextResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- nextResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	state := newModelState()
+	timeout := c.clock.After(c.timeout)
+	for {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "cannot watch model")
+			}
+			state.update(result.deltas)
+			if c.query.Holds(state) {
+				ctx.Verbosef("query holds: %s", c.query)
+				return nil
+			}
+		case <-timeout:
+			ctx.Infof("timed out after %s waiting for %s", c.timeout, c.query)
+			return cmd.NewRcPassthroughError(TimeoutExitCode)
+		}
+	}
+}
+
+// apiClient adapts an *api.Client to the WaitForAPI interface.
+type apiClient struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitForAPI interface.
+func (c apiClient) WatchAll() (AllWatcher, error) {
+	watcher, err := c.Client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type waitForSuite struct {
+	testing.BaseSuite
+	clock   *testclock.Clock
+	api     *mockWaitForAPI
+	watcher *mockAllWatcher
+}
+
+var _ = gc.Suite(&waitForSuite{})
+
+func (s *waitForSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.watcher = &mockAllWatcher{
+		deltas:  make(chan []params.Delta, 10),
+		stopped: make(chan struct{}),
+	}
+	s.api = &mockWaitForAPI{watcher: s.watcher}
+}
+
+func (s *waitForSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := waitfor.NewWaitForCommandForTest(s.api, s.clock, jujuclienttesting.MinimalStore())
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func unitDelta(name string, workload status.Status) params.Delta {
+	return params.Delta{
+		Entity: &params.UnitInfo{
+			Name:           name,
+			Application:    "mysql",
+			WorkloadStatus: params.StatusInfo{Current: workload},
+		},
+	}
+}
+
+func (s *waitForSuite) TestQueryHolds(c *gc.C) {
+	s.watcher.deltas <- []params.Delta{unitDelta("mysql/0", status.Maintenance)}
+	s.watcher.deltas <- []params.Delta{unitDelta("mysql/0", status.Active)}
+
+	_, err := s.run(c, "units(mysql).status == active")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "WatchAll", "Close")
+	s.checkStopped(c)
+}
+
+func (s *waitForSuite) checkStopped(c *gc.C) {
+	select {
+	case <-s.watcher.stopped:
+	default:
+		c.Fatalf("watcher not stopped")
+	}
+}
+
+func (s *waitForSuite) TestTimeout(c *gc.C) {
+	s.watcher.deltas <- []params.Delta{unitDelta("mysql/0", status.Maintenance)}
+
+	errc := make(chan error, 1)
+	var ctx *cmd.Context
+	go func() {
+		var err error
+		ctx, err = s.run(c, "units(mysql).status == active", "--timeout", "5m")
+		errc <- err
+	}()
+	err := s.clock.WaitAdvance(5*time.Minute, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-errc:
+		c.Assert(err, jc.Satisfies, cmd.IsRcPassthroughError)
+		c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 3")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("command did not time out")
+	}
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"timed out after 5m0s waiting for units(mysql).status == \"active\"\n")
+	s.checkStopped(c)
+}
+
+func (s *waitForSuite) TestWatcherError(c *gc.C) {
+	s.watcher.SetErrors(errors.New("boom"))
+
+	_, err := s.run(c, "units(mysql).status == active")
+	c.Assert(err, gc.ErrorMatches, "cannot watch model: boom")
+	c.Assert(cmd.IsRcPassthroughError(err), jc.IsFalse)
+}
+
+func (s *waitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no query specified",
+	}, {
+		args: []string{"units(mysql).instance-status == running"},
+		err:  `invalid query: units field "instance-status" not valid`,
+	}, {
+		args: []string{"units(mysql).status == active", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"units(mysql).status == active", "--timeout", "0s"},
+		err:  "--timeout must be greater than zero",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+type mockWaitForAPI struct {
+	jujutesting.Stub
+	watcher *mockAllWatcher
+}
+
+func (m *mockWaitForAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockWaitForAPI) WatchAll() (waitfor.AllWatcher, error) {
+	m.MethodCall(m, "WatchAll")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+type mockAllWatcher struct {
+	jujutesting.Stub
+	deltas  chan []params.Delta
+	stopped chan struct{}
+}
+
+func (m *mockAllWatcher) Next() ([]params.Delta, error) {
+	m.MethodCall(m, "Next")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	select {
+	case deltas := <-m.deltas:
+		return deltas, nil
+	case <-m.stopped:
+		return nil, errors.New("watcher stopped")
+	}
+}
+
+func (m *mockAllWatcher) Stop() error {
+	m.MethodCall(m, "Stop")
+	close(m.stopped)
+	return m.NextErr()
+}
@@ -407,6 +407,14 @@ func ensureKeys(
 	info *params.StateServingInfo,
 	newConfigAttrs map[string]interface{},
 ) error {
+	// Generate the key used to encrypt charm secrets. It is kept in
+	// the controller agents' config rather than the database.
+	secretsKey, err := state.NewSecretsKey()
+	if err != nil {
+		return errors.Annotate(err, "failed to generate secrets key")
+	}
+	info.SecretsKey = secretsKey
+
 	if isCAAS {
 		return nil
 	}
@@ -34,6 +34,7 @@ type introspectionConfig struct {
 	Engine             *dependency.Engine
 	StatePoolReporter  introspection.IntrospectionReporter
 	PubSubReporter     introspection.IntrospectionReporter
+	HookHistory        introspection.IntrospectionReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	PresenceRecorder   presence.Recorder
@@ -59,6 +60,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		DepEngine:          cfg.Engine,
 		StatePool:          cfg.StatePoolReporter,
 		PubSub:             cfg.PubSubReporter,
+		HookHistory:        cfg.HookHistory,
 		MachineLock:        cfg.MachineLock,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 		Presence:           cfg.PresenceRecorder,
@@ -77,6 +79,47 @@ func startIntrospection(cfg introspectionConfig) error {
 	return nil
 }
 
+// metricsConfig defines the components needed to collect an agent's
+// dependency engine metrics.
+type metricsConfig struct {
+	Engine               *dependency.Engine
+	PrometheusRegisterer prometheus.Registerer
+}
+
+// startMetrics registers a collector for the engine's workers. The
+// returned worker wraps the engine, and only returns from Wait once the
+// collector has been unregistered, so that an engine started in its
+// place can register its own.
+func startMetrics(cfg metricsConfig) (worker.Worker, error) {
+	collector := introspection.NewDepEngineCollector(cfg.Engine)
+	if err := cfg.PrometheusRegisterer.Register(collector); err != nil {
+		return nil, errors.Annotate(err, "registering dependency engine collector")
+	}
+	return &collectedEngine{
+		Engine:     cfg.Engine,
+		registerer: cfg.PrometheusRegisterer,
+		collector:  collector,
+	}, nil
+}
+
+// collectedEngine is a dependency engine whose workers are reported by
+// a registered prometheus collector.
+type collectedEngine struct {
+	*dependency.Engine
+	registerer prometheus.Registerer
+	collector  prometheus.Collector
+	once       sync.Once
+}
+
+// Wait is part of the worker.Worker interface.
+func (e *collectedEngine) Wait() error {
+	err := e.Engine.Wait()
+	e.once.Do(func() {
+		e.registerer.Unregister(e.collector)
+	})
+	return err
+}
+
 // newPrometheusRegistry returns a new prometheus.Registry with
 // the Go and process metric collectors registered. This registry
 // is exposed by the introspection abstract domain socket on all
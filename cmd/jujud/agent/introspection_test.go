@@ -12,10 +12,12 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
+	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/agent"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
@@ -107,6 +109,52 @@ func (s *introspectionSuite) TestStartSuccess(c *gc.C) {
 	}
 }
 
+func (s *introspectionSuite) newEngine(c *gc.C) *dependency.Engine {
+	engine, err := dependency.NewEngine(dependency.EngineConfig{
+		IsFatal:    cmdutil.IsFatal,
+		WorstError: cmdutil.MoreImportantError,
+		Clock:      clock.WallClock,
+		Logger:     loggo.GetLogger("juju.worker.dependency"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return engine
+}
+
+func (s *introspectionSuite) TestStartMetrics(c *gc.C) {
+	engine := s.newEngine(c)
+	registry := prometheus.NewRegistry()
+
+	w, err := startMetrics(metricsConfig{
+		Engine:               engine,
+		PrometheusRegisterer: registry,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The dependency engine collector is registered.
+	err = registry.Register(introspection.NewDepEngineCollector(engine))
+	c.Assert(err, gc.FitsTypeOf, prometheus.AlreadyRegisteredError{})
+
+	// Once the engine has stopped, the collector has been unregistered.
+	workertest.CleanKill(c, w)
+	err = registry.Register(introspection.NewDepEngineCollector(engine))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *introspectionSuite) TestStartMetricsError(c *gc.C) {
+	engine := s.newEngine(c)
+	defer workertest.CleanKill(c, engine)
+	registry := prometheus.NewRegistry()
+	err := registry.Register(introspection.NewDepEngineCollector(engine))
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := startMetrics(metricsConfig{
+		Engine:               engine,
+		PrometheusRegisterer: registry,
+	})
+	c.Check(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "registering dependency engine collector: .*")
+}
+
 func (s *introspectionSuite) TestDefaultIntrospectionSocketName(c *gc.C) {
 	name := DefaultIntrospectionSocketName(names.NewMachineTag("42"))
 	c.Assert(name, gc.Equals, "jujud-machine-42")
@@ -73,6 +73,7 @@ import (
 	"github.com/juju/juju/storage/looputil"
 	"github.com/juju/juju/upgrades"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/apicaller"
 	workercommon "github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/deployer"
@@ -327,6 +328,7 @@ func NewMachineAgent(
 		prometheusRegistry:          prometheusRegistry,
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
+		apiCallerMetrics:            apicaller.NewMetrics(),
 		preUpgradeSteps:             preUpgradeSteps,
 		isCaasAgent:                 isCaasAgent,
 	}
@@ -355,6 +357,9 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	if err := a.prometheusRegistry.Register(a.mongoDialCollector); err != nil {
 		return errors.Annotate(err, "registering mongo dial collector")
 	}
+	if err := a.prometheusRegistry.Register(a.apiCallerMetrics); err != nil {
+		return errors.Annotate(err, "registering api caller collector")
+	}
 	return nil
 }
 
@@ -389,6 +394,7 @@ type MachineAgent struct {
 	prometheusRegistry         *prometheus.Registry
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
+	apiCallerMetrics           *apicaller.Metrics
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
 	// Only API servers have hubs. This is temporary until the apiserver and
@@ -591,6 +597,8 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			Clock:                   clock.WallClock,
 			ValidateMigration:       a.validateMigration,
 			PrometheusRegisterer:    a.prometheusRegistry,
+			PrometheusGatherer:      a.prometheusRegistry,
+			APICallerMetrics:        a.apiCallerMetrics,
 			CentralHub:              a.centralHub,
 			PubSubReporter:          pubsubReporter,
 			PresenceRecorder:        presenceRecorder,
@@ -610,6 +618,9 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			NewContainerBrokerFunc:            newCAASBroker,
 			NewBrokerFunc:                     newBroker,
 			IsCaasConfig:                      a.isCaasAgent,
+			UnitIntrospectionSocketName: func(tag names.UnitTag) string {
+				return DefaultIntrospectionSocketName(tag)
+			},
 		}
 		manifolds := iaasMachineManifolds(manifoldsCfg)
 		if a.isCaasAgent {
@@ -638,7 +649,17 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			// and the agent is controlled by by the OS to only have one.
 			logger.Errorf("failed to start introspection worker: %v", err)
 		}
-		return engine, nil
+		w, err := startMetrics(metricsConfig{
+			Engine:               engine,
+			PrometheusRegisterer: a.prometheusRegistry,
+		})
+		if err != nil {
+			// As with the introspection worker, failing to collect the
+			// engine's metrics should not stop the agent from running.
+			logger.Errorf("failed to start metrics: %v", err)
+			return engine, nil
+		}
+		return w, nil
 	}
 }
 
@@ -869,6 +890,7 @@ func (a *MachineAgent) openStateForUpgrade() (*state.StatePool, error) {
 		// to pass in the max-txn-log-size value.
 		InitDatabaseFunc:       state.InitDatabase,
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		SecretsKey:             secretsKey(agentConfig),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -876,6 +898,16 @@ func (a *MachineAgent) openStateForUpgrade() (*state.StatePool, error) {
 	return pool, nil
 }
 
+// secretsKey returns the key used to encrypt charm secrets, which is
+// kept in the controller agent's config rather than the database.
+func secretsKey(agentConfig agent.Config) string {
+	info, ok := agentConfig.StateServingInfo()
+	if !ok {
+		return ""
+	}
+	return info.SecretsKey
+}
+
 // validateMigration is called by the migrationminion to help check
 // that the agent will be ok when connected to a new controller.
 func (a *MachineAgent) validateMigration(apiCaller base.APICaller) error {
@@ -1024,6 +1056,7 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		SecretsKey:             secretsKey(agentConfig),
 	})
 	return ctrl, errors.Trace(err)
 }
@@ -1231,6 +1264,7 @@ func openStatePool(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		SecretsKey:             secretsKey(agentConfig),
 	})
 	if err != nil {
 		return nil, err
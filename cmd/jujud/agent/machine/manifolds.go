@@ -16,6 +16,7 @@ import (
 	"github.com/juju/utils/voyeur"
 	"github.com/juju/version"
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
@@ -44,6 +45,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -64,6 +66,7 @@ import (
 	"github.com/juju/juju/worker/httpserverargs"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/instancemutater"
+	"github.com/juju/juju/worker/introspection"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
@@ -200,6 +203,20 @@ type ManifoldsConfig struct {
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
 
+	// PrometheusGatherer supplies the agent's metrics, which are
+	// served remotely when the controller configures an
+	// agent-metrics-port.
+	PrometheusGatherer prometheus.Gatherer
+
+	// UnitIntrospectionSocketName returns the name of the
+	// introspection socket of a unit agent running on the machine,
+	// so that its metrics can be served alongside the machine's.
+	UnitIntrospectionSocketName func(names.UnitTag) string
+
+	// APICallerMetrics, if not nil, records the state of the agent's
+	// API connection.
+	APICallerMetrics *apicaller.Metrics
+
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
 
@@ -466,6 +483,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewConnection:        apicaller.ScaryConnect,
 			Filter:               connectFilter,
 			Logger:               loggo.GetLogger("juju.worker.apicaller"),
+			Metrics:              config.APICallerMetrics,
 		}),
 
 		// The upgrade database gate is used to coordinate workers that should
@@ -1008,6 +1026,30 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewClient:     instancemutater.NewClient,
 			NewWorker:     instancemutater.NewContainerWorker,
 		})),
+
+		// The agent metrics worker serves the metrics of the machine
+		// agent, and of the unit agents on the machine, over HTTPS
+		// when the controller configures an agent-metrics-port.
+		agentMetricsName: ifNotMigrating(introspection.MetricsManifold(introspection.MetricsManifoldConfig{
+			AgentName:          agentName,
+			APICallerName:      apiCallerName,
+			PrometheusGatherer: config.PrometheusGatherer,
+			UnitSocketName:     config.UnitIntrospectionSocketName,
+			NewFacade:          introspection.NewMetricsFacade,
+			NewWorker:          introspection.NewMetricsWorker,
+		})),
+
+		// The backup scheduler runs only on the primary controller,
+		// so that a single backup is created on each schedule.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName: agentName,
+				ClockName: clockName,
+				StateName: stateName,
+				Logger:    loggo.GetLogger("juju.worker.backupscheduler"),
+				NewWorker: backupscheduler.NewWorker,
+			},
+		))),
 	}
 
 	return mergeManifolds(config, manifolds)
@@ -1164,6 +1206,8 @@ const (
 	restoreWatcherName            = "restore-watcher"
 	certificateUpdaterName        = "certificate-updater"
 	auditConfigUpdaterName        = "audit-config-updater"
+	backupSchedulerName           = "backup-scheduler"
+	agentMetricsName              = "agent-metrics"
 	leaseManagerName              = "lease-manager"
 	legacyLeasesFlagName          = "legacy-leases-flag"
 
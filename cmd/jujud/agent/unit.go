@@ -27,9 +27,11 @@ import (
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/upgrades"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/logsender"
+	workeruniter "github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/upgradesteps"
 )
 
@@ -60,6 +62,9 @@ type UnitAgent struct {
 	upgradeComplete             gate.Lock
 
 	prometheusRegistry *prometheus.Registry
+	apiCallerMetrics   *apicaller.Metrics
+	hookMetrics        *workeruniter.HookMetrics
+	hookHistory        *workeruniter.HookHistory
 }
 
 // NewUnitAgent creates a new UnitAgent value properly initialized.
@@ -68,6 +73,14 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	apiCallerMetrics := apicaller.NewMetrics()
+	if err := prometheusRegistry.Register(apiCallerMetrics); err != nil {
+		return nil, errors.Annotate(err, "registering api caller collector")
+	}
+	hookMetrics := workeruniter.NewHookMetrics()
+	if err := prometheusRegistry.Register(hookMetrics); err != nil {
+		return nil, errors.Annotate(err, "registering hook collector")
+	}
 	return &UnitAgent{
 		AgentConf:                   NewAgentConf(""),
 		configChangedVal:            voyeur.NewValue(true),
@@ -76,6 +89,9 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 		initialUpgradeCheckComplete: gate.NewLock(),
 		bufferedLogger:              bufferedLogger,
 		prometheusRegistry:          prometheusRegistry,
+		apiCallerMetrics:            apiCallerMetrics,
+		hookMetrics:                 hookMetrics,
+		hookHistory:                 workeruniter.NewHookHistory(workeruniter.DefaultHookHistorySize),
 		preUpgradeSteps:             upgrades.PreUpgradeSteps,
 	}, nil
 }
@@ -198,6 +214,9 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		AgentConfigChanged:   a.configChangedVal,
 		ValidateMigration:    a.validateMigration,
 		PrometheusRegisterer: a.prometheusRegistry,
+		APICallerMetrics:     a.apiCallerMetrics,
+		HookMetrics:          a.hookMetrics,
+		HookHistory:          a.hookHistory,
 		UpdateLoggerConfig:   updateAgentConfLogging,
 		PreviousAgentVersion: agentConfig.UpgradedToVersion(),
 		PreUpgradeSteps:      a.preUpgradeSteps,
@@ -222,6 +241,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		Engine:             engine,
 		NewSocketName:      DefaultIntrospectionSocketName,
 		PrometheusGatherer: a.prometheusRegistry,
+		HookHistory:        a.hookHistory,
 		MachineLock:        machineLock,
 		WorkerFunc:         introspection.NewWorker,
 	}); err != nil {
@@ -231,7 +251,19 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		// and the agent is controlled by by the OS to only have one.
 		logger.Errorf("failed to start introspection worker: %v", err)
 	}
-	return engine, nil
+	// The unit agent's metrics are served on its introspection socket,
+	// and remotely by the machine agent.
+	w, err := startMetrics(metricsConfig{
+		Engine:               engine,
+		PrometheusRegisterer: a.prometheusRegistry,
+	})
+	if err != nil {
+		// As with the introspection worker, failing to collect the
+		// engine's metrics should not stop the agent from running.
+		logger.Errorf("failed to start metrics: %v", err)
+		return engine, nil
+	}
+	return w, nil
 }
 
 func (a *UnitAgent) Tag() names.Tag {
@@ -72,6 +72,17 @@ type ManifoldsConfig struct {
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
 
+	// APICallerMetrics, if not nil, records the state of the agent's
+	// API connection.
+	APICallerMetrics *apicaller.Metrics
+
+	// HookMetrics, if not nil, records the hooks run by the uniter.
+	HookMetrics *uniter.HookMetrics
+
+	// HookHistory, if not nil, records the hooks and actions run by
+	// the uniter.
+	HookHistory *uniter.HookHistory
+
 	// UpdateLoggerConfig is a function that will save the specified
 	// config value as the logging config in the agent.conf file.
 	UpdateLoggerConfig func(string) error
@@ -153,6 +164,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewConnection:        apicaller.ScaryConnect,
 			Filter:               connectFilter,
 			Logger:               loggo.GetLogger("juju.worker.apicaller"),
+			Metrics:              config.APICallerMetrics,
 		}),
 
 		// The log sender is a leaf worker that sends log messages to some
@@ -319,6 +331,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			CharmDirName:          charmDirName,
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
+			HookMetrics:           config.HookMetrics,
+			HookHistory:           config.HookHistory,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
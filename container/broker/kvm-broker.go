@@ -133,6 +133,7 @@ func (broker *kvmBroker) StartInstance(ctx context.ProviderCallContext, args env
 		config.EnableOSRefreshUpdate,
 		config.EnableOSUpgrade,
 		cloudInitUserData,
+		config.CloudInitUserHooks,
 		nil,
 	); err != nil {
 		kvmLogger.Errorf("failed to populate machine config: %v", err)
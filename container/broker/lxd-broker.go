@@ -132,6 +132,7 @@ func (broker *lxdBroker) StartInstance(ctx context.ProviderCallContext, args env
 		config.EnableOSRefreshUpdate,
 		config.EnableOSUpgrade,
 		cloudInitUserData,
+		config.CloudInitUserHooks,
 		append([]string{"default"}, pNames...),
 	); err != nil {
 		lxdLogger.Errorf("failed to populate machine config: %v", err)
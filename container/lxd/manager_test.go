@@ -104,6 +104,7 @@ func prepInstanceConfig(c *gc.C) *instancecfg.InstanceConfig {
 		false,
 		nil,
 		nil,
+		nil,
 	)
 	list := coretools.List{
 		&coretools.Tools{Version: version.MustParseBinary("2.3.4-trusty-amd64")},
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/robfig/cron.v2"
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/core/resources"
@@ -83,6 +85,11 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogForward determines whether audit log records are also
+	// written to the controller model's logs, so that they are sent
+	// to the log forwarding sink configured for that model.
+	AuditLogForward = "audit-log-forward"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -206,6 +213,14 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultBackupLocalRoot is the default directory under which
+	// "file://" backup destinations must reside.
+	DefaultBackupLocalRoot = "/var/lib/juju/backups"
+
+	// DefaultAuditLogForward is the default for the AuditLogForward
+	// setting (which is not to forward audit log records).
+	DefaultAuditLogForward = false
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -279,6 +294,61 @@ const (
 
 	// MeteringURL is the key for the url to use for metrics
 	MeteringURL = "metering-url"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store
+	// used for backups created with an "s3://" destination. If not
+	// set, AWS S3 is used.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the object store used for
+	// backups created with an "s3://" destination.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3AccessKey is the access key used to authenticate with
+	// the object store used for backups.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with
+	// the object store used for backups.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupLocalRoot is the directory on the controller machines under
+	// which "file://" backup destinations must reside.
+	BackupLocalRoot = "backup-local-root"
+
+	// BackupSchedule is a cron-like schedule on which the controller
+	// creates backups of itself, eg "0 3 * * *" for 03:00 UTC every day.
+	// If not set, no scheduled backups are created.
+	BackupSchedule = "backup-schedule"
+
+	// BackupScheduleDestination is the destination URL, as accepted by
+	// "juju create-backup --destination", for scheduled backups. If not
+	// set, scheduled backups are stored in the controller.
+	BackupScheduleDestination = "backup-schedule-destination"
+
+	// BackupKeepLast is the number of most recent scheduled backups
+	// which are always kept.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDaily is the number of days for which the latest
+	// scheduled backup of each day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the latest
+	// scheduled backup of each week is kept.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// AgentMetricsPort is the port on which machine agents serve their
+	// prometheus metrics, and those of the unit agents on the machine,
+	// over HTTPS. If zero, agent metrics are only served on the
+	// machine's introspection sockets.
+	AgentMetricsPort = "agent-metrics-port"
+
+	// AgentMetricsUsername and AgentMetricsPassword are the basic
+	// authentication credentials that must be supplied to read agent
+	// metrics.
+	AgentMetricsUsername = "agent-metrics-username"
+	AgentMetricsPassword = "agent-metrics-password"
 )
 
 var (
@@ -318,10 +388,24 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogForward,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
 		MeteringURL,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupLocalRoot,
+		BackupSchedule,
+		BackupScheduleDestination,
+		BackupKeepLast,
+		BackupKeepDaily,
+		BackupKeepWeekly,
+		AgentMetricsPort,
+		AgentMetricsUsername,
+		AgentMetricsPassword,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -334,6 +418,7 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogForward,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -352,6 +437,27 @@ var (
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupSchedule,
+		BackupScheduleDestination,
+		BackupKeepLast,
+		BackupKeepDaily,
+		BackupKeepWeekly,
+		AgentMetricsPort,
+		AgentMetricsUsername,
+		AgentMetricsPassword,
+	)
+
+	// SecretAttributes are controller config attributes holding
+	// credentials. They are never returned through the API, because
+	// agents can read the controller config.
+	SecretAttributes = set.NewStrings(
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		AgentMetricsPassword,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -567,6 +673,15 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogForward returns whether audit log records should be
+// forwarded with the controller model's logs. The default is false.
+func (c Config) AuditLogForward() bool {
+	if v, ok := c[AuditLogForward]; ok {
+		return v.(bool)
+	}
+	return DefaultAuditLogForward
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -762,6 +877,87 @@ func (c Config) MeteringURL() string {
 	return url
 }
 
+// BackupS3Endpoint returns the URL of the object store used for "s3://"
+// backup destinations.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region of the object store used for "s3://"
+// backup destinations.
+func (c Config) BackupS3Region() string {
+	return c.asString(BackupS3Region)
+}
+
+// BackupS3AccessKey returns the access key for the object store used for
+// "s3://" backup destinations.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key for the object store used for
+// "s3://" backup destinations.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// BackupLocalRoot returns the directory under which "file://" backup
+// destinations must reside.
+func (c Config) BackupLocalRoot() string {
+	if root := c.asString(BackupLocalRoot); root != "" {
+		return root
+	}
+	return DefaultBackupLocalRoot
+}
+
+// BackupSchedule returns the cron-like schedule for controller backups,
+// or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupScheduleDestination returns the destination URL for scheduled
+// backups, or "" if they are stored in the controller.
+func (c Config) BackupScheduleDestination() string {
+	return c.asString(BackupScheduleDestination)
+}
+
+// BackupKeepLast returns the number of most recent scheduled backups
+// to keep.
+func (c Config) BackupKeepLast() int {
+	return c.intOrDefault(BackupKeepLast, 0)
+}
+
+// BackupKeepDaily returns the number of days for which a daily
+// scheduled backup is kept.
+func (c Config) BackupKeepDaily() int {
+	return c.intOrDefault(BackupKeepDaily, 0)
+}
+
+// AgentMetricsPort returns the port on which machine agents serve
+// agent metrics, or 0 if they aren't served over the network.
+func (c Config) AgentMetricsPort() int {
+	return c.intOrDefault(AgentMetricsPort, 0)
+}
+
+// AgentMetricsUsername returns the username required to read agent
+// metrics.
+func (c Config) AgentMetricsUsername() string {
+	return c.asString(AgentMetricsUsername)
+}
+
+// AgentMetricsPassword returns the password required to read agent
+// metrics.
+func (c Config) AgentMetricsPassword() string {
+	return c.asString(AgentMetricsPassword)
+}
+
+// BackupKeepWeekly returns the number of weeks for which a weekly
+// scheduled backup is kept.
+func (c Config) BackupKeepWeekly() int {
+	return c.intOrDefault(BackupKeepWeekly, 0)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -883,6 +1079,43 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupS3Endpoint].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup S3 endpoint in configuration")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("backup S3 endpoint %q must use http or https", v)
+		}
+	}
+
+	if v, ok := c[BackupLocalRoot].(string); ok && v != "" {
+		if !filepath.IsAbs(v) || filepath.Clean(v) == "/" {
+			return errors.NotValidf("backup local root %q (must be an absolute path other than /)", v)
+		}
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotatef(err, "invalid backup schedule %q", v)
+		}
+	}
+
+	for _, key := range []string{BackupKeepLast, BackupKeepDaily, BackupKeepWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.NotValidf("negative %s", key)
+		}
+	}
+
+	if v, ok := c[AgentMetricsPort].(int); ok && v != 0 {
+		if v < 0 || v > 65535 {
+			return errors.NotValidf("agent metrics port %d", v)
+		}
+		if c.AgentMetricsUsername() == "" || c.AgentMetricsPassword() == "" {
+			return errors.NotValidf("agent metrics port without username and password")
+		}
+	}
+
 	var auditLogMaxSize int
 	if v, ok := c[AuditLogMaxSize].(string); ok {
 		if size, err := utils.ParseSize(v); err != nil {
@@ -996,77 +1229,105 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AgentRateLimitMax:       schema.ForceInt(),
-	AgentRateLimitRate:      schema.TimeDuration(),
-	AuditingEnabled:         schema.Bool(),
-	AuditLogCaptureArgs:     schema.Bool(),
-	AuditLogMaxSize:         schema.String(),
-	AuditLogMaxBackups:      schema.ForceInt(),
-	AuditLogExcludeMethods:  schema.List(schema.String()),
-	APIPort:                 schema.ForceInt(),
-	APIPortOpenDelay:        schema.String(),
-	ControllerAPIPort:       schema.ForceInt(),
-	ControllerName:          schema.String(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxDebugLogDuration:     schema.TimeDuration(),
-	MaxTxnLogSize:           schema.String(),
-	MaxPruneTxnBatchSize:    schema.ForceInt(),
-	MaxPruneTxnPasses:       schema.ForceInt(),
-	ModelLogfileMaxBackups:  schema.ForceInt(),
-	ModelLogfileMaxSize:     schema.String(),
-	ModelLogsSize:           schema.String(),
-	PruneTxnQueryCount:      schema.ForceInt(),
-	PruneTxnSleepTime:       schema.String(),
-	JujuHASpace:             schema.String(),
-	JujuManagementSpace:     schema.String(),
-	CAASOperatorImagePath:   schema.String(),
-	CAASImageRepo:           schema.String(),
-	Features:                schema.List(schema.String()),
-	CharmStoreURL:           schema.String(),
-	MeteringURL:             schema.String(),
+	AgentRateLimitMax:         schema.ForceInt(),
+	AgentRateLimitRate:        schema.TimeDuration(),
+	AuditingEnabled:           schema.Bool(),
+	AuditLogCaptureArgs:       schema.Bool(),
+	AuditLogMaxSize:           schema.String(),
+	AuditLogMaxBackups:        schema.ForceInt(),
+	AuditLogExcludeMethods:    schema.List(schema.String()),
+	AuditLogForward:           schema.Bool(),
+	APIPort:                   schema.ForceInt(),
+	APIPortOpenDelay:          schema.String(),
+	ControllerAPIPort:         schema.ForceInt(),
+	ControllerName:            schema.String(),
+	StatePort:                 schema.ForceInt(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
+	MaxDebugLogDuration:       schema.TimeDuration(),
+	MaxTxnLogSize:             schema.String(),
+	MaxPruneTxnBatchSize:      schema.ForceInt(),
+	MaxPruneTxnPasses:         schema.ForceInt(),
+	ModelLogfileMaxBackups:    schema.ForceInt(),
+	ModelLogfileMaxSize:       schema.String(),
+	ModelLogsSize:             schema.String(),
+	PruneTxnQueryCount:        schema.ForceInt(),
+	PruneTxnSleepTime:         schema.String(),
+	JujuHASpace:               schema.String(),
+	JujuManagementSpace:       schema.String(),
+	CAASOperatorImagePath:     schema.String(),
+	CAASImageRepo:             schema.String(),
+	Features:                  schema.List(schema.String()),
+	CharmStoreURL:             schema.String(),
+	MeteringURL:               schema.String(),
+	BackupS3Endpoint:          schema.String(),
+	BackupS3Region:            schema.String(),
+	BackupS3AccessKey:         schema.String(),
+	BackupS3SecretKey:         schema.String(),
+	BackupLocalRoot:           schema.String(),
+	BackupSchedule:            schema.String(),
+	BackupScheduleDestination: schema.String(),
+	BackupKeepLast:            schema.ForceInt(),
+	BackupKeepDaily:           schema.ForceInt(),
+	BackupKeepWeekly:          schema.ForceInt(),
+	AgentMetricsPort:          schema.ForceInt(),
+	AgentMetricsUsername:      schema.String(),
+	AgentMetricsPassword:      schema.String(),
 }, schema.Defaults{
-	AgentRateLimitMax:       schema.Omit,
-	AgentRateLimitRate:      schema.Omit,
-	APIPort:                 DefaultAPIPort,
-	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
-	ControllerAPIPort:       schema.Omit,
-	ControllerName:          schema.Omit,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      DefaultMongoMemoryProfile,
-	MaxDebugLogDuration:     DefaultMaxDebugLogDuration,
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:    DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:       DefaultMaxPruneTxnPasses,
-	ModelLogfileMaxBackups:  DefaultModelLogfileMaxBackups,
-	ModelLogfileMaxSize:     fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
-	ModelLogsSize:           fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
-	PruneTxnQueryCount:      DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:       DefaultPruneTxnSleepTime,
-	JujuHASpace:             schema.Omit,
-	JujuManagementSpace:     schema.Omit,
-	CAASOperatorImagePath:   schema.Omit,
-	CAASImageRepo:           schema.Omit,
-	Features:                schema.Omit,
-	CharmStoreURL:           csclient.ServerURL,
-	MeteringURL:             romulus.DefaultAPIRoot,
+	AgentRateLimitMax:         schema.Omit,
+	AgentRateLimitRate:        schema.Omit,
+	APIPort:                   DefaultAPIPort,
+	APIPortOpenDelay:          DefaultAPIPortOpenDelay,
+	ControllerAPIPort:         schema.Omit,
+	ControllerName:            schema.Omit,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	AuditLogCaptureArgs:       DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:           fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:        DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:    DefaultAuditLogExcludeMethods,
+	AuditLogForward:           DefaultAuditLogForward,
+	StatePort:                 DefaultStatePort,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        DefaultMongoMemoryProfile,
+	MaxDebugLogDuration:       DefaultMaxDebugLogDuration,
+	MaxTxnLogSize:             fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:      DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:         DefaultMaxPruneTxnPasses,
+	ModelLogfileMaxBackups:    DefaultModelLogfileMaxBackups,
+	ModelLogfileMaxSize:       fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
+	ModelLogsSize:             fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
+	PruneTxnQueryCount:        DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:         DefaultPruneTxnSleepTime,
+	JujuHASpace:               schema.Omit,
+	JujuManagementSpace:       schema.Omit,
+	CAASOperatorImagePath:     schema.Omit,
+	CAASImageRepo:             schema.Omit,
+	Features:                  schema.Omit,
+	CharmStoreURL:             csclient.ServerURL,
+	MeteringURL:               romulus.DefaultAPIRoot,
+	BackupS3Endpoint:          schema.Omit,
+	BackupS3Region:            schema.Omit,
+	BackupS3AccessKey:         schema.Omit,
+	BackupS3SecretKey:         schema.Omit,
+	BackupLocalRoot:           schema.Omit,
+	BackupSchedule:            schema.Omit,
+	BackupScheduleDestination: schema.Omit,
+	BackupKeepLast:            schema.Omit,
+	BackupKeepDaily:           schema.Omit,
+	BackupKeepWeekly:          schema.Omit,
+	AgentMetricsPort:          schema.Omit,
+	AgentMetricsUsername:      schema.Omit,
+	AgentMetricsPassword:      schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1101,6 +1362,10 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.FieldType("list of strings"),
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogForward: {
+		Type:        environschema.Tbool,
+		Description: "Determines if audit log records are forwarded with the controller model's logs",
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -1219,4 +1484,56 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tstring,
 		Description: `The url for metrics`,
 	},
+	BackupS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The url of the S3-compatible object store used for "s3://" backup destinations (defaults to AWS S3)`,
+	},
+	BackupS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region of the object store used for "s3://" backup destinations`,
+	},
+	BackupS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key for the object store used for "s3://" backup destinations`,
+	},
+	BackupS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key for the object store used for "s3://" backup destinations`,
+	},
+	BackupLocalRoot: {
+		Type:        environschema.Tstring,
+		Description: `The directory on the controller machines under which "file://" backup destinations must reside`,
+	},
+	BackupSchedule: {
+		Type:        environschema.Tstring,
+		Description: `A cron-like schedule (in UTC) on which to create controller backups, eg "0 3 * * *" (empty disables scheduled backups)`,
+	},
+	BackupScheduleDestination: {
+		Type:        environschema.Tstring,
+		Description: `The destination url for scheduled backups, eg "s3://bucket/prefix" (defaults to storing them in the controller)`,
+	},
+	BackupKeepLast: {
+		Type:        environschema.Tint,
+		Description: `The number of most recent scheduled backups to keep`,
+	},
+	BackupKeepDaily: {
+		Type:        environschema.Tint,
+		Description: `The number of days for which the latest scheduled backup of each day is kept`,
+	},
+	BackupKeepWeekly: {
+		Type:        environschema.Tint,
+		Description: `The number of weeks for which the latest scheduled backup of each week is kept`,
+	},
+	AgentMetricsPort: {
+		Type:        environschema.Tint,
+		Description: `The port on which machine agents serve agent metrics over https (0 disables it)`,
+	},
+	AgentMetricsUsername: {
+		Type:        environschema.Tstring,
+		Description: `The username required to read agent metrics`,
+	},
+	AgentMetricsPassword: {
+		Type:        environschema.Tstring,
+		Description: `The password required to read agent metrics`,
+	},
 }
@@ -343,6 +343,73 @@ var newConfigTests = []struct {
 		controller.AgentRateLimitRate: "4h",
 	},
 	expectError: `agent-ratelimit-rate must be between 0..1m`,
+}, {
+	about: "backup S3 endpoint OK",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "https://minio.example.com:9000",
+	},
+}, {
+	about: "backup S3 endpoint bad scheme",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "ftp://minio.example.com",
+	},
+	expectError: `backup S3 endpoint "ftp://minio.example.com" must use http or https`,
+}, {
+	about: "backup local root OK",
+	config: controller.Config{
+		controller.BackupLocalRoot: "/mnt/juju-backups",
+	},
+}, {
+	about: "backup local root relative",
+	config: controller.Config{
+		controller.BackupLocalRoot: "backups",
+	},
+	expectError: `backup local root "backups" \(must be an absolute path other than /\) not valid`,
+}, {
+	about: "backup local root is /",
+	config: controller.Config{
+		controller.BackupLocalRoot: "/",
+	},
+	expectError: `backup local root "/" \(must be an absolute path other than /\) not valid`,
+}, {
+	about: "backup schedule OK",
+	config: controller.Config{
+		controller.BackupSchedule: "30 2 * * 0",
+	},
+}, {
+	about: "backup schedule not valid",
+	config: controller.Config{
+		controller.BackupSchedule: "every day",
+	},
+	expectError: `invalid backup schedule "every day": .*`,
+}, {
+	about: "backup-keep-daily not valid",
+	config: controller.Config{
+		controller.BackupKeepDaily: -1,
+	},
+	expectError: `negative backup-keep-daily not valid`,
+}, {
+	about: "agent metrics OK",
+	config: controller.Config{
+		controller.AgentMetricsPort:     17072,
+		controller.AgentMetricsUsername: "prometheus",
+		controller.AgentMetricsPassword: "s3cret",
+	},
+}, {
+	about: "agent metrics port out of range",
+	config: controller.Config{
+		controller.AgentMetricsPort:     70000,
+		controller.AgentMetricsUsername: "prometheus",
+		controller.AgentMetricsPassword: "s3cret",
+	},
+	expectError: `agent metrics port 70000 not valid`,
+}, {
+	about: "agent metrics without credentials",
+	config: controller.Config{
+		controller.AgentMetricsPort:     17072,
+		controller.AgentMetricsUsername: "prometheus",
+	},
+	expectError: `agent metrics port without username and password not valid`,
 }}
 
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
@@ -475,6 +542,7 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogForward(), gc.Equals, false)
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -487,6 +555,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
+			"audit-log-forward":         true,
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -499,6 +568,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 		"King.Gizzard",
 		"ReadOnlyMethods",
 	))
+	c.Assert(cfg.AuditLogForward(), gc.Equals, true)
 }
 
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
@@ -653,6 +723,72 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
 
+func (s *ConfigSuite) TestBackupS3Settings(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupS3Endpoint:  "http://10.0.0.1:9000",
+			controller.BackupS3Region:    "eu-west-2",
+			controller.BackupS3AccessKey: "access",
+			controller.BackupS3SecretKey: "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupS3Endpoint(), gc.Equals, "http://10.0.0.1:9000")
+	c.Check(cfg.BackupS3Region(), gc.Equals, "eu-west-2")
+	c.Check(cfg.BackupS3AccessKey(), gc.Equals, "access")
+	c.Check(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+}
+
+func (s *ConfigSuite) TestBackupLocalRoot(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupLocalRoot(), gc.Equals, controller.DefaultBackupLocalRoot)
+
+	cfg[controller.BackupLocalRoot] = "/mnt/juju-backups"
+	c.Check(cfg.BackupLocalRoot(), gc.Equals, "/mnt/juju-backups")
+}
+
+func (s *ConfigSuite) TestBackupScheduleSettings(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupSchedule:            "0 3 * * *",
+			controller.BackupScheduleDestination: "s3://juju-backups",
+			controller.BackupKeepLast:            "3",
+			controller.BackupKeepWeekly:          4,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "0 3 * * *")
+	c.Check(cfg.BackupScheduleDestination(), gc.Equals, "s3://juju-backups")
+	c.Check(cfg.BackupKeepLast(), gc.Equals, 3)
+	c.Check(cfg.BackupKeepDaily(), gc.Equals, 0)
+	c.Check(cfg.BackupKeepWeekly(), gc.Equals, 4)
+}
+
+func (s *ConfigSuite) TestAgentMetricsSettings(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AgentMetricsPort(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.AgentMetricsPort:     "17072",
+			controller.AgentMetricsUsername: "prometheus",
+			controller.AgentMetricsPassword: "s3cret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AgentMetricsPort(), gc.Equals, 17072)
+	c.Check(cfg.AgentMetricsUsername(), gc.Equals, "prometheus")
+	c.Check(cfg.AgentMetricsPassword(), gc.Equals, "s3cret")
+}
+
 func (s *ConfigSuite) TestMaxDebugLogDuration(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
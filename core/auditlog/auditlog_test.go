@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/core/paths"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -142,6 +143,40 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestMultiLog(c *gc.C) {
+	var log1, log2 fakeLog
+	log := auditlog.NewMultiLog(&log1, &log2)
+	err := log.AddConversation(auditlog.Conversation{ConversationID: "0123456789abcdef"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(auditlog.Request{ConversationID: "0123456789abcdef", RequestID: 25})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddResponse(auditlog.ResponseErrors{ConversationID: "0123456789abcdef", RequestID: 25})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, l := range []*fakeLog{&log1, &log2} {
+		l.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+		l.stub.CheckCall(c, 1, "AddRequest", auditlog.Request{ConversationID: "0123456789abcdef", RequestID: 25})
+	}
+}
+
+func (s *AuditLogSuite) TestMultiLogError(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(errors.New("disk full"))
+	log := auditlog.NewMultiLog(&log1, &log2)
+	err := log.AddConversation(auditlog.Conversation{ConversationID: "0123456789abcdef"})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+	log1.stub.CheckCallNames(c, "AddConversation")
+	log2.stub.CheckNoCalls(c)
+
+	// All logs are closed, even if one fails.
+	log1.stub.SetErrors(errors.New("bad file descriptor"))
+	err = log.Close()
+	c.Assert(err, gc.ErrorMatches, "bad file descriptor")
+	log2.stub.CheckCallNames(c, "Close")
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
@@ -31,6 +31,15 @@ type Config struct {
 
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
+
+	// Forward determines whether entries should also be written to
+	// ForwardTarget, from where they are forwarded along with the
+	// controller's logs.
+	Forward bool
+
+	// ForwardTarget is the AuditLog entries are written to when
+	// forwarding is enabled.
+	ForwardTarget AuditLog
 }
 
 // Validate checks the audit logging configuration.
@@ -38,5 +47,8 @@ func (cfg Config) Validate() error {
 	if cfg.Enabled && cfg.Target == nil {
 		return errors.NewNotValid(nil, "logging enabled but no target provided")
 	}
+	if cfg.Enabled && cfg.Forward && cfg.ForwardTarget == nil {
+		return errors.NewNotValid(nil, "forwarding enabled but no forward target provided")
+	}
 	return nil
 }
@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+)
+
+type multiLog struct {
+	logs []AuditLog
+}
+
+// NewMultiLog returns an AuditLog which writes records to each of
+// the given logs in turn, stopping at the first error.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	return &multiLog{logs: logs}
+}
+
+// AddConversation implements AuditLog.
+func (m *multiLog) AddConversation(c Conversation) error {
+	for _, log := range m.logs {
+		if err := log.AddConversation(c); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (m *multiLog) AddRequest(r Request) error {
+	for _, log := range m.logs {
+		if err := log.AddRequest(r); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (m *multiLog) AddResponse(r ResponseErrors) error {
+	for _, log := range m.logs {
+		if err := log.AddResponse(r); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Close implements AuditLog. All of the logs are closed, and the
+// first error encountered is returned.
+func (m *multiLog) Close() error {
+	var result error
+	for _, log := range m.logs {
+		if err := log.Close(); err != nil && result == nil {
+			result = errors.Trace(err)
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"time"
+)
+
+// Entry is a single API request recorded in the audit log, along with
+// the conversation it was made in and the errors in its response.
+type Entry struct {
+	// ControllerID is the ID of the controller machine that handled
+	// the request.
+	ControllerID string
+
+	Conversation Conversation
+	Request      Request
+
+	// Errors holds the errors in the response to the request, if any.
+	Errors []*Error
+}
+
+// Query holds the criteria used to select entries from the audit
+// log. Empty fields match all entries.
+type Query struct {
+	// Who selects entries for requests made by the given user.
+	Who string
+
+	// ModelUUID selects entries for requests made against the given
+	// model.
+	ModelUUID string
+
+	// Facade and Method select entries for requests to the given
+	// facade and method.
+	Facade string
+	Method string
+
+	// After and Before select entries for requests made at or after,
+	// and before, the given times.
+	After  time.Time
+	Before time.Time
+
+	// ErrorsOnly selects only entries whose responses had errors.
+	ErrorsOnly bool
+
+	// Limit is the maximum number of entries to return. If there are
+	// more matching entries, the most recent ones are returned.
+	// Zero means no limit.
+	Limit int
+}
@@ -32,6 +32,10 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// Offline is true if the model has already been imported into the
+	// target controller from an export archive.
+	Offline bool
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -51,6 +55,51 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// Secrets holds the model's secrets. They aren't included in
+	// the model description as their values are decrypted, to be
+	// encrypted again with the target controller's key.
+	Secrets []SerializedModelSecret
+
+	// ActionSchedules holds the model's action schedules and the
+	// history of their runs, which aren't included in the model
+	// description.
+	ActionSchedules []SerializedActionSchedule
+}
+
+// SerializedModelSecret holds a secret and its decrypted value.
+type SerializedModelSecret struct {
+	ID          string
+	Owner       string
+	Description string
+	Revision    int
+	CreateTime  time.Time
+	UpdateTime  time.Time
+	ExpireTime  time.Time
+	Consumers   []string
+	Value       map[string]string
+}
+
+// SerializedActionSchedule holds an action schedule and the history of
+// its runs.
+type SerializedActionSchedule struct {
+	ID         string
+	Schedule   string
+	Receivers  []string
+	ActionName string
+	Parameters map[string]interface{}
+	Created    time.Time
+	CreatedBy  string
+	Runs       []SerializedActionScheduleRun
+}
+
+// SerializedActionScheduleRun holds a single run of an action schedule.
+type SerializedActionScheduleRun struct {
+	Scheduled   time.Time
+	Recorded    time.Time
+	Status      string
+	OperationID string
+	Message     string
 }
 
 // SerializedModelResource defines the resource revisions for a
@@ -61,6 +110,45 @@ type SerializedModelResource struct {
 	UnitRevisions       map[string]resource.Resource
 }
 
+// TransferVolume holds the number of bytes of each kind of data sent
+// to the target controller while a migration was in a phase.
+type TransferVolume struct {
+	// Model is the size of the serialized model description.
+	Model int64
+
+	// Charms is the total size of the charm archives uploaded.
+	Charms int64
+
+	// Tools is the total size of the agent binaries uploaded.
+	Tools int64
+
+	// Resources is the total size of the resources uploaded.
+	Resources int64
+}
+
+// Total returns the number of bytes sent across all kinds of data.
+func (v TransferVolume) Total() int64 {
+	return v.Model + v.Charms + v.Tools + v.Resources
+}
+
+// PhaseProgress records a migration's progress through one of its
+// phases.
+type PhaseProgress struct {
+	// Phase is the migration phase.
+	Phase Phase
+
+	// Started is the time the migration entered the phase.
+	Started time.Time
+
+	// Ended is the time the migration left the phase. It is zero
+	// while the migration remains in the phase.
+	Ended time.Time
+
+	// Sent holds the data sent to the target controller during the
+	// phase.
+	Sent TransferVolume
+}
+
 // ModelInfo is used to report basic details about a model.
 type ModelInfo struct {
 	UUID                   string
@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package userhooks holds the user hooks which the cloudinit-user-hooks
+// model config attribute runs on new machines. It has no dependencies on
+// the rest of Juju, so it can be used both when validating model config
+// and by the agents which run the hooks.
+package userhooks
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Phase identifies the point in the life of a new machine at
+// which a user hook is run.
+type Phase string
+
+const (
+	// PreAgentInstall hooks run once the machine's packages have been
+	// set up, before the Juju agent binaries are downloaded.
+	PreAgentInstall Phase = "pre-agent-install"
+
+	// PostAgentInstall hooks run once the machine agent has been
+	// installed and started.
+	PostAgentInstall Phase = "post-agent-install"
+
+	// PostFirstHook hooks are written to the machine by cloud-init and
+	// run by the unit agent once the first charm hook on the machine
+	// has completed successfully.
+	PostFirstHook Phase = "post-first-hook"
+)
+
+// Phases holds all valid user hook phases, in the order in which
+// they are run.
+var Phases = []Phase{
+	PreAgentInstall,
+	PostAgentInstall,
+	PostFirstHook,
+}
+
+// hooksDir is the directory, relative to the agent data directory,
+// into which rendered user hooks are written.
+const hooksDir = "init/user-hooks"
+
+// defaultInterpreter is prepended to user hooks which do not
+// name their own interpreter.
+const defaultInterpreter = "#!/bin/bash\nset -e\n"
+
+// Params holds the values which may be referred to by a user
+// hook template.
+type Params struct {
+	MachineId      string
+	Series         string
+	ModelUUID      string
+	ControllerUUID string
+	AgentVersion   string
+	DataDir        string
+	LogDir         string
+}
+
+// Path returns the path of the file holding the rendered user
+// hook for the given phase on a machine with the given agent data
+// directory.
+func Path(dataDir string, phase Phase) string {
+	return path.Join(dataDir, hooksDir, string(phase))
+}
+
+// Parse parses the YAML value of the cloudinit-user-hooks model
+// config attribute, which maps user hook phases to templates, and checks
+// that each template is valid.
+func Parse(raw string) (map[string]string, error) {
+	hooks := make(map[string]string)
+	if err := yaml.Unmarshal([]byte(raw), &hooks); err != nil {
+		return nil, errors.Annotate(err, "must be a YAML map of phase to script")
+	}
+	phases := make([]string, 0, len(hooks))
+	for phase := range hooks {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		if !validPhase(Phase(phase)) {
+			return nil, errors.NotValidf("user hook phase %q", phase)
+		}
+		// Rendering against empty parameters catches references to
+		// unknown fields as well as syntax errors.
+		if _, err := Render(hooks[phase], Params{}); err != nil {
+			return nil, errors.Annotatef(err, "%s", phase)
+		}
+	}
+	return hooks, nil
+}
+
+// Render expands the given user hook template with the supplied
+// parameters. Hooks which do not start with a "#!" line are run by bash,
+// and stop at the first failing command.
+func Render(hook string, params Params) (string, error) {
+	tmpl, err := template.New("hook").Option("missingkey=error").Parse(hook)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", errors.Trace(err)
+	}
+	script := buf.String()
+	if !strings.HasPrefix(script, "#!") {
+		script = defaultInterpreter + script
+	}
+	return script, nil
+}
+
+func validPhase(phase Phase) bool {
+	for _, valid := range Phases {
+		if phase == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package userhooks_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/userhooks"
+)
+
+type userHooksSuite struct{}
+
+var _ = gc.Suite(&userHooksSuite{})
+
+func (*userHooksSuite) TestParse(c *gc.C) {
+	hooks, err := userhooks.Parse(`
+pre-agent-install: |
+  apt-get install -y security-agent
+post-first-hook: |
+  #!/usr/bin/python3
+  print("{{.MachineId}}")
+`[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hooks, jc.DeepEquals, map[string]string{
+		"pre-agent-install": "apt-get install -y security-agent\n",
+		"post-first-hook":   "#!/usr/bin/python3\nprint(\"{{.MachineId}}\")\n",
+	})
+}
+
+func (*userHooksSuite) TestParseInvalidYAML(c *gc.C) {
+	_, err := userhooks.Parse("[not a map]")
+	c.Assert(err, gc.ErrorMatches, "(?s)must be a YAML map of phase to script: .*")
+}
+
+func (*userHooksSuite) TestParseUnknownPhase(c *gc.C) {
+	_, err := userhooks.Parse("post-reboot: echo hi\n")
+	c.Assert(err, gc.ErrorMatches, `user hook phase "post-reboot" not valid`)
+}
+
+func (*userHooksSuite) TestParseBadTemplate(c *gc.C) {
+	_, err := userhooks.Parse("post-agent-install: echo {{.MachineId\n")
+	c.Assert(err, gc.ErrorMatches, `post-agent-install: template: hook:1: unclosed action.*`)
+}
+
+func (*userHooksSuite) TestParseUnknownField(c *gc.C) {
+	_, err := userhooks.Parse("post-agent-install: echo {{.Hostname}}\n")
+	c.Assert(err, gc.ErrorMatches, `post-agent-install: .*can't evaluate field Hostname.*`)
+}
+
+func (*userHooksSuite) TestRender(c *gc.C) {
+	script, err := userhooks.Render("echo {{.MachineId}} {{.Series}}\n", userhooks.Params{
+		MachineId: "0/lxd/1",
+		Series:    "focal",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "#!/bin/bash\nset -e\necho 0/lxd/1 focal\n")
+}
+
+func (*userHooksSuite) TestRenderInterpreter(c *gc.C) {
+	script, err := userhooks.Render("#!/bin/sh\necho {{.ModelUUID}}\n", userhooks.Params{
+		ModelUUID: "deadbeef",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "#!/bin/sh\necho deadbeef\n")
+}
+
+func (*userHooksSuite) TestPath(c *gc.C) {
+	c.Assert(userhooks.Path("/var/lib/juju", userhooks.PostFirstHook), gc.Equals,
+		"/var/lib/juju/init/user-hooks/post-first-hook")
+}
@@ -23,8 +23,10 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/userhooks"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -189,6 +191,31 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogForwardSink selects the kind of log sink that records are
+	// forwarded to: "syslog" (the default), "http", "loki" or
+	// "elasticsearch".
+	LogForwardSink = "logforward-sink"
+
+	// LogFwdHTTPURL sets the base URL of the HTTP log store used by the
+	// http, loki and elasticsearch log sinks.
+	LogFwdHTTPURL = "logforward-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log store's server certificate.
+	LogFwdHTTPCACert = "logforward-ca-cert"
+
+	// LogFwdHTTPUsername sets the username used to authenticate with
+	// the HTTP log store.
+	LogFwdHTTPUsername = "logforward-username"
+
+	// LogFwdHTTPPassword sets the password used to authenticate with
+	// the HTTP log store.
+	LogFwdHTTPPassword = "logforward-password"
+
+	// LogFwdHTTPIndex sets the Elasticsearch index that forwarded logs
+	// are written to.
+	LogFwdHTTPIndex = "logforward-index"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -232,6 +259,12 @@ const (
 	// provisioning machines.
 	CloudInitUserDataKey = "cloudinit-userdata"
 
+	// CloudInitUserHooksKey is the key to specify scripts, keyed by the
+	// phase of machine provisioning at which they run, which are added
+	// to the cloud-config data produced by Juju when provisioning
+	// machines.
+	CloudInitUserHooksKey = "cloudinit-user-hooks"
+
 	// BackupDirKey specifies the backup working directory.
 	BackupDirKey = "backup-dir"
 
@@ -244,6 +277,17 @@ const (
 	// endpoint bindings.
 	DefaultSpace = "default-space"
 
+	// EnableNetworkPoliciesKey is the key for whether ingress to the
+	// workloads of applications on a kubernetes model is restricted
+	// to the applications they are related to.
+	EnableNetworkPoliciesKey = "enable-network-policies"
+
+	// HookTimeoutsKey is the key to specify the maximum time hooks of
+	// the given kinds may run for before they are killed and marked as
+	// failed. The value is a comma separated list of <hook>=<duration>,
+	// for example "install=1h,config-changed=10m".
+	HookTimeoutsKey = "hook-timeouts"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -458,6 +502,7 @@ var defaultConfigValues = map[string]interface{}{
 	EgressSubnets:                 "",
 	FanConfig:                     "",
 	CloudInitUserDataKey:          "",
+	CloudInitUserHooksKey:         "",
 	ContainerInheritPropertiesKey: "",
 	BackupDirKey:                  "",
 
@@ -602,12 +647,24 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if sink := cfg.LogFwdSink(); sink != LogForwardSinkSyslog {
+		if err := logfwdhttp.Format(sink).Validate(); err != nil {
+			return errors.Annotate(err, "invalid log forwarding sink")
+		}
+	}
+
 	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
 		if err := lfCfg.Validate(); err != nil {
 			return errors.Annotate(err, "invalid syslog forwarding config")
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdHTTP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -719,6 +776,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if raw, ok := cfg.defined[CloudInitUserHooksKey].(string); ok && raw != "" {
+		if _, err := userhooks.Parse(raw); err != nil {
+			return errors.Annotate(err, "cloudinit-user-hooks")
+		}
+	}
+
 	if raw, ok := cfg.defined[ContainerInheritPropertiesKey].(string); ok && raw != "" {
 		rawProperties := strings.Split(raw, ",")
 		propertySet := set.NewStrings()
@@ -733,6 +796,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if raw, ok := cfg.defined[HookTimeoutsKey].(string); ok && raw != "" {
+		if _, err := ParseHookTimeouts(raw); err != nil {
+			return errors.Annotate(err, HookTimeoutsKey)
+		}
+	}
+
 	if err := cfg.validateDefaultSpace(); err != nil {
 		return err
 	}
@@ -1075,14 +1144,26 @@ func (c *Config) SnapStoreProxyURL() string {
 	return c.asString(SnapStoreProxyURLKey)
 }
 
-// LogFwdSyslog returns the syslog forwarding config.
+// LogForwardSinkSyslog is the default log forwarding sink.
+const LogForwardSinkSyslog = "syslog"
+
+// LogFwdSink returns the kind of log sink that logs are forwarded to.
+func (c *Config) LogFwdSink() string {
+	if sink := c.asString(LogForwardSink); sink != "" {
+		return sink
+	}
+	return LogForwardSinkSyslog
+}
+
+// LogFwdSyslog returns the syslog forwarding config. Forwarding is only
+// enabled if syslog is the selected log forwarding sink.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	partial := false
 	var lfCfg syslog.RawConfig
 
 	if s, ok := c.defined[LogForwardEnabled]; ok {
 		partial = true
-		lfCfg.Enabled = s.(bool)
+		lfCfg.Enabled = s.(bool) && c.LogFwdSink() == LogForwardSinkSyslog
 	}
 
 	if s, ok := c.defined[LogFwdSyslogHost]; ok && s != "" {
@@ -1111,6 +1192,28 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the config for forwarding logs to an HTTP log
+// store. Forwarding is only enabled if one of the HTTP based sinks
+// is selected.
+func (c *Config) LogFwdHTTP() (*logfwdhttp.RawConfig, bool) {
+	sink := c.LogFwdSink()
+	if sink == LogForwardSinkSyslog {
+		return nil, false
+	}
+	lfCfg := logfwdhttp.RawConfig{
+		Format:   logfwdhttp.Format(sink),
+		URL:      c.asString(LogFwdHTTPURL),
+		CACert:   c.asString(LogFwdHTTPCACert),
+		Username: c.asString(LogFwdHTTPUsername),
+		Password: c.asString(LogFwdHTTPPassword),
+		Index:    c.asString(LogFwdHTTPIndex),
+	}
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		lfCfg.Enabled = s.(bool)
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1222,6 +1325,48 @@ func (c *Config) TransmitVendorMetrics() bool {
 	}
 }
 
+// EnableNetworkPolicies returns whether ingress to the workloads of
+// applications on a kubernetes model is restricted to the applications
+// they are related to. By default this is false.
+func (c *Config) EnableNetworkPolicies() bool {
+	val, _ := c.defined[EnableNetworkPoliciesKey].(bool)
+	return val
+}
+
+// HookTimeouts returns the maximum time hooks of each kind may run for,
+// keyed by hook kind. Hooks of kinds not present are not timed out.
+func (c *Config) HookTimeouts() map[string]time.Duration {
+	// Invalid values are rejected when the config is validated.
+	timeouts, _ := ParseHookTimeouts(c.asString(HookTimeoutsKey))
+	return timeouts
+}
+
+// ParseHookTimeouts parses a comma separated list of <hook>=<duration>
+// hook timeouts, as used by the hook-timeouts model config setting.
+func ParseHookTimeouts(raw string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, errors.NotValidf("hook timeout %q", entry)
+		}
+		kind := strings.TrimSpace(parts[0])
+		timeout, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.NotValidf("hook timeout %q", entry)
+		}
+		if timeout <= 0 {
+			return nil, errors.NotValidf("non-positive timeout for hook %q", kind)
+		}
+		timeouts[kind] = timeout
+	}
+	return timeouts, nil
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -1401,6 +1546,18 @@ func (c *Config) CloudInitUserData() map[string]interface{} {
 	return conformingUserDataMap
 }
 
+// CloudInitUserHooks returns the user hook templates specified by the
+// user, keyed by the phase of machine provisioning at which they run.
+func (c *Config) CloudInitUserHooks() map[string]string {
+	raw := c.asString(CloudInitUserHooksKey)
+	if raw == "" {
+		return nil
+	}
+	// The raw data has already passed Validate()
+	hooks, _ := userhooks.Parse(raw)
+	return hooks
+}
+
 // ContainerInheritProperties returns a copy of the raw user data keys
 // that were specified by the user.
 func (c *Config) ContainerInheritProperties() string {
@@ -1477,6 +1634,12 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogForwardSink:         schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPUsername:     schema.Omit,
+	LogFwdHTTPPassword:     schema.Omit,
+	LogFwdHTTPIndex:        schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1533,9 +1696,12 @@ var alwaysOptional = schema.Defaults{
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
+	CloudInitUserHooksKey:         schema.Omit,
 	ContainerInheritPropertiesKey: schema.Omit,
 	BackupDirKey:                  schema.Omit,
 	DefaultSpace:                  schema.Omit,
+	EnableNetworkPoliciesKey:      schema.Omit,
+	HookTimeoutsKey:               schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1925,6 +2091,36 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardSink: {
+		Description: `The kind of log sink to forward logs to, one of "syslog", "http" (JSON lines), "loki" or "elasticsearch". Defaults to "syslog".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The base URL of the log store for the http, loki and elasticsearch log sinks.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the log store server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPUsername: {
+		Description: `The username used to authenticate with the log store.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPPassword: {
+		Description: `The password used to authenticate with the log store.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPIndex: {
+		Description: `The Elasticsearch index that forwarded logs are written to.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -2020,6 +2216,11 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	CloudInitUserHooksKey: {
+		Description: "Scripts (in yaml format, keyed by pre-agent-install, post-agent-install or post-first-hook) to be run on new Linux machines created in this model",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	ContainerInheritPropertiesKey: {
 		Description: "List of properties to be copied from the host machine to new containers created in this model (comma-separated)",
 		Type:        environschema.Tstring,
@@ -2035,4 +2236,14 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	EnableNetworkPoliciesKey: {
+		Description: "Whether ingress to kubernetes application workloads is only allowed from related applications",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	HookTimeoutsKey: {
+		Description: "Maximum time hooks of each kind may run for before they are killed, eg install=1h,config-changed=10m (comma-separated)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -550,6 +550,33 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid loki log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-sink":     "loki",
+			"logforward-url":      "https://loki.example.com:3100",
+			"logforward-ca-cert":  testing.CACert,
+			"logforward-username": "juju",
+			"logforward-password": "secret",
+		}),
+	}, {
+		about:       "Invalid log forwarding sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-sink": "carrier-pigeon",
+		}),
+		err: `invalid log forwarding sink: log forwarding format "carrier-pigeon" not valid`,
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sink":    "elasticsearch",
+			"logforward-url":     "es.example.com",
+		}),
+		err: `invalid HTTP log forwarding config: URL "es.example.com" \(scheme must be http or https\) not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -563,6 +590,33 @@ var configTests = []configTest{
 			"container-inherit-properties": "apt-security, write_files,users,apt-sources",
 		}),
 		err: `container-inherit-properties: users, write_files not allowed`,
+	}, {
+		about:       "Valid hook-timeouts",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeouts": "install=1h, config-changed=10m",
+		}),
+	}, {
+		about:       "Invalid hook-timeouts entry",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeouts": "install=1h,config-changed",
+		}),
+		err: `hook-timeouts: hook timeout "config-changed" not valid`,
+	}, {
+		about:       "Invalid hook-timeouts duration",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeouts": "install=forever",
+		}),
+		err: `hook-timeouts: hook timeout "install=forever" not valid`,
+	}, {
+		about:       "Non-positive hook-timeouts duration",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeouts": "install=0s",
+		}),
+		err: `hook-timeouts: non-positive timeout for hook "install" not valid`,
 	}, {
 		about:       "String as valid value",
 		useDefaults: config.UseDefaults,
@@ -651,10 +705,20 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	keys, _ := test.attrs["authorized-keys"].(string)
 	c.Assert(cfg.AuthorizedKeys(), gc.Equals, keys)
 
+	if v, ok := test.attrs["logforward-sink"].(string); ok {
+		c.Assert(cfg.LogFwdSink(), gc.Equals, v)
+		httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(string(httpCfg.Format), gc.Equals, v)
+		c.Assert(httpCfg.URL, gc.Equals, test.attrs["logforward-url"])
+	} else {
+		c.Assert(cfg.LogFwdSink(), gc.Equals, config.LogForwardSinkSyslog)
+	}
+
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		c.Assert(hasLogCfg, jc.IsTrue)
-		c.Assert(lfCfg.Enabled, gc.Equals, v)
+		c.Assert(lfCfg.Enabled, gc.Equals, v && cfg.LogFwdSink() == config.LogForwardSinkSyslog)
 	}
 	if v, ok := test.attrs["syslog-ca-cert"].(string); v != "" {
 		c.Assert(hasLogCfg, jc.IsTrue)
@@ -1119,6 +1183,31 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestEnableNetworkPoliciesDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.EnableNetworkPolicies(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestEnableNetworkPolicies(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"enable-network-policies": "true"})
+	c.Assert(config.EnableNetworkPolicies(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestHookTimeoutsDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.HookTimeouts(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestHookTimeouts(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"hook-timeouts": "install=1h,config-changed=10m"})
+	c.Assert(config.HookTimeouts(), jc.DeepEquals, map[string]time.Duration{
+		"install":        time.Hour,
+		"config-changed": 10 * time.Minute,
+	})
+}
+
 func (s *ConfigSuite) TestNoBothProxy(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{
 		"http-proxy":  "http://user@10.0.0.1",
@@ -1342,6 +1431,44 @@ func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	)
 }
 
+func (s *ConfigSuite) TestCloudInitUserHooks(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.CloudInitUserHooksKey: "pre-agent-install: apt-get install -y security-agent\n",
+	})
+	c.Assert(cfg.CloudInitUserHooks(), gc.DeepEquals, map[string]string{
+		"pre-agent-install": "apt-get install -y security-agent",
+	})
+}
+
+func (s *ConfigSuite) TestCloudInitUserHooksUnset(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.CloudInitUserHooks(), gc.IsNil)
+}
+
+func (s *ConfigSuite) TestValidateCloudInitUserHooks(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "post-reboot: echo hi\n",
+		err:   `cloudinit-user-hooks: user hook phase "post-reboot" not valid`,
+	}, {
+		value: "post-agent-install: echo {{.Hostname}}\n",
+		err:   `cloudinit-user-hooks: post-agent-install: .*can't evaluate field Hostname.*`,
+	}, {
+		value: "- echo hi\n",
+		err:   `(?s)cloudinit-user-hooks: must be a YAML map of phase to script: .*`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := config.New(config.UseDefaults, testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":                       testing.ModelTag.Id(),
+			config.CloudInitUserHooksKey: test.value,
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestContainerInheritProperties(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"container-inherit-properties": "ca-certs,apt-primary",
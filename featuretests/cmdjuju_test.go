@@ -173,12 +173,26 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-cert-manager-cluster-issuer:
+    description: the cert-manager cluster issuer used to obtain the TLS certificate
+    source: unset
+    type: string
+  kubernetes-ingress-cert-manager-issuer:
+    description: the cert-manager issuer, in the model namespace, used to obtain the
+      TLS certificate
+    source: unset
+    type: string
   kubernetes-ingress-class:
     default: nginx
     description: the class of the ingress controller to be used by the ingress resource
     source: default
     type: string
     value: nginx
+  kubernetes-ingress-rules:
+    description: a space separated list of additional host[/path] rules for the ingress
+      resource
+    source: unset
+    type: string
   kubernetes-ingress-ssl-passthrough:
     default: false
     description: whether to passthrough SSL traffic to the ingress controller
@@ -191,6 +205,11 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-secret:
+    description: the name of the secret holding the TLS certificate for the ingress
+      hosts
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
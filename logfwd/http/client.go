@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// maxBatchSize is the maximum number of records sent in a
+	// single request.
+	maxBatchSize = 500
+
+	// maxResponseSize limits how much of a response body is read.
+	maxResponseSize = 1 << 20
+
+	requestTimeout = 30 * time.Second
+)
+
+// RetryStrategy defines how failed requests are retried. Requests
+// that fail because of a connection error, a server error or
+// throttling are retried with exponential backoff; other failures
+// are returned immediately.
+type RetryStrategy struct {
+	Attempts int
+	Delay    time.Duration
+	MaxDelay time.Duration
+}
+
+// DefaultRetryStrategy is the retry strategy used by Open. Once it is
+// exhausted the error is returned to the log forwarder, which will be
+// restarted and resume from the last record that was sent.
+var DefaultRetryStrategy = RetryStrategy{
+	Attempts: 6,
+	Delay:    time.Second,
+	MaxDelay: 30 * time.Second,
+}
+
+// Doer sends HTTP requests.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to an HTTP log store.
+type Client struct {
+	cfg   RawConfig
+	doer  Doer
+	clock clock.Clock
+	retry RetryStrategy
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// Open returns a new client for the log store described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	doer := &http.Client{
+		Transport: utils.NewHttpTLSTransport(tlsCfg),
+		Timeout:   requestTimeout,
+	}
+	client, err := OpenForDoer(cfg, doer, clock.WallClock, DefaultRetryStrategy)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client for the log store described by the
+// config, which sends requests using the given Doer.
+func OpenForDoer(cfg RawConfig, doer Doer, clock clock.Clock, strategy RetryStrategy) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		cfg:   cfg,
+		doer:  doer,
+		clock: clock,
+		retry: strategy,
+		stop:  make(chan struct{}),
+	}, nil
+}
+
+// Close stops any pending retries. The client cannot be used after
+// it has been closed.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// Send sends the records to the log store, in batches of at most
+// maxBatchSize records.
+func (c *Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := len(records)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		req, err := encode(c.cfg, records[:n])
+		if err != nil {
+			return errors.Annotate(err, "encoding log records")
+		}
+		if err := c.sendWithRetry(req); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (c *Client) sendWithRetry(req request) error {
+	var lastErr error
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			lastErr = c.send(req)
+			return lastErr
+		},
+		IsFatalError: func(err error) bool {
+			_, ok := errors.Cause(err).(*temporaryError)
+			return !ok
+		},
+		Attempts:    c.retry.Attempts,
+		Delay:       c.retry.Delay,
+		MaxDelay:    c.retry.MaxDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       c.clock,
+		Stop:        c.stop,
+	})
+	if retry.IsAttemptsExceeded(err) || retry.IsRetryStopped(err) {
+		return errors.Annotate(lastErr, "sending log records failed after retrying")
+	}
+	return errors.Annotate(err, "sending log records")
+}
+
+func (c *Client) send(req request) error {
+	url := strings.TrimSuffix(c.cfg.URL, "/") + req.path
+	if req.path == "" {
+		url = c.cfg.URL
+	}
+	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(req.body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", req.contentType)
+	if c.cfg.Username != "" {
+		httpReq.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+
+	resp, err := c.doer.Do(httpReq)
+	if err != nil {
+		// Connection level failures are worth retrying.
+		return &temporaryError{err}
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return &temporaryError{errors.Annotate(err, "reading response")}
+	}
+
+	switch {
+	case resp.StatusCode >= 500,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusRequestTimeout:
+		return &temporaryError{errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))}
+	case resp.StatusCode >= 300:
+		return errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	if c.cfg.Format == FormatElasticsearch {
+		return errors.Trace(checkElasticsearchResponse(body))
+	}
+	return nil
+}
+
+// temporaryError wraps errors for requests that may succeed if they
+// are retried.
+type temporaryError struct {
+	error
+}
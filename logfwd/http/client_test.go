@@ -0,0 +1,258 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	doer *stubDoer
+	rec  logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.doer = &stubDoer{stub: &testing.Stub{}}
+	s.rec = logfwd.Record{
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.8.0"),
+			},
+		},
+		ID:        10,
+		Timestamp: time.Date(2020, 5, 4, 3, 2, 1, 0, time.UTC),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "hook failed",
+	}
+}
+
+func (s *ClientSuite) open(c *gc.C, format logfwdhttp.Format) *logfwdhttp.Client {
+	cfg := logfwdhttp.RawConfig{
+		Enabled:  true,
+		Format:   format,
+		URL:      "https://logs.example.com/",
+		Username: "juju",
+		Password: "secret",
+	}
+	client, err := logfwdhttp.OpenForDoer(cfg, s.doer, clock.WallClock, logfwdhttp.RetryStrategy{
+		Attempts: 3,
+		Delay:    time.Millisecond,
+		MaxDelay: time.Millisecond,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestSendJSONLines(c *gc.C) {
+	client := s.open(c, logfwdhttp.FormatJSONLines)
+
+	err := client.Send([]logfwd.Record{s.rec, s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.doer.stub.CheckCallNames(c, "Do")
+	req := s.doer.requests[0]
+	c.Check(req.url, gc.Equals, "https://logs.example.com/")
+	c.Check(req.contentType, gc.Equals, "application/x-ndjson")
+	c.Check(req.auth, gc.Equals, "juju:secret")
+
+	lines := strings.Split(strings.TrimSpace(req.body), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	var got map[string]interface{}
+	err = json.Unmarshal([]byte(lines[0]), &got)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got, jc.DeepEquals, map[string]interface{}{
+		"id":              float64(10),
+		"timestamp":       "2020-05-04T03:02:01Z",
+		"controller-uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":        "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin-type":     "machine",
+		"origin-name":     "99",
+		"software":        "jujud-machine-agent",
+		"version":         "2.8.0",
+		"level":           "ERROR",
+		"module":          "juju.worker.uniter",
+		"location":        "uniter.go:42",
+		"message":         "hook failed",
+	})
+}
+
+func (s *ClientSuite) TestSendLoki(c *gc.C) {
+	client := s.open(c, logfwdhttp.FormatLoki)
+	other := s.rec
+	other.Level = loggo.INFO
+
+	err := client.Send([]logfwd.Record{s.rec, other, s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.doer.stub.CheckCallNames(c, "Do")
+	req := s.doer.requests[0]
+	c.Check(req.url, gc.Equals, "https://logs.example.com/loki/api/v1/push")
+	c.Check(req.contentType, gc.Equals, "application/json")
+
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal([]byte(req.body), &push)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(push.Streams, gc.HasLen, 2)
+	c.Check(push.Streams[0].Stream, jc.DeepEquals, map[string]string{
+		"juju_controller":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"juju_model":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"juju_origin_type": "machine",
+		"juju_origin":      "99",
+		"level":            "error",
+	})
+	c.Check(push.Streams[0].Values, gc.HasLen, 2)
+	c.Check(push.Streams[0].Values[0][0], gc.Equals, "1588561321000000000")
+	c.Check(push.Streams[1].Stream["level"], gc.Equals, "info")
+	c.Check(push.Streams[1].Values, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendElasticsearch(c *gc.C) {
+	s.doer.response = `{"errors":false,"items":[{"index":{"status":201}}]}`
+	client := s.open(c, logfwdhttp.FormatElasticsearch)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := s.doer.requests[0]
+	c.Check(req.url, gc.Equals, "https://logs.example.com/_bulk")
+	c.Check(req.contentType, gc.Equals, "application/x-ndjson")
+	lines := strings.Split(strings.TrimSpace(req.body), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	c.Check(lines[0], gc.Equals, `{"index":{"_index":"juju-logs"}}`)
+}
+
+func (s *ClientSuite) TestSendElasticsearchItemError(c *gc.C) {
+	s.doer.response = `{"errors":true,"items":[{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad field"}}}]}`
+	client := s.open(c, logfwdhttp.FormatElasticsearch)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `sending log records: indexing log record failed \(status 400\): mapper_parsing_exception: bad field`)
+	s.doer.stub.CheckCallNames(c, "Do")
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, logfwdhttp.FormatJSONLines)
+	records := make([]logfwd.Record, 1234)
+	for i := range records {
+		records[i] = s.rec
+	}
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.doer.stub.CheckCallNames(c, "Do", "Do", "Do")
+	c.Check(strings.Count(s.doer.requests[0].body, "\n"), gc.Equals, 500)
+	c.Check(strings.Count(s.doer.requests[2].body, "\n"), gc.Equals, 234)
+}
+
+func (s *ClientSuite) TestSendRetriesTemporaryFailures(c *gc.C) {
+	s.doer.statuses = []int{http.StatusTooManyRequests}
+	s.doer.stub.SetErrors(errors.New("connection refused"))
+	client := s.open(c, logfwdhttp.FormatJSONLines)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+	s.doer.stub.CheckCallNames(c, "Do", "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendGivesUpAfterRetrying(c *gc.C) {
+	s.doer.statuses = []int{500, 502, 503}
+	client := s.open(c, logfwdhttp.FormatJSONLines)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `sending log records failed after retrying: 503 Service Unavailable: nope`)
+	s.doer.stub.CheckCallNames(c, "Do", "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendDoesNotRetryClientErrors(c *gc.C) {
+	s.doer.statuses = []int{http.StatusUnauthorized}
+	client := s.open(c, logfwdhttp.FormatJSONLines)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `sending log records: 401 Unauthorized: nope`)
+	s.doer.stub.CheckCallNames(c, "Do")
+}
+
+type recordedRequest struct {
+	url         string
+	contentType string
+	auth        string
+	body        string
+}
+
+type stubDoer struct {
+	stub     *testing.Stub
+	statuses []int
+	response string
+	requests []recordedRequest
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	d.stub.AddCall("Do")
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	user, password, _ := req.BasicAuth()
+	d.requests = append(d.requests, recordedRequest{
+		url:         req.URL.String(),
+		contentType: req.Header.Get("Content-Type"),
+		auth:        user + ":" + password,
+		body:        string(body),
+	})
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	status := http.StatusOK
+	response := d.response
+	if len(d.statuses) > 0 {
+		status, d.statuses = d.statuses[0], d.statuses[1:]
+		response = "nope"
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+	}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/cert"
+)
+
+// Format identifies the wire format used to send log records.
+type Format string
+
+// These are the supported formats.
+const (
+	// FormatJSONLines sends each record as a JSON object on its own
+	// line, to the configured URL.
+	FormatJSONLines Format = "http"
+
+	// FormatLoki sends records using the Loki push API.
+	FormatLoki Format = "loki"
+
+	// FormatElasticsearch sends records using the Elasticsearch
+	// bulk API.
+	FormatElasticsearch Format = "elasticsearch"
+)
+
+// DefaultIndex is the Elasticsearch index used when none is configured.
+const DefaultIndex = "juju-logs"
+
+// Validate ensures that the format is one that is supported.
+func (f Format) Validate() error {
+	switch f {
+	case FormatJSONLines, FormatLoki, FormatElasticsearch:
+		return nil
+	}
+	return errors.NotValidf("log forwarding format %q", f)
+}
+
+// RawConfig holds the raw configuration data for forwarding logs to
+// an HTTP log store.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Format is the format in which records are sent.
+	Format Format
+
+	// URL is the base URL of the log store. For the JSON-lines format
+	// records are posted to this URL; for Loki and Elasticsearch the
+	// API path is appended to it.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is not set the system roots are used.
+	CACert string
+
+	// Username and Password, if set, are sent using HTTP basic
+	// authentication.
+	Username string
+	Password string
+
+	// Index is the Elasticsearch index the records are written to.
+	Index string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if err := cfg.Format.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		return errors.NotValidf("password without username")
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL %q (scheme must be http or https)", cfg.URL)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q (missing host)", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) index() string {
+	if cfg.Index == "" {
+		return DefaultIndex
+	}
+	return cfg.Index
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return utils.SecureTLSConfig(), nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	tlsCfg := utils.SecureTLSConfig()
+	tlsCfg.RootCAs = rootCAs
+	return tlsCfg, nil
+}
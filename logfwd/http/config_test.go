@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := logfwdhttp.RawConfig{
+		Enabled:  true,
+		Format:   logfwdhttp.FormatElasticsearch,
+		URL:      "https://es.example.com:9200",
+		CACert:   coretesting.CACert,
+		Username: "juju",
+		Password: "secret",
+		Index:    "logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg logfwdhttp.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFormat(c *gc.C) {
+	cfg := logfwdhttp.RawConfig{
+		Enabled: true,
+		Format:  "carrier-pigeon",
+		URL:     "http://logs.example.com",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `log forwarding format "carrier-pigeon" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadURL(c *gc.C) {
+	for _, url := range []string{"", "logs.example.com", "ftp://logs.example.com", "http://"} {
+		cfg := logfwdhttp.RawConfig{
+			Enabled: true,
+			Format:  logfwdhttp.FormatLoki,
+			URL:     url,
+		}
+		err := cfg.Validate()
+		c.Check(err, gc.ErrorMatches, `URL ".*" .*not valid`, gc.Commentf("url %q", url))
+	}
+}
+
+func (s *ConfigSuite) TestRawValidatePasswordWithoutUsername(c *gc.C) {
+	cfg := logfwdhttp.RawConfig{
+		Enabled:  true,
+		Format:   logfwdhttp.FormatJSONLines,
+		URL:      "http://logs.example.com",
+		Password: "secret",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `password without username not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := logfwdhttp.RawConfig{
+		Enabled: true,
+		Format:  logfwdhttp.FormatJSONLines,
+		URL:     "https://logs.example.com",
+		CACert:  "not a certificate",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: .*`)
+}
@@ -0,0 +1,7 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The http package holds the tools needed to perform log forwarding
+// from Juju to log stores that accept records over HTTP: a generic
+// JSON-lines endpoint, Grafana Loki and Elasticsearch.
+package http
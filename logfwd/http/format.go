@@ -0,0 +1,218 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	lokiPushPath          = "/loki/api/v1/push"
+	elasticsearchBulkPath = "/_bulk"
+
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// request holds an encoded batch of records, ready to be posted.
+type request struct {
+	path        string
+	contentType string
+	body        []byte
+}
+
+// jsonRecord is the JSON serialisation of a single log record. It is
+// used directly by the JSON-lines format and as the document body for
+// the other formats.
+type jsonRecord struct {
+	ID             int64     `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	ControllerUUID string    `json:"controller-uuid"`
+	ModelUUID      string    `json:"model-uuid"`
+	Hostname       string    `json:"hostname,omitempty"`
+	OriginType     string    `json:"origin-type"`
+	OriginName     string    `json:"origin-name"`
+	Software       string    `json:"software,omitempty"`
+	Version        string    `json:"version,omitempty"`
+	Level          string    `json:"level"`
+	Module         string    `json:"module,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	Message        string    `json:"message"`
+}
+
+func newJSONRecord(rec logfwd.Record) jsonRecord {
+	jrec := jsonRecord{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Message:        rec.Message,
+	}
+	if rec.Origin.Software.Name != "" {
+		jrec.Version = rec.Origin.Software.Version.String()
+	}
+	if rec.Location.Filename != "" {
+		jrec.Location = rec.Location.String()
+	}
+	return jrec
+}
+
+func encode(cfg RawConfig, records []logfwd.Record) (request, error) {
+	switch cfg.Format {
+	case FormatJSONLines:
+		return encodeJSONLines(records)
+	case FormatLoki:
+		return encodeLoki(records)
+	case FormatElasticsearch:
+		return encodeElasticsearch(cfg.index(), records)
+	}
+	return request{}, errors.NotValidf("log forwarding format %q", cfg.Format)
+}
+
+// encodeJSONLines encodes the records as newline separated JSON objects.
+func encodeJSONLines(records []logfwd.Record) (request, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if err := enc.Encode(newJSONRecord(rec)); err != nil {
+			return request{}, errors.Trace(err)
+		}
+	}
+	return request{
+		contentType: contentTypeNDJSON,
+		body:        buf.Bytes(),
+	}, nil
+}
+
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Labels map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// encodeLoki encodes the records as a Loki push request. Records are
+// grouped into streams by controller, model, origin and level; the
+// line for each entry is the JSON encoded record.
+func encodeLoki(records []logfwd.Record) (request, error) {
+	var push lokiPush
+	streams := make(map[string]*lokiStream)
+	for _, rec := range records {
+		labels := map[string]string{
+			"juju_controller":  rec.Origin.ControllerUUID,
+			"juju_model":       rec.Origin.ModelUUID,
+			"juju_origin_type": rec.Origin.Type.String(),
+			"juju_origin":      rec.Origin.Name,
+			"level":            strings.ToLower(rec.Level.String()),
+		}
+		key := strings.Join([]string{
+			labels["juju_controller"],
+			labels["juju_model"],
+			labels["juju_origin_type"],
+			labels["juju_origin"],
+			labels["level"],
+		}, "\x00")
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{Labels: labels}
+			streams[key] = stream
+			push.Streams = append(push.Streams, stream)
+		}
+		line, err := json.Marshal(newJSONRecord(rec))
+		if err != nil {
+			return request{}, errors.Trace(err)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			string(line),
+		})
+	}
+	body, err := json.Marshal(push)
+	if err != nil {
+		return request{}, errors.Trace(err)
+	}
+	return request{
+		path:        lokiPushPath,
+		contentType: contentTypeJSON,
+		body:        body,
+	}, nil
+}
+
+type elasticsearchAction struct {
+	Index elasticsearchIndex `json:"index"`
+}
+
+type elasticsearchIndex struct {
+	Index string `json:"_index"`
+}
+
+// encodeElasticsearch encodes the records as an Elasticsearch bulk
+// request, indexing each record as a document in the given index.
+func encodeElasticsearch(index string, records []logfwd.Record) (request, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	action := elasticsearchAction{Index: elasticsearchIndex{Index: index}}
+	for _, rec := range records {
+		if err := enc.Encode(action); err != nil {
+			return request{}, errors.Trace(err)
+		}
+		if err := enc.Encode(newJSONRecord(rec)); err != nil {
+			return request{}, errors.Trace(err)
+		}
+	}
+	return request{
+		path:        elasticsearchBulkPath,
+		contentType: contentTypeNDJSON,
+		body:        buf.Bytes(),
+	}, nil
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// checkElasticsearchResponse reports the first failure from a bulk
+// response. The bulk API reports per document failures in the body
+// of an otherwise successful response.
+func checkElasticsearchResponse(body []byte) error {
+	var resp elasticsearchBulkResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return errors.Annotate(err, "decoding bulk response")
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				return errors.Errorf("indexing log record failed (status %d): %s: %s",
+					result.Status, result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return errors.New("indexing log records failed")
+}
@@ -0,0 +1,686 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package archive reads and writes offline model migration archives.
+// An archive holds the serialized description of a model together with
+// the charms, agent binaries and resources it uses, so that the model
+// can be moved between controllers which cannot reach one another.
+//
+// The manifest of an archive records the SHA-384 hash of every other
+// file in it, and is signed with the CA key of the controller the model
+// was exported from.
+package archive
+
+import (
+	"archive/tar"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/naturalsort"
+	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+var logger = loggo.GetLogger("juju.migration.archive")
+
+const (
+	formatVersion = 1
+
+	modelFile     = "model.yaml"
+	manifestFile  = "manifest.yaml"
+	signatureFile = "manifest.sig"
+)
+
+// Info describes the model held in an archive, and the controller it
+// was exported from.
+type Info struct {
+	SourceControllerUUID string
+	SourceCACert         string
+	Model                migration.ModelInfo
+}
+
+// Source provides the binaries used by a model when writing an
+// archive.
+type Source interface {
+	// OpenCharm returns a reader for the archive of the charm
+	// with the given URL.
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+
+	// OpenTools returns a reader for the agent binaries with the
+	// given version.
+	OpenTools(version.Binary) (io.ReadCloser, error)
+
+	// OpenResource returns a reader for the content of the named
+	// resource of an application.
+	OpenResource(application, name string) (io.ReadCloser, error)
+}
+
+// Uploader sends the binaries held in an archive to the controller
+// the model is being imported into.
+type Uploader interface {
+	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
+	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+	UploadResource(resource.Resource, io.ReadSeeker) error
+	SetUnitResource(string, resource.Resource) error
+}
+
+// manifest is written at the end of an archive, after all of the files
+// it describes.
+type manifest struct {
+	FormatVersion          int               `yaml:"format-version"`
+	SourceControllerUUID   string            `yaml:"source-controller-uuid"`
+	SourceCACert           string            `yaml:"source-ca-cert"`
+	ModelUUID              string            `yaml:"model-uuid"`
+	ModelName              string            `yaml:"model-name"`
+	ModelOwner             string            `yaml:"model-owner"`
+	AgentVersion           string            `yaml:"agent-version"`
+	ControllerAgentVersion string            `yaml:"controller-agent-version"`
+	Charms                 map[string]string `yaml:"charms,omitempty"`
+	Tools                  map[string]string `yaml:"tools,omitempty"`
+	Resources              map[string]string `yaml:"resources,omitempty"`
+	Files                  map[string]string `yaml:"files"`
+}
+
+// Write writes an archive of the model serialized in bytes to w. The
+// charms, agent binaries and resources used by the model are read from
+// source, and the manifest is signed with the given CA private key,
+// which must match the source CA certificate in info.
+func Write(w io.Writer, info Info, bytes []byte, source Source, caKey string) error {
+	_, key, err := utilscert.ParseCertAndKey(info.SourceCACert, caKey)
+	if err != nil {
+		return errors.Annotate(err, "parsing CA certificate and key")
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	aw := &writer{
+		tw: tar.NewWriter(w),
+		manifest: manifest{
+			FormatVersion:          formatVersion,
+			SourceControllerUUID:   info.SourceControllerUUID,
+			SourceCACert:           info.SourceCACert,
+			ModelUUID:              info.Model.UUID,
+			ModelName:              info.Model.Name,
+			ModelOwner:             info.Model.Owner.Id(),
+			AgentVersion:           info.Model.AgentVersion.String(),
+			ControllerAgentVersion: info.Model.ControllerAgentVersion.String(),
+			Charms:                 make(map[string]string),
+			Tools:                  make(map[string]string),
+			Resources:              make(map[string]string),
+			Files:                  make(map[string]string),
+		},
+	}
+	if err := aw.writeBytes(modelFile, bytes, true); err != nil {
+		return errors.Trace(err)
+	}
+	for i, curlStr := range modelCharms(model) {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		name := fmt.Sprintf("charms/%d.charm", i)
+		if err := aw.writeFrom(name, func() (io.ReadCloser, error) {
+			return source.OpenCharm(curl)
+		}); err != nil {
+			return errors.Annotatef(err, "writing charm %s", curl)
+		}
+		aw.manifest.Charms[curlStr] = name
+	}
+	if model.Type() == string(coremodel.IAAS) {
+		for _, v := range modelTools(model) {
+			name := fmt.Sprintf("tools/%s.tgz", v)
+			if err := aw.writeFrom(name, func() (io.ReadCloser, error) {
+				return source.OpenTools(v)
+			}); err != nil {
+				return errors.Annotatef(err, "writing agent binaries %s", v)
+			}
+			aw.manifest.Tools[v.String()] = name
+		}
+	}
+	resources, err := modelResources(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, res := range resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			continue
+		}
+		id := path.Join(rev.ApplicationID, rev.Name)
+		name := path.Join("resources", id)
+		if err := aw.writeFrom(name, func() (io.ReadCloser, error) {
+			return source.OpenResource(rev.ApplicationID, rev.Name)
+		}); err != nil {
+			return errors.Annotatef(err, "writing resource %s", id)
+		}
+		aw.manifest.Resources[id] = name
+	}
+
+	data, err := yaml.Marshal(aw.manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	digest := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return errors.Annotate(err, "signing archive manifest")
+	}
+	if err := aw.writeBytes(manifestFile, data, false); err != nil {
+		return errors.Trace(err)
+	}
+	if err := aw.writeBytes(signatureFile, signature, false); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(aw.tw.Close())
+}
+
+type writer struct {
+	tw       *tar.Writer
+	manifest manifest
+}
+
+func (aw *writer) writeBytes(name string, data []byte, record bool) error {
+	if err := aw.tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: int64(len(data)),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := aw.tw.Write(data); err != nil {
+		return errors.Trace(err)
+	}
+	if record {
+		aw.manifest.Files[name] = fmt.Sprintf("%x", sha512.Sum384(data))
+	}
+	return nil
+}
+
+// writeFrom adds the contents of the reader returned by open to the
+// archive. The content is staged through a temporary file, as its size
+// must be known before it is written.
+func (aw *writer) writeFrom(name string, open func() (io.ReadCloser, error)) error {
+	reader, err := open()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+
+	tempFile, err := ioutil.TempFile("", "juju-model-archive")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+	hash := sha512.New384()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := aw.tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: size,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(aw.tw, tempFile); err != nil {
+		return errors.Trace(err)
+	}
+	aw.manifest.Files[name] = fmt.Sprintf("%x", hash.Sum(nil))
+	return nil
+}
+
+// Archive is an unpacked archive. Its contents must not be trusted
+// until Verify has succeeded.
+type Archive struct {
+	// Info describes the model in the archive.
+	Info Info
+
+	// Bytes holds the serialized model.
+	Bytes []byte
+
+	dir       string
+	manifest  []byte
+	signature []byte
+	contents  manifest
+}
+
+// Read unpacks the archive read from r into a temporary directory, and
+// checks that every file in it matches the hash recorded in its
+// manifest. The returned archive must be closed once it is no longer
+// needed.
+func Read(r io.Reader) (_ *Archive, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	a := &Archive{dir: dir}
+	defer func() {
+		if err != nil {
+			a.Close()
+		}
+	}()
+
+	hashes := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "reading archive")
+		}
+		switch hdr.Name {
+		case manifestFile:
+			if a.manifest, err = ioutil.ReadAll(tr); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		case signatureFile:
+			if a.signature, err = ioutil.ReadAll(tr); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		hash, err := a.extract(hdr.Name, tr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "extracting %q", hdr.Name)
+		}
+		hashes[hdr.Name] = hash
+	}
+	if a.manifest == nil || a.signature == nil {
+		return nil, errors.NotValidf("archive without signed manifest")
+	}
+	if err := yaml.Unmarshal(a.manifest, &a.contents); err != nil {
+		return nil, errors.Annotate(err, "reading archive manifest")
+	}
+	if a.contents.FormatVersion != formatVersion {
+		return nil, errors.NotSupportedf("archive format version %d", a.contents.FormatVersion)
+	}
+	if len(hashes) != len(a.contents.Files) {
+		return nil, errors.NotValidf("archive contents not matching manifest")
+	}
+	for name, hash := range a.contents.Files {
+		if hashes[name] != hash {
+			return nil, errors.NotValidf("archive file %q", name)
+		}
+	}
+
+	if a.Bytes, err = ioutil.ReadFile(filepath.Join(dir, modelFile)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if a.Info, err = a.contents.info(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a, nil
+}
+
+func (a *Archive) extract(name string, r io.Reader) (string, error) {
+	// Only accept plain relative names, so that nothing can be
+	// written outside of the archive directory.
+	clean := path.Clean(name)
+	if clean != name || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", errors.NotValidf("file name")
+	}
+	target := filepath.Join(a.dir, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha512.New384()
+	if _, err := io.Copy(io.MultiWriter(f, hash), r); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func (m manifest) info() (Info, error) {
+	if !names.IsValidUser(m.ModelOwner) {
+		return Info{}, errors.NotValidf("model owner %q", m.ModelOwner)
+	}
+	agentVersion, err := version.Parse(m.AgentVersion)
+	if err != nil {
+		return Info{}, errors.Annotate(err, "parsing agent version")
+	}
+	controllerVersion, err := version.Parse(m.ControllerAgentVersion)
+	if err != nil {
+		return Info{}, errors.Annotate(err, "parsing controller agent version")
+	}
+	return Info{
+		SourceControllerUUID: m.SourceControllerUUID,
+		SourceCACert:         m.SourceCACert,
+		Model: migration.ModelInfo{
+			UUID:                   m.ModelUUID,
+			Owner:                  names.NewUserTag(m.ModelOwner),
+			Name:                   m.ModelName,
+			AgentVersion:           agentVersion,
+			ControllerAgentVersion: controllerVersion,
+		},
+	}, nil
+}
+
+// Verify checks that the archive manifest was signed by the CA of the
+// source controller, whose certificate is given. The certificate must
+// come from a trusted source rather than from the archive itself.
+func (a *Archive) Verify(caCert string) error {
+	cert, err := utilscert.ParseCert(caCert)
+	if err != nil {
+		return errors.Annotate(err, "parsing CA certificate")
+	}
+	if err := cert.CheckSignature(x509.SHA256WithRSA, a.manifest, a.signature); err != nil {
+		return errors.Annotate(err, "archive signature not valid")
+	}
+	return nil
+}
+
+// Charms returns the URLs of the charms held in the archive.
+func (a *Archive) Charms() []string {
+	result := make([]string, 0, len(a.contents.Charms))
+	for curl := range a.contents.Charms {
+		result = append(result, curl)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Tools returns the versions of the agent binaries held in the archive,
+// with the URIs which may be passed to OpenURI to read them.
+func (a *Archive) Tools() (map[version.Binary]string, error) {
+	result := make(map[version.Binary]string)
+	for vers, name := range a.contents.Tools {
+		v, err := version.ParseBinary(vers)
+		if err != nil {
+			return nil, errors.Annotate(err, "error parsing agent binary version")
+		}
+		result[v] = name
+	}
+	return result, nil
+}
+
+// Resources returns the resources used by the archived model.
+func (a *Archive) Resources() ([]migration.SerializedModelResource, error) {
+	model, err := description.Deserialize(a.Bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelResources(model)
+}
+
+// OpenCharm returns a reader for the archive of the charm with the
+// given URL.
+func (a *Archive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	name, ok := a.contents.Charms[curl.String()]
+	if !ok {
+		return nil, errors.NotFoundf("charm %s", curl)
+	}
+	f, err := a.open(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// OpenURI returns a reader for the file in the archive with the given
+// URI, as returned by Tools.
+func (a *Archive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	if _, ok := a.contents.Files[uri]; !ok {
+		return nil, errors.NotFoundf("file %q", uri)
+	}
+	f, err := a.open(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// OpenResource returns a reader for the content of the named resource
+// of an application.
+func (a *Archive) OpenResource(application, name string) (io.ReadCloser, error) {
+	file, ok := a.contents.Resources[path.Join(application, name)]
+	if !ok {
+		return nil, errors.NotFoundf("resource %s/%s", application, name)
+	}
+	f, err := a.open(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+func (a *Archive) open(name string) (*os.File, error) {
+	return os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+}
+
+// Upload sends the charms, agent binaries and resources held in the
+// archive to the given uploader, in the same way as the binaries of a
+// model are sent to the target controller during a migration.
+func (a *Archive) Upload(uploader Uploader) error {
+	// Charms are uploaded in ascending charm URL order so that charm
+	// revisions end up the same in the target as they were in the
+	// source.
+	charms := a.Charms()
+	naturalsort.Sort(charms)
+	for _, curlStr := range charms {
+		logger.Debugf("uploading charm %s", curlStr)
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		f, err := a.open(a.contents.Charms[curlStr])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		usedCurl, err := uploader.UploadCharm(curl, f)
+		f.Close()
+		if err != nil {
+			return errors.Annotate(err, "cannot upload charm")
+		} else if usedCurl.String() != curl.String() {
+			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
+		}
+	}
+
+	allTools, err := a.Tools()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for v, name := range allTools {
+		logger.Debugf("uploading agent binaries %s", v)
+		f, err := a.open(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = uploader.UploadTools(f, v)
+		f.Close()
+		if err != nil {
+			return errors.Annotate(err, "cannot upload agent binaries")
+		}
+	}
+
+	resources, err := a.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, res := range resources {
+		// Resource placeholders are created by the model import, so
+		// only resources with content are uploaded.
+		if rev := res.ApplicationRevision; !rev.IsPlaceholder() {
+			logger.Debugf("uploading resource %s/%s", rev.ApplicationID, rev.Name)
+			f, err := a.open(a.contents.Resources[path.Join(rev.ApplicationID, rev.Name)])
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = uploader.UploadResource(rev, f)
+			f.Close()
+			if err != nil {
+				return errors.Annotate(err, "cannot upload resource")
+			}
+		}
+		for unitName, unitRev := range res.UnitRevisions {
+			if err := uploader.SetUnitResource(unitName, unitRev); err != nil {
+				return errors.Annotate(err, "cannot set unit resource")
+			}
+		}
+	}
+	return nil
+}
+
+// Close removes the unpacked archive.
+func (a *Archive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
+
+// modelCharms returns the URLs of the charms used by the model.
+func modelCharms(model description.Model) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, application := range model.Applications() {
+		if curl := application.CharmURL(); !seen[curl] {
+			seen[curl] = true
+			result = append(result, curl)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// modelTools returns the versions of the agent binaries used by the
+// machines and units of the model.
+func modelTools(model description.Model) []version.Binary {
+	used := make(map[version.Binary]bool)
+	var addMachine func(description.Machine)
+	addMachine = func(machine description.Machine) {
+		used[machine.Tools().Version()] = true
+		for _, container := range machine.Containers() {
+			addMachine(container)
+		}
+	}
+	for _, machine := range model.Machines() {
+		addMachine(machine)
+	}
+	for _, application := range model.Applications() {
+		for _, unit := range application.Units() {
+			used[unit.Tools().Version()] = true
+		}
+	}
+	result := make([]version.Binary, 0, len(used))
+	for v := range used {
+		result = append(result, v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
+// modelResources returns the revisions of the resources used by the
+// applications and units of the model.
+func modelResources(model description.Model) ([]migration.SerializedModelResource, error) {
+	var result []migration.SerializedModelResource
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := resourceRevision(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return nil, errors.Annotate(err, "application revision")
+			}
+			csRev, err := resourceRevision(app.Name(), res.Name(), res.CharmStoreRevision())
+			if err != nil {
+				return nil, errors.Annotate(err, "charmstore revision")
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitResource := range unit.Resources() {
+					if unitResource.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := resourceRevision(app.Name(), res.Name(), unitResource.Revision())
+					if err != nil {
+						return nil, errors.Annotate(err, "unit revision")
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			result = append(result, migration.SerializedModelResource{
+				ApplicationRevision: appRev,
+				CharmStoreRevision:  csRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return result, nil
+}
+
+func resourceRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	var empty resource.Resource
+	if rev == nil {
+		return empty, nil
+	}
+	type_, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return empty, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
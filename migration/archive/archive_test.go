@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package archive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/migration/archive"
+	"github.com/juju/juju/resource"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/tools"
+)
+
+type ArchiveSuite struct {
+	statetesting.StateSuite
+	info archive.Info
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.Factory.MakeApplication(c, &factory.ApplicationParams{
+			Name:  "mysql",
+			Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+		}),
+	})
+	s.info = archive.Info{
+		SourceControllerUUID: coretesting.ControllerTag.Id(),
+		SourceCACert:         coretesting.CACert,
+		Model: coremigration.ModelInfo{
+			UUID:                   s.State.ModelUUID(),
+			Owner:                  names.NewUserTag("admin"),
+			Name:                   "testmodel",
+			AgentVersion:           version.MustParse("2.7.1"),
+			ControllerAgentVersion: version.MustParse("2.7.2"),
+		},
+	}
+}
+
+func (s *ArchiveSuite) writeArchive(c *gc.C) []byte {
+	serialized, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	err = archive.Write(&buf, s.info, serialized, &fakeArchiveSource{}, coretesting.CAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	data := s.writeArchive(c)
+
+	a, err := archive.Read(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer a.Close()
+
+	c.Assert(a.Verify(coretesting.CACert), jc.ErrorIsNil)
+	c.Check(a.Info, jc.DeepEquals, s.info)
+	expected, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(len(a.Bytes), gc.Equals, len(expected))
+
+	charms := a.Charms()
+	c.Assert(charms, gc.HasLen, 1)
+	r, err := a.OpenCharm(charm.MustParseURL(charms[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, charms[0]+" content")
+
+	tools, err := a.Tools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tools, gc.Not(gc.HasLen), 0)
+	for v, uri := range tools {
+		r, err := a.OpenURI(uri, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		content, err := ioutil.ReadAll(r)
+		r.Close()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(content), gc.Equals, v.String())
+	}
+
+	_, err = a.OpenResource("mysql", "missing")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ArchiveSuite) TestUpload(c *gc.C) {
+	data := s.writeArchive(c)
+
+	a, err := archive.Read(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer a.Close()
+
+	uploader := &fakeArchiveUploader{tools: make(map[version.Binary]string)}
+	err = a.Upload(uploader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(uploader.charms, jc.DeepEquals, a.Charms())
+	tools, err := a.Tools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploader.tools, gc.HasLen, len(tools))
+	for v, content := range uploader.tools {
+		c.Check(content, gc.Equals, v.String())
+	}
+}
+
+func (s *ArchiveSuite) TestUploadWrongCharmURLAssigned(c *gc.C) {
+	data := s.writeArchive(c)
+
+	a, err := archive.Read(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer a.Close()
+
+	err = a.Upload(&fakeArchiveUploader{reassignCharmURL: true})
+	c.Assert(err, gc.ErrorMatches, "charm .* unexpectedly assigned .*")
+}
+
+func (s *ArchiveSuite) TestVerifyWrongCACert(c *gc.C) {
+	data := s.writeArchive(c)
+
+	a, err := archive.Read(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer a.Close()
+
+	err = a.Verify(coretesting.OtherCACert)
+	c.Assert(err, gc.ErrorMatches, "archive signature not valid: .*")
+}
+
+func (s *ArchiveSuite) TestReadTamperedArchive(c *gc.C) {
+	data := s.writeArchive(c)
+
+	// Rewrite the archive, replacing the content of the charm.
+	var buf bytes.Buffer
+	tr := tar.NewReader(bytes.NewReader(data))
+	tw := tar.NewWriter(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		content, err := ioutil.ReadAll(tr)
+		c.Assert(err, jc.ErrorIsNil)
+		if hdr.Name == "charms/0.charm" {
+			content = []byte("something else")
+			hdr.Size = int64(len(content))
+		}
+		c.Assert(tw.WriteHeader(hdr), jc.ErrorIsNil)
+		_, err = tw.Write(content)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	_, err := archive.Read(&buf)
+	c.Assert(err, gc.ErrorMatches, `archive file "charms/0.charm" not valid`)
+}
+
+func (s *ArchiveSuite) TestReadArchiveWithoutManifest(c *gc.C) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	_, err := archive.Read(&buf)
+	c.Assert(err, gc.ErrorMatches, "archive without signed manifest not valid")
+}
+
+func (s *ArchiveSuite) TestReadArchiveRejectsEscapingPaths(c *gc.C) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0600, Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("x"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	_, err = archive.Read(&buf)
+	c.Assert(err, gc.ErrorMatches, `extracting "../evil": file name not valid`)
+}
+
+type fakeArchiveSource struct{}
+
+func (*fakeArchiveSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader([]byte(curl.String() + " content"))), nil
+}
+
+func (*fakeArchiveSource) OpenTools(v version.Binary) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader([]byte(v.String()))), nil
+}
+
+func (*fakeArchiveSource) OpenResource(app, name string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader([]byte(name))), nil
+}
+
+type fakeArchiveUploader struct {
+	charms           []string
+	tools            map[version.Binary]string
+	reassignCharmURL bool
+}
+
+func (u *fakeArchiveUploader) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	u.charms = append(u.charms, curl.String())
+	if u.reassignCharmURL {
+		return curl.WithRevision(curl.Revision + 1), nil
+	}
+	return curl, nil
+}
+
+func (u *fakeArchiveUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	u.tools[v] = string(content)
+	return tools.List{&tools.Tools{Version: v}}, nil
+}
+
+func (u *fakeArchiveUploader) UploadResource(resource.Resource, io.ReadSeeker) error {
+	return nil
+}
+
+func (u *fakeArchiveUploader) SetUnitResource(string, resource.Resource) error {
+	return nil
+}
@@ -4,10 +4,12 @@
 package migration
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"reflect"
 
 	"github.com/juju/description"
 	"github.com/juju/errors"
@@ -15,9 +17,12 @@ import (
 	"github.com/juju/naturalsort"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -123,6 +128,81 @@ func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte) (
 	return dbModel, dbState, nil
 }
 
+// ImportValidationBackend describes the state of the target
+// controller needed to check that a model can be imported into it.
+type ImportValidationBackend interface {
+	Cloud(name string) (cloud.Cloud, error)
+	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
+}
+
+// ValidateImport deserializes a model description from the bytes and
+// checks whether it could be imported by ImportModel into the
+// controller described by the backend, without modifying the
+// controller. Every problem which would prevent the import is
+// returned. An error is only returned if the checks could not be run.
+func ValidateImport(backend ImportValidationBackend, bytes []byte) ([]string, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return []string{fmt.Sprintf("model description cannot be read: %v", err)}, nil
+	}
+
+	var problems []string
+	if err := model.Validate(); err != nil {
+		problems = append(problems, fmt.Sprintf("model description is not valid: %v", err))
+	}
+	if model.Type() != "" {
+		if _, err := state.ParseModelType(model.Type()); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if _, err := config.New(config.NoDefaults, model.Config()); err != nil {
+		problems = append(problems, fmt.Sprintf("model config is not valid: %v", err))
+	}
+
+	modelCloud, err := backend.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		problems = append(problems, fmt.Sprintf("cloud %q not found", model.Cloud()))
+		return problems, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "retrieving cloud")
+	}
+	if region := model.CloudRegion(); region != "" {
+		if _, err := cloud.RegionByName(modelCloud.Regions, region); err != nil {
+			problems = append(problems, fmt.Sprintf("cloud %q has no region %q", model.Cloud(), region))
+		}
+	}
+
+	creds := model.CloudCredential()
+	if creds == nil {
+		return problems, nil
+	}
+	credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+	if !names.IsValidCloudCredential(credID) {
+		return append(problems, fmt.Sprintf("cloud credential ID %q not valid", credID)), nil
+	}
+	existingCreds, err := backend.CloudCredential(names.NewCloudCredentialTag(credID))
+	if errors.IsNotFound(err) {
+		// The credential will be added when the model is imported.
+		authType := cloud.AuthType(creds.AuthType())
+		if !modelCloud.AuthTypes.Contains(authType) {
+			problems = append(problems, fmt.Sprintf("cloud %q does not support credential auth type %q", model.Cloud(), authType))
+		}
+		return problems, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "retrieving cloud credential")
+	}
+	if existingCreds.AuthType != creds.AuthType() {
+		problems = append(problems, fmt.Sprintf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType()))
+	}
+	if !reflect.DeepEqual(existingCreds.Attributes, creds.Attributes()) {
+		problems = append(problems, fmt.Sprintf("credential %q attributes do not match those on the target controller", credID))
+	}
+	if existingCreds.Revoked {
+		problems = append(problems, fmt.Sprintf("credential %q is revoked", credID))
+	}
+	return problems, nil
+}
+
 // CharmDownlaoder defines a single method that is used to download a
 // charm from the source controller in a migration.
 type CharmDownloader interface {
@@ -20,7 +20,9 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -138,6 +140,63 @@ func (s *ImportSuite) TestImportsLeadershipLegacy(c *gc.C) {
 	c.Assert(leaders, gc.DeepEquals, map[string]string{"wordpress": "wordpress/1"})
 }
 
+func (s *ImportSuite) exportedModel(c *gc.C) (string, []byte) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": uuid,
+	})
+
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return uuid, bytes
+}
+
+func (s *ImportSuite) TestValidateImport(c *gc.C) {
+	uuid, bytes := s.exportedModel(c)
+	problems, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+
+	// Validation must not leave the model behind.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.IsFalse)
+}
+
+func (s *ImportSuite) TestValidateImportBadBytes(c *gc.C) {
+	problems, err := migration.ValidateImport(s.State, []byte("not a model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0], gc.Matches, "model description cannot be read: yaml: unmarshal errors:\n.*")
+}
+
+func (s *ImportSuite) TestValidateImportCloudNotFound(c *gc.C) {
+	_, bytes := s.exportedModel(c)
+	backend := &fakeImportBackend{cloudErr: errors.NotFoundf("cloud")}
+	problems, err := migration.ValidateImport(backend, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{`cloud "dummy" not found`})
+}
+
+func (s *ImportSuite) TestValidateImportMissingRegion(c *gc.C) {
+	_, bytes := s.exportedModel(c)
+	backend := &fakeImportBackend{cloud: cloud.Cloud{Name: "dummy"}}
+	problems, err := migration.ValidateImport(backend, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{`cloud "dummy" has no region "dummy-region"`})
+}
+
+func (s *ImportSuite) TestValidateImportCloudError(c *gc.C) {
+	_, bytes := s.exportedModel(c)
+	backend := &fakeImportBackend{cloudErr: errors.New("boom")}
+	_, err := migration.ValidateImport(backend, bytes)
+	c.Assert(err, gc.ErrorMatches, "retrieving cloud: boom")
+}
+
 func (s *ImportSuite) makeApplicationWithUnits(c *gc.C, applicationname string, count int) {
 	units := make([]*state.Unit, count)
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
@@ -384,6 +443,19 @@ func (s *ExportSuite) TestExportModel(c *gc.C) {
 	c.Assert(modelDesc.Validate(), jc.ErrorIsNil)
 }
 
+type fakeImportBackend struct {
+	cloud    cloud.Cloud
+	cloudErr error
+}
+
+func (b *fakeImportBackend) Cloud(string) (cloud.Cloud, error) {
+	return b.cloud, b.cloudErr
+}
+
+func (b *fakeImportBackend) CloudCredential(names.CloudCredentialTag) (state.Credential, error) {
+	return state.Credential{}, errors.NotFoundf("credential")
+}
+
 func fakeGetClaimer(string) (leadership.Claimer, error) {
 	return &fakeClaimer{}, nil
 }
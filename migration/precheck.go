@@ -35,6 +35,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	Charm(*charm.URL) (PrecheckCharm, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -88,6 +89,12 @@ type PrecheckUnit interface {
 	ShouldBeAssigned() bool
 }
 
+// PrecheckCharm describes the state interface for a charm needed by
+// migration prechecks.
+type PrecheckCharm interface {
+	IsUploaded() bool
+}
+
 // PrecheckRelation describes the state interface for relations needed
 // for prechecks.
 type PrecheckRelation interface {
@@ -118,7 +125,32 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
+	return errors.Trace(sourcePrecheck(backend, modelPresence, controllerPresence, nil))
+}
+
+// SourcePrecheckReport runs all of the checks made by SourcePrecheck,
+// but rather than stopping at the first problem found it returns every
+// problem which would prevent the model from being migrated. An error
+// is only returned if the checks could not be run.
+func SourcePrecheckReport(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) ([]string, error) {
+	var problems []string
+	if err := sourcePrecheck(backend, modelPresence, controllerPresence, &problems); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return problems, nil
+}
+
+func sourcePrecheck(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+	problems *[]string,
+) error {
+	ctx := precheckContext{backend: backend, presence: modelPresence, problems: problems}
 	if err := ctx.checkModel(); err != nil {
 		return errors.Trace(err)
 	}
@@ -139,7 +171,9 @@ func SourcePrecheck(
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.blocker(errors.New("cleanup needed")); err != nil {
+			return err
+		}
 	}
 
 	// Check the source controller.
@@ -147,7 +181,12 @@ func SourcePrecheck(
 	if err != nil {
 		return errors.Trace(err)
 	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
+	controllerCtx := precheckContext{
+		backend:  controllerBackend,
+		presence: controllerPresence,
+		problems: problems,
+		prefix:   "controller: ",
+	}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Annotate(err, "controller")
 	}
@@ -157,6 +196,24 @@ func SourcePrecheck(
 type precheckContext struct {
 	backend  PrecheckBackend
 	presence ModelPresence
+
+	// problems, when not nil, collects every problem which blocks
+	// the migration rather than failing on the first one found.
+	problems *[]string
+
+	// prefix is prepended to the problems collected.
+	prefix string
+}
+
+// blocker handles a problem which blocks the migration. If problems
+// are being collected the problem is recorded and nil is returned so
+// that checking continues, otherwise the problem is returned.
+func (ctx *precheckContext) blocker(problem error) error {
+	if ctx.problems == nil {
+		return problem
+	}
+	*ctx.problems = append(*ctx.problems, ctx.prefix+problem.Error())
+	return nil
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -165,10 +222,14 @@ func (ctx *precheckContext) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blocker(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		if err := ctx.blocker(errors.New("model is being imported as part of another migration")); err != nil {
+			return err
+		}
 	}
 	if credTag, found := model.CloudCredentialTag(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -176,7 +237,9 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			if err := ctx.blocker(errors.New("model has revoked credentials")); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -186,6 +249,30 @@ func (ctx *precheckContext) checkModel() error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	return errors.Trace(targetPrecheck(backend, pool, modelInfo, presence, nil))
+}
+
+// TargetPrecheckReport runs all of the checks made by TargetPrecheck,
+// but rather than stopping at the first problem found it returns every
+// problem which would prevent the model from being migrated to the
+// target controller. An error is only returned if the checks could not
+// be run.
+func TargetPrecheckReport(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) ([]string, error) {
+	var problems []string
+	if err := targetPrecheck(backend, pool, modelInfo, presence, &problems); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return problems, nil
+}
+
+func targetPrecheck(
+	backend PrecheckBackend,
+	pool Pool,
+	modelInfo coremigration.ModelInfo,
+	presence ModelPresence,
+	problems *[]string,
+) error {
+	controllerCtx := precheckContext{backend: backend, presence: presence, problems: problems}
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
@@ -200,7 +287,9 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := controllerCtx.blocker(errors.New("model is being migrated out of target controller")); err != nil {
+			return err
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
@@ -209,16 +298,21 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		err := controllerCtx.blocker(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion))
+		if err != nil {
+			return err
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		err := controllerCtx.blocker(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion))
+		if err != nil {
+			return err
+		}
 	}
 
-	controllerCtx := precheckContext{backend, presence}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Trace(err)
 	}
@@ -239,10 +333,15 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			err := controllerCtx.blocker(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
+			if err != nil {
+				return err
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := controllerCtx.blocker(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -272,13 +371,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blocker(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.blocker(errors.New("upgrade in progress")); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -297,29 +400,39 @@ func (ctx *precheckContext) checkMachines() error {
 	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			if err := ctx.blocker(errors.Errorf("machine %s is %s", machine.Id(), machine.Life())); err != nil {
+				return err
+			}
+			continue
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			if err := ctx.blocker(newStatusError("machine %s not running", machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			err := ctx.blocker(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status))
+			if err != nil {
+				return err
+			}
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			if err := ctx.blocker(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)); err != nil {
+				return err
+			}
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -343,7 +456,12 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.blocker(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, err
+			}
+		}
+		if err := ctx.checkCharm(app); err != nil {
+			return nil, errors.Trace(err)
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -358,16 +476,37 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	return appUnits, nil
 }
 
+// checkCharm ensures that the archive of the application's charm is
+// held by the controller, so that it can be transferred to the target.
+func (ctx *precheckContext) checkCharm(app PrecheckApplication) error {
+	curl, _ := app.CharmURL()
+	ch, err := ctx.backend.Charm(curl)
+	if errors.IsNotFound(err) {
+		return ctx.blocker(errors.Errorf("charm %s for application %s not found", curl, app.Name()))
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving charm for %s", app.Name())
+	}
+	if !ch.IsUploaded() {
+		return ctx.blocker(errors.Errorf("charm %s for application %s is not available", curl, app.Name()))
+	}
+	return nil
+}
+
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.blocker(errors.Errorf("application %s is below its minimum units threshold", app.Name())); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			if err := ctx.blocker(errors.Errorf("unit %s is %s", unit.Name(), unit.Life())); err != nil {
+				return err
+			}
+			continue
 		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
@@ -375,21 +514,23 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 		}
 
 		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 				return errors.Trace(err)
 			}
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			if err := ctx.blocker(errors.Errorf("unit %s is upgrading", unit.Name())); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
 func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
-	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
+	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	statusData, _ := modelPresenceContext.UnitStatus(unit)
 	if statusData.Err != nil {
 		return errors.Annotatef(statusData.Err, "retrieving unit %s status", unit.Name())
@@ -399,20 +540,20 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return ctx.blocker(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return ctx.blocker(errors.Errorf("%s agent binaries don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
 	}
 	return nil
 }
@@ -463,7 +604,9 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 					return errors.Trace(err)
 				}
 				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+					if err := ctx.blocker(errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -396,6 +396,87 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SourcePrecheckSuite) TestCharmNotAvailable(c *gc.C) {
+	backend := newHappyBackend()
+	backend.unavailableCharms = []string{"cs:foo-1"}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "charm cs:foo-1 for application foo is not available")
+}
+
+func (s *SourcePrecheckSuite) TestCharmNotFound(c *gc.C) {
+	backend := newHappyBackend()
+	backend.charmErr = errors.NotFoundf("charm")
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "charm cs:foo-1 for application foo not found")
+}
+
+func (s *SourcePrecheckSuite) TestCharmError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.charmErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving charm for foo: boom")
+}
+
+func (s *SourcePrecheckSuite) TestReportSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	problems, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (s *SourcePrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	backend := &fakeBackend{
+		model: fakeModel{
+			life:      state.Dying,
+			modelType: state.ModelTypeIAAS,
+		},
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", rebootAction: state.ShouldReboot},
+			&fakeMachine{id: "1", life: state.Dying},
+		},
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:     "foo",
+				minunits: 3,
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", agentStatus: status.Failed},
+					&fakeUnit{name: "foo/1", version: version.MustParseBinary("1.2.4-trusty-amd64")},
+				},
+			},
+			&fakeApp{
+				name:     "bar",
+				charmURL: "cs:bar-2",
+				units:    []migration.PrecheckUnit{&fakeUnit{name: "bar/0", charmURL: "cs:bar-2"}},
+			},
+		},
+		unavailableCharms: []string{"cs:bar-2"},
+		cleanupNeeded:     true,
+		controllerBackend: &fakeBackend{isUpgrading: true},
+	}
+	problems, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"model is dying",
+		"machine 0 is scheduled to reboot",
+		"machine 1 is dying",
+		"application foo is below its minimum units threshold",
+		"unit foo/0 not idle or executing (failed)",
+		"unit foo/1 agent binaries don't match model (1.2.4 != 1.2.3)",
+		"charm cs:bar-2 for application bar is not available",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
+func (s *SourcePrecheckSuite) TestReportError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.life = state.Dying
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -623,6 +704,35 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: modelUUID, modelType: state.ModelTypeIAAS},
+			&fakeModel{
+				uuid:      "uuid",
+				name:      modelName,
+				modelType: state.ModelTypeIAAS,
+				owner:     modelOwner,
+			},
+		},
+	}
+	backend := newBackendWithDownMachine()
+	backend.models = pool.uuids()
+	backend.migrationActive = true
+	backend.isUpgrading = true
+	s.modelInfo.AgentVersion.Minor++
+	problems, err := migration.TargetPrecheckReport(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.3.3 > 1.2.3)",
+		"upgrade in progress",
+		"machine 0 agent not functioning at this time (down)",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -749,6 +859,9 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	unavailableCharms []string
+	charmErr          error
+
 	controllerBackend *fakeBackend
 }
 
@@ -796,6 +909,18 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) Charm(curl *charm.URL) (migration.PrecheckCharm, error) {
+	if b.charmErr != nil {
+		return nil, b.charmErr
+	}
+	for _, url := range b.unavailableCharms {
+		if url == curl.String() {
+			return &fakeCharm{}, nil
+		}
+	}
+	return &fakeCharm{uploaded: true}, nil
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -951,6 +1076,14 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+type fakeCharm struct {
+	uploaded bool
+}
+
+func (ch *fakeCharm) IsUploaded() bool {
+	return ch.uploaded
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
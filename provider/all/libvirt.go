@@ -0,0 +1,11 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !minimal provider_libvirt
+
+package all
+
+import (
+	// Register the provider.
+	_ "github.com/juju/juju/provider/libvirt"
+)
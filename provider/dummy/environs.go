@@ -887,6 +887,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Provi
 				MongoSession:     session,
 				NewPolicy:        estate.newStatePolicy,
 				AdminPassword:    icfg.Controller.MongoInfo.Password,
+				SecretsKey:       testing.SecretsKey,
 			})
 			if err != nil {
 				return err
@@ -0,0 +1,139 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+const (
+	// schemeTCP and schemeTLS are the libvirt URI schemes for
+	// unencrypted and TLS connections to a remote libvirt daemon.
+	schemeTCP = "qemu+tcp"
+	schemeTLS = "qemu+tls"
+
+	defaultTCPPort = "16509"
+	defaultTLSPort = "16514"
+)
+
+// DialFunc is a function type for dialing libvirt RPC connections.
+type DialFunc func(_ context.Context, address string, tlsConfig *tls.Config) (Client, error)
+
+// Client is an interface for interacting with the libvirt RPC API.
+type Client interface {
+	Close() error
+	Domains() ([]libvirtclient.Domain, error)
+	DefineDomain(xml string) error
+	StartDomain(name string) error
+	DestroyDomain(name string) error
+	UndefineDomain(name string) error
+	SetDomainMetadata(name, uri, key, xml string) error
+	AttachDevice(name, xml string) error
+	DetachDevice(name, xml string) error
+	Networks() ([]libvirtclient.Network, error)
+	NetworkLeases(name string) ([]libvirtclient.Lease, error)
+	StoragePools() ([]string, error)
+	Volumes(pool string) ([]libvirtclient.Volume, error)
+	CreateVolume(pool, xml string) (libvirtclient.Volume, error)
+	UploadVolume(pool, name string, r io.Reader, length uint64) error
+	DeleteVolume(pool, name string) error
+}
+
+func dialClient(
+	ctx context.Context,
+	cloudSpec environs.CloudSpec,
+	dial DialFunc,
+) (Client, error) {
+	address, useTLS, err := parseEndpoint(cloudSpec.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tlsConfig *tls.Config
+	if useTLS {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tlsConfig, err = clientTLSConfig(cloudSpec.Credential, host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return dial(ctx, address, tlsConfig)
+}
+
+// parseEndpoint parses a cloud endpoint, which may be a libvirt URI
+// such as "qemu+tls://host/system" or a bare host name or address,
+// and returns the address of the libvirt daemon and whether or not
+// the connection must use TLS. A bare host is connected to with TLS;
+// an unencrypted connection requires an explicit "qemu+tcp" URI.
+func parseEndpoint(endpoint string) (address string, useTLS bool, _ error) {
+	if endpoint == "" {
+		return "", false, errors.NotValidf("empty endpoint")
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = schemeTLS + "://" + endpoint + "/system"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false, errors.Annotate(err, "parsing endpoint")
+	}
+	port := u.Port()
+	switch u.Scheme {
+	case schemeTCP:
+		if port == "" {
+			port = defaultTCPPort
+		}
+	case schemeTLS:
+		if port == "" {
+			port = defaultTLSPort
+		}
+		useTLS = true
+	default:
+		return "", false, errors.NotSupportedf("libvirt URI scheme %q", u.Scheme)
+	}
+	if u.Path != "" && u.Path != "/system" {
+		return "", false, errors.NotSupportedf("libvirt URI path %q", u.Path)
+	}
+	if u.Hostname() == "" {
+		return "", false, errors.NotValidf("endpoint %q without host", endpoint)
+	}
+	return net.JoinHostPort(u.Hostname(), port), useTLS, nil
+}
+
+// clientTLSConfig returns the TLS configuration for connecting to the
+// libvirt daemon on the given host using the given certificate
+// credential.
+func clientTLSConfig(credential *cloud.Credential, host string) (*tls.Config, error) {
+	if credential == nil || credential.AuthType() != cloud.CertificateAuthType {
+		return nil, errors.NotValidf("TLS endpoint without %q credential", cloud.CertificateAuthType)
+	}
+	attrs := credential.Attributes()
+	cert, err := tls.X509KeyPair([]byte(attrs[credAttrClientCert]), []byte(attrs[credAttrClientKey]))
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing client certificate")
+	}
+	caCerts := x509.NewCertPool()
+	if !caCerts.AppendCertsFromPEM([]byte(attrs[credAttrCACert])) {
+		return nil, errors.NotValidf("CA certificate")
+	}
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	tlsConfig.RootCAs = caCerts
+	tlsConfig.ServerName = host
+	return tlsConfig, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/testing"
+)
+
+func fakeConfig(c *gc.C, attrs ...testing.Attrs) *config.Config {
+	cfg, err := testing.ModelConfig(c).Apply(fakeConfigAttrs(attrs...))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func fakeConfigAttrs(attrs ...testing.Attrs) testing.Attrs {
+	merged := testing.FakeConfig().Merge(testing.Attrs{
+		"type": "libvirt",
+		"uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	})
+	for _, attrs := range attrs {
+		merged = merged.Merge(attrs)
+	}
+	return merged
+}
+
+func fakeCloudSpec() environs.CloudSpec {
+	cred := cloud.NewEmptyCredential()
+	return environs.CloudSpec{
+		Type:       "libvirt",
+		Name:       "libvirt",
+		Endpoint:   "qemu+tcp://host1/system",
+		Credential: &cred,
+	}
+}
+
+type ConfigSuite struct {
+	testing.BaseSuite
+	config   *config.Config
+	provider environs.EnvironProvider
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.config = fakeConfig(c)
+	s.provider = libvirt.NewEnvironProvider(libvirt.EnvironProviderConfig{})
+}
+
+func (s *ConfigSuite) TestDefaults(c *gc.C) {
+	validCfg, err := s.provider.Validate(s.config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := validCfg.UnknownAttrs()
+	c.Assert(attrs["primary-network"], gc.Equals, "default")
+	c.Assert(attrs["libvirt-pool"], gc.Equals, "default")
+}
+
+var validateConfigTests = []struct {
+	info   string
+	insert testing.Attrs
+	expect testing.Attrs
+	err    string
+}{{
+	info:   "unknown field is not touched",
+	insert: testing.Attrs{"unknown-field": "12345"},
+	expect: testing.Attrs{"unknown-field": "12345"},
+}, {
+	info:   "primary-network can be set",
+	insert: testing.Attrs{"primary-network": "juju"},
+	expect: testing.Attrs{"primary-network": "juju"},
+}, {
+	info:   "libvirt-pool can be set",
+	insert: testing.Attrs{"libvirt-pool": "fast"},
+	expect: testing.Attrs{"libvirt-pool": "fast"},
+}, {
+	info:   "primary-network must not be empty",
+	insert: testing.Attrs{"primary-network": ""},
+	err:    "primary-network: must not be empty",
+}, {
+	info:   "libvirt-pool must not be empty",
+	insert: testing.Attrs{"libvirt-pool": ""},
+	err:    "libvirt-pool: must not be empty",
+}, {
+	info:   "libvirt-pool must be a string",
+	insert: testing.Attrs{"libvirt-pool": 123},
+	err:    `libvirt-pool: expected string, got int\(123\)`,
+}}
+
+func (s *ConfigSuite) TestValidateNewConfig(c *gc.C) {
+	for i, test := range validateConfigTests {
+		c.Logf("test %d: %s", i, test.info)
+
+		cfg := fakeConfig(c, test.insert)
+		validCfg, err := s.provider.Validate(cfg, nil)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, "invalid config: "+test.err)
+			continue
+		}
+		if !c.Check(err, jc.ErrorIsNil) {
+			continue
+		}
+		attrs := validCfg.AllAttrs()
+		for field, value := range test.expect {
+			c.Check(attrs[field], gc.Equals, value)
+		}
+	}
+}
+
+func (s *ConfigSuite) TestValidateChange(c *gc.C) {
+	for i, test := range validateConfigTests {
+		c.Logf("test %d: %s", i, test.info)
+
+		cfg := fakeConfig(c, test.insert)
+		validCfg, err := s.provider.Validate(cfg, s.config)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, "invalid config change: "+test.err)
+			continue
+		}
+		if !c.Check(err, jc.ErrorIsNil) {
+			continue
+		}
+		attrs := validCfg.AllAttrs()
+		for field, value := range test.expect {
+			c.Check(attrs[field], gc.Equals, value)
+		}
+	}
+}
+
+func (s *ConfigSuite) TestSetConfig(c *gc.C) {
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: s.config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = env.SetConfig(fakeConfig(c, testing.Attrs{"primary-network": "juju"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Config().UnknownAttrs()["primary-network"], gc.Equals, "juju")
+
+	err = env.SetConfig(fakeConfig(c, testing.Attrs{"libvirt-pool": ""}))
+	c.Assert(err, gc.ErrorMatches, "invalid config change: libvirt-pool: must not be empty")
+	c.Assert(env.Config().UnknownAttrs()["primary-network"], gc.Equals, "juju")
+}
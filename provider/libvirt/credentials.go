@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+const (
+	credAttrCACert     = "ca-cert"
+	credAttrClientCert = "client-cert"
+	credAttrClientKey  = "client-key"
+)
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.CertificateAuthType: {
+			{
+				Name: credAttrCACert,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "the path to the PEM-encoded certificate of the CA which signed the libvirtd certificate",
+					ExpandFilePath: true,
+				},
+			}, {
+				Name: credAttrClientCert,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "the path to the PEM-encoded libvirt client certificate file",
+					ExpandFilePath: true,
+				},
+			}, {
+				Name: credAttrClientKey,
+				CredentialAttr: cloud.CredentialAttr{
+					Description:    "the path to the PEM-encoded libvirt client key file",
+					ExpandFilePath: true,
+					Hidden:         true,
+				},
+			},
+		},
+		cloud.EmptyAuthType: {},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
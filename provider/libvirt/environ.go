@@ -0,0 +1,311 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	callcontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environ struct {
+	name     string
+	cloud    environs.CloudSpec
+	provider *environProvider
+
+	// namespace is used to create the machine and volume names.
+	namespace instance.Namespace
+
+	// imageMutex serialises the caching of cloud images, so that
+	// concurrent StartInstance calls do not upload the same image.
+	imageMutex sync.Mutex
+
+	lock sync.Mutex // lock protects access the following fields.
+	ecfg *environConfig
+}
+
+func newEnviron(
+	provider *environProvider,
+	cloud environs.CloudSpec,
+	cfg *config.Config,
+) (*environ, error) {
+	ecfg, err := newValidConfig(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	env := &environ{
+		name:      ecfg.Name(),
+		cloud:     cloud,
+		provider:  provider,
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	return env, nil
+}
+
+func (env *environ) withClient(f func(Client) error) error {
+	client, err := dialClient(context.Background(), env.cloud, env.provider.dial)
+	if err != nil {
+		return errors.Annotate(err, "dialing client")
+	}
+	defer client.Close()
+	return f(client)
+}
+
+// Name is part of the environs.Environ interface.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider is part of the environs.Environ interface.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig is part of the environs.Environ interface.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.ecfg == nil {
+		return errors.New("cannot set config on uninitialized env")
+	}
+
+	if err := env.ecfg.update(cfg); err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	return nil
+}
+
+// Config is part of the environs.Environ interface.
+func (env *environ) Config() *config.Config {
+	return env.envConfig().Config
+}
+
+func (env *environ) envConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(ctx callcontext.ProviderCallContext, args environs.CreateParams) error {
+	return env.withClient(env.checkResources)
+}
+
+// checkResources checks that the network and storage pool named in
+// the model config exist.
+func (env *environ) checkResources(client Client) error {
+	ecfg := env.envConfig()
+	if err := checkNetwork(client, ecfg.primaryNetwork()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(checkStoragePool(client, ecfg.storagePool()))
+}
+
+func checkNetwork(client Client, name string) error {
+	networks, err := client.Networks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, n := range networks {
+		if n.Name == name {
+			return nil
+		}
+	}
+	return errors.NotFoundf("network %q", name)
+}
+
+func checkStoragePool(client Client, name string) error {
+	pools, err := client.StoragePools()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !set.NewStrings(pools...).Contains(name) {
+		return errors.NotFoundf("storage pool %q", name)
+	}
+	return nil
+}
+
+// Bootstrap is exported, because it has to be rewritten in external unit tests
+var Bootstrap = common.Bootstrap
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(
+	ctx environs.BootstrapContext,
+	callCtx callcontext.ProviderCallContext,
+	args environs.BootstrapParams,
+) (result *environs.BootstrapResult, err error) {
+	if err := env.withClient(env.checkResources); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return Bootstrap(ctx, env, callCtx, args)
+}
+
+// DestroyEnv is exported, because it has to be rewritten in external unit tests.
+var DestroyEnv = common.Destroy
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(ctx callcontext.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	return env.withClient(func(client Client) error {
+		domains, err := env.modelDomains(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, d := range domains {
+			instanceTags := d.def.tags()
+			instanceTags[tags.JujuController] = controllerUUID
+			metadata, err := marshalXML(newDomainTags(instanceTags))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := client.SetDomainMetadata(d.Name, metadataNamespace, metadataKey, metadata); err != nil {
+				return errors.Annotatef(err, "updating tags of domain %q", d.Name)
+			}
+		}
+		return nil
+	})
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy(ctx callcontext.ProviderCallContext) error {
+	return errors.Trace(DestroyEnv(env, ctx))
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(ctx callcontext.ProviderCallContext, controllerUUID string) error {
+	if err := env.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	return env.withClient(func(client Client) error {
+		// Remove the machines of the controller's hosted models,
+		// which have not otherwise been cleaned up.
+		domains, err := env.domains(client, func(def *domain) bool {
+			return def.tags()[tags.JujuController] == controllerUUID
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, d := range domains {
+			if err := removeDomain(client, d); err != nil {
+				return errors.Trace(err)
+			}
+		}
+
+		// Remove the controller's cached images. The storage
+		// pool may have changed over time, so check them all.
+		pools, err := client.StoragePools()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		prefix := imageVolumePrefix(controllerUUID)
+		for _, pool := range pools {
+			volumes, err := client.Volumes(pool)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, v := range volumes {
+				if !strings.HasPrefix(v.Name, prefix) {
+					continue
+				}
+				logger.Debugf("deleting cached image %q from storage pool %q", v.Name, pool)
+				if err := client.DeleteVolume(pool, v.Name); err != nil {
+					return errors.Annotatef(err, "deleting cached image %q", v.Name)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// modelDomain is a domain along with its parsed XML definition.
+type modelDomain struct {
+	libvirtclient.Domain
+	def *domain
+}
+
+// modelDomains returns the domains belonging to the model.
+func (env *environ) modelDomains(client Client) ([]modelDomain, error) {
+	modelUUID := env.Config().UUID()
+	return env.domains(client, func(def *domain) bool {
+		return def.tags()[tags.JujuModel] == modelUUID
+	})
+}
+
+// domains returns the domains whose definitions satisfy match.
+func (env *environ) domains(client Client, match func(*domain) bool) ([]modelDomain, error) {
+	domains, err := client.Domains()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []modelDomain
+	for _, d := range domains {
+		def, err := parseDomain(d.XML)
+		if err != nil {
+			return nil, errors.Annotatef(err, "domain %q", d.Name)
+		}
+		if match(def) {
+			result = append(result, modelDomain{Domain: d, def: def})
+		}
+	}
+	return result, nil
+}
+
+// removeDomain stops and undefines the domain, and deletes the root
+// disk and seed image volumes created for it. Any other volumes
+// attached to the domain are left alone.
+func removeDomain(client Client, d modelDomain) error {
+	if d.Running {
+		if err := client.DestroyDomain(d.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := client.UndefineDomain(d.Name); err != nil {
+		return errors.Trace(err)
+	}
+	ownVolumes := set.NewStrings(rootVolumeName(d.Name), seedVolumeName(d.Name))
+	for _, disk := range d.def.Devices.Disks {
+		if !ownVolumes.Contains(disk.Source.Volume) {
+			continue
+		}
+		err := client.DeleteVolume(disk.Source.Pool, disk.Source.Volume)
+		if err != nil {
+			return errors.Annotatef(err, "deleting volume %q of domain %q", disk.Source.Volume, d.Name)
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,523 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	// defaultMemoryMiB and defaultCpuCores are the resources given to
+	// machines whose constraints do not specify them.
+	defaultMemoryMiB uint64 = 2048
+	defaultCpuCores  uint64 = 1
+)
+
+// domainArches maps the architectures supported by the provider to
+// the names libvirt uses for them.
+var domainArches = map[string]string{
+	arch.AMD64: "x86_64",
+}
+
+// imageVolumePrefix returns the prefix of the names of the volumes in
+// which the cloud images used by the controller's machines are cached.
+func imageVolumePrefix(controllerUUID string) string {
+	return fmt.Sprintf("juju-image-%s-", controllerUUID)
+}
+
+// imageVolumeName returns the name of the volume in which the given
+// cloud image is cached. The image's checksum is included so that a
+// new volume is created whenever the image is rebuilt.
+func imageVolumeName(controllerUUID string, img *imagedownloads.Metadata) string {
+	checksum := img.SHA256
+	if len(checksum) > 12 {
+		checksum = checksum[:12]
+	}
+	return fmt.Sprintf("%s%s-%s-%s.img", imageVolumePrefix(controllerUUID), img.Release, img.Arch, checksum)
+}
+
+// rootVolumeName returns the name of the volume holding the root disk
+// of the named machine.
+func rootVolumeName(hostname string) string {
+	return hostname + "-root"
+}
+
+// seedVolumeName returns the name of the volume holding the cloud-init
+// seed image of the named machine.
+func seedVolumeName(hostname string) string {
+	return hostname + "-seed.iso"
+}
+
+// interfaceName returns the name given to the i'th network interface
+// of a machine.
+func interfaceName(i int) string {
+	return fmt.Sprintf("eth%d", i)
+}
+
+// generateMAC returns a random MAC address in the range which libvirt
+// uses for the interfaces of QEMU/KVM domains.
+func generateMAC() (string, error) {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", b[0], b[1], b[2]), nil
+}
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	img, err := findImageMetadata(env, args)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	if err := env.finishMachineConfig(args, img); err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
+	var result *environs.StartInstanceResult
+	err = env.withClient(func(client Client) error {
+		d, hw, err := env.newRawInstance(client, args, img)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("started instance %q", d.Name)
+		result = &environs.StartInstanceResult{
+			Instance: newInstance(d, nil, env),
+			Hardware: hw,
+		}
+		return nil
+	})
+	if err != nil {
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// FinishInstanceConfig is exported, because it has to be rewritten in external unit tests
+var FinishInstanceConfig = instancecfg.FinishInstanceConfig
+
+// finishMachineConfig updates args.MachineConfig in place. Setting up
+// the API, StateServing, and SSHkeys information.
+func (env *environ) finishMachineConfig(args environs.StartInstanceParams, img *imagedownloads.Metadata) error {
+	envTools, err := args.Tools.Match(tools.Filter{Arch: img.Arch})
+	if err != nil {
+		return err
+	}
+	if err := args.InstanceConfig.SetTools(envTools); err != nil {
+		return errors.Trace(err)
+	}
+	return FinishInstanceConfig(args.InstanceConfig, env.Config())
+}
+
+// newRawInstance is where the new physical instance is actually
+// provisioned, relative to the provided args and image. The domain
+// and its hardware characteristics are returned.
+func (env *environ) newRawInstance(
+	client Client,
+	args environs.StartInstanceParams,
+	img *imagedownloads.Metadata,
+) (_ modelDomain, _ *instance.HardwareCharacteristics, err error) {
+	domainArch, ok := domainArches[img.Arch]
+	if !ok {
+		return modelDomain{}, nil, errors.NotSupportedf("architecture %q", img.Arch)
+	}
+
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return modelDomain{}, nil, errors.Trace(err)
+	}
+
+	// Obtain the final constraints by merging with defaults.
+	cons := args.Constraints
+	if cons.Mem == nil {
+		mem := defaultMemoryMiB
+		cons.Mem = &mem
+	}
+	if cons.CpuCores == nil {
+		cores := defaultCpuCores
+		cons.CpuCores = &cores
+	}
+	minRootDisk := common.MinRootDiskSizeGiB(args.InstanceConfig.Series) * 1024
+	if cons.RootDisk == nil || *cons.RootDisk < minRootDisk {
+		cons.RootDisk = &minRootDisk
+	}
+	if cons.RootDiskSource == nil || *cons.RootDiskSource == "" {
+		pool := env.envConfig().storagePool()
+		cons.RootDiskSource = &pool
+	}
+	pool := *cons.RootDiskSource
+
+	networks := env.instanceNetworks(args)
+	macAddresses := make([]string, len(networks))
+	for i := range networks {
+		if macAddresses[i], err = generateMAC(); err != nil {
+			return modelDomain{}, nil, errors.Trace(err)
+		}
+	}
+
+	series := args.Tools.OneSeries()
+	cloudcfg, err := cloudinit.New(series)
+	if err != nil {
+		return modelDomain{}, nil, errors.Trace(err)
+	}
+	// Make sure the hostname is resolvable by adding it to /etc/hosts.
+	cloudcfg.ManageEtcHosts(true)
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, libvirtRenderer{})
+	if err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "cannot make user data")
+	}
+	logger.Debugf("libvirt user data; %d bytes", len(userData))
+	seed, err := newSeedImage(hostname, userData, macAddresses)
+	if err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "cannot make seed image")
+	}
+
+	image, err := env.ensureImage(client, args, img)
+	if err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "caching image")
+	}
+
+	// Remove anything created below if the domain is not started.
+	var createdVolumes []string
+	var defined bool
+	defer func() {
+		if err == nil {
+			return
+		}
+		if defined {
+			if err := client.UndefineDomain(hostname); err != nil {
+				logger.Errorf("failed to undefine domain %q: %v", hostname, err)
+			}
+		}
+		for _, name := range createdVolumes {
+			if err := client.DeleteVolume(pool, name); err != nil {
+				logger.Errorf("failed to delete volume %q: %v", name, err)
+			}
+		}
+	}()
+
+	args.StatusCallback(status.Provisioning, "creating root disk", nil)
+	rootVolume, err := marshalXML(storageVolume{
+		Name:     rootVolumeName(hostname),
+		Capacity: storageVolumeSize{Unit: "MiB", Value: *cons.RootDisk},
+		Target: &storageVolumeTarget{
+			Format: &storageVolumeFormat{Type: "qcow2"},
+		},
+		BackingStore: &storageVolumeTarget{
+			Path:   image.Path,
+			Format: &storageVolumeFormat{Type: "qcow2"},
+		},
+	})
+	if err != nil {
+		return modelDomain{}, nil, errors.Trace(err)
+	}
+	if _, err := client.CreateVolume(pool, rootVolume); err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "creating root disk")
+	}
+	createdVolumes = append(createdVolumes, rootVolumeName(hostname))
+
+	seedVolume, err := marshalXML(storageVolume{
+		Name:     seedVolumeName(hostname),
+		Capacity: storageVolumeSize{Unit: "bytes", Value: uint64(len(seed))},
+		Target: &storageVolumeTarget{
+			Format: &storageVolumeFormat{Type: "raw"},
+		},
+	})
+	if err != nil {
+		return modelDomain{}, nil, errors.Trace(err)
+	}
+	if _, err := client.CreateVolume(pool, seedVolume); err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "creating seed image")
+	}
+	createdVolumes = append(createdVolumes, seedVolumeName(hostname))
+	if err := client.UploadVolume(pool, seedVolumeName(hostname), bytes.NewReader(seed), uint64(len(seed))); err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "uploading seed image")
+	}
+
+	def := newDomain(hostname, domainArch, cons.Mem, cons.CpuCores, pool, networks, macAddresses, args.InstanceConfig.Tags)
+	domainXML, err := marshalXML(def)
+	if err != nil {
+		return modelDomain{}, nil, errors.Trace(err)
+	}
+	args.StatusCallback(status.Provisioning, "starting machine", nil)
+	if err := client.DefineDomain(domainXML); err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "defining domain")
+	}
+	defined = true
+	if err := client.StartDomain(hostname); err != nil {
+		return modelDomain{}, nil, errors.Annotate(err, "starting domain")
+	}
+
+	d := modelDomain{
+		Domain: libvirtclient.Domain{
+			Name:    hostname,
+			State:   "running",
+			Running: true,
+			XML:     domainXML,
+		},
+		def: def,
+	}
+	hw := &instance.HardwareCharacteristics{
+		Arch:           &img.Arch,
+		Mem:            cons.Mem,
+		CpuCores:       cons.CpuCores,
+		RootDisk:       cons.RootDisk,
+		RootDiskSource: cons.RootDiskSource,
+	}
+	return d, hw, nil
+}
+
+// instanceNetworks returns the names of the libvirt networks to which
+// the machine's interfaces are attached. The first is always the
+// primary network; the others are those of the subnets and spaces
+// the machine must be reachable in.
+func (env *environ) instanceNetworks(args environs.StartInstanceParams) []string {
+	primary := env.envConfig().primaryNetwork()
+	networks := []string{primary}
+	seen := set.NewStrings(primary)
+	add := func(name string) {
+		if name == "" || seen.Contains(name) {
+			return
+		}
+		seen.Add(name)
+		networks = append(networks, name)
+	}
+	for _, subnetsToZones := range args.SubnetsToZones {
+		var subnetNetworks []string
+		for subnetID := range subnetsToZones {
+			subnetNetworks = append(subnetNetworks, subnetNetworkName(string(subnetID)))
+		}
+		for _, name := range set.NewStrings(subnetNetworks...).SortedValues() {
+			add(name)
+		}
+	}
+	var boundNetworks []string
+	for _, spaceID := range args.EndpointBindings {
+		boundNetworks = append(boundNetworks, string(spaceID))
+	}
+	for _, name := range set.NewStrings(boundNetworks...).SortedValues() {
+		add(name)
+	}
+	return networks
+}
+
+// newDomain returns the definition of a KVM domain, which boots from
+// the root disk in the given pool, with the machine's seed image
+// attached as a CD-ROM, and with an interface on each of the given
+// networks.
+func newDomain(
+	hostname, domainArch string,
+	memMiB, cpuCores *uint64,
+	pool string,
+	networks, macAddresses []string,
+	instanceTags map[string]string,
+) *domain {
+	def := &domain{
+		Type:     "kvm",
+		Name:     hostname,
+		Metadata: &domainMetadata{Tags: newDomainTags(instanceTags)},
+		Memory:   domainMemory{Unit: "MiB", Value: *memMiB},
+		VCPU:     *cpuCores,
+		OS: domainOS{
+			Type: domainOSType{Arch: domainArch, Value: "hvm"},
+			Boot: []domainBoot{{Dev: "hd"}},
+		},
+		Features: &domainFeatures{ACPI: &struct{}{}, APIC: &struct{}{}},
+		CPU:      &domainCPU{Mode: "host-model"},
+		Devices: domainDevices{
+			Disks: []domainDisk{{
+				Type:   "volume",
+				Device: "disk",
+				Driver: domainDiskDriver{Name: "qemu", Type: "qcow2"},
+				Source: domainDiskSource{Pool: pool, Volume: rootVolumeName(hostname)},
+				Target: domainDiskTarget{Dev: "vda", Bus: "virtio"},
+			}, {
+				Type:     "volume",
+				Device:   "cdrom",
+				Driver:   domainDiskDriver{Name: "qemu", Type: "raw"},
+				Source:   domainDiskSource{Pool: pool, Volume: seedVolumeName(hostname)},
+				Target:   domainDiskTarget{Dev: "sda", Bus: "sata"},
+				ReadOnly: &struct{}{},
+			}},
+			Serials:  []domainSerial{{Type: "pty", Target: domainSerialTarget{Port: 0}}},
+			Consoles: []domainConsole{{Type: "pty", Target: domainConsoleTarget{Type: "serial", Port: 0}}},
+		},
+	}
+	for i, name := range networks {
+		def.Devices.Interfaces = append(def.Devices.Interfaces, domainInterface{
+			Type:   "network",
+			MAC:    domainInterfaceMAC{Address: macAddresses[i]},
+			Source: domainInterfaceSource{Network: name},
+			Model:  domainInterfaceModel{Type: "virtio"},
+		})
+	}
+	return def
+}
+
+// ensureImage returns the volume in which the given cloud image is
+// cached, downloading the image into the storage pool if it is not
+// already there.
+func (env *environ) ensureImage(
+	client Client,
+	args environs.StartInstanceParams,
+	img *imagedownloads.Metadata,
+) (libvirtclient.Volume, error) {
+	env.imageMutex.Lock()
+	defer env.imageMutex.Unlock()
+
+	pool := env.envConfig().storagePool()
+	name := imageVolumeName(args.ControllerUUID, img)
+	volumes, err := client.Volumes(pool)
+	if err != nil {
+		return libvirtclient.Volume{}, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+
+	imageURL, err := img.DownloadURL()
+	if err != nil {
+		return libvirtclient.Volume{}, errors.Trace(err)
+	}
+	args.StatusCallback(status.Provisioning, fmt.Sprintf("downloading image %s", imageURL), nil)
+	logger.Debugf("downloading image %q to volume %q", imageURL, name)
+	resp, err := http.Get(imageURL.String())
+	if err != nil {
+		return libvirtclient.Volume{}, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return libvirtclient.Volume{}, errors.Errorf("downloading %s: %s", imageURL, resp.Status)
+	}
+
+	// The image is uploaded as is: libvirt treats the volume as raw
+	// data, and the root disks refer to it as a qcow2 backing file.
+	volumeXML, err := marshalXML(storageVolume{
+		Name:     name,
+		Capacity: storageVolumeSize{Unit: "bytes", Value: uint64(img.Size)},
+		Target: &storageVolumeTarget{
+			Format: &storageVolumeFormat{Type: "raw"},
+		},
+	})
+	if err != nil {
+		return libvirtclient.Volume{}, errors.Trace(err)
+	}
+	volume, err := client.CreateVolume(pool, volumeXML)
+	if err != nil {
+		return libvirtclient.Volume{}, errors.Annotate(err, "creating image volume")
+	}
+
+	hash := sha256.New()
+	body := io.TeeReader(io.LimitReader(resp.Body, img.Size), hash)
+	err = client.UploadVolume(pool, name, body, uint64(img.Size))
+	if err == nil {
+		if checksum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(checksum, img.SHA256) {
+			err = errors.Errorf("SHA256 hash mismatch (%s != %s)", checksum, img.SHA256)
+		}
+	}
+	if err != nil {
+		if err := client.DeleteVolume(pool, name); err != nil {
+			logger.Errorf("failed to delete image volume %q: %v", name, err)
+		}
+		return libvirtclient.Volume{}, errors.Annotate(err, "uploading image")
+	}
+	return volume, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances(ctx context.ProviderCallContext) (results []instances.Instance, err error) {
+	err = env.withClient(func(client Client) error {
+		domains, err := env.modelDomains(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		leases, err := allLeases(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, d := range domains {
+			results = append(results, newInstance(d, leases, env))
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+// AllRunningInstances implements environs.InstanceBroker.
+func (env *environ) AllRunningInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	// AllInstances() already handles all instances irrespective of the state, so
+	// here 'all' is also 'all running'.
+	return env.AllInstances(ctx)
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	return env.withClient(func(client Client) error {
+		domains, err := env.modelDomains(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		byName := make(map[string]modelDomain)
+		for _, d := range domains {
+			byName[d.Name] = d
+		}
+
+		var errIds []instance.Id
+		var errs []error
+		for _, id := range ids {
+			d, ok := byName[string(id)]
+			if !ok {
+				// Already gone.
+				continue
+			}
+			if err := removeDomain(client, d); err != nil {
+				errIds = append(errIds, id)
+				errs = append(errs, err)
+			}
+		}
+		switch len(errs) {
+		case 0:
+			return nil
+		case 1:
+			return errors.Annotatef(errs[0], "failed to stop instance %s", errIds[0])
+		default:
+			return errors.Errorf(
+				"failed to stop instances %s: %s",
+				errIds, errs,
+			)
+		}
+	})
+}
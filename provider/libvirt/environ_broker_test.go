@@ -0,0 +1,335 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environBrokerSuite struct {
+	EnvironFixture
+	statusCallbackStub testing.Stub
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.statusCallbackStub.ResetCalls()
+}
+
+func (s *environBrokerSuite) createStartInstanceArgs(c *gc.C) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "trusty", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.AuthorizedKeys = fakeConfig(c).AuthorizedKeys()
+	instanceConfig.Tags = modelTags(true)
+
+	tools := coretools.List{{
+		Version: version.Binary{
+			Number: version.MustParse("1.2.3"),
+			Arch:   arch.AMD64,
+			Series: "trusty",
+		},
+		URL: "https://example.org",
+	}}
+	err = instanceConfig.SetTools(tools)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return environs.StartInstanceParams{
+		ControllerUUID: instanceConfig.Controller.Config.ControllerUUID(),
+		InstanceConfig: instanceConfig,
+		Tools:          tools,
+		Constraints:    cons,
+		StatusCallback: func(status status.Status, info string, data map[string]interface{}) error {
+			s.statusCallbackStub.AddCall("StatusCallback", status, info, data)
+			return s.statusCallbackStub.NextErr()
+		},
+	}
+}
+
+func imageVolumeName() string {
+	return "juju-image-" + fakeControllerUUID + "-trusty-amd64-" + fakeImageSHA256()[:12] + ".img"
+}
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	result, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.Instance, gc.NotNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+
+	s.client.CheckCallNames(c,
+		"Volumes",
+		"CreateVolume", "UploadVolume", // image
+		"CreateVolume",                 // root disk
+		"CreateVolume", "UploadVolume", // seed image
+		"DefineDomain", "StartDomain",
+		"Close",
+	)
+	calls := s.client.Calls()
+
+	// The image is cached in the model's storage pool.
+	c.Assert(calls[0].Args, jc.DeepEquals, []interface{}{"default"})
+	c.Assert(calls[1].Args[0], gc.Equals, "default")
+	c.Assert(calls[1].Args[1], jc.Contains, "<name>"+imageVolumeName()+"</name>")
+	c.Assert(s.client.uploaded[imageVolumeName()], jc.DeepEquals, fakeImageContents)
+
+	// The root disk is backed by the cached image.
+	c.Assert(calls[3].Args[1], jc.Contains, "<name>juju-f75cba-0-root</name>")
+	c.Assert(calls[3].Args[1], jc.Contains,
+		"<backingStore><path>/var/lib/libvirt/images/"+imageVolumeName()+"</path>",
+	)
+
+	c.Assert(calls[4].Args[1], jc.Contains, "<name>juju-f75cba-0-seed.iso</name>")
+	c.Assert(s.client.uploaded["juju-f75cba-0-seed.iso"], gc.Not(gc.HasLen), 0)
+
+	domainXML := calls[6].Args[0].(string)
+	c.Assert(domainXML, jc.Contains, "<name>juju-f75cba-0</name>")
+	c.Assert(domainXML, jc.Contains, `<source pool="default" volume="juju-f75cba-0-root"></source>`)
+	c.Assert(domainXML, jc.Contains, `<source network="default"></source>`)
+	c.Assert(domainXML, jc.Contains, `<tags xmlns="https://juju.is/libvirt/1"><tag key="juju-controller-uuid">`)
+	c.Assert(domainXML, jc.Contains, `<tag key="juju-is-controller">true</tag>`)
+	c.Assert(calls[7].Args, jc.DeepEquals, []interface{}{"juju-f75cba-0"})
+
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback", "StatusCallback", "StatusCallback")
+	c.Assert(s.statusCallbackStub.Calls()[0].Args[1], gc.Matches, "downloading image .*-disk1.img")
+	c.Assert(s.statusCallbackStub.Calls()[1].Args[1], gc.Equals, "creating root disk")
+	c.Assert(s.statusCallbackStub.Calls()[2].Args[1], gc.Equals, "starting machine")
+}
+
+func (s *environBrokerSuite) TestStartInstanceCachedImage(c *gc.C) {
+	s.client.volumes = map[string][]libvirtclient.Volume{
+		"default": {{
+			Pool: "default",
+			Name: imageVolumeName(),
+			Path: "/var/lib/libvirt/images/" + imageVolumeName(),
+		}},
+	}
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c,
+		"Volumes",
+		"CreateVolume",
+		"CreateVolume", "UploadVolume",
+		"DefineDomain", "StartDomain",
+		"Close",
+	)
+	for _, req := range s.imageServerRequests {
+		c.Assert(req.URL.Path, gc.Not(jc.HasSuffix), "-disk1.img")
+	}
+}
+
+func (s *environBrokerSuite) TestStartInstanceStartDomainError(c *gc.C) {
+	s.client.SetErrors(nil, nil, nil, nil, nil, nil, nil, errors.New("boom"))
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, gc.ErrorMatches, "starting domain: boom")
+
+	// The domain and the volumes created for it are removed, but
+	// the cached image is kept for the next machine.
+	s.client.CheckCallNames(c,
+		"Volumes",
+		"CreateVolume", "UploadVolume",
+		"CreateVolume",
+		"CreateVolume", "UploadVolume",
+		"DefineDomain", "StartDomain",
+		"UndefineDomain", "DeleteVolume", "DeleteVolume",
+		"Close",
+	)
+	s.client.CheckCall(c, 8, "UndefineDomain", "juju-f75cba-0")
+	s.client.CheckCall(c, 9, "DeleteVolume", "default", "juju-f75cba-0-root")
+	s.client.CheckCall(c, 10, "DeleteVolume", "default", "juju-f75cba-0-seed.iso")
+
+	calls := s.statusCallbackStub.Calls()
+	c.Assert(calls[len(calls)-1].Args, jc.DeepEquals, []interface{}{
+		status.ProvisioningError, "starting domain: boom", map[string]interface{}(nil),
+	})
+}
+
+func (s *environBrokerSuite) TestStartInstanceNetworks(c *gc.C) {
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.SubnetsToZones = []map[corenetwork.Id][]string{{
+		"internal:10.0.0.0/24": nil,
+	}}
+	startInstArgs.EndpointBindings = map[string]corenetwork.Id{
+		"db":      "storage",
+		"website": "default",
+	}
+	_, err := s.env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.client.Calls()
+	domainXML := calls[len(calls)-3].Args[0].(string)
+	c.Assert(domainXML, gc.Matches, `(?s).*`+
+		`<source network="default"></source>.*`+
+		`<source network="internal"></source>.*`+
+		`<source network="storage"></source>.*`,
+	)
+}
+
+func (s *environBrokerSuite) TestStartInstanceDefaultConstraintsApplied(c *gc.C) {
+	res, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var (
+		arch     = "amd64"
+		mem      = uint64(2048)
+		cpuCores = uint64(1)
+		rootDisk = common.MinRootDiskSizeGiB("trusty") * 1024
+		pool     = "default"
+	)
+	c.Assert(res.Hardware, jc.DeepEquals, &instance.HardwareCharacteristics{
+		Arch:           &arch,
+		Mem:            &mem,
+		CpuCores:       &cpuCores,
+		RootDisk:       &rootDisk,
+		RootDiskSource: &pool,
+	})
+}
+
+func (s *environBrokerSuite) TestStartInstanceCustomConstraintsApplied(c *gc.C) {
+	var (
+		cpuCores uint64 = 4
+		mem      uint64 = 4096
+		rootDisk uint64 = 20480
+		source          = "fast"
+	)
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints.CpuCores = &cpuCores
+	startInstArgs.Constraints.Mem = &mem
+	startInstArgs.Constraints.RootDisk = &rootDisk
+	startInstArgs.Constraints.RootDiskSource = &source
+
+	res, err := s.env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	arch := "amd64"
+	c.Assert(res.Hardware, jc.DeepEquals, &instance.HardwareCharacteristics{
+		Arch:           &arch,
+		CpuCores:       &cpuCores,
+		Mem:            &mem,
+		RootDisk:       &rootDisk,
+		RootDiskSource: &source,
+	})
+
+	// The cached image stays in the model's storage pool, while the
+	// machine's own volumes are created in the requested one.
+	calls := s.client.Calls()
+	c.Assert(calls[1].Args[0], gc.Equals, "default")
+	c.Assert(calls[3].Args[0], gc.Equals, "fast")
+	c.Assert(calls[3].Args[1], jc.Contains, `<capacity unit="MiB">20480</capacity>`)
+	c.Assert(calls[4].Args[0], gc.Equals, "fast")
+	domainXML := calls[6].Args[0].(string)
+	c.Assert(domainXML, jc.Contains, `<memory unit="MiB">4096</memory>`)
+	c.Assert(domainXML, jc.Contains, `<vcpu>4</vcpu>`)
+}
+
+func (s *environBrokerSuite) TestStartInstanceCallsFinishMachineConfig(c *gc.C) {
+	startInstArgs := s.createStartInstanceArgs(c)
+	s.PatchValue(&libvirt.FinishInstanceConfig, func(mcfg *instancecfg.InstanceConfig, cfg *config.Config) (err error) {
+		return errors.New("FinishMachineConfig called")
+	})
+	_, err := s.env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, gc.ErrorMatches, "FinishMachineConfig called")
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(true)),
+		newDomain("juju-f75cba-1", false, modelTags(false),
+			diskXML("default", "juju-f75cba-volume-0", "vdb", "volume-0"),
+		),
+		newDomain("juju-f75cba-2", true, modelTags(false)),
+	}
+
+	err := s.env.StopInstances(s.callCtx, "juju-f75cba-1", "juju-f75cba-3")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the volumes created for the machine are deleted, and
+	// machines which are not running are not destroyed.
+	s.client.CheckCalls(c, []testing.StubCall{
+		{"Domains", nil},
+		{"UndefineDomain", []interface{}{"juju-f75cba-1"}},
+		{"DeleteVolume", []interface{}{"default", "juju-f75cba-1-root"}},
+		{"DeleteVolume", []interface{}{"default", "juju-f75cba-1-seed.iso"}},
+		{"Close", nil},
+	})
+}
+
+func (s *environBrokerSuite) TestStopInstancesErrors(c *gc.C) {
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(false)),
+		newDomain("juju-f75cba-1", true, modelTags(false)),
+	}
+	s.client.SetErrors(nil, errors.New("boom"), errors.New("bang"))
+
+	err := s.env.StopInstances(s.callCtx, "juju-f75cba-0", "juju-f75cba-1")
+	c.Assert(err, gc.ErrorMatches,
+		`failed to stop instances \[juju-f75cba-0 juju-f75cba-1\]: \[boom bang\]`,
+	)
+}
+
+func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
+	otherModelTags := map[string]string{
+		tags.JujuModel: "0f5a3e8c-22d8-4b8f-8d8a-2f0d1c6a9b3e",
+	}
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(true),
+			interfaceXML("52:54:00:12:34:56", "default"),
+		),
+		newDomain("juju-9b3e-0", true, otherModelTags),
+		newDomain("juju-f75cba-1", false, modelTags(false)),
+	}
+	s.client.leases = map[string][]libvirtclient.Lease{
+		"default": {{
+			MACAddress: "52:54:00:12:34:56",
+			IPAddress:  "192.168.122.10",
+			Prefix:     24,
+		}},
+	}
+
+	insts, err := s.env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+	c.Assert(insts[1].Id(), gc.Equals, instance.Id("juju-f75cba-1"))
+
+	c.Assert(insts[0].Status(s.callCtx).Status, gc.Equals, status.Running)
+	c.Assert(insts[1].Status(s.callCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Empty,
+		Message: "shut off",
+	})
+
+	addrs, err := insts[0].Addresses(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, corenetwork.ProviderAddresses{
+		corenetwork.NewScopedProviderAddress("192.168.122.10", corenetwork.ScopeCloudLocal),
+	})
+
+	s.client.CheckCallNames(c, "Domains", "Networks", "NetworkLeases", "Close")
+	s.client.CheckCall(c, 2, "NetworkLeases", "default")
+}
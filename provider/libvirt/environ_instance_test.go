@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environInstanceSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environInstanceSuite{})
+
+func (s *environInstanceSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(true)),
+		newDomain("juju-f75cba-1", true, modelTags(false)),
+	}
+}
+
+func (s *environInstanceSuite) TestInstances(c *gc.C) {
+	insts, err := s.env.Instances(s.callCtx, []instance.Id{"juju-f75cba-1", "juju-f75cba-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id("juju-f75cba-1"))
+	c.Assert(insts[1].Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+}
+
+func (s *environInstanceSuite) TestInstancesPartial(c *gc.C) {
+	insts, err := s.env.Instances(s.callCtx, []instance.Id{"juju-f75cba-0", "juju-f75cba-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+	c.Assert(insts[1], gc.IsNil)
+}
+
+func (s *environInstanceSuite) TestInstancesNone(c *gc.C) {
+	_, err := s.env.Instances(s.callCtx, []instance.Id{"juju-f75cba-2"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environInstanceSuite) TestControllerInstances(c *gc.C) {
+	ids, err := s.env.ControllerInstances(s.callCtx, fakeControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"juju-f75cba-0"})
+}
+
+func (s *environInstanceSuite) TestControllerInstancesOtherController(c *gc.C) {
+	_, err := s.env.ControllerInstances(s.callCtx, "6e8d1f0c-5d4b-4b0b-9c5a-1d2e3f4a5b6c")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
@@ -0,0 +1,266 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+)
+
+var _ environs.NetworkingEnviron = (*environ)(nil)
+
+// Each libvirt network is modelled as a space, with the same name,
+// holding a subnet for each of the network's IP ranges. The subnet
+// provider IDs are formed from the network name and CIDR.
+
+// subnetProviderId returns the provider ID of the subnet of the named
+// network with the given CIDR.
+func subnetProviderId(networkName, cidr string) corenetwork.Id {
+	return corenetwork.Id(fmt.Sprintf("%s:%s", networkName, cidr))
+}
+
+// subnetNetworkName returns the name of the network holding the subnet
+// with the given provider ID.
+func subnetNetworkName(subnetId string) string {
+	return strings.SplitN(subnetId, ":", 2)[0]
+}
+
+// networkSubnets returns the subnets of each libvirt network, keyed by
+// network name, along with the names of all of the networks.
+func networkSubnets(client Client) (map[string][]corenetwork.SubnetInfo, []string, error) {
+	networks, err := client.Networks()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	subnets := make(map[string][]corenetwork.SubnetInfo)
+	var networkNames []string
+	for _, n := range networks {
+		def, err := parseNetwork(n.XML)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "network %q", n.Name)
+		}
+		networkNames = append(networkNames, n.Name)
+		for _, ip := range def.IPs {
+			cidr, err := ip.CIDR()
+			if err != nil {
+				return nil, nil, errors.Annotatef(err, "network %q", n.Name)
+			}
+			subnets[n.Name] = append(subnets[n.Name], corenetwork.SubnetInfo{
+				CIDR:              cidr,
+				ProviderId:        subnetProviderId(n.Name, cidr),
+				ProviderSpaceId:   corenetwork.Id(n.Name),
+				ProviderNetworkId: corenetwork.Id(n.Name),
+			})
+		}
+	}
+	return subnets, networkNames, nil
+}
+
+// Subnets implements environs.NetworkingEnviron.
+func (env *environ) Subnets(
+	ctx context.ProviderCallContext, inst instance.Id, subnetIds []corenetwork.Id,
+) (results []corenetwork.SubnetInfo, err error) {
+	err = env.withClient(func(client Client) error {
+		subnets, networkNames, err := networkSubnets(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if inst != instance.UnknownId {
+			if networkNames, err = env.instanceNetworkNames(client, inst); err != nil {
+				return errors.Trace(err)
+			}
+		}
+
+		wanted := set.NewStrings()
+		for _, id := range subnetIds {
+			wanted.Add(string(id))
+		}
+		found := set.NewStrings()
+		for _, name := range networkNames {
+			for _, subnet := range subnets[name] {
+				id := string(subnet.ProviderId)
+				if !wanted.IsEmpty() && !wanted.Contains(id) {
+					continue
+				}
+				found.Add(id)
+				results = append(results, subnet)
+			}
+		}
+		if missing := wanted.Difference(found); !missing.IsEmpty() {
+			return errors.NotFoundf("subnets %s", strings.Join(missing.SortedValues(), ", "))
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+// instanceNetworkNames returns the names of the networks to which the
+// instance's interfaces are attached.
+func (env *environ) instanceNetworkNames(client Client, inst instance.Id) ([]string, error) {
+	domains, err := env.modelDomains(client)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, d := range domains {
+		if d.Name != string(inst) {
+			continue
+		}
+		seen := set.NewStrings()
+		var networkNames []string
+		for _, iface := range d.def.Devices.Interfaces {
+			if !seen.Contains(iface.Source.Network) {
+				seen.Add(iface.Source.Network)
+				networkNames = append(networkNames, iface.Source.Network)
+			}
+		}
+		return networkNames, nil
+	}
+	return nil, errors.NotFoundf("instance %q", inst)
+}
+
+// NetworkInterfaces implements environs.NetworkingEnviron.
+func (env *environ) NetworkInterfaces(ctx context.ProviderCallContext, ids []instance.Id) ([][]corenetwork.InterfaceInfo, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+
+	// Fetch instance information for the IDs we are interested in.
+	insts, err := env.Instances(ctx, ids)
+	partialInfo := err == environs.ErrPartialInstances
+	if err != nil && err != environs.ErrPartialInstances {
+		if errors.Cause(err) == environs.ErrNoInstances {
+			return nil, err
+		}
+		return nil, errors.Trace(err)
+	}
+
+	var subnets map[string][]corenetwork.SubnetInfo
+	err = env.withClient(func(client Client) error {
+		subnets, _, err = networkSubnets(client)
+		return errors.Trace(err)
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	infos := make([][]corenetwork.InterfaceInfo, len(ids))
+	for idx, inst := range insts {
+		if inst == nil {
+			continue // no instance with this ID known by provider
+		}
+		envInst := inst.(*environInstance)
+		for i, iface := range envInst.base.def.Devices.Interfaces {
+			info := corenetwork.InterfaceInfo{
+				DeviceIndex: i,
+				MACAddress:  iface.MAC.Address,
+				// The network interface has no id in libvirt so it's
+				// identified by the machine's id + its name.
+				ProviderId:        corenetwork.Id(fmt.Sprintf("%s/%s", ids[idx], interfaceName(i))),
+				ProviderNetworkId: corenetwork.Id(iface.Source.Network),
+				InterfaceName:     interfaceName(i),
+				InterfaceType:     corenetwork.EthernetInterface,
+				ConfigType:        corenetwork.ConfigDHCP,
+			}
+			if networkSubnets := subnets[iface.Source.Network]; len(networkSubnets) > 0 {
+				info.CIDR = networkSubnets[0].CIDR
+				info.ProviderSubnetId = networkSubnets[0].ProviderId
+			}
+			for _, lease := range envInst.leases[strings.ToLower(iface.MAC.Address)] {
+				info.Addresses = append(info.Addresses,
+					corenetwork.NewScopedProviderAddress(lease.IPAddress, corenetwork.ScopeCloudLocal),
+				)
+			}
+			infos[idx] = append(infos[idx], info)
+		}
+	}
+
+	if partialInfo {
+		err = environs.ErrPartialInstances
+	}
+	return infos, err
+}
+
+// SupportsSpaces implements environs.NetworkingEnviron.
+func (env *environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery implements environs.NetworkingEnviron.
+func (env *environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool, error) {
+	return true, nil
+}
+
+// Spaces implements environs.NetworkingEnviron.
+func (env *environ) Spaces(ctx context.ProviderCallContext) (results []corenetwork.SpaceInfo, err error) {
+	err = env.withClient(func(client Client) error {
+		subnets, networkNames, err := networkSubnets(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, name := range networkNames {
+			results = append(results, corenetwork.SpaceInfo{
+				Name:       corenetwork.SpaceName(name),
+				ProviderId: corenetwork.Id(name),
+				Subnets:    subnets[name],
+			})
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+// SupportsContainerAddresses implements environs.NetworkingEnviron.
+func (env *environ) SupportsContainerAddresses(ctx context.ProviderCallContext) (bool, error) {
+	return false, nil
+}
+
+// AllocateContainerAddresses implements environs.NetworkingEnviron.
+func (env *environ) AllocateContainerAddresses(context.ProviderCallContext, instance.Id, names.MachineTag, []corenetwork.InterfaceInfo) ([]corenetwork.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container addresses")
+}
+
+// ReleaseContainerAddresses implements environs.NetworkingEnviron.
+func (env *environ) ReleaseContainerAddresses(context.ProviderCallContext, []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container addresses")
+}
+
+// ProviderSpaceInfo implements environs.NetworkingEnviron.
+func (*environ) ProviderSpaceInfo(
+	ctx context.ProviderCallContext, space *corenetwork.SpaceInfo,
+) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable implements environs.NetworkingEnviron.
+func (*environ) AreSpacesRoutable(ctx context.ProviderCallContext, space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SSHAddresses implements environs.SSHAddresses.
+func (*environ) SSHAddresses(ctx context.ProviderCallContext, addresses corenetwork.SpaceAddresses) (corenetwork.SpaceAddresses, error) {
+	return addresses, nil
+}
+
+// SuperSubnets implements environs.SuperSubnets
+func (env *environ) SuperSubnets(ctx context.ProviderCallContext) ([]string, error) {
+	subnets, err := env.Subnets(ctx, instance.UnknownId, nil)
+	if err != nil {
+		return nil, err
+	}
+	cidrs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		cidrs[i] = subnet.CIDR
+	}
+	return cidrs, nil
+}
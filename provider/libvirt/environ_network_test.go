@@ -0,0 +1,196 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environNetworkSuite struct {
+	EnvironFixture
+	netEnv environs.NetworkingEnviron
+}
+
+var _ = gc.Suite(&environNetworkSuite{})
+
+func (s *environNetworkSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.client.networks = append(s.client.networks, libvirtclient.Network{
+		Name: "internal",
+		XML: `<network><name>internal</name>` +
+			`<ip address="10.0.0.1" prefix="24"/>` +
+			`<ip family="ipv6" address="fd00::1" prefix="64"/></network>`,
+	})
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(false),
+			interfaceXML("52:54:00:12:34:56", "default"),
+			interfaceXML("52:54:00:AB:CD:EF", "internal"),
+		),
+	}
+	s.client.leases = map[string][]libvirtclient.Lease{
+		"default": {{
+			MACAddress: "52:54:00:12:34:56",
+			IPAddress:  "192.168.122.10",
+			Prefix:     24,
+		}},
+		"internal": {{
+			MACAddress: "52:54:00:ab:cd:ef",
+			IPAddress:  "10.0.0.10",
+			Prefix:     24,
+		}},
+	}
+
+	netEnv, ok := environs.SupportsNetworking(s.env)
+	c.Assert(ok, jc.IsTrue)
+	s.netEnv = netEnv
+}
+
+var (
+	defaultSubnet = corenetwork.SubnetInfo{
+		CIDR:              "192.168.122.0/24",
+		ProviderId:        "default:192.168.122.0/24",
+		ProviderSpaceId:   "default",
+		ProviderNetworkId: "default",
+	}
+	internalSubnet = corenetwork.SubnetInfo{
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        "internal:10.0.0.0/24",
+		ProviderSpaceId:   "internal",
+		ProviderNetworkId: "internal",
+	}
+	internalSubnetV6 = corenetwork.SubnetInfo{
+		CIDR:              "fd00::/64",
+		ProviderId:        "internal:fd00::/64",
+		ProviderSpaceId:   "internal",
+		ProviderNetworkId: "internal",
+	}
+)
+
+func (s *environNetworkSuite) TestSubnets(c *gc.C) {
+	subnets, err := s.netEnv.Subnets(s.callCtx, instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []corenetwork.SubnetInfo{
+		defaultSubnet, internalSubnet, internalSubnetV6,
+	})
+	s.client.CheckCallNames(c, "Networks", "Close")
+}
+
+func (s *environNetworkSuite) TestSubnetsWithIds(c *gc.C) {
+	subnets, err := s.netEnv.Subnets(s.callCtx, instance.UnknownId, []corenetwork.Id{
+		"internal:fd00::/64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []corenetwork.SubnetInfo{internalSubnetV6})
+}
+
+func (s *environNetworkSuite) TestSubnetsMissingIds(c *gc.C) {
+	_, err := s.netEnv.Subnets(s.callCtx, instance.UnknownId, []corenetwork.Id{
+		"internal:10.0.0.0/24", "other:10.1.0.0/24", "default:10.2.0.0/24",
+	})
+	c.Assert(err, gc.ErrorMatches, "subnets default:10.2.0.0/24, other:10.1.0.0/24 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environNetworkSuite) TestSubnetsForInstance(c *gc.C) {
+	s.client.domains[0] = newDomain("juju-f75cba-0", true, modelTags(false),
+		interfaceXML("52:54:00:12:34:56", "internal"),
+	)
+	subnets, err := s.netEnv.Subnets(s.callCtx, "juju-f75cba-0", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []corenetwork.SubnetInfo{
+		internalSubnet, internalSubnetV6,
+	})
+}
+
+func (s *environNetworkSuite) TestSubnetsForUnknownInstance(c *gc.C) {
+	_, err := s.netEnv.Subnets(s.callCtx, "juju-f75cba-1", nil)
+	c.Assert(err, gc.ErrorMatches, `instance "juju-f75cba-1" not found`)
+}
+
+func (s *environNetworkSuite) TestSpaces(c *gc.C) {
+	spaces, err := s.netEnv.Spaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spaces, jc.DeepEquals, []corenetwork.SpaceInfo{{
+		Name:       "default",
+		ProviderId: "default",
+		Subnets:    []corenetwork.SubnetInfo{defaultSubnet},
+	}, {
+		Name:       "internal",
+		ProviderId: "internal",
+		Subnets:    []corenetwork.SubnetInfo{internalSubnet, internalSubnetV6},
+	}})
+}
+
+func (s *environNetworkSuite) TestSuperSubnets(c *gc.C) {
+	cidrs, err := s.netEnv.SuperSubnets(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"192.168.122.0/24", "10.0.0.0/24", "fd00::/64"})
+}
+
+func (s *environNetworkSuite) TestNetworkInterfaces(c *gc.C) {
+	infos, err := s.netEnv.NetworkInterfaces(s.callCtx, []instance.Id{"juju-f75cba-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, [][]corenetwork.InterfaceInfo{{{
+		DeviceIndex:       0,
+		MACAddress:        "52:54:00:12:34:56",
+		CIDR:              "192.168.122.0/24",
+		ProviderId:        "juju-f75cba-0/eth0",
+		ProviderSubnetId:  "default:192.168.122.0/24",
+		ProviderNetworkId: "default",
+		InterfaceName:     "eth0",
+		InterfaceType:     corenetwork.EthernetInterface,
+		ConfigType:        corenetwork.ConfigDHCP,
+		Addresses: corenetwork.ProviderAddresses{
+			corenetwork.NewScopedProviderAddress("192.168.122.10", corenetwork.ScopeCloudLocal),
+		},
+	}, {
+		DeviceIndex:       1,
+		MACAddress:        "52:54:00:AB:CD:EF",
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        "juju-f75cba-0/eth1",
+		ProviderSubnetId:  "internal:10.0.0.0/24",
+		ProviderNetworkId: "internal",
+		InterfaceName:     "eth1",
+		InterfaceType:     corenetwork.EthernetInterface,
+		ConfigType:        corenetwork.ConfigDHCP,
+		Addresses: corenetwork.ProviderAddresses{
+			corenetwork.NewScopedProviderAddress("10.0.0.10", corenetwork.ScopeCloudLocal),
+		},
+	}}})
+}
+
+func (s *environNetworkSuite) TestNetworkInterfacesPartialResults(c *gc.C) {
+	infos, err := s.netEnv.NetworkInterfaces(s.callCtx, []instance.Id{"juju-f75cba-1", "juju-f75cba-0"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(infos, gc.HasLen, 2)
+	c.Assert(infos[0], gc.IsNil)
+	c.Assert(infos[1], gc.HasLen, 2)
+}
+
+func (s *environNetworkSuite) TestNetworkInterfacesNoInstances(c *gc.C) {
+	_, err := s.netEnv.NetworkInterfaces(s.callCtx, nil)
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *environNetworkSuite) TestSupportsSpaces(c *gc.C) {
+	ok, err := s.netEnv.SupportsSpaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+
+	ok, err = s.netEnv.SupportsSpaceDiscovery(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+
+	ok, err = s.netEnv.SupportsContainerAddresses(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+}
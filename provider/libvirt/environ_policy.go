@@ -0,0 +1,44 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if args.Placement != "" {
+		return errors.Errorf("unknown placement directive: %v", args.Placement)
+	}
+	pool := args.Constraints.RootDiskSource
+	if pool == nil || *pool == "" {
+		return nil
+	}
+	return env.withClient(func(client Client) error {
+		return errors.Trace(checkStoragePool(client, *pool))
+	})
+}
+
+var unsupportedConstraints = []string{
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.InstanceType,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{
+		arch.AMD64,
+	})
+	return validator, nil
+}
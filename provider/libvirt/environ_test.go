@@ -0,0 +1,217 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	environscontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const (
+	fakeModelUUID      = "2d02eeac-9dbb-11e4-89d3-123b93f75cba"
+	fakeControllerUUID = "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+)
+
+// modelTags returns the tags of a machine in the fake model.
+func modelTags(isController bool) map[string]string {
+	result := map[string]string{
+		tags.JujuModel:      fakeModelUUID,
+		tags.JujuController: fakeControllerUUID,
+	}
+	if isController {
+		result[tags.JujuIsController] = "true"
+	}
+	return result
+}
+
+// newDomain returns a domain with the given name and tags, which has
+// a root disk and seed image, and any other given devices.
+func newDomain(name string, running bool, domainTags map[string]string, devices ...string) libvirtclient.Domain {
+	keys := make([]string, 0, len(domainTags))
+	for k := range domainTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var tagsXML string
+	for _, k := range keys {
+		tagsXML += fmt.Sprintf(`<tag key=%q>%s</tag>`, k, domainTags[k])
+	}
+	state := "shut off"
+	if running {
+		state = "running"
+	}
+	return libvirtclient.Domain{
+		Name:    name,
+		State:   state,
+		Running: running,
+		XML: fmt.Sprintf(`<domain type="kvm">`+
+			`<name>%s</name>`+
+			`<metadata><juju:tags xmlns:juju="https://juju.is/libvirt/1">%s</juju:tags></metadata>`+
+			`<devices>%s%s%s</devices>`+
+			`</domain>`,
+			name, tagsXML,
+			diskXML("default", name+"-root", "vda", ""),
+			diskXML("default", name+"-seed.iso", "sda", ""),
+			strings.Join(devices, ""),
+		),
+	}
+}
+
+func diskXML(pool, volume, dev, serial string) string {
+	var serialXML string
+	if serial != "" {
+		serialXML = fmt.Sprintf(`<serial>%s</serial>`, serial)
+	}
+	return fmt.Sprintf(
+		`<disk type="volume" device="disk"><driver name="qemu" type="raw"/><source pool=%q volume=%q/><target dev=%q bus="virtio"/>%s</disk>`,
+		pool, volume, dev, serialXML,
+	)
+}
+
+func interfaceXML(mac, network string) string {
+	return fmt.Sprintf(
+		`<interface type="network"><mac address=%q/><source network=%q/><model type="virtio"/></interface>`,
+		mac, network,
+	)
+}
+
+type environSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) TestBootstrap(c *gc.C) {
+	s.PatchValue(&libvirt.Bootstrap, func(
+		ctx environs.BootstrapContext,
+		env environs.Environ,
+		callCtx environscontext.ProviderCallContext,
+		args environs.BootstrapParams,
+	) (*environs.BootstrapResult, error) {
+		return nil, errors.New("Bootstrap called")
+	})
+
+	_, err := s.env.Bootstrap(nil, s.callCtx, environs.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+	})
+	c.Assert(err, gc.ErrorMatches, "Bootstrap called")
+
+	// We dial a connection before calling Bootstrap, in order
+	// to check that the network and storage pool exist.
+	s.dialStub.CheckCallNames(c, "Dial")
+	s.client.CheckCallNames(c, "Networks", "StoragePools", "Close")
+}
+
+func (s *environSuite) TestBootstrapMissingNetwork(c *gc.C) {
+	s.client.networks = nil
+	_, err := s.env.Bootstrap(nil, s.callCtx, environs.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+	})
+	c.Assert(err, gc.ErrorMatches, `network "default" not found`)
+}
+
+func (s *environSuite) TestCreateMissingStoragePool(c *gc.C) {
+	s.client.pools = []string{"other"}
+	err := s.env.Create(s.callCtx, environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `storage pool "default" not found`)
+	s.client.CheckCallNames(c, "Networks", "StoragePools", "Close")
+}
+
+func (s *environSuite) TestDestroy(c *gc.C) {
+	var destroyCalled bool
+	s.PatchValue(&libvirt.DestroyEnv, func(env environs.Environ, callCtx environscontext.ProviderCallContext) error {
+		destroyCalled = true
+		return nil
+	})
+	err := s.env.Destroy(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyCalled, jc.IsTrue)
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *environSuite) TestDestroyController(c *gc.C) {
+	s.PatchValue(&libvirt.DestroyEnv, func(env environs.Environ, callCtx environscontext.ProviderCallContext) error {
+		return nil
+	})
+	otherModelTags := map[string]string{
+		tags.JujuModel:      "0f5a3e8c-22d8-4b8f-8d8a-2f0d1c6a9b3e",
+		tags.JujuController: fakeControllerUUID,
+	}
+	otherControllerTags := map[string]string{
+		tags.JujuModel:      "7a1cbd3e-7a22-4b38-8d6c-6f4ce1a1e0f1",
+		tags.JujuController: "6e8d1f0c-5d4b-4b0b-9c5a-1d2e3f4a5b6c",
+	}
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-9b3e-0", true, otherModelTags),
+		newDomain("juju-e0f1-0", true, otherControllerTags),
+	}
+	imagePrefix := "juju-image-" + fakeControllerUUID + "-"
+	s.client.volumes = map[string][]libvirtclient.Volume{
+		"default": {
+			{Pool: "default", Name: imagePrefix + "trusty-amd64-0123456789ab.img"},
+			{Pool: "default", Name: "juju-image-6e8d1f0c-5d4b-4b0b-9c5a-1d2e3f4a5b6c-trusty-amd64-0123456789ab.img"},
+			{Pool: "default", Name: "juju-9b3e-0-root"},
+		},
+	}
+
+	err := s.env.DestroyController(s.callCtx, fakeControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCalls(c, []testing.StubCall{
+		{"Domains", nil},
+		{"DestroyDomain", []interface{}{"juju-9b3e-0"}},
+		{"UndefineDomain", []interface{}{"juju-9b3e-0"}},
+		{"DeleteVolume", []interface{}{"default", "juju-9b3e-0-root"}},
+		{"DeleteVolume", []interface{}{"default", "juju-9b3e-0-seed.iso"}},
+		{"StoragePools", nil},
+		{"Volumes", []interface{}{"default"}},
+		{"DeleteVolume", []interface{}{"default", imagePrefix + "trusty-amd64-0123456789ab.img"}},
+		{"Close", nil},
+	})
+}
+
+func (s *environSuite) TestAdoptResources(c *gc.C) {
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(true)),
+	}
+	err := s.env.AdoptResources(s.callCtx, "new-controller", version.MustParse("2.8.0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "Domains", "SetDomainMetadata", "Close")
+	s.client.CheckCall(c, 1, "SetDomainMetadata",
+		"juju-f75cba-0",
+		"https://juju.is/libvirt/1",
+		"juju",
+		`<tags xmlns="https://juju.is/libvirt/1">`+
+			`<tag key="juju-controller-uuid">new-controller</tag>`+
+			`<tag key="juju-is-controller">true</tag>`+
+			`<tag key="juju-model-uuid">`+fakeModelUUID+`</tag>`+
+			`</tags>`,
+	)
+}
+
+func (s *environSuite) TestPrepareForBootstrap(c *gc.C) {
+	err := s.env.PrepareForBootstrap(envtesting.BootstrapContext(c), "controller-1")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *environSuite) TestSupportsNetworking(c *gc.C) {
+	_, ok := environs.SupportsNetworking(s.env)
+	c.Assert(ok, jc.IsTrue)
+}
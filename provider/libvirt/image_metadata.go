@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+)
+
+// cloudImageFileType is the "image-downloads" file type of the cloud
+// images which libvirt boots directly.
+const cloudImageFileType = "disk1.img"
+
+func findImageMetadata(env environs.Environ, args environs.StartInstanceParams) (*imagedownloads.Metadata, error) {
+	arches := args.Tools.Arches()
+	series := args.Tools.OneSeries()
+	ic := &imagemetadata.ImageConstraint{
+		LookupParams: simplestreams.LookupParams{
+			Series: []string{series},
+			Arches: arches,
+			Stream: env.Config().ImageStream(),
+		},
+	}
+	sources, err := environs.ImageMetadataSources(env)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	matchingImages, _, err := imagedownloads.Fetch(sources, ic, appendMatchingFunc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(matchingImages) == 0 {
+		return nil, errors.Errorf("no matching images found for given constraints: %v", ic)
+	}
+
+	return matchingImages[0], nil
+}
+
+// appendMatchingFunc keeps only the cloud images, and records the
+// source from which each was found so that it can be downloaded from
+// the same location.
+func appendMatchingFunc(source simplestreams.DataSource, matchingImages []interface{},
+	images map[string]interface{}, cons simplestreams.LookupConstraint) ([]interface{}, error) {
+
+	matchingImages, err := imagedownloads.Filter(cloudImageFileType)(source, matchingImages, images, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	//ignore error for url data source
+	baseURL, _ := source.URL("")
+	for _, val := range matchingImages {
+		im := val.(*imagedownloads.Metadata)
+		if im.BaseURL == "" {
+			im.BaseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+	return matchingImages, nil
+}
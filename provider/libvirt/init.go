@@ -0,0 +1,25 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+const (
+	providerType = "libvirt"
+)
+
+func init() {
+	dial := func(ctx context.Context, address string, tlsConfig *tls.Config) (Client, error) {
+		return libvirtclient.Dial(ctx, address, tlsConfig)
+	}
+	environs.RegisterProvider(providerType, NewEnvironProvider(EnvironProviderConfig{
+		Dial: dial,
+	}))
+}
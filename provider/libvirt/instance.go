@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environInstance struct {
+	base modelDomain
+	// leases holds the DHCP leases of the domain's interfaces,
+	// keyed by MAC address.
+	leases map[string][]libvirtclient.Lease
+	env    *environ
+}
+
+var _ instances.Instance = (*environInstance)(nil)
+
+func newInstance(base modelDomain, leases map[string][]libvirtclient.Lease, env *environ) *environInstance {
+	return &environInstance{
+		base:   base,
+		leases: leases,
+		env:    env,
+	}
+}
+
+// Id implements instances.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.base.Name)
+}
+
+// Status implements instances.Instance.
+func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.Status {
+	instanceStatus := instance.Status{
+		Status:  status.Empty,
+		Message: inst.base.State,
+	}
+	if inst.base.Running {
+		instanceStatus.Status = status.Running
+	}
+	return instanceStatus
+}
+
+// Addresses implements instances.Instance.
+func (inst *environInstance) Addresses(ctx context.ProviderCallContext) (corenetwork.ProviderAddresses, error) {
+	var res corenetwork.ProviderAddresses
+	for _, iface := range inst.base.def.Devices.Interfaces {
+		for _, lease := range inst.leases[strings.ToLower(iface.MAC.Address)] {
+			res = append(res, corenetwork.NewScopedProviderAddress(lease.IPAddress, corenetwork.ScopeCloudLocal))
+		}
+	}
+	return res, nil
+}
+
+// allLeases returns the DHCP leases of all of the libvirt networks,
+// keyed by MAC address.
+func allLeases(client Client) (map[string][]libvirtclient.Lease, error) {
+	networks, err := client.Networks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]libvirtclient.Lease)
+	for _, n := range networks {
+		leases, err := client.NetworkLeases(n.Name)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting DHCP leases of network %q", n.Name)
+		}
+		for _, lease := range leases {
+			mac := strings.ToLower(lease.MACAddress)
+			result[mac] = append(result[mac], lease)
+		}
+	}
+	return result, nil
+}
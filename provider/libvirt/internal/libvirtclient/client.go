@@ -0,0 +1,421 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+
+	"github.com/digitalocean/go-libvirt"
+	"github.com/juju/errors"
+)
+
+// The following values are defined by the libvirt API, and are not
+// exported by go-libvirt in a form which is stable between versions.
+const (
+	// domainAffectLive and domainAffectConfig are the
+	// virDomainModificationImpact flags for changing the running
+	// domain and its persistent definition respectively.
+	domainAffectLive   = 1
+	domainAffectConfig = 2
+
+	// domainMetadataElement is the virDomainMetadataType for
+	// custom XML metadata elements.
+	domainMetadataElement = 2
+
+	// domainStateRunning is the virDomainState of a running domain.
+	domainStateRunning = 1
+)
+
+// domainStates maps virDomainState values to the names used for
+// them by virsh.
+var domainStates = map[int32]string{
+	0: "no state",
+	1: "running",
+	2: "idle",
+	3: "paused",
+	4: "in shutdown",
+	5: "shut off",
+	6: "crashed",
+	7: "pmsuspended",
+}
+
+// Domain describes a libvirt domain (virtual machine).
+type Domain struct {
+	// Name is the unique name of the domain.
+	Name string
+
+	// State is the virsh name for the domain's state, e.g.
+	// "running" or "shut off".
+	State string
+
+	// Running reports whether or not the domain is running.
+	Running bool
+
+	// XML is the domain's live XML description.
+	XML string
+}
+
+// Network describes a libvirt virtual network.
+type Network struct {
+	// Name is the unique name of the network.
+	Name string
+
+	// XML is the network's XML description.
+	XML string
+}
+
+// Lease describes a DHCP lease handed out by a libvirt network.
+type Lease struct {
+	// MACAddress is the hardware address of the leasing interface.
+	MACAddress string
+
+	// IPAddress is the address which was leased.
+	IPAddress string
+
+	// Prefix is the prefix length of the leased address's subnet.
+	Prefix uint
+
+	// Hostname is the hostname reported by the client, if any.
+	Hostname string
+}
+
+// Volume describes a volume in a libvirt storage pool.
+type Volume struct {
+	// Pool is the name of the storage pool containing the volume.
+	Pool string
+
+	// Name is the name of the volume, unique within the pool.
+	Name string
+
+	// Path is the path of the volume on the libvirt host.
+	Path string
+
+	// Capacity is the logical size of the volume, in bytes.
+	Capacity uint64
+}
+
+// Client encapsulates a libvirt RPC connection, exposing the subset
+// of functionality that we require in the Juju provider.
+type Client struct {
+	conn net.Conn
+	l    *libvirt.Libvirt
+}
+
+// Dial dials a new libvirt RPC connection to the libvirt daemon at the
+// given address. If tlsConfig is non-nil, the connection is secured
+// with TLS. The resulting Client's Close method must be called in
+// order to release resources allocated by Dial.
+func Dial(ctx context.Context, address string, tlsConfig *tls.Config) (*Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tlsConfig != nil {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, errors.Annotate(err, "TLS handshake")
+		}
+		conn = tlsConn
+	}
+	l := libvirt.New(conn)
+	if err := l.Connect(); err != nil {
+		conn.Close()
+		return nil, errors.Annotate(err, "connecting to libvirt")
+	}
+	return &Client{conn: conn, l: l}, nil
+}
+
+// Close disconnects from libvirt and closes the client connection.
+func (c *Client) Close() error {
+	err := c.l.Disconnect()
+	if closeErr := c.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
+
+// Domains returns all of the domains, running or not, defined on the
+// libvirt host.
+func (c *Client) Domains() ([]Domain, error) {
+	doms, _, err := c.l.ConnectListAllDomains(1, 0)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing domains")
+	}
+	result := make([]Domain, len(doms))
+	for i, dom := range doms {
+		state, _, err := c.l.DomainGetState(dom, 0)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting state of domain %q", dom.Name)
+		}
+		xml, err := c.l.DomainGetXMLDesc(dom, 0)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting XML for domain %q", dom.Name)
+		}
+		result[i] = Domain{
+			Name:    dom.Name,
+			State:   domainStates[state],
+			Running: state == domainStateRunning,
+			XML:     xml,
+		}
+	}
+	return result, nil
+}
+
+// DefineDomain defines a persistent domain with the given XML
+// description.
+func (c *Client) DefineDomain(xml string) error {
+	_, err := c.l.DomainDefineXML(xml)
+	return errors.Annotate(err, "defining domain")
+}
+
+// StartDomain starts the named domain.
+func (c *Client) StartDomain(name string) error {
+	dom, err := c.l.DomainLookupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(c.l.DomainCreate(dom), "starting domain %q", name)
+}
+
+// DestroyDomain forcibly stops the named domain.
+func (c *Client) DestroyDomain(name string) error {
+	dom, err := c.l.DomainLookupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(c.l.DomainDestroy(dom), "stopping domain %q", name)
+}
+
+// UndefineDomain removes the persistent definition of the named
+// domain.
+func (c *Client) UndefineDomain(name string) error {
+	dom, err := c.l.DomainLookupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(c.l.DomainUndefineFlags(dom, 0), "undefining domain %q", name)
+}
+
+// SetDomainMetadata replaces the custom metadata element, identified
+// by the given namespace URI, of the named domain. The element is
+// prefixed with key when rendered in the domain's XML.
+func (c *Client) SetDomainMetadata(name, uri, key, xml string) error {
+	dom, err := c.l.DomainLookupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	flags, err := c.modificationFlags(dom)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = c.l.DomainSetMetadata(
+		dom, domainMetadataElement,
+		libvirt.OptString{xml}, libvirt.OptString{key}, libvirt.OptString{uri},
+		flags,
+	)
+	return errors.Annotatef(err, "setting metadata for domain %q", name)
+}
+
+// AttachDevice attaches the device with the given XML description to
+// the named domain. The device is attached to the running domain, if
+// it is running, and to its persistent definition.
+func (c *Client) AttachDevice(name, xml string) error {
+	dom, err := c.l.DomainLookupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	flags, err := c.modificationFlags(dom)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = c.l.DomainAttachDeviceFlags(dom, xml, uint32(flags))
+	return errors.Annotatef(err, "attaching device to domain %q", name)
+}
+
+// DetachDevice detaches the device with the given XML description from
+// the named domain, and from its persistent definition.
+func (c *Client) DetachDevice(name, xml string) error {
+	dom, err := c.l.DomainLookupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	flags, err := c.modificationFlags(dom)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = c.l.DomainDetachDeviceFlags(dom, xml, uint32(flags))
+	return errors.Annotatef(err, "detaching device from domain %q", name)
+}
+
+// modificationFlags returns the flags to use when modifying the given
+// domain. Changes to a running domain must be made to both the live
+// domain and its persistent definition, whereas changes to a stopped
+// domain may only be made to the definition.
+func (c *Client) modificationFlags(dom libvirt.Domain) (libvirt.DomainModificationImpact, error) {
+	state, _, err := c.l.DomainGetState(dom, 0)
+	if err != nil {
+		return 0, errors.Annotatef(err, "getting state of domain %q", dom.Name)
+	}
+	if state == domainStateRunning {
+		return domainAffectLive | domainAffectConfig, nil
+	}
+	return domainAffectConfig, nil
+}
+
+// Networks returns all of the virtual networks defined on the libvirt
+// host.
+func (c *Client) Networks() ([]Network, error) {
+	nets, _, err := c.l.ConnectListAllNetworks(1, 0)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing networks")
+	}
+	result := make([]Network, len(nets))
+	for i, n := range nets {
+		xml, err := c.l.NetworkGetXMLDesc(n, 0)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting XML for network %q", n.Name)
+		}
+		result[i] = Network{Name: n.Name, XML: xml}
+	}
+	return result, nil
+}
+
+// NetworkLeases returns the DHCP leases handed out by the named
+// network.
+func (c *Client) NetworkLeases(name string) ([]Lease, error) {
+	n, err := c.l.NetworkLookupByName(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	leases, _, err := c.l.NetworkGetDhcpLeases(n, nil, 1, 0)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting DHCP leases for network %q", name)
+	}
+	result := make([]Lease, len(leases))
+	for i, lease := range leases {
+		result[i] = Lease{
+			MACAddress: optString(lease.Mac),
+			IPAddress:  lease.Ipaddr,
+			Prefix:     uint(lease.Prefix),
+			Hostname:   optString(lease.Hostname),
+		}
+	}
+	return result, nil
+}
+
+// StoragePools returns the names of all of the storage pools defined
+// on the libvirt host.
+func (c *Client) StoragePools() ([]string, error) {
+	pools, _, err := c.l.ConnectListAllStoragePools(1, 0)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing storage pools")
+	}
+	result := make([]string, len(pools))
+	for i, pool := range pools {
+		result[i] = pool.Name
+	}
+	return result, nil
+}
+
+// Volumes returns all of the volumes in the named storage pool.
+func (c *Client) Volumes(pool string) ([]Volume, error) {
+	p, err := c.l.StoragePoolLookupByName(pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Refresh the pool first, so that we see volumes which have
+	// been created or removed outside of libvirt.
+	if err := c.l.StoragePoolRefresh(p, 0); err != nil {
+		return nil, errors.Annotatef(err, "refreshing storage pool %q", pool)
+	}
+	vols, _, err := c.l.StoragePoolListAllVolumes(p, 1, 0)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing volumes in storage pool %q", pool)
+	}
+	result := make([]Volume, len(vols))
+	for i, vol := range vols {
+		v, err := c.volume(pool, vol)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+// CreateVolume creates a volume with the given XML description in the
+// named storage pool.
+func (c *Client) CreateVolume(pool, xml string) (Volume, error) {
+	p, err := c.l.StoragePoolLookupByName(pool)
+	if err != nil {
+		return Volume{}, errors.Trace(err)
+	}
+	vol, err := c.l.StorageVolCreateXML(p, xml, 0)
+	if err != nil {
+		return Volume{}, errors.Annotatef(err, "creating volume in storage pool %q", pool)
+	}
+	return c.volume(pool, vol)
+}
+
+// UploadVolume replaces the contents of the named volume with length
+// bytes read from r.
+func (c *Client) UploadVolume(pool, name string, r io.Reader, length uint64) error {
+	vol, err := c.lookupVolume(pool, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = c.l.StorageVolUpload(vol, r, 0, length, 0)
+	return errors.Annotatef(err, "uploading volume %q", name)
+}
+
+// DeleteVolume deletes the named volume from the named storage pool.
+func (c *Client) DeleteVolume(pool, name string) error {
+	vol, err := c.lookupVolume(pool, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = c.l.StorageVolDelete(vol, 0)
+	return errors.Annotatef(err, "deleting volume %q", name)
+}
+
+func (c *Client) lookupVolume(pool, name string) (libvirt.StorageVol, error) {
+	p, err := c.l.StoragePoolLookupByName(pool)
+	if err != nil {
+		return libvirt.StorageVol{}, errors.Trace(err)
+	}
+	vol, err := c.l.StorageVolLookupByName(p, name)
+	if err != nil {
+		return libvirt.StorageVol{}, errors.Annotatef(err, "looking up volume %q in storage pool %q", name, pool)
+	}
+	return vol, nil
+}
+
+func (c *Client) volume(pool string, vol libvirt.StorageVol) (Volume, error) {
+	path, err := c.l.StorageVolGetPath(vol)
+	if err != nil {
+		return Volume{}, errors.Annotatef(err, "getting path of volume %q", vol.Name)
+	}
+	_, capacity, _, err := c.l.StorageVolGetInfo(vol)
+	if err != nil {
+		return Volume{}, errors.Annotatef(err, "getting info for volume %q", vol.Name)
+	}
+	return Volume{
+		Pool:     pool,
+		Name:     vol.Name,
+		Path:     path,
+		Capacity: capacity,
+	}, nil
+}
+
+func optString(s libvirt.OptString) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}
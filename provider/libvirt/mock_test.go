@@ -0,0 +1,181 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"path"
+	"sync"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+func newMockDialFunc(dialStub *testing.Stub, client libvirt.Client) libvirt.DialFunc {
+	return func(ctx context.Context, address string, tlsConfig *tls.Config) (libvirt.Client, error) {
+		dialStub.AddCall("Dial", ctx, address, tlsConfig)
+		if err := dialStub.NextErr(); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+type mockClient struct {
+	// mu guards testing.Stub access, to ensure that the recorded
+	// method calls correspond to the errors returned.
+	mu sync.Mutex
+	testing.Stub
+
+	domains  []libvirtclient.Domain
+	networks []libvirtclient.Network
+	leases   map[string][]libvirtclient.Lease
+	pools    []string
+	volumes  map[string][]libvirtclient.Volume
+	uploaded map[string][]byte
+}
+
+func (c *mockClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Close")
+	return c.NextErr()
+}
+
+func (c *mockClient) Domains() ([]libvirtclient.Domain, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Domains")
+	return c.domains, c.NextErr()
+}
+
+func (c *mockClient) DefineDomain(xml string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DefineDomain", xml)
+	return c.NextErr()
+}
+
+func (c *mockClient) StartDomain(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "StartDomain", name)
+	return c.NextErr()
+}
+
+func (c *mockClient) DestroyDomain(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DestroyDomain", name)
+	return c.NextErr()
+}
+
+func (c *mockClient) UndefineDomain(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "UndefineDomain", name)
+	return c.NextErr()
+}
+
+func (c *mockClient) SetDomainMetadata(name, uri, key, xml string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "SetDomainMetadata", name, uri, key, xml)
+	return c.NextErr()
+}
+
+func (c *mockClient) AttachDevice(name, xml string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "AttachDevice", name, xml)
+	return c.NextErr()
+}
+
+func (c *mockClient) DetachDevice(name, xml string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DetachDevice", name, xml)
+	return c.NextErr()
+}
+
+func (c *mockClient) Networks() ([]libvirtclient.Network, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Networks")
+	return c.networks, c.NextErr()
+}
+
+func (c *mockClient) NetworkLeases(name string) ([]libvirtclient.Lease, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "NetworkLeases", name)
+	return c.leases[name], c.NextErr()
+}
+
+func (c *mockClient) StoragePools() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "StoragePools")
+	return c.pools, c.NextErr()
+}
+
+func (c *mockClient) Volumes(pool string) ([]libvirtclient.Volume, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "Volumes", pool)
+	return c.volumes[pool], c.NextErr()
+}
+
+// CreateVolume records the call, and returns a volume with the name
+// and capacity given in the XML description.
+func (c *mockClient) CreateVolume(pool, volumeXML string) (libvirtclient.Volume, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "CreateVolume", pool, volumeXML)
+	if err := c.NextErr(); err != nil {
+		return libvirtclient.Volume{}, err
+	}
+	var v struct {
+		Name string `xml:"name"`
+	}
+	if err := xml.Unmarshal([]byte(volumeXML), &v); err != nil {
+		return libvirtclient.Volume{}, err
+	}
+	return libvirtclient.Volume{
+		Pool: pool,
+		Name: v.Name,
+		Path: path.Join("/var/lib/libvirt/images", v.Name),
+	}, nil
+}
+
+func (c *mockClient) UploadVolume(pool, name string, r io.Reader, length uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "UploadVolume", pool, name, r, length)
+	if err := c.NextErr(); err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if c.uploaded == nil {
+		c.uploaded = make(map[string][]byte)
+	}
+	c.uploaded[name] = data
+	return nil
+}
+
+func (c *mockClient) DeleteVolume(pool, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "DeleteVolume", pool, name)
+	return c.NextErr()
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type providerSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	config := fakeConfig(c)
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: config,
+	})
+	c.Check(err, jc.ErrorIsNil)
+
+	envConfig := env.Config()
+	c.Assert(envConfig.Name(), gc.Equals, "testmodel")
+
+	// Opening the environ does not connect to libvirt.
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *providerSuite) TestOpenInvalidCloudSpec(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Name = ""
+	s.testOpenError(c, spec, `validating cloud spec: cloud name "" not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Credential = nil
+	s.testOpenError(c, spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	spec := fakeCloudSpec()
+	spec.Credential = &credential
+	s.testOpenError(c, spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) TestOpenTLSEndpointEmptyCredential(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "qemu+tls://host1/system"
+	s.testOpenError(c, spec, `validating cloud spec: "empty" auth-type with TLS endpoint not valid`)
+
+	// A bare host is connected to with TLS.
+	spec.Endpoint = "host1"
+	s.testOpenError(c, spec, `validating cloud spec: "empty" auth-type with TLS endpoint not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedEndpoint(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "qemu+ssh://host1/system"
+	s.testOpenError(c, spec, `validating cloud spec: libvirt URI scheme "qemu\+ssh" not supported`)
+
+	spec.Endpoint = "qemu+tcp://host1/session"
+	s.testOpenError(c, spec, `validating cloud spec: libvirt URI path "/session" not supported`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environs.CloudSpec, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestPrepareConfig(c *gc.C) {
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Config: fakeConfig(c),
+		Cloud:  fakeCloudSpec(),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.NotNil)
+}
+
+func (s *providerSuite) TestValidate(c *gc.C) {
+	config := fakeConfig(c)
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Check(err, jc.ErrorIsNil)
+
+	validAttrs := validCfg.AllAttrs()
+	c.Assert(validAttrs["primary-network"], gc.Equals, "default")
+	c.Assert(validAttrs["libvirt-pool"], gc.Equals, "default")
+}
+
+func (s *providerSuite) TestSchema(c *gc.C) {
+	y := []byte(`
+auth-types: [certificate, empty]
+endpoint: qemu+tls://host1/system
+`[1:])
+	var v interface{}
+	err := yaml.Unmarshal(y, &v)
+	c.Assert(err, jc.ErrorIsNil)
+	v, err = utils.ConformYAML(v)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.provider.CloudSchema().Validate(v)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestCredentialSchemas(c *gc.C) {
+	schemas := s.provider.CredentialSchemas()
+	c.Assert(schemas, gc.HasLen, 2)
+	c.Assert(schemas[cloud.EmptyAuthType], gc.HasLen, 0)
+	var names []string
+	for _, attr := range schemas[cloud.CertificateAuthType] {
+		names = append(names, attr.Name)
+	}
+	c.Assert(names, jc.DeepEquals, []string{"ca-cert", "client-cert", "client-key"})
+}
+
+func (s *providerSuite) TestDetectCredentials(c *gc.C) {
+	_, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type pingSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&pingSuite{})
+
+func (s *pingSuite) TestPingNoDaemon(c *gc.C) {
+	s.dialStub.SetErrors(errors.New("connection refused"))
+	err := s.provider.Ping(s.callCtx, "qemu+tcp://host1/system")
+	c.Assert(err, gc.ErrorMatches, "No libvirt daemon available at qemu\\+tcp://host1/system")
+}
+
+func (s *pingSuite) TestPingInvalidEndpoint(c *gc.C) {
+	err := s.provider.Ping(s.callCtx, "http://host1")
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint: libvirt URI scheme "http" not supported`)
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *pingSuite) TestPingTCP(c *gc.C) {
+	for _, endpoint := range []string{
+		"qemu+tcp://host1/system",
+		"qemu+tcp://host1:16509",
+	} {
+		s.dialStub.ResetCalls()
+		s.client.ResetCalls()
+		err := s.provider.Ping(s.callCtx, endpoint)
+		c.Assert(err, jc.ErrorIsNil)
+
+		s.dialStub.CheckCallNames(c, "Dial")
+		call := s.dialStub.Calls()[0]
+		c.Assert(call.Args, gc.HasLen, 3)
+		c.Assert(call.Args[0], gc.Implements, new(context.Context))
+		c.Assert(call.Args[1], gc.Equals, "host1:16509")
+		c.Assert(call.Args[2], gc.Equals, (*tls.Config)(nil))
+
+		s.client.CheckCallNames(c, "Close")
+	}
+}
+
+func (s *pingSuite) TestPingTLS(c *gc.C) {
+	// The TLS handshake requires a client certificate, which
+	// is not available until credentials have been added.
+	for _, endpoint := range []string{
+		"host1",
+		"qemu+tls://host1/system",
+	} {
+		err := s.provider.Ping(s.callCtx, endpoint)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.dialStub.CheckNoCalls(c)
+}
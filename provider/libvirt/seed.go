@@ -0,0 +1,218 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// cloud-init's NoCloud datasource reads its configuration from the
+// root directory of a filesystem labelled "cidata". We provide that
+// filesystem as a minimal ISO 9660 image, attached to the machine as a
+// CD-ROM. The image holds a single directory, and so the layout is
+// fixed: the primary volume descriptor and terminator, the little- and
+// big-endian path tables, the root directory, and then the contents of
+// each file. See ECMA-119 for the details of the format.
+const (
+	seedVolumeLabel = "cidata"
+
+	isoSectorSize = 2048
+
+	isoPrimaryVolumeSector = 16
+	isoTerminatorSector    = 17
+	isoLPathTableSector    = 18
+	isoMPathTableSector    = 19
+	isoRootDirSector       = 20
+	isoFirstFileSector     = 21
+
+	// isoPathTableSize is the size of a path table holding only
+	// the root directory.
+	isoPathTableSize = 10
+)
+
+// seedFile is a file in the root directory of a seed image.
+type seedFile struct {
+	name string
+	data []byte
+}
+
+// seedMetadata is the NoCloud meta-data for a machine.
+type seedMetadata struct {
+	InstanceId    string `yaml:"instance-id"`
+	LocalHostname string `yaml:"local-hostname"`
+}
+
+// seedNetworkConfig is version 2 (netplan) network configuration for a
+// machine, in which each interface is identified by its MAC address.
+type seedNetworkConfig struct {
+	Version   int                           `yaml:"version"`
+	Ethernets map[string]seedEthernetConfig `yaml:"ethernets"`
+}
+
+type seedEthernetConfig struct {
+	Match   seedEthernetMatch `yaml:"match"`
+	SetName string            `yaml:"set-name"`
+	DHCP4   bool              `yaml:"dhcp4"`
+}
+
+type seedEthernetMatch struct {
+	MACAddress string `yaml:"macaddress"`
+}
+
+// newSeedImage returns a NoCloud seed image for the machine with the
+// given hostname, with the given user data, and with DHCP configured
+// on a network interface for each of the given MAC addresses.
+func newSeedImage(hostname string, userData []byte, macAddresses []string) ([]byte, error) {
+	metadata, err := yaml.Marshal(seedMetadata{
+		InstanceId:    hostname,
+		LocalHostname: hostname,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networkConfig := seedNetworkConfig{
+		Version:   2,
+		Ethernets: make(map[string]seedEthernetConfig),
+	}
+	for i, mac := range macAddresses {
+		name := interfaceName(i)
+		networkConfig.Ethernets[name] = seedEthernetConfig{
+			Match:   seedEthernetMatch{MACAddress: mac},
+			SetName: name,
+			DHCP4:   true,
+		}
+	}
+	networkData, err := yaml.Marshal(networkConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newISOImage(seedVolumeLabel, []seedFile{
+		{name: "meta-data", data: metadata},
+		{name: "network-config", data: networkData},
+		{name: "user-data", data: userData},
+	})
+}
+
+// newISOImage returns an ISO 9660 image with the given volume label,
+// holding the given files in its root directory.
+func newISOImage(label string, files []seedFile) ([]byte, error) {
+	files = append([]seedFile(nil), files...)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	// Lay out the root directory, which starts with the
+	// records for itself and its parent.
+	rootDir := append(
+		isoDirectoryRecord([]byte{0}, isoRootDirSector, isoSectorSize, true),
+		isoDirectoryRecord([]byte{1}, isoRootDirSector, isoSectorSize, true)...,
+	)
+	sector := uint32(isoFirstFileSector)
+	for _, f := range files {
+		// Linux maps "NAME.;1" to "name" when mounting an
+		// image without Rock Ridge extensions.
+		id := []byte(strings.ToUpper(f.name) + ".;1")
+		rootDir = append(rootDir, isoDirectoryRecord(id, sector, uint32(len(f.data)), false)...)
+		sector += isoSectors(len(f.data))
+	}
+	if len(rootDir) > isoSectorSize {
+		return nil, errors.Errorf("too many files for seed image")
+	}
+
+	image := make([]byte, int(sector)*isoSectorSize)
+	writeISOPrimaryVolumeDescriptor(image[isoPrimaryVolumeSector*isoSectorSize:], label, sector)
+	terminator := image[isoTerminatorSector*isoSectorSize:]
+	terminator[0] = 255
+	copy(terminator[1:], "CD001")
+	terminator[6] = 1
+
+	lPathTable := image[isoLPathTableSector*isoSectorSize:]
+	mPathTable := image[isoMPathTableSector*isoSectorSize:]
+	for _, table := range [][]byte{lPathTable, mPathTable} {
+		table[0] = 1 // length of the root directory identifier
+	}
+	binary.LittleEndian.PutUint32(lPathTable[2:], isoRootDirSector)
+	binary.LittleEndian.PutUint16(lPathTable[6:], 1)
+	binary.BigEndian.PutUint32(mPathTable[2:], isoRootDirSector)
+	binary.BigEndian.PutUint16(mPathTable[6:], 1)
+
+	copy(image[isoRootDirSector*isoSectorSize:], rootDir)
+	offset := isoFirstFileSector * isoSectorSize
+	for _, f := range files {
+		copy(image[offset:], f.data)
+		offset += int(isoSectors(len(f.data))) * isoSectorSize
+	}
+	return image, nil
+}
+
+func writeISOPrimaryVolumeDescriptor(b []byte, label string, sectors uint32) {
+	b[0] = 1
+	copy(b[1:], "CD001")
+	b[6] = 1
+	copyPadded(b[8:40], "")     // system identifier
+	copyPadded(b[40:72], label) // volume identifier
+	putBothEndian32(b[80:], sectors)
+	putBothEndian16(b[120:], 1) // volume set size
+	putBothEndian16(b[124:], 1) // volume sequence number
+	putBothEndian16(b[128:], isoSectorSize)
+	putBothEndian32(b[132:], isoPathTableSize)
+	binary.LittleEndian.PutUint32(b[140:], isoLPathTableSector)
+	binary.BigEndian.PutUint32(b[148:], isoMPathTableSector)
+	copy(b[156:190], isoDirectoryRecord([]byte{0}, isoRootDirSector, isoSectorSize, true))
+	// Volume set, publisher, data preparer, application,
+	// copyright, abstract and bibliographic identifiers.
+	copyPadded(b[190:813], "")
+	// Creation, modification, expiration and effective
+	// times, all unspecified.
+	for i := 813; i < 881; i += 17 {
+		copy(b[i:i+16], "0000000000000000")
+	}
+	b[881] = 1 // file structure version
+}
+
+func isoDirectoryRecord(id []byte, sector, size uint32, dir bool) []byte {
+	length := 33 + len(id)
+	if length%2 != 0 {
+		length++
+	}
+	r := make([]byte, length)
+	r[0] = byte(length)
+	putBothEndian32(r[2:], sector)
+	putBothEndian32(r[10:], size)
+	// Recording time: 1970-01-01 00:00:00 UTC.
+	r[18], r[19], r[20] = 70, 1, 1
+	if dir {
+		r[25] = 2
+	}
+	putBothEndian16(r[28:], 1) // volume sequence number
+	r[32] = byte(len(id))
+	copy(r[33:], id)
+	return r
+}
+
+func isoSectors(size int) uint32 {
+	return uint32((size + isoSectorSize - 1) / isoSectorSize)
+}
+
+func putBothEndian16(b []byte, v uint16) {
+	binary.LittleEndian.PutUint16(b, v)
+	binary.BigEndian.PutUint16(b[2:], v)
+}
+
+func putBothEndian32(b []byte, v uint32) {
+	binary.LittleEndian.PutUint32(b, v)
+	binary.BigEndian.PutUint32(b[4:], v)
+}
+
+func copyPadded(b []byte, s string) {
+	n := copy(b, s)
+	for i := n; i < len(b); i++ {
+		b[i] = ' '
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"encoding/binary"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type seedSuite struct{}
+
+var _ = gc.Suite(&seedSuite{})
+
+// readISOFiles returns the contents of the files in the root directory
+// of the given ISO 9660 image, keyed by file identifier.
+func readISOFiles(c *gc.C, image []byte) map[string][]byte {
+	c.Assert(len(image)%isoSectorSize, gc.Equals, 0)
+	pvd := image[isoPrimaryVolumeSector*isoSectorSize:]
+	c.Assert(string(pvd[1:6]), gc.Equals, "CD001")
+	c.Assert(binary.LittleEndian.Uint32(pvd[80:]), gc.Equals, uint32(len(image)/isoSectorSize))
+
+	// The root directory record in the volume descriptor
+	// tells us where to find the root directory.
+	rootSector := binary.LittleEndian.Uint32(pvd[156+2:])
+	rootSize := binary.LittleEndian.Uint32(pvd[156+10:])
+	rootDir := image[rootSector*isoSectorSize:][:rootSize]
+
+	files := make(map[string][]byte)
+	for len(rootDir) > 0 && rootDir[0] != 0 {
+		record := rootDir[:rootDir[0]]
+		rootDir = rootDir[rootDir[0]:]
+		if record[25]&2 != 0 {
+			continue // "." or ".."
+		}
+		sector := binary.LittleEndian.Uint32(record[2:])
+		size := binary.LittleEndian.Uint32(record[10:])
+		id := string(record[33 : 33+record[32]])
+		files[id] = image[sector*isoSectorSize:][:size]
+	}
+	return files
+}
+
+func (*seedSuite) TestNewISOImage(c *gc.C) {
+	image, err := newISOImage("cidata", []seedFile{
+		{name: "user-data", data: []byte("#cloud-config\n")},
+		{name: "big", data: []byte(strings.Repeat("x", 3*isoSectorSize+1))},
+		{name: "empty"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pvd := image[isoPrimaryVolumeSector*isoSectorSize:]
+	c.Assert(strings.TrimRight(string(pvd[40:72]), " "), gc.Equals, "cidata")
+	c.Assert(image[isoTerminatorSector*isoSectorSize], gc.Equals, byte(255))
+
+	files := readISOFiles(c, image)
+	c.Assert(files, jc.DeepEquals, map[string][]byte{
+		"BIG.;1":       []byte(strings.Repeat("x", 3*isoSectorSize+1)),
+		"EMPTY.;1":     {},
+		"USER-DATA.;1": []byte("#cloud-config\n"),
+	})
+}
+
+func (*seedSuite) TestNewISOImageTooManyFiles(c *gc.C) {
+	files := make([]seedFile, 100)
+	for i := range files {
+		files[i].name = strings.Repeat("f", 20) + string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+	_, err := newISOImage("cidata", files)
+	c.Assert(err, gc.ErrorMatches, "too many files for seed image")
+}
+
+func (*seedSuite) TestNewSeedImage(c *gc.C) {
+	image, err := newSeedImage("juju-f75cba-0", []byte("user data"), []string{
+		"52:54:00:12:34:56", "52:54:00:ab:cd:ef",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	files := readISOFiles(c, image)
+	c.Assert(files, gc.HasLen, 3)
+	c.Assert(string(files["USER-DATA.;1"]), gc.Equals, "user data")
+
+	var metadata map[string]interface{}
+	err = yaml.Unmarshal(files["META-DATA.;1"], &metadata)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata, jc.DeepEquals, map[string]interface{}{
+		"instance-id":    "juju-f75cba-0",
+		"local-hostname": "juju-f75cba-0",
+	})
+
+	var networkConfig map[string]interface{}
+	err = yaml.Unmarshal(files["NETWORK-CONFIG.;1"], &networkConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networkConfig, jc.DeepEquals, map[string]interface{}{
+		"version": 2,
+		"ethernets": map[interface{}]interface{}{
+			"eth0": map[interface{}]interface{}{
+				"match":    map[interface{}]interface{}{"macaddress": "52:54:00:12:34:56"},
+				"set-name": "eth0",
+				"dhcp4":    true,
+			},
+			"eth1": map[interface{}]interface{}{
+				"match":    map[interface{}]interface{}{"macaddress": "52:54:00:ab:cd:ef"},
+				"set-name": "eth1",
+				"dhcp4":    true,
+			},
+		},
+	})
+}
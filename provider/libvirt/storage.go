@@ -0,0 +1,441 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	libvirtStorageProviderType = storage.ProviderType("libvirt")
+
+	// attrLibvirtStoragePool is the attribute name for the storage
+	// pool's corresponding libvirt storage pool name. If this is not
+	// provided, the model's "libvirt-pool" setting is used.
+	attrLibvirtStoragePool = "libvirt-pool"
+
+	// maxVolumeSerialLength is the length to which QEMU truncates the
+	// serial numbers of virtio block devices.
+	maxVolumeSerialLength = 20
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (env *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{libvirtStorageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == libvirtStorageProviderType {
+		return &libvirtStorageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// libvirtStorageProvider is a storage provider for volumes in libvirt
+// storage pools, exposed to Juju as block devices.
+type libvirtStorageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*libvirtStorageProvider)(nil)
+
+var libvirtStorageConfigChecker = schema.FieldMap(
+	schema.Fields{
+		attrLibvirtStoragePool: schema.String(),
+	},
+	schema.Defaults{
+		attrLibvirtStoragePool: schema.Omit,
+	},
+)
+
+// libvirtStoragePool returns the name of the libvirt storage pool in
+// which volumes with the given attributes are created.
+func (e *libvirtStorageProvider) libvirtStoragePool(attrs map[string]interface{}) (string, error) {
+	coerced, err := libvirtStorageConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return "", errors.Annotate(err, "validating libvirt storage config")
+	}
+	pool, _ := coerced.(map[string]interface{})[attrLibvirtStoragePool].(string)
+	if pool == "" {
+		pool = e.env.envConfig().storagePool()
+	}
+	return pool, nil
+}
+
+// ValidateConfig is part of the Provider interface.
+func (e *libvirtStorageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := e.libvirtStoragePool(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// Supports is part of the Provider interface.
+func (e *libvirtStorageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is part of the Provider interface.
+func (e *libvirtStorageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the Provider interface.
+func (e *libvirtStorageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the Provider interface.
+func (e *libvirtStorageProvider) Releasable() bool {
+	// Volumes carry no metadata in libvirt, and are owned by the
+	// model through their names, so they cannot be released.
+	return false
+}
+
+// DefaultPools is part of the Provider interface.
+func (e *libvirtStorageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// VolumeSource is part of the Provider interface.
+func (e *libvirtStorageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	return &libvirtVolumeSource{e}, nil
+}
+
+// FilesystemSource is part of the Provider interface.
+func (e *libvirtStorageProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// libvirtVolumeSource is an implementation of storage.VolumeSource
+// that creates volumes in libvirt storage pools, and attaches them to
+// machines as virtio disks.
+type libvirtVolumeSource struct {
+	provider *libvirtStorageProvider
+}
+
+var _ storage.VolumeSource = (*libvirtVolumeSource)(nil)
+
+// volumeName returns the name of the libvirt volume for the volume
+// with the given tag.
+func (s *libvirtVolumeSource) volumeName(tag names.VolumeTag) string {
+	return s.provider.env.namespace.Value(tag.String())
+}
+
+// volumeId returns the provider ID of the named volume in the given
+// libvirt storage pool.
+func volumeId(pool, name string) string {
+	return fmt.Sprintf("%s:%s", pool, name)
+}
+
+// parseVolumeId returns the storage pool and name of the volume with
+// the given provider ID.
+func parseVolumeId(id string) (pool, name string, _ error) {
+	fields := strings.SplitN(id, ":", 2)
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		return "", "", errors.NotValidf("volume ID %q", id)
+	}
+	return fields[0], fields[1], nil
+}
+
+// volumeSerial returns the serial number given to the disk to which
+// the volume with the given tag is attached.
+func volumeSerial(tag names.VolumeTag) string {
+	serial := tag.String()
+	if len(serial) > maxVolumeSerialLength {
+		serial = serial[:maxVolumeSerialLength]
+	}
+	return serial
+}
+
+// bytesToMiB converts bytes to mebibytes, rounding down.
+func bytesToMiB(b uint64) uint64 {
+	return b / (1024 * 1024)
+}
+
+// CreateVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) (results []storage.CreateVolumesResult, err error) {
+	results = make([]storage.CreateVolumesResult, len(params))
+	err = s.provider.env.withClient(func(client Client) error {
+		for i, p := range params {
+			volume, err := s.createVolume(client, p)
+			if err != nil {
+				results[i].Error = err
+				continue
+			}
+			results[i].Volume = volume
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+func (s *libvirtVolumeSource) createVolume(client Client, p storage.VolumeParams) (*storage.Volume, error) {
+	if err := s.ValidateVolumeParams(p); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pool, err := s.provider.libvirtStoragePool(p.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name := s.volumeName(p.Tag)
+	volumeXML, err := marshalXML(storageVolume{
+		Name:     name,
+		Capacity: storageVolumeSize{Unit: "MiB", Value: p.Size},
+		Target: &storageVolumeTarget{
+			Format: &storageVolumeFormat{Type: "raw"},
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	created, err := client.CreateVolume(pool, volumeXML)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating volume %q in storage pool %q", name, pool)
+	}
+	size := bytesToMiB(created.Capacity)
+	if size == 0 {
+		size = p.Size
+	}
+	return &storage.Volume{
+		Tag: p.Tag,
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   volumeId(pool, name),
+			Size:       size,
+			Persistent: true,
+		},
+	}, nil
+}
+
+// ListVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) ListVolumes(ctx context.ProviderCallContext) (ids []string, err error) {
+	prefix := s.provider.env.namespace.Value(names.VolumeTagKind + "-")
+	err = s.provider.env.withClient(func(client Client) error {
+		pools, err := client.StoragePools()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, pool := range pools {
+			volumes, err := client.Volumes(pool)
+			if err != nil {
+				return errors.Annotatef(err, "listing volumes in storage pool %q", pool)
+			}
+			for _, v := range volumes {
+				if strings.HasPrefix(v.Name, prefix) {
+					ids = append(ids, volumeId(pool, v.Name))
+				}
+			}
+		}
+		return nil
+	})
+	return ids, errors.Trace(err)
+}
+
+// DescribeVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volumeIds []string) (results []storage.DescribeVolumesResult, err error) {
+	results = make([]storage.DescribeVolumesResult, len(volumeIds))
+	err = s.provider.env.withClient(func(client Client) error {
+		poolVolumes := make(map[string][]libvirtclient.Volume)
+		for i, id := range volumeIds {
+			pool, name, err := parseVolumeId(id)
+			if err != nil {
+				results[i].Error = err
+				continue
+			}
+			volumes, ok := poolVolumes[pool]
+			if !ok {
+				if volumes, err = client.Volumes(pool); err != nil {
+					results[i].Error = errors.Annotatef(err, "listing volumes in storage pool %q", pool)
+					continue
+				}
+				poolVolumes[pool] = volumes
+			}
+			results[i].Error = errors.NotFoundf("volume %q", id)
+			for _, v := range volumes {
+				if v.Name == name {
+					results[i].Error = nil
+					results[i].VolumeInfo = &storage.VolumeInfo{
+						VolumeId:   id,
+						Size:       bytesToMiB(v.Capacity),
+						Persistent: true,
+					}
+					break
+				}
+			}
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+// DestroyVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) (results []error, err error) {
+	results = make([]error, len(volumeIds))
+	err = s.provider.env.withClient(func(client Client) error {
+		for i, id := range volumeIds {
+			pool, name, err := parseVolumeId(id)
+			if err != nil {
+				results[i] = err
+				continue
+			}
+			if err := client.DeleteVolume(pool, name); err != nil {
+				results[i] = errors.Annotatef(err, "destroying volume %q", id)
+			}
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+// ReleaseVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	return nil, errors.NotSupportedf("releasing volumes")
+}
+
+// ValidateVolumeParams is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	if params.Size == 0 {
+		return errors.NotValidf("volume size 0")
+	}
+	return nil
+}
+
+// AttachVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) (results []storage.AttachVolumesResult, err error) {
+	results = make([]storage.AttachVolumesResult, len(params))
+	err = s.provider.env.withClient(func(client Client) error {
+		for i, p := range params {
+			attachment, err := s.attachVolume(client, p)
+			if err != nil {
+				results[i].Error = errors.Annotatef(err, "attaching %s to %s", names.ReadableString(p.Volume), names.ReadableString(p.Machine))
+				continue
+			}
+			results[i].VolumeAttachment = attachment
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+func (s *libvirtVolumeSource) attachVolume(client Client, p storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	pool, name, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	d, err := s.instanceDomain(client, p.InstanceId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	serial := volumeSerial(p.Volume)
+	attachment := &storage.VolumeAttachment{
+		Volume:  p.Volume,
+		Machine: p.Machine,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-" + serial,
+			ReadOnly:   p.ReadOnly,
+		},
+	}
+
+	// Is it already attached?
+	inUse := make(map[string]bool)
+	for _, disk := range d.def.Devices.Disks {
+		if disk.Source.Pool == pool && disk.Source.Volume == name {
+			return attachment, nil
+		}
+		inUse[disk.Target.Dev] = true
+	}
+
+	var dev string
+	for i := 0; dev == ""; i++ {
+		candidate, err := diskDeviceName(i)
+		if err != nil {
+			return nil, errors.Annotate(err, "no free disk device")
+		}
+		if !inUse[candidate] {
+			dev = candidate
+		}
+	}
+	disk := domainDisk{
+		Type:   "volume",
+		Device: "disk",
+		Driver: domainDiskDriver{Name: "qemu", Type: "raw"},
+		Source: domainDiskSource{Pool: pool, Volume: name},
+		Target: domainDiskTarget{Dev: dev, Bus: "virtio"},
+		Serial: serial,
+	}
+	if p.ReadOnly {
+		disk.ReadOnly = &struct{}{}
+	}
+	diskXML, err := marshalXML(disk)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := client.AttachDevice(d.Name, diskXML); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return attachment, nil
+}
+
+// DetachVolumes is specified on the storage.VolumeSource interface.
+func (s *libvirtVolumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) (results []error, err error) {
+	results = make([]error, len(params))
+	err = s.provider.env.withClient(func(client Client) error {
+		for i, p := range params {
+			if err := s.detachVolume(client, p); err != nil {
+				results[i] = errors.Annotatef(err, "detaching %s from %s", names.ReadableString(p.Volume), names.ReadableString(p.Machine))
+			}
+		}
+		return nil
+	})
+	return results, errors.Trace(err)
+}
+
+func (s *libvirtVolumeSource) detachVolume(client Client, p storage.VolumeAttachmentParams) error {
+	pool, name, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	d, err := s.instanceDomain(client, p.InstanceId)
+	if errors.IsNotFound(err) {
+		// The machine is gone, and so is the attachment.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, disk := range d.def.Devices.Disks {
+		if disk.Source.Pool != pool || disk.Source.Volume != name {
+			continue
+		}
+		diskXML, err := marshalXML(disk)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(client.DetachDevice(d.Name, diskXML))
+	}
+	return nil
+}
+
+// instanceDomain returns the model's domain with the given instance ID.
+func (s *libvirtVolumeSource) instanceDomain(client Client, id instance.Id) (modelDomain, error) {
+	domains, err := s.provider.env.modelDomains(client)
+	if err != nil {
+		return modelDomain{}, errors.Trace(err)
+	}
+	for _, d := range domains {
+		if d.Name == string(id) {
+			return d, nil
+		}
+	}
+	return modelDomain{}, errors.NotFoundf("instance %q", id)
+}
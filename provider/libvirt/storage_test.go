@@ -0,0 +1,268 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	provider storage.Provider
+	source   storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+
+	provider, err := s.env.StorageProvider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+	s.provider = provider
+
+	cfg, err := storage.NewConfig("libvirt", "libvirt", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.source, err = s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestStorageProviderTypes(c *gc.C) {
+	types, err := s.env.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"libvirt"})
+}
+
+func (s *storageSuite) TestStorageProviderUnknown(c *gc.C) {
+	_, err := s.env.StorageProvider("lxd")
+	c.Assert(err, gc.ErrorMatches, `storage provider "lxd" not found`)
+}
+
+func (s *storageSuite) TestSupports(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *storageSuite) TestScope(c *gc.C) {
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("fast", "libvirt", map[string]interface{}{
+		"libvirt-pool": "ssd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provider.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("fast", "libvirt", map[string]interface{}{
+		"libvirt-pool": 123,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating libvirt storage config: libvirt-pool: expected string, got int\(123\)`)
+}
+
+func (s *storageSuite) TestFilesystemSource(c *gc.C) {
+	cfg, err := storage.NewConfig("libvirt", "libvirt", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.FilesystemSource(cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	results, err := s.source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}, {
+		Tag:        names.NewVolumeTag("1"),
+		Size:       2048,
+		Attributes: map[string]interface{}{"libvirt-pool": "ssd"},
+	}, {
+		Tag: names.NewVolumeTag("2"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "default:juju-f75cba-volume-0",
+			Size:       1024,
+			Persistent: true,
+		},
+	})
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Volume.VolumeId, gc.Equals, "ssd:juju-f75cba-volume-1")
+	c.Assert(results[2].Error, gc.ErrorMatches, "volume size 0 not valid")
+
+	s.client.CheckCallNames(c, "CreateVolume", "CreateVolume", "Close")
+	s.client.CheckCall(c, 0, "CreateVolume", "default",
+		`<volume><name>juju-f75cba-volume-0</name><capacity unit="MiB">1024</capacity>`+
+			`<target><format type="raw"></format></target></volume>`,
+	)
+	c.Assert(s.client.Calls()[1].Args[0], gc.Equals, "ssd")
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	s.client.pools = []string{"default", "ssd"}
+	s.client.volumes = map[string][]libvirtclient.Volume{
+		"default": {
+			{Pool: "default", Name: "juju-f75cba-volume-0"},
+			{Pool: "default", Name: "juju-f75cba-0-root"},
+			{Pool: "default", Name: "juju-9b3e00-volume-0"},
+		},
+		"ssd": {
+			{Pool: "ssd", Name: "juju-f75cba-volume-1"},
+		},
+	}
+	ids, err := s.source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{
+		"default:juju-f75cba-volume-0",
+		"ssd:juju-f75cba-volume-1",
+	})
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	s.client.volumes = map[string][]libvirtclient.Volume{
+		"default": {{
+			Pool:     "default",
+			Name:     "juju-f75cba-volume-0",
+			Capacity: 1024 * 1024 * 1024,
+		}},
+	}
+	results, err := s.source.DescribeVolumes(s.callCtx, []string{
+		"default:juju-f75cba-volume-0",
+		"default:juju-f75cba-volume-1",
+		"nonsense",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "default:juju-f75cba-volume-0",
+		Size:       1024,
+		Persistent: true,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+	c.Assert(results[2].Error, gc.ErrorMatches, `volume ID "nonsense" not valid`)
+
+	// The pool's volumes are only listed once.
+	s.client.CheckCallNames(c, "Volumes", "Close")
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("boom"))
+	results, err := s.source.DestroyVolumes(s.callCtx, []string{
+		"default:juju-f75cba-volume-0",
+		"ssd:juju-f75cba-volume-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `destroying volume "ssd:juju-f75cba-volume-1": boom`)
+	s.client.CheckCalls(c, []testing.StubCall{
+		{"DeleteVolume", []interface{}{"default", "juju-f75cba-volume-0"}},
+		{"DeleteVolume", []interface{}{"ssd", "juju-f75cba-volume-1"}},
+		{"Close", nil},
+	})
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(false),
+			diskXML("default", "juju-f75cba-volume-0", "vdb", "volume-0"),
+		),
+	}
+
+	results, err := s.source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "juju-f75cba-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "default:juju-f75cba-volume-0",
+	}, {
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "juju-f75cba-0",
+		},
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "ssd:juju-f75cba-volume-1",
+	}, {
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1"),
+			InstanceId: "juju-f75cba-1",
+		},
+		Volume:   names.NewVolumeTag("2"),
+		VolumeId: "default:juju-f75cba-volume-2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+
+	// Volume 0 is already attached.
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-volume-0",
+		},
+	})
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].VolumeAttachment.DeviceLink, gc.Equals, "/dev/disk/by-id/virtio-volume-1")
+	c.Assert(results[2].Error, gc.ErrorMatches, `attaching volume 2 to machine 1: instance "juju-f75cba-1" not found`)
+
+	s.client.CheckCallNames(c, "Domains", "Domains", "AttachDevice", "Domains", "Close")
+	s.client.CheckCall(c, 2, "AttachDevice", "juju-f75cba-0",
+		`<disk type="volume" device="disk">`+
+			`<driver name="qemu" type="raw"></driver>`+
+			`<source pool="ssd" volume="juju-f75cba-volume-1"></source>`+
+			`<target dev="vdc" bus="virtio"></target>`+
+			`<serial>volume-1</serial>`+
+			`</disk>`,
+	)
+}
+
+func (s *storageSuite) TestDetachVolumes(c *gc.C) {
+	s.client.domains = []libvirtclient.Domain{
+		newDomain("juju-f75cba-0", true, modelTags(false),
+			diskXML("default", "juju-f75cba-volume-0", "vdb", "volume-0"),
+		),
+	}
+	results, err := s.source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "juju-f75cba-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "default:juju-f75cba-volume-0",
+	}, {
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1"),
+			InstanceId: "juju-f75cba-1",
+		},
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "default:juju-f75cba-volume-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil, nil})
+
+	s.client.CheckCallNames(c, "Domains", "DetachDevice", "Domains", "Close")
+	s.client.CheckCall(c, 1, "DetachDevice", "juju-f75cba-0",
+		`<disk type="volume" device="disk">`+
+			`<driver name="qemu" type="raw"></driver>`+
+			`<source pool="default" volume="juju-f75cba-volume-0"></source>`+
+			`<target dev="vdb" bus="virtio"></target>`+
+			`<serial>volume-0</serial>`+
+			`</disk>`,
+	)
+}
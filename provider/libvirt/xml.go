@@ -0,0 +1,289 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+// Only the subset of the libvirt XML formats which the provider needs
+// is modelled here. See https://libvirt.org/format.html for details of
+// each format.
+
+// metadataNamespace is the namespace URI of the custom domain metadata
+// element in which the provider records a machine's Juju tags.
+const metadataNamespace = "https://juju.is/libvirt/1"
+
+// metadataKey is the prefix libvirt uses for the metadata element.
+const metadataKey = "juju"
+
+// domain describes a libvirt domain.
+// See: https://libvirt.org/formatdomain.html
+type domain struct {
+	XMLName  xml.Name        `xml:"domain"`
+	Type     string          `xml:"type,attr"`
+	Name     string          `xml:"name"`
+	Metadata *domainMetadata `xml:"metadata,omitempty"`
+	Memory   domainMemory    `xml:"memory"`
+	VCPU     uint64          `xml:"vcpu"`
+	OS       domainOS        `xml:"os"`
+	Features *domainFeatures `xml:"features,omitempty"`
+	CPU      *domainCPU      `xml:"cpu,omitempty"`
+	Devices  domainDevices   `xml:"devices"`
+}
+
+// domainMetadata holds custom metadata for a domain. Each element
+// must be in its own namespace.
+type domainMetadata struct {
+	Tags *domainTags `xml:"https://juju.is/libvirt/1 tags,omitempty"`
+}
+
+// domainTags holds the Juju tags of a domain.
+type domainTags struct {
+	XMLName xml.Name    `xml:"https://juju.is/libvirt/1 tags"`
+	Tags    []domainTag `xml:"tag"`
+}
+
+// domainTag is a single key/value tag.
+type domainTag struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type domainMemory struct {
+	Unit  string `xml:"unit,attr"`
+	Value uint64 `xml:",chardata"`
+}
+
+type domainOS struct {
+	Type domainOSType `xml:"type"`
+	Boot []domainBoot `xml:"boot"`
+}
+
+type domainOSType struct {
+	Arch  string `xml:"arch,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type domainBoot struct {
+	Dev string `xml:"dev,attr"`
+}
+
+type domainFeatures struct {
+	ACPI *struct{} `xml:"acpi"`
+	APIC *struct{} `xml:"apic"`
+}
+
+type domainCPU struct {
+	Mode string `xml:"mode,attr"`
+}
+
+type domainDevices struct {
+	Disks      []domainDisk      `xml:"disk"`
+	Interfaces []domainInterface `xml:"interface"`
+	Serials    []domainSerial    `xml:"serial"`
+	Consoles   []domainConsole   `xml:"console"`
+}
+
+// domainDisk is a disk backed by a volume in a storage pool.
+// See: https://libvirt.org/formatdomain.html#elementsDisks
+type domainDisk struct {
+	XMLName  xml.Name         `xml:"disk"`
+	Type     string           `xml:"type,attr"`
+	Device   string           `xml:"device,attr"`
+	Driver   domainDiskDriver `xml:"driver"`
+	Source   domainDiskSource `xml:"source"`
+	Target   domainDiskTarget `xml:"target"`
+	Serial   string           `xml:"serial,omitempty"`
+	ReadOnly *struct{}        `xml:"readonly"`
+}
+
+type domainDiskDriver struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type domainDiskSource struct {
+	Pool   string `xml:"pool,attr,omitempty"`
+	Volume string `xml:"volume,attr,omitempty"`
+}
+
+type domainDiskTarget struct {
+	Dev string `xml:"dev,attr"`
+	Bus string `xml:"bus,attr,omitempty"`
+}
+
+// domainInterface is a network interface attached to a libvirt
+// virtual network.
+// See: https://libvirt.org/formatdomain.html#elementsNICSVirtual
+type domainInterface struct {
+	Type   string                `xml:"type,attr"`
+	MAC    domainInterfaceMAC    `xml:"mac"`
+	Source domainInterfaceSource `xml:"source"`
+	Model  domainInterfaceModel  `xml:"model"`
+}
+
+type domainInterfaceMAC struct {
+	Address string `xml:"address,attr"`
+}
+
+type domainInterfaceSource struct {
+	Network string `xml:"network,attr"`
+}
+
+type domainInterfaceModel struct {
+	Type string `xml:"type,attr"`
+}
+
+type domainSerial struct {
+	Type   string             `xml:"type,attr"`
+	Target domainSerialTarget `xml:"target"`
+}
+
+type domainSerialTarget struct {
+	Port int `xml:"port,attr"`
+}
+
+type domainConsole struct {
+	Type   string              `xml:"type,attr"`
+	Target domainConsoleTarget `xml:"target"`
+}
+
+type domainConsoleTarget struct {
+	Type string `xml:"type,attr"`
+	Port int    `xml:"port,attr"`
+}
+
+// parseDomain parses a domain's XML description.
+func parseDomain(data string) (*domain, error) {
+	var d domain
+	if err := xml.Unmarshal([]byte(data), &d); err != nil {
+		return nil, errors.Annotate(err, "parsing domain XML")
+	}
+	return &d, nil
+}
+
+// tags returns the Juju tags recorded in the domain's metadata.
+func (d *domain) tags() map[string]string {
+	result := make(map[string]string)
+	if d.Metadata == nil || d.Metadata.Tags == nil {
+		return result
+	}
+	for _, tag := range d.Metadata.Tags.Tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+// newDomainTags returns the metadata element holding the given tags.
+func newDomainTags(tags map[string]string) *domainTags {
+	result := &domainTags{}
+	for _, key := range sortedKeys(tags) {
+		result.Tags = append(result.Tags, domainTag{Key: key, Value: tags[key]})
+	}
+	return result
+}
+
+// storageVolume describes a volume in a storage pool.
+// See: https://libvirt.org/formatstorage.html#StorageVol
+type storageVolume struct {
+	XMLName      xml.Name             `xml:"volume"`
+	Name         string               `xml:"name"`
+	Capacity     storageVolumeSize    `xml:"capacity"`
+	Target       *storageVolumeTarget `xml:"target,omitempty"`
+	BackingStore *storageVolumeTarget `xml:"backingStore,omitempty"`
+}
+
+type storageVolumeSize struct {
+	Unit  string `xml:"unit,attr"`
+	Value uint64 `xml:",chardata"`
+}
+
+type storageVolumeTarget struct {
+	Path   string               `xml:"path,omitempty"`
+	Format *storageVolumeFormat `xml:"format,omitempty"`
+}
+
+type storageVolumeFormat struct {
+	Type string `xml:"type,attr"`
+}
+
+// virtualNetwork describes a libvirt virtual network.
+// See: https://libvirt.org/formatnetwork.html
+type virtualNetwork struct {
+	XMLName xml.Name    `xml:"network"`
+	Name    string      `xml:"name"`
+	IPs     []networkIP `xml:"ip"`
+}
+
+type networkIP struct {
+	Family  string `xml:"family,attr"`
+	Address string `xml:"address,attr"`
+	Netmask string `xml:"netmask,attr"`
+	Prefix  string `xml:"prefix,attr"`
+}
+
+// parseNetwork parses a network's XML description.
+func parseNetwork(data string) (*virtualNetwork, error) {
+	var n virtualNetwork
+	if err := xml.Unmarshal([]byte(data), &n); err != nil {
+		return nil, errors.Annotate(err, "parsing network XML")
+	}
+	return &n, nil
+}
+
+// CIDR returns the CIDR of the subnet defined by the network address.
+func (ip networkIP) CIDR() (string, error) {
+	addr := net.ParseIP(ip.Address)
+	if addr == nil {
+		return "", errors.NotValidf("network address %q", ip.Address)
+	}
+	var mask net.IPMask
+	switch {
+	case ip.Netmask != "":
+		maskIP := net.ParseIP(ip.Netmask).To4()
+		if maskIP == nil {
+			return "", errors.NotValidf("netmask %q", ip.Netmask)
+		}
+		mask = net.IPMask(maskIP)
+	case ip.Prefix != "":
+		bits := 128
+		if addr.To4() != nil {
+			bits = 32
+		}
+		prefix, err := strconv.Atoi(ip.Prefix)
+		if err != nil || prefix < 0 || prefix > bits {
+			return "", errors.NotValidf("prefix %q", ip.Prefix)
+		}
+		mask = net.CIDRMask(prefix, bits)
+	default:
+		return "", errors.NotValidf("network address %q without netmask or prefix", ip.Address)
+	}
+	if addr.To4() != nil {
+		addr = addr.To4()
+	}
+	subnet := net.IPNet{IP: addr.Mask(mask), Mask: mask}
+	return subnet.String(), nil
+}
+
+func marshalXML(v interface{}) (string, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// diskDeviceName returns the name of the i'th virtio disk device.
+func diskDeviceName(i int) (string, error) {
+	if i < 0 || i > 25 {
+		return "", errors.Errorf("got %d but only support devices 0-25", i)
+	}
+	return fmt.Sprintf("vd%c", 'a'+i), nil
+}
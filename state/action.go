@@ -127,6 +127,13 @@ type actionDoc struct {
 
 	// Logs holds the progress messages logged by the action.
 	Logs []ActionMessage `bson:"messages"`
+
+	// Batch is the index of the batch of a rolling operation in
+	// which the action runs.
+	Batch int `bson:"batch,omitempty"`
+
+	// Attachments is the number of files attached to the action.
+	Attachments int `bson:"attachments,omitempty"`
 }
 
 // ActionMessage represents a progress message logged by an action.
@@ -213,6 +220,12 @@ func (a *action) Status() ActionStatus {
 	return a.doc.Status
 }
 
+// Batch returns the index of the batch of a rolling operation in
+// which the action runs.
+func (a *action) Batch() int {
+	return a.doc.Batch
+}
+
 // Results returns the structured output of the action and any error.
 func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
@@ -356,6 +369,7 @@ func (a *action) Cancel() (Action, error) {
 	if err = m.st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
+	a.updateRollingOperation(m, parentOperation)
 	return m.Action(a.Id())
 }
 
@@ -378,9 +392,22 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 	if err = m.st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
+	a.updateRollingOperation(m, parentOperation)
 	return m.Action(a.Id())
 }
 
+// updateRollingOperation moves the parent operation of a finished
+// action on to its next batch, if it is a rolling operation. The action
+// has already finished, so a failure is logged rather than returned.
+func (a *action) updateRollingOperation(m *Model, parentOperation Operation) {
+	if parentOperation == nil || parentOperation.Rolling() == nil {
+		return
+	}
+	if err := m.updateRollingOperation(parentOperation.Id()); err != nil {
+		actionLogger.Errorf("updating rolling operation %v: %v", parentOperation.Id(), err)
+	}
+}
+
 // removeAndLogBuildTxn is shared by Cancel and removeAndLog to correctly finalise an action and it's parent op.
 func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[string]interface{}, message string,
 	m *Model, parentOperation Operation, completedTime time.Time) jujutxn.TransactionSource {
@@ -541,7 +568,6 @@ func IsNewActionIDSupported(ver version.Number) bool {
 
 // newActionDoc builds the actionDoc with the given name and parameters.
 func newActionDoc(mb modelBackend, operationID string, receiverTag names.Tag, actionName string, parameters map[string]interface{}, modelAgentVersion version.Number) (actionDoc, actionNotificationDoc, error) {
-	prefix := ensureActionMarker(receiverTag.Id())
 	// For actions run on units, we want to use a user friendly action id.
 	// Theoretically, an action receiver could also be a machine, but for
 	// now we'll continue to use a UUID for that case, since I don't think
@@ -562,22 +588,27 @@ func newActionDoc(mb modelBackend, operationID string, receiverTag names.Tag, ac
 		actionId = actionUUID.String()
 	}
 	actionLogger.Debugf("newActionDoc name: '%s', receiver: '%s', actionId: '%s'", actionName, receiverTag, actionId)
-	modelUUID := mb.modelUUID()
 	return actionDoc{
-			DocId:      mb.docID(actionId),
-			ModelUUID:  modelUUID,
-			Receiver:   receiverTag.Id(),
-			Name:       actionName,
-			Parameters: parameters,
-			Enqueued:   mb.nowToTheSecond(),
-			Operation:  operationID,
-			Status:     ActionPending,
-		}, actionNotificationDoc{
-			DocId:     mb.docID(prefix + actionId),
-			ModelUUID: modelUUID,
-			Receiver:  receiverTag.Id(),
-			ActionID:  actionId,
-		}, nil
+		DocId:      mb.docID(actionId),
+		ModelUUID:  mb.modelUUID(),
+		Receiver:   receiverTag.Id(),
+		Name:       actionName,
+		Parameters: parameters,
+		Enqueued:   mb.nowToTheSecond(),
+		Operation:  operationID,
+		Status:     ActionPending,
+	}, newActionNotificationDoc(mb, receiverTag.Id(), actionId), nil
+}
+
+// newActionNotificationDoc builds the actionNotificationDoc that lets
+// the receiver know the action with the given id is queued for it.
+func newActionNotificationDoc(mb modelBackend, receiver, actionId string) actionNotificationDoc {
+	return actionNotificationDoc{
+		DocId:     mb.docID(ensureActionMarker(receiver) + actionId),
+		ModelUUID: mb.modelUUID(),
+		Receiver:  receiver,
+		ActionID:  actionId,
+	}
 }
 
 var ensureActionMarker = ensureSuffixFn(actionMarker)
@@ -707,38 +738,67 @@ func (m *Model) EnqueueAction(operationID string, receiver names.Tag, actionName
 		return nil, errors.Trace(err)
 	}
 
-	ops := []txn.Op{{
-		C:      receiverCollectionName,
-		Id:     receiverId,
-		Assert: notDeadDoc,
-	}, {
-		C:      operationsC,
-		Id:     m.st.docID(operationID),
-		Assert: txn.DocExists,
-	}, {
-		C:      actionsC,
-		Id:     doc.DocId,
-		Assert: txn.DocMissing,
-		Insert: doc,
-	}, {
-		C:      actionNotificationsC,
-		Id:     ndoc.DocId,
-		Assert: txn.DocMissing,
-		Insert: ndoc,
-	}}
-
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
 			return nil, err
 		} else if !notDead {
 			return nil, ErrDead
-		} else if attempt != 0 {
-			_, err := m.Operation(operationID)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
+		}
+		op, err := m.Operation(operationID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		opDoc := op.(*operation).doc
+		if attempt != 0 && opDoc.BatchSize == 0 {
 			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
 		}
+		if opDoc.Message != "" {
+			return nil, errors.Errorf("operation %v has been stopped: %v", operationID, opDoc.Message)
+		}
+
+		ops := []txn.Op{{
+			C:      receiverCollectionName,
+			Id:     receiverId,
+			Assert: notDeadDoc,
+		}}
+		notify := true
+		if opDoc.BatchSize == 0 {
+			ops = append(ops, txn.Op{
+				C:      operationsC,
+				Id:     opDoc.DocId,
+				Assert: txn.DocExists,
+			})
+		} else {
+			// Tasks of a rolling operation are assigned to batches in
+			// the order they are enqueued. The receiver is only notified
+			// of a task once its batch is able to run.
+			doc.Batch = opDoc.TaskCount / opDoc.BatchSize
+			notify = doc.Batch <= opDoc.CurrentBatch
+			ops = append(ops, txn.Op{
+				C:  operationsC,
+				Id: opDoc.DocId,
+				Assert: bson.D{
+					{"task-count", opDoc.TaskCount},
+					{"current-batch", opDoc.CurrentBatch},
+					{"message", bson.D{{"$exists", false}}},
+				},
+				Update: bson.D{{"$inc", bson.D{{"task-count", 1}}}},
+			})
+		}
+		ops = append(ops, txn.Op{
+			C:      actionsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+		if notify {
+			ops = append(ops, txn.Op{
+				C:      actionNotificationsC,
+				Id:     ndoc.DocId,
+				Assert: txn.DocMissing,
+				Insert: ndoc,
+			})
+		}
 		return ops, nil
 	}
 	if err = m.st.db().Run(buildTxn); err == nil {
@@ -816,7 +876,9 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 
 // PruneOperations removes operation entries and their sub-tasks until
 // only logs newer than <maxLogTime> remain and also ensures
-// that the actions collection is smaller than <maxLogsMB> after the deletion.
+// that the actions collection, together with the tasks' attachments, is
+// smaller than <maxLogsMB> after the deletion. The attachments of removed
+// tasks are removed with them.
 func PruneOperations(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
 	// There may be older actions without parent operations so try those first.
 	hasNoOperation := bson.D{{"$or", []bson.D{
@@ -847,5 +909,14 @@ func PruneOperations(st *State, maxHistoryTime time.Duration, maxHistoryMB int)
 	sizeFactor := float64(actionsCount) / float64(operationsCount)
 
 	err = pruneCollectionAndChildren(st, maxHistoryTime, maxHistoryMB, operationsC, "completed", actionsC, "operation", nil, sizeFactor, GoTime)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Attachments are removed once the actions they belong to have gone.
+	if err := pruneActionAttachments(st); err != nil {
+		return errors.Annotate(err, "pruning action attachments")
+	}
+	// Attachment contents are held in blob storage, so they aren't
+	// counted in the size of the actions collection above.
+	return errors.Annotate(pruneActionAttachmentsBySize(st, maxHistoryMB), "pruning action attachments by size")
 }
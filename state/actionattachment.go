@@ -0,0 +1,310 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+const (
+	// MaxActionAttachmentSize is the size in bytes of the largest file
+	// that may be attached to an action.
+	MaxActionAttachmentSize = 100 * 1024 * 1024
+
+	// MaxActionAttachments is the number of files that may be attached
+	// to a single action.
+	MaxActionAttachments = 10
+)
+
+var validActionAttachmentName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// ActionAttachment describes a file attached to an action by the unit
+// running it.
+type ActionAttachment struct {
+	Name    string
+	Size    int64
+	SHA384  string
+	Created time.Time
+}
+
+type actionAttachmentDoc struct {
+	DocId       string    `bson:"_id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	ActionId    string    `bson:"action"`
+	Name        string    `bson:"name"`
+	Size        int64     `bson:"size"`
+	SHA384      string    `bson:"sha384"`
+	StoragePath string    `bson:"storage-path"`
+	Created     time.Time `bson:"created"`
+}
+
+func (doc actionAttachmentDoc) attachment() ActionAttachment {
+	return ActionAttachment{
+		Name:    doc.Name,
+		Size:    doc.Size,
+		SHA384:  doc.SHA384,
+		Created: doc.Created.UTC(),
+	}
+}
+
+func actionAttachmentId(actionId, name string) string {
+	return fmt.Sprintf("%s:%s", actionId, name)
+}
+
+// AddAttachment stores size bytes read from r as a file attached to the
+// action with the given name. The contents must match the given SHA384
+// hash, and the action must be running.
+func (a *action) AddAttachment(name string, r io.Reader, size int64, sha384 string) error {
+	if !validActionAttachmentName.MatchString(name) {
+		return errors.NotValidf("attachment name %q", name)
+	}
+	if size > MaxActionAttachmentSize {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"attachment %q is %d bytes, larger than the %d bytes allowed", name, size, MaxActionAttachmentSize))
+	}
+	if s := a.Status(); s != ActionRunning && s != ActionAborting {
+		return errors.Errorf("cannot attach file to task %q with status %v", a.Id(), s)
+	}
+	existing, err := a.Attachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(existing) >= MaxActionAttachments {
+		return errors.Errorf("task %q already has %d attachments", a.Id(), len(existing))
+	}
+	for _, att := range existing {
+		if att.Name == name {
+			return errors.AlreadyExistsf("attachment %q", name)
+		}
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("actionattachments/%s", uuid)
+	stor := storage.NewStorage(a.st.ModelUUID(), a.st.MongoSession())
+	if err := stor.PutAndCheckHash(storagePath, r, size, sha384); err != nil {
+		return errors.Annotatef(err, "storing attachment %q", name)
+	}
+
+	id := actionAttachmentId(a.Id(), name)
+	ops := []txn.Op{{
+		C:  actionsC,
+		Id: a.doc.DocId,
+		Assert: bson.D{
+			{"$or", []bson.D{
+				{{"status", ActionRunning}},
+				{{"status", ActionAborting}},
+			}},
+			// $not also matches actions without the field, which
+			// have no attachments.
+			{"attachments", bson.D{{"$not", bson.D{{"$gte", MaxActionAttachments}}}}},
+		},
+		Update: bson.D{{"$inc", bson.D{{"attachments", 1}}}},
+	}, {
+		C:      actionAttachmentsC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &actionAttachmentDoc{
+			DocId:       a.st.docID(id),
+			ModelUUID:   a.st.ModelUUID(),
+			ActionId:    a.Id(),
+			Name:        name,
+			Size:        size,
+			SHA384:      sha384,
+			StoragePath: storagePath,
+			Created:     a.st.nowToTheSecond(),
+		},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		if removeErr := stor.Remove(storagePath); removeErr != nil {
+			logger.Warningf("cannot remove unused attachment %q: %v", storagePath, removeErr)
+		}
+		if err == txn.ErrAborted {
+			if err := a.Refresh(); err == nil && a.doc.Attachments >= MaxActionAttachments {
+				return errors.Errorf("task %q already has %d attachments", a.Id(), a.doc.Attachments)
+			}
+			return errors.Errorf("cannot attach file %q to task %q: task finished or file already attached", name, a.Id())
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Attachments returns the files attached to the action, sorted by name.
+func (a *action) Attachments() ([]ActionAttachment, error) {
+	attachments, closer := a.st.db().GetCollection(actionAttachmentsC)
+	defer closer()
+
+	var docs []actionAttachmentDoc
+	if err := attachments.Find(bson.D{{"action", a.Id()}}).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get attachments for task %q", a.Id())
+	}
+	result := make([]ActionAttachment, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.attachment()
+	}
+	return result, nil
+}
+
+// OpenAttachment returns the action's attachment with the given name,
+// and a reader for its contents. The caller is responsible for closing
+// the reader.
+func (a *action) OpenAttachment(name string) (ActionAttachment, io.ReadCloser, error) {
+	attachments, closer := a.st.db().GetCollection(actionAttachmentsC)
+	defer closer()
+
+	var doc actionAttachmentDoc
+	err := attachments.FindId(actionAttachmentId(a.Id(), name)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ActionAttachment{}, nil, errors.NotFoundf("attachment %q of task %q", name, a.Id())
+	}
+	if err != nil {
+		return ActionAttachment{}, nil, errors.Annotatef(err, "cannot get attachment %q", name)
+	}
+	stor := storage.NewStorage(a.st.ModelUUID(), a.st.MongoSession())
+	r, _, err := stor.Get(doc.StoragePath)
+	if err != nil {
+		return ActionAttachment{}, nil, errors.Annotatef(err, "reading attachment %q", name)
+	}
+	return doc.attachment(), r, nil
+}
+
+// pruneActionAttachments removes the attachments, and their stored
+// contents, of actions that no longer exist.
+func pruneActionAttachments(st *State) error {
+	attachments, closer := st.db().GetCollection(actionAttachmentsC)
+	defer closer()
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionAttachmentDoc
+	if err := attachments.Find(nil).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	var actionIds []string
+	for _, doc := range docs {
+		actionIds = append(actionIds, st.docID(doc.ActionId))
+	}
+	var actionDocs []struct {
+		DocId string `bson:"_id"`
+	}
+	err := actions.Find(bson.D{{"_id", bson.D{{"$in", actionIds}}}}).Select(bson.D{{"_id", 1}}).All(&actionDocs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	exists := make(map[string]bool)
+	for _, doc := range actionDocs {
+		exists[st.localID(doc.DocId)] = true
+	}
+
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	removed := 0
+	for _, doc := range docs {
+		if exists[doc.ActionId] {
+			continue
+		}
+		if err := stor.Remove(doc.StoragePath); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing attachment %q", doc.StoragePath)
+		}
+		ops := []txn.Op{{
+			C:      actionAttachmentsC,
+			Id:     doc.DocId,
+			Remove: true,
+		}}
+		if err := st.db().RunTransaction(ops); err != nil {
+			return errors.Trace(err)
+		}
+		removed++
+	}
+	if removed > 0 {
+		logger.Infof("removed %d attachments of pruned actions", removed)
+	}
+	return nil
+}
+
+// pruneActionAttachmentsBySize removes the oldest attachments of
+// finished actions until they fit, together with the actions
+// collection, within maxHistoryMB. Like the collection pruner, it only
+// prunes by size in the controller model, as the sizes are those of all
+// models.
+func pruneActionAttachmentsBySize(st *State, maxHistoryMB int) error {
+	if !st.IsController() || maxHistoryMB == 0 {
+		return nil
+	}
+	// Raw collections are used to see the attachments of all models.
+	actions, closer := st.db().GetRawCollection(actionsC)
+	defer closer()
+	actionsMB, err := getCollectionMB(actions)
+	if err != nil {
+		return errors.Annotate(err, "retrieving actions collection size")
+	}
+	attachments, closer := st.db().GetRawCollection(actionAttachmentsC)
+	defer closer()
+	var docs []actionAttachmentDoc
+	if err := attachments.Find(nil).Sort("created").All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	var total int64
+	for _, doc := range docs {
+		total += doc.Size
+	}
+	allowed := int64(maxHistoryMB-actionsMB) * humanize.MiByte
+	if total <= allowed {
+		return nil
+	}
+
+	// Attachments of running actions may still be being added to,
+	// and are kept however large they are.
+	var running []struct {
+		DocId string `bson:"_id"`
+	}
+	err = actions.Find(bson.D{{"status", bson.D{{"$in", []ActionStatus{ActionRunning, ActionAborting}}}}}).Select(bson.D{{"_id", 1}}).All(&running)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	isRunning := make(map[string]bool)
+	for _, doc := range running {
+		isRunning[doc.DocId] = true
+	}
+
+	removed := 0
+	for _, doc := range docs {
+		if total <= allowed {
+			break
+		}
+		if isRunning[ensureModelUUID(doc.ModelUUID, doc.ActionId)] {
+			continue
+		}
+		stor := storage.NewStorage(doc.ModelUUID, st.MongoSession())
+		if err := stor.Remove(doc.StoragePath); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing attachment %q", doc.StoragePath)
+		}
+		if err := attachments.RemoveId(doc.DocId); err != nil && err != mgo.ErrNotFound {
+			return errors.Trace(err)
+		}
+		total -= doc.Size
+		removed++
+	}
+	if removed > 0 {
+		logger.Infof("action attachment size pruning: %d attachments removed", removed)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionAttachmentSuite struct {
+	ConnSuite
+	clock  *testclock.Clock
+	unit   *state.Unit
+	action state.Action
+}
+
+var _ = gc.Suite(&ActionAttachmentSuite{})
+
+func (s *ActionAttachmentSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = s.unit.AddAction(operationID, "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = s.action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func sha384(content string) string {
+	return fmt.Sprintf("%x", sha512.Sum384([]byte(content)))
+}
+
+func (s *ActionAttachmentSuite) addAttachment(c *gc.C, name, content string) {
+	err := s.action.AddAttachment(name, strings.NewReader(content), int64(len(content)), sha384(content))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachment(c *gc.C) {
+	s.addAttachment(c, "dump.sql", "select 1;")
+	s.addAttachment(c, "diagnostics.tar.gz", "not really a tarball")
+
+	attachments, err := s.action.Attachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, jc.DeepEquals, []state.ActionAttachment{{
+		Name:    "diagnostics.tar.gz",
+		Size:    20,
+		SHA384:  sha384("not really a tarball"),
+		Created: s.clock.Now().UTC(),
+	}, {
+		Name:    "dump.sql",
+		Size:    9,
+		SHA384:  sha384("select 1;"),
+		Created: s.clock.Now().UTC(),
+	}})
+
+	attachment, r, err := s.action.OpenAttachment("dump.sql")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Assert(attachment.Name, gc.Equals, "dump.sql")
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "select 1;")
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentInvalidName(c *gc.C) {
+	for _, name := range []string{"", "../passwd", "a/b", ".hidden"} {
+		err := s.action.AddAttachment(name, strings.NewReader("x"), 1, sha384("x"))
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentTooLarge(c *gc.C) {
+	err := s.action.AddAttachment("big", strings.NewReader(""), state.MaxActionAttachmentSize+1, "")
+	c.Assert(err, gc.ErrorMatches, `attachment "big" is \d+ bytes, larger than the \d+ bytes allowed`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentTooMany(c *gc.C) {
+	for i := 0; i < state.MaxActionAttachments; i++ {
+		s.addAttachment(c, fmt.Sprintf("file%d", i), "x")
+	}
+	err := s.action.AddAttachment("another", strings.NewReader("x"), 1, sha384("x"))
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`task %q already has 10 attachments`, s.action.Id()))
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentTooManyConcurrently(c *gc.C) {
+	for i := 0; i < state.MaxActionAttachments-1; i++ {
+		s.addAttachment(c, fmt.Sprintf("file%d", i), "x")
+	}
+	defer state.SetBeforeHooks(c, s.State, func() {
+		s.addAttachment(c, "last", "x")
+	})()
+	err := s.action.AddAttachment("another", strings.NewReader("x"), 1, sha384("x"))
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`task %q already has 10 attachments`, s.action.Id()))
+
+	attachments, err := s.action.Attachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, state.MaxActionAttachments)
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentDuplicate(c *gc.C) {
+	s.addAttachment(c, "dump.sql", "select 1;")
+	err := s.action.AddAttachment("dump.sql", strings.NewReader("x"), 1, sha384("x"))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentHashMismatch(c *gc.C) {
+	err := s.action.AddAttachment("dump.sql", strings.NewReader("select 1;"), 9, sha384("select 2;"))
+	c.Assert(err, gc.ErrorMatches, `storing attachment "dump.sql": .*`)
+
+	attachments, err := s.action.Attachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 0)
+}
+
+func (s *ActionAttachmentSuite) TestAddAttachmentNotRunning(c *gc.C) {
+	a, err := s.action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.AddAttachment("dump.sql", strings.NewReader("x"), 1, sha384("x"))
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`cannot attach file to task %q with status completed`, s.action.Id()))
+}
+
+func (s *ActionAttachmentSuite) TestOpenAttachmentNotFound(c *gc.C) {
+	_, _, err := s.action.OpenAttachment("dump.sql")
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`attachment "dump.sql" of task %q not found`, s.action.Id()))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionAttachmentSuite) TestPruneRemovesAttachments(c *gc.C) {
+	s.addAttachment(c, "dump.sql", "select 1;")
+	_, err := s.action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(2 * time.Hour)
+	err = state.PruneOperations(s.State, time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.Model.Action(s.action.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, _, err = s.action.OpenAttachment("dump.sql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionAttachmentSuite) TestPruneAttachmentsBySize(c *gc.C) {
+	large := strings.Repeat("x", 3*1024*1024)
+	s.addAttachment(c, "old.tar", large)
+	_, err := s.action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Minute)
+	operationID, err := s.Model.EnqueueOperation("another test")
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := s.unit.AddAction(operationID, "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = running.AddAttachment("new.tar", strings.NewReader(large), int64(len(large)), sha384(large))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The attachments don't fit in 2MB, but those of the running
+	// task are kept.
+	err = state.PruneOperations(s.State, 0, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.Model.Action(s.action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.action.OpenAttachment("old.tar")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, r, err := running.OpenAttachment("new.tar")
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+}
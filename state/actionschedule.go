@@ -0,0 +1,473 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/robfig/cron.v2"
+
+	"github.com/juju/juju/core/migration"
+)
+
+// maxActionScheduleRuns is the number of runs recorded for each action
+// schedule. When a new run is recorded, the oldest runs beyond this
+// number are discarded.
+const maxActionScheduleRuns = 100
+
+// ActionScheduleRunStatus describes the outcome of a scheduled run of
+// an action schedule.
+type ActionScheduleRunStatus string
+
+const (
+	// ActionScheduleRunEnqueued is the status of a run whose actions
+	// were all enqueued.
+	ActionScheduleRunEnqueued ActionScheduleRunStatus = "enqueued"
+
+	// ActionScheduleRunFailed is the status of a run for which one or
+	// more of the actions could not be enqueued.
+	ActionScheduleRunFailed ActionScheduleRunStatus = "failed"
+
+	// ActionScheduleRunMissed is the status of a run that was not
+	// attempted at its scheduled time, typically because the
+	// controller was unavailable.
+	ActionScheduleRunMissed ActionScheduleRunStatus = "missed"
+)
+
+// AddActionScheduleArgs holds the details of a new action schedule.
+type AddActionScheduleArgs struct {
+	// Schedule is the cron expression defining when the action is
+	// run, in the form accepted by gopkg.in/robfig/cron.v2.
+	Schedule string
+
+	// Receivers holds the names of the units on which the action is
+	// run. A receiver of the form "<application>/leader" runs the
+	// action on the application's leader at the time of each run.
+	Receivers []string
+
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters holds the parameters passed to the action.
+	Parameters map[string]interface{}
+
+	// CreatedBy is the name of the user that created the schedule.
+	CreatedBy string
+}
+
+// ActionSchedule is a schedule on which an action is run.
+type ActionSchedule struct {
+	Id         string
+	Schedule   string
+	Receivers  []string
+	ActionName string
+	Parameters map[string]interface{}
+	Created    time.Time
+	CreatedBy  string
+
+	// Runs holds the most recent runs of the schedule, oldest first.
+	Runs []ActionScheduleRun
+}
+
+// LastScheduled returns the scheduled time of the most recent run of
+// the schedule, or the time it was created if it has never run.
+func (s ActionSchedule) LastScheduled() time.Time {
+	if len(s.Runs) == 0 {
+		return s.Created
+	}
+	return s.Runs[len(s.Runs)-1].Scheduled
+}
+
+// ActionScheduleRun records a single run of an action schedule.
+type ActionScheduleRun struct {
+	// Scheduled is the time at which the run was scheduled. For a
+	// missed run recording several missed times, it is the latest of
+	// them.
+	Scheduled time.Time
+
+	// Recorded is the time at which the run was recorded.
+	Recorded time.Time
+
+	Status ActionScheduleRunStatus
+
+	// OperationId is the ID of the operation holding the enqueued
+	// actions. It is empty for missed runs.
+	OperationId string
+
+	// Message holds details of a failed or missed run.
+	Message string
+}
+
+type actionScheduleDoc struct {
+	DocId      string                 `bson:"_id"`
+	ModelUUID  string                 `bson:"model-uuid"`
+	Schedule   string                 `bson:"schedule"`
+	Receivers  []string               `bson:"receivers"`
+	ActionName string                 `bson:"action-name"`
+	Parameters map[string]interface{} `bson:"parameters,omitempty"`
+	Created    time.Time              `bson:"created"`
+	CreatedBy  string                 `bson:"created-by"`
+	Runs       []actionScheduleRunDoc `bson:"runs,omitempty"`
+	TxnRevno   int64                  `bson:"txn-revno"`
+}
+
+type actionScheduleRunDoc struct {
+	Scheduled   time.Time `bson:"scheduled"`
+	Recorded    time.Time `bson:"recorded"`
+	Status      string    `bson:"status"`
+	OperationId string    `bson:"operation-id,omitempty"`
+	Message     string    `bson:"message,omitempty"`
+}
+
+func (m *Model) newActionSchedule(doc *actionScheduleDoc) ActionSchedule {
+	schedule := ActionSchedule{
+		Id:         m.st.localID(doc.DocId),
+		Schedule:   doc.Schedule,
+		Receivers:  doc.Receivers,
+		ActionName: doc.ActionName,
+		Parameters: doc.Parameters,
+		Created:    doc.Created.UTC(),
+		CreatedBy:  doc.CreatedBy,
+	}
+	for _, run := range doc.Runs {
+		schedule.Runs = append(schedule.Runs, ActionScheduleRun{
+			Scheduled:   run.Scheduled.UTC(),
+			Recorded:    run.Recorded.UTC(),
+			Status:      ActionScheduleRunStatus(run.Status),
+			OperationId: run.OperationId,
+			Message:     run.Message,
+		})
+	}
+	return schedule
+}
+
+// validateActionScheduleReceiver checks that the receiver names a unit
+// or the leader of an application in the model.
+func (m *Model) validateActionScheduleReceiver(receiver string) error {
+	if appName := strings.TrimSuffix(receiver, "/leader"); appName != receiver {
+		if !names.IsValidApplication(appName) {
+			return errors.NotValidf("receiver %q", receiver)
+		}
+		_, err := m.st.Application(appName)
+		return errors.Trace(err)
+	}
+	if !names.IsValidUnit(receiver) {
+		return errors.NotValidf("receiver %q", receiver)
+	}
+	_, err := m.st.Unit(receiver)
+	return errors.Trace(err)
+}
+
+// AddActionSchedule adds a schedule on which an action is run on the
+// given receivers.
+func (m *Model) AddActionSchedule(args AddActionScheduleArgs) (ActionSchedule, error) {
+	if args.ActionName == "" {
+		return ActionSchedule{}, errors.New("action name required")
+	}
+	if _, err := cron.Parse(args.Schedule); err != nil {
+		return ActionSchedule{}, errors.NotValidf("schedule %q: %v", args.Schedule, err)
+	}
+	if len(args.Receivers) == 0 {
+		return ActionSchedule{}, errors.New("at least one receiver required")
+	}
+	for _, receiver := range args.Receivers {
+		if err := m.validateActionScheduleReceiver(receiver); err != nil {
+			return ActionSchedule{}, errors.Trace(err)
+		}
+	}
+	seq, err := sequenceWithMin(m.st, "actionschedule", 1)
+	if err != nil {
+		return ActionSchedule{}, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := &actionScheduleDoc{
+		DocId:      m.st.docID(id),
+		ModelUUID:  m.st.ModelUUID(),
+		Schedule:   args.Schedule,
+		Receivers:  args.Receivers,
+		ActionName: args.ActionName,
+		Parameters: args.Parameters,
+		Created:    m.st.nowToTheSecond(),
+		CreatedBy:  args.CreatedBy,
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.st.ModelUUID(),
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return ActionSchedule{}, errors.Annotate(err, "cannot add action schedule")
+	}
+	return m.newActionSchedule(doc), nil
+}
+
+func (m *Model) getActionScheduleDoc(id string) (*actionScheduleDoc, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &doc, nil
+}
+
+// ActionSchedule returns the action schedule with the given ID.
+func (m *Model) ActionSchedule(id string) (ActionSchedule, error) {
+	doc, err := m.getActionScheduleDoc(id)
+	if err != nil {
+		return ActionSchedule{}, errors.Trace(err)
+	}
+	return m.newActionSchedule(doc), nil
+}
+
+// AllActionSchedules returns all the action schedules in the model,
+// ordered by ID.
+func (m *Model) AllActionSchedules() ([]ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]ActionSchedule, len(docs))
+	for i := range docs {
+		result[i] = m.newActionSchedule(&docs[i])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, _ := strconv.Atoi(result[i].Id)
+		b, _ := strconv.Atoi(result[j].Id)
+		return a < b
+	})
+	return result, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given ID,
+// along with the history of its runs. Actions already enqueued by the
+// schedule are not affected.
+func (m *Model) RemoveActionSchedule(id string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := m.getActionScheduleDoc(id); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     m.st.docID(id),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// RunActionSchedule enqueues the actions of the schedule with the given
+// ID as a single operation, and records the run as happening at the
+// given scheduled time. A failure to enqueue the action on one of the
+// receivers does not prevent it being enqueued on the others, but is
+// recorded as a failed run.
+func (m *Model) RunActionSchedule(id string, scheduled time.Time) (ActionScheduleRun, error) {
+	doc, err := m.getActionScheduleDoc(id)
+	if err != nil {
+		return ActionScheduleRun{}, errors.Trace(err)
+	}
+	run := ActionScheduleRun{
+		Scheduled: scheduled.UTC(),
+		Status:    ActionScheduleRunEnqueued,
+	}
+	summary := fmt.Sprintf("%v run on %v (schedule %v)", doc.ActionName, strings.Join(doc.Receivers, ","), id)
+	run.OperationId, err = m.EnqueueOperation(summary)
+	if err != nil {
+		return ActionScheduleRun{}, errors.Annotate(err, "creating operation for scheduled actions")
+	}
+
+	var leaders map[string]string
+	var failures []string
+	for _, receiver := range doc.Receivers {
+		unitName := receiver
+		if appName := strings.TrimSuffix(receiver, "/leader"); appName != receiver {
+			if leaders == nil {
+				if leaders, err = m.st.ApplicationLeaders(); err != nil {
+					return ActionScheduleRun{}, errors.Trace(err)
+				}
+			}
+			leader, ok := leaders[appName]
+			if !ok {
+				failures = append(failures, fmt.Sprintf("%s: could not determine leader for %q", receiver, appName))
+				continue
+			}
+			unitName = leader
+		}
+		unit, err := m.st.Unit(unitName)
+		if err == nil {
+			_, err = unit.AddAction(run.OperationId, doc.ActionName, doc.Parameters)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", receiver, err))
+		}
+	}
+	if len(failures) > 0 {
+		run.Status = ActionScheduleRunFailed
+		run.Message = strings.Join(failures, "; ")
+	}
+	return m.recordActionScheduleRun(id, run)
+}
+
+// RecordActionScheduleMissed records that the schedule with the given ID
+// missed count runs, the latest of which was at the given scheduled
+// time.
+func (m *Model) RecordActionScheduleMissed(id string, scheduled time.Time, count int) error {
+	run := ActionScheduleRun{
+		Scheduled: scheduled.UTC(),
+		Status:    ActionScheduleRunMissed,
+		Message:   "missed 1 scheduled run",
+	}
+	if count > 1 {
+		run.Message = fmt.Sprintf("missed %d scheduled runs", count)
+	}
+	_, err := m.recordActionScheduleRun(id, run)
+	return errors.Trace(err)
+}
+
+func (m *Model) recordActionScheduleRun(id string, run ActionScheduleRun) (ActionScheduleRun, error) {
+	run.Recorded = m.st.nowToTheSecond()
+	runDoc := actionScheduleRunDoc{
+		Scheduled:   run.Scheduled,
+		Recorded:    run.Recorded,
+		Status:      string(run.Status),
+		OperationId: run.OperationId,
+		Message:     run.Message,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := m.getActionScheduleDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		runs := append(doc.Runs, runDoc)
+		if len(runs) > maxActionScheduleRuns {
+			runs = runs[len(runs)-maxActionScheduleRuns:]
+		}
+		return []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"runs", runs}}}},
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return ActionScheduleRun{}, errors.Annotatef(err, "cannot record run of action schedule %q", id)
+	}
+	return run, nil
+}
+
+// ExportActionSchedules returns the model's action schedules and the
+// history of their runs, for migrating them to another controller.
+// Schedules aren't part of the model description, so they're
+// transferred alongside it.
+func (st *State) ExportActionSchedules() ([]migration.SerializedActionSchedule, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules, err := m.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]migration.SerializedActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = migration.SerializedActionSchedule{
+			ID:         schedule.Id,
+			Schedule:   schedule.Schedule,
+			Receivers:  schedule.Receivers,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Created:    schedule.Created,
+			CreatedBy:  schedule.CreatedBy,
+		}
+		for _, run := range schedule.Runs {
+			result[i].Runs = append(result[i].Runs, migration.SerializedActionScheduleRun{
+				Scheduled:   run.Scheduled,
+				Recorded:    run.Recorded,
+				Status:      string(run.Status),
+				OperationID: run.OperationId,
+				Message:     run.Message,
+			})
+		}
+	}
+	return result, nil
+}
+
+// ImportActionSchedules adds action schedules exported from another
+// controller to the model, keeping their IDs and run history. The
+// schedules' receivers, and the sequence their IDs were taken from,
+// must already have been imported.
+func (st *State) ImportActionSchedules(schedules []migration.SerializedActionSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	m, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, schedule := range schedules {
+		for _, receiver := range schedule.Receivers {
+			if err := m.validateActionScheduleReceiver(receiver); err != nil {
+				return errors.Annotatef(err, "action schedule %q", schedule.ID)
+			}
+		}
+		doc := &actionScheduleDoc{
+			DocId:      st.docID(schedule.ID),
+			ModelUUID:  st.ModelUUID(),
+			Schedule:   schedule.Schedule,
+			Receivers:  schedule.Receivers,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Created:    schedule.Created.UTC(),
+			CreatedBy:  schedule.CreatedBy,
+		}
+		for _, run := range schedule.Runs {
+			doc.Runs = append(doc.Runs, actionScheduleRunDoc{
+				Scheduled:   run.Scheduled.UTC(),
+				Recorded:    run.Recorded.UTC(),
+				Status:      run.Status,
+				OperationId: run.OperationID,
+				Message:     run.Message,
+			})
+		}
+		ops = append(ops, txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot import action schedules")
+	}
+	return nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that triggers whenever
+// an action schedule in the model is added, removed or run.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, actionSchedulesC, isLocalID(m.st))
+}
@@ -0,0 +1,231 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.application.CharmURL()
+	err = s.unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, receivers ...string) state.ActionSchedule {
+	schedule, err := s.Model.AddActionSchedule(state.AddActionScheduleArgs{
+		Schedule:   "0 0 2 * * *",
+		Receivers:  receivers,
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "nightly.bz2"},
+		CreatedBy:  "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	first := s.addSchedule(c, "dummy/0")
+	second := s.addSchedule(c, "dummy/leader")
+	c.Assert(first.Id, gc.Equals, "1")
+	c.Assert(second.Id, gc.Equals, "2")
+
+	schedule, err := s.Model.ActionSchedule("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Schedule, gc.Equals, "0 0 2 * * *")
+	c.Assert(schedule.Receivers, jc.DeepEquals, []string{"dummy/0"})
+	c.Assert(schedule.ActionName, gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters, jc.DeepEquals, map[string]interface{}{"outfile": "nightly.bz2"})
+	c.Assert(schedule.CreatedBy, gc.Equals, "admin")
+	c.Assert(schedule.Runs, gc.HasLen, 0)
+	c.Assert(schedule.LastScheduled(), gc.Equals, schedule.Created)
+
+	all, err := s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].Id, gc.Equals, "1")
+	c.Assert(all[1].Id, gc.Equals, "2")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.AddActionScheduleArgs
+		err  string
+	}{{
+		args: state.AddActionScheduleArgs{Schedule: "@daily", Receivers: []string{"dummy/0"}},
+		err:  "action name required",
+	}, {
+		args: state.AddActionScheduleArgs{Schedule: "every night", Receivers: []string{"dummy/0"}, ActionName: "snapshot"},
+		err:  `schedule "every night": .* not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Schedule: "@daily", ActionName: "snapshot"},
+		err:  "at least one receiver required",
+	}, {
+		args: state.AddActionScheduleArgs{Schedule: "@daily", Receivers: []string{"dummy"}, ActionName: "snapshot"},
+		err:  `receiver "dummy" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Schedule: "@daily", Receivers: []string{"dummy/1"}, ActionName: "snapshot"},
+		err:  `unit "dummy/1" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{Schedule: "@daily", Receivers: []string{"mysql/leader"}, ActionName: "snapshot"},
+		err:  `application "mysql" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0")
+	err := s.Model.RemoveActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.Model.RemoveActionSchedule(schedule.Id)
+	c.Assert(err, gc.ErrorMatches, `action schedule "1" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0")
+	scheduled := time.Date(2020, 5, 1, 2, 0, 0, 0, time.UTC)
+	run, err := s.Model.RunActionSchedule(schedule.Id, scheduled)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.Status, gc.Equals, state.ActionScheduleRunEnqueued)
+	c.Assert(run.Scheduled, gc.Equals, scheduled)
+	c.Assert(run.Message, gc.Equals, "")
+
+	op, err := s.Model.OperationWithActions(run.OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Actions, gc.HasLen, 1)
+	c.Assert(op.Actions[0].Receiver(), gc.Equals, "dummy/0")
+	c.Assert(op.Actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "nightly.bz2"})
+
+	schedule, err = s.Model.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Runs, gc.HasLen, 1)
+	c.Assert(schedule.Runs[0].OperationId, gc.Equals, run.OperationId)
+	c.Assert(schedule.LastScheduled(), gc.Equals, scheduled)
+}
+
+func (s *ActionScheduleSuite) TestRunActionScheduleLeader(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("dummy", "dummy/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	schedule := s.addSchedule(c, "dummy/leader")
+	run, err := s.Model.RunActionSchedule(schedule.Id, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.Status, gc.Equals, state.ActionScheduleRunEnqueued)
+
+	op, err := s.Model.OperationWithActions(run.OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Actions, gc.HasLen, 1)
+	c.Assert(op.Actions[0].Receiver(), gc.Equals, "dummy/0")
+}
+
+func (s *ActionScheduleSuite) TestRunActionScheduleFailed(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0")
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	run, err := s.Model.RunActionSchedule(schedule.Id, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.Status, gc.Equals, state.ActionScheduleRunFailed)
+	c.Assert(run.Message, gc.Matches, `dummy/0: .*`)
+}
+
+func (s *ActionScheduleSuite) TestRecordActionScheduleMissed(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0")
+	scheduled := time.Date(2020, 5, 3, 2, 0, 0, 0, time.UTC)
+	err := s.Model.RecordActionScheduleMissed(schedule.Id, scheduled, 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.Model.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Runs, gc.HasLen, 1)
+	c.Assert(schedule.Runs[0].Status, gc.Equals, state.ActionScheduleRunMissed)
+	c.Assert(schedule.Runs[0].Message, gc.Equals, "missed 3 scheduled runs")
+	c.Assert(schedule.Runs[0].OperationId, gc.Equals, "")
+	c.Assert(schedule.LastScheduled(), gc.Equals, scheduled)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.Model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule := s.addSchedule(c, "dummy/0")
+	wc.AssertOneChange()
+
+	err := s.Model.RecordActionScheduleMissed(schedule.Id, time.Now(), 1)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.Model.RemoveActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *ActionScheduleSuite) TestExportImportActionSchedules(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0", "dummy/leader")
+	scheduled := time.Date(2020, 5, 3, 2, 0, 0, 0, time.UTC)
+	err := s.Model.RecordActionScheduleMissed(schedule.Id, scheduled, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err = s.Model.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	exported, err := s.State.ExportActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exported, gc.HasLen, 1)
+	c.Check(exported[0].ID, gc.Equals, schedule.Id)
+	c.Check(exported[0].Receivers, jc.DeepEquals, []string{"dummy/0", "dummy/leader"})
+	c.Assert(exported[0].Runs, gc.HasLen, 1)
+	c.Check(exported[0].Runs[0].Status, gc.Equals, "missed")
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "dummy"})
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = st.ImportActionSchedules(exported)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := m.ActionSchedule(schedule.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported, jc.DeepEquals, schedule)
+}
+
+func (s *ActionScheduleSuite) TestImportActionSchedulesMissingReceiver(c *gc.C) {
+	s.addSchedule(c, "dummy/0")
+	exported, err := s.State.ExportActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err = st.ImportActionSchedules(exported)
+	c.Assert(err, gc.ErrorMatches, `action schedule "1": unit "dummy/0" not found`)
+}
@@ -421,6 +421,16 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "_id"},
 			}},
 		},
+		// actionSchedulesC holds the schedules on which actions are
+		// enqueued, with the history of their recent runs.
+		actionSchedulesC: {},
+		// actionAttachmentsC holds the metadata of files attached to
+		// actions; their contents are held in blob storage.
+		actionAttachmentsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "action"},
+			}},
+		},
 
 		// -----
 
@@ -533,6 +543,14 @@ func allCollections() CollectionSchema {
 		// for units, eg address, ports.
 		cloudContainersC: {},
 
+		// secretsC holds the secrets created by charms, with their
+		// values encrypted using the controller's secrets key.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+
 		// cloudServicesC holds the CAAS service information
 		// eg addresses.
 		cloudServicesC: {},
@@ -553,8 +571,10 @@ func allCollections() CollectionSchema {
 // it in allCollections, above; and please keep this list sorted for easy
 // inspection.
 const (
+	actionAttachmentsC         = "actionattachments"
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
+	actionSchedulesC           = "actionschedules"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
@@ -610,6 +630,7 @@ const (
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	secretsC                   = "secrets"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
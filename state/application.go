@@ -538,6 +538,13 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove the application's secrets, and its access to others.
+	removeSecretOps, err := removeApplicationSecretsOps(a.st, a.doc.Name)
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeSecretOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
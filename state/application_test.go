@@ -3870,6 +3870,24 @@ func (s *ApplicationSuite) TestUpdateApplicationConfig(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.UpdateApplicationConfig(map[string]interface{}{"title": "foo"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Charm config changes are not reported.
+	err = s.mysql.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func sampleApplicationConfigSchema() environschema.Fields {
 	schema := environschema.Fields{
 		"title":       environschema.Attr{Type: environschema.Tstring},
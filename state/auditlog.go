@@ -0,0 +1,495 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+	jujuversion "github.com/juju/juju/version"
+)
+
+const (
+	auditConversationsC = "audit.conversations"
+	auditRequestsC      = "audit.requests"
+
+	// AuditLogModule is the logging module of the log records audit
+	// log records are forwarded in.
+	AuditLogModule = "juju.audit"
+)
+
+// auditRequestIndexes defines the indexes we need on the audit
+// requests collection.
+var auditRequestIndexes = [][]string{
+	{"t", "_id"},
+	{"who", "t"},
+	{"model-uuid", "t"},
+}
+
+// InitDbAuditLog sets up the capped collections holding the audit
+// log, along with their indexes. It is idempotent.
+func InitDbAuditLog(session *mgo.Session, size int) error {
+	db := session.DB(logsDB)
+	for _, name := range []string{auditConversationsC, auditRequestsC} {
+		if err := initCappedCollection(db.C(name), size); err != nil {
+			return errors.Annotatef(err, "cannot initialize %s collection", name)
+		}
+	}
+	requests := db.C(auditRequestsC)
+	for _, key := range auditRequestIndexes {
+		if err := requests.EnsureIndex(mgo.Index{Key: key}); err != nil {
+			return errors.Annotatef(err, "cannot create index for %s collection", auditRequestsC)
+		}
+	}
+	return nil
+}
+
+// initCappedCollection ensures that the collection exists, and is
+// capped at the given size in MB.
+func initCappedCollection(coll *mgo.Collection, size int) error {
+	capped, maxSize, err := getCollectionCappedInfo(coll)
+	if errors.IsNotFound(err) {
+		logger.Infof("creating %s collection, capped at %v MiB", coll.Name, size)
+		return errors.Trace(coll.Create(&mgo.CollectionInfo{
+			Capped:   true,
+			MaxBytes: size * humanize.MiByte,
+		}))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if capped && maxSize == size {
+		return nil
+	}
+	logger.Infof("capping %s collection at %v MiB", coll.Name, size)
+	return errors.Trace(convertToCapped(coll, size))
+}
+
+// auditConversationDoc holds a conversation recorded in the audit log.
+type auditConversationDoc struct {
+	Id        string `bson:"_id"` // the conversation ID
+	Who       string `bson:"who"`
+	What      string `bson:"what"`
+	When      string `bson:"when"`
+	ModelName string `bson:"model-name"`
+	ModelUUID string `bson:"model-uuid"`
+}
+
+// auditRequestDoc holds an API request recorded in the audit log,
+// along with the details of the conversation it was made in and the
+// errors in its response. As the audit log collections are capped,
+// the document can't be updated once written, so it is only written
+// when the response is seen, or when the request has waited too long
+// for one.
+type auditRequestDoc struct {
+	Id             bson.ObjectId `bson:"_id"`
+	Time           int64         `bson:"t"` // unix nano UTC
+	ControllerID   string        `bson:"controller-id"`
+	ConversationID string        `bson:"conversation-id"`
+	ConnectionID   string        `bson:"connection-id"`
+	RequestID      uint64        `bson:"request-id"`
+
+	Who              string `bson:"who"`
+	What             string `bson:"what"`
+	ConversationWhen string `bson:"conversation-when"`
+	ModelName        string `bson:"model-name"`
+	ModelUUID        string `bson:"model-uuid"`
+
+	When    string `bson:"when"`
+	Facade  string `bson:"facade"`
+	Method  string `bson:"method"`
+	Version int    `bson:"version"`
+	Args    string `bson:"args,omitempty"`
+
+	Errors     []auditErrorDoc `bson:"errors,omitempty"`
+	NoResponse bool            `bson:"no-response,omitempty"`
+}
+
+type auditErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code"`
+}
+
+type auditRequestKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+const (
+	// maxPendingAuditRequests is the most requests held waiting for
+	// their responses. Beyond that the oldest are written without one.
+	maxPendingAuditRequests = 10000
+
+	// pendingAuditRequestTimeout is how long a request waits for its
+	// response, measured by the times of later requests, before it is
+	// written without one.
+	pendingAuditRequestTimeout = 10 * time.Minute
+
+	// maxAuditConversations is the most conversations whose details
+	// are kept to be recorded with their requests. The least recently
+	// used are dropped first.
+	maxAuditConversations = 10000
+)
+
+// pendingAuditRequest is a request waiting for its response.
+type pendingAuditRequest struct {
+	key     auditRequestKey
+	request auditlog.Request
+	time    time.Time
+}
+
+// DbAuditLog is an auditlog.AuditLog which records API requests in
+// the database, where they can be queried from any controller.
+type DbAuditLog struct {
+	controllerID string
+
+	// sessionMu guards the session, which is closed by Close. API
+	// connections may still hold the log after it has been closed,
+	// so records added later are dropped.
+	sessionMu sync.RWMutex
+	session   *mgo.Session
+	closed    bool
+
+	mu            sync.Mutex
+	pending       map[auditRequestKey]*list.Element
+	pendingOrder  *list.List
+	conversations map[string]*list.Element
+	recent        *list.List
+}
+
+// NewDbAuditLog returns a DbAuditLog recording requests handled by
+// the controller with the given ID.
+func NewDbAuditLog(st MongoSessioner, controllerID string) *DbAuditLog {
+	session, _ := initLogsSessionDB(st)
+	return &DbAuditLog{
+		session:       session,
+		controllerID:  controllerID,
+		pending:       make(map[auditRequestKey]*list.Element),
+		pendingOrder:  list.New(),
+		conversations: make(map[string]*list.Element),
+		recent:        list.New(),
+	}
+}
+
+// AddConversation implements auditlog.AuditLog. The conversation's
+// details are also kept, to be recorded with each of its requests.
+func (l *DbAuditLog) AddConversation(c auditlog.Conversation) error {
+	l.mu.Lock()
+	if e, ok := l.conversations[c.ConversationID]; ok {
+		e.Value = c
+		l.recent.MoveToFront(e)
+	} else {
+		l.conversations[c.ConversationID] = l.recent.PushFront(c)
+	}
+	for l.recent.Len() > maxAuditConversations {
+		oldest := l.recent.Back()
+		delete(l.conversations, oldest.Value.(auditlog.Conversation).ConversationID)
+		l.recent.Remove(oldest)
+	}
+	l.mu.Unlock()
+
+	return l.withSession(func(db *mgo.Database) error {
+		err := db.C(auditConversationsC).Insert(&auditConversationDoc{
+			Id:        c.ConversationID,
+			Who:       c.Who,
+			What:      c.What,
+			When:      c.When,
+			ModelName: c.ModelName,
+			ModelUUID: c.ModelUUID,
+		})
+		return errors.Annotate(err, "inserting audit log conversation")
+	})
+}
+
+// AddRequest implements auditlog.AuditLog. The request is held until
+// its response is added, unless it waits too long, in which case it
+// is written without one.
+func (l *DbAuditLog) AddRequest(r auditlog.Request) error {
+	t, err := time.Parse(time.RFC3339, r.When)
+	if err != nil {
+		return errors.Annotatef(err, "parsing audit log request time")
+	}
+	key := auditRequestKey{r.ConversationID, r.RequestID}
+
+	l.mu.Lock()
+	if e, ok := l.pending[key]; ok {
+		l.pendingOrder.Remove(e)
+	}
+	l.pending[key] = l.pendingOrder.PushBack(&pendingAuditRequest{
+		key:     key,
+		request: r,
+		time:    t,
+	})
+	var expired []*auditRequestDoc
+	for e := l.pendingOrder.Front(); e != nil; e = l.pendingOrder.Front() {
+		p := e.Value.(*pendingAuditRequest)
+		if l.pendingOrder.Len() <= maxPendingAuditRequests && t.Sub(p.time) < pendingAuditRequestTimeout {
+			break
+		}
+		l.pendingOrder.Remove(e)
+		delete(l.pending, p.key)
+		doc := l.requestDoc(p)
+		doc.NoResponse = true
+		expired = append(expired, doc)
+	}
+	l.mu.Unlock()
+
+	if len(expired) == 0 {
+		return nil
+	}
+	return l.withSession(func(db *mgo.Database) error {
+		docs := make([]interface{}, len(expired))
+		for i, doc := range expired {
+			docs[i] = doc
+		}
+		err := db.C(auditRequestsC).Insert(docs...)
+		return errors.Annotate(err, "inserting audit log requests")
+	})
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (l *DbAuditLog) AddResponse(r auditlog.ResponseErrors) error {
+	key := auditRequestKey{r.ConversationID, r.RequestID}
+	l.mu.Lock()
+	e, ok := l.pending[key]
+	if !ok {
+		l.mu.Unlock()
+		// The request was made before the audit log was set up.
+		return nil
+	}
+	l.pendingOrder.Remove(e)
+	delete(l.pending, key)
+	doc := l.requestDoc(e.Value.(*pendingAuditRequest))
+	l.mu.Unlock()
+
+	for _, e := range r.Errors {
+		doc.Errors = append(doc.Errors, auditErrorDoc{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return l.withSession(func(db *mgo.Database) error {
+		err := db.C(auditRequestsC).Insert(doc)
+		return errors.Annotate(err, "inserting audit log request")
+	})
+}
+
+// requestDoc returns the document recording the pending request. It
+// must be called with l.mu held.
+func (l *DbAuditLog) requestDoc(p *pendingAuditRequest) *auditRequestDoc {
+	req := p.request
+	doc := &auditRequestDoc{
+		Id:             bson.NewObjectId(),
+		Time:           p.time.UnixNano(),
+		ControllerID:   l.controllerID,
+		ConversationID: req.ConversationID,
+		ConnectionID:   req.ConnectionID,
+		RequestID:      req.RequestID,
+		When:           req.When,
+		Facade:         req.Facade,
+		Method:         req.Method,
+		Version:        req.Version,
+		Args:           req.Args,
+	}
+	// If the conversation isn't known it was added before the audit
+	// log was set up, or has not been used for a long time, so the
+	// request is recorded without it.
+	if e, ok := l.conversations[req.ConversationID]; ok {
+		l.recent.MoveToFront(e)
+		conversation := e.Value.(auditlog.Conversation)
+		doc.Who = conversation.Who
+		doc.What = conversation.What
+		doc.ConversationWhen = conversation.When
+		doc.ModelName = conversation.ModelName
+		doc.ModelUUID = conversation.ModelUUID
+	}
+	return doc
+}
+
+// withSession calls f with the audit log database, unless the log has
+// been closed.
+func (l *DbAuditLog) withSession(f func(*mgo.Database) error) error {
+	l.sessionMu.RLock()
+	defer l.sessionMu.RUnlock()
+	if l.closed {
+		logger.Debugf("audit log closed, dropping record")
+		return nil
+	}
+	return f(l.session.DB(logsDB))
+}
+
+// Close implements auditlog.AuditLog. Records added after the log is
+// closed are dropped.
+func (l *DbAuditLog) Close() error {
+	l.sessionMu.Lock()
+	defer l.sessionMu.Unlock()
+	if !l.closed {
+		l.closed = true
+		l.session.Close()
+	}
+	return nil
+}
+
+// QueryAuditLog returns the audit log entries matching the query, in
+// the order the requests were made.
+func QueryAuditLog(st MongoSessioner, query auditlog.Query) ([]auditlog.Entry, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	var sel bson.D
+	if query.Who != "" {
+		sel = append(sel, bson.DocElem{Name: "who", Value: query.Who})
+	}
+	if query.ModelUUID != "" {
+		sel = append(sel, bson.DocElem{Name: "model-uuid", Value: query.ModelUUID})
+	}
+	if query.Facade != "" {
+		sel = append(sel, bson.DocElem{Name: "facade", Value: query.Facade})
+	}
+	if query.Method != "" {
+		sel = append(sel, bson.DocElem{Name: "method", Value: query.Method})
+	}
+	var timeRange bson.D
+	if !query.After.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{Name: "$gte", Value: query.After.UnixNano()})
+	}
+	if !query.Before.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{Name: "$lt", Value: query.Before.UnixNano()})
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{Name: "t", Value: timeRange})
+	}
+	if query.ErrorsOnly {
+		sel = append(sel, bson.DocElem{Name: "errors.0", Value: bson.M{"$exists": true}})
+	}
+
+	// Read the most recent entries first, so that the limit leaves
+	// out the oldest ones.
+	q := session.DB(logsDB).C(auditRequestsC).Find(sel).Sort("-t", "-_id")
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	var docs []auditRequestDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying audit log")
+	}
+
+	entries := make([]auditlog.Entry, len(docs))
+	for i, doc := range docs {
+		entry := auditlog.Entry{
+			ControllerID: doc.ControllerID,
+			Conversation: auditlog.Conversation{
+				Who:            doc.Who,
+				What:           doc.What,
+				When:           doc.ConversationWhen,
+				ModelName:      doc.ModelName,
+				ModelUUID:      doc.ModelUUID,
+				ConversationID: doc.ConversationID,
+				ConnectionID:   doc.ConnectionID,
+			},
+			Request: auditlog.Request{
+				ConversationID: doc.ConversationID,
+				ConnectionID:   doc.ConnectionID,
+				RequestID:      doc.RequestID,
+				When:           doc.When,
+				Facade:         doc.Facade,
+				Method:         doc.Method,
+				Version:        doc.Version,
+				Args:           doc.Args,
+			},
+		}
+		for _, e := range doc.Errors {
+			entry.Errors = append(entry.Errors, &auditlog.Error{
+				Message: e.Message,
+				Code:    e.Code,
+			})
+		}
+		entries[len(docs)-1-i] = entry
+	}
+	return entries, nil
+}
+
+// auditLogForwarder is an auditlog.AuditLog which writes audit log
+// records to a model's logs, so that they are sent to the model's log
+// forwarding sink.
+type auditLogForwarder struct {
+	entity string
+
+	// mu guards the logger, which is closed by Close. API connections
+	// may still hold the forwarder after it has been closed, so
+	// records added later are dropped.
+	mu     sync.RWMutex
+	logger *DbLogger
+	closed bool
+}
+
+// NewAuditLogForwarder returns an auditlog.AuditLog which writes audit
+// log records, in the same JSON format as the audit log file, to the
+// logs of the given model as if logged by the given entity. The
+// records are logged at INFO level by the AuditLogModule module.
+func NewAuditLogForwarder(st ModelSessioner, entity string) auditlog.AuditLog {
+	return &auditLogForwarder{
+		logger: NewDbLogger(st),
+		entity: entity,
+	}
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (f *auditLogForwarder) AddConversation(c auditlog.Conversation) error {
+	return errors.Trace(f.log(c.When, auditlog.Record{Conversation: &c}))
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (f *auditLogForwarder) AddRequest(r auditlog.Request) error {
+	return errors.Trace(f.log(r.When, auditlog.Record{Request: &r}))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (f *auditLogForwarder) AddResponse(r auditlog.ResponseErrors) error {
+	return errors.Trace(f.log(r.When, auditlog.Record{Errors: &r}))
+}
+
+// Close implements auditlog.AuditLog.
+func (f *auditLogForwarder) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.closed {
+		f.closed = true
+		f.logger.Close()
+	}
+	return nil
+}
+
+func (f *auditLogForwarder) log(when string, record auditlog.Record) error {
+	t, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return errors.Annotate(err, "parsing audit log record time")
+	}
+	message, err := json.Marshal(record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		logger.Debugf("audit log forwarder closed, dropping record")
+		return nil
+	}
+	return errors.Trace(f.logger.Log([]LogRecord{{
+		Time:    t,
+		Entity:  f.entity,
+		Version: jujuversion.Current,
+		Module:  AuditLogModule,
+		Level:   loggo.INFO,
+		Message: string(message),
+	}}))
+}
@@ -0,0 +1,277 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+var (
+	auditConversation = auditlog.Conversation{
+		Who:            "bob",
+		What:           "juju deploy mysql",
+		When:           "2020-05-01T03:00:00Z",
+		ModelName:      "default",
+		ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	}
+	auditOtherConversation = auditlog.Conversation{
+		Who:            "alice",
+		What:           "juju remove-application mysql",
+		When:           "2020-05-01T04:00:00Z",
+		ModelName:      "prod",
+		ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		ConversationID: "fedcba9876543210",
+		ConnectionID:   "AC2",
+	}
+)
+
+func auditRequest(conversation auditlog.Conversation, id uint64, when, facade, method string) auditlog.Request {
+	return auditlog.Request{
+		ConversationID: conversation.ConversationID,
+		ConnectionID:   conversation.ConnectionID,
+		RequestID:      id,
+		When:           when,
+		Facade:         facade,
+		Method:         method,
+		Version:        7,
+	}
+}
+
+func (s *AuditLogSuite) addRequest(c *gc.C, log auditlog.AuditLog, req auditlog.Request, errs ...*auditlog.Error) {
+	err := log.AddRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: req.ConversationID,
+		ConnectionID:   req.ConnectionID,
+		RequestID:      req.RequestID,
+		When:           req.When,
+		Errors:         errs,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) populate(c *gc.C) {
+	log := state.NewDbAuditLog(s.State, "0")
+	defer log.Close()
+
+	err := log.AddConversation(auditConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addRequest(c, log, auditRequest(auditConversation, 1, "2020-05-01T03:00:01Z", "Application", "Deploy"))
+	s.addRequest(c, log, auditRequest(auditConversation, 2, "2020-05-01T03:00:02Z", "Application", "AddRelation"),
+		&auditlog.Error{Message: "no relations found", Code: "not found"},
+	)
+
+	other := state.NewDbAuditLog(s.State, "1")
+	defer other.Close()
+	err = other.AddConversation(auditOtherConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addRequest(c, other, auditRequest(auditOtherConversation, 1, "2020-05-01T04:00:01Z", "Application", "DestroyApplication"))
+}
+
+func (s *AuditLogSuite) TestQueryAll(c *gc.C) {
+	s.populate(c)
+
+	entries, err := state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []auditlog.Entry{{
+		ControllerID: "0",
+		Conversation: auditConversation,
+		Request:      auditRequest(auditConversation, 1, "2020-05-01T03:00:01Z", "Application", "Deploy"),
+	}, {
+		ControllerID: "0",
+		Conversation: auditConversation,
+		Request:      auditRequest(auditConversation, 2, "2020-05-01T03:00:02Z", "Application", "AddRelation"),
+		Errors:       []*auditlog.Error{{Message: "no relations found", Code: "not found"}},
+	}, {
+		ControllerID: "1",
+		Conversation: auditOtherConversation,
+		Request:      auditRequest(auditOtherConversation, 1, "2020-05-01T04:00:01Z", "Application", "DestroyApplication"),
+	}})
+}
+
+func (s *AuditLogSuite) TestQueryFilters(c *gc.C) {
+	s.populate(c)
+
+	for i, test := range []struct {
+		about    string
+		query    auditlog.Query
+		expected []string
+	}{{
+		about:    "who",
+		query:    auditlog.Query{Who: "alice"},
+		expected: []string{"DestroyApplication"},
+	}, {
+		about:    "model",
+		query:    auditlog.Query{ModelUUID: auditConversation.ModelUUID},
+		expected: []string{"Deploy", "AddRelation"},
+	}, {
+		about:    "facade and method",
+		query:    auditlog.Query{Facade: "Application", Method: "Deploy"},
+		expected: []string{"Deploy"},
+	}, {
+		about: "time range",
+		query: auditlog.Query{
+			After:  time.Date(2020, 5, 1, 3, 0, 2, 0, time.UTC),
+			Before: time.Date(2020, 5, 1, 4, 0, 1, 0, time.UTC),
+		},
+		expected: []string{"AddRelation"},
+	}, {
+		about:    "errors only",
+		query:    auditlog.Query{ErrorsOnly: true},
+		expected: []string{"AddRelation"},
+	}, {
+		about:    "limit keeps the most recent",
+		query:    auditlog.Query{Limit: 2},
+		expected: []string{"AddRelation", "DestroyApplication"},
+	}, {
+		about: "no matches",
+		query: auditlog.Query{Who: "carol"},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		entries, err := state.QueryAuditLog(s.State, test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		var methods []string
+		for _, entry := range entries {
+			methods = append(methods, entry.Request.Method)
+		}
+		c.Check(methods, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *AuditLogSuite) TestResponseWithoutRequest(c *gc.C) {
+	log := state.NewDbAuditLog(s.State, "0")
+	defer log.Close()
+
+	err := log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: auditConversation.ConversationID,
+		RequestID:      1,
+		When:           "2020-05-01T03:00:01Z",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *AuditLogSuite) TestConversationMissing(c *gc.C) {
+	log := state.NewDbAuditLog(s.State, "0")
+	defer log.Close()
+	req := auditRequest(auditConversation, 1, "2020-05-01T03:00:01Z", "Application", "Deploy")
+	s.addRequest(c, log, req)
+
+	entries, err := state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Request, jc.DeepEquals, req)
+	c.Check(entries[0].Conversation.Who, gc.Equals, "")
+}
+
+func (s *AuditLogSuite) TestRequestWithoutResponse(c *gc.C) {
+	log := state.NewDbAuditLog(s.State, "0")
+	defer log.Close()
+	err := log.AddConversation(auditConversation)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stale := auditRequest(auditConversation, 1, "2020-05-01T03:00:01Z", "Application", "Deploy")
+	err = log.AddRequest(stale)
+	c.Assert(err, jc.ErrorIsNil)
+	entries, err := state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+
+	// A request made long after the first one was answered expires it,
+	// and it's recorded without a response.
+	s.addRequest(c, log, auditRequest(auditConversation, 2, "2020-05-01T03:20:00Z", "Application", "AddRelation"))
+	entries, err = state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Request, jc.DeepEquals, stale)
+	c.Check(entries[0].Conversation.Who, gc.Equals, "bob")
+	c.Check(entries[0].Errors, gc.HasLen, 0)
+	c.Check(entries[1].Request.Method, gc.Equals, "AddRelation")
+
+	// The late response is ignored.
+	err = log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: stale.ConversationID,
+		RequestID:      stale.RequestID,
+		When:           "2020-05-01T03:20:01Z",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	entries, err = state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 2)
+}
+
+func (s *AuditLogSuite) TestAddAfterClose(c *gc.C) {
+	log := state.NewDbAuditLog(s.State, "0")
+	err := log.AddConversation(auditConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	req := auditRequest(auditConversation, 1, "2020-05-01T03:00:01Z", "Application", "Deploy")
+	err = log.AddRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Connections may still hold the log after it's closed.
+	err = log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: req.ConversationID,
+		RequestID:      req.RequestID,
+		When:           req.When,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddConversation(auditOtherConversation)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := state.QueryAuditLog(s.State, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+
+	forwarder := state.NewAuditLogForwarder(s.State, "machine-0")
+	err = forwarder.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = forwarder.AddRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) TestAuditLogForwarder(c *gc.C) {
+	forwarder := state.NewAuditLogForwarder(s.State, "machine-0")
+	defer forwarder.Close()
+
+	err := forwarder.AddConversation(auditConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	err = forwarder.AddRequest(auditRequest(auditConversation, 1, "2020-05-01T03:00:01Z", "Application", "Deploy"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	logsColl := s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
+	err = logsColl.Find(bson.M{"m": state.AuditLogModule}).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+
+	c.Check(docs[0]["t"], gc.Equals, time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC).UnixNano())
+	c.Check(docs[0]["n"], gc.Equals, "machine-0")
+	c.Check(docs[0]["v"], gc.Equals, int(loggo.INFO))
+	c.Check(docs[0]["x"], gc.Equals, `{"conversation":{"who":"bob","what":"juju deploy mysql","when":"2020-05-01T03:00:00Z","model-name":"default","model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","conversation-id":"0123456789abcdef","connection-id":"AC1"}}`)
+	c.Check(docs[1]["x"], gc.Equals, `{"request":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":1,"when":"2020-05-01T03:00:01Z","facade":"Application","method":"Deploy","version":7}}`)
+}
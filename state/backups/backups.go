@@ -61,6 +61,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getOplogDumper   = NewOplogDumper
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -87,7 +88,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
+	// the provided metadata. If the metadata has a Base, an
+	// incremental backup is created, holding only the oplog
+	// since that full backup.
 	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
@@ -125,9 +128,10 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	meta.setFormatVersion()
 
-	// The metadata file will not contain the ID or the "finished" data.
-	// However, that information is not as critical. The alternatives
+	// The metadata file will not contain the ID, the "finished" data
+	// or the oplog range. However, that information is not as critical. The alternatives
 	// are either adding the metadata file to the archive after the fact
 	// or adding placeholders here for the finished data and filling
 	// them in afterward.  Neither is particularly trivial.
@@ -142,7 +146,17 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while listing files to back up")
 	}
 
-	dumper, err := getDBDumper(dbInfo)
+	var base *Metadata
+	var dumper DBDumper
+	if meta.IsIncremental() {
+		base, err = b.incrementalBase(meta.Base)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		dumper, err = getOplogDumper(dbInfo, base.Oplog.Start)
+	} else {
+		dumper, err = getDBDumper(dbInfo)
+	}
 	if err != nil {
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
@@ -154,11 +168,27 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	}
 	defer result.archiveFile.Close()
 
+	// A full backup covers every change made since the dump started,
+	// even if the dumped oplog is empty because nothing was written.
+	if base == nil {
+		result.oplog = result.oplog.withHead(dbInfo.OplogHead)
+	}
+
+	// An incremental backup is only any use if its oplog carries on
+	// from where its base backup's oplog starts. If the oplog has been
+	// truncated since, a new full backup is needed.
+	if base != nil && (result.oplog.Start.IsZero() || result.oplog.Start.After(base.Oplog.Start)) {
+		return "", errors.Errorf(
+			"oplog no longer holds changes since backup %q, create a full backup instead", base.ID())
+	}
+
 	// Finalize the metadata.
 	err = finishMeta(meta, result)
 	if err != nil {
 		return "", errors.Annotate(err, "while updating metadata")
 	}
+	meta.Oplog = result.oplog
+	meta.setFormatVersion()
 
 	// Store the archive if asked by user
 	if keepCopy {
@@ -171,6 +201,44 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	return result.filename, nil
 }
 
+// incrementalBase returns the metadata for the full backup with the
+// given ID, checking that an incremental backup can be based on it.
+func (b *backups) incrementalBase(id string) (*Metadata, error) {
+	rawmeta, err := b.storage.Metadata(id)
+	if err != nil {
+		return nil, errors.Annotatef(err, "while getting base backup %q", id)
+	}
+	base, ok := rawmeta.(*Metadata)
+	if !ok {
+		return nil, errors.New("did not get a backups.Metadata value from storage")
+	}
+	if base.IsIncremental() {
+		return nil, errors.Errorf("backup %q is incremental, and cannot be used as a base", id)
+	}
+	if base.Oplog.Start.IsZero() {
+		return nil, errors.Errorf("backup %q has no oplog, and cannot be used as a base", id)
+	}
+	return base, nil
+}
+
+// LatestFullBackup returns the most recent of the given backups which
+// incremental backups can be based on.
+func LatestFullBackup(all []*Metadata) (*Metadata, error) {
+	var latest *Metadata
+	for _, meta := range all {
+		if meta.IsIncremental() || meta.Oplog.Start.IsZero() {
+			continue
+		}
+		if latest == nil || meta.Started.After(latest.Started) {
+			latest = meta
+		}
+	}
+	if latest == nil {
+		return nil, errors.NotFoundf("full backup to base an incremental backup on")
+	}
+	return latest, nil
+}
+
 // Add stores the backup archive and returns its new ID.
 func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 	// Store the archive.
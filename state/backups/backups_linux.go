@@ -7,7 +7,10 @@ package backups
 
 import (
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/shell"
@@ -82,6 +85,20 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 	}
 	defer workspace.Close()
 
+	// An incremental backup holds only the oplog, which is replayed
+	// on top of its base backup.
+	if meta.IsIncremental() {
+		var baseWorkspace *ArchiveWorkspace
+		meta, baseWorkspace, err = b.openIncrementalRestore(meta, workspace, args.Until)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer baseWorkspace.Close()
+		workspace = baseWorkspace
+	} else if !args.Until.IsZero() {
+		return nil, errors.Errorf("cannot restore backup %q to a point in time: it is not an incremental backup", backupId)
+	}
+
 	// This might actually work, but we don't have a guarantee so we don't allow it.
 	if meta.Origin.Series != args.NewInstSeries {
 		return nil, errors.Errorf("cannot restore a backup made in a machine with series %q into a machine with series %q, %#v", meta.Origin.Series, args.NewInstSeries, meta)
@@ -213,6 +230,7 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 		StopMongo:       mongo.StopService,
 		NewMongoSession: NewMongoSession,
 		GetDB:           GetDB,
+		OplogLimit:      args.Until,
 	}
 
 	// Restore mongodb from backup
@@ -327,3 +345,46 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	return backupMachine, nil
 }
+
+// openIncrementalRestore unpacks the base backup of the given
+// incremental backup, and replaces the base backup's oplog with the
+// incremental backup's. Restoring the resulting workspace replays every
+// change up to the end of the incremental backup, or up to the given
+// time if it is set. It returns the base backup's metadata along with
+// the workspace.
+func (b *backups) openIncrementalRestore(meta *Metadata, workspace *ArchiveWorkspace, until time.Time) (*Metadata, *ArchiveWorkspace, error) {
+	base, baseReader, err := b.Get(meta.Base)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "could not fetch base backup %q", meta.Base)
+	}
+	defer baseReader.Close()
+
+	// The base backup's database dump is only consistent once its
+	// own oplog has been replayed, so there is no going back to
+	// before the end of it.
+	if !until.IsZero() && (until.Before(base.Oplog.End) || until.After(meta.Oplog.End)) {
+		return nil, nil, errors.Errorf(
+			"cannot restore backup %q to %s: it covers %s to %s",
+			meta.ID(),
+			until.UTC().Format(time.RFC3339),
+			base.Oplog.End.UTC().Format(time.RFC3339),
+			meta.Oplog.End.UTC().Format(time.RFC3339),
+		)
+	}
+
+	baseWorkspace, err := NewArchiveWorkspaceReader(baseReader)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot unpack base backup file")
+	}
+	// The incremental backup's oplog starts no later than the base
+	// backup's, so it can simply take its place.
+	err = os.Rename(
+		filepath.Join(workspace.DBDumpDir, oplogFilename),
+		filepath.Join(baseWorkspace.DBDumpDir, oplogFilename),
+	)
+	if err != nil {
+		baseWorkspace.Close()
+		return nil, nil, errors.Annotate(err, "cannot replace base backup oplog")
+	}
+	return base, baseWorkspace, nil
+}
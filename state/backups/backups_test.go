@@ -9,7 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
-	"time" // Only used for time types.
+	"time" // Only used for time types and funcs, not Now().
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -58,7 +58,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, time.Time{}}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
@@ -114,7 +114,7 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	// Run the backup.
 	paths := backups.Paths{BackupDir: backupDir, DataDir: dataDir}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, time.Time{}}
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
@@ -205,6 +205,169 @@ func (s *backupsSuite) TestCreateFailToStoreArchive(c *gc.C) {
 	s.checkFailure(c, "while storing backup archive: failed!")
 }
 
+func (s *backupsSuite) testCreateFull(c *gc.C, head time.Time, oplog backups.OplogRange) *backups.Metadata {
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>", "<filename>")
+	backups.SetTestCreateResultOplog(result, oplog)
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	targets := set.NewStrings("juju", "admin")
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, head}
+	meta := backupstesting.NewMetadataStarted()
+	_, err := s.api.Create(meta, &paths, &dbInfo, false, false)
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *backupsSuite) TestCreateFullNoWrites(c *gc.C) {
+	// Nothing was written during the dump, so the oplog range starts
+	// and ends at the oplog head.
+	head := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	meta := s.testCreateFull(c, head, backups.OplogRange{})
+	c.Check(meta.Oplog, jc.DeepEquals, backups.OplogRange{Start: head, End: head})
+	c.Check(meta.FormatVersion, gc.Equals, int64(2))
+}
+
+func (s *backupsSuite) TestCreateFullWithWrites(c *gc.C) {
+	head := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	meta := s.testCreateFull(c, head, backups.OplogRange{
+		Start: head.Add(time.Second),
+		End:   head.Add(time.Minute),
+	})
+	c.Check(meta.Oplog, jc.DeepEquals, backups.OplogRange{
+		Start: head,
+		End:   head.Add(time.Minute),
+	})
+	c.Check(meta.FormatVersion, gc.Equals, int64(2))
+}
+
+func (s *backupsSuite) TestCreateFullWithoutOplog(c *gc.C) {
+	meta := s.testCreateFull(c, time.Time{}, backups.OplogRange{})
+	c.Check(meta.Oplog.IsZero(), jc.IsTrue)
+	c.Check(meta.FormatVersion, gc.Equals, int64(1))
+}
+
+func (s *backupsSuite) setBase(c *gc.C, id string, oplogStart time.Time) *backups.Metadata {
+	base := backupstesting.NewMetadataStarted()
+	base.SetID(id)
+	base.Oplog = backups.OplogRange{
+		Start: oplogStart,
+		End:   oplogStart.Add(time.Minute),
+	}
+	s.Storage.Meta = base
+	return base
+}
+
+func (s *backupsSuite) testCreateIncremental(c *gc.C, oplog backups.OplogRange) (*backups.Metadata, error) {
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>", "<filename>")
+	backups.SetTestCreateResultOplog(result, oplog)
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	targets := set.NewStrings("juju", "admin")
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, time.Time{}}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Base = "base-id"
+	_, err := s.api.Create(meta, &paths, &dbInfo, false, false)
+	return meta, err
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	oplogStart := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	s.setBase(c, "base-id", oplogStart)
+	var since time.Time
+	s.PatchValue(backups.GetOplogDumper, func(info *backups.DBInfo, t time.Time) (backups.DBDumper, error) {
+		since = t
+		return &fakeDumper{}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return nil, errors.New("unexpected full dump")
+	})
+
+	oplog := backups.OplogRange{
+		Start: oplogStart,
+		End:   oplogStart.Add(time.Hour),
+	}
+	meta, err := s.testCreateIncremental(c, oplog)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(since, gc.Equals, oplogStart)
+	c.Check(meta.Base, gc.Equals, "base-id")
+	c.Check(meta.Oplog, jc.DeepEquals, oplog)
+	c.Check(meta.FormatVersion, gc.Equals, int64(2))
+	s.Storage.CheckCalled(c, "base-id", nil, nil, "Metadata")
+}
+
+func (s *backupsSuite) TestCreateIncrementalOplogTruncated(c *gc.C) {
+	oplogStart := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	s.setBase(c, "base-id", oplogStart)
+	s.PatchValue(backups.GetOplogDumper, func(*backups.DBInfo, time.Time) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+
+	_, err := s.testCreateIncremental(c, backups.OplogRange{
+		Start: oplogStart.Add(time.Minute),
+		End:   oplogStart.Add(time.Hour),
+	})
+	c.Check(err, gc.ErrorMatches, `oplog no longer holds changes since backup "base-id", create a full backup instead`)
+}
+
+func (s *backupsSuite) TestCreateIncrementalOnIncremental(c *gc.C) {
+	base := s.setBase(c, "base-id", time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC))
+	base.Base = "other-id"
+
+	_, err := s.testCreateIncremental(c, backups.OplogRange{})
+	c.Check(err, gc.ErrorMatches, `backup "base-id" is incremental, and cannot be used as a base`)
+}
+
+func (s *backupsSuite) TestCreateIncrementalBaseWithoutOplog(c *gc.C) {
+	s.setBase(c, "base-id", time.Time{})
+
+	_, err := s.testCreateIncremental(c, backups.OplogRange{})
+	c.Check(err, gc.ErrorMatches, `backup "base-id" has no oplog, and cannot be used as a base`)
+}
+
+func (s *backupsSuite) TestLatestFullBackup(c *gc.C) {
+	newMeta := func(id string, started time.Time, base string, oplog bool) *backups.Metadata {
+		meta := backupstesting.NewMetadataStarted()
+		meta.SetID(id)
+		meta.Started = started
+		meta.Base = base
+		if oplog {
+			meta.Oplog = backups.OplogRange{Start: started, End: started}
+		}
+		return meta
+	}
+	day := func(d int) time.Time {
+		return time.Date(2020, 5, d, 3, 0, 0, 0, time.UTC)
+	}
+	all := []*backups.Metadata{
+		newMeta("old", day(1), "", true),
+		newMeta("full", day(2), "", true),
+		newMeta("incremental", day(3), "full", true),
+		newMeta("no-oplog", day(4), "", false),
+	}
+	latest, err := backups.LatestFullBackup(all)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(latest.ID(), gc.Equals, "full")
+
+	_, err = backups.LatestFullBackup(all[2:])
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *backupsSuite) TestStoreArchive(c *gc.C) {
 	stored := s.setStored("spam")
 
@@ -40,6 +40,8 @@ type createResult struct {
 	size        int64
 	checksum    string
 	filename    string
+	// oplog is the range of the oplog in the database dump.
+	oplog OplogRange
 }
 
 // create builds a new backup archive file and returns it.  It also
@@ -105,6 +107,8 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// oplog is the range of the oplog in the database dump.
+	oplog OplogRange
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -286,6 +290,14 @@ func (b *builder) buildDBDump() error {
 		return errors.Annotate(err, "while dumping juju state database")
 	}
 
+	// Record the range of the dumped oplog, so that incremental
+	// backups can later be based on this one.
+	oplog, err := readOplogRange(filepath.Join(dumpDir, oplogFilename))
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return errors.Annotate(err, "while reading dumped oplog")
+	}
+	b.oplog = oplog
+
 	return nil
 }
 
@@ -389,6 +401,7 @@ func (b *builder) result() (*createResult, error) {
 		size:        size,
 		checksum:    checksum,
 		filename:    b.filename,
+		oplog:       b.oplog,
 	}
 	return &result, nil
 }
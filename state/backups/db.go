@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -44,6 +45,10 @@ type DBInfo struct {
 	Targets set.Strings
 	// MongoVersion the version of the running mongo db.
 	MongoVersion mongo.Version
+	// OplogHead is the time of the most recent oplog entry when the
+	// info was gathered, just before the database is dumped. It is
+	// zero if the oplog could not be read.
+	OplogHead time.Time
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -68,11 +73,17 @@ func NewDBInfo(mgoInfo *mongo.MongoInfo, session DBSession, version mongo.Versio
 		return nil, errors.Trace(err)
 	}
 
+	head, err := getOplogHead(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to read oplog head")
+	}
+
 	info := DBInfo{
 		Address:      mgoInfo.Addrs[0],
 		Password:     mgoInfo.Password,
 		Targets:      targets,
 		MongoVersion: version,
+		OplogHead:    head,
 	}
 
 	// TODO(dfc) Backup should take a Tag.
@@ -93,6 +104,33 @@ func getBackupTargetDatabases(session DBSession) (set.Strings, error) {
 	return targets, nil
 }
 
+// oplogSession is implemented by sessions which can read the oplog.
+type oplogSession interface {
+	DB(name string) *mgo.Database
+}
+
+var getOplogHead = oplogHead
+
+// oplogHead returns the time of the most recent entry in the oplog. A
+// full backup's oplog range starts here, even if nothing is written
+// while the database is dumped, so incremental backups replay every
+// change made after the dump started.
+func oplogHead(session DBSession) (time.Time, error) {
+	mgoSession, ok := session.(oplogSession)
+	if !ok {
+		return time.Time{}, nil
+	}
+	var entry oplogEntry
+	err := mgoSession.DB("local").C("oplog.rs").Find(nil).
+		Sort("-$natural").Select(bson.M{"ts": 1}).One(&entry)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return oplogTime(entry.Timestamp), nil
+}
+
 const (
 	dumpName    = "mongodump"
 	restoreName = "mongorestore"
@@ -199,6 +237,64 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 	return errors.Trace(err)
 }
 
+type mongoOplogDumper struct {
+	*DBInfo
+	// binPath is the path to the dump executable.
+	binPath string
+	// since is the time of the earliest oplog entry to dump.
+	since time.Time
+}
+
+// NewOplogDumper returns a new value with a Dump method for dumping the
+// entries in the database oplog made since the given time. This is used
+// for incremental backups, which are replayed on top of a full backup.
+func NewOplogDumper(info *DBInfo, since time.Time) (DBDumper, error) {
+	mongodumpPath, err := getMongodumpPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongodump not available")
+	}
+
+	dumper := mongoOplogDumper{
+		DBInfo:  info,
+		binPath: mongodumpPath,
+		since:   since,
+	}
+	return &dumper, nil
+}
+
+func (md *mongoOplogDumper) options(dumpDir string) []string {
+	query := fmt.Sprintf(`{"ts": {"$gte": {"$timestamp": {"t": %d, "i": 0}}}}`, md.since.Unix())
+	options := []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", md.Address,
+		"--username", md.Username,
+		"--password", md.Password,
+		"--out", dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", query,
+	}
+	return options
+}
+
+// Dump dumps the oplog entries since the dumper's start time, and moves
+// them to where mongorestore looks for an oplog to replay.
+func (md *mongoOplogDumper) Dump(baseDumpDir string) error {
+	options := md.options(baseDumpDir)
+	if err := runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+
+	localDir := filepath.Join(baseDumpDir, "local")
+	dumped := filepath.Join(localDir, "oplog.rs.bson")
+	if err := os.Rename(dumped, filepath.Join(baseDumpDir, oplogFilename)); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.RemoveAll(localDir))
+}
+
 // stripIgnored removes the ignored DBs from the mongo dump files.
 // This involves deleting DB-specific directories.
 //
@@ -262,7 +358,20 @@ type mongoRestorer struct {
 	tagUser         string
 	tagUserPassword string
 	runCommandFn    func(string, ...string) error
+	// oplogLimit, if set, is the time before which oplog
+	// entries are replayed.
+	oplogLimit time.Time
 }
+
+// oplogLimitOptions returns the mongorestore options needed to stop
+// replaying the oplog at the restorer's oplog limit, if it has one.
+func (md *mongoRestorer) oplogLimitOptions() []string {
+	if md.oplogLimit.IsZero() {
+		return nil
+	}
+	return []string{"--oplogLimit", fmt.Sprint(md.oplogLimit.Unix())}
+}
+
 type mongoRestorer32 struct {
 	mongoRestorer
 	getDB           func(string, MongoSession) MongoDB
@@ -282,9 +391,9 @@ func (md *mongoRestorer24) options(dumpDir string) []string {
 		"--journal",
 		"--oplogReplay",
 		"--dbpath", dbDir,
-		dumpDir,
 	}
-	return options
+	options = append(options, md.oplogLimitOptions()...)
+	return append(options, dumpDir)
 }
 
 func (md *mongoRestorer24) Restore(dumpDir string, _ *mgo.DialInfo) error {
@@ -322,6 +431,10 @@ type RestorerArgs struct {
 	TagUserPassword string
 	GetDB           func(string, MongoSession) MongoDB
 
+	// OplogLimit, if set, is the time before which the oplog in
+	// the dump is replayed. It is used to restore to a point in time.
+	OplogLimit time.Time
+
 	RunCommandFn func(string, ...string) error
 	StartMongo   func() error
 	StopMongo    func() error
@@ -360,6 +473,7 @@ func NewDBRestorer(args RestorerArgs) (DBRestorer, error) {
 		tagUser:         args.TagUser,
 		tagUserPassword: args.TagUserPassword,
 		runCommandFn:    args.RunCommandFn,
+		oplogLimit:      args.OplogLimit,
 	}
 	switch args.Version.Major {
 	case 2:
@@ -398,9 +512,9 @@ func (md *mongoRestorer32) options(dumpDir string) []string {
 		"--drop",
 		"--oplogReplay",
 		"--batchSize", "10",
-		dumpDir,
 	}
-	return options
+	options = append(options, md.oplogLimitOptions()...)
+	return append(options, dumpDir)
 }
 
 // MongoDB represents a mgo.DB.
@@ -4,8 +4,10 @@
 package backups_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time" // Only used for time types and funcs, not Now().
 
 	"github.com/juju/collections/set"
 	jc "github.com/juju/testing/checkers"
@@ -31,7 +33,7 @@ func (s *dumpSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	targets := set.NewStrings("juju", "admin")
-	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24}
+	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24, time.Time{}}
 	s.targets = targets
 	s.dumpDir = c.MkDir()
 }
@@ -155,3 +157,39 @@ func (s *dumpSuite) TestDumpNothingIgnored(c *gc.C) {
 
 	s.checkDBs(c, "juju", "admin")
 }
+
+func (s *dumpSuite) TestOplogDump(c *gc.C) {
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+	var ranWithArgs []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ranWithArgs = args
+		// mongodump writes the oplog collection under the local database.
+		localDir := s.prepDB(c, "local")
+		return ioutil.WriteFile(filepath.Join(localDir, "oplog.rs.bson"), []byte("<oplog>"), 0600)
+	})
+	since := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	dumper, err := backups.NewOplogDumper(s.dbInfo, since)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ranWithArgs, jc.DeepEquals, []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--out", s.dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", `{"ts": {"$gte": {"$timestamp": {"t": 1588302000, "i": 0}}}}`,
+	})
+	data, err := ioutil.ReadFile(filepath.Join(s.dumpDir, "oplog.bson"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<oplog>")
+	s.checkStripped(c, "local")
+}
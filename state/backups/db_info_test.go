@@ -4,6 +4,9 @@
 package backups_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -45,6 +48,7 @@ func (s *dbInfoSuite) TestNewDBInfoOkay(c *gc.C) {
 	c.Check(dbInfo.Address, gc.Equals, "localhost:8080")
 	c.Check(dbInfo.Username, gc.Equals, "machine-0")
 	c.Check(dbInfo.Password, gc.Equals, "eggs")
+	c.Check(dbInfo.OplogHead.IsZero(), jc.IsTrue)
 }
 
 func (s *dbInfoSuite) TestNewDBInfoMissingTag(c *gc.C) {
@@ -63,3 +67,31 @@ func (s *dbInfoSuite) TestNewDBInfoMissingTag(c *gc.C) {
 	c.Check(dbInfo.Address, gc.Equals, "localhost:8080")
 	c.Check(dbInfo.Password, gc.Equals, "eggs")
 }
+
+func (s *dbInfoSuite) TestNewDBInfoOplogHead(c *gc.C) {
+	head := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	s.PatchValue(backups.GetOplogHead, func(backups.DBSession) (time.Time, error) {
+		return head, nil
+	})
+	mgoInfo := &mongo.MongoInfo{
+		Info: mongo.Info{
+			Addrs: []string{"localhost:8080"},
+		},
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, &fakeSession{}, mongo.Mongo32wt)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dbInfo.OplogHead, gc.Equals, head)
+}
+
+func (s *dbInfoSuite) TestNewDBInfoOplogHeadError(c *gc.C) {
+	s.PatchValue(backups.GetOplogHead, func(backups.DBSession) (time.Time, error) {
+		return time.Time{}, errors.New("boom")
+	})
+	mgoInfo := &mongo.MongoInfo{
+		Info: mongo.Info{
+			Addrs: []string{"localhost:8080"},
+		},
+	}
+	_, err := backups.NewDBInfo(mgoInfo, &fakeSession{}, mongo.Mongo32wt)
+	c.Check(err, gc.ErrorMatches, "unable to read oplog head: boom")
+}
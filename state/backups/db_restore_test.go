@@ -5,6 +5,7 @@ package backups_test
 
 import (
 	"fmt"
+	"time" // Only used for time types and funcs, not Now().
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -175,3 +176,27 @@ func (s *mongoRestoreSuite) TestRestoreFailsOnOlderMongo(c *gc.C) {
 	_, err := backups.NewDBRestorer(args)
 	c.Assert(err, gc.ErrorMatches, "restore mongo version 3.2/wiredTiger into version 2.4/mmapv1 not supported")
 }
+
+func (s *mongoRestoreSuite) TestRestoreDatabaseOplogLimit(c *gc.C) {
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) { return "/a/fake/mongorestore", nil })
+	var ranWithArgs []string
+	fakeRunCommand := func(c string, args ...string) error {
+		ranWithArgs = args
+		return nil
+	}
+	args := backups.RestorerArgs{
+		Version:      mongo.Mongo24,
+		RunCommandFn: fakeRunCommand,
+		StartMongo:   func() error { return nil },
+		StopMongo:    func() error { return nil },
+		OplogLimit:   time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+	}
+
+	s.PatchValue(backups.MongoInstalledVersion, func() mongo.Version { return mongo.Mongo24 })
+	restorer, err := backups.NewDBRestorer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	err = restorer.Restore("fakePath", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(ranWithArgs, gc.DeepEquals, []string{"--drop", "--journal", "--oplogReplay", "--dbpath", "/var/lib/juju/db", "--oplogLimit", "1588302000", "fakePath"})
+}
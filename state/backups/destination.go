@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+const (
+	// archiveSuffix is appended to a backup ID to name the archive
+	// object held by a destination.
+	archiveSuffix = ".tar.gz"
+
+	// metadataSuffix is appended to a backup ID to name the metadata
+	// object held by a destination.
+	metadataSuffix = ".json"
+)
+
+// Destination is somewhere outside of the controller's database where
+// backup archives may be kept, so that they survive the loss of the
+// controller itself.
+type Destination interface {
+	io.Closer
+
+	// URL returns the URL which identifies the destination.
+	URL() string
+
+	// Put stores the content read from r under the given name,
+	// replacing any existing object with that name.
+	Put(name string, r io.Reader) error
+
+	// Open returns the content stored under the given name. If there
+	// is no such object, an error satisfying errors.IsNotFound is
+	// returned.
+	Open(name string) (io.ReadCloser, error)
+
+	// List returns the names of all objects held by the destination.
+	List() ([]string, error)
+
+	// Remove deletes the object with the given name. If there is no
+	// such object, an error satisfying errors.IsNotFound is returned.
+	Remove(name string) error
+}
+
+// OpenDestination returns the Destination identified by the given URL.
+// Supported URLs are "file:///<dir>", for a directory on the controller
+// machine beneath the backup-local-root controller setting, and
+// "s3://<bucket>[/<prefix>]", for an S3-compatible object store
+// configured through the controller's backup-s3-* settings.
+func OpenDestination(rawURL string, cfg controller.Config) (Destination, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing backup destination %q", rawURL)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Host != "" && u.Host != "localhost" {
+			return nil, errors.NotValidf("backup destination %q with host %q", rawURL, u.Host)
+		}
+		return newLocalDestination(cfg.BackupLocalRoot(), u.Path)
+	case "s3":
+		return newS3Destination(u.Host, strings.Trim(u.Path, "/"), S3Config{
+			Endpoint:  cfg.BackupS3Endpoint(),
+			Region:    cfg.BackupS3Region(),
+			AccessKey: cfg.BackupS3AccessKey(),
+			SecretKey: cfg.BackupS3SecretKey(),
+		})
+	case "":
+		return nil, errors.NotValidf("backup destination %q without a scheme", rawURL)
+	}
+	return nil, errors.NotSupportedf("backup destination scheme %q", u.Scheme)
+}
+
+// NewDestinationStorage returns a FileStorage that keeps backup
+// archives and their metadata in the given destination. The archive is
+// stored as "<id>.tar.gz" and the metadata next to it as "<id>.json",
+// so the destination can be read without access to the controller.
+func NewDestinationStorage(dest Destination) filestorage.FileStorage {
+	return &destinationStorage{dest: dest}
+}
+
+type destinationStorage struct {
+	dest Destination
+}
+
+// Metadata implements filestorage.FileStorage.
+func (s *destinationStorage) Metadata(id string) (filestorage.Metadata, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+func (s *destinationStorage) metadata(id string) (*Metadata, error) {
+	file, err := s.dest.Open(id + metadataSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup %q in %s", id, s.dest.URL())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer file.Close()
+
+	meta, err := NewMetadataJSONReader(file)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading metadata for backup %q", id)
+	}
+	meta.SetID(id)
+	return meta, nil
+}
+
+// Get implements filestorage.FileStorage.
+func (s *destinationStorage) Get(id string) (filestorage.Metadata, io.ReadCloser, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := s.dest.Open(id + archiveSuffix)
+	if errors.IsNotFound(err) {
+		return nil, nil, errors.NotFoundf("archive for backup %q in %s", id, s.dest.URL())
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, archive, nil
+}
+
+// List implements filestorage.FileStorage.
+func (s *destinationStorage) List() ([]filestorage.Metadata, error) {
+	names, err := s.dest.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(names)
+
+	var result []filestorage.Metadata
+	for _, name := range names {
+		if !strings.HasSuffix(name, metadataSuffix) {
+			continue
+		}
+		meta, err := s.metadata(strings.TrimSuffix(name, metadataSuffix))
+		if errors.IsNotFound(err) {
+			// Removed since we listed the destination.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, meta)
+	}
+	return result, nil
+}
+
+// Add implements filestorage.FileStorage.
+func (s *destinationStorage) Add(rawmeta filestorage.Metadata, archive io.Reader) (string, error) {
+	meta, ok := rawmeta.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("expected backups.Metadata value, got %T", rawmeta)
+	}
+	if meta.Origin.Model == "" {
+		return "", errors.New("missing Model")
+	}
+	// Use the same IDs as backups held by the controller, so
+	// they can be used interchangeably in the CLI.
+	id := meta.Started.UTC().Format(backupIDTimestamp) + "." + meta.Origin.Model
+	if _, err := s.metadata(id); err == nil {
+		return "", errors.AlreadyExistsf("backup %q in %s", id, s.dest.URL())
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	// The archive is written before the metadata; backups without
+	// metadata are never listed, so a failed upload is not mistaken
+	// for a usable backup.
+	if err := s.dest.Put(id+archiveSuffix, archive); err != nil {
+		return "", errors.Annotatef(err, "storing archive for backup %q", id)
+	}
+	meta.SetID(id)
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	stored := time.Now().UTC()
+	meta.SetStored(&stored)
+	if err := s.putMetadata(meta); err != nil {
+		return "", errors.Trace(err)
+	}
+	return id, nil
+}
+
+func (s *destinationStorage) putMetadata(meta *Metadata) error {
+	data, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.dest.Put(meta.ID()+metadataSuffix, data); err != nil {
+		return errors.Annotatef(err, "storing metadata for backup %q", meta.ID())
+	}
+	return nil
+}
+
+// SetFile implements filestorage.FileStorage.
+func (s *destinationStorage) SetFile(id string, file io.Reader) error {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.dest.Put(id+archiveSuffix, file); err != nil {
+		return errors.Annotatef(err, "storing archive for backup %q", id)
+	}
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	stored := time.Now().UTC()
+	meta.SetStored(&stored)
+	return errors.Trace(s.putMetadata(meta))
+}
+
+// Remove implements filestorage.FileStorage.
+func (s *destinationStorage) Remove(id string) error {
+	// Remove the metadata first so a partially removed backup is no
+	// longer listed.
+	if err := s.dest.Remove(id + metadataSuffix); errors.IsNotFound(err) {
+		return errors.NotFoundf("backup %q in %s", id, s.dest.URL())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.dest.Remove(id + archiveSuffix); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Close implements filestorage.FileStorage.
+func (s *destinationStorage) Close() error {
+	return s.dest.Close()
+}
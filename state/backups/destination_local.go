@@ -0,0 +1,186 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// localDestination is a Destination backed by a directory on the
+// controller machine, typically a mount of remote storage.
+type localDestination struct {
+	dir string
+}
+
+// newLocalDestination returns a destination for the directory dir,
+// which must lie within root. Paths containing ".." are rejected, and
+// symbolic links are resolved so that a link cannot be used to escape
+// the root.
+func newLocalDestination(root, dir string) (*localDestination, error) {
+	if !filepath.IsAbs(dir) {
+		return nil, errors.NotValidf("backup destination directory %q (must be absolute)", dir)
+	}
+	for _, part := range strings.Split(filepath.ToSlash(dir), "/") {
+		if part == ".." {
+			return nil, errors.NotValidf("backup destination directory %q (must not contain \"..\")", dir)
+		}
+	}
+	root = filepath.Clean(root)
+	dir = filepath.Clean(dir)
+	if !withinDir(root, dir) {
+		return nil, errors.NotValidf("backup destination directory %q (must be within %q)", dir, root)
+	}
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, errors.Annotatef(err, "creating backup root directory %q", root)
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Check the part of the path that already exists before creating
+	// anything, so a link inside the root cannot cause directories to
+	// be created elsewhere.
+	existing := dir
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		existing = filepath.Dir(existing)
+	}
+	if err := checkWithinRoot(realRoot, existing, dir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Annotatef(err, "creating backup destination directory %q", dir)
+	}
+	if err := checkWithinRoot(realRoot, dir, dir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &localDestination{dir: dir}, nil
+}
+
+// checkWithinRoot returns an error if path, once symbolic links are
+// resolved, lies outside realRoot.
+func checkWithinRoot(realRoot, path, dir string) error {
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !withinDir(realRoot, realPath) {
+		return errors.NotValidf("backup destination directory %q (resolves outside %q)", dir, realRoot)
+	}
+	return nil
+}
+
+// withinDir reports whether path is dir or lies beneath it. Both
+// paths must be clean.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// URL implements Destination.
+func (d *localDestination) URL() string {
+	return "file://" + filepath.ToSlash(d.dir)
+}
+
+func (d *localDestination) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) {
+		return "", errors.NotValidf("backup object name %q", name)
+	}
+	return filepath.Join(d.dir, name), nil
+}
+
+// Put implements Destination. The content is written to a temporary
+// file which is renamed into place once complete.
+func (d *localDestination) Put(name string, r io.Reader) (err error) {
+	target, err := d.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	file, err := ioutil.TempFile(d.dir, "."+name+".")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+	if _, err := io.Copy(file, r); err != nil {
+		return errors.Annotatef(err, "writing %q", target)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(file.Name(), target))
+}
+
+// Open implements Destination.
+func (d *localDestination) Open(name string) (io.ReadCloser, error) {
+	path, err := d.path(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q", path)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errors.NotValidf("backup object %q (not a regular file)", path)
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q", path)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return file, nil
+}
+
+// List implements Destination.
+func (d *localDestination) List() ([]string, error) {
+	infos, err := ioutil.ReadDir(d.dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() || info.Name()[0] == '.' {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
+
+// Remove implements Destination.
+func (d *localDestination) Remove(name string) error {
+	path, err := d.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%q", path)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements Destination.
+func (d *localDestination) Close() error {
+	return nil
+}
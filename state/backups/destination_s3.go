@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/juju/errors"
+)
+
+// defaultS3Region is used when no region has been configured, which is
+// what most S3-compatible stores expect.
+const defaultS3Region = "us-east-1"
+
+// S3Config holds the settings used to connect to an S3-compatible
+// object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If empty, AWS S3
+	// is used.
+	Endpoint string
+
+	// Region is the region holding the bucket.
+	Region string
+
+	// AccessKey and SecretKey are the credentials used to
+	// authenticate with the object store.
+	AccessKey string
+	SecretKey string
+}
+
+// Validate returns an error if the config is not usable.
+func (cfg S3Config) Validate() error {
+	if cfg.AccessKey == "" {
+		return errors.NotValidf("S3 config without access key")
+	}
+	if cfg.SecretKey == "" {
+		return errors.NotValidf("S3 config without secret key")
+	}
+	return nil
+}
+
+// newS3Client is overridden in tests.
+var newS3Client = func(cfg S3Config) (s3iface.S3API, error) {
+	awsCfg := &aws.Config{
+		Region:      aws.String(cfg.Region),
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, ""),
+	}
+	if cfg.Endpoint != "" {
+		// Most S3-compatible stores don't support virtual-hosted
+		// style bucket addressing.
+		awsCfg.Endpoint = aws.String(cfg.Endpoint)
+		awsCfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s3.New(sess), nil
+}
+
+// s3Destination is a Destination backed by a bucket in an S3-compatible
+// object store. Objects are stored under an optional key prefix.
+type s3Destination struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+func newS3Destination(bucket, prefix string, cfg S3Config) (*s3Destination, error) {
+	if bucket == "" {
+		return nil, errors.NotValidf("S3 backup destination without a bucket")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Annotate(err, "S3 backup destination")
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+	}
+	client, err := newS3Client(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to S3")
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3Destination{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+// URL implements Destination.
+func (d *s3Destination) URL() string {
+	return "s3://" + d.bucket + "/" + d.prefix
+}
+
+// Put implements Destination. Archives are streamed to the object
+// store using a multipart upload, so they need not be held in memory.
+func (d *s3Destination) Put(name string, r io.Reader) error {
+	uploader := s3manager.NewUploaderWithClient(d.client)
+	_, err := uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(d.prefix + name),
+		Body:   r,
+	})
+	return errors.Annotatef(err, "uploading %q", d.URL()+name)
+}
+
+// Open implements Destination.
+func (d *s3Destination) Open(name string) (io.ReadCloser, error) {
+	out, err := d.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(d.prefix + name),
+	})
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("%q", d.URL()+name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "fetching %q", d.URL()+name)
+	}
+	return out.Body, nil
+}
+
+// List implements Destination. Only objects directly under the prefix
+// are returned.
+func (d *s3Destination) List() ([]string, error) {
+	var names []string
+	err := d.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket:    aws.String(d.bucket),
+		Prefix:    aws.String(d.prefix),
+		Delimiter: aws.String("/"),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(obj.Key), d.prefix))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing %q", d.URL())
+	}
+	return names, nil
+}
+
+// Remove implements Destination. S3 does not report deletion of a
+// missing object as an error, so the object is checked first.
+func (d *s3Destination) Remove(name string) error {
+	key := aws.String(d.prefix + name)
+	_, err := d.client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    key,
+	})
+	if isS3NotFound(err) {
+		return errors.NotFoundf("%q", d.URL()+name)
+	} else if err != nil {
+		return errors.Annotatef(err, "checking %q", d.URL()+name)
+	}
+	_, err = d.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    key,
+	})
+	return errors.Annotatef(err, "removing %q", d.URL()+name)
+}
+
+// Close implements Destination.
+func (d *s3Destination) Close() error {
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() == http.StatusNotFound
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type destinationSuite struct {
+	testing.IsolationSuite
+
+	root string
+	dir  string
+}
+
+var _ = gc.Suite(&destinationSuite{})
+
+func (s *destinationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.root = c.MkDir()
+	s.dir = filepath.Join(s.root, "backups")
+}
+
+func (s *destinationSuite) localConfig() controller.Config {
+	return controller.Config{controller.BackupLocalRoot: s.root}
+}
+
+func (s *destinationSuite) openLocal(c *gc.C) backups.Backups {
+	dest, err := backups.OpenDestination("file://"+s.dir, s.localConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dest.URL(), gc.Equals, "file://"+s.dir)
+	return backups.NewBackups(backups.NewDestinationStorage(dest))
+}
+
+func (s *destinationSuite) TestLocalAddGet(c *gc.C) {
+	api := s.openLocal(c)
+	meta := backupstesting.NewMetadataStarted()
+	backupstesting.FinishMetadata(meta)
+	meta.Notes = "before upgrade"
+
+	id, err := api.Add(bytes.NewBufferString("<compressed data>"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, meta.Started.UTC().Format("20060102-150405")+"."+meta.Origin.Model)
+	c.Check(meta.ID(), gc.Equals, id)
+	c.Check(meta.Stored(), gc.NotNil)
+
+	// The archive and metadata are kept side by side.
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed data>")
+	_, err = os.Stat(filepath.Join(s.dir, id+".json"))
+	c.Check(err, jc.ErrorIsNil)
+
+	got, archive, err := api.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err = ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed data>")
+	c.Check(got.ID(), gc.Equals, id)
+	c.Check(got.Notes, gc.Equals, "before upgrade")
+	c.Check(got.Checksum(), gc.Equals, meta.Checksum())
+	c.Check(got.Size(), gc.Equals, meta.Size())
+	c.Check(got.Started.Equal(meta.Started), jc.IsTrue)
+	c.Check(got.Origin, jc.DeepEquals, meta.Origin)
+}
+
+func (s *destinationSuite) TestLocalAddExists(c *gc.C) {
+	api := s.openLocal(c)
+	meta := backupstesting.NewMetadataStarted()
+	_, err := api.Add(bytes.NewBufferString("<data>"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Add(bytes.NewBufferString("<data>"), meta)
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *destinationSuite) TestLocalListRemove(c *gc.C) {
+	api := s.openLocal(c)
+	meta := backupstesting.NewMetadataStarted()
+	id, err := api.Add(bytes.NewBufferString("<data>"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Archives without metadata are incomplete, and are not listed.
+	err = ioutil.WriteFile(filepath.Join(s.dir, "partial.tar.gz"), []byte("<data>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Check(list[0].ID(), gc.Equals, id)
+
+	err = api.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	list, err = api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(list, gc.HasLen, 0)
+	_, err = os.Stat(filepath.Join(s.dir, id+".tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+
+	err = api.Remove(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *destinationSuite) TestLocalGetNotFound(c *gc.C) {
+	api := s.openLocal(c)
+	_, _, err := api.Get("20140924-010319.missing")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *destinationSuite) TestLocalOutsideRoot(c *gc.C) {
+	outside := c.MkDir()
+	_, err := backups.OpenDestination("file://"+outside, s.localConfig())
+	c.Check(err, gc.ErrorMatches, `backup destination directory ".*" \(must be within ".*"\) not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *destinationSuite) TestLocalDotDot(c *gc.C) {
+	_, err := backups.OpenDestination("file://"+s.root+"/backups/../../etc", s.localConfig())
+	c.Check(err, gc.ErrorMatches, `backup destination directory ".*" \(must not contain ".."\) not valid`)
+}
+
+func (s *destinationSuite) TestLocalSymlinkEscape(c *gc.C) {
+	outside := c.MkDir()
+	err := os.Symlink(outside, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.OpenDestination("file://"+s.dir+"/nested", s.localConfig())
+	c.Check(err, gc.ErrorMatches, `backup destination directory ".*" \(resolves outside ".*"\) not valid`)
+	_, err = os.Stat(filepath.Join(outside, "nested"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *destinationSuite) TestLocalOpenSymlink(c *gc.C) {
+	api := s.openLocal(c)
+	meta := backupstesting.NewMetadataStarted()
+	id, err := api.Add(bytes.NewBufferString("<data>"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An archive replaced by a link to another file is not read.
+	secret := filepath.Join(c.MkDir(), "secret")
+	err = ioutil.WriteFile(secret, []byte("<secret>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	archive := filepath.Join(s.dir, id+".tar.gz")
+	c.Assert(os.Remove(archive), jc.ErrorIsNil)
+	c.Assert(os.Symlink(secret, archive), jc.ErrorIsNil)
+
+	_, _, err = api.Get(id)
+	c.Check(err, gc.ErrorMatches, `.*not a regular file.*`)
+}
+
+func (s *destinationSuite) TestOpenS3(c *gc.C) {
+	var cfg backups.S3Config
+	s.PatchValue(backups.NewS3Client, func(s3cfg backups.S3Config) (s3iface.S3API, error) {
+		cfg = s3cfg
+		return nil, nil
+	})
+	dest, err := backups.OpenDestination("s3://juju-backups/prod/", controller.Config{
+		controller.BackupS3Endpoint:  "http://10.0.0.1:9000",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(dest.URL(), gc.Equals, "s3://juju-backups/prod/")
+	c.Check(cfg, jc.DeepEquals, backups.S3Config{
+		Endpoint:  "http://10.0.0.1:9000",
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+}
+
+func (s *destinationSuite) TestOpenS3MissingCredentials(c *gc.C) {
+	_, err := backups.OpenDestination("s3://juju-backups", controller.Config{})
+	c.Check(err, gc.ErrorMatches, "S3 backup destination: S3 config without access key not valid")
+}
+
+func (s *destinationSuite) TestOpenInvalid(c *gc.C) {
+	for i, test := range []struct {
+		url string
+		err string
+	}{{
+		url: "/var/backups",
+		err: `backup destination "/var/backups" without a scheme not valid`,
+	}, {
+		url: "ftp://example.com/backups",
+		err: `backup destination scheme "ftp" not supported`,
+	}, {
+		url: "file://example.com/backups",
+		err: `backup destination "file://example.com/backups" with host "example.com" not valid`,
+	}, {
+		url: "s3:///backups",
+		err: `S3 backup destination without a bucket not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.url)
+		_, err := backups.OpenDestination(test.url, controller.Config{})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -17,11 +17,14 @@ import (
 )
 
 var (
-	Create        = create
-	FileTimestamp = fileTimestamp
+	Create         = create
+	FileTimestamp  = fileTimestamp
+	ReadOplogRange = readOplogRange
 
 	TestGetFilesToBackUp  = &getFilesToBackUp
 	GetDBDumper           = &getDBDumper
+	GetOplogDumper        = &getOplogDumper
+	GetOplogHead          = &getOplogHead
 	RunCreate             = &runCreate
 	FinishMeta            = &finishMeta
 	StoreArchiveRef       = &storeArchive
@@ -30,6 +33,7 @@ var (
 	RunCommand            = &runCommandFn
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+	NewS3Client           = &newS3Client
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
@@ -121,6 +125,11 @@ func NewTestCreateResult(file io.ReadCloser, size int64, checksum, filename stri
 	return &result
 }
 
+// SetTestCreateResultOplog sets the oplog range of a create() result.
+func SetTestCreateResultOplog(result *createResult, oplog OplogRange) {
+	result.oplog = oplog
+}
+
 // NewTestCreate builds a new replacement for create() with the given result.
 func NewTestCreate(result *createResult) (*createArgs, func(*createArgs) (*createResult, error)) {
 	var received createArgs
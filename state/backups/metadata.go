@@ -89,6 +89,16 @@ type Metadata struct {
 	// Controller contains metadata about the controller where the backup was taken.
 	Controller ControllerMetadata
 
+	// Base is the ID of the full backup that an incremental backup
+	// builds on. It is empty for full backups.
+	Base string
+
+	// Oplog is the range of the database oplog held in the backup.
+	// For a full backup, this covers the changes made while the
+	// database was being dumped. For an incremental backup, it covers
+	// every change since the start of its base backup's range.
+	Oplog OplogRange
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -118,9 +128,44 @@ type ControllerMetadata struct {
 	HANodes int64
 }
 
+// OplogRange describes the span of the database oplog held in a backup.
+type OplogRange struct {
+	// Start is the time of the first operation in the oplog.
+	Start time.Time
+
+	// End is the time of the last operation in the oplog.
+	End time.Time
+}
+
+// IsZero returns whether the range is unset, as it is for backups
+// made without an oplog.
+func (r OplogRange) IsZero() bool {
+	return r.Start.IsZero() && r.End.IsZero()
+}
+
+// withHead returns the range extended to start no later than the
+// given oplog head.
+func (r OplogRange) withHead(head time.Time) OplogRange {
+	if head.IsZero() {
+		return r
+	}
+	if r.Start.IsZero() || head.Before(r.Start) {
+		r.Start = head
+	}
+	if r.End.IsZero() {
+		r.End = head
+	}
+	return r
+}
+
 // All un-versioned metadata is considered to be version 0,
-// so the versions start with 1.
-const currentFormatVersion = 1
+// so the versions start with 1. Version 2 adds the oplog range and
+// base backup, and is only used for backups that hold oplog data so
+// that other backups can still be read by older clients.
+const (
+	currentFormatVersion = 1
+	oplogFormatVersion   = 2
+)
 
 // NewMetadata returns a new Metadata for a state backup archive,
 //in the most current format.
@@ -166,6 +211,22 @@ func NewMetadataState(db DB, machine, series string) (*Metadata, error) {
 	return meta, nil
 }
 
+// IsIncremental returns whether the metadata is for an incremental
+// backup, which holds only the oplog since its base backup.
+func (m *Metadata) IsIncremental() bool {
+	return m.Base != ""
+}
+
+// setFormatVersion sets the format version to the oldest that can
+// hold the metadata.
+func (m *Metadata) setFormatVersion() {
+	if m.IsIncremental() || !m.Oplog.IsZero() {
+		m.FormatVersion = oplogFormatVersion
+	} else {
+		m.FormatVersion = currentFormatVersion
+	}
+}
+
 // MarkComplete populates the remaining metadata values.  The default
 // checksum format is used.
 func (m *Metadata) MarkComplete(size int64, checksum string) error {
@@ -246,11 +307,9 @@ func (flat *flatMetadataV0) inflate() (*Metadata, error) {
 	return meta, nil
 }
 
-// flatMetadata contains the latest format of the backup.
-// NOTE If any changes need to be made here, rename this struct to
-// reflect version 1, for example flatMetadataV1 and construct
-// new flatMetadata with desired modifications.
-type flatMetadata struct {
+// flatMetadataV1 contains version 1 of the backup format, which
+// predates incremental backups.
+type flatMetadataV1 struct {
 	ID            string
 	FormatVersion int64
 
@@ -279,8 +338,8 @@ type flatMetadata struct {
 	CAPrivateKey                string
 }
 
-func (m *Metadata) flat() flatMetadata {
-	flat := flatMetadata{
+func (m *Metadata) flatV1() flatMetadataV1 {
+	flat := flatMetadataV1{
 		ID:                          m.ID(),
 		Checksum:                    m.Checksum(),
 		ChecksumFormat:              m.ChecksumFormat(),
@@ -311,7 +370,7 @@ func (m *Metadata) flat() flatMetadata {
 	return flat
 }
 
-func (flat *flatMetadata) inflate() (*Metadata, error) {
+func (flat *flatMetadataV1) inflate() (*Metadata, error) {
 	meta := NewMetadata()
 	meta.SetID(flat.ID)
 	meta.FormatVersion = flat.FormatVersion
@@ -352,6 +411,49 @@ func (flat *flatMetadata) inflate() (*Metadata, error) {
 	return meta, nil
 }
 
+// flatMetadata contains the latest format of the backup.
+// NOTE If any changes need to be made here, rename this struct to
+// reflect version 2, for example flatMetadataV2 and construct
+// new flatMetadata with desired modifications.
+type flatMetadata struct {
+	flatMetadataV1
+
+	Base       string     `json:",omitempty"`
+	OplogStart *time.Time `json:",omitempty"`
+	OplogEnd   *time.Time `json:",omitempty"`
+}
+
+func (m *Metadata) flat() flatMetadata {
+	flat := flatMetadata{
+		flatMetadataV1: m.flatV1(),
+		Base:           m.Base,
+	}
+	if !m.Oplog.Start.IsZero() {
+		start := m.Oplog.Start
+		flat.OplogStart = &start
+	}
+	if !m.Oplog.End.IsZero() {
+		end := m.Oplog.End
+		flat.OplogEnd = &end
+	}
+	return flat
+}
+
+func (flat *flatMetadata) inflate() (*Metadata, error) {
+	meta, err := flat.flatMetadataV1.inflate()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Base = flat.Base
+	if flat.OplogStart != nil {
+		meta.Oplog.Start = *flat.OplogStart
+	}
+	if flat.OplogEnd != nil {
+		meta.Oplog.End = *flat.OplogEnd
+	}
+	return meta, nil
+}
+
 // AsJSONBuffer returns a bytes.Buffer containing the JSON-ified metadata.
 // This will always produce latest known format.
 func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
@@ -385,6 +487,8 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 			return v0.inflate()
 		}
 	case 1:
+		return flat.flatMetadataV1.inflate()
+	case 2:
 		return flat.inflate()
 	default:
 		return nil, errors.NotSupportedf("backup format %d", flat.FormatVersion)
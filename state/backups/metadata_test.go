@@ -147,6 +147,46 @@ func (s *metadataSuite) TestAsJSONBufferV1HA(c *gc.C) {
 		`}`+"\n")
 }
 
+func (s *metadataSuite) TestAsJSONBufferV2Incremental(c *gc.C) {
+	meta := s.createTestMetadata(c)
+	meta.FormatVersion = 2
+	meta.Controller = backups.ControllerMetadata{
+		UUID:              "controller-uuid",
+		MachineInstanceID: "inst-10101010",
+		MachineID:         "10",
+	}
+	meta.Base = "20140909-105934.asdf-zxcv-qwe"
+	meta.Oplog = backups.OplogRange{
+		Start: time.Date(2014, time.Month(9), 9, 10, 59, 30, 0, time.UTC),
+		End:   time.Date(2014, time.Month(9), 9, 11, 59, 34, 0, time.UTC),
+	}
+	s.assertMetadata(c, meta, `{`+
+		`"ID":"20140909-115934.asdf-zxcv-qwe",`+
+		`"FormatVersion":2,`+
+		`"Checksum":"123af2cef",`+
+		`"ChecksumFormat":"SHA-1, base64 encoded",`+
+		`"Size":10,`+
+		`"Stored":"0001-01-01T00:00:00Z",`+
+		`"Started":"2014-09-09T11:59:34Z",`+
+		`"Finished":"2014-09-09T12:00:34Z",`+
+		`"Notes":"",`+
+		`"ModelUUID":"asdf-zxcv-qwe",`+
+		`"Machine":"0",`+
+		`"Hostname":"myhost",`+
+		`"Version":"1.21-alpha3",`+
+		`"Series":"trusty",`+
+		`"ControllerUUID":"controller-uuid",`+
+		`"HANodes":0,`+
+		`"ControllerMachineID":"10",`+
+		`"ControllerMachineInstanceID":"inst-10101010",`+
+		`"CACert":"ca-cert",`+
+		`"CAPrivateKey":"ca-private-key",`+
+		`"Base":"20140909-105934.asdf-zxcv-qwe",`+
+		`"OplogStart":"2014-09-09T10:59:30Z",`+
+		`"OplogEnd":"2014-09-09T11:59:34Z"`+
+		`}`+"\n")
+}
+
 func (s *metadataSuite) TestNewMetadataJSONReaderV0(c *gc.C) {
 	file := bytes.NewBufferString(`{` +
 		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
@@ -226,7 +266,7 @@ func (s *metadataSuite) TestNewMetadataJSONReaderV1(c *gc.C) {
 	c.Check(meta.Controller.MachineID, gc.Equals, "10")
 }
 
-func (s *metadataSuite) TestNewMetadataJSONReaderUnsupported(c *gc.C) {
+func (s *metadataSuite) TestNewMetadataJSONReaderV2(c *gc.C) {
 	file := bytes.NewBufferString(`{` +
 		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
 		`"FormatVersion":2,` +
@@ -244,6 +284,57 @@ func (s *metadataSuite) TestNewMetadataJSONReaderUnsupported(c *gc.C) {
 		`"ControllerUUID":"controller-uuid",` +
 		`"HANodes":3,` +
 		`"ControllerMachineID":"10",` +
+		`"ControllerMachineInstanceID":"inst-10101010",` +
+		`"Base":"20140909-105934.asdf-zxcv-qwe",` +
+		`"OplogStart":"2014-09-09T10:59:30Z",` +
+		`"OplogEnd":"2014-09-09T11:59:34Z"` +
+		`}` + "\n")
+	meta, err := backups.NewMetadataJSONReader(file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.ID(), gc.Equals, "20140909-115934.asdf-zxcv-qwe")
+	c.Check(meta.FormatVersion, gc.Equals, int64(2))
+	c.Check(meta.Controller.UUID, gc.Equals, "controller-uuid")
+	c.Check(meta.IsIncremental(), jc.IsTrue)
+	c.Check(meta.Base, gc.Equals, "20140909-105934.asdf-zxcv-qwe")
+	c.Check(meta.Oplog.Start.Unix(), gc.Equals, int64(1410260370))
+	c.Check(meta.Oplog.End.Unix(), gc.Equals, int64(1410263974))
+}
+
+func (s *metadataSuite) TestNewMetadataJSONReaderV2Full(c *gc.C) {
+	meta, err := backups.NewMetadataJSONReader(bytes.NewBufferString(`{` +
+		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
+		`"FormatVersion":2,` +
+		`"Checksum":"123af2cef",` +
+		`"ChecksumFormat":"SHA-1, base64 encoded",` +
+		`"Size":10,` +
+		`"Started":"2014-09-09T11:59:34Z",` +
+		`"ModelUUID":"asdf-zxcv-qwe"` +
+		`}` + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.IsIncremental(), jc.IsFalse)
+	c.Check(meta.Oplog.IsZero(), jc.IsTrue)
+}
+
+func (s *metadataSuite) TestNewMetadataJSONReaderUnsupported(c *gc.C) {
+	file := bytes.NewBufferString(`{` +
+		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
+		`"FormatVersion":3,` +
+		`"Checksum":"123af2cef",` +
+		`"ChecksumFormat":"SHA-1, base64 encoded",` +
+		`"Size":10,` +
+		`"Stored":"0001-01-01T00:00:00Z",` +
+		`"Started":"2014-09-09T11:59:34Z",` +
+		`"Finished":"2014-09-09T12:00:34Z",` +
+		`"Notes":"",` +
+		`"ModelUUID":"asdf-zxcv-qwe",` +
+		`"Machine":"0",` +
+		`"Hostname":"myhost",` +
+		`"Version":"1.21-alpha3",` +
+		`"ControllerUUID":"controller-uuid",` +
+		`"HANodes":3,` +
+		`"ControllerMachineID":"10",` +
 		`"ControllerMachineInstanceID":"inst-10101010"` +
 		`}` + "\n")
 	meta, err := backups.NewMetadataJSONReader(file)
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// oplogFilename is the name of the file in a database dump holding
+// the oplog entries to be replayed by mongorestore.
+const oplogFilename = "oplog.bson"
+
+// oplogEntry holds the only part of an oplog entry backups cares about.
+type oplogEntry struct {
+	Timestamp bson.MongoTimestamp `bson:"ts"`
+}
+
+// oplogTime converts a mongo timestamp, which holds the seconds since
+// the epoch in its high 32 bits and an ordinal in its low 32 bits,
+// into a time.
+func oplogTime(ts bson.MongoTimestamp) time.Time {
+	return time.Unix(int64(ts)>>32, 0).UTC()
+}
+
+// readOplogRange returns the range of the oplog entries in the given
+// dump file. The entries are in oplog order, so the range runs from
+// the first entry to the last.
+func readOplogRange(filename string) (OplogRange, error) {
+	var result OplogRange
+	file, err := os.Open(filename)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		// Each BSON document starts with its total size, as a
+		// little-endian int32.
+		var size [4]byte
+		if _, err := io.ReadFull(reader, size[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return result, errors.Annotate(err, "reading oplog entry")
+		}
+		n := binary.LittleEndian.Uint32(size[:])
+		if n < uint32(len(size))+1 {
+			return result, errors.Errorf("invalid oplog entry size %d", n)
+		}
+		doc := make([]byte, n)
+		copy(doc, size[:])
+		if _, err := io.ReadFull(reader, doc[len(size):]); err != nil {
+			return result, errors.Annotate(err, "reading oplog entry")
+		}
+
+		var entry oplogEntry
+		if err := bson.Unmarshal(doc, &entry); err != nil {
+			return result, errors.Annotate(err, "decoding oplog entry")
+		}
+		t := oplogTime(entry.Timestamp)
+		if result.Start.IsZero() {
+			result.Start = t
+		}
+		result.End = t
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time" // Only used for time types and funcs, not Now().
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type oplogSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&oplogSuite{}) // Register the suite.
+
+func mongoTimestamp(t time.Time, ordinal uint32) bson.MongoTimestamp {
+	return bson.MongoTimestamp(t.Unix()<<32 | int64(ordinal))
+}
+
+func writeOplog(c *gc.C, filename string, timestamps ...bson.MongoTimestamp) {
+	var data []byte
+	for _, ts := range timestamps {
+		doc, err := bson.Marshal(bson.D{
+			{Name: "ts", Value: ts},
+			{Name: "op", Value: "i"},
+			{Name: "ns", Value: "juju.machines"},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		data = append(data, doc...)
+	}
+	err := ioutil.WriteFile(filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oplogSuite) TestReadOplogRange(c *gc.C) {
+	start := time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	filename := filepath.Join(c.MkDir(), "oplog.bson")
+	writeOplog(c, filename,
+		mongoTimestamp(start, 1),
+		mongoTimestamp(start, 2),
+		mongoTimestamp(end, 1),
+	)
+
+	oplog, err := backups.ReadOplogRange(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(oplog, jc.DeepEquals, backups.OplogRange{Start: start, End: end})
+}
+
+func (s *oplogSuite) TestReadOplogRangeEmpty(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "oplog.bson")
+	writeOplog(c, filename)
+
+	oplog, err := backups.ReadOplogRange(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(oplog.IsZero(), jc.IsTrue)
+}
+
+func (s *oplogSuite) TestReadOplogRangeTruncated(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "oplog.bson")
+	writeOplog(c, filename, mongoTimestamp(time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC), 1))
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filename, data[:len(data)-3], 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.ReadOplogRange(filename)
+	c.Check(err, gc.ErrorMatches, "reading oplog entry: unexpected EOF")
+}
+
+func (s *oplogSuite) TestReadOplogRangeMissing(c *gc.C) {
+	_, err := backups.ReadOplogRange(filepath.Join(c.MkDir(), "oplog.bson"))
+	c.Check(errors.Cause(err), jc.Satisfies, os.IsNotExist)
+}
@@ -4,6 +4,8 @@
 package backups
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/instance"
@@ -16,4 +18,8 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// Until, if set, is the time up to which the changes in an
+	// incremental backup are restored.
+	Until time.Time
 }
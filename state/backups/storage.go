@@ -55,6 +55,12 @@ type storageMetaDoc struct {
 	Hostname string         `bson:"hostname"`
 	Version  version.Number `bson:"version"`
 	Series   string         `bson:"series"`
+
+	// incremental backups
+
+	Base       string `bson:"base,omitempty"`
+	OplogStart int64  `bson:"oplogstart,omitempty"`
+	OplogEnd   int64  `bson:"oplogend,omitempty"`
 }
 
 func (doc *storageMetaDoc) isFileInfoComplete() bool {
@@ -133,6 +139,14 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 
 	meta.SetID(doc.ID)
 
+	meta.Base = doc.Base
+	if doc.OplogStart != 0 {
+		meta.Oplog.Start = metadocUnixToTime(doc.OplogStart)
+	}
+	if doc.OplogEnd != 0 {
+		meta.Oplog.End = metadocUnixToTime(doc.OplogEnd)
+	}
+
 	if doc.Finished != 0 {
 		finished := metadocUnixToTime(doc.Finished)
 		meta.Finished = &finished
@@ -182,6 +196,14 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 	doc.Version = meta.Origin.Version
 	doc.Series = meta.Origin.Series
 
+	doc.Base = meta.Base
+	if !meta.Oplog.Start.IsZero() {
+		doc.OplogStart = metadocTimeToUnix(meta.Oplog.Start)
+	}
+	if !meta.Oplog.End.IsZero() {
+		doc.OplogEnd = metadocTimeToUnix(meta.Oplog.End)
+	}
+
 	return doc
 }
 
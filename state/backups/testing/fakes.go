@@ -5,6 +5,7 @@ package testing
 
 import (
 	"io"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -53,6 +54,10 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// BaseArg holds the base backup ID of the metadata that was passed in.
+	BaseArg string
+	// Until holds the time to restore up to that was passed in.
+	Until time.Time
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -70,6 +75,7 @@ func (b *FakeBackups) Create(
 	b.PathsArg = paths
 	b.DBInfoArg = dbInfo
 	b.MetaArg = meta
+	b.BaseArg = meta.Base
 	b.KeepCopy = keepCopy
 	b.NoDownload = noDownload
 
@@ -117,6 +123,7 @@ func (b *FakeBackups) Restore(bkpId string, args backups.RestoreArgs) (names.Tag
 	b.Calls = append(b.Calls, "Restore")
 	b.PrivateAddr = args.PrivateAddress
 	b.InstanceId = args.NewInstId
+	b.Until = args.Until
 	return nil, errors.Trace(b.Error)
 }
 
@@ -0,0 +1,101 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const backupScheduleStatusKey = "backupScheduleStatus"
+
+// BackupScheduleStatus records the outcome of the most recent backups
+// created by the controller's backup scheduler.
+type BackupScheduleStatus struct {
+	// LastAttempt is when the scheduler last tried to create a backup.
+	LastAttempt time.Time
+
+	// LastSuccess is when the scheduler last created a backup
+	// successfully. It is zero if no scheduled backup has succeeded.
+	LastSuccess time.Time
+
+	// LastBackupID is the ID of the last backup created successfully.
+	LastBackupID string
+
+	// LastError holds the reason the last attempt failed, or is empty
+	// if it succeeded.
+	LastError string
+}
+
+type backupScheduleStatusDoc struct {
+	LastAttempt  time.Time `bson:"last-attempt"`
+	LastSuccess  time.Time `bson:"last-success,omitempty"`
+	LastBackupID string    `bson:"last-backup-id,omitempty"`
+	LastError    string    `bson:"last-error,omitempty"`
+}
+
+// BackupScheduleStatus returns the status of the controller's scheduled
+// backups. If the scheduler has never attempted a backup, an error
+// satisfying errors.IsNotFound is returned.
+func (st *State) BackupScheduleStatus() (BackupScheduleStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc backupScheduleStatusDoc
+	err := controllers.FindId(backupScheduleStatusKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return BackupScheduleStatus{}, errors.NotFoundf("backup schedule status")
+	} else if err != nil {
+		return BackupScheduleStatus{}, errors.Annotate(err, "cannot get backup schedule status")
+	}
+	return BackupScheduleStatus{
+		LastAttempt:  doc.LastAttempt.UTC(),
+		LastSuccess:  doc.LastSuccess.UTC(),
+		LastBackupID: doc.LastBackupID,
+		LastError:    doc.LastError,
+	}, nil
+}
+
+// SetBackupScheduleStatus records the status of the controller's
+// scheduled backups, replacing any previously recorded status.
+func (st *State) SetBackupScheduleStatus(status BackupScheduleStatus) error {
+	doc := backupScheduleStatusDoc{
+		LastAttempt:  status.LastAttempt.UTC(),
+		LastSuccess:  status.LastSuccess.UTC(),
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := st.BackupScheduleStatus()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     backupScheduleStatusKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     backupScheduleStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"last-attempt", doc.LastAttempt},
+				{"last-success", doc.LastSuccess},
+				{"last-backup-id", doc.LastBackupID},
+				{"last-error", doc.LastError},
+			}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set backup schedule status")
+	}
+	return nil
+}
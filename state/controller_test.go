@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -29,6 +31,9 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := set.NewStrings(
+		controller.AgentMetricsPassword,
+		controller.AgentMetricsPort,
+		controller.AgentMetricsUsername,
 		controller.AgentRateLimitMax,
 		controller.AgentRateLimitRate,
 		controller.AllowModelAccessKey,
@@ -36,6 +41,16 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AuditLogExcludeMethods,
 		controller.AutocertURLKey,
 		controller.AutocertDNSNameKey,
+		controller.BackupS3AccessKey,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3SecretKey,
+		controller.BackupLocalRoot,
+		controller.BackupSchedule,
+		controller.BackupScheduleDestination,
+		controller.BackupKeepLast,
+		controller.BackupKeepDaily,
+		controller.BackupKeepWeekly,
 		controller.CAASImageRepo,
 		controller.CAASOperatorImagePath,
 		controller.CharmStoreURL,
@@ -258,6 +273,33 @@ func (s *ControllerSuite) TestStateServingInfo(c *gc.C) {
 	c.Assert(info, jc.DeepEquals, data)
 }
 
+func (s *ControllerSuite) TestBackupScheduleStatus(c *gc.C) {
+	_, err := s.State.BackupScheduleStatus()
+	c.Assert(err, gc.ErrorMatches, "backup schedule status not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	failed := state.BackupScheduleStatus{
+		LastAttempt: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		LastError:   "disk full",
+	}
+	err = s.State.SetBackupScheduleStatus(failed)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.State.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, failed)
+
+	succeeded := state.BackupScheduleStatus{
+		LastAttempt:  time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC),
+		LastSuccess:  time.Date(2020, 5, 2, 3, 1, 0, 0, time.UTC),
+		LastBackupID: "20200502-030000.deadbeef",
+	}
+	err = s.State.SetBackupScheduleStatus(succeeded)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.State.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, succeeded)
+}
+
 var setStateServingInfoWithInvalidInfoTests = []func(info *state.StateServingInfo){
 	func(info *state.StateServingInfo) { info.APIPort = 0 },
 	func(info *state.StateServingInfo) { info.StatePort = 0 },
@@ -383,6 +383,10 @@ func GetAllUpgradeInfos(st *State) ([]*UpgradeInfo, error) {
 	return out, nil
 }
 
+func UpgradeInfoSecretsKey(info *UpgradeInfo) string {
+	return info.doc.SecretsKey
+}
+
 func UserModelNameIndex(username, modelName string) string {
 	return userModelNameIndex(username, modelName)
 }
@@ -1020,3 +1024,8 @@ func (st *State) SetClockForTesting(clock clock.Clock) error {
 	}
 	return nil
 }
+
+// SetSecretsKey sets the key used to encrypt secret values.
+func SetSecretsKey(st *State, key string) {
+	st.secretsKey = key
+}
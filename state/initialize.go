@@ -62,6 +62,10 @@ type InitializeParams struct {
 
 	// AdminPassword holds the password for the initial user.
 	AdminPassword string
+
+	// SecretsKey is the base64 encoded key used to encrypt secret
+	// values, as created by NewSecretsKey.
+	SecretsKey string
 }
 
 // Validate checks that the state initialization parameters are valid.
@@ -156,6 +160,7 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 		MongoSession:       args.MongoSession,
 		NewPolicy:          args.NewPolicy,
 		InitDatabaseFunc:   InitDatabase,
+		SecretsKey:         args.SecretsKey,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "opening controller")
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"io"
 	"time"
 
 	"github.com/juju/version"
@@ -218,6 +219,10 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Batch returns the index of the batch of a rolling operation in
+	// which the action runs.
+	Batch() int
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -236,6 +241,19 @@ type Action interface {
 	// Messages returns the action's progress messages.
 	Messages() []ActionMessage
 
+	// AddAttachment stores size bytes read from r as a file attached
+	// to the action with the given name. The contents must match the
+	// given SHA384 hash, and the action must be running.
+	AddAttachment(name string, r io.Reader, size int64, sha384 string) error
+
+	// Attachments returns the files attached to the action, sorted by
+	// name.
+	Attachments() ([]ActionAttachment, error)
+
+	// OpenAttachment returns the action's attachment with the given
+	// name, and a reader for its contents.
+	OpenAttachment(name string) (ActionAttachment, io.ReadCloser, error)
+
 	// Cancel or Abort the action.
 	Cancel() (Action, error)
 
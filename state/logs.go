@@ -107,6 +107,15 @@ func InitDbLogs(session *mgo.Session) error {
 		}
 	}
 
+	auditSize, err := controllerSizeSetting(session, controller.AuditLogMaxSize, controller.DefaultAuditLogMaxSizeMB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := InitDbAuditLog(session, auditSize); err != nil {
+		encounteredError = true
+		logger.Errorf("unable to initialize audit log: %v", err)
+	}
+
 	if encounteredError {
 		return errors.New("one or more errors initializing logs")
 	}
@@ -117,6 +126,13 @@ func InitDbLogs(session *mgo.Session) error {
 // config document and returns it. If the value isn't found the default
 // size value is returned.
 func modelLogsSize(session *mgo.Session) (int, error) {
+	return controllerSizeSetting(session, controller.ModelLogsSize, controller.DefaultModelLogsSizeMB)
+}
+
+// controllerSizeSetting reads the size value with the given key from
+// the controller config document and returns it in MB. If the value
+// isn't found the default size value is returned.
+func controllerSizeSetting(session *mgo.Session, key string, defaultSizeMB int) (int, error) {
 	// This is executed very early in the opening of the database, so there
 	// is no State, Controller, nor StatePool objects just now. Use low level
 	// mgo to access the settings.
@@ -127,7 +143,7 @@ func modelLogsSize(session *mgo.Session) (int, error) {
 	}
 	// During initial migration there is no guarantee that the value exists
 	// in the settings document.
-	if value, ok := doc.Settings[controller.ModelLogsSize]; ok {
+	if value, ok := doc.Settings[key]; ok {
 		if s, ok := value.(string); ok {
 			size, _ := utils.ParseSize(s)
 			if size > 0 {
@@ -136,7 +152,7 @@ func modelLogsSize(session *mgo.Session) (int, error) {
 			}
 		}
 	}
-	return controller.DefaultModelLogsSizeMB, nil
+	return defaultSizeMB, nil
 }
 
 // modelUUIDs returns the UUIDs of all models currently stored in the database.
@@ -441,7 +457,21 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// IncludeMessage and ExcludeMessage hold regular expressions
+	// that are matched against the log message text. A record is
+	// included if it matches any of the include patterns, and
+	// excluded if it matches any of the exclude patterns.
+	IncludeMessage []string
+	ExcludeMessage []string
+
+	// IncludeLocation and ExcludeLocation hold regular expressions
+	// that are matched against the source location ("filename:lineno")
+	// of the log record.
+	IncludeLocation []string
+	ExcludeLocation []string
+
+	Oplog *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -760,6 +790,22 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.RegEx{Pattern: makeRegexPattern(params.IncludeMessage)}})
+	}
+	if len(params.ExcludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.M{"$not": bson.RegEx{Pattern: makeRegexPattern(params.ExcludeMessage)}}})
+	}
+	if len(params.IncludeLocation) > 0 {
+		sel = append(sel,
+			bson.DocElem{"l", bson.RegEx{Pattern: makeRegexPattern(params.IncludeLocation)}})
+	}
+	if len(params.ExcludeLocation) > 0 {
+		sel = append(sel,
+			bson.DocElem{"l", bson.M{"$not": bson.RegEx{Pattern: makeRegexPattern(params.ExcludeLocation)}}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -786,6 +832,16 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+// makeRegexPattern combines the user supplied regular expressions
+// into a single pattern which matches if any of them match.
+func makeRegexPattern(exprs []string) string {
+	var patterns []string
+	for _, expr := range exprs {
+		patterns = append(patterns, `(?:`+expr+`)`)
+	}
+	return strings.Join(patterns, "|")
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -627,6 +627,48 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	refused := logTemplate{Message: "dial tcp: connection refused"}
+	timeout := logTemplate{Message: "dial tcp: i/o timeout"}
+	hook := logTemplate{Message: "running config-changed hook"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, refused)
+		s.writeLogs(c, s.otherUUID, 2, hook)
+		s.writeLogs(c, s.otherUUID, 1, timeout)
+		s.writeLogs(c, s.otherUUID, 1, refused)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"^dial tcp", "hook$"},
+		ExcludeMessage: []string{"refused"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, hook)
+		s.assertTailer(c, tailer, 1, timeout)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeLocation(c *gc.C) {
+	uniter := logTemplate{Location: "uniter.go:42"}
+	resolver := logTemplate{Location: "resolver.go:101"}
+	agent := logTemplate{Location: "agent.go:7"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, uniter)
+		s.writeLogs(c, s.otherUUID, 1, agent)
+		s.writeLogs(c, s.otherUUID, 1, resolver)
+		s.writeLogs(c, s.otherUUID, 1, uniter)
+	}
+	params := state.LogTailerParams{
+		IncludeLocation: []string{`^(uniter|resolver)\.go`},
+		ExcludeLocation: []string{`:101$`},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, uniter)
+		s.assertTailer(c, tailer, 1, uniter)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
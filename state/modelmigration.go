@@ -6,6 +6,7 @@ package state
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -60,6 +61,11 @@ type ModelMigration interface {
 	// progress of the migration.
 	StatusMessage() string
 
+	// PhaseProgress returns when the migration entered and left each
+	// phase it has reached, along with the data sent to the target
+	// controller in each, ordered by the time the phase was entered.
+	PhaseProgress() []migration.PhaseProgress
+
 	// InitiatedBy returns username the initiated the migration.
 	InitiatedBy() string
 
@@ -67,6 +73,11 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// Offline returns true if the model was imported into the target
+	// controller from an export archive, so that the migration only
+	// needs to switch the model's agents over to it.
+	Offline() bool
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -76,6 +87,10 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// SetTransferVolume records the data sent to the target
+	// controller while the migration has been in its current phase.
+	SetTransferVolume(volume migration.TransferVolume) error
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -160,6 +175,10 @@ type modelMigDoc struct {
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
 
+	// Offline is true if the model was imported into the target
+	// controller from an export archive.
+	Offline bool `bson:"offline,omitempty"`
+
 	// The list of users and their access-level to the model being migrated.
 	ModelUsers []modelMigUserDoc `bson:"model-users,omitempty"`
 }
@@ -205,6 +224,23 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// PhaseTimes holds the time the migration entered each phase
+	// (stored as per UnixNano), keyed by phase name.
+	PhaseTimes map[string]int64 `bson:"phase-times,omitempty"`
+
+	// Transfers holds the data sent to the target controller in
+	// each phase, keyed by phase name.
+	Transfers map[string]modelMigTransferDoc `bson:"transfers,omitempty"`
+}
+
+// modelMigTransferDoc holds the number of bytes of each kind of data
+// sent to the target controller during a migration phase.
+type modelMigTransferDoc struct {
+	Model     int64 `bson:"model"`
+	Charms    int64 `bson:"charms"`
+	Tools     int64 `bson:"tools"`
+	Resources int64 `bson:"resources"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -265,11 +301,49 @@ func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
 }
 
+// PhaseProgress implements ModelMigration.
+func (mig *modelMigration) PhaseProgress() []migration.PhaseProgress {
+	var progress []migration.PhaseProgress
+	for name, started := range mig.statusDoc.PhaseTimes {
+		phase, ok := migration.ParsePhase(name)
+		if !ok {
+			logger.Warningf("ignoring invalid phase %q in migration %s", name, mig.Id())
+			continue
+		}
+		transfer := mig.statusDoc.Transfers[name]
+		progress = append(progress, migration.PhaseProgress{
+			Phase:   phase,
+			Started: unixNanoToTime0(started),
+			Sent: migration.TransferVolume{
+				Model:     transfer.Model,
+				Charms:    transfer.Charms,
+				Tools:     transfer.Tools,
+				Resources: transfer.Resources,
+			},
+		})
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].Started.Equal(progress[j].Started) {
+			return progress[i].Phase < progress[j].Phase
+		}
+		return progress[i].Started.Before(progress[j].Started)
+	})
+	for i := 1; i < len(progress); i++ {
+		progress[i-1].Ended = progress[i].Started
+	}
+	return progress
+}
+
 // InitiatedBy implements ModelMigration.
 func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
 }
 
+// Offline implements ModelMigration.
+func (mig *modelMigration) Offline() bool {
+	return mig.doc.Offline
+}
+
 // TargetInfo implements ModelMigration.
 func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	authTag, err := names.ParseUserTag(mig.doc.TargetAuthTag)
@@ -310,10 +384,16 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	nextDoc := mig.statusDoc
 	nextDoc.Phase = nextPhase.String()
 	nextDoc.PhaseChangedTime = now
+	nextDoc.PhaseTimes = make(map[string]int64)
+	for name, started := range mig.statusDoc.PhaseTimes {
+		nextDoc.PhaseTimes[name] = started
+	}
+	nextDoc.PhaseTimes[nextDoc.Phase] = now
 	update := bson.M{
 		"phase":              nextDoc.Phase,
 		"phase-changed-time": now,
 	}
+	update["phase-times."+nextDoc.Phase] = now
 	if nextPhase == migration.SUCCESS {
 		nextDoc.SuccessTime = now
 		update["success-time"] = now
@@ -427,6 +507,36 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// SetTransferVolume implements ModelMigration.
+func (mig *modelMigration) SetTransferVolume(volume migration.TransferVolume) error {
+	phase := mig.statusDoc.Phase
+	doc := modelMigTransferDoc{
+		Model:     volume.Model,
+		Charms:    volume.Charms,
+		Tools:     volume.Tools,
+		Resources: volume.Resources,
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"transfers." + phase: doc}},
+		// Ensure the volume is recorded against the right phase.
+		Assert: bson.M{"phase": phase},
+	}}
+	if err := mig.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.New("phase already changed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to set transfer volume")
+	}
+	transfers := make(map[string]modelMigTransferDoc)
+	for name, transfer := range mig.statusDoc.Transfers {
+		transfers[name] = transfer
+	}
+	transfers[phase] = doc
+	mig.statusDoc.Transfers = transfers
+	return nil
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -638,6 +748,11 @@ func (mig *modelMigration) ModelUserAccess(tag names.Tag) permission.Access {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+
+	// Offline is set when the model has already been exported and
+	// imported into the target controller, so only its agents need
+	// to be moved.
+	Offline bool
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -689,6 +804,15 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			return nil, errors.New("already in progress")
 		}
 
+		// An exported model has a copy in another controller, so it
+		// may only be migrated by switching over to that copy.
+		mode := model.MigrationMode()
+		if spec.Offline && mode != MigrationModeExporting {
+			return nil, errors.New("model has not been exported")
+		} else if !spec.Offline && mode != MigrationModeNone {
+			return nil, errors.New("model has been exported, use an offline migration")
+		}
+
 		macsJSON, err := macaroonsToJSON(spec.TargetInfo.Macaroons)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -717,6 +841,7 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetAuthTag:         spec.TargetInfo.AuthTag.String(),
 			TargetPassword:        spec.TargetInfo.Password,
 			TargetMacaroons:       macsJSON,
+			Offline:               spec.Offline,
 			ModelUsers:            userDocs,
 		}
 
@@ -726,6 +851,9 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			Phase:            migration.QUIESCE.String(),
 			PhaseChangedTime: now,
 			StatusMessage:    msg,
+			PhaseTimes: map[string]int64{
+				migration.QUIESCE.String(): now,
+			},
 		}
 
 		ops := append(ops, []txn.Op{{
@@ -746,12 +874,11 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 		}, {
 			C:      modelsC,
 			Id:     modelUUID,
-			Assert: txn.DocExists,
+			Assert: append(isAliveDoc, bson.DocElem{"migration-mode", mode}),
 			Update: bson.M{"$set": bson.M{
 				"migration-mode": MigrationModeExporting,
 			}},
-		}, model.assertActiveOp(),
-		}...)
+		}}...)
 		return ops, nil
 	}
 	if err := st.db().Run(buildTxn); err != nil {
@@ -96,6 +96,44 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *MigrationSuite) TestCreateOffline(c *gc.C) {
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeExporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec := s.stdSpec
+	spec.Offline = true
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Offline(), jc.IsTrue)
+	assertPhase(c, mig, migration.QUIESCE)
+	assertMigrationActive(c, s.State2)
+
+	mig, err = s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Offline(), jc.IsTrue)
+}
+
+func (s *MigrationSuite) TestCreateOfflineNotExported(c *gc.C) {
+	spec := s.stdSpec
+	spec.Offline = true
+	mig, err := s.State2.CreateMigration(spec)
+	c.Check(mig, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "failed to create migration: model has not been exported")
+}
+
+func (s *MigrationSuite) TestCreateExported(c *gc.C) {
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeExporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Check(mig, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "failed to create migration: model has been exported, use an offline migration")
+}
+
 func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check := func(expected bool) {
 		isActive, err := s.State2.IsMigrationActive()
@@ -525,6 +563,73 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestPhaseProgress(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	started := s.Clock.Now()
+
+	s.Clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.PROCESSRELATIONS), jc.ErrorIsNil)
+
+	expected := []migration.PhaseProgress{{
+		Phase:   migration.QUIESCE,
+		Started: started,
+		Ended:   started.Add(time.Minute),
+	}, {
+		Phase:   migration.IMPORT,
+		Started: started.Add(time.Minute),
+		Ended:   started.Add(2 * time.Minute),
+	}, {
+		Phase:   migration.PROCESSRELATIONS,
+		Started: started.Add(2 * time.Minute),
+	}}
+	c.Check(mig.PhaseProgress(), jc.DeepEquals, expected)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.PhaseProgress(), jc.DeepEquals, expected)
+}
+
+func (s *MigrationSuite) TestSetTransferVolume(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	volume := migration.TransferVolume{
+		Model:     10,
+		Charms:    200,
+		Tools:     3000,
+		Resources: 40000,
+	}
+	err = mig.SetTransferVolume(volume)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, m := range []state.ModelMigration{mig, mig2} {
+		progress := m.PhaseProgress()
+		c.Assert(progress, gc.HasLen, 2)
+		c.Check(progress[0].Phase, gc.Equals, migration.QUIESCE)
+		c.Check(progress[0].Sent, gc.Equals, migration.TransferVolume{})
+		c.Check(progress[1].Phase, gc.Equals, migration.IMPORT)
+		c.Check(progress[1].Sent, gc.Equals, volume)
+	}
+}
+
+func (s *MigrationSuite) TestSetTransferVolumePhaseChanged(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig2.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	err = mig.SetTransferVolume(migration.TransferVolume{Model: 10})
+	c.Assert(err, gc.ErrorMatches, "phase already changed")
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
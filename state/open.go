@@ -49,6 +49,12 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// SecretsKey is the base64 encoded key used to encrypt secret
+	// values, as created by NewSecretsKey. It is held in the
+	// controller agent's configuration. Secrets can't be used if
+	// it is empty.
+	SecretsKey string
 }
 
 // Validate validates the OpenParams.
@@ -4,11 +4,13 @@
 package state
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -47,10 +49,43 @@ type Operation interface {
 	// OperationTag returns the operation's tag.
 	OperationTag() names.OperationTag
 
+	// Rolling returns the strategy used to run the operation's tasks
+	// in batches, or nil if the tasks all run at once.
+	Rolling() *RollingStrategy
+
+	// CurrentBatch returns the index of the batch of a rolling
+	// operation whose tasks are able to run.
+	CurrentBatch() int
+
+	// Message returns the reason a rolling operation was stopped
+	// before all of its tasks were run, if it was.
+	Message() string
+
 	// Refresh refreshes the contents of the operation.
 	Refresh() error
 }
 
+// RollingStrategy defines how the tasks of a rolling operation are run.
+// The tasks are split into batches of BatchSize tasks, in the order they
+// were enqueued, and each batch is run once the tasks of the previous
+// batch have finished. If more than MaxFailures tasks fail, the tasks of
+// the remaining batches are cancelled.
+type RollingStrategy struct {
+	BatchSize   int
+	MaxFailures int
+}
+
+// Validate returns an error if the strategy is not valid.
+func (s RollingStrategy) Validate() error {
+	if s.BatchSize < 1 {
+		return errors.NotValidf("batch size %d", s.BatchSize)
+	}
+	if s.MaxFailures < 0 {
+		return errors.NotValidf("max failures %d", s.MaxFailures)
+	}
+	return nil
+}
+
 type operationDoc struct {
 	DocId     string `bson:"_id"`
 	ModelUUID string `bson:"model-uuid"`
@@ -75,6 +110,26 @@ type operationDoc struct {
 	// If not explicitly set, this is derived from the
 	// status of the associated actions.
 	Status ActionStatus `bson:"status"`
+
+	// BatchSize is the number of tasks of a rolling operation run
+	// at a time. It is zero if all the tasks run at once.
+	BatchSize int `bson:"batch-size,omitempty"`
+
+	// MaxFailures is the number of failed tasks a rolling operation
+	// tolerates before the tasks of its remaining batches are cancelled.
+	MaxFailures int `bson:"max-failures,omitempty"`
+
+	// TaskCount is the number of tasks enqueued for a rolling
+	// operation, and is used to assign each new task to its batch.
+	TaskCount int `bson:"task-count,omitempty"`
+
+	// CurrentBatch is the index of the batch of a rolling operation
+	// whose tasks are able to run.
+	CurrentBatch int `bson:"current-batch,omitempty"`
+
+	// Message records why a rolling operation was stopped before
+	// all of its tasks were run.
+	Message string `bson:"message,omitempty"`
 }
 
 // operation represents a group of associated actions.
@@ -140,6 +195,30 @@ func (op *operation) Status() ActionStatus {
 	return op.doc.Status
 }
 
+// Rolling returns the strategy used to run the operation's tasks
+// in batches, or nil if the tasks all run at once.
+func (op *operation) Rolling() *RollingStrategy {
+	if op.doc.BatchSize == 0 {
+		return nil
+	}
+	return &RollingStrategy{
+		BatchSize:   op.doc.BatchSize,
+		MaxFailures: op.doc.MaxFailures,
+	}
+}
+
+// CurrentBatch returns the index of the batch of a rolling
+// operation whose tasks are able to run.
+func (op *operation) CurrentBatch() int {
+	return op.doc.CurrentBatch
+}
+
+// Message returns the reason a rolling operation was stopped before
+// all of its tasks were run, if it was.
+func (op *operation) Message() string {
+	return op.doc.Message
+}
+
 // Refresh refreshes the contents of the operation.
 func (op *operation) Refresh() error {
 	doc, taskStatus, err := op.st.getOperationDoc(op.Id())
@@ -196,6 +275,19 @@ func newOperationDoc(mb modelBackend, summary string) (operationDoc, string, err
 
 // EnqueueOperation records the start of an operation.
 func (m *Model) EnqueueOperation(summary string) (string, error) {
+	return m.enqueueOperation(summary, nil)
+}
+
+// EnqueueRollingOperation records the start of an operation whose
+// tasks are run in batches according to the given strategy.
+func (m *Model) EnqueueRollingOperation(summary string, strategy RollingStrategy) (string, error) {
+	if err := strategy.Validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return m.enqueueOperation(summary, &strategy)
+}
+
+func (m *Model) enqueueOperation(summary string, strategy *RollingStrategy) (string, error) {
 	var operationID string
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		var doc operationDoc
@@ -204,6 +296,10 @@ func (m *Model) EnqueueOperation(summary string) (string, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if strategy != nil {
+			doc.BatchSize = strategy.BatchSize
+			doc.MaxFailures = strategy.MaxFailures
+		}
 
 		ops := []txn.Op{{
 			C:      operationsC,
@@ -410,3 +506,115 @@ func (m *Model) ListOperations(
 	}
 	return result, truncated, nil
 }
+
+// isActionFinished reports whether a task with the given status has
+// finished running.
+func isActionFinished(status ActionStatus) bool {
+	switch status {
+	case ActionPending, ActionRunning, ActionAborting:
+		return false
+	}
+	return true
+}
+
+// updateRollingOperation is called when a task of a rolling operation
+// finishes. Once all the tasks of the current batch have finished, the
+// tasks of the next batch are made available to run; or, if more tasks
+// have failed than the operation tolerates, the tasks of the remaining
+// batches are cancelled.
+func (m *Model) updateRollingOperation(operationID string) error {
+	operations, closer := m.st.db().GetCollection(operationsC)
+	defer closer()
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	var stopped []actionDoc
+	var message string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		stopped = nil
+		var doc operationDoc
+		err := operations.FindId(operationID).One(&doc)
+		if err == mgo.ErrNotFound {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "cannot get operation %q", operationID)
+		}
+		if doc.BatchSize == 0 || doc.Message != "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		var tasks []actionDoc
+		err = actions.Find(bson.D{{"operation", operationID}}).All(&tasks)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get tasks for operation %q", operationID)
+		}
+
+		var failures int
+		batchFinished := true
+		for _, task := range tasks {
+			if task.Status == ActionFailed || task.Status == ActionAborted {
+				failures++
+			}
+			if task.Batch == doc.CurrentBatch && !isActionFinished(task.Status) {
+				batchFinished = false
+			}
+			if task.Batch > doc.CurrentBatch && task.Status == ActionPending {
+				stopped = append(stopped, task)
+			}
+		}
+		if failures > doc.MaxFailures && len(stopped) > 0 {
+			message = fmt.Sprintf("stopped after batch %d: %d of %d tasks failed, more than the %d allowed",
+				doc.CurrentBatch+1, failures, len(tasks), doc.MaxFailures)
+			return []txn.Op{{
+				C:  operationsC,
+				Id: doc.DocId,
+				Assert: bson.D{
+					{"current-batch", doc.CurrentBatch},
+					{"message", bson.D{{"$exists", false}}},
+				},
+				Update: bson.D{{"$set", bson.D{{"message", message}}}},
+			}}, nil
+		}
+		stopped = nil
+
+		// The next batch is only started once the current one is
+		// complete; if it is not yet full, tasks still being enqueued
+		// join the current batch instead.
+		if !batchFinished || doc.TaskCount < (doc.CurrentBatch+1)*doc.BatchSize {
+			return nil, jujutxn.ErrNoOperations
+		}
+		nextBatch := doc.CurrentBatch + 1
+		ops := []txn.Op{{
+			C:  operationsC,
+			Id: doc.DocId,
+			Assert: bson.D{
+				{"current-batch", doc.CurrentBatch},
+				{"task-count", doc.TaskCount},
+			},
+			Update: bson.D{{"$set", bson.D{{"current-batch", nextBatch}}}},
+		}}
+		for _, task := range tasks {
+			if task.Batch != nextBatch || task.Status != ActionPending {
+				continue
+			}
+			ndoc := newActionNotificationDoc(m.st, task.Receiver, m.st.localID(task.DocId))
+			ops = append(ops, txn.Op{
+				C:      actionNotificationsC,
+				Id:     ndoc.DocId,
+				Assert: txn.DocMissing,
+				Insert: ndoc,
+			})
+		}
+		return ops, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range stopped {
+		a := newAction(m.st, doc).(*action)
+		if _, err := a.removeAndLog(ActionCancelled, nil, message); err != nil {
+			return errors.Annotatef(err, "cancelling task %v", a.Id())
+		}
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -270,3 +271,145 @@ func (s *OperationSuite) TestOperationWithActionsNotFound(c *gc.C) {
 	_, err := s.Model.OperationWithActions("1")
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *OperationSuite) TestEnqueueRollingOperation(c *gc.C) {
+	operationID, err := s.Model.EnqueueRollingOperation("an operation", state.RollingStrategy{
+		BatchSize:   2,
+		MaxFailures: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Rolling(), jc.DeepEquals, &state.RollingStrategy{
+		BatchSize:   2,
+		MaxFailures: 1,
+	})
+	c.Assert(operation.CurrentBatch(), gc.Equals, 0)
+	c.Assert(operation.Message(), gc.Equals, "")
+
+	operationID, err = s.Model.EnqueueOperation("another operation")
+	c.Assert(err, jc.ErrorIsNil)
+	operation, err = s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Rolling(), gc.IsNil)
+}
+
+func (s *OperationSuite) TestEnqueueRollingOperationInvalid(c *gc.C) {
+	_, err := s.Model.EnqueueRollingOperation("an operation", state.RollingStrategy{})
+	c.Assert(err, gc.ErrorMatches, "batch size 0 not valid")
+	_, err = s.Model.EnqueueRollingOperation("an operation", state.RollingStrategy{
+		BatchSize:   1,
+		MaxFailures: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "max failures -1 not valid")
+}
+
+func (s *OperationSuite) addRollingUnits(c *gc.C, n int) []*state.Unit {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+	units := make([]*state.Unit, n)
+	for i := range units {
+		var err error
+		units[i], err = application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return units
+}
+
+func (s *OperationSuite) TestRollingOperationBatches(c *gc.C) {
+	units := s.addRollingUnits(c, 3)
+	operationID, err := s.Model.EnqueueRollingOperation("an operation", state.RollingStrategy{BatchSize: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	tasks := make([]state.Action, len(units))
+	for i, u := range units {
+		tasks[i], err = s.Model.EnqueueAction(operationID, u.Tag(), "snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tasks[0].Batch(), gc.Equals, 0)
+	c.Assert(tasks[1].Batch(), gc.Equals, 0)
+	c.Assert(tasks[2].Batch(), gc.Equals, 1)
+
+	// The last unit is not told about its task until the
+	// first batch has finished.
+	w := units[2].WatchPendingActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	_, err = tasks[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	_, err = tasks[1].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(tasks[2].Id())
+	wc.AssertNoChange()
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.CurrentBatch(), gc.Equals, 1)
+	c.Assert(operation.Status(), gc.Equals, state.ActionPending)
+
+	_, err = tasks[2].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = operation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(operation.Message(), gc.Equals, "")
+}
+
+func (s *OperationSuite) TestRollingOperationMaxFailures(c *gc.C) {
+	units := s.addRollingUnits(c, 3)
+	operationID, err := s.Model.EnqueueRollingOperation("an operation", state.RollingStrategy{BatchSize: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	tasks := make([]state.Action, len(units))
+	for i, u := range units {
+		tasks[i], err = s.Model.EnqueueAction(operationID, u.Tag(), "snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	_, err = tasks[0].Finish(state.ActionResults{Status: state.ActionFailed, Message: "oops"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Message(), gc.Equals, "stopped after batch 1: 1 of 3 tasks failed, more than the 0 allowed")
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Completed(), gc.Not(gc.Equals), time.Time{})
+	for _, task := range tasks[1:] {
+		err := task.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(task.Status(), gc.Equals, state.ActionCancelled)
+		_, message := task.Results()
+		c.Check(message, gc.Equals, operation.Message())
+	}
+
+	// No more tasks can be added to the stopped operation.
+	_, err = s.Model.EnqueueAction(operationID, units[0].Tag(), "snapshot", nil)
+	c.Assert(err, gc.ErrorMatches, "operation .* has been stopped: .*")
+}
+
+func (s *OperationSuite) TestRollingOperationToleratesFailures(c *gc.C) {
+	units := s.addRollingUnits(c, 2)
+	operationID, err := s.Model.EnqueueRollingOperation("an operation", state.RollingStrategy{
+		BatchSize:   1,
+		MaxFailures: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tasks := make([]state.Action, len(units))
+	for i, u := range units {
+		tasks[i], err = s.Model.EnqueueAction(operationID, u.Tag(), "snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	_, err = tasks[0].Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Message(), gc.Equals, "")
+	c.Assert(operation.CurrentBatch(), gc.Equals, 1)
+	err = tasks[1].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tasks[1].Status(), gc.Equals, state.ActionPending)
+}
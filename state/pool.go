@@ -159,6 +159,7 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 			return nil, errors.Trace(mongo.MaybeUnauthorizedf(err, "cannot read model %s", args.ControllerModelTag.Id()))
 		}
 	}
+	st.secretsKey = args.SecretsKey
 	if err = st.start(args.ControllerTag, pool.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -259,6 +260,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = p.systemState.secretsKey
 	if err := newSt.start(p.systemState.controllerTag, p.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
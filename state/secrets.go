@@ -0,0 +1,554 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/migration"
+)
+
+// secretsKeySize is the size of the secrets key in bytes, selecting
+// AES-256.
+const secretsKeySize = 32
+
+// Secret represents a secret created by a charm. The secret's value
+// is held encrypted, and is only returned by State.SecretValue.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+type secretDoc struct {
+	DocID       string    `bson:"_id"`
+	ID          string    `bson:"secret-id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	Owner       string    `bson:"owner"`
+	Description string    `bson:"description,omitempty"`
+	Revision    int       `bson:"revision"`
+	CreateTime  time.Time `bson:"create-time"`
+	UpdateTime  time.Time `bson:"update-time"`
+	ExpireTime  time.Time `bson:"expire-time,omitempty"`
+	Consumers   []string  `bson:"consumers,omitempty"`
+
+	// Value holds the nonce followed by the encrypted JSON encoding
+	// of the secret's value.
+	Value []byte `bson:"value"`
+}
+
+// ID returns the secret's ID.
+func (s *Secret) ID() string {
+	return s.doc.ID
+}
+
+// Owner returns the tag of the application owning the secret.
+func (s *Secret) Owner() (names.Tag, error) {
+	return names.ParseTag(s.doc.Owner)
+}
+
+// Description returns the secret's description.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Revision returns the secret's revision, which starts at 1 and is
+// incremented each time its value is updated.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// CreateTime returns when the secret was created.
+func (s *Secret) CreateTime() time.Time {
+	return s.doc.CreateTime.UTC()
+}
+
+// UpdateTime returns when the secret was last updated.
+func (s *Secret) UpdateTime() time.Time {
+	return s.doc.UpdateTime.UTC()
+}
+
+// ExpireTime returns when the secret expires, or the zero time if it
+// doesn't expire.
+func (s *Secret) ExpireTime() time.Time {
+	if s.doc.ExpireTime.IsZero() {
+		return time.Time{}
+	}
+	return s.doc.ExpireTime.UTC()
+}
+
+// Consumers returns the tags of the applications and units, other
+// than the owner, that have been granted access to the secret.
+func (s *Secret) Consumers() ([]names.Tag, error) {
+	result := make([]names.Tag, len(s.doc.Consumers))
+	for i, consumer := range s.doc.Consumers {
+		tag, err := names.ParseTag(consumer)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = tag
+	}
+	return result, nil
+}
+
+// Expired reports whether the secret has expired at the given time.
+func (s *Secret) Expired(now time.Time) bool {
+	return !s.doc.ExpireTime.IsZero() && !now.Before(s.doc.ExpireTime)
+}
+
+// CanRead reports whether the given application or unit may read the
+// secret's value. The owning application and its units may always
+// read it; other applications and units need to have been granted
+// access.
+func (s *Secret) CanRead(entity names.Tag) bool {
+	candidates := []string{entity.String()}
+	if unit, ok := entity.(names.UnitTag); ok {
+		appName, err := names.UnitApplication(unit.Id())
+		if err == nil {
+			candidates = append(candidates, names.NewApplicationTag(appName).String())
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate == s.doc.Owner {
+			return true
+		}
+		for _, consumer := range s.doc.Consumers {
+			if candidate == consumer {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CreateSecretParams holds the details of a secret to create.
+type CreateSecretParams struct {
+	// Owner is the application owning the secret.
+	Owner names.ApplicationTag
+
+	// Description describes the secret.
+	Description string
+
+	// ExpireTime is when the secret expires. The secret doesn't
+	// expire if it is zero.
+	ExpireTime time.Time
+
+	// Value holds the secret's content.
+	Value map[string]string
+}
+
+// UpdateSecretParams holds the changes to make to a secret.
+type UpdateSecretParams struct {
+	// Value, if not nil, replaces the secret's content.
+	Value map[string]string
+
+	// ExpireTime, if not nil, replaces when the secret expires. A
+	// zero time means the secret doesn't expire.
+	ExpireTime *time.Time
+}
+
+// CreateSecret creates a new secret owned by an application, and
+// returns it.
+func (st *State) CreateSecret(args CreateSecretParams) (*Secret, error) {
+	if len(args.Value) == 0 {
+		return nil, errors.NotValidf("empty secret value")
+	}
+	value, err := st.encryptSecretValue(args.Value)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot encrypt secret value")
+	}
+	now := st.clock().Now().UTC().Round(time.Second)
+	id := bson.NewObjectId().Hex()
+	doc := secretDoc{
+		DocID:       st.docID(id),
+		ID:          id,
+		ModelUUID:   st.ModelUUID(),
+		Owner:       args.Owner.String(),
+		Description: args.Description,
+		Revision:    1,
+		CreateTime:  now,
+		UpdateTime:  now,
+		ExpireTime:  args.ExpireTime.UTC(),
+		Value:       value,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		app, err := st.Application(args.Owner.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", app.Name())
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot create secret")
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secret returns the secret with the given ID.
+func (st *State) Secret(id string) (*Secret, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all the secrets in the model.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(nil).Sort("create-time", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// SecretValue returns the decrypted value of the secret with the given
+// ID. An error is returned if the secret has expired.
+func (st *State) SecretValue(id string) (map[string]string, error) {
+	secret, err := st.Secret(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if secret.Expired(st.clock().Now()) {
+		return nil, errors.Errorf("secret %q expired", id)
+	}
+	value, err := st.decryptSecretValue(secret.doc.Value)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot decrypt secret %q", id)
+	}
+	return value, nil
+}
+
+// UpdateSecret updates the value and expiry time of the secret with
+// the given ID. Updating the value increments the secret's revision.
+func (st *State) UpdateSecret(id string, args UpdateSecretParams) error {
+	var value []byte
+	if args.Value != nil {
+		if len(args.Value) == 0 {
+			return errors.NotValidf("empty secret value")
+		}
+		var err error
+		if value, err = st.encryptSecretValue(args.Value); err != nil {
+			return errors.Annotate(err, "cannot encrypt secret value")
+		}
+	}
+	set := bson.D{{"update-time", st.clock().Now().UTC().Round(time.Second)}}
+	if value != nil {
+		set = append(set, bson.DocElem{Name: "value", Value: value})
+	}
+	if args.ExpireTime != nil && !args.ExpireTime.IsZero() {
+		set = append(set, bson.DocElem{Name: "expire-time", Value: args.ExpireTime.UTC()})
+	}
+	update := bson.D{{"$set", set}}
+	if args.ExpireTime != nil && args.ExpireTime.IsZero() {
+		update = append(update, bson.DocElem{Name: "$unset", Value: bson.D{{"expire-time", nil}}})
+	}
+	if value != nil {
+		update = append(update, bson.DocElem{Name: "$inc", Value: bson.D{{"revision", 1}}})
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot update secret %q", id)
+	}
+	return nil
+}
+
+// GrantSecretAccess allows the given application or unit to read the
+// secret with the given ID.
+func (st *State) GrantSecretAccess(id string, consumer names.Tag) error {
+	if err := validateSecretConsumer(consumer); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"consumers", consumer.String()}}}},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot grant access to secret %q", id)
+	}
+	return nil
+}
+
+// RevokeSecretAccess stops the given application or unit from reading
+// the secret with the given ID, unless it owns the secret.
+func (st *State) RevokeSecretAccess(id string, consumer names.Tag) error {
+	if err := validateSecretConsumer(consumer); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"consumers", consumer.String()}}}},
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot revoke access to secret %q", id)
+	}
+	return nil
+}
+
+// ExportSecrets returns the model's secrets with their values
+// decrypted, for migrating them to another controller. Secret values
+// are encrypted with a key held by each controller, so they can't be
+// included in the model description.
+func (st *State) ExportSecrets() ([]migration.SerializedModelSecret, error) {
+	secrets, err := st.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]migration.SerializedModelSecret, len(secrets))
+	for i, secret := range secrets {
+		value, err := st.decryptSecretValue(secret.doc.Value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot decrypt secret %q", secret.doc.ID)
+		}
+		result[i] = migration.SerializedModelSecret{
+			ID:          secret.doc.ID,
+			Owner:       secret.doc.Owner,
+			Description: secret.doc.Description,
+			Revision:    secret.doc.Revision,
+			CreateTime:  secret.doc.CreateTime,
+			UpdateTime:  secret.doc.UpdateTime,
+			ExpireTime:  secret.doc.ExpireTime,
+			Consumers:   secret.doc.Consumers,
+			Value:       value,
+		}
+	}
+	return result, nil
+}
+
+// ImportSecrets adds secrets exported from another controller to the
+// model, encrypting their values with this controller's key. The
+// secrets' owners must already have been imported.
+func (st *State) ImportSecrets(secrets []migration.SerializedModelSecret) error {
+	var ops []txn.Op
+	for _, secret := range secrets {
+		owner, err := names.ParseApplicationTag(secret.Owner)
+		if err != nil {
+			return errors.Annotatef(err, "secret %q owner", secret.ID)
+		}
+		value, err := st.encryptSecretValue(secret.Value)
+		if err != nil {
+			return errors.Annotate(err, "cannot encrypt secret value")
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     st.docID(owner.Id()),
+			Assert: txn.DocExists,
+		}, txn.Op{
+			C:      secretsC,
+			Id:     st.docID(secret.ID),
+			Assert: txn.DocMissing,
+			Insert: &secretDoc{
+				DocID:       st.docID(secret.ID),
+				ID:          secret.ID,
+				ModelUUID:   st.ModelUUID(),
+				Owner:       secret.Owner,
+				Description: secret.Description,
+				Revision:    secret.Revision,
+				CreateTime:  secret.CreateTime.UTC(),
+				UpdateTime:  secret.UpdateTime.UTC(),
+				ExpireTime:  secret.ExpireTime.UTC(),
+				Consumers:   secret.Consumers,
+				Value:       value,
+			},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot import secrets")
+	}
+	return nil
+}
+
+// removeApplicationSecretsOps returns the operations to remove the
+// secrets owned by an application being removed, and to revoke the
+// application's and its units' access to other secrets. No secrets
+// can be created for the application once it's no longer alive.
+func removeApplicationSecretsOps(st *State, appName string) ([]txn.Op, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+
+	owner := names.NewApplicationTag(appName).String()
+	unitConsumer := "^" + regexp.QuoteMeta("unit-"+appName+"-") + "[0-9]+$"
+	var docs []secretDoc
+	err := secrets.Find(bson.D{{"$or", []bson.D{
+		{{"owner", owner}},
+		{{"consumers", owner}},
+		{{"consumers", bson.D{{"$regex", unitConsumer}}}},
+	}}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secrets for application %q", appName)
+	}
+	unitConsumerRE := regexp.MustCompile(unitConsumer)
+	var ops []txn.Op
+	for _, doc := range docs {
+		if doc.Owner == owner {
+			ops = append(ops, txn.Op{
+				C:      secretsC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+			continue
+		}
+		var revoked []string
+		for _, consumer := range doc.Consumers {
+			if consumer == owner || unitConsumerRE.MatchString(consumer) {
+				revoked = append(revoked, consumer)
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$pullAll", bson.D{{"consumers", revoked}}}},
+		})
+	}
+	return ops, nil
+}
+
+func validateSecretConsumer(consumer names.Tag) error {
+	switch consumer.(type) {
+	case names.ApplicationTag, names.UnitTag:
+		return nil
+	}
+	return errors.NotValidf("secret consumer %q", consumer)
+}
+
+// encryptSecretValue returns the JSON encoding of the value, encrypted
+// with the controller's secrets key and prefixed with the nonce used.
+func (st *State) encryptSecretValue(value map[string]string) ([]byte, error) {
+	aead, err := st.secretsCipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decryptSecretValue reverses encryptSecretValue.
+func (st *State) decryptSecretValue(data []byte) (map[string]string, error) {
+	aead, err := st.secretsCipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("secret value too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var value map[string]string
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return value, nil
+}
+
+func (st *State) secretsCipher() (cipher.AEAD, error) {
+	key, err := st.secretsKeyBytes()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewSecretsKey returns a new random key for encrypting secret
+// values, base64 encoded. It is created when the controller is
+// bootstrapped and kept in the controller agents' configuration.
+func NewSecretsKey() (string, error) {
+	key := make([]byte, secretsKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SecretsKey returns the base64 encoded key used to encrypt secret
+// values, as given when the state was opened. Controllers pass it on
+// to the controllers added when enabling HA.
+func (st *State) SecretsKey() string {
+	return st.secretsKey
+}
+
+// secretsKeyBytes returns the key used to encrypt secret values.
+func (st *State) secretsKeyBytes() ([]byte, error) {
+	if st.secretsKey == "" {
+		return nil, errors.NotFoundf("secrets key in controller agent config")
+	}
+	key, err := base64.StdEncoding.DecodeString(st.secretsKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding secrets key")
+	}
+	if len(key) != secretsKeySize {
+		return nil, errors.NotValidf("secrets key with %d bytes", len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,328 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	clock *testclock.Clock
+	owner names.ApplicationTag
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(testing.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.Factory.MakeApplication(c, nil)
+	s.owner = app.ApplicationTag()
+}
+
+func (s *SecretsSuite) createSecret(c *gc.C, expire time.Time) *state.Secret {
+	secret, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner:       s.owner,
+		Description: "database password",
+		ExpireTime:  expire,
+		Value:       map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestCreateSecret(c *gc.C) {
+	created := s.createSecret(c, time.Time{})
+
+	secret, err := s.State.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.ID(), gc.Equals, created.ID())
+	owner, err := secret.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, names.Tag(s.owner))
+	c.Check(secret.Description(), gc.Equals, "database password")
+	c.Check(secret.Revision(), gc.Equals, 1)
+	c.Check(secret.CreateTime(), gc.Equals, s.clock.Now().UTC())
+	c.Check(secret.UpdateTime(), gc.Equals, s.clock.Now().UTC())
+	c.Check(secret.ExpireTime().IsZero(), jc.IsTrue)
+	consumers, err := secret.Consumers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(consumers, gc.HasLen, 0)
+
+	value, err := s.State.SecretValue(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestSecretValueEncrypted(c *gc.C) {
+	secret := s.createSecret(c, time.Time{})
+
+	coll := s.Session.DB("juju").C("secrets")
+	var doc bson.M
+	err := coll.Find(bson.M{"secret-id": secret.ID()}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, ok := doc["value"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(bytes.Contains(stored, []byte("s3cret")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretsKeyNotInDatabase(c *gc.C) {
+	s.createSecret(c, time.Time{})
+
+	// The key is only held in the controller agents' config.
+	n, err := s.Session.DB("juju").C("controllers").FindId("secretsKey").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(n, gc.Equals, 0)
+	c.Check(s.State.SecretsKey(), gc.Equals, testing.SecretsKey)
+}
+
+func (s *SecretsSuite) TestSecretsKeyMissing(c *gc.C) {
+	state.SetSecretsKey(s.State, "")
+	_, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: s.owner,
+		Value: map[string]string{"password": "s3cret"},
+	})
+	c.Check(err, gc.ErrorMatches, "cannot encrypt secret value: cannot get secrets key: secrets key in controller agent config not found")
+}
+
+func (s *SecretsSuite) TestSecretsKeyChanged(c *gc.C) {
+	secret := s.createSecret(c, time.Time{})
+
+	key, err := state.NewSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Not(gc.Equals), testing.SecretsKey)
+	state.SetSecretsKey(s.State, key)
+	_, err = s.State.SecretValue(secret.ID())
+	c.Check(err, gc.ErrorMatches, `cannot decrypt secret ".*": .*message authentication failed`)
+}
+
+func (s *SecretsSuite) TestCreateSecretEmptyValue(c *gc.C) {
+	_, err := s.State.CreateSecret(state.CreateSecretParams{Owner: s.owner})
+	c.Assert(err, gc.ErrorMatches, "empty secret value not valid")
+}
+
+func (s *SecretsSuite) TestCreateSecretMissingOwner(c *gc.C) {
+	_, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: names.NewApplicationTag("missing"),
+		Value: map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot create secret: application "missing" not found`)
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.SecretValue("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestAllSecrets(c *gc.C) {
+	first := s.createSecret(c, time.Time{})
+	s.clock.Advance(time.Minute)
+	second := s.createSecret(c, time.Time{})
+
+	secrets, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 2)
+	c.Check(secrets[0].ID(), gc.Equals, first.ID())
+	c.Check(secrets[1].ID(), gc.Equals, second.ID())
+}
+
+func (s *SecretsSuite) TestUpdateSecret(c *gc.C) {
+	created := s.createSecret(c, time.Time{})
+	s.clock.Advance(time.Minute)
+
+	expire := s.clock.Now().Add(time.Hour)
+	err := s.State.UpdateSecret(created.ID(), state.UpdateSecretParams{
+		Value:      map[string]string{"password": "n3w"},
+		ExpireTime: &expire,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 2)
+	c.Check(secret.UpdateTime(), gc.Equals, s.clock.Now().UTC())
+	c.Check(secret.ExpireTime(), gc.Equals, expire.UTC())
+	value, err := s.State.SecretValue(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+}
+
+func (s *SecretsSuite) TestUpdateSecretExpiryOnly(c *gc.C) {
+	created := s.createSecret(c, s.clock.Now().Add(time.Hour))
+
+	var never time.Time
+	err := s.State.UpdateSecret(created.ID(), state.UpdateSecretParams{
+		ExpireTime: &never,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 1)
+	c.Check(secret.ExpireTime().IsZero(), jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestUpdateSecretNotFound(c *gc.C) {
+	err := s.State.UpdateSecret("missing", state.UpdateSecretParams{
+		Value: map[string]string{"password": "n3w"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretExpired(c *gc.C) {
+	created := s.createSecret(c, s.clock.Now().Add(time.Hour))
+
+	_, err := s.State.SecretValue(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Hour)
+	_, err = s.State.SecretValue(created.ID())
+	c.Assert(err, gc.ErrorMatches, `secret ".*" expired`)
+}
+
+func (s *SecretsSuite) TestGrantRevokeSecretAccess(c *gc.C) {
+	created := s.createSecret(c, time.Time{})
+	ownerUnit := names.NewUnitTag(s.owner.Id() + "/0")
+	consumer := names.NewApplicationTag("wordpress")
+	consumerUnit := names.NewUnitTag("wordpress/1")
+	otherUnit := names.NewUnitTag("mysql/0")
+
+	secret, err := s.State.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(s.owner), jc.IsTrue)
+	c.Check(secret.CanRead(ownerUnit), jc.IsTrue)
+	c.Check(secret.CanRead(consumerUnit), jc.IsFalse)
+
+	err = s.State.GrantSecretAccess(created.ID(), consumer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.GrantSecretAccess(created.ID(), otherUnit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err = s.State.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	consumers, err := secret.Consumers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(consumers, jc.DeepEquals, []names.Tag{consumer, otherUnit})
+	c.Check(secret.CanRead(consumerUnit), jc.IsTrue)
+	c.Check(secret.CanRead(otherUnit), jc.IsTrue)
+	c.Check(secret.CanRead(names.NewUnitTag("mysql/1")), jc.IsFalse)
+
+	err = s.State.RevokeSecretAccess(created.ID(), consumer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err = s.State.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(consumerUnit), jc.IsFalse)
+	c.Check(secret.CanRead(otherUnit), jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestGrantSecretAccessInvalidConsumer(c *gc.C) {
+	created := s.createSecret(c, time.Time{})
+	err := s.State.GrantSecretAccess(created.ID(), names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, `secret consumer "machine-0" not valid`)
+}
+
+func (s *SecretsSuite) TestGrantSecretAccessNotFound(c *gc.C) {
+	err := s.State.GrantSecretAccess("missing", names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RevokeSecretAccess("missing", names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestExportImportSecrets(c *gc.C) {
+	created := s.createSecret(c, s.clock.Now().Add(time.Hour))
+	err := s.State.GrantSecretAccess(created.ID(), names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	exported, err := s.State.ExportSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exported, gc.HasLen, 1)
+	c.Check(exported[0].Owner, gc.Equals, s.owner.String())
+	c.Check(exported[0].Consumers, jc.DeepEquals, []string{"application-wordpress"})
+	c.Check(exported[0].Value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	f.MakeApplication(c, &factory.ApplicationParams{Name: s.owner.Id()})
+
+	// The target controller encrypts values with its own key.
+	key, err := state.NewSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	state.SetSecretsKey(st, key)
+	err = st.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.ImportSecrets(exported)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := st.Secret(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Description(), gc.Equals, "database password")
+	c.Check(secret.Revision(), gc.Equals, 1)
+	c.Check(secret.CreateTime(), gc.Equals, created.CreateTime())
+	c.Check(secret.ExpireTime(), gc.Equals, created.ExpireTime())
+	c.Check(secret.CanRead(names.NewUnitTag("wordpress/0")), jc.IsTrue)
+	value, err := st.SecretValue(created.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestImportSecretsMissingOwner(c *gc.C) {
+	s.createSecret(c, time.Time{})
+	exported, err := s.State.ExportSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err = st.ImportSecrets(exported)
+	c.Assert(err, gc.ErrorMatches, "cannot import secrets: transaction aborted")
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	owned := s.createSecret(c, time.Time{})
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	consumed, err := s.State.CreateSecret(state.CreateSecretParams{
+		Owner: other.ApplicationTag(),
+		Value: map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, consumer := range []names.Tag{
+		s.owner,
+		names.NewUnitTag(s.owner.Id() + "/0"),
+		names.NewApplicationTag(s.owner.Id() + "-two"),
+	} {
+		err = s.State.GrantSecretAccess(consumed.ID(), consumer)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	app, err := s.State.Application(s.owner.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(owned.ID())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	secret, err := s.State.Secret(consumed.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	consumers, err := secret.Consumers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(consumers, jc.DeepEquals, []names.Tag{names.NewApplicationTag(s.owner.Id() + "-two")})
+}
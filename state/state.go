@@ -88,6 +88,11 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// secretsKey is the base64 encoded key used to encrypt secret
+	// values. It is kept in the controller agents' configuration,
+	// never in the database.
+	secretsKey string
+
 	// leaseStoreId is used by the lease infrastructure to
 	// differentiate between machines whose clocks may be
 	// relatively-skewed.
@@ -138,6 +143,7 @@ func (st *State) newStateNoWorkers(modelUUID string) (*State, error) {
 		session.Close()
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = st.secretsKey
 	return newSt, nil
 }
 
@@ -69,6 +69,7 @@ func InitializeWithArgs(c *gc.C, args InitializeArgs) *state.Controller {
 	ctlr, err := state.Initialize(state.InitializeParams{
 		Clock:            args.Clock,
 		ControllerConfig: controllerCfg,
+		SecretsKey:       testing.SecretsKey,
 		ControllerModelArgs: state.ModelArgs{
 			Type:        state.ModelTypeIAAS,
 			CloudName:   "dummy",
@@ -107,6 +107,9 @@ func (op *unitSetStateOperation) newUnitStateDoc(unitGlobalKey string) unitState
 	if storState, found := op.newState.StorageState(); found {
 		newStDoc.StorageState = storState
 	}
+	if history, found := op.newState.HookHistory(); found {
+		newStDoc.HookHistory = history
+	}
 	return newStDoc
 }
 
@@ -159,6 +162,14 @@ func (op *unitSetStateOperation) fields(currentDoc unitStateDoc) (bson.D, bson.D
 		}
 	}
 
+	if history, found := op.newState.HookHistory(); found {
+		if history == "" {
+			unsetFields = append(unsetFields, bson.DocElem{Name: "hook-history"})
+		} else if history != currentDoc.HookHistory {
+			setFields = append(setFields, bson.DocElem{"hook-history", history})
+		}
+	}
+
 	return setFields, unsetFields
 }
 
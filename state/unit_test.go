@@ -174,6 +174,41 @@ func (s *UnitSuite) TestUnitStateMutateStorageState(c *gc.C) {
 	assertUnitStateRelationState(c, uState, initialRelationState)
 }
 
+func (s *UnitSuite) TestUnitStateMutateHookHistory(c *gc.C) {
+	// Set initial state; this should create a new unitstate doc
+	initialState, initialUniterState, initialRelationState, initialStorageState := s.testUnitSuite(c)
+
+	// Set the hook history with an existing state doc
+	newHistory := "- kind: install"
+	newUS := state.NewUnitState()
+	newUS.SetHookHistory(newHistory)
+	err := s.unit.SetState(newUS)
+	c.Assert(err, gc.IsNil)
+
+	// Ensure the hook history changed
+	uState, err := s.unit.State()
+	c.Assert(err, gc.IsNil)
+	obtained, found := uState.HookHistory()
+	c.Assert(found, jc.IsTrue)
+	c.Assert(obtained, gc.Equals, newHistory)
+
+	// Ensure the other state did not.
+	assertUnitStateState(c, uState, initialState)
+	assertUnitStateUniterState(c, uState, initialUniterState)
+	assertUnitStateRelationState(c, uState, initialRelationState)
+	assertUnitStateStorageState(c, uState, initialStorageState)
+
+	// Ensure an empty history removes it.
+	newUS = state.NewUnitState()
+	newUS.SetHookHistory("")
+	err = s.unit.SetState(newUS)
+	c.Assert(err, gc.IsNil)
+	uState, err = s.unit.State()
+	c.Assert(err, gc.IsNil)
+	obtained, _ = uState.HookHistory()
+	c.Assert(obtained, gc.Equals, "")
+}
+
 func (s *UnitSuite) TestUnitStateDeleteState(c *gc.C) {
 	// Set initial state; this should create a new unitstate doc
 	_, initialUniterState, initialRelationState, initialStorageState := s.testUnitSuite(c)
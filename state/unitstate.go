@@ -36,6 +36,10 @@ type unitStateDoc struct {
 	// StorageState is a serialized yaml string containing storage internal
 	// state for this unit from the uniter.
 	StorageState string `bson:"storage-state,omitempty"`
+
+	// HookHistory is a serialized yaml string containing the most recent
+	// hook and action executions recorded by the uniter for this unit.
+	HookHistory string `bson:"hook-history,omitempty"`
 }
 
 // stateMatches returns true if the State map within the unitStateDoc matches
@@ -117,6 +121,11 @@ type UnitState struct {
 	// state for this unit from the uniter.
 	storageState    string
 	storageStateSet bool
+
+	// hookHistory is a serialized yaml string containing the most recent
+	// hook and action executions recorded by the uniter for this unit.
+	hookHistory    string
+	hookHistorySet bool
 }
 
 // NewUnitState returns a new UnitState struct.
@@ -126,7 +135,7 @@ func NewUnitState() *UnitState {
 
 // Modified returns true if any of the struct have been set.
 func (u *UnitState) Modified() bool {
-	return u.relationStateSet || u.storageStateSet || u.stateSet || u.uniterStateSet
+	return u.relationStateSet || u.storageStateSet || u.stateSet || u.uniterStateSet || u.hookHistorySet
 }
 
 // SetState sets the state value.
@@ -187,6 +196,18 @@ func (u *UnitState) StorageState() (string, bool) {
 	return u.storageState, u.storageStateSet
 }
 
+// SetHookHistory sets the hook history value.
+func (u *UnitState) SetHookHistory(history string) {
+	u.hookHistorySet = true
+	u.hookHistory = history
+}
+
+// HookHistory returns the hook history and bool indicating
+// whether the data was set.
+func (u *UnitState) HookHistory() (string, bool) {
+	return u.hookHistory, u.hookHistorySet
+}
+
 // SetState replaces the currently stored state for a unit with the contents
 // of the provided UnitState.
 //
@@ -244,6 +265,7 @@ func (u *Unit) State() (*UnitState, error) {
 
 	us.SetUniterState(stDoc.UniterState)
 	us.SetStorageState(stDoc.StorageState)
+	us.SetHookHistory(stDoc.HookHistory)
 
 	return us, nil
 }
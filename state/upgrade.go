@@ -89,6 +89,11 @@ type upgradeInfoDoc struct {
 	Started          time.Time      `bson:"started"`
 	ControllersReady []string       `bson:"controllersReady"`
 	ControllersDone  []string       `bson:"controllersDone"`
+
+	// SecretsKey is the secrets key the controllers agree on while
+	// upgrading to a version which keeps the key in the controller
+	// agent config. It is never archived.
+	SecretsKey string `bson:"secretsKey,omitempty"`
 }
 
 // UpgradeInfo is used to synchronise controller upgrades.
@@ -493,6 +498,7 @@ func (info *UpgradeInfo) Abort() error {
 func (info *UpgradeInfo) makeArchiveOps(doc *upgradeInfoDoc, status UpgradeStatus) []txn.Op {
 	doc.Status = status
 	doc.Id = bson.NewObjectId().String() // change id to archive value
+	doc.SecretsKey = ""
 	return []txn.Op{{
 		C:      upgradeInfoC,
 		Id:     currentUpgradeId,
@@ -506,6 +512,39 @@ func (info *UpgradeInfo) makeArchiveOps(doc *upgradeInfoDoc, status UpgradeStatu
 	}}
 }
 
+// EnsureUpgradeSecretsKey records key as the key used to encrypt
+// secret values by every controller, unless another controller has
+// already recorded one during the current upgrade, and returns the
+// recorded key. The key is only held in the database until the
+// upgrade completes or is aborted.
+func (st *State) EnsureUpgradeSecretsKey(key string) (string, error) {
+	if key == "" {
+		return "", errors.NotValidf("empty secrets key")
+	}
+	var shared string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := currentUpgradeInfoDoc(st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.SecretsKey != "" {
+			shared = doc.SecretsKey
+			return nil, jujutxn.ErrNoOperations
+		}
+		shared = key
+		return []txn.Op{{
+			C:      upgradeInfoC,
+			Id:     currentUpgradeId,
+			Assert: bson.D{{"secretsKey", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"secretsKey", key}}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return "", errors.Annotate(err, "cannot share secrets key")
+	}
+	return shared, nil
+}
+
 // IsUpgrading returns true if an upgrade is currently in progress.
 func (st *State) IsUpgrading() (bool, error) {
 	doc, err := currentUpgradeInfoDoc(st)
@@ -634,6 +634,59 @@ func (s *UpgradeSuite) TestAbortRace(c *gc.C) {
 	s.checkUpgradeInfoArchived(c, info, state.UpgradeAborted, 0)
 }
 
+func (s *UpgradeSuite) TestEnsureUpgradeSecretsKey(c *gc.C) {
+	serverIdB, _ := s.addControllers(c)
+	s.provision(c, serverIdB)
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnsureUpgradeInfo(serverIdB, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	key, err := s.State.EnsureUpgradeSecretsKey("first")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Equals, "first")
+
+	// Other controllers get the key recorded first.
+	key, err = s.State.EnsureUpgradeSecretsKey("second")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Equals, "first")
+
+	// The key is not kept once the upgrade is done.
+	s.setToFinishing(c, info)
+	c.Assert(info.SetControllerDone(s.serverIdA), jc.ErrorIsNil)
+	c.Assert(info.SetControllerDone(serverIdB), jc.ErrorIsNil)
+	c.Assert(state.UpgradeInfoSecretsKey(s.getOneUpgradeInfo(c)), gc.Equals, "")
+}
+
+func (s *UpgradeSuite) TestEnsureUpgradeSecretsKeyRace(c *gc.C) {
+	_, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		key, err := s.State.EnsureUpgradeSecretsKey("first")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(key, gc.Equals, "first")
+	}).Check()
+	key, err := s.State.EnsureUpgradeSecretsKey("second")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, gc.Equals, "first")
+}
+
+func (s *UpgradeSuite) TestEnsureUpgradeSecretsKeyAborted(c *gc.C) {
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnsureUpgradeSecretsKey("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(info.Abort(), jc.ErrorIsNil)
+	c.Assert(state.UpgradeInfoSecretsKey(s.getOneUpgradeInfo(c)), gc.Equals, "")
+}
+
+func (s *UpgradeSuite) TestEnsureUpgradeSecretsKeyNotUpgrading(c *gc.C) {
+	_, err := s.State.EnsureUpgradeSecretsKey("first")
+	c.Assert(err, gc.ErrorMatches, "cannot share secrets key: current upgrade info not found")
+}
+
 func (s *UpgradeSuite) checkUpgradeInfoArchived(
 	c *gc.C,
 	initialInfo *state.UpgradeInfo,
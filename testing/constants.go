@@ -21,6 +21,9 @@ const ShortWait = 50 * time.Millisecond
 // test suite
 const LongWait = 10 * time.Second
 
+// SecretsKey is the key used to encrypt charm secrets in tests.
+const SecretsKey = "bXktdGVzdC1zZWNyZXRzLWtleS1mb3ItdW5pdC10ZXM="
+
 // TODO(katco): 2016-08-09: lp:1611427
 var LongAttempt = &utils.AttemptStrategy{
 	Total: LongWait,
@@ -79,6 +79,7 @@ type StateBackend interface {
 	IncrementTasksSequence() error
 	AddMachineIDToSubordinates() error
 	DropPresenceDatabase() error
+	EnsureUpgradeSecretsKey(string) (string, error)
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -323,3 +324,7 @@ func (s stateBackend) AddMachineIDToSubordinates() error {
 func (s stateBackend) DropPresenceDatabase() error {
 	return state.DropPresenceDatabase(s.pool)
 }
+
+func (s stateBackend) EnsureUpgradeSecretsKey(key string) (string, error) {
+	return s.pool.SystemState().EnsureUpgradeSecretsKey(key)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/api/upgradesteps"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/service"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common/reboot"
 	"github.com/juju/juju/worker/uniter/operation"
 )
@@ -46,7 +47,44 @@ func stateStepsFor28() []Step {
 				return context.State().AddMachineIDToSubordinates()
 			},
 		},
+		&upgradeStep{
+			description: "add secrets key to controller agent config",
+			targets:     []Target{Controller},
+			run:         AddSecretsKey,
+		},
+	}
+}
+
+// AddSecretsKey writes the key used to encrypt charm secrets into the
+// controller agent config. The key is not kept in the database, so
+// the controllers agree on it through the upgrade info: whichever
+// controller gets there first generates it.
+func AddSecretsKey(context Context) error {
+	agentConfig := context.AgentConfig()
+	info, ok := agentConfig.StateServingInfo()
+	if !ok {
+		return errors.New("no state serving info in agent config")
+	}
+	key := info.SecretsKey
+	if key == "" {
+		var err error
+		if key, err = state.NewSecretsKey(); err != nil {
+			return errors.Trace(err)
+		}
 	}
+	shared, err := context.State().EnsureUpgradeSecretsKey(key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.SecretsKey != "" {
+		if info.SecretsKey != shared {
+			return errors.New("secrets key in agent config does not match the other controllers")
+		}
+		return nil
+	}
+	info.SecretsKey = shared
+	agentConfig.SetStateServingInfo(info)
+	return nil
 }
 
 // stepsFor28 returns upgrade steps for Juju 2.8.0.
@@ -4,6 +4,7 @@
 package upgrades_test
 
 import (
+	"encoding/base64"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
 	apicallermocks "github.com/juju/juju/api/base/mocks"
+	"github.com/juju/juju/apiserver/params"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/upgrades"
@@ -46,6 +48,70 @@ func (s *steps28Suite) TestAddMachineIDToSubordinates(c *gc.C) {
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
 
+func (s *steps28Suite) TestAddSecretsKeyStep(c *gc.C) {
+	step := findStateStep(c, v280, "add secrets key to controller agent config")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.Controller})
+}
+
+func (s *steps28Suite) TestAddSecretsKey(c *gc.C) {
+	backend := &secretsKeyBackend{}
+	primary := &mockContext{agentConfig: &mockAgentConfig{}, state: backend}
+	secondary := &mockContext{agentConfig: &mockAgentConfig{}, state: backend}
+
+	err := upgrades.AddSecretsKey(primary)
+	c.Assert(err, jc.ErrorIsNil)
+	key := primary.agentConfig.servingInfo.SecretsKey
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decoded, gc.HasLen, 32)
+
+	// Every controller ends up with the same key.
+	err = upgrades.AddSecretsKey(secondary)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secondary.agentConfig.servingInfo.SecretsKey, gc.Equals, key)
+
+	// The step is idempotent.
+	err = upgrades.AddSecretsKey(primary)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(primary.agentConfig.servingInfo.SecretsKey, gc.Equals, key)
+}
+
+func (s *steps28Suite) TestAddSecretsKeyShareExisting(c *gc.C) {
+	backend := &secretsKeyBackend{}
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{servingInfo: params.StateServingInfo{SecretsKey: "existing"}},
+		state:       backend,
+	}
+	err := upgrades.AddSecretsKey(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.agentConfig.servingInfo.SecretsKey, gc.Equals, "existing")
+	c.Assert(backend.shared, gc.Equals, "existing")
+}
+
+func (s *steps28Suite) TestAddSecretsKeyMismatch(c *gc.C) {
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{servingInfo: params.StateServingInfo{SecretsKey: "existing"}},
+		state:       &secretsKeyBackend{shared: "other"},
+	}
+	err := upgrades.AddSecretsKey(context)
+	c.Assert(err, gc.ErrorMatches, "secrets key in agent config does not match the other controllers")
+	c.Assert(context.agentConfig.servingInfo.SecretsKey, gc.Equals, "existing")
+}
+
+// secretsKeyBackend shares the first secrets key it is given, as the
+// upgrade info does.
+type secretsKeyBackend struct {
+	upgrades.StateBackend
+	shared string
+}
+
+func (b *secretsKeyBackend) EnsureUpgradeSecretsKey(key string) (string, error) {
+	if b.shared == "" {
+		b.shared = key
+	}
+	return b.shared, nil
+}
+
 func (s *steps28Suite) TestPopulateRebootHandledFlagsForDeployedUnits(c *gc.C) {
 	step := findStep(c, v280, "ensure currently running units do not fire start hooks thinking a reboot has occurred")
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.HostMachine})
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	config actionscheduler.ManifoldConfig
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		Clock:         testclock.NewClock(epoch),
+		Logger:        loggo.GetLogger("test"),
+		NewFacade: func(base.APICaller) actionscheduler.Facade {
+			return &fakeFacade{}
+		},
+		NewWorker: func(actionscheduler.Config) (worker.Worker, error) {
+			return nil, errors.New("unexpected")
+		},
+	}
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	manifold := actionscheduler.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, []string{"api-caller"})
+}
+
+func (s *manifoldSuite) TestValidate(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+	for i, test := range []struct {
+		f      func(*actionscheduler.ManifoldConfig)
+		expect string
+	}{{
+		f:      func(config *actionscheduler.ManifoldConfig) { config.APICallerName = "" },
+		expect: "empty APICallerName not valid",
+	}, {
+		f:      func(config *actionscheduler.ManifoldConfig) { config.Clock = nil },
+		expect: "nil Clock not valid",
+	}, {
+		f:      func(config *actionscheduler.ManifoldConfig) { config.Logger = nil },
+		expect: "nil Logger not valid",
+	}, {
+		f:      func(config *actionscheduler.ManifoldConfig) { config.NewFacade = nil },
+		expect: "nil NewFacade not valid",
+	}, {
+		f:      func(config *actionscheduler.ManifoldConfig) { config.NewWorker = nil },
+		expect: "nil NewWorker not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.f(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *manifoldSuite) TestMissingAPICaller(c *gc.C) {
+	manifold := actionscheduler.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *manifoldSuite) TestStart(c *gc.C) {
+	facade := &fakeFacade{}
+	var apiCaller struct{ base.APICaller }
+	s.config.NewFacade = func(caller base.APICaller) actionscheduler.Facade {
+		c.Check(caller, gc.Equals, &apiCaller)
+		return facade
+	}
+	expectWorker := workertest.NewErrorWorker(nil)
+	s.config.NewWorker = func(config actionscheduler.Config) (worker.Worker, error) {
+		c.Check(config.Facade, gc.Equals, facade)
+		c.Check(config.Clock, gc.Equals, s.config.Clock)
+		c.Check(config.Logger, gc.Equals, s.config.Logger)
+		return expectWorker, nil
+	}
+	manifold := actionscheduler.Manifold(s.config)
+	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &apiCaller,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWorker)
+	workertest.CleanKill(c, w)
+}
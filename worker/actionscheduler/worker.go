@@ -0,0 +1,216 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+	"gopkg.in/robfig/cron.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// MissedRunGrace is how late the scheduler may be in running a
+// scheduled action before the run is recorded as missed instead. Runs
+// are late when the controller was unavailable at the scheduled time.
+const MissedRunGrace = 10 * time.Minute
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade provides access to the model's action schedules.
+type Facade interface {
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+	ActionSchedules() ([]params.ActionSchedule, error)
+	RecordRuns([]params.ActionScheduleRunArg) error
+}
+
+// Config holds the dependencies and configuration for an action
+// scheduler worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot be expected to
+// run an action scheduler.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which enqueues the actions of the model's
+// action schedules at their scheduled times.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type scheduler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *scheduler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *scheduler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *scheduler) loop() error {
+	watcher, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		timer   clock.Timer
+		timeout <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("action schedules watcher closed")
+			}
+		case <-timeout:
+		}
+		next, err := w.runDue()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if !next.IsZero() {
+			timer = w.config.Clock.NewTimer(next.Sub(w.config.Clock.Now()))
+			timeout = timer.Chan()
+		}
+	}
+}
+
+// runDue runs, or records as missed, every scheduled run that is due,
+// and returns the time at which the next run is due. It returns the
+// zero time if there are no schedules.
+func (w *scheduler) runDue() (time.Time, error) {
+	schedules, err := w.config.Facade.ActionSchedules()
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "getting action schedules")
+	}
+	// Schedules are interpreted in UTC unless they specify a time
+	// zone.
+	now := w.config.Clock.Now().UTC()
+	var (
+		runs []params.ActionScheduleRunArg
+		next time.Time
+	)
+	for _, s := range schedules {
+		schedule, err := cron.Parse(s.Schedule)
+		if err != nil {
+			w.config.Logger.Errorf("cannot parse schedule %q of action schedule %s: %v", s.Schedule, s.Id, err)
+			continue
+		}
+		count, previous, latest := dueTimes(schedule, lastScheduled(s), now)
+		if count > 0 {
+			// Only the latest due run is attempted, and only if it
+			// is not too late; any others were missed.
+			run := now.Sub(latest) <= MissedRunGrace
+			missed, lastMissed := count, latest
+			if run {
+				missed, lastMissed = count-1, previous
+			}
+			if missed > 0 {
+				runs = append(runs, params.ActionScheduleRunArg{
+					Id:        s.Id,
+					Scheduled: lastMissed,
+					Missed:    missed,
+				})
+				w.config.Logger.Debugf("action schedule %s missed %d runs", s.Id, missed)
+			}
+			if run {
+				runs = append(runs, params.ActionScheduleRunArg{
+					Id:        s.Id,
+					Scheduled: latest,
+				})
+				w.config.Logger.Debugf("running action schedule %s scheduled at %s", s.Id, latest)
+			}
+		}
+		if n := schedule.Next(now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	if len(runs) > 0 {
+		// A failure to run one schedule must not hold up the others,
+		// so the error is only logged. Runs that were not recorded
+		// are retried when the schedules next change, or recorded as
+		// missed once they are too late.
+		if err := w.config.Facade.RecordRuns(runs); err != nil {
+			w.config.Logger.Errorf("cannot record scheduled action runs: %v", err)
+		}
+	}
+	return next, nil
+}
+
+// lastScheduled returns the scheduled time of the most recent run of
+// the schedule, or the time it was created if it has never run.
+func lastScheduled(s params.ActionSchedule) time.Time {
+	if len(s.Runs) == 0 {
+		return s.Created
+	}
+	return s.Runs[len(s.Runs)-1].Scheduled
+}
+
+// dueTimes returns the number of times after last, and no later than
+// now, at which the schedule was due to run, along with the latest two
+// of those times.
+func dueTimes(schedule cron.Schedule, last, now time.Time) (count int, previous, latest time.Time) {
+	for t := schedule.Next(last); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		count++
+		previous, latest = latest, t
+	}
+	return count, previous, latest
+}
@@ -0,0 +1,269 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+var epoch = time.Date(2020, 5, 1, 2, 30, 0, 0, time.UTC)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	changes chan struct{}
+	facade  *fakeFacade
+	config  actionscheduler.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(epoch)
+	s.changes = make(chan struct{}, 1)
+	s.facade = &fakeFacade{
+		changes:  s.changes,
+		recorded: make(chan []params.ActionScheduleRunArg, 10),
+		schedules: []params.ActionSchedule{{
+			Id:       "1",
+			Schedule: "0 3 * * *",
+			Created:  time.Date(2020, 5, 1, 1, 0, 0, 0, time.UTC),
+		}},
+	}
+	s.config = actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(config *actionscheduler.Config) {
+		config.Facade = nil
+	}, "nil Facade not valid")
+	s.testValidate(c, func(config *actionscheduler.Config) {
+		config.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(config *actionscheduler.Config) {
+		config.Logger = nil
+	}, "nil Logger not valid")
+}
+
+func (s *workerSuite) testValidate(c *gc.C, f func(*actionscheduler.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := actionscheduler.NewWorker(config)
+	if !c.Check(err, gc.ErrorMatches, expect) {
+		workertest.DirtyKill(c, w)
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.changes <- struct{}{}
+}
+
+func (s *workerSuite) waitRecorded(c *gc.C) []params.ActionScheduleRunArg {
+	select {
+	case runs := <-s.facade.recorded:
+		return runs
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for runs to be recorded")
+	}
+	panic("unreachable")
+}
+
+func (s *workerSuite) TestRunsOnSchedule(c *gc.C) {
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+	}})
+
+	// The next run is a day later.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *workerSuite) TestNoSchedules(c *gc.C) {
+	s.facade.schedules = nil
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(24*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.NotNil)
+	select {
+	case runs := <-s.facade.recorded:
+		c.Fatalf("unexpected runs recorded: %v", runs)
+	default:
+	}
+}
+
+func (s *workerSuite) TestScheduleAdded(c *gc.C) {
+	s.facade.schedules = nil
+	s.startWorker(c)
+
+	s.facade.setSchedules([]params.ActionSchedule{{
+		Id:       "2",
+		Schedule: "0 * * * *",
+		Created:  epoch,
+	}})
+	s.changes <- struct{}{}
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "2",
+		Scheduled: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *workerSuite) TestLateRunWithinGrace(c *gc.C) {
+	// The controller was down at 03:00, but came back soon enough
+	// for the run to go ahead.
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 3, 5, 0, 0, time.UTC))
+	s.config.Clock = s.clock
+	s.startWorker(c)
+
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *workerSuite) TestMissedRuns(c *gc.C) {
+	// The controller was down for the runs on the 1st, 2nd and 3rd,
+	// and came back too late for the last of them.
+	s.clock = testclock.NewClock(time.Date(2020, 5, 3, 4, 0, 0, 0, time.UTC))
+	s.config.Clock = s.clock
+	s.startWorker(c)
+
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 3, 3, 0, 0, 0, time.UTC),
+		Missed:    3,
+	}})
+}
+
+func (s *workerSuite) TestMissedRunsThenRun(c *gc.C) {
+	s.facade.schedules[0].Runs = []params.ActionScheduleRun{{
+		Scheduled: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		Status:    "enqueued",
+	}}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 4, 3, 1, 0, 0, time.UTC))
+	s.config.Clock = s.clock
+	s.startWorker(c)
+
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 3, 3, 0, 0, 0, time.UTC),
+		Missed:    2,
+	}, {
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 4, 3, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *workerSuite) TestRecordRunsErrorNotFatal(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitRecorded(c)
+
+	// The worker keeps running, and tries again on schedule.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitRecorded(c)
+}
+
+func (s *workerSuite) TestInvalidScheduleSkipped(c *gc.C) {
+	s.facade.schedules = append([]params.ActionSchedule{{
+		Id:       "9",
+		Schedule: "every night",
+		Created:  epoch,
+	}}, s.facade.schedules...)
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitRecorded(c), jc.DeepEquals, []params.ActionScheduleRunArg{{
+		Id:        "1",
+		Scheduled: time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+	}})
+}
+
+// fakeFacade records runs in the schedules it returns, as the
+// ActionScheduler facade does.
+type fakeFacade struct {
+	testing.Stub
+
+	mu        sync.Mutex
+	schedules []params.ActionSchedule
+	changes   chan struct{}
+	recorded  chan []params.ActionScheduleRunArg
+}
+
+func (f *fakeFacade) setSchedules(schedules []params.ActionSchedule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedules = schedules
+}
+
+func (f *fakeFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) ActionSchedules() ([]params.ActionSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make([]params.ActionSchedule, len(f.schedules))
+	copy(result, f.schedules)
+	return result, nil
+}
+
+func (f *fakeFacade) RecordRuns(runs []params.ActionScheduleRunArg) error {
+	f.MethodCall(f, "RecordRuns", runs)
+	defer func() { f.recorded <- runs }()
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, run := range runs {
+		for i, s := range f.schedules {
+			if s.Id == run.Id {
+				f.schedules[i].Runs = append(s.Runs, params.ActionScheduleRun{
+					Scheduled: run.Scheduled,
+				})
+			}
+		}
+	}
+	return nil
+}
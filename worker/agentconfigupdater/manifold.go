@@ -111,6 +111,11 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 					// apiState.
 					info.Cert = existing.Cert
 					info.PrivateKey = existing.PrivateKey
+					// Don't lose the secrets key if the controller
+					// serving the API doesn't have one.
+					if info.SecretsKey == "" {
+						info.SecretsKey = existing.SecretsKey
+					}
 				}
 				config.SetStateServingInfo(info)
 				if mongoProfileChanged {
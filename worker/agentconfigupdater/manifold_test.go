@@ -293,6 +293,25 @@ func (s *AgentConfigUpdaterSuite) TestJobManageEnvironNotOverwriteCert(c *gc.C)
 	c.Assert(a.conf.ssi.PrivateKey, gc.Equals, existingKey)
 }
 
+func (s *AgentConfigUpdaterSuite) TestJobManageEnvironNotOverwriteSecretsKey(c *gc.C) {
+	// The API doesn't return a secrets key, so the one already in
+	// the agent config is kept.
+	a := &mockAgent{}
+	a.conf.SetStateServingInfo(params.StateServingInfo{
+		Cert:       "cert",
+		PrivateKey: "key",
+		SecretsKey: "secrets key",
+	})
+
+	w, err := s.startManifold(c, a, 1234)
+	c.Assert(w, gc.NotNil)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+
+	c.Assert(a.conf.ssiSet, jc.IsTrue)
+	c.Assert(a.conf.ssi.SecretsKey, gc.Equals, "secrets key")
+}
+
 func (s *AgentConfigUpdaterSuite) TestJobHostUnits(c *gc.C) {
 	// State serving info should not be set for JobHostUnits.
 	s.checkNotController(c, model.JobHostUnits)
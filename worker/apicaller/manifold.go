@@ -60,6 +60,9 @@ type ManifoldConfig struct {
 
 	// Logger is used to write logging statements for the worker.
 	Logger Logger
+
+	// Metrics, if not nil, records the state of the connection.
+	Metrics *Metrics
 }
 
 // Manifold returns a manifold whose worker wraps an API connection
@@ -93,11 +96,13 @@ func (config ManifoldConfig) startFunc() dependency.StartFunc {
 		if errors.Cause(err) == ErrChangedPassword {
 			return nil, dependency.ErrBounce
 		} else if err != nil {
+			config.Metrics.connectionFailed()
 			cfg := agent.CurrentConfig()
 			return nil, errors.Annotatef(err, "[%s] %q cannot open api",
 				shortModelUUID(cfg.Model()), cfg.Tag().String())
 		}
-		return newAPIConnWorker(conn), nil
+		config.Metrics.connectionOpened()
+		return newAPIConnWorker(conn, config.Metrics), nil
 	}
 }
 
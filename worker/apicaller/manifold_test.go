@@ -4,10 +4,13 @@
 package apicaller_test
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
@@ -128,6 +131,38 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	}})
 }
 
+func (s *ManifoldSuite) TestMetrics(c *gc.C) {
+	metrics := apicaller.NewMetrics()
+	s.manifoldConfig.Metrics = metrics
+	s.manifold = apicaller.Manifold(s.manifoldConfig)
+	s.SetErrors(errors.New("no api for you"))
+
+	_, err := s.manifold.Start(s.context)
+	c.Assert(err, gc.NotNil)
+	worker, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	err = testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP juju_agent_api_connected Whether the agent is connected to the API (1) or not (0).
+# TYPE juju_agent_api_connected gauge
+juju_agent_api_connected 1
+`[1:]), "juju_agent_api_connected")
+	c.Check(err, jc.ErrorIsNil)
+
+	assertStop(c, worker)
+	err = testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP juju_agent_api_connected Whether the agent is connected to the API (1) or not (0).
+# TYPE juju_agent_api_connected gauge
+juju_agent_api_connected 0
+# HELP juju_agent_api_connection_failures_total Number of failed attempts by the agent to connect to the API.
+# TYPE juju_agent_api_connection_failures_total counter
+juju_agent_api_connection_failures_total 1
+# HELP juju_agent_api_connections_total Number of API connections made by the agent.
+# TYPE juju_agent_api_connections_total counter
+juju_agent_api_connections_total 1
+`[1:]))
+	c.Check(err, jc.ErrorIsNil)
+}
+
 func (s *ManifoldSuite) setupWorkerTest(c *gc.C) worker.Worker {
 	w, err := s.manifold.Start(s.context)
 	c.Assert(err, jc.ErrorIsNil)
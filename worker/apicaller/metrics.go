@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apicaller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "juju_agent_api"
+
+// Metrics is a prometheus.Collector that records the state of an
+// agent's API connection. A single Metrics should be shared by every
+// apicaller manifold in an agent.
+type Metrics struct {
+	connected   prometheus.Gauge
+	connections prometheus.Counter
+	failures    prometheus.Counter
+}
+
+// NewMetrics returns a new Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		connected: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "connected",
+			Help:      "Whether the agent is connected to the API (1) or not (0).",
+		}),
+		connections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "connections_total",
+			Help:      "Number of API connections made by the agent.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "connection_failures_total",
+			Help:      "Number of failed attempts by the agent to connect to the API.",
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.connected.Describe(ch)
+	m.connections.Describe(ch)
+	m.failures.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.connected.Collect(ch)
+	m.connections.Collect(ch)
+	m.failures.Collect(ch)
+}
+
+func (m *Metrics) connectionOpened() {
+	if m == nil {
+		return
+	}
+	m.connections.Inc()
+	m.connected.Set(1)
+}
+
+func (m *Metrics) connectionClosed() {
+	if m == nil {
+		return
+	}
+	m.connected.Set(0)
+}
+
+func (m *Metrics) connectionFailed() {
+	if m == nil {
+		return
+	}
+	m.failures.Inc()
+}
@@ -23,15 +23,16 @@ var logger interface{}
 // The lack of error return is considered and intentional; it signals the
 // transfer of responsibility for the connection from the caller to the
 // worker.
-func newAPIConnWorker(conn api.Connection) worker.Worker {
-	w := &apiConnWorker{conn: conn}
+func newAPIConnWorker(conn api.Connection, metrics *Metrics) worker.Worker {
+	w := &apiConnWorker{conn: conn, metrics: metrics}
 	w.tomb.Go(w.loop)
 	return w
 }
 
 type apiConnWorker struct {
-	tomb tomb.Tomb
-	conn api.Connection
+	tomb    tomb.Tomb
+	conn    api.Connection
+	metrics *Metrics
 }
 
 // Kill is part of the worker.Worker interface.
@@ -51,6 +52,7 @@ func (w *apiConnWorker) loop() (err error) {
 	// TODO(fwereade): we should make this rational at some point.
 
 	defer func() {
+		w.metrics.connectionClosed()
 		// Since we can't tell for sure what error killed the connection, any
 		// error out of Close is more important and relevant than any error we
 		// might return in the loop.
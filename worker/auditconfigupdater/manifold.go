@@ -10,6 +10,7 @@ import (
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -73,12 +74,28 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		}
 	}()
 
-	logDir := agent.CurrentConfig().LogDir()
+	agentConfig := agent.CurrentConfig()
+	logDir := agentConfig.LogDir()
 
 	st := statePool.SystemState()
 
+	// Audit log records are also kept in the database, so that they
+	// can be queried from any controller, and may be forwarded along
+	// with the controller model's logs.
+	dbLog := state.NewDbAuditLog(st, agentConfig.Tag().Id())
+	forwarder := state.NewAuditLogForwarder(st, agentConfig.Tag().String())
+	defer func() {
+		if err != nil {
+			dbLog.Close()
+			forwarder.Close()
+		}
+	}()
+
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		return auditlog.NewMultiLog(
+			auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups),
+			dbLog,
+		)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -87,12 +104,20 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	if auditConfig.Enabled {
 		auditConfig.Target = logFactory(auditConfig)
 	}
+	auditConfig.ForwardTarget = forwarder
 
 	w, err := config.NewWorker(st, auditConfig, logFactory)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+	// API connections keep the audit log they were given at login, so
+	// they may still write to these after the worker is restarted.
+	// Records written after they're closed are dropped.
+	return common.NewCleanupWorker(w, func() {
+		dbLog.Close()
+		forwarder.Close()
+		stTracker.Done()
+	}), nil
 }
 
 type withCurrentConfig interface {
@@ -126,6 +151,7 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Forward:        cfg.AuditLogForward(),
 	}
 	return result, nil
 }
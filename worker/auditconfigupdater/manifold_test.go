@@ -9,6 +9,7 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 	dt "gopkg.in/juju/worker.v1/dependency/testing"
@@ -41,11 +42,13 @@ func (s *manifoldSuite) SetUpTest(c *gc.C) {
 	s.ControllerConfig["audit-log-exclude-methods"] = []interface{}{"This.Method"}
 	s.ControllerConfig["audit-log-max-size"] = "10M"
 	s.ControllerConfig["audit-log-max-backups"] = 10
+	s.ControllerConfig["audit-log-forward"] = true
 
 	s.StateSuite.SetUpTest(c)
 
 	s.agent = &mockAgent{}
 	s.agent.conf.logDir = c.MkDir()
+	s.agent.conf.tag = names.NewMachineTag("0")
 
 	s.stateTracker = stubStateTracker{
 		pool: s.StatePool,
@@ -118,14 +121,17 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 	target := auditConfig.Target
 	c.Assert(target, gc.NotNil)
 	defer target.Close()
+	c.Assert(auditConfig.ForwardTarget, gc.NotNil)
 
 	auditConfig.Target = nil
+	auditConfig.ForwardTarget = nil
 	c.Assert(auditConfig, gc.DeepEquals, auditlog.Config{
 		Enabled:        true,
 		CaptureAPIArgs: true,
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+		Forward:        true,
 	})
 
 	c.Assert(args[2], gc.NotNil)
@@ -202,12 +208,17 @@ func (ma *mockAgent) CurrentConfig() agent.Config {
 type mockAgentConfig struct {
 	agent.Config
 	logDir string
+	tag    names.Tag
 }
 
 func (c *mockAgentConfig) LogDir() string {
 	return c.logDir
 }
 
+func (c *mockAgentConfig) Tag() names.Tag {
+	return c.tag
+}
+
 type stubStateTracker struct {
 	testing.Stub
 	pool *state.StatePool
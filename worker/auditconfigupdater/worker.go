@@ -95,6 +95,8 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Forward:        cfg.AuditLogForward(),
+		ForwardTarget:  u.current.ForwardTarget,
 	}
 	if result.Enabled && u.current.Target == nil {
 		result.Target = u.logFactory(result)
@@ -188,6 +188,34 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingForward(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	forwardTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled:       true,
+		Target:        &apitesting.FakeAuditLog{},
+		ForwardTarget: forwardTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-forward"] = true
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Forward
+	})
+	c.Assert(newConfig.ForwardTarget, gc.Equals, auditlog.AuditLog(forwardTarget))
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	config backupscheduler.ManifoldConfig
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = backupscheduler.ManifoldConfig{
+		AgentName: "agent",
+		ClockName: "clock",
+		StateName: "state",
+		Logger:    loggo.GetLogger("test"),
+		NewWorker: func(backupscheduler.Config) (worker.Worker, error) {
+			return nil, errors.New("unexpected")
+		},
+	}
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	manifold := backupscheduler.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, []string{"agent", "clock", "state"})
+}
+
+func (s *manifoldSuite) TestValidate(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+	for i, test := range []struct {
+		f      func(*backupscheduler.ManifoldConfig)
+		expect string
+	}{{
+		f:      func(config *backupscheduler.ManifoldConfig) { config.AgentName = "" },
+		expect: "empty AgentName not valid",
+	}, {
+		f:      func(config *backupscheduler.ManifoldConfig) { config.ClockName = "" },
+		expect: "empty ClockName not valid",
+	}, {
+		f:      func(config *backupscheduler.ManifoldConfig) { config.StateName = "" },
+		expect: "empty StateName not valid",
+	}, {
+		f:      func(config *backupscheduler.ManifoldConfig) { config.Logger = nil },
+		expect: "nil Logger not valid",
+	}, {
+		f:      func(config *backupscheduler.ManifoldConfig) { config.NewWorker = nil },
+		expect: "nil NewWorker not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.f(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *manifoldSuite) TestMissingInputs(c *gc.C) {
+	manifold := backupscheduler.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
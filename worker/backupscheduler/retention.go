@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+
+	"github.com/juju/juju/state/backups"
+)
+
+// RetentionPolicy determines which scheduled backups are kept. A backup
+// is kept if any of the rules below keeps it.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days, counting back from the most
+	// recent backup, for which the latest backup of the day is kept.
+	// Days without backups are not counted.
+	KeepDaily int
+
+	// KeepWeekly is like KeepDaily, but for ISO weeks.
+	KeepWeekly int
+}
+
+// KeepAll reports whether the policy keeps every backup, which is the
+// case when no rules are set.
+func (p RetentionPolicy) KeepAll() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0
+}
+
+// Expired returns the IDs of the scheduled backups which are not kept
+// by the policy, newest first. Backups which were not created by the
+// scheduler are never expired.
+func (p RetentionPolicy) Expired(all []*backups.Metadata) []string {
+	if p.KeepAll() {
+		return nil
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == ScheduledBackupNotes {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+
+	days := set.NewStrings()
+	weeks := set.NewStrings()
+	var expired []string
+	for i, meta := range scheduled {
+		started := meta.Started.UTC()
+		day := started.Format("2006-01-02")
+		year, week := started.ISOWeek()
+		weekKey := fmt.Sprintf("%d-W%02d", year, week)
+
+		keep := i < p.KeepLast
+		if !days.Contains(day) && days.Size() < p.KeepDaily {
+			days.Add(day)
+			keep = true
+		}
+		if !weeks.Contains(weekKey) && weeks.Size() < p.KeepWeekly {
+			weeks.Add(weekKey)
+			keep = true
+		}
+		if !keep {
+			expired = append(expired, meta.ID())
+		}
+	}
+	return expired
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type retentionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+func newMetadata(id string, started time.Time, notes string) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Notes = notes
+	return meta
+}
+
+func scheduledBackups() []*backups.Metadata {
+	// Backups are taken at 03:00 every day from Wednesday 2020-04-15
+	// to Saturday 2020-05-02, with an extra backup at 15:00 on the
+	// last day. The list is deliberately unordered.
+	var all []*backups.Metadata
+	start := time.Date(2020, 4, 15, 3, 0, 0, 0, time.UTC)
+	for day := 17; day >= 0; day-- {
+		started := start.AddDate(0, 0, day)
+		all = append(all, newMetadata(started.Format("0102-15"), started, backupscheduler.ScheduledBackupNotes))
+	}
+	extra := time.Date(2020, 5, 2, 15, 0, 0, 0, time.UTC)
+	all = append(all, newMetadata(extra.Format("0102-15"), extra, backupscheduler.ScheduledBackupNotes))
+	return all
+}
+
+func (s *retentionSuite) TestKeepAll(c *gc.C) {
+	var policy backupscheduler.RetentionPolicy
+	c.Check(policy.KeepAll(), jc.IsTrue)
+	c.Check(policy.Expired(scheduledBackups()), gc.HasLen, 0)
+}
+
+func (s *retentionSuite) TestExpired(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		policy backupscheduler.RetentionPolicy
+		kept   []string
+	}{{
+		about:  "keep last",
+		policy: backupscheduler.RetentionPolicy{KeepLast: 3},
+		kept:   []string{"0502-15", "0502-03", "0501-03"},
+	}, {
+		about:  "keep daily",
+		policy: backupscheduler.RetentionPolicy{KeepDaily: 3},
+		kept:   []string{"0502-15", "0501-03", "0430-03"},
+	}, {
+		about:  "keep weekly",
+		policy: backupscheduler.RetentionPolicy{KeepWeekly: 3},
+		// Weeks start on Monday 2020-04-27, 2020-04-20 and 2020-04-13.
+		kept: []string{"0502-15", "0426-03", "0419-03"},
+	}, {
+		about:  "combined",
+		policy: backupscheduler.RetentionPolicy{KeepLast: 2, KeepDaily: 2, KeepWeekly: 2},
+		kept:   []string{"0502-15", "0502-03", "0501-03", "0426-03"},
+	}, {
+		about:  "more than available",
+		policy: backupscheduler.RetentionPolicy{KeepWeekly: 10},
+		kept:   []string{"0502-15", "0426-03", "0419-03"},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		all := scheduledBackups()
+		kept := set.NewStrings(test.kept...)
+		var expected []string
+		for _, meta := range all {
+			if !kept.Contains(meta.ID()) {
+				expected = append(expected, meta.ID())
+			}
+		}
+		c.Check(test.policy.Expired(all), jc.SameContents, expected)
+	}
+}
+
+func (s *retentionSuite) TestExpiredIgnoresOtherBackups(c *gc.C) {
+	all := []*backups.Metadata{
+		newMetadata("manual", time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), "before upgrade"),
+		newMetadata("new", time.Date(2020, 5, 3, 3, 0, 0, 0, time.UTC), backupscheduler.ScheduledBackupNotes),
+		newMetadata("old", time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC), backupscheduler.ScheduledBackupNotes),
+	}
+	policy := backupscheduler.RetentionPolicy{KeepLast: 1}
+	c.Check(policy.Expired(all), jc.DeepEquals, []string{"old"})
+}
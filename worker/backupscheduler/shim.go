@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+type stateShim struct {
+	*state.State
+	*state.Model
+}
+
+// ModelTag disambiguates the ModelTag method, as required by backups.DB.
+func (s stateShim) ModelTag() names.ModelTag {
+	return s.Model.ModelTag()
+}
+
+// backupsShim implements Backups by creating backups in the same way
+// as the Backups facade.
+type backupsShim struct {
+	st          stateShim
+	agentConfig agent.Config
+}
+
+func (b backupsShim) open(destination string) (backups.Backups, io.Closer, error) {
+	if destination == "" {
+		stor := backups.NewStorage(b.st)
+		return backups.NewBackups(stor), stor, nil
+	}
+	controllerConfig, err := b.st.ControllerConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dest, err := backups.OpenDestination(destination, controllerConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	stor := backups.NewDestinationStorage(dest)
+	return backups.NewBackups(stor), stor, nil
+}
+
+// Create is part of the Backups interface.
+func (b backupsShim) Create(destination, notes string) (string, error) {
+	backupsMethods, closer, err := b.open(destination)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer closer.Close()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return "", errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return "", errors.New("no mongo info in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return "", errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	m, err := b.st.Machine(machineID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, machineID, m.Series())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Controller.MachineID = machineID
+	instanceID, err := m.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+	nodes, err := b.st.ControllerNodes()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+
+	modelConfig, err := b.st.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := backupsMethods.Create(meta, &paths, dbInfo, true, true); err != nil {
+		return "", errors.Trace(err)
+	}
+	return meta.ID(), nil
+}
+
+// List is part of the Backups interface.
+func (b backupsShim) List(destination string) ([]*backups.Metadata, error) {
+	backupsMethods, closer, err := b.open(destination)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	return backupsMethods.List()
+}
+
+// Remove is part of the Backups interface.
+func (b backupsShim) Remove(destination, id string) error {
+	backupsMethods, closer, err := b.open(destination)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer.Close()
+	return backupsMethods.Remove(id)
+}
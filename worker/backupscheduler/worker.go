@@ -0,0 +1,242 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+	"gopkg.in/robfig/cron.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// ScheduledBackupNotes is recorded as the notes of every backup created
+// by the scheduler. Only backups with these notes are ever pruned, so
+// backups created by users are left alone.
+const ScheduledBackupNotes = "scheduled backup"
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Backend provides access to the controller config which configures the
+// scheduler, and records the outcome of scheduled backups. (Primary
+// implementation is State.)
+type Backend interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+	BackupScheduleStatus() (state.BackupScheduleStatus, error)
+	SetBackupScheduleStatus(state.BackupScheduleStatus) error
+}
+
+// Backups creates, lists and removes controller backups. Each method
+// takes the URL of the destination holding the backups; an empty
+// destination refers to the controller's own backup storage.
+type Backups interface {
+	// Create creates a backup of the controller with the given
+	// notes, and returns its ID.
+	Create(destination, notes string) (string, error)
+
+	// List returns the metadata of all backups in the destination.
+	List(destination string) ([]*backups.Metadata, error)
+
+	// Remove removes the backup with the given ID from the destination.
+	Remove(destination, id string) error
+}
+
+// Config holds the dependencies and configuration for a backup
+// scheduler worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+	Logger  Logger
+}
+
+// Validate returns an error if the config cannot be expected to
+// run a backup scheduler.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which creates controller backups on the
+// schedule given by the controller config, and prunes old scheduled
+// backups according to the configured retention policy.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type scheduler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *scheduler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *scheduler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// settings holds the scheduler's view of the controller config.
+type settings struct {
+	schedule    cron.Schedule
+	destination string
+	retention   RetentionPolicy
+}
+
+func (w *scheduler) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		current settings
+		timer   clock.Timer
+		timeout <-chan time.Time
+	)
+	stopTimer := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+	}
+	defer stopTimer()
+	resetTimer := func() {
+		stopTimer()
+		if current.schedule == nil {
+			return
+		}
+		// Schedules are interpreted in UTC unless they specify
+		// a time zone.
+		now := w.config.Clock.Now().UTC()
+		timer = w.config.Clock.NewTimer(current.schedule.Next(now).Sub(now))
+		timeout = timer.Chan()
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			newSettings, err := w.settings()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			current = newSettings
+			resetTimer()
+		case <-timeout:
+			if err := w.backup(current); err != nil {
+				return errors.Trace(err)
+			}
+			resetTimer()
+		}
+	}
+}
+
+func (w *scheduler) settings() (settings, error) {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return settings{}, errors.Annotate(err, "getting controller config")
+	}
+	result := settings{
+		destination: cfg.BackupScheduleDestination(),
+		retention: RetentionPolicy{
+			KeepLast:   cfg.BackupKeepLast(),
+			KeepDaily:  cfg.BackupKeepDaily(),
+			KeepWeekly: cfg.BackupKeepWeekly(),
+		},
+	}
+	if spec := cfg.BackupSchedule(); spec != "" {
+		result.schedule, err = cron.Parse(spec)
+		if err != nil {
+			return settings{}, errors.Annotatef(err, "parsing backup schedule %q", spec)
+		}
+	}
+	return result, nil
+}
+
+// backup creates a scheduled backup, prunes old ones and records the
+// outcome. Failing to create or prune backups does not stop the worker;
+// the failure is recorded and the next scheduled backup is attempted
+// as usual.
+func (w *scheduler) backup(current settings) error {
+	status, err := w.config.Backend.BackupScheduleStatus()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	status.LastAttempt = w.config.Clock.Now().UTC()
+	status.LastError = ""
+
+	id, err := w.config.Backups.Create(current.destination, ScheduledBackupNotes)
+	if err != nil {
+		w.config.Logger.Errorf("scheduled backup failed: %v", err)
+		status.LastError = err.Error()
+	} else {
+		w.config.Logger.Infof("created scheduled backup %q", id)
+		status.LastSuccess = w.config.Clock.Now().UTC()
+		status.LastBackupID = id
+		if err := w.prune(current); err != nil {
+			w.config.Logger.Errorf("pruning scheduled backups failed: %v", err)
+			status.LastError = "pruning old backups: " + err.Error()
+		}
+	}
+	return errors.Annotate(w.config.Backend.SetBackupScheduleStatus(status), "recording backup status")
+}
+
+func (w *scheduler) prune(current settings) error {
+	if current.retention.KeepAll() {
+		return nil
+	}
+	all, err := w.config.Backups.List(current.destination)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, id := range current.retention.Expired(all) {
+		err := w.config.Backups.Remove(current.destination, id)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing backup %q", id)
+		}
+		w.config.Logger.Infof("removed expired backup %q", id)
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	changes chan struct{}
+	backend *fakeBackend
+	backups *fakeBackups
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 2, 30, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.backend = &fakeBackend{
+		watcher: watchertest.NewNotifyWatcher(s.changes),
+		cfg: controller.Config{
+			controller.BackupSchedule: "0 3 * * *",
+		},
+		statusSet: make(chan state.BackupScheduleStatus, 1),
+	}
+	s.backups = &fakeBackups{}
+	s.config = backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+		Logger:  loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(config *backupscheduler.Config) {
+		config.Backend = nil
+	}, "nil Backend not valid")
+	s.testValidate(c, func(config *backupscheduler.Config) {
+		config.Backups = nil
+	}, "nil Backups not valid")
+	s.testValidate(c, func(config *backupscheduler.Config) {
+		config.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(config *backupscheduler.Config) {
+		config.Logger = nil
+	}, "nil Logger not valid")
+}
+
+func (s *workerSuite) testValidate(c *gc.C, f func(*backupscheduler.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := backupscheduler.NewWorker(config)
+	if !c.Check(err, gc.ErrorMatches, expect) {
+		workertest.DirtyKill(c, w)
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.changes <- struct{}{}
+}
+
+func (s *workerSuite) waitStatus(c *gc.C) state.BackupScheduleStatus {
+	select {
+	case status := <-s.backend.statusSet:
+		return status
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup status")
+	}
+	panic("unreachable")
+}
+
+func (s *workerSuite) TestBackupOnSchedule(c *gc.C) {
+	s.backups.created = []string{"first", "second"}
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status := s.waitStatus(c)
+	c.Check(status, jc.DeepEquals, state.BackupScheduleStatus{
+		LastAttempt:  time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		LastSuccess:  time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		LastBackupID: "first",
+	})
+
+	// The next backup is a day later.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status = s.waitStatus(c)
+	c.Check(status.LastBackupID, gc.Equals, "second")
+	c.Check(status.LastSuccess, gc.Equals, time.Date(2020, 5, 2, 3, 0, 0, 0, time.UTC))
+
+	s.backups.CheckCalls(c, []testing.StubCall{
+		{FuncName: "Create", Args: []interface{}{"", backupscheduler.ScheduledBackupNotes}},
+		{FuncName: "Create", Args: []interface{}{"", backupscheduler.ScheduledBackupNotes}},
+	})
+}
+
+func (s *workerSuite) TestBackupToDestination(c *gc.C) {
+	s.backend.cfg[controller.BackupScheduleDestination] = "s3://juju-backups"
+	s.backups.created = []string{"first"}
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitStatus(c)
+	s.backups.CheckCall(c, 0, "Create", "s3://juju-backups", backupscheduler.ScheduledBackupNotes)
+}
+
+func (s *workerSuite) TestBackupFailure(c *gc.C) {
+	lastSuccess := time.Date(2020, 4, 30, 3, 0, 0, 0, time.UTC)
+	s.backend.status = &state.BackupScheduleStatus{
+		LastAttempt:  lastSuccess,
+		LastSuccess:  lastSuccess,
+		LastBackupID: "previous",
+	}
+	s.backups.SetErrors(errors.New("HA not ready"))
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status := s.waitStatus(c)
+	c.Check(status, jc.DeepEquals, state.BackupScheduleStatus{
+		LastAttempt:  time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC),
+		LastSuccess:  lastSuccess,
+		LastBackupID: "previous",
+		LastError:    "HA not ready",
+	})
+
+	// The worker keeps running, and tries again on schedule.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitStatus(c)
+}
+
+func (s *workerSuite) TestNoSchedule(c *gc.C) {
+	delete(s.backend.cfg, controller.BackupSchedule)
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(24*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.NotNil)
+	s.backups.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestScheduleEnabled(c *gc.C) {
+	delete(s.backend.cfg, controller.BackupSchedule)
+	s.backups.created = []string{"first"}
+	s.startWorker(c)
+
+	s.backend.setConfig(controller.Config{
+		controller.BackupSchedule: "0 * * * *",
+	})
+	s.changes <- struct{}{}
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status := s.waitStatus(c)
+	c.Check(status.LastAttempt, gc.Equals, time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC))
+}
+
+func (s *workerSuite) TestPrunesScheduledBackups(c *gc.C) {
+	s.backend.cfg[controller.BackupKeepLast] = 1
+	s.backups.created = []string{"new"}
+	s.backups.list = []*backups.Metadata{
+		newMetadata("manual", time.Date(2020, 4, 28, 0, 0, 0, 0, time.UTC), "before upgrade"),
+		newMetadata("old", time.Date(2020, 4, 30, 3, 0, 0, 0, time.UTC), backupscheduler.ScheduledBackupNotes),
+		newMetadata("new", time.Date(2020, 5, 1, 3, 0, 0, 0, time.UTC), backupscheduler.ScheduledBackupNotes),
+	}
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status := s.waitStatus(c)
+	c.Check(status.LastError, gc.Equals, "")
+	s.backups.CheckCalls(c, []testing.StubCall{
+		{FuncName: "Create", Args: []interface{}{"", backupscheduler.ScheduledBackupNotes}},
+		{FuncName: "List", Args: []interface{}{""}},
+		{FuncName: "Remove", Args: []interface{}{"", "old"}},
+	})
+}
+
+func (s *workerSuite) TestPruneFailure(c *gc.C) {
+	s.backend.cfg[controller.BackupKeepLast] = 1
+	s.backups.created = []string{"new"}
+	s.backups.SetErrors(nil, errors.New("access denied"))
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	status := s.waitStatus(c)
+	c.Check(status.LastBackupID, gc.Equals, "new")
+	c.Check(status.LastError, gc.Equals, "pruning old backups: access denied")
+}
+
+type fakeBackend struct {
+	mu        sync.Mutex
+	watcher   state.NotifyWatcher
+	cfg       controller.Config
+	status    *state.BackupScheduleStatus
+	statusSet chan state.BackupScheduleStatus
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, nil
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+func (b *fakeBackend) BackupScheduleStatus() (state.BackupScheduleStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.status == nil {
+		return state.BackupScheduleStatus{}, errors.NotFoundf("backup schedule status")
+	}
+	return *b.status, nil
+}
+
+func (b *fakeBackend) SetBackupScheduleStatus(status state.BackupScheduleStatus) error {
+	b.mu.Lock()
+	b.status = &status
+	b.mu.Unlock()
+	b.statusSet <- status
+	return nil
+}
+
+type fakeBackups struct {
+	testing.Stub
+	created []string
+	list    []*backups.Metadata
+}
+
+func (b *fakeBackups) Create(destination, notes string) (string, error) {
+	b.MethodCall(b, "Create", destination, notes)
+	if err := b.NextErr(); err != nil {
+		return "", err
+	}
+	id := b.created[0]
+	b.created = b.created[1:]
+	return id, nil
+}
+
+func (b *fakeBackups) List(destination string) ([]*backups.Metadata, error) {
+	b.MethodCall(b, "List", destination)
+	return b.list, b.NextErr()
+}
+
+func (b *fakeBackups) Remove(destination, id string) error {
+	b.MethodCall(b, "Remove", destination, id)
+	return b.NextErr()
+}
@@ -4,28 +4,44 @@
 package caasfirewaller
 
 import (
+	"reflect"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/tags"
 )
 
 type applicationWorker struct {
-	catacomb          catacomb.Catacomb
-	controllerUUID    string
-	modelUUID         string
-	application       string
-	applicationGetter ApplicationGetter
-	serviceExposer    ServiceExposer
+	catacomb             catacomb.Catacomb
+	controllerUUID       string
+	modelUUID            string
+	application          string
+	applicationGetter    ApplicationGetter
+	serviceExposer       ServiceExposer
+	networkPolicyManager NetworkPolicyManager
 
-	lifeGetter LifeGetter
+	lifeGetter        LifeGetter
+	modelConfigGetter ModelConfigGetter
 
 	initial           bool
 	previouslyExposed bool
+	// exposedConfig is the application config the service was
+	// last exposed with.
+	exposedConfig application.ConfigAttributes
+
+	// networkPolicies is true if ingress to the application
+	// is restricted to the applications related to it.
+	networkPolicies      bool
+	initialNetworkPolicy bool
+	// allowedApplications are the related applications allowed
+	// by the network policy last applied, nil if there is none.
+	allowedApplications set.Strings
 
 	logger Logger
 }
@@ -36,18 +52,23 @@ func newApplicationWorker(
 	application string,
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
+	networkPolicyManager NetworkPolicyManager,
 	lifeGetter LifeGetter,
+	modelConfigGetter ModelConfigGetter,
 	logger Logger,
 ) (worker.Worker, error) {
 	w := &applicationWorker{
-		controllerUUID:    controllerUUID,
-		modelUUID:         modelUUID,
-		application:       application,
-		applicationGetter: applicationGetter,
-		serviceExposer:    applicationExposer,
-		lifeGetter:        lifeGetter,
-		initial:           true,
-		logger:            logger,
+		controllerUUID:       controllerUUID,
+		modelUUID:            modelUUID,
+		application:          application,
+		applicationGetter:    applicationGetter,
+		serviceExposer:       applicationExposer,
+		networkPolicyManager: networkPolicyManager,
+		lifeGetter:           lifeGetter,
+		modelConfigGetter:    modelConfigGetter,
+		initial:              true,
+		initialNetworkPolicy: true,
+		logger:               logger,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -80,6 +101,23 @@ func (w *applicationWorker) loop() (err error) {
 	if err := w.catacomb.Add(appWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	appConfigWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(appConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	configWatcher, err := w.modelConfigGetter.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	if w.networkPolicies, err = w.networkPoliciesEnabled(); err != nil {
+		return errors.Trace(err)
+	}
 
 	for {
 		select {
@@ -95,10 +133,52 @@ func (w *applicationWorker) loop() (err error) {
 				}
 				return errors.Trace(err)
 			}
+		case _, ok := <-appConfigWatcher.Changes():
+			if !ok {
+				return errors.New("application config watcher closed")
+			}
+			if err := w.processApplicationConfigChange(); err != nil {
+				if strings.Contains(err.Error(), "unexpected EOF") {
+					return nil
+				}
+				return errors.Trace(err)
+			}
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.processModelConfigChange(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
 
+func (w *applicationWorker) networkPoliciesEnabled() (bool, error) {
+	cfg, err := w.modelConfigGetter.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.EnableNetworkPolicies(), nil
+}
+
+func (w *applicationWorker) processModelConfigChange() error {
+	enabled, err := w.networkPoliciesEnabled()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if enabled == w.networkPolicies {
+		return nil
+	}
+	w.networkPolicies = enabled
+	if w.initial {
+		// The network policy is applied when the
+		// application is first processed.
+		return nil
+	}
+	return errors.Trace(w.processNetworkPolicy())
+}
+
 func (w *applicationWorker) processApplicationChange() (err error) {
 	defer func() {
 		if errors.IsNotFound(err) {
@@ -112,10 +192,43 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !w.initial && exposed == w.previouslyExposed {
+	if w.initial || exposed != w.previouslyExposed {
+		if err := w.processExposedChange(exposed); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// The application changes whenever a relation is added or removed,
+	// so this is also when the network policy needs to be updated.
+	return errors.Trace(w.processNetworkPolicy())
+}
+
+// processApplicationConfigChange exposes the service again when the
+// config of an exposed application changes, so that changes to the
+// expose options, such as the ingress TLS secret or rules, are applied.
+func (w *applicationWorker) processApplicationConfigChange() (err error) {
+	defer func() {
+		if errors.IsNotFound(err) {
+			w.logger.Warningf("processing config change for application %q, %v", w.application, err)
+			err = nil
+		}
+	}()
+
+	if w.initial || !w.previouslyExposed {
+		// The service is exposed with the current config
+		// when the application is exposed.
 		return nil
 	}
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if reflect.DeepEqual(appConfig, w.exposedConfig) {
+		return nil
+	}
+	return errors.Trace(w.exposeService(appConfig))
+}
 
+func (w *applicationWorker) processExposedChange(exposed bool) error {
 	w.initial = false
 	w.previouslyExposed = exposed
 	if exposed {
@@ -123,17 +236,57 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		resourceTags := tags.ResourceTags(
-			names.NewModelTag(w.modelUUID),
-			names.NewControllerTag(w.controllerUUID),
-		)
-		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
+		return errors.Trace(w.exposeService(appConfig))
+	}
+	w.exposedConfig = nil
+	if err := w.serviceExposer.UnexposeService(w.application); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (w *applicationWorker) exposeService(appConfig application.ConfigAttributes) error {
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(w.modelUUID),
+		names.NewControllerTag(w.controllerUUID),
+	)
+	if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
+		return errors.Trace(err)
+	}
+	w.exposedConfig = appConfig
+	return nil
+}
+
+// processNetworkPolicy restricts ingress to the application to the
+// applications related to it, if network policies are enabled for the
+// model. Exposed applications accept ingress from anywhere.
+func (w *applicationWorker) processNetworkPolicy() error {
+	if !w.networkPolicies || w.previouslyExposed {
+		if !w.initialNetworkPolicy && w.allowedApplications == nil {
+			return nil
+		}
+		if err := w.networkPolicyManager.DeleteNetworkPolicy(w.application); err != nil {
 			return errors.Trace(err)
 		}
+		w.initialNetworkPolicy = false
+		w.allowedApplications = nil
 		return nil
 	}
-	if err := w.serviceExposer.UnexposeService(w.application); err != nil {
+
+	related, err := w.applicationGetter.RelatedApplications(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	allowed := set.NewStrings(related...)
+	if !w.initialNetworkPolicy && w.allowedApplications != nil &&
+		allowed.Difference(w.allowedApplications).IsEmpty() &&
+		w.allowedApplications.Difference(allowed).IsEmpty() {
+		return nil
+	}
+	if err := w.networkPolicyManager.EnsureNetworkPolicy(w.application, allowed.SortedValues()); err != nil {
 		return errors.Trace(err)
 	}
+	w.initialNetworkPolicy = false
+	w.allowedApplications = allowed
 	return nil
 }
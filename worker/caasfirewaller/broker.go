@@ -9,3 +9,9 @@ type ServiceExposer interface {
 	ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
 }
+
+// NetworkPolicyManager instances restrict ingress to applications.
+type NetworkPolicyManager interface {
+	EnsureNetworkPolicy(appName string, relatedApps []string) error
+	DeleteNetworkPolicy(appName string) error
+}
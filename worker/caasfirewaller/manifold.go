@@ -87,12 +87,14 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 
 	client := config.NewClient(apiCaller)
 	w, err := config.NewWorker(Config{
-		ControllerUUID:    config.ControllerUUID,
-		ModelUUID:         config.ModelUUID,
-		ApplicationGetter: client,
-		LifeGetter:        client,
-		ServiceExposer:    broker,
-		Logger:            config.Logger,
+		ControllerUUID:       config.ControllerUUID,
+		ModelUUID:            config.ModelUUID,
+		ApplicationGetter:    client,
+		LifeGetter:           client,
+		ModelConfigGetter:    client,
+		ServiceExposer:       broker,
+		NetworkPolicyManager: broker,
+		Logger:               config.Logger,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
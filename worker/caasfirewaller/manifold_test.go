@@ -150,11 +150,13 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	config := args[0].(caasfirewaller.Config)
 
 	c.Assert(config, jc.DeepEquals, caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.client,
-		ServiceExposer:    &s.broker,
-		LifeGetter:        &s.client,
-		Logger:            loggo.GetLogger("test"),
+		ControllerUUID:       coretesting.ControllerTag.Id(),
+		ModelUUID:            coretesting.ModelTag.Id(),
+		ApplicationGetter:    &s.client,
+		ServiceExposer:       &s.broker,
+		NetworkPolicyManager: &s.broker,
+		LifeGetter:           &s.client,
+		ModelConfigGetter:    &s.client,
+		Logger:               loggo.GetLogger("test"),
 	})
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasfirewaller"
 )
 
@@ -45,11 +47,31 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 	return m.NextErr()
 }
 
+type mockNetworkPolicyManager struct {
+	testing.Stub
+	changed chan<- struct{}
+}
+
+func (m *mockNetworkPolicyManager) EnsureNetworkPolicy(appName string, relatedApps []string) error {
+	m.MethodCall(m, "EnsureNetworkPolicy", appName, relatedApps)
+	m.changed <- struct{}{}
+	return m.NextErr()
+}
+
+func (m *mockNetworkPolicyManager) DeleteNetworkPolicy(appName string) error {
+	m.MethodCall(m, "DeleteNetworkPolicy", appName)
+	m.changed <- struct{}{}
+	return m.NextErr()
+}
+
 type mockApplicationGetter struct {
 	testing.Stub
-	allWatcher *watchertest.MockStringsWatcher
-	appWatcher *watchertest.MockNotifyWatcher
-	exposed    bool
+	allWatcher       *watchertest.MockStringsWatcher
+	appWatcher       *watchertest.MockNotifyWatcher
+	appConfigWatcher *watchertest.MockNotifyWatcher
+	exposed          bool
+	config           application.ConfigAttributes
+	related          []string
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -68,6 +90,14 @@ func (m *mockApplicationGetter) WatchApplication(appName string) (watcher.Notify
 	return m.appWatcher, nil
 }
 
+func (m *mockApplicationGetter) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.appConfigWatcher, nil
+}
+
 func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 	m.MethodCall(m, "IsExposed", appName)
 	if err := m.NextErr(); err != nil {
@@ -78,7 +108,37 @@ func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
-	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
+	return a.config, a.NextErr()
+}
+
+func (m *mockApplicationGetter) RelatedApplications(appName string) ([]string, error) {
+	m.MethodCall(m, "RelatedApplications", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.related, nil
+}
+
+type mockModelConfigGetter struct {
+	testing.Stub
+	configWatcher *watchertest.MockNotifyWatcher
+	attrs         coretesting.Attrs
+}
+
+func (m *mockModelConfigGetter) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
+func (m *mockModelConfigGetter) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(m.attrs))
 }
 
 type mockLifeGetter struct {
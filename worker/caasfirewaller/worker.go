@@ -17,12 +17,14 @@ var logger interface{}
 
 // Config holds configuration for the CAAS unit firewaller worker.
 type Config struct {
-	ControllerUUID    string
-	ModelUUID         string
-	ApplicationGetter ApplicationGetter
-	LifeGetter        LifeGetter
-	ServiceExposer    ServiceExposer
-	Logger            Logger
+	ControllerUUID       string
+	ModelUUID            string
+	ApplicationGetter    ApplicationGetter
+	LifeGetter           LifeGetter
+	ModelConfigGetter    ModelConfigGetter
+	ServiceExposer       ServiceExposer
+	NetworkPolicyManager NetworkPolicyManager
+	Logger               Logger
 }
 
 // Validate validates the worker configuration.
@@ -42,6 +44,12 @@ func (config Config) Validate() error {
 	if config.LifeGetter == nil {
 		return errors.NotValidf("missing LifeGetter")
 	}
+	if config.ModelConfigGetter == nil {
+		return errors.NotValidf("missing ModelConfigGetter")
+	}
+	if config.NetworkPolicyManager == nil {
+		return errors.NotValidf("missing NetworkPolicyManager")
+	}
 	if config.Logger == nil {
 		return errors.NotValidf("missing Logger")
 	}
@@ -121,7 +129,9 @@ func (p *firewaller) loop() error {
 					appId,
 					p.config.ApplicationGetter,
 					p.config.ServiceExposer,
+					p.config.NetworkPolicyManager,
 					p.config.LifeGetter,
+					p.config.ModelConfigGetter,
 					logger,
 				)
 				if err != nil {
@@ -23,15 +23,20 @@ import (
 type WorkerSuite struct {
 	testing.IsolationSuite
 
-	config            caasfirewaller.Config
-	applicationGetter mockApplicationGetter
-	serviceExposer    mockServiceExposer
-	lifeGetter        mockLifeGetter
-
-	applicationChanges chan []string
-	appExposedChange   chan struct{}
-	serviceExposed     chan struct{}
-	serviceUnexposed   chan struct{}
+	config               caasfirewaller.Config
+	applicationGetter    mockApplicationGetter
+	serviceExposer       mockServiceExposer
+	networkPolicyManager mockNetworkPolicyManager
+	lifeGetter           mockLifeGetter
+	modelConfigGetter    mockModelConfigGetter
+
+	applicationChanges   chan []string
+	appExposedChange     chan struct{}
+	appConfigChange      chan struct{}
+	serviceExposed       chan struct{}
+	serviceUnexposed     chan struct{}
+	modelConfigChanges   chan struct{}
+	networkPolicyChanged chan struct{}
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -41,12 +46,19 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.appExposedChange = make(chan struct{})
+	s.appConfigChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.modelConfigChanges = make(chan struct{})
+	// Stale network policies are removed when an application is first
+	// seen, so don't block tests which aren't interested in them.
+	s.networkPolicyChanged = make(chan struct{}, 10)
 
 	s.applicationGetter = mockApplicationGetter{
-		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
-		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		allWatcher:       watchertest.NewMockStringsWatcher(s.applicationChanges),
+		appWatcher:       watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		appConfigWatcher: watchertest.NewMockNotifyWatcher(s.appConfigChange),
+		config:           application.ConfigAttributes{"juju-external-hostname": "exthost"},
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
 
@@ -57,14 +69,23 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		exposed:   s.serviceExposed,
 		unexposed: s.serviceUnexposed,
 	}
+	s.networkPolicyManager = mockNetworkPolicyManager{
+		changed: s.networkPolicyChanged,
+	}
+	s.modelConfigGetter = mockModelConfigGetter{
+		configWatcher: watchertest.NewMockNotifyWatcher(s.modelConfigChanges),
+	}
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.modelConfigGetter.configWatcher) })
 
 	s.config = caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
-		Logger:            loggo.GetLogger("test"),
+		ControllerUUID:       coretesting.ControllerTag.Id(),
+		ModelUUID:            coretesting.ModelTag.Id(),
+		ApplicationGetter:    &s.applicationGetter,
+		ServiceExposer:       &s.serviceExposer,
+		NetworkPolicyManager: &s.networkPolicyManager,
+		LifeGetter:           &s.lifeGetter,
+		ModelConfigGetter:    &s.modelConfigGetter,
+		Logger:               loggo.GetLogger("test"),
 	}
 }
 
@@ -76,6 +97,30 @@ func (s *WorkerSuite) sendApplicationExposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) sendApplicationConfigChange(c *gc.C) {
+	select {
+	case s.appConfigChange <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+}
+
+func (s *WorkerSuite) assertNetworkPolicyChanged(c *gc.C) {
+	select {
+	case <-s.networkPolicyChanged:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy change")
+	}
+}
+
+func (s *WorkerSuite) assertNetworkPolicyNotChanged(c *gc.C) {
+	select {
+	case <-s.networkPolicyChanged:
+		c.Fatal("network policy changed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.ControllerUUID = ""
@@ -97,6 +142,14 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
 
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.ModelConfigGetter = nil
+	}, `missing ModelConfigGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.NetworkPolicyManager = nil
+	}, `missing NetworkPolicyManager not valid`)
+
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.Logger = nil
 	}, `missing Logger not valid`)
@@ -159,6 +212,83 @@ func (s *WorkerSuite) TestExposedChange(c *gc.C) {
 		application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestExposedApplicationConfigChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// Config which hasn't changed doesn't expose the service again.
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	// Exposing again with new options updates the ingress.
+	newConfig := application.ConfigAttributes{
+		"juju-external-hostname":        "exthost",
+		"kubernetes-ingress-tls-secret": "gitlab-tls",
+	}
+	s.applicationGetter.config = newConfig
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed again")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
+		map[string]string{
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-model-uuid":      coretesting.ModelTag.Id()},
+		newConfig)
+}
+
+func (s *WorkerSuite) TestUnexposedApplicationConfigChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+
+	// The expose options of an unexposed application are
+	// only used once it is exposed.
+	s.applicationGetter.config = application.ConfigAttributes{"juju-external-hostname": "otherhost"}
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.serviceExposer.CheckCallNames(c, "UnexposeService")
+}
+
 func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -258,3 +388,82 @@ func (s *WorkerSuite) TestWatcherErrorStopsWorker(c *gc.C) {
 	err = workertest.CheckKilled(c, w)
 	c.Assert(err, gc.ErrorMatches, "splat")
 }
+
+func (s *WorkerSuite) TestNetworkPolicyRelationsChange(c *gc.C) {
+	s.modelConfigGetter.attrs = coretesting.Attrs{"enable-network-policies": true}
+	s.applicationGetter.related = []string{"mysql"}
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	s.assertNetworkPolicyChanged(c)
+
+	// A relation to redis is added.
+	s.applicationGetter.related = []string{"redis", "mysql"}
+	s.sendApplicationExposedChange(c)
+	s.assertNetworkPolicyChanged(c)
+
+	// Nothing changed for the relations.
+	s.sendApplicationExposedChange(c)
+	s.assertNetworkPolicyNotChanged(c)
+
+	// Exposed applications accept ingress from anywhere.
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.assertNetworkPolicyChanged(c)
+
+	s.networkPolicyManager.CheckCallNames(c, "EnsureNetworkPolicy", "EnsureNetworkPolicy", "DeleteNetworkPolicy")
+	s.networkPolicyManager.CheckCall(c, 0, "EnsureNetworkPolicy", "gitlab", []string{"mysql"})
+	s.networkPolicyManager.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab", []string{"mysql", "redis"})
+	s.networkPolicyManager.CheckCall(c, 2, "DeleteNetworkPolicy", "gitlab")
+}
+
+func (s *WorkerSuite) TestNetworkPolicyModelConfigChange(c *gc.C) {
+	s.applicationGetter.related = []string{"mysql"}
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	// Any stale network policy is removed.
+	s.assertNetworkPolicyChanged(c)
+
+	s.modelConfigGetter.attrs = coretesting.Attrs{"enable-network-policies": true}
+	select {
+	case s.modelConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending model config change")
+	}
+	s.assertNetworkPolicyChanged(c)
+
+	s.networkPolicyManager.CheckCallNames(c, "DeleteNetworkPolicy", "EnsureNetworkPolicy")
+	s.networkPolicyManager.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab", []string{"mysql"})
+}
@@ -6,6 +6,7 @@ package caasoperator
 import (
 	"bytes"
 	"io"
+	"path/filepath"
 
 	"github.com/juju/errors"
 	utilexec "github.com/juju/utils/exec"
@@ -39,18 +40,23 @@ func remoteExecute(execClient exec.Executor,
 		return nil, errors.NotFoundf("pod for %q", unitName)
 	}
 
-	// juju run - return stdout and stderr to ExecResponse.
-	err := execClient.Exec(
-		exec.ExecParams{
-			PodName:    providerID,
-			Commands:   params.Commands,
-			WorkingDir: params.WorkingDir,
-			Env:        params.Env,
-			Stdout:     params.Stdout,
-			Stderr:     params.Stderr,
-		},
-		params.Cancel,
-	)
+	var err error
+	if params.RunAsJob {
+		err = runJob(execClient, providerID, unitPaths, params)
+	} else {
+		// juju run - return stdout and stderr to ExecResponse.
+		err = execClient.Exec(
+			exec.ExecParams{
+				PodName:    providerID,
+				Commands:   params.Commands,
+				WorkingDir: params.WorkingDir,
+				Env:        params.Env,
+				Stdout:     params.Stdout,
+				Stderr:     params.Stderr,
+			},
+			params.Cancel,
+		)
+	}
 	if params.StdoutLogger != nil {
 		params.StdoutLogger.Stop()
 	}
@@ -86,3 +92,31 @@ func remoteExecute(execClient exec.Executor,
 		Stderr: readBytes(params.Stderr),
 	}, err
 }
+
+// runJob runs the commands in a Kubernetes Job based on the unit's pod,
+// with a copy of the charm. The logs of the Job are returned as stdout.
+func runJob(execClient exec.Executor,
+	providerID string,
+	unitPaths uniter.Paths,
+	params runner.ExecParams) error {
+	// The unit's charm dir is a symlink to the operator's charm dir.
+	charmDir, err := filepath.EvalSymlinks(unitPaths.GetCharmDir())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return execClient.RunJob(
+		exec.JobParams{
+			ID:         params.ActionID,
+			PodName:    providerID,
+			Commands:   params.Commands,
+			WorkingDir: params.WorkingDir,
+			Env:        params.Env,
+			Files: []exec.JobFile{{
+				Src:  charmDir,
+				Dest: unitPaths.GetCharmDir(),
+			}},
+			Stdout: params.Stdout,
+		},
+		params.Cancel,
+	)
+}
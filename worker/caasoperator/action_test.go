@@ -124,6 +124,63 @@ func (s *actionSuite) assertRunnerExecFunc(c *gc.C, errMsg string) {
 	}
 }
 
+func (s *actionSuite) TestRunnerExecFuncRunAsJob(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	baseDir := c.MkDir()
+	unitPaths := uniter.NewPaths(baseDir, names.NewUnitTag("gitlab-k8s/0"), &uniter.SocketConfig{})
+	err := os.MkdirAll(unitPaths.GetCharmDir(), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	charmDir, err := filepath.EvalSymlinks(unitPaths.GetCharmDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	runnerExecFunc := caasoperator.GetNewRunnerExecutor(s.executor)(s.unitAPI, unitPaths)
+	cancel := make(<-chan struct{}, 1)
+	stdout := bytes.NewBufferString("")
+	stderr := bytes.NewBufferString("")
+	gomock.InOrder(
+		s.unitAPI.EXPECT().Refresh().Return(nil),
+		s.unitAPI.EXPECT().ProviderID().Return("gitlab-xxxx"),
+		s.unitAPI.EXPECT().Name().Return("gitlab-k8s/0"),
+		s.executor.EXPECT().RunJob(
+			exec.JobParams{
+				ID:         "42",
+				PodName:    "gitlab-xxxx",
+				Commands:   []string{"./actions/migrate"},
+				Env:        []string{"AAAA=1111"},
+				WorkingDir: unitPaths.GetCharmDir(),
+				Files: []exec.JobFile{{
+					Src:  charmDir,
+					Dest: unitPaths.GetCharmDir(),
+				}},
+				Stdout: stdout,
+			}, cancel,
+		).DoAndReturn(func(...interface{}) error {
+			stdout.WriteString("migrated")
+			return errors.Trace(k8sexec.CodeExitError{Code: 2, Err: errors.New("job failed")})
+		}),
+	)
+
+	result, err := runnerExecFunc(
+		runner.ExecParams{
+			Commands:   []string{"./actions/migrate"},
+			Env:        []string{"AAAA=1111"},
+			WorkingDir: unitPaths.GetCharmDir(),
+			Stdout:     stdout,
+			Stderr:     stderr,
+			Cancel:     cancel,
+			RunAsJob:   true,
+			ActionID:   "42",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "job failed")
+	c.Assert(result, jc.DeepEquals, &utilexec.ExecResponse{
+		Code:   2,
+		Stdout: []byte("migrated"),
+	})
+}
+
 type exitError struct {
 	code int
 	err  string
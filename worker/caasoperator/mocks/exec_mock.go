@@ -57,3 +57,15 @@ func (m *MockExecutor) Exec(arg0 exec.ExecParams, arg1 <-chan struct{}) error {
 func (mr *MockExecutorMockRecorder) Exec(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockExecutor)(nil).Exec), arg0, arg1)
 }
+
+// RunJob mocks base method
+func (m *MockExecutor) RunJob(arg0 exec.JobParams, arg1 <-chan struct{}) error {
+	ret := m.ctrl.Call(m, "RunJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunJob indicates an expected call of RunJob
+func (mr *MockExecutorMockRecorder) RunJob(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunJob", reflect.TypeOf((*MockExecutor)(nil).RunJob), arg0, arg1)
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/worker.v1/dependency"
+)
+
+const depEngineMetricsNamespace = "juju_dependency_engine"
+
+var (
+	workerStartsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(depEngineMetricsNamespace, "", "worker_starts_total"),
+		"Number of times each worker in the dependency engine has been started.",
+		[]string{"worker"},
+		nil,
+	)
+	workerRunningDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(depEngineMetricsNamespace, "", "worker_running"),
+		"Whether each worker in the dependency engine is running (1) or not (0).",
+		[]string{"worker"},
+		nil,
+	)
+)
+
+// depEngineCollector is a prometheus.Collector that reports on the
+// workers in a dependency engine.
+type depEngineCollector struct {
+	reporter DepEngineReporter
+}
+
+// NewDepEngineCollector returns a prometheus.Collector that reports how
+// many times each worker in the dependency engine has been started,
+// and whether it is currently running. A worker that has been started
+// more than once has been restarted.
+func NewDepEngineCollector(reporter DepEngineReporter) prometheus.Collector {
+	return depEngineCollector{reporter: reporter}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c depEngineCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workerStartsDesc
+	ch <- workerRunningDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c depEngineCollector) Collect(ch chan<- prometheus.Metric) {
+	manifolds, _ := c.reporter.Report()[dependency.KeyManifolds].(map[string]interface{})
+	for name, value := range manifolds {
+		report, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if starts, ok := report[dependency.KeyStartCount].(int); ok {
+			ch <- prometheus.MustNewConstMetric(
+				workerStartsDesc, prometheus.CounterValue, float64(starts), name,
+			)
+		}
+		var running float64
+		if report[dependency.KeyState] == "started" {
+			running = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			workerRunningDesc, prometheus.GaugeValue, running, name,
+		)
+	}
+}
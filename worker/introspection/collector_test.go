@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/introspection"
+)
+
+type collectorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&collectorSuite{})
+
+type reportFunc func() map[string]interface{}
+
+func (f reportFunc) Report() map[string]interface{} {
+	return f()
+}
+
+func (s *collectorSuite) TestDepEngineCollector(c *gc.C) {
+	reporter := reportFunc(func() map[string]interface{} {
+		return map[string]interface{}{
+			"state": "started",
+			"manifolds": map[string]interface{}{
+				"api-caller": map[string]interface{}{
+					"state":       "started",
+					"start-count": 3,
+				},
+				"uniter": map[string]interface{}{
+					"state":       "stopped",
+					"start-count": 1,
+				},
+			},
+		}
+	})
+	collector := introspection.NewDepEngineCollector(reporter)
+	err := testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP juju_dependency_engine_worker_running Whether each worker in the dependency engine is running (1) or not (0).
+# TYPE juju_dependency_engine_worker_running gauge
+juju_dependency_engine_worker_running{worker="api-caller"} 1
+juju_dependency_engine_worker_running{worker="uniter"} 0
+# HELP juju_dependency_engine_worker_starts_total Number of times each worker in the dependency engine has been started.
+# TYPE juju_dependency_engine_worker_starts_total counter
+juju_dependency_engine_worker_starts_total{worker="api-caller"} 3
+juju_dependency_engine_worker_starts_total{worker="uniter"} 1
+`[1:]))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *collectorSuite) TestDepEngineCollectorNoManifolds(c *gc.C) {
+	reporter := reportFunc(func() map[string]interface{} {
+		return map[string]interface{}{"state": "stopping"}
+	})
+	collector := introspection.NewDepEngineCollector(reporter)
+	err := testutil.CollectAndCompare(collector, strings.NewReader(""))
+	c.Assert(err, jc.ErrorIsNil)
+}
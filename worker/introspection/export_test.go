@@ -0,0 +1,12 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import "gopkg.in/juju/worker.v1"
+
+// MetricsWorkerAddr returns the address the metrics worker is
+// listening on.
+func MetricsWorkerAddr(w worker.Worker) string {
+	return w.(*metricsListener).listener.Addr().String()
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+)
+
+// MetricsConfig describes the arguments required to create the
+// metrics worker.
+type MetricsConfig struct {
+	// ListenAddress is the TCP address the metrics are served on.
+	ListenAddress string
+
+	// Username is the HTTP basic authentication username that must be
+	// supplied to read the metrics.
+	Username string
+
+	// PasswordHash and PasswordSalt identify the HTTP basic
+	// authentication password, hashed with utils.UserPasswordHash.
+	PasswordHash string
+	PasswordSalt string
+
+	// TLSConfig holds the certificate the metrics are served with.
+	TLSConfig *tls.Config
+
+	// PrometheusGatherer supplies the metrics that are served.
+	PrometheusGatherer prometheus.Gatherer
+
+	// UnitSocketName, if set, returns the name of the introspection
+	// socket of the given unit's agent. The metrics of unit agents
+	// running on the machine are then served on /units/<unit-tag>/metrics.
+	UnitSocketName func(names.UnitTag) string
+}
+
+// Validate checks the config values to assert they are valid to create
+// the worker.
+func (c *MetricsConfig) Validate() error {
+	if c.ListenAddress == "" {
+		return errors.NotValidf("empty ListenAddress")
+	}
+	if c.Username == "" {
+		return errors.NotValidf("empty Username")
+	}
+	if c.PasswordHash == "" {
+		return errors.NotValidf("empty PasswordHash")
+	}
+	if c.PasswordSalt == "" {
+		return errors.NotValidf("empty PasswordSalt")
+	}
+	if c.TLSConfig == nil {
+		return errors.NotValidf("nil TLSConfig")
+	}
+	if c.PrometheusGatherer == nil {
+		return errors.NotValidf("nil PrometheusGatherer")
+	}
+	return nil
+}
+
+// metricsListener is a worker and constructed with NewMetricsWorker.
+type metricsListener struct {
+	tomb     tomb.Tomb
+	listener net.Listener
+	server   *http.Server
+	done     chan struct{}
+}
+
+// NewMetricsWorker starts an https server listening on the configured
+// TCP address, which serves the agent's prometheus metrics on /metrics
+// to clients that supply the configured credentials. Unlike the
+// introspection socket, the metrics can be scraped from other
+// machines.
+func NewMetricsWorker(config MetricsConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	l, err := tls.Listen("tcp", config.ListenAddress, config.TLSConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to listen for metrics requests")
+	}
+	logger.Debugf("metrics worker listening on %q", l.Addr())
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(config.PrometheusGatherer, promhttp.HandlerOpts{}))
+	if config.UnitSocketName != nil {
+		mux.Handle("/units/", unitMetricsHandler{socketName: config.UnitSocketName})
+	}
+	w := &metricsListener{
+		listener: l,
+		server: &http.Server{Handler: basicAuthHandler{
+			username:     config.Username,
+			passwordHash: config.PasswordHash,
+			passwordSalt: config.PasswordSalt,
+			handler:      mux,
+		}},
+		done: make(chan struct{}),
+	}
+	go w.serve()
+	w.tomb.Go(w.run)
+	return w, nil
+}
+
+func (w *metricsListener) serve() {
+	defer close(w.done)
+	w.server.Serve(w.listener)
+}
+
+func (w *metricsListener) run() error {
+	defer logger.Debugf("metrics worker finished")
+	<-w.tomb.Dying()
+	w.server.Close()
+	// Don't mark the worker as done until the serve goroutine has finished.
+	<-w.done
+	return nil
+}
+
+// Kill implements worker.Worker.
+func (w *metricsListener) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *metricsListener) Wait() error {
+	return w.tomb.Wait()
+}
+
+// basicAuthHandler only passes requests with the expected basic
+// authentication credentials on to the wrapped handler.
+type basicAuthHandler struct {
+	username     string
+	passwordHash string
+	passwordSalt string
+	handler      http.Handler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h basicAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || !secureCompare(username, h.username) ||
+		!secureCompare(utils.UserPasswordHash(password, h.passwordSalt), h.passwordHash) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="juju"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// unitMetricsHandler serves the metrics of a unit agent, read from the
+// agent's introspection socket, on /units/<unit-tag>/metrics.
+type unitMetricsHandler struct {
+	socketName func(names.UnitTag) string
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h unitMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/units/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[1] != "metrics" {
+		http.NotFound(w, r)
+		return
+	}
+	tag, err := names.ParseUnitTag(parts[0])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	socketPath := "@" + h.socketName(tag)
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "http"
+			req.URL.Host = "unit"
+			req.URL.Path = "/metrics"
+			// The unit agent's socket doesn't expect the credentials
+			// supplied to the machine agent.
+			req.Header.Del("Authorization")
+		},
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+			DisableKeepAlives: true,
+		},
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			logger.Debugf("reading metrics of %s: %v", tag.Id(), err)
+			http.Error(w, "unit agent metrics unavailable", http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/cert"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/introspection"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+	worker worker.Worker
+	url    string
+	client *http.Client
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(&cert.NewLeafKeyBits, 1024)
+	certPEM, keyPEM, err := cert.NewDefaultServer(coretesting.CACert, coretesting.CAKey, []string{"127.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	serverCert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	c.Assert(err, jc.ErrorIsNil)
+	roots := x509.NewCertPool()
+	roots.AddCert(coretesting.CACertX509)
+	s.client = &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: roots},
+	}}
+
+	w, err := introspection.NewMetricsWorker(introspection.MetricsConfig{
+		ListenAddress:      "127.0.0.1:0",
+		Username:           "prometheus",
+		PasswordHash:       utils.UserPasswordHash("s3cret", "salt"),
+		PasswordSalt:       "salt",
+		TLSConfig:          &tls.Config{Certificates: []tls.Certificate{serverCert}},
+		PrometheusGatherer: newPrometheusGatherer(),
+		UnitSocketName: func(tag names.UnitTag) string {
+			return "metrics-test-" + tag.String()
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.worker = w
+	s.url = "https://" + introspection.MetricsWorkerAddr(w) + "/metrics"
+	s.AddCleanup(func(c *gc.C) {
+		workertest.CheckKill(c, w)
+	})
+}
+
+func (s *metricsSuite) get(c *gc.C, username, password string) (*http.Response, string) {
+	req, err := http.NewRequest("GET", s.url, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := s.client.Do(req)
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	return resp, string(body)
+}
+
+func (s *metricsSuite) TestConfigValidation(c *gc.C) {
+	for i, test := range []struct {
+		config introspection.MetricsConfig
+		err    string
+	}{{
+		config: introspection.MetricsConfig{},
+		err:    "empty ListenAddress not valid",
+	}, {
+		config: introspection.MetricsConfig{ListenAddress: ":0"},
+		err:    "empty Username not valid",
+	}, {
+		config: introspection.MetricsConfig{ListenAddress: ":0", Username: "prometheus"},
+		err:    "empty PasswordHash not valid",
+	}, {
+		config: introspection.MetricsConfig{ListenAddress: ":0", Username: "prometheus", PasswordHash: "hash"},
+		err:    "empty PasswordSalt not valid",
+	}, {
+		config: introspection.MetricsConfig{
+			ListenAddress: ":0",
+			Username:      "prometheus",
+			PasswordHash:  "hash",
+			PasswordSalt:  "salt",
+		},
+		err: "nil TLSConfig not valid",
+	}, {
+		config: introspection.MetricsConfig{
+			ListenAddress: ":0",
+			Username:      "prometheus",
+			PasswordHash:  "hash",
+			PasswordSalt:  "salt",
+			TLSConfig:     &tls.Config{},
+		},
+		err: "nil PrometheusGatherer not valid",
+	}} {
+		c.Logf("test %d", i)
+		w, err := introspection.NewMetricsWorker(test.config)
+		c.Check(w, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *metricsSuite) TestMetrics(c *gc.C) {
+	resp, body := s.get(c, "prometheus", "s3cret")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(body, jc.Contains, "# HELP tau Tau.\n# TYPE tau counter\ntau 6.283185\n")
+}
+
+func (s *metricsSuite) TestMissingCredentials(c *gc.C) {
+	resp, body := s.get(c, "", "")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+	c.Assert(resp.Header.Get("WWW-Authenticate"), gc.Equals, `Basic realm="juju"`)
+	c.Assert(body, gc.Equals, "unauthorized\n")
+}
+
+func (s *metricsSuite) TestBadCredentials(c *gc.C) {
+	resp, _ := s.get(c, "prometheus", "wrong")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+	resp, _ = s.get(c, "someone", "s3cret")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+}
+
+func (s *metricsSuite) TestOtherPathsNotServed(c *gc.C) {
+	s.url = "https://" + introspection.MetricsWorkerAddr(s.worker) + "/debug/pprof/"
+	resp, _ := s.get(c, "prometheus", "s3cret")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusNotFound)
+}
+
+func (s *metricsSuite) TestPlainHTTPRejected(c *gc.C) {
+	resp, err := http.Get("http://" + introspection.MetricsWorkerAddr(s.worker) + "/metrics")
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusBadRequest)
+}
+
+func (s *metricsSuite) TestUnitMetrics(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection sockets are only supported on linux")
+	}
+	unitWorker, err := introspection.NewWorker(introspection.Config{
+		SocketName:         "metrics-test-unit-mysql-0",
+		PrometheusGatherer: newPrometheusGatherer(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, unitWorker)
+
+	s.url = "https://" + introspection.MetricsWorkerAddr(s.worker) + "/units/unit-mysql-0/metrics"
+	resp, body := s.get(c, "prometheus", "s3cret")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(body, jc.Contains, "tau 6.283185\n")
+
+	resp, _ = s.get(c, "", "")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+}
+
+func (s *metricsSuite) TestUnitMetricsUnavailable(c *gc.C) {
+	s.url = "https://" + introspection.MetricsWorkerAddr(s.worker) + "/units/unit-wordpress-1/metrics"
+	resp, _ := s.get(c, "prometheus", "s3cret")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusBadGateway)
+
+	s.url = "https://" + introspection.MetricsWorkerAddr(s.worker) + "/units/machine-0/metrics"
+	resp, _ = s.get(c, "prometheus", "s3cret")
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusNotFound)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	metricsCertFile = "metrics-cert.pem"
+	metricsKeyFile  = "metrics-key.pem"
+)
+
+// metricsCertificate returns the self-signed certificate the agent
+// serves metrics with. The certificate is created in dir the first
+// time, and reused after that so that scrapers can pin it.
+func metricsCertificate(dir string) (tls.Certificate, error) {
+	certPath := filepath.Join(dir, metricsCertFile)
+	keyPath := filepath.Join(dir, metricsKeyFile)
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err == nil {
+		return cert, nil
+	}
+	if _, statErr := os.Stat(certPath); !os.IsNotExist(statErr) {
+		return tls.Certificate{}, errors.Annotate(err, "loading metrics certificate")
+	}
+
+	certPEM, keyPEM, err := newMetricsCertificate()
+	if err != nil {
+		return tls.Certificate{}, errors.Annotate(err, "generating metrics certificate")
+	}
+	// The key is written first, as the certificate marks the pair as
+	// complete.
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return tls.Certificate{}, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil {
+		return tls.Certificate{}, errors.Trace(err)
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// newMetricsCertificate returns a new self-signed server certificate
+// and key, in PEM format, for the machine's hostname.
+func newMetricsCertificate() (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+	now := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   dnsNames[len(dnsNames)-1],
+			Organization: []string{"juju"},
+		},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+)
+
+// MetricsFacade provides the port and credentials needed to serve an
+// agent's metrics.
+type MetricsFacade interface {
+	MetricsConfig() (agent.MetricsConfig, error)
+}
+
+// NewMetricsFacade returns a MetricsFacade backed by the Agent facade.
+func NewMetricsFacade(apiCaller base.APICaller) (MetricsFacade, error) {
+	facade, err := agent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+// MetricsManifoldConfig holds the information necessary to run the
+// metrics worker in a dependency engine.
+type MetricsManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	PrometheusGatherer prometheus.Gatherer
+	UnitSocketName     func(names.UnitTag) string
+
+	NewFacade func(base.APICaller) (MetricsFacade, error)
+	NewWorker func(MetricsConfig) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config MetricsManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.PrometheusGatherer == nil {
+		return errors.NotValidf("nil PrometheusGatherer")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// MetricsManifold returns a dependency.Manifold that runs the metrics
+// worker when the controller is configured with an agent-metrics-port.
+// The worker serves with a self-signed certificate kept in the agent's
+// data directory, and reads the port and credentials when it starts,
+// so changes to them take effect when the agent restarts.
+func MetricsManifold(config MetricsManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on MetricsManifoldConfig because it's more readable
+// than a closure.
+func (config MetricsManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var a coreagent.Agent
+	if err := context.Get(config.AgentName, &a); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	metricsConfig, err := facade.MetricsConfig()
+	if errors.IsNotFound(err) {
+		logger.Debugf("agent-metrics-port not set, not serving metrics")
+		return nil, dependency.ErrUninstall
+	} else if err != nil {
+		return nil, errors.Annotate(err, "unable to get metrics config")
+	}
+
+	cert, err := metricsCertificate(a.CurrentConfig().DataDir())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(MetricsConfig{
+		ListenAddress:      fmt.Sprintf(":%d", metricsConfig.Port),
+		Username:           metricsConfig.Username,
+		PasswordHash:       metricsConfig.PasswordHash,
+		PasswordSalt:       metricsConfig.PasswordSalt,
+		TLSConfig:          &tls.Config{Certificates: []tls.Certificate{cert}},
+		PrometheusGatherer: config.PrometheusGatherer,
+		UnitSocketName:     config.UnitSocketName,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
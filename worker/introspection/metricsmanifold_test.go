@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/introspection"
+)
+
+type metricsManifoldSuite struct {
+	testing.IsolationSuite
+
+	config  introspection.MetricsManifoldConfig
+	facade  *fakeMetricsFacade
+	agent   *fakeAgent
+	context dependency.Context
+}
+
+var _ = gc.Suite(&metricsManifoldSuite{})
+
+func (s *metricsManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeMetricsFacade{
+		config: agent.MetricsConfig{
+			Port:         17072,
+			Username:     "prometheus",
+			PasswordHash: "hash",
+			PasswordSalt: "salt",
+		},
+	}
+	s.agent = &fakeAgent{dataDir: c.MkDir()}
+	s.context = dt.StubContext(nil, map[string]interface{}{
+		"agent":      s.agent,
+		"api-caller": &struct{ base.APICaller }{},
+	})
+	s.config = introspection.MetricsManifoldConfig{
+		AgentName:          "agent",
+		APICallerName:      "api-caller",
+		PrometheusGatherer: newPrometheusGatherer(),
+		NewFacade: func(base.APICaller) (introspection.MetricsFacade, error) {
+			return s.facade, nil
+		},
+		NewWorker: func(introspection.MetricsConfig) (worker.Worker, error) {
+			return nil, errors.New("unexpected")
+		},
+	}
+}
+
+func (s *metricsManifoldSuite) TestInputs(c *gc.C) {
+	manifold := introspection.MetricsManifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, []string{"agent", "api-caller"})
+}
+
+func (s *metricsManifoldSuite) TestValidate(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+	for i, test := range []struct {
+		f      func(*introspection.MetricsManifoldConfig)
+		expect string
+	}{{
+		f:      func(config *introspection.MetricsManifoldConfig) { config.AgentName = "" },
+		expect: "empty AgentName not valid",
+	}, {
+		f:      func(config *introspection.MetricsManifoldConfig) { config.APICallerName = "" },
+		expect: "empty APICallerName not valid",
+	}, {
+		f:      func(config *introspection.MetricsManifoldConfig) { config.PrometheusGatherer = nil },
+		expect: "nil PrometheusGatherer not valid",
+	}, {
+		f:      func(config *introspection.MetricsManifoldConfig) { config.NewFacade = nil },
+		expect: "nil NewFacade not valid",
+	}, {
+		f:      func(config *introspection.MetricsManifoldConfig) { config.NewWorker = nil },
+		expect: "nil NewWorker not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.f(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *metricsManifoldSuite) TestMissingAPICaller(c *gc.C) {
+	manifold := introspection.MetricsManifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      s.agent,
+		"api-caller": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *metricsManifoldSuite) TestUninstallWithoutPort(c *gc.C) {
+	s.facade.err = errors.NotFoundf("agent-metrics-port")
+	manifold := introspection.MetricsManifold(s.config)
+	_, err := manifold.Start(s.context)
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *metricsManifoldSuite) TestStartMetricsConfigError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	manifold := introspection.MetricsManifold(s.config)
+	_, err := manifold.Start(s.context)
+	c.Check(err, gc.ErrorMatches, "unable to get metrics config: boom")
+}
+
+func (s *metricsManifoldSuite) TestStart(c *gc.C) {
+	var apiCaller struct{ base.APICaller }
+	s.config.NewFacade = func(caller base.APICaller) (introspection.MetricsFacade, error) {
+		c.Check(caller, gc.Equals, &apiCaller)
+		return s.facade, nil
+	}
+	var servedCert []byte
+	expectWorker := workertest.NewErrorWorker(nil)
+	s.config.NewWorker = func(config introspection.MetricsConfig) (worker.Worker, error) {
+		c.Check(config.ListenAddress, gc.Equals, ":17072")
+		c.Check(config.Username, gc.Equals, "prometheus")
+		c.Check(config.PasswordHash, gc.Equals, "hash")
+		c.Check(config.PasswordSalt, gc.Equals, "salt")
+		c.Check(config.PrometheusGatherer, gc.Equals, s.config.PrometheusGatherer)
+		c.Assert(config.TLSConfig, gc.NotNil)
+		c.Assert(config.TLSConfig.Certificates, gc.HasLen, 1)
+		servedCert = config.TLSConfig.Certificates[0].Certificate[0]
+		return expectWorker, nil
+	}
+	manifold := introspection.MetricsManifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      s.agent,
+		"api-caller": &apiCaller,
+	})
+	w, err := manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, expectWorker)
+	workertest.CleanKill(c, w)
+
+	// The certificate is self-signed rather than issued by the
+	// controller, and kept in the data dir.
+	cert, err := x509.ParseCertificate(servedCert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cert.CheckSignatureFrom(cert), jc.ErrorIsNil)
+	c.Check(cert.VerifyHostname("localhost"), jc.ErrorIsNil)
+	info, err := os.Stat(filepath.Join(s.agent.dataDir, "metrics-key.pem"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	// The same certificate is served after a restart.
+	firstCert := servedCert
+	w, err = manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+	c.Check(servedCert, gc.DeepEquals, firstCert)
+}
+
+type fakeMetricsFacade struct {
+	config agent.MetricsConfig
+	err    error
+}
+
+func (f *fakeMetricsFacade) MetricsConfig() (agent.MetricsConfig, error) {
+	return f.config, f.err
+}
+
+type fakeAgent struct {
+	coreagent.Agent
+	dataDir string
+}
+
+func (a *fakeAgent) CurrentConfig() coreagent.Config {
+	return fakeAgentConfig{dataDir: a.dataDir}
+}
+
+type fakeAgentConfig struct {
+	coreagent.Config
+	dataDir string
+}
+
+func (c fakeAgentConfig) DataDir() string {
+	return c.dataDir
+}
@@ -113,6 +113,10 @@ juju_pubsub_report () {
   juju_agent pubsub $@
 }
 
+juju_hook_history () {
+  juju_agent hookhistory $@
+}
+
 juju_metrics () {
   juju_agent metrics/ $@
 }
@@ -148,6 +152,7 @@ if [ "$shell" = "bash" ]; then
   export -f juju_statepool_report
   export -f juju_statetracker_report
   export -f juju_pubsub_report
+  export -f juju_hook_history
   export -f juju_presence_report
   export -f juju_machine_lock
 fi
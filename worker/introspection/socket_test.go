@@ -142,6 +142,12 @@ func (s *introspectionSuite) TestMissingPubSubReporter(c *gc.C) {
 	matches(c, buf, "PubSub Report: missing reporter")
 }
 
+func (s *introspectionSuite) TestMissingHookHistoryReporter(c *gc.C) {
+	buf := s.call(c, "/hookhistory")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Hook History Report: missing reporter")
+}
+
 func (s *introspectionSuite) TestMissingMachineLock(c *gc.C) {
 	buf := s.call(c, "/machinelock/")
 	matches(c, buf, "404 Not Found")
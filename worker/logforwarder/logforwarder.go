@@ -44,6 +44,13 @@ type sender interface {
 // TODO(ericsnow) It is likely that eventually we will want to support
 // multiplexing to multiple senders, each in its own goroutine (or worker).
 
+// sinkRecords holds log records read from the stream opened for
+// the named sink.
+type sinkRecords struct {
+	sink    string
+	records []logfwd.Record
+}
+
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
@@ -52,6 +59,7 @@ type LogForwarder struct {
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+	sinkName  string
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -65,12 +73,9 @@ type OpenLogForwarderArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Name is the name given to the log sink.
-	Name string
-
-	// OpenSink is the function that opens the underlying log sink that
-	// will be wrapped.
-	OpenSink LogSinkFn
+	// Sinks are the log sinks that may be forwarded to. The sink used
+	// is the one whose type is selected by the log forwarding config.
+	Sinks []LogSinkSpec
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -79,7 +84,40 @@ type OpenLogForwarderArgs struct {
 	Logger Logger
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// readConfig reads the current log forwarding config for all sinks.
+func (lf *LogForwarder) readConfig() (*SinkConfig, bool, error) {
+	syslogCfg, syslogOK, err := lf.args.LogForwardConfig.LogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	httpCfg, httpOK, err := lf.args.LogForwardConfig.HTTPLogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !syslogOK && !httpOK {
+		return nil, false, nil
+	}
+	cfg := &SinkConfig{}
+	if syslogOK {
+		cfg.Syslog = syslogCfg
+	}
+	if httpOK {
+		cfg.HTTP = httpCfg
+	}
+	return cfg, true, nil
+}
+
+// sinkSpec returns the spec for the log sink of the given type.
+func (lf *LogForwarder) sinkSpec(sinkType string) (LogSinkSpec, bool) {
+	for _, spec := range lf.args.Sinks {
+		if spec.Type == sinkType {
+			return spec, true
+		}
+	}
+	return LogSinkSpec{}, false
+}
+
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -94,12 +132,12 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	cfg, ok, err := lf.readConfig()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
+	if !ok || !cfg.Enabled() {
 		lf.args.Logger.Infof("config change - log forwarding not enabled")
 		return nil, closeExisting()
 	}
@@ -111,20 +149,32 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		lf.args.Logger.Errorf("invalid log forward config change: %v", err)
 		return currentSender, nil
 	}
+	spec, ok := lf.sinkSpec(cfg.Type())
+	if !ok {
+		lf.args.Logger.Errorf("log forwarding to %q sink not supported", cfg.Type())
+		return currentSender, nil
+	}
 
 	// Shutdown the existing sink since we need to now create a new one.
 	if err := closeExisting(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if lf.sinkName != "" && lf.sinkName != spec.Name {
+		// The log stream resumes from the last record sent to the
+		// sink it was opened for, so the streaming goroutine
+		// reopens it for the new sink when it sees the change.
+		lf.args.Logger.Infof("log forwarding sink changed from %q to %q", lf.sinkName, spec.Name)
+	}
 	sink, err := OpenTrackingSink(TrackingSinkArgs{
-		Name:     lf.args.Name,
+		Name:     spec.Name,
 		Config:   cfg,
 		Caller:   lf.args.Caller,
-		OpenSink: lf.args.OpenSink,
+		OpenSink: spec.OpenFn,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	lf.sinkName = spec.Name
 	lf.enabledCh <- true
 	return sink, nil
 }
@@ -148,7 +198,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		lf.args.Logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		lf.args.Logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.sinkName)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -182,9 +232,15 @@ func (lf *LogForwarder) loop() error {
 		return errors.Trace(err)
 	}
 
-	records := make(chan []logfwd.Record)
+	records := make(chan sinkRecords)
 	var stream LogStream
+	var streamSink string
 	go func() {
+		defer func() {
+			if closer, ok := stream.(io.Closer); ok {
+				closer.Close()
+			}
+		}()
 		for {
 			enabled, err := lf.waitForEnabled()
 			if err == tomb.ErrDying {
@@ -193,10 +249,23 @@ func (lf *LogForwarder) loop() error {
 			if !enabled {
 				continue
 			}
+			lf.mu.Lock()
+			sinkName := lf.sinkName
+			lf.mu.Unlock()
+			// The stream is tied to the sink it was opened for,
+			// so open a new one when the sink changes.
+			if stream != nil && streamSink != sinkName {
+				if closer, ok := stream.(io.Closer); ok {
+					if err := closer.Close(); err != nil {
+						lf.args.Logger.Errorf("closing log stream for %q sink: %v", streamSink, err)
+					}
+				}
+				stream = nil
+			}
 			// Lazily create log streamer if needed.
 			if stream == nil {
 				streamCfg := params.LogStreamConfig{
-					Sink: lf.args.Name,
+					Sink: sinkName,
 					// TODO(wallyworld) - this should be configurable via lf.args.LogForwardConfig
 					MaxLookbackRecords: 100,
 				}
@@ -205,7 +274,7 @@ func (lf *LogForwarder) loop() error {
 					lf.catacomb.Kill(errors.Annotate(err, "creating log stream"))
 					break
 				}
-
+				streamSink = sinkName
 			}
 			rec, err := stream.Next()
 			if err != nil {
@@ -215,7 +284,7 @@ func (lf *LogForwarder) loop() error {
 			select {
 			case <-lf.catacomb.Dying():
 				return
-			case records <- rec: // Wait until the last one is sent.
+			case records <- sinkRecords{sink: streamSink, records: rec}: // Wait until the last one is sent.
 			}
 		}
 	}()
@@ -233,7 +302,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -242,7 +311,16 @@ func (lf *LogForwarder) loop() error {
 			if sender == nil {
 				continue
 			}
-			if err := sender.Send(rec); err != nil {
+			lf.mu.Lock()
+			sinkName := lf.sinkName
+			lf.mu.Unlock()
+			if rec.sink != sinkName {
+				// The records were read from the stream of the
+				// previous sink; the new sink's stream will
+				// deliver them again if they are unsent.
+				continue
+			}
+			if err := sender.Send(rec.records); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -26,9 +27,10 @@ import (
 type LogForwarderSuite struct {
 	testing.IsolationSuite
 
-	stream *stubStream
-	sender *stubSender
-	rec    logfwd.Record
+	stream     *stubStream
+	sender     *stubSender
+	rec        logfwd.Record
+	streamSink string
 }
 
 var _ = gc.Suite(&LogForwarderSuite{})
@@ -85,15 +87,30 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
-			sink := &logforwarder.LogSink{
-				sender,
-			}
-			return sink, nil
-		},
-		OpenLogStream: func(_ base.APICaller, _ params.LogStreamConfig, controllerUUID string) (logforwarder.LogStream, error) {
+		Sinks: []logforwarder.LogSinkSpec{{
+			Name: "test-syslog",
+			Type: logforwarder.SinkTypeSyslog,
+			OpenFn: func(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+				sender.host = cfg.Syslog.Host
+				sink := &logforwarder.LogSink{
+					sender,
+				}
+				return sink, nil
+			},
+		}, {
+			Name: "test-loki",
+			Type: "loki",
+			OpenFn: func(cfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+				sender.host = cfg.HTTP.URL
+				sink := &logforwarder.LogSink{
+					sender,
+				}
+				return sink, nil
+			},
+		}},
+		OpenLogStream: func(_ base.APICaller, cfg params.LogStreamConfig, controllerUUID string) (logforwarder.LogStream, error) {
 			c.Assert(controllerUUID, gc.Equals, "feebdaed-2f18-4fd2-967d-db9663db7bea")
+			s.streamSink = cfg.Sink
 			return stream, nil
 		},
 		Logger: loggo.GetLogger("test"),
@@ -153,6 +170,88 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestHTTPSink(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled: true,
+		sink:    "loki",
+		url:     "https://loki.example.com",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, s.rec)
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	c.Check(s.streamSink, gc.Equals, "test-loki")
+	rec := s.rec
+	rec.Message = "send to https://loki.example.com"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestSinkChange(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+	rec2 := s.rec
+	rec2.ID = 12
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+	c.Check(s.streamSink, gc.Equals, "test-syslog")
+
+	api.sink = "loki"
+	api.url = "https://loki.example.com"
+	api.changes <- struct{}{}
+	s.sender.waitForClose(c)
+
+	// The record read from the syslog sink's stream is dropped, and
+	// the stream is reopened for the loki sink.
+	s.stream.addRecords(c, rec1, rec2)
+	s.sender.waitForSend(c)
+	c.Check(s.streamSink, gc.Equals, "test-loki")
+
+	workertest.CleanKill(c, lf)
+
+	rec0.Message = "send to 10.0.0.1"
+	rec2.Message = "send to https://loki.example.com"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Close", nil},
+		{"Send", []interface{}{[]logfwd.Record{rec2}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestUnsupportedSink(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled: true,
+		sink:    "elasticsearch",
+		url:     "https://es.example.com",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	// There is no elasticsearch sink registered, so nothing is sent.
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -210,6 +309,8 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
+	sink    string
+	url     string
 	changes chan struct{}
 }
 
@@ -251,7 +352,7 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 
 func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	return &syslog.RawConfig{
-		Enabled:    c.enabled,
+		Enabled:    c.enabled && c.sink == "",
 		Host:       c.host,
 		CACert:     coretesting.CACert,
 		ClientCert: coretesting.ServerCert,
@@ -259,6 +360,17 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) HTTPLogForwardConfig() (*logfwdhttp.RawConfig, bool, error) {
+	if c.sink == "" {
+		return nil, false, nil
+	}
+	return &logfwdhttp.RawConfig{
+		Enabled: c.enabled,
+		Format:  logfwdhttp.Format(c.sink),
+		URL:     c.url,
+	}, true, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
@@ -27,7 +27,7 @@ type ManifoldConfig struct {
 	APICallerName string
 
 	// Sinks are the named functions that opens the underlying log sinks
-	// to which log records will be forwarded.
+	// to which log records may be forwarded.
 	Sinks []LogSinkSpec
 
 	// OpenLogStream is the function that will be used to for the
@@ -26,7 +26,8 @@ type OrchestratorArgs struct {
 	Caller base.APICaller
 
 	// Sinks are the named functions that open the underlying log sinks
-	// to which log records will be forwarded.
+	// to which log records may be forwarded. The sink used is the one
+	// whose type is selected by the log forwarding config.
 	Sinks []LogSinkSpec
 
 	// OpenLogStream is the function that will be used to for the
@@ -40,20 +41,24 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
+	// For now we work with only 1 forwarder, which sends to the
+	// configured sink. Later we can have a proper orchestrator that
+	// spawns a sub-worker for each log sink.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	seen := make(map[string]bool)
+	for _, spec := range args.Sinks {
+		if seen[spec.Type] {
+			return nil, errors.Errorf("multiple log sinks of type %q", spec.Type)
+		}
+		seen[spec.Type] = true
 	}
 	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
 		ControllerUUID:   args.ControllerUUID,
 		LogForwardConfig: args.LogForwardConfig,
 		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
+		Sinks:            args.Sinks,
 		OpenLogStream:    args.OpenLogStream,
 		Logger:           args.Logger,
 	})
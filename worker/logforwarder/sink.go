@@ -4,30 +4,81 @@
 package logforwarder
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/core/watcher"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
+// SinkTypeSyslog is the type of the syslog log sink, which is used
+// when no other sink type has been configured.
+const SinkTypeSyslog = "syslog"
+
 // LogForwardConfig provides access to the log forwarding config for a model.
 type LogForwardConfig interface {
 	// WatchForLogForwardConfigChanges return a NotifyWatcher waiting for the
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
+	// LogForwardConfig returns the current syslog forwarding configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// HTTPLogForwardConfig returns the current configuration for
+	// forwarding logs to an HTTP log store.
+	HTTPLogForwardConfig() (*logfwdhttp.RawConfig, bool, error)
+}
+
+// SinkConfig holds the log forwarding configuration for a model. The
+// HTTP config is only set when one of the HTTP based sinks is selected.
+type SinkConfig struct {
+	Syslog *syslog.RawConfig
+	HTTP   *logfwdhttp.RawConfig
+}
+
+// Type returns the type of log sink selected by the config.
+func (cfg SinkConfig) Type() string {
+	if cfg.HTTP != nil {
+		return string(cfg.HTTP.Format)
+	}
+	return SinkTypeSyslog
+}
+
+// Enabled returns whether log forwarding is enabled for the selected
+// log sink.
+func (cfg SinkConfig) Enabled() bool {
+	if cfg.HTTP != nil {
+		return cfg.HTTP.Enabled
+	}
+	return cfg.Syslog != nil && cfg.Syslog.Enabled
+}
+
+// Validate ensures that the config for the selected log sink is valid.
+func (cfg SinkConfig) Validate() error {
+	if cfg.HTTP != nil {
+		return errors.Trace(cfg.HTTP.Validate())
+	}
+	if cfg.Syslog != nil {
+		return errors.Trace(cfg.Syslog.Validate())
+	}
+	return nil
 }
 
 type LogSinkSpec struct {
-	// Name is the name of the log sink.
+	// Name is the name of the log sink. The last record sent to the
+	// sink is tracked using this name.
 	Name string
 
+	// Type is the type of the log sink, as selected in the model
+	// config.
+	Type string
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg *SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
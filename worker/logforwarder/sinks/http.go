@@ -0,0 +1,27 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink used to forward log messages to an HTTP log
+// store, in the format selected by the config.
+func OpenHTTP(sinkCfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg := sinkCfg.HTTP
+	if cfg == nil || !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := logfwdhttp.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
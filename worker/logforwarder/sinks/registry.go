@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// All returns the specs of every log sink that logs may be forwarded
+// to. The sink that is used is selected by the logforward-sink model
+// config.
+func All() []logforwarder.LogSinkSpec {
+	return []logforwarder.LogSinkSpec{{
+		Name:   "juju-log-forward",
+		Type:   logforwarder.SinkTypeSyslog,
+		OpenFn: OpenSyslog,
+	}, {
+		Name:   "juju-log-forward-http",
+		Type:   string(logfwdhttp.FormatJSONLines),
+		OpenFn: OpenHTTP,
+	}, {
+		Name:   "juju-log-forward-loki",
+		Type:   string(logfwdhttp.FormatLoki),
+		OpenFn: OpenHTTP,
+	}, {
+		Name:   "juju-log-forward-elasticsearch",
+		Type:   string(logfwdhttp.FormatElasticsearch),
+		OpenFn: OpenHTTP,
+	}}
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type RegistrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) TestAll(c *gc.C) {
+	var types []string
+	names := make(map[string]bool)
+	for _, spec := range sinks.All() {
+		c.Check(spec.OpenFn, gc.NotNil)
+		c.Check(names[spec.Name], jc.IsFalse, gc.Commentf("duplicate sink name %q", spec.Name))
+		names[spec.Name] = true
+		types = append(types, spec.Type)
+	}
+	c.Assert(types, jc.DeepEquals, []string{
+		"syslog",
+		string(logfwdhttp.FormatJSONLines),
+		string(logfwdhttp.FormatLoki),
+		string(logfwdhttp.FormatElasticsearch),
+	})
+}
+
+func (s *RegistrySuite) TestOpenHTTPNotEnabled(c *gc.C) {
+	_, err := sinks.OpenHTTP(&logforwarder.SinkConfig{
+		HTTP: &logfwdhttp.RawConfig{
+			Format: logfwdhttp.FormatLoki,
+			URL:    "https://loki.example.com",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
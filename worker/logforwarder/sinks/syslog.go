@@ -12,8 +12,9 @@ import (
 )
 
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-	if !cfg.Enabled {
+func OpenSyslog(sinkCfg *logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg := sinkCfg.Syslog
+	if cfg == nil || !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
 	client, err := syslog.Open(*cfg)
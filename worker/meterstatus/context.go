@@ -9,6 +9,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/caas"
@@ -97,6 +98,12 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
+// Clock implements runner.Context.
+func (ctx *limitedContext) Clock() context.Clock { return clock.WallClock }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -9,6 +9,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/caas"
@@ -104,6 +105,12 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
+// Clock implements runner.Context.
+func (ctx *hookContext) Clock() context.Clock { return clock.WallClock }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
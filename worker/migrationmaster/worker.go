@@ -78,6 +78,10 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// SetTransferVolume records the data sent to the target
+	// controller while the migration has been in its current phase.
+	SetTransferVolume(coremigration.TransferVolume) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -223,7 +227,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status)
 		case coremigration.PROCESSRELATIONS:
 			phase, err = w.doPROCESSRELATIONS(status)
 		case coremigration.VALIDATION:
@@ -231,11 +235,11 @@ func (w *Worker) run() error {
 		case coremigration.SUCCESS:
 			phase, err = w.doSUCCESS(status)
 		case coremigration.LOGTRANSFER:
-			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doLOGTRANSFER(status)
 		case coremigration.REAP:
 			phase, err = w.doREAP()
 		case coremigration.ABORT:
-			phase, err = w.doABORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doABORT(status)
 		default:
 			return errors.Errorf("unknown phase: %v [%d]", phase.String(), phase)
 		}
@@ -336,6 +340,13 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 		return errors.Annotate(err, "source prechecks failed")
 	}
 
+	if status.Offline {
+		// The source controller may not be able to reach the target
+		// controller at all. The target checked the model when it
+		// was imported there.
+		return nil
+	}
+
 	w.setInfoStatus("performing target prechecks")
 	model, err := w.config.Facade.ModelInfo()
 	if err != nil {
@@ -357,8 +368,14 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 	return errors.Annotate(err, "target prechecks failed")
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+func (w *Worker) doIMPORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Offline {
+		// The model was exported and imported into the target
+		// controller already, so only the agents need to move.
+		w.setInfoStatus("model already imported into target controller")
+		return coremigration.PROCESSRELATIONS, nil
+	}
+	err := w.transferModel(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
 		return coremigration.ABORT, nil
@@ -366,24 +383,56 @@ func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string)
 	return coremigration.PROCESSRELATIONS, nil
 }
 
+// uploadWrapper passes uploads to the target controller for a model,
+// totalling the bytes sent for each kind of binary and reporting the
+// running total after each upload.
 type uploadWrapper struct {
 	client    *migrationtarget.Client
 	modelUUID string
+	sent      coremigration.TransferVolume
+	report    func(coremigration.TransferVolume)
 }
 
 // UploadTools prepends the model UUID to the args passed to the migration client.
 func (w *uploadWrapper) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
-	return w.client.UploadTools(w.modelUUID, r, vers, additionalSeries...)
+	size, err := contentSize(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	list, err := w.client.UploadTools(w.modelUUID, r, vers, additionalSeries...)
+	if err == nil {
+		w.sent.Tools += size
+		w.report(w.sent)
+	}
+	return list, err
 }
 
 // UploadCharm prepends the model UUID to the args passed to the migration client.
 func (w *uploadWrapper) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
-	return w.client.UploadCharm(w.modelUUID, curl, content)
+	size, err := contentSize(content)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl, err = w.client.UploadCharm(w.modelUUID, curl, content)
+	if err == nil {
+		w.sent.Charms += size
+		w.report(w.sent)
+	}
+	return curl, err
 }
 
 // UploadResource prepends the model UUID to the args passed to the migration client.
 func (w *uploadWrapper) UploadResource(res resource.Resource, content io.ReadSeeker) error {
-	return w.client.UploadResource(w.modelUUID, res, content)
+	size, err := contentSize(content)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = w.client.UploadResource(w.modelUUID, res, content)
+	if err == nil {
+		w.sent.Resources += size
+		w.report(w.sent)
+	}
+	return err
 }
 
 // SetPlaceholderResource prepends the model UUID to the args passed to the migration client.
@@ -396,6 +445,23 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resource.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
+// contentSize returns the number of bytes remaining to be read from
+// content, leaving its position unchanged.
+func contentSize(content io.ReadSeeker) (int64, error) {
+	start, err := content.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, errors.Annotate(err, "finding content size")
+	}
+	end, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, errors.Annotate(err, "finding content size")
+	}
+	if _, err := content.Seek(start, io.SeekStart); err != nil {
+		return 0, errors.Annotate(err, "finding content size")
+	}
+	return end - start, nil
+}
+
 func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
 	w.setInfoStatus("exporting model")
 	serialized, err := w.config.Facade.Export()
@@ -410,10 +476,14 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	err = targetClient.Import(serialized)
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
+	// The volume is recorded as each part is sent, so that a stalled
+	// migration shows how far it got.
+	sent := coremigration.TransferVolume{Model: int64(len(serialized.Bytes))}
+	w.setTransferVolume(sent)
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
 		// Simulate a abort causing failure to test last status not over written.
@@ -421,7 +491,12 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 
 	w.setInfoStatus("uploading model binaries into target controller")
-	wrapper := &uploadWrapper{targetClient, modelUUID}
+	wrapper := &uploadWrapper{
+		client:    targetClient,
+		modelUUID: modelUUID,
+		sent:      sent,
+		report:    w.setTransferVolume,
+	}
 	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
 		Charms:          serialized.Charms,
 		CharmDownloader: w.config.CharmDownloader,
@@ -435,7 +510,23 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
 	})
-	return errors.Annotate(err, "failed to migrate binaries")
+	if err != nil {
+		return errors.Annotate(err, "failed to migrate binaries")
+	}
+	volume := wrapper.sent
+	w.logger.Infof("sent %d bytes to target controller (model %d, charms %d, agent binaries %d, resources %d)",
+		volume.Total(), volume.Model, volume.Charms, volume.Tools, volume.Resources)
+	return nil
+}
+
+func (w *Worker) setTransferVolume(volume coremigration.TransferVolume) {
+	w.logger.Debugf("sent %d bytes to target controller (model %d, charms %d, agent binaries %d, resources %d)",
+		volume.Total(), volume.Model, volume.Charms, volume.Tools, volume.Resources)
+	if err := w.config.Facade.SetTransferVolume(volume); err != nil {
+		// As with status messages, failing to record the volume
+		// shouldn't stop the migration.
+		w.logger.Errorf("failed to set transfer volume: %v", err)
+	}
 }
 
 func (w *Worker) doPROCESSRELATIONS(status coremigration.MigrationStatus) (coremigration.Phase, error) {
@@ -465,6 +556,12 @@ func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigrati
 	if !ok {
 		return coremigration.ABORT, nil
 	}
+	if status.Offline {
+		// The imported model's machines were checked when it was
+		// imported, and it is activated on the target controller
+		// with "juju import-model --activate".
+		return coremigration.SUCCESS, nil
+	}
 
 	client, closer, err := w.openTargetAPI(status.TargetInfo)
 	if err != nil {
@@ -522,6 +619,11 @@ func (w *Worker) doSUCCESS(status coremigration.MigrationStatus) (coremigration.
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
+	if status.Offline {
+		// The target controller adopts the model's cloud resources
+		// when the model is activated there.
+		return coremigration.LOGTRANSFER, nil
+	}
 	err = w.transferResources(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
@@ -545,8 +647,14 @@ func (w *Worker) transferResources(targetInfo coremigration.TargetInfo, modelUUI
 	return errors.Trace(err)
 }
 
-func (w *Worker) doLOGTRANSFER(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferLogs(targetInfo, modelUUID)
+func (w *Worker) doLOGTRANSFER(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Offline {
+		// There's no connection to the target controller to send
+		// the logs over.
+		w.setInfoStatus("successful, logs not transferred for offline migration")
+		return coremigration.REAP, nil
+	}
+	err := w.transferLogs(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
@@ -642,9 +750,13 @@ func (w *Worker) doREAP() (coremigration.Phase, error) {
 	return coremigration.DONE, nil
 }
 
-func (w *Worker) doABORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
+func (w *Worker) doABORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Offline {
+		w.setInfoStatus("aborted, remove model from target controller with \"juju import-model --abort\": %s", w.lastFailure)
+		return coremigration.ABORTDONE, nil
+	}
 	w.setInfoStatus("aborted, removing model from target controller: %s", w.lastFailure)
-	if err := w.removeImportedModel(targetInfo, modelUUID); err != nil {
+	if err := w.removeImportedModel(status.TargetInfo, status.ModelUUID); err != nil {
 		// This isn't fatal. Removing the imported model is a best
 		// efforts attempt so just report the error and proceed.
 		w.logger.Warningf("failed to remove model from target controller, %v", err)
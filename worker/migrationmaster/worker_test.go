@@ -244,6 +244,9 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 			{"facade.Export", nil},
 			apiOpenControllerCall,
 			importCall,
+			{"facade.SetTransferVolume", []interface{}{
+				coremigration.TransferVolume{Model: int64(len(fakeModelBytes))},
+			}},
 			{"UploadBinaries", []interface{}{
 				[]string{"charm0", "charm1"},
 				fakeCharmDownloader,
@@ -292,6 +295,82 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	)
 }
 
+func (s *Suite) TestSuccessfulOfflineMigration(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.Offline = true
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(makeMinionReports(coremigration.QUIESCE))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.VALIDATION))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.SUCCESS))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+
+	// The model was imported into the target controller before the
+	// migration started, and is activated there, so the target
+	// controller API is never used.
+	s.stub.CheckCalls(c, joinCalls(
+		// Wait for migration to start.
+		watchStatusLockdownCalls,
+
+		// QUIESCE
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.Prechecks", nil},
+			{"facade.SetPhase", []interface{}{coremigration.IMPORT}},
+
+			// IMPORT
+			{"facade.SetPhase", []interface{}{coremigration.PROCESSRELATIONS}},
+
+			// PROCESSRELATIONS
+			{"facade.ProcessRelations", []interface{}{""}},
+			{"facade.SetPhase", []interface{}{coremigration.VALIDATION}},
+
+			// VALIDATION
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.SetPhase", []interface{}{coremigration.SUCCESS}},
+
+			// SUCCESS
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			// LOGTRANSFER
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+
+			// REAP
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		}),
+	)
+}
+
+func (s *Suite) TestOfflineMigrationAbort(c *gc.C) {
+	status := s.makeStatus(coremigration.VALIDATION)
+	status.Offline = true
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(coremigration.MinionReports{
+		MigrationId:    "model-uuid:2",
+		Phase:          coremigration.VALIDATION,
+		FailedMachines: []string{"42"}, // a machine failed
+	})
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	// The imported model is left for the target controller's
+	// operator to remove.
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+}
+
 func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 	s.facade.queueStatus(s.makeStatus(coremigration.SUCCESS))
@@ -543,6 +622,30 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestUploadBinariesFailure(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.config.UploadBinaries = func(migration.UploadBinariesConfig) error {
+		s.stub.AddCall("UploadBinaries")
+		return errors.New("boom")
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			{"facade.SetTransferVolume", []interface{}{
+				coremigration.TransferVolume{Model: int64(len(fakeModelBytes))},
+			}},
+			{"UploadBinaries", nil},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -1230,6 +1333,11 @@ func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (f *stubMasterFacade) SetTransferVolume(volume coremigration.TransferVolume) error {
+	f.stub.AddCall("facade.SetTransferVolume", volume)
+	return nil
+}
+
 func (f *stubMasterFacade) Reap() error {
 	f.stub.AddCall("facade.Reap")
 	return nil
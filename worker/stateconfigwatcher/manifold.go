@@ -26,7 +26,8 @@ type ManifoldConfig struct {
 // agent's voyeur.Value which gets set whenever it the machine agent's
 // config is changed. Whenever the config is updated the presence of
 // state serving info is checked and if state serving info was added
-// or removed the manifold worker will bounce itself.
+// or removed the manifold worker will bounce itself. It also bounces
+// if the secrets key changes, so that the state is reopened with it.
 //
 // The manifold offes a single boolean output which will be true if
 // state serving info is available (i.e. the machine agent should be a
@@ -91,11 +92,18 @@ func (w *stateConfigWatcher) isStateServer() bool {
 	return ok
 }
 
+func (w *stateConfigWatcher) secretsKey() string {
+	config := w.agent.CurrentConfig()
+	info, _ := config.StateServingInfo()
+	return info.SecretsKey
+}
+
 func (w *stateConfigWatcher) loop() error {
 	watch := w.agentConfigChanged.Watch()
 	defer watch.Close()
 
 	lastValue := w.isStateServer()
+	lastSecretsKey := w.secretsKey()
 
 	watchCh := make(chan bool)
 	go func() {
@@ -130,6 +138,10 @@ func (w *stateConfigWatcher) loop() error {
 				logger.Debugf("state serving info change in agent config")
 				return dependency.ErrBounce
 			}
+			if w.secretsKey() != lastSecretsKey {
+				logger.Debugf("secrets key change in agent config")
+				return dependency.ErrBounce
+			}
 		}
 	}
 }
@@ -169,6 +169,24 @@ func (s *ManifoldSuite) TestBounceOnChange(c *gc.C) {
 	checkExitsWithError(c, w, dependency.ErrBounce)
 }
 
+func (s *ManifoldSuite) TestBounceOnSecretsKeyChange(c *gc.C) {
+	w, err := s.manifold.Start(s.goodContext)
+	c.Assert(err, jc.ErrorIsNil)
+	checkNotExiting(c, w)
+
+	// The upgrade step adding the secrets key to the agent config
+	// needs the state to be reopened with it.
+	s.agent.conf.setSecretsKey("key")
+	s.agentConfigChanged.Set(0)
+	checkExitsWithError(c, w, dependency.ErrBounce)
+
+	w, err = s.manifold.Start(s.goodContext)
+	c.Assert(err, jc.ErrorIsNil)
+	s.agentConfigChanged.Set(0)
+	checkNotExiting(c, w)
+	checkStop(c, w)
+}
+
 func (s *ManifoldSuite) TestClosedVoyeur(c *gc.C) {
 	w, err := s.manifold.Start(s.goodContext)
 	c.Assert(err, jc.ErrorIsNil)
@@ -231,6 +249,7 @@ type mockConfig struct {
 	tag         names.Tag
 	mu          sync.Mutex
 	ssInfoIsSet bool
+	secretsKey  string
 }
 
 func (mc *mockConfig) Tag() names.Tag {
@@ -243,10 +262,16 @@ func (mc *mockConfig) setStateServingInfo(isSet bool) {
 	mc.ssInfoIsSet = isSet
 }
 
+func (mc *mockConfig) setSecretsKey(key string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.secretsKey = key
+}
+
 func (mc *mockConfig) StateServingInfo() (params.StateServingInfo, bool) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
-	return params.StateServingInfo{}, mc.ssInfoIsSet
+	return params.StateServingInfo{SecretsKey: mc.secretsKey}, mc.ssInfoIsSet
 }
 
 type dummyWorker struct {
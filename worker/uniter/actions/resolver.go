@@ -74,8 +74,13 @@ func (r *actionsResolver) NextOp(
 		// and before updating the remote state to indicate that
 		// the action was completed. The only safe thing to do
 		// is fail the action, since rerunning an arbitrary
-		// command can potentially be hazardous.
+		// command can potentially be hazardous. An action run
+		// in a Kubernetes Job is still running however, so it's
+		// run again to reattach to the Job rather than rerun.
 		if nextAction == *localState.ActionId {
+			if localState.RunAsJob {
+				return opFactory.NewAction(*localState.ActionId)
+			}
 			return opFactory.NewFailAction(*localState.ActionId)
 		}
 
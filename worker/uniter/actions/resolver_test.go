@@ -127,6 +127,26 @@ func (s *actionsSuite) TestActionStateKindRunActionPendingRemote(c *gc.C) {
 	c.Assert(op, jc.DeepEquals, mockFailAction("actionA"))
 }
 
+func (s *actionsSuite) TestActionStateKindRunActionAsJobPendingRemote(c *gc.C) {
+	actionResolver := actions.NewResolver()
+	var actionA string = "actionA"
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind:     operation.RunAction,
+			ActionId: &actionA,
+			RunAsJob: true,
+		},
+		CompletedActions: map[string]struct{}{},
+	}
+	remoteState := remotestate.Snapshot{
+		ActionsPending: []string{"actionA", "actionB"},
+	}
+	op, err := actionResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, mockOp("actionA"))
+}
+
 type mockOperations struct {
 	operation.Factory
 }
@@ -0,0 +1,34 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// UnitHookRecorder returns the operation.HookRecorder that the uniter
+// for the named unit uses to record hooks in m.
+func UnitHookRecorder(m *HookMetrics, unitName string) operation.HookRecorder {
+	return m.unitRecorder(unitName)
+}
+
+// UnitExecutionRecorder returns the operation.ExecutionRecorder that the
+// uniter for the named unit uses to record executions in h.
+func UnitExecutionRecorder(h *HookHistory, unitName string) operation.ExecutionRecorder {
+	return h.unitRecorder(unitName)
+}
+
+// UnitStateReadWriter returns the operation.UnitStateReadWriter that the
+// uniter for the named unit uses to publish the executions in h, when
+// connected to the given version of the Uniter facade.
+func UnitStateReadWriter(h *HookHistory, unitName string, unit operation.UnitStateReadWriter, facadeVersion int) operation.UnitStateReadWriter {
+	return h.unitStateReadWriter(unitName, unit, facadeVersion)
+}
+
+// RunPostFirstHook runs the post-first-hook user hook at hookPath.
+func RunPostFirstHook(abort <-chan struct{}, hookPath string, timeout time.Duration) (bool, error) {
+	return runPostFirstHook(abort, hookPath, timeout)
+}
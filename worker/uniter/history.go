@@ -0,0 +1,235 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// DefaultHookHistorySize is the number of hook and action executions
+// kept for each unit by a HookHistory created with a non-positive size.
+const DefaultHookHistorySize = 50
+
+// HookHistory records the most recent hooks and actions run by the
+// uniters in an agent, keeping a bounded number of executions for each
+// unit. A single HookHistory should be shared by every uniter in an
+// agent.
+type HookHistory struct {
+	mu    sync.Mutex
+	size  int
+	units map[string]*executionRing
+}
+
+// NewHookHistory returns a new HookHistory which keeps the given
+// number of executions for each unit.
+func NewHookHistory(size int) *HookHistory {
+	if size <= 0 {
+		size = DefaultHookHistorySize
+	}
+	return &HookHistory{
+		size:  size,
+		units: make(map[string]*executionRing),
+	}
+}
+
+// Executions returns the executions recorded for the unit with the
+// given name, oldest first.
+func (h *HookHistory) Executions(unitName string) []operation.HookExecution {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring, ok := h.units[unitName]
+	if !ok {
+		return nil
+	}
+	return ring.executions()
+}
+
+// add records the execution for the unit with the given name.
+func (h *HookHistory) add(unitName string, execution operation.HookExecution) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring, ok := h.units[unitName]
+	if !ok {
+		ring = &executionRing{buf: make([]operation.HookExecution, h.size)}
+		h.units[unitName] = ring
+	}
+	ring.add(execution)
+}
+
+// unpublished returns the executions recorded for the unit with the
+// given name, oldest first, if any have been recorded since they were
+// last published, along with the number of executions recorded so far.
+func (h *HookHistory) unpublished(unitName string) ([]operation.HookExecution, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring, ok := h.units[unitName]
+	if !ok || ring.published == ring.recorded {
+		return nil, 0
+	}
+	return ring.executions(), ring.recorded
+}
+
+// setPublished records that the first recorded executions of the unit
+// with the given name have been published.
+func (h *HookHistory) setPublished(unitName string, recorded int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ring, ok := h.units[unitName]; ok && ring.published < recorded {
+		ring.published = recorded
+	}
+}
+
+// IntrospectionReport is part of the introspection.IntrospectionReporter
+// interface.
+func (h *HookHistory) IntrospectionReport() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	unitNames := make([]string, 0, len(h.units))
+	for unitName := range h.units {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+
+	var buf bytes.Buffer
+	for _, unitName := range unitNames {
+		fmt.Fprintf(&buf, "%s:\n", unitName)
+		tw := tabwriter.NewWriter(&buf, 2, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  STARTED\tKIND\tNAME\tRELATION\tDURATION\tEXIT CODE\tTOOLS")
+		for _, e := range h.units[unitName].executions() {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%v\t%d\t%s\n",
+				e.Started.UTC().Format(time.RFC3339),
+				e.Kind,
+				e.Name,
+				e.Relation,
+				e.Duration,
+				e.ExitCode,
+				strings.Join(e.ToolsCalled, ","),
+			)
+		}
+		tw.Flush()
+		fmt.Fprintln(&buf)
+	}
+	return buf.String()
+}
+
+// unitRecorder returns an operation.ExecutionRecorder that records the
+// hooks and actions run by the unit with the given name.
+func (h *HookHistory) unitRecorder(unitName string) operation.ExecutionRecorder {
+	return unitExecutionRecorder{history: h, unitName: unitName}
+}
+
+type unitExecutionRecorder struct {
+	history  *HookHistory
+	unitName string
+}
+
+// RecordExecution is part of the operation.ExecutionRecorder interface.
+func (r unitExecutionRecorder) RecordExecution(execution operation.HookExecution) {
+	r.history.add(r.unitName, execution)
+}
+
+// hookHistoryFacadeVersion is the first version of the Uniter facade
+// which accepts hook history in SetState.
+const hookHistoryFacadeVersion = 16
+
+// unitStateReadWriter returns an operation.UnitStateReadWriter which
+// publishes the hook history of the unit with the given name to the
+// controller along with the uniter state. The uniter writes its state
+// once a hook or action has run, so this costs no extra API calls.
+// If the controller's Uniter facade, of the given version, does not
+// accept hook history, unit is returned unchanged.
+func (h *HookHistory) unitStateReadWriter(unitName string, unit operation.UnitStateReadWriter, facadeVersion int) operation.UnitStateReadWriter {
+	if facadeVersion < hookHistoryFacadeVersion {
+		return unit
+	}
+	return unitStateReadWriter{UnitStateReadWriter: unit, history: h, unitName: unitName}
+}
+
+type unitStateReadWriter struct {
+	operation.UnitStateReadWriter
+	history  *HookHistory
+	unitName string
+}
+
+// SetState is part of the operation.UnitStateReadWriter interface.
+func (rw unitStateReadWriter) SetState(arg params.SetUnitStateArg) error {
+	executions, recorded := rw.history.unpublished(rw.unitName)
+	if len(executions) == 0 {
+		return rw.UnitStateReadWriter.SetState(arg)
+	}
+	serialized, err := serializeHookHistory(executions)
+	if err != nil {
+		logger.Warningf("cannot publish hook history of %s: %v", rw.unitName, err)
+		return rw.UnitStateReadWriter.SetState(arg)
+	}
+	arg.HookHistory = &serialized
+	if err := rw.UnitStateReadWriter.SetState(arg); err != nil {
+		return errors.Trace(err)
+	}
+	rw.history.setPublished(rw.unitName, recorded)
+	return nil
+}
+
+// serializeHookHistory returns the yaml representation of the given
+// executions, as persisted by the controller.
+func serializeHookHistory(executions []operation.HookExecution) (string, error) {
+	history := make([]params.HookExecution, len(executions))
+	for i, e := range executions {
+		history[i] = params.HookExecution{
+			Kind:        e.Kind,
+			Name:        e.Name,
+			Relation:    e.Relation,
+			Started:     e.Started,
+			Duration:    e.Duration,
+			ExitCode:    e.ExitCode,
+			ToolsCalled: e.ToolsCalled,
+		}
+	}
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// executionRing is a fixed size ring buffer of executions. It counts
+// the executions recorded and published to the controller.
+type executionRing struct {
+	buf  []operation.HookExecution
+	next int
+	full bool
+
+	recorded  int
+	published int
+}
+
+func (r *executionRing) add(execution operation.HookExecution) {
+	r.recorded++
+	r.buf[r.next] = execution
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *executionRing) executions() []operation.HookExecution {
+	if !r.full {
+		return append([]operation.HookExecution(nil), r.buf[:r.next]...)
+	}
+	result := make([]operation.HookExecution, 0, len(r.buf))
+	result = append(result, r.buf[r.next:]...)
+	return append(result, r.buf[:r.next]...)
+}
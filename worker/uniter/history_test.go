@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type hookHistorySuite struct{}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+type fakeUnitStateReadWriter struct {
+	operation.UnitStateReadWriter
+	args []params.SetUnitStateArg
+	err  error
+}
+
+func (rw *fakeUnitStateReadWriter) SetState(arg params.SetUnitStateArg) error {
+	rw.args = append(rw.args, arg)
+	return rw.err
+}
+
+func (s *hookHistorySuite) TestRecordExecution(c *gc.C) {
+	history := uniter.NewHookHistory(2)
+	mysql := uniter.UnitExecutionRecorder(history, "mysql/0")
+
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	executions := []operation.HookExecution{{
+		Kind:     "install",
+		Name:     "install",
+		Started:  started,
+		Duration: time.Second,
+	}, {
+		Kind:        "db-relation-joined",
+		Name:        "db-relation-joined",
+		Relation:    "db:1",
+		Started:     started.Add(time.Minute),
+		Duration:    2 * time.Second,
+		ExitCode:    1,
+		ToolsCalled: []string{"relation-get", "relation-set"},
+	}, {
+		Kind:        "action",
+		Name:        "backup",
+		Started:     started.Add(2 * time.Minute),
+		Duration:    3 * time.Second,
+		ToolsCalled: []string{"action-set"},
+	}}
+	for _, e := range executions {
+		mysql.RecordExecution(e)
+	}
+	uniter.UnitExecutionRecorder(history, "wordpress/0").RecordExecution(executions[0])
+
+	// Only the most recent executions are kept for each unit.
+	c.Assert(history.Executions("mysql/0"), jc.DeepEquals, executions[1:])
+	c.Assert(history.Executions("wordpress/0"), jc.DeepEquals, executions[:1])
+	c.Assert(history.Executions("redis/0"), gc.HasLen, 0)
+
+}
+
+func (s *hookHistorySuite) TestPublishWithUniterState(c *gc.C) {
+	history := uniter.NewHookHistory(2)
+	rw := &fakeUnitStateReadWriter{}
+	unitRW := uniter.UnitStateReadWriter(history, "mysql/0", rw, 16)
+	mysql := uniter.UnitExecutionRecorder(history, "mysql/0")
+	uniterState := "uniter state"
+
+	// Nothing is published until an execution is recorded, and the
+	// executions are only published with the uniter state.
+	err := unitRW.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	mysql.RecordExecution(operation.HookExecution{
+		Kind:     "db-relation-joined",
+		Name:     "db-relation-joined",
+		Relation: "db:1",
+		Started:  started,
+		ExitCode: 1,
+	})
+	mysql.RecordExecution(operation.HookExecution{
+		Kind:        "action",
+		Name:        "backup",
+		Started:     started.Add(time.Minute),
+		Duration:    3 * time.Second,
+		ToolsCalled: []string{"action-set"},
+	})
+	c.Assert(rw.args, gc.HasLen, 1)
+	c.Assert(rw.args[0].HookHistory, gc.IsNil)
+
+	err = unitRW.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rw.args, gc.HasLen, 2)
+	c.Assert(*rw.args[1].UniterState, gc.Equals, uniterState)
+	var published []params.HookExecution
+	err = yaml.Unmarshal([]byte(*rw.args[1].HookHistory), &published)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(published, gc.HasLen, 2)
+	c.Assert(published[0].Name, gc.Equals, "db-relation-joined")
+	c.Assert(published[0].Relation, gc.Equals, "db:1")
+	c.Assert(published[0].ExitCode, gc.Equals, 1)
+	c.Assert(published[1].Name, gc.Equals, "backup")
+	c.Assert(published[1].Duration, gc.Equals, 3*time.Second)
+	c.Assert(published[1].ToolsCalled, jc.DeepEquals, []string{"action-set"})
+
+	// Published executions aren't published again.
+	err = unitRW.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rw.args, gc.HasLen, 3)
+	c.Assert(rw.args[2].HookHistory, gc.IsNil)
+}
+
+func (s *hookHistorySuite) TestNoPublishBeforeFacadeVersion16(c *gc.C) {
+	history := uniter.NewHookHistory(2)
+	rw := &fakeUnitStateReadWriter{}
+	unitRW := uniter.UnitStateReadWriter(history, "mysql/0", rw, 15)
+	uniter.UnitExecutionRecorder(history, "mysql/0").RecordExecution(operation.HookExecution{
+		Kind: "install",
+		Name: "install",
+	})
+
+	uniterState := "uniter state"
+	err := unitRW.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rw.args, gc.HasLen, 1)
+	c.Assert(*rw.args[0].UniterState, gc.Equals, uniterState)
+	c.Assert(rw.args[0].HookHistory, gc.IsNil)
+}
+
+func (s *hookHistorySuite) TestPublishError(c *gc.C) {
+	history := uniter.NewHookHistory(2)
+	rw := &fakeUnitStateReadWriter{err: errors.New("boom")}
+	unitRW := uniter.UnitStateReadWriter(history, "mysql/0", rw, 16)
+	execution := operation.HookExecution{Kind: "install", Name: "install"}
+	uniter.UnitExecutionRecorder(history, "mysql/0").RecordExecution(execution)
+	c.Assert(history.Executions("mysql/0"), jc.DeepEquals, []operation.HookExecution{execution})
+
+	uniterState := "uniter state"
+	err := unitRW.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	// The executions are published with the next uniter state.
+	rw.err = nil
+	err = unitRW.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rw.args, gc.HasLen, 2)
+	c.Assert(rw.args[1].HookHistory, gc.NotNil)
+}
+
+func (s *hookHistorySuite) TestIntrospectionReport(c *gc.C) {
+	history := uniter.NewHookHistory(0)
+	started := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	uniter.UnitExecutionRecorder(history, "mysql/0").RecordExecution(operation.HookExecution{
+		Kind:        "db-relation-joined",
+		Name:        "db-relation-joined",
+		Relation:    "db:1",
+		Started:     started,
+		Duration:    2 * time.Second,
+		ToolsCalled: []string{"relation-get", "relation-set"},
+	})
+	c.Assert(history.IntrospectionReport(), gc.Equals, `
+mysql/0:
+  STARTED               KIND                NAME                RELATION  DURATION  EXIT CODE  TOOLS
+  2020-04-01T12:00:00Z  db-relation-joined  db-relation-joined  db:1      2s        0          relation-get,relation-set
+
+`[1:])
+}
@@ -34,6 +34,13 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// HookMetrics, if not nil, records the hooks run by the uniter.
+	HookMetrics *HookMetrics
+
+	// HookHistory, if not nil, records the hooks and actions run by
+	// the uniter.
+	HookHistory *HookHistory
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -107,6 +114,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
 				RebootQuerier:        reboot.NewMonitor(agentConfig.TransientDataDir()),
+				HookMetrics:          config.HookMetrics,
+				HookHistory:          config.HookHistory,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
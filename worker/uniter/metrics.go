@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+const (
+	metricsNamespace = "juju_uniter"
+
+	hookResultSucceeded = "succeeded"
+	hookResultFailed    = "failed"
+)
+
+// HookMetrics is a prometheus.Collector that records the number and
+// duration of the hooks run by the uniters in an agent. A single
+// HookMetrics should be shared by every uniter in an agent.
+type HookMetrics struct {
+	hooks     *prometheus.CounterVec
+	durations *prometheus.HistogramVec
+}
+
+// NewHookMetrics returns a new HookMetrics.
+func NewHookMetrics() *HookMetrics {
+	return &HookMetrics{
+		hooks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "hooks_total",
+			Help:      "Number of hooks run, by unit, hook kind and result.",
+		}, []string{"unit", "hook", "result"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "hook_duration_seconds",
+			Help:      "Time taken to run hooks, by unit and hook kind.",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{"unit", "hook"}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *HookMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hooks.Describe(ch)
+	m.durations.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *HookMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.hooks.Collect(ch)
+	m.durations.Collect(ch)
+}
+
+// unitRecorder returns an operation.HookRecorder that records the
+// hooks run by the unit with the given name.
+func (m *HookMetrics) unitRecorder(unitName string) operation.HookRecorder {
+	return unitHookRecorder{metrics: m, unitName: unitName}
+}
+
+type unitHookRecorder struct {
+	metrics  *HookMetrics
+	unitName string
+}
+
+// RecordHook is part of the operation.HookRecorder interface.
+func (r unitHookRecorder) RecordHook(kind string, duration time.Duration, failed bool) {
+	result := hookResultSucceeded
+	if failed {
+		result = hookResultFailed
+	}
+	r.metrics.hooks.WithLabelValues(r.unitName, kind, result).Inc()
+	r.metrics.durations.WithLabelValues(r.unitName, kind).Observe(duration.Seconds())
+}
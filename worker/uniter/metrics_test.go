@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+)
+
+type hookMetricsSuite struct{}
+
+var _ = gc.Suite(&hookMetricsSuite{})
+
+func (s *hookMetricsSuite) TestRecordHook(c *gc.C) {
+	metrics := uniter.NewHookMetrics()
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(metrics)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := uniter.UnitHookRecorder(metrics, "mysql/0")
+	mysql.RecordHook("install", 2*time.Second, false)
+	mysql.RecordHook("config-changed", time.Second, true)
+	mysql.RecordHook("config-changed", time.Second, false)
+	uniter.UnitHookRecorder(metrics, "wordpress/0").RecordHook("install", time.Second, false)
+
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(families, gc.HasLen, 2)
+
+	c.Assert(families[0].GetName(), gc.Equals, "juju_uniter_hook_duration_seconds")
+	durations := make(map[string]float64)
+	for _, m := range families[0].GetMetric() {
+		labels := m.GetLabel()
+		key := labels[0].GetValue() + " " + labels[1].GetValue()
+		durations[key] = m.GetHistogram().GetSampleSum()
+	}
+	c.Check(durations, jc.DeepEquals, map[string]float64{
+		"config-changed mysql/0": 2,
+		"install mysql/0":        2,
+		"install wordpress/0":    1,
+	})
+
+	c.Assert(families[1].GetName(), gc.Equals, "juju_uniter_hooks_total")
+	counts := make(map[string]float64)
+	for _, m := range families[1].GetMetric() {
+		labels := m.GetLabel()
+		key := labels[0].GetValue() + " " + labels[1].GetValue() + " " + labels[2].GetValue()
+		counts[key] = m.GetCounter().GetValue()
+	}
+	c.Check(counts, jc.DeepEquals, map[string]float64{
+		"config-changed failed mysql/0":    1,
+		"config-changed succeeded mysql/0": 1,
+		"install succeeded mysql/0":        1,
+		"install succeeded wordpress/0":    1,
+	})
+}
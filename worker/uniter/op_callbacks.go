@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	corecharm "gopkg.in/juju/charm.v6"
@@ -58,11 +59,18 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 
 // CommitHook is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
+	var err error
 	switch {
 	case hi.Kind.IsRelation():
-		return opc.u.relationStateTracker.CommitHook(hi)
+		err = opc.u.relationStateTracker.CommitHook(hi)
 	case hi.Kind.IsStorage():
-		return opc.u.storage.CommitHook(hi)
+		err = opc.u.storage.CommitHook(hi)
+	}
+	if err != nil {
+		return err
+	}
+	if opc.u.postFirstHookPath != "" {
+		opc.u.startPostFirstHook(hi.Kind)
 	}
 	return nil
 }
@@ -95,6 +103,11 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// SetHookTimeout is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) SetHookTimeout(hi hook.Info, timeout time.Duration) {
+	opc.u.hookTimeout = hookTimeout{info: hi, timeout: timeout}
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
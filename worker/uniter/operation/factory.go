@@ -4,6 +4,7 @@
 package operation
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
@@ -20,6 +21,15 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// HookRecorder, if not nil, is told the outcome and duration of
+	// every hook that is run, as measured by Clock.
+	HookRecorder HookRecorder
+
+	// ExecutionRecorder, if not nil, is told the details of every
+	// hook and action that is run, as measured by Clock.
+	ExecutionRecorder ExecutionRecorder
+	Clock             clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -90,9 +100,12 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		return nil, err
 	}
 	return &runHook{
-		info:          hookInfo,
-		callbacks:     f.config.Callbacks,
-		runnerFactory: f.config.RunnerFactory,
+		info:              hookInfo,
+		callbacks:         f.config.Callbacks,
+		runnerFactory:     f.config.RunnerFactory,
+		hookRecorder:      f.config.HookRecorder,
+		executionRecorder: f.config.ExecutionRecorder,
+		clock:             f.config.Clock,
 	}, nil
 }
 
@@ -111,9 +124,11 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		return nil, errors.Errorf("invalid action id %q", actionId)
 	}
 	return &runAction{
-		actionId:      actionId,
-		callbacks:     f.config.Callbacks,
-		runnerFactory: f.config.RunnerFactory,
+		actionId:          actionId,
+		callbacks:         f.config.Callbacks,
+		runnerFactory:     f.config.RunnerFactory,
+		executionRecorder: f.config.ExecutionRecorder,
+		clock:             f.config.Clock,
 	}, nil
 }
 
@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/juju/loggo"
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6"
@@ -146,6 +148,46 @@ type CommandArgs struct {
 // of the original request.
 type CommandResponseFunc func(*utilexec.ExecResponse, error) bool
 
+// HookRecorder records the outcome of the hooks run by a unit.
+type HookRecorder interface {
+	// RecordHook records that a hook of the given kind ran for the
+	// given duration, and whether it failed.
+	RecordHook(kind string, duration time.Duration, failed bool)
+}
+
+// HookExecution describes a single hook or action run by a unit.
+type HookExecution struct {
+	// Kind is the kind of hook that ran, or "action" for actions.
+	Kind string
+
+	// Name is the name of the hook or action that ran.
+	Name string
+
+	// Relation identifies the relation of a relation hook, in
+	// the form "endpoint:id".
+	Relation string
+
+	// Started is when the hook or action started running.
+	Started time.Time
+
+	// Duration is how long the hook or action ran for.
+	Duration time.Duration
+
+	// ExitCode is the exit code of the hook or action, or -1 if
+	// it didn't exit normally.
+	ExitCode int
+
+	// ToolsCalled holds the names of the hook tools called by the
+	// hook or action, in the order they were called.
+	ToolsCalled []string
+}
+
+// ExecutionRecorder records every hook and action run by a unit.
+type ExecutionRecorder interface {
+	// RecordExecution records that a hook or action has finished.
+	RecordExecution(execution HookExecution)
+}
+
 // Callbacks exposes all the uniter code that's required by the various operations.
 // It's far from cohesive, and fundamentally represents inappropriate coupling, so
 // it's a prime candidate for future refactoring.
@@ -164,6 +206,11 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// SetHookTimeout records the timeout after which the hook with the
+	// supplied info was killed, or zero if it failed for any other reason.
+	// It's only used by RunHook operations.
+	SetHookTimeout(info hook.Info, timeout time.Duration)
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -5,7 +5,9 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
@@ -21,8 +23,10 @@ type runAction struct {
 	changed chan struct{}
 	cancel  chan struct{}
 
-	callbacks     Callbacks
-	runnerFactory runner.Factory
+	callbacks         Callbacks
+	runnerFactory     runner.Factory
+	executionRecorder ExecutionRecorder
+	clock             clock.Clock
 
 	name   string
 	runner runner.Runner
@@ -68,6 +72,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		Kind:     RunAction,
 		Step:     Pending,
 		ActionId: &ra.actionId,
+		RunAsJob: actionData.RunAsJob,
 		Hook:     state.Hook,
 	}.apply(state), nil
 }
@@ -103,9 +108,14 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		}
 	}()
 
+	var started time.Time
+	if ra.executionRecorder != nil {
+		started = ra.clock.Now()
+	}
 	handlerType, err := ra.runner.RunAction(ra.name)
 	close(done)
 	<-wait
+	ra.recordAction(started)
 
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
@@ -120,6 +130,23 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// recordAction tells the execution recorder, if any, that the action
+// started at the given time has finished.
+func (ra *runAction) recordAction(started time.Time) {
+	if ra.executionRecorder == nil {
+		return
+	}
+	info := ra.runner.ExecutionInfo()
+	ra.executionRecorder.RecordExecution(HookExecution{
+		Kind:        "action",
+		Name:        ra.name,
+		Started:     started,
+		Duration:    ra.clock.Now().Sub(started),
+		ExitCode:    info.ExitCode,
+		ToolsCalled: info.ToolsCalled,
+	})
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
@@ -6,6 +6,7 @@ package operation_test
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -190,6 +192,25 @@ func (s *RunActionSuite) TestPrepareSuccessDirtyState(c *gc.C) {
 	c.Assert(runnerFactory.MockNewActionRunner.gotCancel, gc.NotNil)
 }
 
+func (s *RunActionSuite) TestPrepareSuccessRunAsJob(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
+	runnerFactory.MockNewActionRunner.runner.context.(*MockContext).actionData.RunAsJob = true
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Pending,
+		ActionId: &someActionId,
+		RunAsJob: true,
+	})
+}
+
 func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -237,6 +258,33 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteRecordsExecution(c *gc.C) {
+	recorder := &fakeExecutionRecorder{}
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	runnerFactory.MockNewActionRunner.runner.executionInfo = runner.ExecutionInfo{
+		ToolsCalled: []string{"action-get", "action-set"},
+	}
+	now := time.Now()
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory:     runnerFactory,
+		Callbacks:         &RunActionCallbacks{},
+		ExecutionRecorder: recorder,
+		Clock:             testclock.NewClock(now),
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorder.executions, jc.DeepEquals, []operation.HookExecution{{
+		Kind:        "action",
+		Name:        "some-action-name",
+		Started:     now,
+		ToolsCalled: []string{"action-get", "action-set"},
+	}})
+}
+
 func (s *RunActionSuite) TestExecuteCancel(c *gc.C) {
 	actionChan := make(chan error)
 	defer close(actionChan)
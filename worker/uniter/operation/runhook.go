@@ -5,7 +5,9 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"gopkg.in/juju/charm.v6/hooks"
@@ -23,8 +25,11 @@ import (
 type runHook struct {
 	info hook.Info
 
-	callbacks     Callbacks
-	runnerFactory runner.Factory
+	callbacks         Callbacks
+	runnerFactory     runner.Factory
+	hookRecorder      HookRecorder
+	executionRecorder ExecutionRecorder
+	clock             clock.Clock
 
 	name   string
 	runner runner.Runner
@@ -119,6 +124,10 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	rh.hookFound = true
 	step := Done
 
+	var started time.Time
+	if rh.hookRecorder != nil || rh.executionRecorder != nil {
+		started = rh.clock.Now()
+	}
 	handlerType, err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
 	switch {
@@ -133,12 +142,19 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
+		rh.recordHook(started, true)
+		var timeout time.Duration
+		if timeoutErr, ok := cause.(*runner.HookTimeoutError); ok {
+			timeout = timeoutErr.Timeout
+		}
+		rh.callbacks.SetHookTimeout(rh.info, timeout)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if rh.hookFound {
 		logger.Infof("ran %q hook (via %s)", rh.name, handlerType)
+		rh.recordHook(started, false)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -157,6 +173,36 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordHook tells the hook and execution recorders, if any, that the
+// hook started at the given time has finished.
+func (rh *runHook) recordHook(started time.Time, failed bool) {
+	if rh.hookRecorder == nil && rh.executionRecorder == nil {
+		return
+	}
+	duration := rh.clock.Now().Sub(started)
+	if rh.hookRecorder != nil {
+		rh.hookRecorder.RecordHook(string(rh.info.Kind), duration, failed)
+	}
+	if rh.executionRecorder == nil {
+		return
+	}
+	info := rh.runner.ExecutionInfo()
+	execution := HookExecution{
+		Kind:        string(rh.info.Kind),
+		Name:        rh.name,
+		Started:     started,
+		Duration:    duration,
+		ExitCode:    info.ExitCode,
+		ToolsCalled: info.ToolsCalled,
+	}
+	if rh.info.Kind.IsRelation() {
+		if rel, err := rh.runner.Context().HookRelation(); err == nil {
+			execution.Relation = rel.FakeId()
+		}
+	}
+	rh.executionRecorder.RecordExecution(execution)
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
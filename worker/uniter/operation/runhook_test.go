@@ -4,6 +4,9 @@
 package operation_test
 
 import (
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +17,7 @@ import (
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -297,6 +301,110 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(*callbacks.gotHookTimeout, gc.Equals, time.Duration(0))
+}
+
+func (s *RunHookSuite) TestExecuteTimeoutError(c *gc.C) {
+	runErr := errors.Trace(&runner.HookTimeoutError{Hook: "config-changed", Timeout: time.Minute})
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(*callbacks.gotHookTimeout, gc.Equals, time.Minute)
+}
+
+type hookRecord struct {
+	kind     string
+	duration time.Duration
+	failed   bool
+}
+
+type fakeHookRecorder struct {
+	records []hookRecord
+}
+
+func (r *fakeHookRecorder) RecordHook(kind string, duration time.Duration, failed bool) {
+	r.records = append(r.records, hookRecord{kind, duration, failed})
+}
+
+func (s *RunHookSuite) testExecuteRecordsHook(c *gc.C, runErr error) []hookRecord {
+	recorder := &fakeHookRecorder{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: NewRunHookRunnerFactory(runErr),
+		Callbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+			MockNotifyHookCompleted: &MockNotify{},
+			MockNotifyHookFailed:    &MockNotify{},
+		},
+		HookRecorder: recorder,
+		Clock:        testclock.NewClock(time.Now()),
+	})
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	op.Execute(operation.State{})
+	return recorder.records
+}
+
+func (s *RunHookSuite) TestExecuteRecordsHookSuccess(c *gc.C) {
+	records := s.testExecuteRecordsHook(c, nil)
+	c.Assert(records, jc.DeepEquals, []hookRecord{{kind: "config-changed"}})
+}
+
+func (s *RunHookSuite) TestExecuteRecordsHookFailure(c *gc.C) {
+	records := s.testExecuteRecordsHook(c, errors.New("graaargh"))
+	c.Assert(records, jc.DeepEquals, []hookRecord{{kind: "config-changed", failed: true}})
+}
+
+func (s *RunHookSuite) TestExecuteMissingHookNotRecorded(c *gc.C) {
+	records := s.testExecuteRecordsHook(c, charmrunner.NewMissingHookError("blah-blah"))
+	c.Assert(records, gc.HasLen, 0)
+}
+
+type fakeExecutionRecorder struct {
+	executions []operation.HookExecution
+}
+
+func (r *fakeExecutionRecorder) RecordExecution(execution operation.HookExecution) {
+	r.executions = append(r.executions, execution)
+}
+
+func (s *RunHookSuite) TestExecuteRecordsExecution(c *gc.C) {
+	recorder := &fakeExecutionRecorder{}
+	runnerFactory := NewRunHookRunnerFactory(errors.New("graaargh"))
+	runnerFactory.MockNewHookRunner.runner.executionInfo = runner.ExecutionInfo{
+		ExitCode:    1,
+		ToolsCalled: []string{"config-get", "status-set"},
+	}
+	now := time.Now()
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks: NewPrepareHookCallbacks(),
+			MockNotifyHookFailed: &MockNotify{},
+		},
+		ExecutionRecorder: recorder,
+		Clock:             testclock.NewClock(now),
+	})
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(recorder.executions, jc.DeepEquals, []operation.HookExecution{{
+		Kind:        "config-changed",
+		Name:        "some-hook-name",
+		Started:     now,
+		ExitCode:    1,
+		ToolsCalled: []string{"config-get", "status-set"},
+	}})
 }
 
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
@@ -92,6 +92,10 @@ type State struct {
 	// RunAction, it holds the running action.
 	ActionId *string `yaml:"action-id,omitempty"`
 
+	// RunAsJob is true if the running action runs in a Kubernetes Job,
+	// which keeps running if the uniter is interrupted.
+	RunAsJob bool `yaml:"run-as-job,omitempty"`
+
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
@@ -186,6 +190,7 @@ type stateChange struct {
 	Step            Step
 	Hook            *hook.Info
 	ActionId        *string
+	RunAsJob        bool
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
 }
@@ -195,6 +200,7 @@ func (change stateChange) apply(state State) *State {
 	state.Step = change.Step
 	state.Hook = change.Hook
 	state.ActionId = change.ActionId
+	state.RunAsJob = change.RunAsJob
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
 	return &state
@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -203,6 +204,7 @@ type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	gotHookTimeout          *time.Duration
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -213,6 +215,10 @@ func (cb *ExecuteHookCallbacks) NotifyHookFailed(hookName string, ctx runner.Con
 	cb.MockNotifyHookFailed.Call(hookName, ctx)
 }
 
+func (cb *ExecuteHookCallbacks) SetHookTimeout(hookInfo hook.Info, timeout time.Duration) {
+	cb.gotHookTimeout = &timeout
+}
+
 type MockCommitHook struct {
 	gotHook *hook.Info
 	err     error
@@ -411,13 +417,18 @@ type MockRunner struct {
 	*MockRunAction
 	*MockRunCommands
 	*MockRunHook
-	context runner.Context
+	context       runner.Context
+	executionInfo runner.ExecutionInfo
 }
 
 func (r *MockRunner) Context() runner.Context {
 	return r.context
 }
 
+func (r *MockRunner) ExecutionInfo() runner.ExecutionInfo {
+	return r.executionInfo
+}
+
 func (r *MockRunner) RunAction(actionName string) (runner.HookHandlerType, error) {
 	return runner.ExplicitHookHandler, r.MockRunAction.Call(actionName)
 }
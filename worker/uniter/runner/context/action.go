@@ -16,6 +16,10 @@ type ActionData struct {
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
 	Cancel         <-chan struct{}
+
+	// RunAsJob is true if the action declares in actions.yaml that it
+	// runs to completion in a Kubernetes Job.
+	RunAsJob bool
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -6,7 +6,10 @@
 package context
 
 import (
+	"crypto/sha512"
 	"fmt"
+	"io"
+	"os"
 	"path"
 	"strings"
 	"sync"
@@ -175,6 +178,14 @@ type HookContext struct {
 
 	hookName string
 
+	// hookTimeouts holds the maximum time hooks of each kind may run for,
+	// as set in the model config.
+	hookTimeouts map[string]time.Duration
+
+	// hookTimeout is the maximum time the hook may run for, or zero if it
+	// may run for as long as it needs.
+	hookTimeout time.Duration
+
 	// actionData contains the values relevant to the run of an Action:
 	// its tag, its parameters, and its results.
 	actionData *ActionData
@@ -481,6 +492,19 @@ func (ctx *HookContext) ModelType() model.ModelType {
 	return ctx.modelType
 }
 
+// HookTimeout returns the maximum time the hook may run for before it is
+// killed, or zero if it is not timed out.
+// Implements runner.Context.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+// Clock returns the clock used to time the hook.
+// Implements runner.Context.
+func (ctx *HookContext) Clock() Clock {
+	return ctx.clock
+}
+
 // UnitStatus will return the status for the current Unit.
 // Implements jujuc.HookContext.ContextStatus, part of runner.Context.
 func (ctx *HookContext) UnitStatus() (*jujuc.StatusInfo, error) {
@@ -783,6 +807,30 @@ func (ctx *HookContext) LogActionMessage(message string) error {
 	return ctx.unit.LogActionMessage(ctx.actionData.Tag, message)
 }
 
+// AddActionAttachment uploads the file at the given path to the controller
+// as an attachment of the Action.
+// Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
+func (ctx *HookContext) AddActionAttachment(name, filename string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha512.New384()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return errors.Annotatef(err, "reading %q", filename)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	sha384 := fmt.Sprintf("%x", hash.Sum(nil))
+	return ctx.state.UploadActionAttachment(ctx.actionData.Tag, name, f, size, sha384)
+}
+
 // SetActionMessage sets a message for the Action, usually an error message.
 // Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
 func (ctx *HookContext) SetActionMessage(message string) error {
@@ -1203,6 +1251,36 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	return result.OneError()
 }
 
+// CreateSecret creates a secret owned by the unit's application.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) CreateSecret(description string, expireTime time.Time, value map[string]string) (string, error) {
+	return ctx.state.CreateSecret(description, expireTime, value)
+}
+
+// UpdateSecret updates the value and expiry time of a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) UpdateSecret(id string, expireTime *time.Time, value map[string]string) error {
+	return ctx.state.UpdateSecret(id, expireTime, value)
+}
+
+// GetSecret returns the value of a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GetSecret(id string) (map[string]string, error) {
+	return ctx.state.SecretValue(id)
+}
+
+// GrantSecret allows an application or unit to read a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GrantSecret(id string, subject names.Tag) error {
+	return ctx.state.GrantSecret(id, subject)
+}
+
+// RevokeSecret stops an application or unit from reading a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) RevokeSecret(id string, subject names.Tag) error {
+	return ctx.state.RevokeSecret(id, subject)
+}
+
 // NetworkInfo returns the network info for the given bindings on the given relation.
 // Implements jujuc.HookContext.ContextNetworking, part of runner.Context.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
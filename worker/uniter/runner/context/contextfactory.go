@@ -5,12 +5,16 @@ package context
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
@@ -238,9 +242,50 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 	}
 	ctx.id = f.newId(hookName)
 	ctx.hookName = hookName
+	ctx.hookTimeout = f.hookTimeout(hookInfo.Kind, ctx.hookTimeouts)
 	return ctx, nil
 }
 
+// hookTimeout returns the maximum time hooks of the given kind may run for.
+// Timeouts declared in the charm metadata take precedence over those set in
+// the model config.
+func (f *contextFactory) hookTimeout(kind hooks.Kind, modelTimeouts map[string]time.Duration) time.Duration {
+	charmTimeouts, err := readCharmHookTimeouts(f.paths.GetCharmDir())
+	if err != nil {
+		logger.Warningf("ignoring hook timeouts of charm: %v", err)
+	}
+	if timeout, ok := charmTimeouts[string(kind)]; ok {
+		return timeout
+	}
+	return modelTimeouts[string(kind)]
+}
+
+// readCharmHookTimeouts returns the timeouts of the hook-timeouts section
+// of the metadata of the charm in the given directory, keyed by hook kind.
+func readCharmHookTimeouts(charmDir string) (map[string]time.Duration, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var meta struct {
+		HookTimeouts map[string]string `yaml:"hook-timeouts"`
+	}
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Annotate(err, "parsing charm metadata")
+	}
+	timeouts := make(map[string]time.Duration)
+	for kind, value := range meta.HookTimeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, errors.NotValidf("timeout %q for hook %q", value, kind)
+		}
+		timeouts[kind] = timeout
+	}
+	return timeouts, nil
+}
+
 // CommandContext is part of the ContextFactory interface.
 func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext, error) {
 	ctx, err := f.coreContext()
@@ -320,6 +365,7 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.legacyProxySettings = modelConfig.LegacyProxySettings()
 	ctx.jujuProxySettings = modelConfig.JujuProxySettings()
+	ctx.hookTimeouts = modelConfig.HookTimeouts()
 
 	statusCode, statusInfo, err := f.unit.MeterStatus()
 	if err != nil {
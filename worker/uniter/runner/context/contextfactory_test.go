@@ -4,7 +4,9 @@
 package context_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -144,6 +146,32 @@ func (s *ContextFactorySuite) TestNewHookContextRetrievesSLALevel(c *gc.C) {
 	c.Assert(ctx.SLALevel(), gc.Equals, "essential")
 }
 
+func (s *ContextFactorySuite) TestNewHookContextHookTimeout(c *gc.C) {
+	err := s.Model(c).UpdateModelConfig(map[string]interface{}{
+		"hook-timeouts": "install=1h,config-changed=10m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	metadata := `
+name: wordpress
+summary: "test"
+description: "test"
+hook-timeouts:
+  config-changed: 30s
+`[1:]
+	err = ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for kind, timeout := range map[hooks.Kind]time.Duration{
+		hooks.Install:       time.Hour,
+		hooks.ConfigChanged: 30 * time.Second,
+		hooks.Start:         0,
+	} {
+		ctx, err := s.factory.HookContext(hook.Info{Kind: kind})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(ctx.HookTimeout(), gc.Equals, timeout, gc.Commentf("hook %q", kind))
+	}
+}
+
 func (s *ContextFactorySuite) TestNewHookContextLeadershipContext(c *gc.C) {
 	s.testLeadershipContextWiring(c, func() *context.HookContext {
 		ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
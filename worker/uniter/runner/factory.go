@@ -126,6 +126,7 @@ func (f *factory) NewActionRunner(actionId string, cancel <-chan struct{}) (Runn
 	}
 
 	actionData := context.NewActionData(name, &tag, params, cancel)
+	actionData.RunAsJob = runAsJob(spec)
 	ctx, err := f.contextFactory.ActionContext(actionData)
 	if err != nil {
 		return nil, charmrunner.NewBadActionError(name, err.Error())
@@ -134,6 +135,14 @@ func (f *factory) NewActionRunner(actionId string, cancel <-chan struct{}) (Runn
 	return runner, nil
 }
 
+// runAsJob returns whether the action spec declares that the action runs
+// in a Kubernetes Job. The charm package keeps the keys of an action in
+// actions.yaml other than description and params in the params schema.
+func runAsJob(spec charm.ActionSpec) bool {
+	runAsJob, _ := spec.Params["run-as-job"].(bool)
+	return runAsJob
+}
+
 func getCharm(charmPath string) (charm.Charm, error) {
 	ch, err := charm.ReadCharm(charmPath)
 	if err != nil {
@@ -4,7 +4,9 @@
 package runner_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -262,6 +264,25 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 	}
 }
 
+func (s *FactorySuite) TestNewActionRunnerRunAsJob(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	err := ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "actions.yaml"), []byte(`
+migrate:
+  description: Migrate the database.
+  run-as-job: true
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	operationID, err := s.model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "migrate", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewActionRunner(action.Id(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := rnr.Context().ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.RunAsJob, jc.IsTrue)
+}
+
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
 	rnr, err := s.factory.NewActionRunner("irrelevant", nil)
 	c.Assert(rnr, gc.IsNil)
@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// ActionAttachCommand implements the action-attach command.
+type ActionAttachCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	Path string
+	Name string
+}
+
+func NewActionAttachCommand(ctx Context) (cmd.Command, error) {
+	return &ActionAttachCommand{ctx: ctx}, nil
+}
+
+func (c *ActionAttachCommand) Info() *cmd.Info {
+	doc := `
+action-attach uploads a file to the controller, where it is kept with the
+results of the current action until they are pruned. Users retrieve it with
+"juju show-task --download". The attachment is named after the file, unless
+another name is given with --name.
+
+Each file can be at most 100MiB, and at most 10 files can be attached to an
+action.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "action-attach",
+		Args:    "<file>",
+		Purpose: "attach a file to the results of the current action",
+		Doc:     doc,
+	})
+}
+
+func (c *ActionAttachCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Name, "name", "", "name of the attachment")
+}
+
+func (c *ActionAttachCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no file specified")
+	}
+	c.Path = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *ActionAttachCommand) Run(ctx *cmd.Context) error {
+	path := ctx.AbsPath(c.Path)
+	name := c.Name
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	return c.ctx.AddActionAttachment(name, path)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionAttachSuite struct {
+	ContextSuite
+}
+
+type actionAttachContext struct {
+	jujuc.Context
+	name string
+	path string
+}
+
+func (ctx *actionAttachContext) AddActionAttachment(name, path string) error {
+	ctx.name = name
+	ctx.path = path
+	return nil
+}
+
+type nonActionAttachContext struct {
+	jujuc.Context
+}
+
+func (ctx *nonActionAttachContext) AddActionAttachment(name, path string) error {
+	return fmt.Errorf("not running an action")
+}
+
+var _ = gc.Suite(&ActionAttachSuite{})
+
+func (s *ActionAttachSuite) TestActionAttach(c *gc.C) {
+	var actionAttachTests = []struct {
+		summary string
+		command []string
+		name    string
+		path    string
+		code    int
+		errMsg  string
+	}{{
+		summary: "attachment named after the file",
+		command: []string{"out/dump.sql"},
+		name:    "dump.sql",
+		path:    "out/dump.sql",
+	}, {
+		summary: "attachment with another name",
+		command: []string{"--name", "backup.sql", "out/dump.sql"},
+		name:    "backup.sql",
+		path:    "out/dump.sql",
+	}, {
+		summary: "no file specified",
+		command: []string{},
+		errMsg:  "ERROR no file specified\n",
+		code:    2,
+	}, {
+		summary: "too many arguments",
+		command: []string{"dump.sql", "other.sql"},
+		errMsg:  "ERROR unrecognized args: [\"other.sql\"]\n",
+		code:    2,
+	}}
+
+	for i, t := range actionAttachTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionAttachContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-attach"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.name, gc.Equals, t.name)
+		if t.path != "" {
+			c.Check(hctx.path, gc.Equals, filepath.Join(ctx.Dir, t.path))
+		}
+	}
+}
+
+func (s *ActionAttachSuite) TestNonActionAttachFails(c *gc.C) {
+	hctx := &nonActionAttachContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-attach"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"dump.sql"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR not running an action\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
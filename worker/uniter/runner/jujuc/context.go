@@ -43,6 +43,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -95,6 +96,10 @@ type actionHookContext interface {
 
 	// LogActionMessage records a progress message for the Action.
 	LogActionMessage(string) error
+
+	// AddActionAttachment uploads the file at the given path as an
+	// attachment of the Action with the given name.
+	AddActionAttachment(name, path string) error
 }
 
 // unitCacheContext is cache for charm state to be held in the context.
@@ -240,6 +245,31 @@ type ContextStorage interface {
 	AddUnitStorage(map[string]params.StorageConstraints) error
 }
 
+// ContextSecrets is the part of a hook context related to secrets
+// owned by, or shared with, the unit's application.
+type ContextSecrets interface {
+	// CreateSecret creates a secret owned by the unit's application
+	// with the supplied value, and returns its ID. If expireTime is
+	// the zero time the secret does not expire.
+	CreateSecret(description string, expireTime time.Time, value map[string]string) (string, error)
+
+	// UpdateSecret updates the value and expiry time of the secret
+	// with the supplied ID. A nil value or expireTime is left
+	// unchanged.
+	UpdateSecret(id string, expireTime *time.Time, value map[string]string) error
+
+	// GetSecret returns the value of the secret with the supplied ID.
+	GetSecret(id string) (map[string]string, error)
+
+	// GrantSecret allows the application or unit with the supplied
+	// tag to read the secret with the supplied ID.
+	GrantSecret(id string, subject names.Tag) error
+
+	// RevokeSecret stops the application or unit with the supplied
+	// tag from reading the secret with the supplied ID.
+	RevokeSecret(id string, subject names.Tag) error
+}
+
 // ContextComponents exposes modular Juju components as they relate to
 // the unit in the context of the hook.
 type ContextComponents interface {
@@ -57,6 +57,19 @@ func (c *ContextActionHook) LogActionMessage(message string) error {
 	return nil
 }
 
+// AddActionAttachment implements jujuc.ActionHookContext.
+func (c *ContextActionHook) AddActionAttachment(name, path string) error {
+	c.stub.AddCall("AddActionAttachment", name, path)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	return nil
+}
+
 // SetActionMessage implements jujuc.ActionHookContext.
 func (c *ContextActionHook) SetActionMessage(message string) error {
 	c.stub.AddCall("SetActionMessage", message)
@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// NewSecretID is the ID returned by CreateSecret.
+	NewSecretID string
+	// SecretValues holds the value of each secret, keyed by ID.
+	SecretValues map[string]map[string]string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(description string, expireTime time.Time, value map[string]string) (string, error) {
+	c.stub.AddCall("CreateSecret", description, expireTime, value)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	if c.info.SecretValues == nil {
+		c.info.SecretValues = make(map[string]map[string]string)
+	}
+	c.info.SecretValues[c.info.NewSecretID] = value
+	return c.info.NewSecretID, nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(id string, expireTime *time.Time, value map[string]string) error {
+	c.stub.AddCall("UpdateSecret", id, expireTime, value)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, ok := c.info.SecretValues[id]; !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	if value != nil {
+		c.info.SecretValues[id] = value
+	}
+	return nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", id)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	value, ok := c.info.SecretValues[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return value, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, subject names.Tag) error {
+	c.stub.AddCall("GrantSecret", id, subject)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(id string, subject names.Tag) error {
+	c.stub.AddCall("RevokeSecret", id, subject)
+	return errors.Trace(c.stub.NextErr())
+}
@@ -52,6 +52,18 @@ func (mr *MockContextMockRecorder) ActionParams() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionParams", reflect.TypeOf((*MockContext)(nil).ActionParams))
 }
 
+// AddActionAttachment mocks base method
+func (m *MockContext) AddActionAttachment(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "AddActionAttachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddActionAttachment indicates an expected call of AddActionAttachment
+func (mr *MockContextMockRecorder) AddActionAttachment(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActionAttachment", reflect.TypeOf((*MockContext)(nil).AddActionAttachment), arg0, arg1)
+}
+
 // AddMetric mocks base method
 func (m *MockContext) AddMetric(arg0, arg1 string, arg2 time.Time) error {
 	ret := m.ctrl.Call(m, "AddMetric", arg0, arg1, arg2)
@@ -165,6 +177,19 @@ func (mr *MockContextMockRecorder) ConfigSettings() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSettings", reflect.TypeOf((*MockContext)(nil).ConfigSettings))
 }
 
+// CreateSecret mocks base method
+func (m *MockContext) CreateSecret(arg0 string, arg1 time.Time, arg2 map[string]string) (string, error) {
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecret indicates an expected call of CreateSecret
+func (mr *MockContextMockRecorder) CreateSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockContext)(nil).CreateSecret), arg0, arg1, arg2)
+}
+
 // DeleteCacheValue mocks base method
 func (m *MockContext) DeleteCacheValue(arg0 string) error {
 	ret := m.ctrl.Call(m, "DeleteCacheValue", arg0)
@@ -203,6 +228,19 @@ func (mr *MockContextMockRecorder) GetPodSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodSpec", reflect.TypeOf((*MockContext)(nil).GetPodSpec))
 }
 
+// GetSecret mocks base method
+func (m *MockContext) GetSecret(arg0 string) (map[string]string, error) {
+	ret := m.ctrl.Call(m, "GetSecret", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret
+func (mr *MockContextMockRecorder) GetSecret(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockContext)(nil).GetSecret), arg0)
+}
+
 // GetSingleCacheValue mocks base method
 func (m *MockContext) GetSingleCacheValue(arg0 string) (string, error) {
 	ret := m.ctrl.Call(m, "GetSingleCacheValue", arg0)
@@ -229,6 +267,18 @@ func (mr *MockContextMockRecorder) GoalState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoalState", reflect.TypeOf((*MockContext)(nil).GoalState))
 }
 
+// GrantSecret mocks base method
+func (m *MockContext) GrantSecret(arg0 string, arg1 names_v3.Tag) error {
+	ret := m.ctrl.Call(m, "GrantSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantSecret indicates an expected call of GrantSecret
+func (mr *MockContextMockRecorder) GrantSecret(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantSecret", reflect.TypeOf((*MockContext)(nil).GrantSecret), arg0, arg1)
+}
+
 // HookRelation mocks base method
 func (m *MockContext) HookRelation() (jujuc.ContextRelation, error) {
 	ret := m.ctrl.Call(m, "HookRelation")
@@ -420,6 +470,18 @@ func (mr *MockContextMockRecorder) RequestReboot(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReboot", reflect.TypeOf((*MockContext)(nil).RequestReboot), arg0)
 }
 
+// RevokeSecret mocks base method
+func (m *MockContext) RevokeSecret(arg0 string, arg1 names_v3.Tag) error {
+	ret := m.ctrl.Call(m, "RevokeSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSecret indicates an expected call of RevokeSecret
+func (mr *MockContextMockRecorder) RevokeSecret(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSecret", reflect.TypeOf((*MockContext)(nil).RevokeSecret), arg0, arg1)
+}
+
 // SetActionFailed mocks base method
 func (m *MockContext) SetActionFailed() error {
 	ret := m.ctrl.Call(m, "SetActionFailed")
@@ -580,6 +642,18 @@ func (mr *MockContextMockRecorder) UpdateActionResults(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActionResults", reflect.TypeOf((*MockContext)(nil).UpdateActionResults), arg0, arg1)
 }
 
+// UpdateSecret mocks base method
+func (m *MockContext) UpdateSecret(arg0 string, arg1 *time.Time, arg2 map[string]string) error {
+	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret
+func (mr *MockContextMockRecorder) UpdateSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockContext)(nil).UpdateSecret), arg0, arg1, arg2)
+}
+
 // WriteLeaderSettings mocks base method
 func (m *MockContext) WriteLeaderSettings(arg0 map[string]string) error {
 	ret := m.ctrl.Call(m, "WriteLeaderSettings", arg0)
@@ -184,6 +184,9 @@ func (*RestrictedContext) UpdateActionResults(keys []string, value string) error
 // LogActionMessage implements hooks.Context.
 func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
 
+// AddActionAttachment implements hooks.Context.
+func (*RestrictedContext) AddActionAttachment(string, string) error { return ErrRestrictedContext }
+
 // SetActionMessage implements hooks.Context.
 func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedContext }
 
@@ -204,3 +207,24 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// CreateSecret implements hooks.Context.
+func (*RestrictedContext) CreateSecret(string, time.Time, map[string]string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// UpdateSecret implements hooks.Context.
+func (*RestrictedContext) UpdateSecret(string, *time.Time, map[string]string) error {
+	return ErrRestrictedContext
+}
+
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(string) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements hooks.Context.
+func (*RestrictedContext) GrantSecret(string, names.Tag) error { return ErrRestrictedContext }
+
+// RevokeSecret implements hooks.Context.
+func (*RestrictedContext) RevokeSecret(string, names.Tag) error { return ErrRestrictedContext }
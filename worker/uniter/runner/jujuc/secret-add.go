@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	description string
+	expire      string
+	expireTime  time.Time
+	value       map[string]string
+}
+
+// NewSecretAddCommand returns a command to create a secret.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add creates a secret owned by the unit's application, holding
+the supplied key-value pairs, and prints the ID used to refer to it.
+The secret's value is encrypted by the controller and can only be read
+by the owning application's units, and by any applications or units
+the secret is shared with using secret-grant.
+
+If --expire is given the secret can no longer be read after the given
+time, which is either an RFC3339 timestamp or a duration such as "24h".
+
+Examples:
+
+    secret-add password=s3cret
+    secret-add --description "admin account" username=admin password=s3cret
+    secret-add --expire 24h token=abc123
+
+See also:
+    secret-get
+    secret-grant
+    secret-revoke
+    secret-set
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-add",
+		Args:    "key=value [key=value ...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.description, "description", "", "the secret description")
+	f.StringVar(&c.expire, "expire", "", "either a duration or time when the secret should expire")
+}
+
+// Init implements cmd.Command.
+func (c *secretAddCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing secret value")
+	}
+	if c.expire != "" {
+		expireTime, err := parseSecretExpiry(c.expire, time.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.expireTime = expireTime
+	}
+	var err error
+	c.value, err = keyvalues.Parse(args, true)
+	return errors.Trace(err)
+}
+
+// Run implements cmd.Command.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.CreateSecret(c.description, c.expireTime, c.value)
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	_, err = ctx.Stdout.Write([]byte(id + "\n"))
+	return err
+}
+
+// parseSecretExpiry parses the value of an --expire flag, which is
+// either an RFC3339 timestamp or a duration relative to now.
+func parseSecretExpiry(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.NotValidf("expiry duration %q", value)
+		}
+		return now.Add(d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("expiry %q is not a duration or RFC3339 time", value)
+	}
+	return t.UTC(), nil
+}
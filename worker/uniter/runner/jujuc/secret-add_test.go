@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "missing secret value",
+	}, {
+		args: []string{"password"},
+		err:  `expected "key=value", got "password"`,
+	}, {
+		args: []string{"--expire", "tomorrow", "password=s3cret"},
+		err:  `expiry "tomorrow" is not a duration or RFC3339 time`,
+	}, {
+		args: []string{"--expire", "-1h", "password=s3cret"},
+		err:  `expiry duration "-1h" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, _ := s.ContextSuite.NewHookContext()
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR "+t.err+"\n")
+	}
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.NewSecretID = "9m4e2mr0ui3e8a215n4g"
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--description", "admin account",
+		"--expire", "2020-06-01T00:00:00Z",
+		"username=admin", "password=s3cret",
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "9m4e2mr0ui3e8a215n4g\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+
+	value := map[string]string{"username": "admin", "password": "s3cret"}
+	s.Stub.CheckCall(c, 0, "CreateSecret",
+		"admin account", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), value,
+	)
+	c.Check(info.SecretValues, jc.DeepEquals, map[string]map[string]string{
+		"9m4e2mr0ui3e8a215n4g": value,
+	})
+}
+
+func (s *SecretAddSuite) TestAddSecretExpireDuration(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	before := time.Now()
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--expire", "24h", "password=s3cret",
+	})
+	c.Assert(code, gc.Equals, 0)
+
+	s.Stub.CheckCallNames(c, "CreateSecret")
+	expireTime := s.Stub.Calls()[0].Args[1].(time.Time)
+	c.Check(expireTime.Before(before.Add(24*time.Hour)), jc.IsFalse)
+	c.Check(expireTime.After(time.Now().Add(24*time.Hour)), jc.IsFalse)
+}
+
+func (s *SecretAddSuite) TestAddSecretError(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+	s.Stub.SetErrors(errors.New("boom"))
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"password=s3cret"})
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot add secret: boom\n")
+}
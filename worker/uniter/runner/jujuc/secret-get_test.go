@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValues = map[string]map[string]string{
+		"9m4e2mr0ui3e8a215n4g": {"username": "admin", "password": "s3cret"},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *SecretGetSuite) TestInitErrors(c *gc.C) {
+	ctx, code := s.run(c)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR missing secret id\n")
+
+	ctx, code = s.run(c, "9m4e2mr0ui3e8a215n4g", "password", "extra")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR unrecognized args: ["extra"]`+"\n")
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretGetSuite) TestGetAll(c *gc.C) {
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "password: s3cret\nusername: admin\n")
+	s.Stub.CheckCall(c, 0, "GetSecret", "9m4e2mr0ui3e8a215n4g")
+}
+
+func (s *SecretGetSuite) TestGetKey(c *gc.C) {
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "password")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "s3cret\n")
+}
+
+func (s *SecretGetSuite) TestGetMissingKey(c *gc.C) {
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "token")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *SecretGetSuite) TestGetJSON(c *gc.C) {
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "--format", "json")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `{"password":"s3cret","username":"admin"}`+"\n")
+}
+
+func (s *SecretGetSuite) TestGetNotFound(c *gc.C) {
+	ctx, code := s.run(c, "missing")
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot read secret "missing": secret "missing" not found`+"\n")
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// secretGrantCommand implements the secret-grant command.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id      string
+	subject names.Tag
+}
+
+// NewSecretGrantCommand returns a command to share a secret with an
+// application or unit.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return &secretGrantCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+secret-grant allows an application, or a single unit, to read a secret
+owned by the unit's application with secret-get.
+
+Examples:
+
+    secret-grant 9m4e2mr0ui3e8a215n4g wordpress
+    secret-grant 9m4e2mr0ui3e8a215n4g wordpress/0
+
+See also:
+    secret-add
+    secret-revoke
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<id> <application or unit>",
+		Purpose: "grant access to a secret",
+		Doc:     doc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *secretGrantCommand) Init(args []string) (err error) {
+	c.id, c.subject, err = parseSecretAccessArgs(args)
+	return err
+}
+
+// Run implements cmd.Command.
+func (c *secretGrantCommand) Run(ctx *cmd.Context) error {
+	err := c.ctx.GrantSecret(c.id, c.subject)
+	return errors.Annotatef(err, "cannot grant access to secret %q", c.id)
+}
+
+// parseSecretAccessArgs parses the arguments of secret-grant and
+// secret-revoke.
+func parseSecretAccessArgs(args []string) (string, names.Tag, error) {
+	if len(args) == 0 {
+		return "", nil, errors.New("missing secret id")
+	}
+	if len(args) == 1 {
+		return "", nil, errors.New("missing application or unit")
+	}
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return "", nil, err
+	}
+	var subject names.Tag
+	switch {
+	case names.IsValidUnit(args[1]):
+		subject = names.NewUnitTag(args[1])
+	case names.IsValidApplication(args[1]):
+		subject = names.NewApplicationTag(args[1])
+	default:
+		return "", nil, errors.NotValidf("application or unit %q", args[1])
+	}
+	return args[0], subject, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "missing secret id",
+	}, {
+		args: []string{"9m4e2mr0ui3e8a215n4g"},
+		err:  "missing application or unit",
+	}, {
+		args: []string{"9m4e2mr0ui3e8a215n4g", "wordpress/x"},
+		err:  `application or unit "wordpress/x" not valid`,
+	}, {
+		args: []string{"9m4e2mr0ui3e8a215n4g", "wordpress", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		ctx, code := s.run(c, t.args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Matches, "ERROR "+t.err+"\n")
+	}
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretGrantSuite) TestGrantApplication(c *gc.C) {
+	_, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "wordpress")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "GrantSecret", "9m4e2mr0ui3e8a215n4g", names.NewApplicationTag("wordpress"))
+}
+
+func (s *SecretGrantSuite) TestGrantUnit(c *gc.C) {
+	_, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "wordpress/0")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "GrantSecret", "9m4e2mr0ui3e8a215n4g", names.NewUnitTag("wordpress/0"))
+}
+
+func (s *SecretGrantSuite) TestGrantError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "wordpress")
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot grant access to secret "9m4e2mr0ui3e8a215n4g": boom`+"\n")
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// secretRevokeCommand implements the secret-revoke command.
+type secretRevokeCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id      string
+	subject names.Tag
+}
+
+// NewSecretRevokeCommand returns a command to stop sharing a secret
+// with an application or unit.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return &secretRevokeCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretRevokeCommand) Info() *cmd.Info {
+	doc := `
+secret-revoke stops an application, or a single unit, that was granted
+access to a secret with secret-grant from reading it. Revoking access
+from an application does not affect units granted access individually.
+
+Examples:
+
+    secret-revoke 9m4e2mr0ui3e8a215n4g wordpress
+    secret-revoke 9m4e2mr0ui3e8a215n4g wordpress/0
+
+See also:
+    secret-grant
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<id> <application or unit>",
+		Purpose: "revoke access to a secret",
+		Doc:     doc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *secretRevokeCommand) Init(args []string) (err error) {
+	c.id, c.subject, err = parseSecretAccessArgs(args)
+	return err
+}
+
+// Run implements cmd.Command.
+func (c *secretRevokeCommand) Run(ctx *cmd.Context) error {
+	err := c.ctx.RevokeSecret(c.id, c.subject)
+	return errors.Annotatef(err, "cannot revoke access to secret %q", c.id)
+}
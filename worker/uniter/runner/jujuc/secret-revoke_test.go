@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretRevokeSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretRevokeSuite{})
+
+func (s *SecretRevokeSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *SecretRevokeSuite) TestInitError(c *gc.C) {
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR missing application or unit\n")
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretRevokeSuite) TestRevoke(c *gc.C) {
+	_, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "wordpress/0")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "RevokeSecret", "9m4e2mr0ui3e8a215n4g", names.NewUnitTag("wordpress/0"))
+}
+
+func (s *SecretRevokeSuite) TestRevokeError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "wordpress")
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot revoke access to secret "9m4e2mr0ui3e8a215n4g": boom`+"\n")
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// secretSetCommand implements the secret-set command.
+type secretSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id         string
+	expire     string
+	expireTime *time.Time
+	value      map[string]string
+}
+
+// NewSecretSetCommand returns a command to update a secret.
+func NewSecretSetCommand(ctx Context) (cmd.Command, error) {
+	return &secretSetCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretSetCommand) Info() *cmd.Info {
+	doc := `
+secret-set updates a secret owned by the unit's application. If any
+key-value pairs are given they replace the secret's current value, and
+the secret's revision is incremented.
+
+--expire sets when the secret expires, either as an RFC3339 timestamp
+or as a duration such as "24h". Use --expire never to stop the secret
+from expiring.
+
+Examples:
+
+    secret-set 9m4e2mr0ui3e8a215n4g password=n3w
+    secret-set 9m4e2mr0ui3e8a215n4g --expire never
+
+See also:
+    secret-add
+    secret-get
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-set",
+		Args:    "<id> [key=value ...]",
+		Purpose: "update an existing secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.expire, "expire", "", `either a duration or time when the secret should expire, or "never"`)
+}
+
+// Init implements cmd.Command.
+func (c *secretSetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing secret id")
+	}
+	c.id = args[0]
+	if len(args) == 1 && c.expire == "" {
+		return errors.New("missing secret value or expiry")
+	}
+	if c.expire == "never" {
+		c.expireTime = &time.Time{}
+	} else if c.expire != "" {
+		expireTime, err := parseSecretExpiry(c.expire, time.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.expireTime = &expireTime
+	}
+	if len(args) > 1 {
+		var err error
+		if c.value, err = keyvalues.Parse(args[1:], true); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *secretSetCommand) Run(ctx *cmd.Context) error {
+	err := c.ctx.UpdateSecret(c.id, c.expireTime, c.value)
+	return errors.Annotatef(err, "cannot update secret %q", c.id)
+}
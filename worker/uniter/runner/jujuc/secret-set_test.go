@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretSetSuite{})
+
+func (s *SecretSetSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValues = map[string]map[string]string{
+		"9m4e2mr0ui3e8a215n4g": {"password": "s3cret"},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *SecretSetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "missing secret id",
+	}, {
+		args: []string{"9m4e2mr0ui3e8a215n4g"},
+		err:  "missing secret value or expiry",
+	}, {
+		args: []string{"9m4e2mr0ui3e8a215n4g", "password"},
+		err:  `expected "key=value", got "password"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		ctx, code := s.run(c, t.args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR "+t.err+"\n")
+	}
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretSetSuite) TestSetValue(c *gc.C) {
+	_, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "password=n3w")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "UpdateSecret",
+		"9m4e2mr0ui3e8a215n4g", (*time.Time)(nil), map[string]string{"password": "n3w"},
+	)
+}
+
+func (s *SecretSetSuite) TestSetExpireNever(c *gc.C) {
+	_, code := s.run(c, "9m4e2mr0ui3e8a215n4g", "--expire", "never")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "UpdateSecret",
+		"9m4e2mr0ui3e8a215n4g", &time.Time{}, map[string]string(nil),
+	)
+}
+
+func (s *SecretSetSuite) TestSetNotFound(c *gc.C) {
+	ctx, code := s.run(c, "missing", "password=n3w")
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot update secret "missing": secret "missing" not found`+"\n")
+}
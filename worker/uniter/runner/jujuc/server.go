@@ -70,10 +70,11 @@ var baseCommands = map[string]creator{
 	"goal-state" + cmdSuffix:     NewGoalStateCommand,
 	"credential-get" + cmdSuffix: NewCredentialGetCommand,
 
-	"action-get" + cmdSuffix:  NewActionGetCommand,
-	"action-set" + cmdSuffix:  NewActionSetCommand,
-	"action-fail" + cmdSuffix: NewActionFailCommand,
-	"action-log" + cmdSuffix:  NewActionLogCommand,
+	"action-get" + cmdSuffix:    NewActionGetCommand,
+	"action-set" + cmdSuffix:    NewActionSetCommand,
+	"action-fail" + cmdSuffix:   NewActionFailCommand,
+	"action-log" + cmdSuffix:    NewActionLogCommand,
+	"action-attach" + cmdSuffix: NewActionAttachCommand,
 
 	"state-get" + cmdSuffix:    NewStateGetCommand,
 	"state-delete" + cmdSuffix: NewStateDeleteCommand,
@@ -100,6 +101,14 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-grant" + cmdSuffix:  NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
+	"secret-set" + cmdSuffix:    NewSecretSetCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -110,6 +119,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
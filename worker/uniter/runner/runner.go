@@ -73,6 +73,30 @@ const (
 	hookDispatcherScript = "dispatch"
 )
 
+// hookKillDelay is how long a hook which has timed out is given to exit
+// after being sent SIGTERM before all the processes in its process group
+// are killed.
+var hookKillDelay = 10 * time.Second
+
+// HookTimeoutError is returned when a hook is killed because it ran for
+// longer than the timeout of its kind.
+type HookTimeoutError struct {
+	Hook    string
+	Timeout time.Duration
+}
+
+// Error is part of the error interface.
+func (e *HookTimeoutError) Error() string {
+	return fmt.Sprintf("hook %q timed out after %v", e.Hook, e.Timeout)
+}
+
+// IsHookTimeoutError returns true if the cause of the error is a
+// *HookTimeoutError.
+func IsHookTimeoutError(err error) bool {
+	_, ok := errors.Cause(err).(*HookTimeoutError)
+	return ok
+}
+
 // Runner is responsible for invoking commands in a context.
 type Runner interface {
 
@@ -89,6 +113,21 @@ type Runner interface {
 
 	// RunCommands executes the supplied script.
 	RunCommands(commands string) (*utilexec.ExecResponse, error)
+
+	// ExecutionInfo returns the exit code of, and the hook tools called
+	// by, the last hook or action run.
+	ExecutionInfo() ExecutionInfo
+}
+
+// ExecutionInfo holds the details of the last hook or action run by a Runner.
+type ExecutionInfo struct {
+	// ExitCode is the exit code of the hook or action process, or -1
+	// if it is not known.
+	ExitCode int
+
+	// ToolsCalled holds the names of the hook tools called by the
+	// process, in the order they were called.
+	ToolsCalled []string
 }
 
 // Context exposes hooks.Context, and additional methods needed by Runner.
@@ -101,6 +140,8 @@ type Context interface {
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 	ModelType() model.ModelType
+	HookTimeout() time.Duration
+	Clock() context.Clock
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -108,7 +149,11 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths, remoteExecutor ExecFunc) Runner {
-	return &runner{context, paths, remoteExecutor}
+	return &runner{
+		context:        context,
+		paths:          paths,
+		remoteExecutor: remoteExecutor,
+	}
 }
 
 // ExecParams holds all the necessary parameters for ExecFunc.
@@ -120,6 +165,13 @@ type ExecParams struct {
 	ProcessSetter func(context.HookProcess)
 	Cancel        <-chan struct{}
 
+	// RunAsJob is true if the commands should be run to completion in
+	// a Kubernetes Job rather than in the workload pod.
+	RunAsJob bool
+
+	// ActionID identifies the action the commands are run for, if any.
+	ActionID string
+
 	Stdout       io.ReadWriter
 	StdoutLogger charmrunner.Stopper
 
@@ -154,6 +206,9 @@ type runner struct {
 	paths   context.Paths
 	// remoteExecutor executes commands on a remote workload pod for CAAS.
 	remoteExecutor ExecFunc
+
+	mu        sync.Mutex
+	execution ExecutionInfo
 }
 
 func (runner *runner) Context() Context {
@@ -336,6 +391,10 @@ func (runner *runner) RunHook(hookName string) (HookHandlerType, error) {
 }
 
 func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, rMode runMode) (hookHandlerType HookHandlerType, err error) {
+	runner.mu.Lock()
+	runner.execution = ExecutionInfo{ExitCode: -1}
+	runner.mu.Unlock()
+
 	token := ""
 	if rMode == runOnRemote {
 		token, err = utils.RandomPassword()
@@ -428,8 +487,37 @@ func (b *bufferAdaptor) Messagef(isPrefix bool, message string, args ...interfac
 	b.outCopy.WriteString(formattedMessage)
 }
 
+// actionLogAdaptor implements MessageReceiver and
+// records messages in the log of the running action.
+type actionLogAdaptor struct {
+	context Context
+}
+
+func (a *actionLogAdaptor) Messagef(isPrefix bool, message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	if err := a.context.LogActionMessage(message); err != nil {
+		logger.Warningf("cannot record action log message: %v", err)
+	}
+}
+
 func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string) error {
 	var cancel <-chan struct{}
+	actionData, err := runner.context.ActionData()
+	runningAction := err == nil && actionData != nil
+
+	// Actions declared to run as a job are run in a Kubernetes Job,
+	// and the output of the Job is recorded in the action log as well.
+	var (
+		runAsJob bool
+		actionID string
+	)
+	if runningAction {
+		runAsJob = actionData.RunAsJob
+		actionID = actionData.Tag.Id()
+	}
+
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make stdout logging pipe: %v", err)
@@ -437,10 +525,14 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	defer func() { _ = outWriter.Close() }()
 
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
-	hookOutLogger := charmrunner.NewHookLogger(outReader,
+	receivers := []charmrunner.MessageReceiver{
 		&loggerAdaptor{runner.getLogger(hookName)},
 		actionOut,
-	)
+	}
+	if runAsJob {
+		receivers = append(receivers, &actionLogAdaptor{runner.context})
+	}
+	hookOutLogger := charmrunner.NewHookLogger(outReader, receivers...)
 	defer hookOutLogger.Stop()
 	go hookOutLogger.Run()
 
@@ -448,8 +540,6 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	// separately to pass back.
 	var actionErr = actionOut
 	var hookErrLogger *charmrunner.HookLogger
-	actionData, err := runner.context.ActionData()
-	runningAction := err == nil && actionData != nil
 	if runningAction {
 		cancel = actionData.Cancel
 
@@ -482,9 +572,17 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 			StdoutLogger: hookOutLogger,
 			Stderr:       actionErr,
 			StderrLogger: hookErrLogger,
+			RunAsJob:     runAsJob,
+			ActionID:     actionID,
 		},
 	)
 
+	code := exitCode(err)
+	if resp != nil {
+		code = resp.Code
+	}
+	runner.setExitCode(code)
+
 	// If we are running an action, record stdout and stderr.
 	if runningAction && resp != nil {
 		if err := runner.updateActionResults(resp); err != nil {
@@ -543,6 +641,18 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		go hookErrLogger.Run()
 	}
 
+	// Hooks, but not actions, are killed if they run for
+	// longer than the timeout of their kind.
+	var timeout time.Duration
+	if !runningAction {
+		timeout = runner.context.HookTimeout()
+	}
+	if timeout > 0 {
+		// Run the hook in its own process group so that any
+		// processes it starts are also killed if it times out.
+		setProcessGroup(ps)
+	}
+
 	err = ps.Start()
 	var exitErr error
 	if err == nil {
@@ -556,11 +666,44 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 				}
 			}()
 		}
+		timedOut := make(chan struct{})
+		if timeout > 0 {
+			clock := runner.context.Clock()
+			go func() {
+				select {
+				case <-clock.After(timeout):
+				case <-done:
+					return
+				}
+				close(timedOut)
+				logger.Warningf("hook %q timed out after %v, terminating it", hookName, timeout)
+				if err := killProcessGroup(ps.Process, false); err != nil {
+					logger.Warningf("cannot terminate hook %q: %v", hookName, err)
+				}
+				// The hook is killed if it's still running after the
+				// delay. Once the hook has exited its process group ID
+				// may be reused, so the group isn't signalled again.
+				select {
+				case <-clock.After(hookKillDelay):
+				case <-done:
+					return
+				}
+				if err := killProcessGroup(ps.Process, true); err != nil {
+					logger.Warningf("cannot kill hook %q: %v", hookName, err)
+				}
+			}()
+		}
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
 		exitErr = ps.Wait()
 		close(done)
+		runner.setExitCode(exitCode(exitErr))
+		select {
+		case <-timedOut:
+			exitErr = &HookTimeoutError{Hook: hookName, Timeout: timeout}
+		default:
+		}
 	} else {
 		exitErr = err
 	}
@@ -577,17 +720,6 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 			_, _ = o.ReadFrom(r)
 			return o.Bytes()
 		}
-		exitCode := func(exitErr error) int {
-			if exitErr != nil {
-				if exitErr, ok := exitErr.(*exec.ExitError); ok {
-					if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
-						return status.ExitStatus()
-					}
-				}
-				return -1
-			}
-			return 0
-		}
 		resp := &utilexec.ExecResponse{
 			// TODO(wallyworld) - use ExitCode() when we support Go 1.12
 			// Code:   ps.ProcessState.ExitCode(),
@@ -603,6 +735,38 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	return errors.Trace(exitErr)
 }
 
+// ExecutionInfo exists to satisfy the Runner interface.
+func (runner *runner) ExecutionInfo() ExecutionInfo {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	info := runner.execution
+	info.ToolsCalled = append([]string(nil), info.ToolsCalled...)
+	return info
+}
+
+func (runner *runner) setExitCode(code int) {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	runner.execution.ExitCode = code
+}
+
+// exitCode returns the exit code of the process which returned the
+// supplied error, or -1 if it did not exit normally.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	switch err := errors.Cause(err).(type) {
+	case *exec.ExitError:
+		if status, ok := err.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	case interface{ ExitStatus() int }:
+		return err.ExitStatus()
+	}
+	return -1
+}
+
 // discoverHookHandler checks to see if the dispatch script exists, if not,
 // check for the given hookName.  Based on what is discovered, return the
 // HookHandlerType and the actual script to be run.
@@ -626,6 +790,9 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 		if ctxId != runner.context.Id() {
 			return nil, errors.Errorf("expected context id %q, got %q", runner.context.Id(), ctxId)
 		}
+		runner.mu.Lock()
+		runner.execution.ToolsCalled = append(runner.execution.ToolsCalled, cmdName)
+		runner.mu.Unlock()
 		return jujuc.NewCommand(runner.context, cmdName)
 	}
 
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/proxy"
 	envtesting "github.com/juju/testing"
@@ -19,8 +20,10 @@ import (
 	"github.com/juju/utils/exec"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6/hooks"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -186,6 +189,7 @@ type MockContext struct {
 	runner.Context
 	actionData      *context.ActionData
 	actionDataErr   error
+	actionLog       []string
 	actionParams    map[string]interface{}
 	actionParamsErr error
 	actionResults   map[string]interface{}
@@ -193,6 +197,8 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
+	clock           context.Clock
 	modelType       model.ModelType
 }
 
@@ -236,6 +242,11 @@ func (ctx *MockContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+func (ctx *MockContext) LogActionMessage(message string) error {
+	ctx.actionLog = append(ctx.actionLog, message)
+	return nil
+}
+
 func (ctx *MockContext) ModelType() model.ModelType {
 	if ctx.modelType == "" {
 		return model.IAAS
@@ -243,6 +254,14 @@ func (ctx *MockContext) ModelType() model.ModelType {
 	return ctx.modelType
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+func (ctx *MockContext) Clock() context.Clock {
+	return ctx.clock
+}
+
 type RunMockContextSuite struct {
 	envtesting.IsolationSuite
 	paths runnertesting.RealPaths
@@ -273,10 +292,12 @@ func (s *RunMockContextSuite) TestRunHookFlushSuccess(c *gc.C) {
 		name: hookName,
 		perm: 0700,
 	}, s.paths.GetCharmDir())
-	_, actualErr := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	rnr := runner.NewRunner(ctx, s.paths, nil)
+	_, actualErr := rnr.RunHook("something-happened")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(rnr.ExecutionInfo(), jc.DeepEquals, runner.ExecutionInfo{ExitCode: 0})
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
@@ -291,10 +312,46 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 		perm: 0700,
 		code: 123,
 	}, s.paths.GetCharmDir())
-	_, actualErr := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	rnr := runner.NewRunner(ctx, s.paths, nil)
+	_, actualErr := rnr.RunHook("something-happened")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+	c.Assert(rnr.ExecutionInfo().ExitCode, gc.Equals, 123)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	expectErr := errors.New("pew pew pew")
+	clk := testclock.NewClock(time.Now())
+	ctx := &MockContext{
+		flushResult: expectErr,
+		hookTimeout: 100 * time.Millisecond,
+		clock:       clk,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+		errChan <- err
+	}()
+	err := clk.WaitAdvance(100*time.Millisecond, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	var actualErr error
+	select {
+	case actualErr = <-errChan:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for hook to be terminated")
+	}
+	c.Assert(actualErr, gc.Equals, expectErr)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 100ms`)
+	c.Assert(runner.IsHookTimeoutError(ctx.flushFailure), jc.IsTrue)
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
@@ -538,6 +595,44 @@ func (s *RunMockContextSuite) TestRunActionCAASSuccess(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionAsJobCAASSuccess(c *gc.C) {
+	params := map[string]interface{}{}
+	ctx := &MockContext{
+		modelType: model.CAAS,
+		actionData: &context.ActionData{
+			Tag:      names.NewActionTag("42"),
+			Params:   params,
+			RunAsJob: true,
+		},
+		actionParams:  params,
+		actionResults: map[string]interface{}{},
+	}
+	makeCharm(c, hookSpec{
+		dir:  "actions",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		c.Assert(params.RunAsJob, jc.IsTrue)
+		c.Assert(params.ActionID, gc.Equals, "42")
+		_, err := params.Stdout.Write([]byte("migrating\ndone\n"))
+		c.Assert(err, jc.ErrorIsNil)
+		params.StdoutLogger.Stop()
+		return &exec.ExecResponse{
+			Code:   2,
+			Stdout: bytes.NewBufferString("migrating\ndone\n").Bytes(),
+		}, nil
+	}
+	_, err := runner.NewRunner(ctx, s.paths, execFunc).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.actionLog, jc.DeepEquals, []string{"migrating", "done"})
+	c.Assert(ctx.actionResults, jc.DeepEquals, map[string]interface{}{
+		"Code": "2", "Stdout": "migrating\ndone\n",
+	})
+}
+
 func (s *RunMockContextSuite) TestRunActionOnWorkloadIgnoredIAAS(c *gc.C) {
 	params := map[string]interface{}{
 		"command":          "echo 1",
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
@@ -27,6 +28,7 @@ import (
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/userhooks"
 	"github.com/juju/juju/core/watcher"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/fortress"
@@ -137,6 +139,26 @@ type Uniter struct {
 	// rebootQuerier allows the uniter to detect when the machine has
 	// rebooted so we can notify the charms accordingly.
 	rebootQuerier RebootQuerier
+
+	// hookMetrics, if not nil, records the hooks run by the uniter.
+	hookMetrics *HookMetrics
+
+	// hookHistory records the hooks and actions run by the uniter.
+	hookHistory *HookHistory
+
+	// postFirstHookPath is where cloud-init writes the user hook to
+	// run after the first charm hook on the machine, if any.
+	postFirstHookPath string
+
+	// hookTimeout records the timeout of the last hook to fail, if it
+	// failed because it timed out.
+	hookTimeout hookTimeout
+}
+
+// hookTimeout holds the timeout after which a hook was killed.
+type hookTimeout struct {
+	info    hook.Info
+	timeout time.Duration
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -165,6 +187,11 @@ type UniterParams struct {
 	// that write to files, and have the tests watch the output to know that hooks have finished.
 	Observer      UniterExecutionObserver
 	RebootQuerier RebootQuerier
+	// HookMetrics, if not nil, records the hooks run by the uniter.
+	HookMetrics *HookMetrics
+	// HookHistory, if not nil, records the hooks and actions run by
+	// the uniter. Otherwise the uniter keeps its own history.
+	HookHistory *HookHistory
 }
 
 // NewOperationExecutorFunc is a func which returns an operations.Executor.
@@ -203,6 +230,10 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 	if translateResolverErr == nil {
 		translateResolverErr = func(err error) error { return err }
 	}
+	hookHistory := uniterParams.HookHistory
+	if hookHistory == nil {
+		hookHistory = NewHookHistory(DefaultHookHistorySize)
+	}
 	u := &Uniter{
 		st:                      uniterParams.UniterFacade,
 		paths:                   NewPaths(uniterParams.DataDir, uniterParams.UnitTag, uniterParams.SocketConfig),
@@ -223,6 +254,11 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		runningStatusFunc:       uniterParams.RunningStatusFunc,
 		runListener:             uniterParams.RunListener,
 		rebootQuerier:           uniterParams.RebootQuerier,
+		hookMetrics:             uniterParams.HookMetrics,
+		hookHistory:             hookHistory,
+	}
+	if u.modelType == model.IAAS {
+		u.postFirstHookPath = userhooks.Path(uniterParams.DataDir, userhooks.PostFirstHook)
 	}
 	startFunc := func() (worker.Worker, error) {
 		plan := catacomb.Plan{
@@ -641,13 +677,19 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	u.operationFactory = operation.NewFactory(operation.FactoryParams{
-		Deployer:       deployer,
-		RunnerFactory:  runnerFactory,
-		Callbacks:      &operationCallbacks{u},
-		Abort:          u.catacomb.Dying(),
-		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
-	})
+	factoryParams := operation.FactoryParams{
+		Deployer:          deployer,
+		RunnerFactory:     runnerFactory,
+		Callbacks:         &operationCallbacks{u},
+		Abort:             u.catacomb.Dying(),
+		MetricSpoolDir:    u.paths.GetMetricsSpoolDir(),
+		ExecutionRecorder: u.hookHistory.unitRecorder(u.unit.Name()),
+		Clock:             u.clock,
+	}
+	if u.hookMetrics != nil {
+		factoryParams.HookRecorder = u.hookMetrics.unitRecorder(u.unit.Name())
+	}
+	u.operationFactory = operation.NewFactory(factoryParams)
 
 	charmURL, err := u.getApplicationCharmURL()
 	if err != nil {
@@ -674,7 +716,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	}
 
 	operationExecutor, err := u.newOperationExecutor(operation.ExecutorConfig{
-		StateReadWriter: u.unit,
+		StateReadWriter: u.hookHistory.unitStateReadWriter(u.unit.Name(), u.unit, u.st.BestAPIVersion()),
 		InitialState:    initialState,
 		AcquireLock:     u.acquireExecutionLock,
 	})
@@ -771,5 +813,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.hookTimeout.info == hookInfo && u.hookTimeout.timeout > 0 {
+		statusData["timeout"] = u.hookTimeout.timeout.String()
+		statusMessage += fmt.Sprintf(" (timed out after %v)", u.hookTimeout.timeout)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+// postFirstHookTimeout is how long the post-first-hook user hook may
+// run before it is killed.
+var postFirstHookTimeout = 10 * time.Minute
+
+// startPostFirstHook runs the post-first-hook user hook in the
+// background once the first charm hook has been committed, so that a
+// slow or hung script neither holds up the commit nor blocks the unit.
+// A failing hook is only logged, as it will not be run again.
+func (u *Uniter) startPostFirstHook(after hooks.Kind) {
+	hookPath := u.postFirstHookPath
+	// Whether or not this unit gets to run the hook, there's no need
+	// to try again after later hooks.
+	u.postFirstHookPath = ""
+	abort := u.catacomb.Dying()
+	go func() {
+		if ran, err := runPostFirstHook(abort, hookPath, postFirstHookTimeout); err != nil {
+			logger.Errorf("%v", err)
+		} else if ran {
+			logger.Infof("ran post-first-hook user hook after %s hook", after)
+		}
+	}()
+}
+
+// runPostFirstHook runs the post-first-hook user hook which cloud-init
+// wrote to hookPath from the model's cloudinit-user-hooks config. The
+// hook is claimed by renaming it before it is run, so that it is run
+// exactly once on a machine no matter how many units are deployed to
+// it; it reports whether this call ran the hook. The hook is killed if
+// it runs for longer than timeout, or when abort is closed.
+func runPostFirstHook(abort <-chan struct{}, hookPath string, timeout time.Duration) (bool, error) {
+	claimed := hookPath + ".running"
+	if err := os.Rename(hookPath, claimed); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotate(err, "claiming post-first-hook user hook")
+	}
+	runErr := runUserHook(abort, claimed, hookPath+".log", timeout)
+	if err := os.Rename(claimed, hookPath+".done"); err != nil {
+		logger.Warningf("cannot mark post-first-hook user hook as done: %v", err)
+	}
+	if runErr != nil {
+		return true, errors.Annotate(runErr, "running post-first-hook user hook")
+	}
+	return true, nil
+}
+
+// runUserHook runs the script at scriptPath, writing its output to
+// logPath. The output goes to a file rather than a pipe so that a
+// background process started by the script can't keep the wait going
+// after the script has been killed.
+func runUserHook(abort <-chan struct{}, scriptPath, logPath string, timeout time.Duration) error {
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return errors.Annotate(err, "creating user hook log")
+	}
+	defer logFile.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	cmd := exec.CommandContext(ctx, scriptPath)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	runErr := cmd.Run()
+	if output, err := ioutil.ReadFile(logPath); err == nil && len(output) > 0 {
+		logger.Infof("post-first-hook user hook output:\n%s", strings.TrimRight(string(output), "\n"))
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return errors.Errorf("timed out after %v", timeout)
+	case context.Canceled:
+		return errors.New("aborted")
+	}
+	return errors.Trace(runErr)
+}
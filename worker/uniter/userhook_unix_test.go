@@ -0,0 +1,82 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package uniter_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+)
+
+type userHookSuite struct{}
+
+var _ = gc.Suite(&userHookSuite{})
+
+func (s *userHookSuite) writeHook(c *gc.C, script string) string {
+	hookPath := filepath.Join(c.MkDir(), "post-first-hook")
+	err := ioutil.WriteFile(hookPath, []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	return hookPath
+}
+
+func (s *userHookSuite) TestRunPostFirstHook(c *gc.C) {
+	marker := filepath.Join(c.MkDir(), "marker")
+	hookPath := s.writeHook(c, "#!/bin/sh\necho started\ntouch "+marker+"\n")
+
+	ran, err := uniter.RunPostFirstHook(nil, hookPath, coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.IsTrue)
+	c.Assert(marker, jc.IsNonEmptyFile)
+	c.Assert(hookPath, jc.DoesNotExist)
+	c.Assert(hookPath+".done", jc.IsNonEmptyFile)
+	c.Assert(hookPath+".log", jc.IsNonEmptyFile)
+
+	// The hook is only ever run once.
+	ran, err = uniter.RunPostFirstHook(nil, hookPath, coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.IsFalse)
+}
+
+func (s *userHookSuite) TestRunPostFirstHookMissing(c *gc.C) {
+	ran, err := uniter.RunPostFirstHook(nil, filepath.Join(c.MkDir(), "post-first-hook"), coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.IsFalse)
+}
+
+func (s *userHookSuite) TestRunPostFirstHookFails(c *gc.C) {
+	hookPath := s.writeHook(c, "#!/bin/sh\nexit 3\n")
+
+	ran, err := uniter.RunPostFirstHook(nil, hookPath, coretesting.LongWait)
+	c.Assert(err, gc.ErrorMatches, "running post-first-hook user hook: exit status 3")
+	c.Assert(ran, jc.IsTrue)
+	c.Assert(hookPath, jc.DoesNotExist)
+	c.Assert(hookPath+".done", jc.IsNonEmptyFile)
+}
+
+func (s *userHookSuite) TestRunPostFirstHookTimeout(c *gc.C) {
+	hookPath := s.writeHook(c, "#!/bin/sh\nsleep 60\n")
+
+	ran, err := uniter.RunPostFirstHook(nil, hookPath, 100*time.Millisecond)
+	c.Assert(err, gc.ErrorMatches, "running post-first-hook user hook: timed out after 100ms")
+	c.Assert(ran, jc.IsTrue)
+	c.Assert(hookPath+".done", jc.IsNonEmptyFile)
+}
+
+func (s *userHookSuite) TestRunPostFirstHookAborted(c *gc.C) {
+	hookPath := s.writeHook(c, "#!/bin/sh\nsleep 60\n")
+	abort := make(chan struct{})
+	close(abort)
+
+	ran, err := uniter.RunPostFirstHook(abort, hookPath, coretesting.LongWait)
+	c.Assert(err, gc.ErrorMatches, "running post-first-hook user hook: aborted")
+	c.Assert(ran, jc.IsTrue)
+}